@@ -1,6 +1,8 @@
 // Package handlers contains HTTP handler functions for the example API.
 package handlers
 
+//go:generate go run github.com/gork-labs/gork/cmd/gork handlers generate .
+
 import "context"
 
 // LoginRequest represents the request body for the login endpoint.
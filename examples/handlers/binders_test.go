@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gork-labs/gork/pkg/api"
+)
+
+type pathAdapter struct {
+	api.HTTPParameterAdapter
+	path map[string]string
+}
+
+func (a pathAdapter) Path(_ *http.Request, key string) (string, bool) {
+	v, ok := a.path[key]
+	return v, ok
+}
+
+// TestGeneratedBindersMatchParser checks that the binders in
+// handlers_gen.go bind requests as the reflective parser does.
+func TestGeneratedBindersMatchParser(t *testing.T) {
+	adapter := pathAdapter{path: map[string]string{"user_id": "u1", "userId": "u2"}}
+	reflective := api.NewConventionParser()
+	generated := api.NewConventionParser(api.PreferGeneratedBinders())
+
+	for _, reqType := range []reflect.Type{
+		reflect.TypeOf(GetUserConventionRequest{}),
+		reflect.TypeOf(UpdateUserConventionRequest{}),
+		reflect.TypeOf(DeleteUserRequest{}),
+	} {
+		if !reflect.PointerTo(reqType).Implements(reflect.TypeOf((*api.RequestBinder)(nil)).Elem()) {
+			t.Fatalf("%s has no generated binder", reqType)
+		}
+		for _, query := range []string{"", "include_profile=true&fields=a,%20b", "fields=a&fields=b", "force=yes", "force=1&notify=false"} {
+			newRequest := func() *http.Request {
+				return httptest.NewRequest(http.MethodPut, "/users?"+query, strings.NewReader(`{"name":"Jane"}`))
+			}
+			want, got := reflect.New(reqType), reflect.New(reqType)
+			wantErr := reflective.ParseRequest(context.Background(), newRequest(), want, adapter)
+			gotErr := generated.ParseRequest(context.Background(), newRequest(), got, adapter)
+			if (wantErr == nil) != (gotErr == nil) || (wantErr != nil && wantErr.Error() != gotErr.Error()) {
+				t.Errorf("%s %q: expected error %v, got %v", reqType, query, wantErr, gotErr)
+			}
+			if !reflect.DeepEqual(want.Interface(), got.Interface()) {
+				t.Errorf("%s %q: expected %+v, got %+v", reqType, query, want.Elem(), got.Elem())
+			}
+		}
+	}
+}
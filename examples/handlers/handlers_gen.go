@@ -0,0 +1,287 @@
+// Code generated by gork handlers generate. DO NOT EDIT.
+
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gork-labs/gork/pkg/api"
+)
+
+// BindRequest binds CreateUserConventionRequest without reflection. It implements
+// api.RequestBinder, used by routes registered with api.WithGeneratedBinders.
+func (req *CreateUserConventionRequest) BindRequest(r *http.Request, params api.GenericParameterAdapter[*http.Request], bindBody func(body any) error) error {
+	{
+		v, ok := params.Header(r, "Authorization")
+		if ok {
+			req.Headers.Authorization = v
+		}
+	}
+	{
+		v, ok := params.Header(r, "Content-Type")
+		if ok {
+			req.Headers.ContentType = v
+		}
+	}
+	if err := bindBody(&req.Body); err != nil {
+		return fmt.Errorf("failed to parse Body section: %w", err)
+	}
+	return nil
+}
+
+// BindRequest binds CreateUserRequest without reflection. It implements
+// api.RequestBinder, used by routes registered with api.WithGeneratedBinders.
+func (req *CreateUserRequest) BindRequest(r *http.Request, params api.GenericParameterAdapter[*http.Request], bindBody func(body any) error) error {
+	{
+		v, ok := params.Query(r, "return-to")
+		if ok {
+			req.Query.ReturnTo = v
+		}
+	}
+	if err := bindBody(&req.Body); err != nil {
+		return fmt.Errorf("failed to parse Body section: %w", err)
+	}
+	return nil
+}
+
+// BindRequest binds DeleteUserRequest without reflection. It implements
+// api.RequestBinder, used by routes registered with api.WithGeneratedBinders.
+func (req *DeleteUserRequest) BindRequest(r *http.Request, params api.GenericParameterAdapter[*http.Request], bindBody func(body any) error) error {
+	{
+		v, ok := params.Path(r, "userId")
+		if ok {
+			req.Path.UserID = v
+		}
+	}
+	{
+		v, ok := params.Query(r, "force")
+		if ok {
+			x, err := strconv.ParseBool(v)
+			if err != nil {
+				return errors.New("failed to parse Query section: failed to set query parameter force: invalid boolean value: " + v)
+			}
+			req.Query.Force = x
+		}
+	}
+	if err := bindBody(&req.Body); err != nil {
+		return fmt.Errorf("failed to parse Body section: %w", err)
+	}
+	return nil
+}
+
+// BindRequest binds GetUserConventionRequest without reflection. It implements
+// api.RequestBinder, used by routes registered with api.WithGeneratedBinders.
+func (req *GetUserConventionRequest) BindRequest(r *http.Request, params api.GenericParameterAdapter[*http.Request], bindBody func(body any) error) error {
+	{
+		v, ok := params.Path(r, "user_id")
+		if ok {
+			req.Path.UserID = v
+		}
+	}
+	{
+		v, ok := params.Query(r, "include_profile")
+		if ok {
+			x, err := strconv.ParseBool(v)
+			if err != nil {
+				return errors.New("failed to parse Query section: failed to set query parameter include_profile: invalid boolean value: " + v)
+			}
+			req.Query.IncludeProfile = x
+		}
+	}
+	{
+		v, ok := params.Query(r, "fields")
+		var values []string
+		if multi, isMulti := params.(api.QueryValuesAdapter[*http.Request]); isMulti {
+			if vs := multi.QueryValues(r, "fields"); values == nil && len(vs) > 1 {
+				values = vs
+			}
+		}
+		if values == nil && ok && v != "" {
+			values = strings.Split(v, ",")
+			for i := range values {
+				values[i] = strings.TrimSpace(values[i])
+			}
+		}
+		if values != nil {
+			req.Query.Fields = values
+		}
+	}
+	return nil
+}
+
+// BindRequest binds GetUserRequest without reflection. It implements
+// api.RequestBinder, used by routes registered with api.WithGeneratedBinders.
+func (req *GetUserRequest) BindRequest(r *http.Request, params api.GenericParameterAdapter[*http.Request], bindBody func(body any) error) error {
+	{
+		v, ok := params.Path(r, "userId")
+		if ok {
+			req.Path.UserID = v
+		}
+	}
+	return nil
+}
+
+// BindRequest binds ListUsersRequest without reflection. It implements
+// api.RequestBinder, used by routes registered with api.WithGeneratedBinders.
+func (req *ListUsersRequest) BindRequest(r *http.Request, params api.GenericParameterAdapter[*http.Request], bindBody func(body any) error) error {
+	{
+		v, ok := params.Query(r, "limit")
+		if ok {
+			x, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return errors.New("failed to parse Query section: failed to set query parameter limit: invalid integer value: " + v)
+			}
+			req.Query.Limit = int(x)
+		}
+	}
+	{
+		v, ok := params.Query(r, "offset")
+		if ok {
+			x, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return errors.New("failed to parse Query section: failed to set query parameter offset: invalid integer value: " + v)
+			}
+			req.Query.Offset = int(x)
+		}
+	}
+	return nil
+}
+
+// BindRequest binds LoginConventionRequest without reflection. It implements
+// api.RequestBinder, used by routes registered with api.WithGeneratedBinders.
+func (req *LoginConventionRequest) BindRequest(r *http.Request, params api.GenericParameterAdapter[*http.Request], bindBody func(body any) error) error {
+	if err := bindBody(&req.Body); err != nil {
+		return fmt.Errorf("failed to parse Body section: %w", err)
+	}
+	return nil
+}
+
+// BindRequest binds PaymentMethodRequest without reflection. It implements
+// api.RequestBinder, used by routes registered with api.WithGeneratedBinders.
+func (req *PaymentMethodRequest) BindRequest(r *http.Request, params api.GenericParameterAdapter[*http.Request], bindBody func(body any) error) error {
+	{
+		v, ok := params.Path(r, "userId")
+		if ok {
+			req.Path.UserID = v
+		}
+	}
+	if err := bindBody(&req.Body); err != nil {
+		return fmt.Errorf("failed to parse Body section: %w", err)
+	}
+	return nil
+}
+
+// BindRequest binds UpdateOwnedItemRequest without reflection. It implements
+// api.RequestBinder, used by routes registered with api.WithGeneratedBinders.
+func (req *UpdateOwnedItemRequest) BindRequest(r *http.Request, params api.GenericParameterAdapter[*http.Request], bindBody func(body any) error) error {
+	{
+		v, ok := params.Path(r, "itemId")
+		if ok {
+			req.Path.ItemID = v
+		}
+	}
+	return nil
+}
+
+// BindRequest binds UpdateUserConventionRequest without reflection. It implements
+// api.RequestBinder, used by routes registered with api.WithGeneratedBinders.
+func (req *UpdateUserConventionRequest) BindRequest(r *http.Request, params api.GenericParameterAdapter[*http.Request], bindBody func(body any) error) error {
+	{
+		v, ok := params.Path(r, "user_id")
+		if ok {
+			req.Path.UserID = v
+		}
+	}
+	{
+		v, ok := params.Query(r, "force")
+		if ok {
+			x, err := strconv.ParseBool(v)
+			if err != nil {
+				return errors.New("failed to parse Query section: failed to set query parameter force: invalid boolean value: " + v)
+			}
+			req.Query.Force = x
+		}
+	}
+	{
+		v, ok := params.Query(r, "notify")
+		if ok {
+			x, err := strconv.ParseBool(v)
+			if err != nil {
+				return errors.New("failed to parse Query section: failed to set query parameter notify: invalid boolean value: " + v)
+			}
+			req.Query.Notify = x
+		}
+	}
+	{
+		v, ok := params.Header(r, "Authorization")
+		if ok {
+			req.Headers.Authorization = v
+		}
+	}
+	{
+		v, ok := params.Header(r, "If-Match")
+		if ok {
+			req.Headers.IfMatch = v
+		}
+	}
+	{
+		v, ok := params.Cookie(r, "session_id")
+		if ok {
+			req.Cookies.SessionID = v
+		}
+	}
+	{
+		v, ok := params.Cookie(r, "preferences")
+		if ok {
+			req.Cookies.Preferences = v
+		}
+	}
+	if err := bindBody(&req.Body); err != nil {
+		return fmt.Errorf("failed to parse Body section: %w", err)
+	}
+	return nil
+}
+
+// BindRequest binds UpdateUserPreferencesRequest without reflection. It implements
+// api.RequestBinder, used by routes registered with api.WithGeneratedBinders.
+func (req *UpdateUserPreferencesRequest) BindRequest(r *http.Request, params api.GenericParameterAdapter[*http.Request], bindBody func(body any) error) error {
+	{
+		v, ok := params.Path(r, "userId")
+		if ok {
+			req.Path.UserID = v
+		}
+	}
+	if err := bindBody(&req.Body); err != nil {
+		return fmt.Errorf("failed to parse Body section: %w", err)
+	}
+	return nil
+}
+
+// BindRequest binds UpdateUserRequest without reflection. It implements
+// api.RequestBinder, used by routes registered with api.WithGeneratedBinders.
+func (req *UpdateUserRequest) BindRequest(r *http.Request, params api.GenericParameterAdapter[*http.Request], bindBody func(body any) error) error {
+	{
+		v, ok := params.Path(r, "userId")
+		if ok {
+			req.Path.UserID = v
+		}
+	}
+	{
+		v, ok := params.Header(r, "X-User-Version")
+		if ok {
+			x, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return errors.New("failed to parse Headers section: failed to set header X-User-Version: invalid integer value: " + v)
+			}
+			req.Headers.Version = int(x)
+		}
+	}
+	if err := bindBody(&req.Body); err != nil {
+		return fmt.Errorf("failed to parse Body section: %w", err)
+	}
+	return nil
+}
@@ -12,7 +12,8 @@ import (
 
 // RegisterRoutes registers all API routes.
 func RegisterRoutes(mux *http.ServeMux) *stdlib.Router {
-	r := stdlib.NewRouter(mux)
+	// Bind requests with the code in handlers/handlers_gen.go
+	r := stdlib.NewRouter(mux, api.WithGeneratedBinders())
 
 	// Auth
 	r.Post("/api/v1/auth/login", handlers.Login, api.WithTags("auth"))
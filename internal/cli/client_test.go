@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// clientRunner records the client language the built binary was asked for.
+type clientRunner struct {
+	MockBuildRunner
+	lang, pkg string
+}
+
+func (r *clientRunner) RunCommand(_ string, env []string) ([]byte, error) {
+	r.lang, _ = envOf(env, "GORK_CLIENT")
+	r.pkg, _ = envOf(env, "GORK_CLIENT_PACKAGE")
+	return []byte("export class Client {}\n"), nil
+}
+
+func TestGenerateClient(t *testing.T) {
+	runner := &clientRunner{}
+	var stdout bytes.Buffer
+	if err := generateClientWithRunner(&ClientConfig{Lang: "ts", BuildPath: "./cmd/server", OutputPath: "-"}, &stdout, runner); err != nil {
+		t.Fatal(err)
+	}
+	if runner.lang != "ts" || stdout.String() != "export class Client {}\n" {
+		t.Errorf("unexpected lang %q and output %q", runner.lang, stdout.String())
+	}
+	if _, ok := os.LookupEnv("GORK_CLIENT"); ok {
+		t.Error("GORK_CLIENT must not be set in the generating process")
+	}
+
+	output := filepath.Join(t.TempDir(), "client.ts")
+	if err := generateClientWithRunner(&ClientConfig{Lang: "ts", OutputPath: output}, &stdout, runner); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(output); err != nil || string(data) != "export class Client {}\n" {
+		t.Errorf("expected the client in %s, got %q (%v)", output, data, err)
+	}
+}
+
+func TestNewClientCommand(t *testing.T) {
+	cmd := newClientCommand()
+	if cmd.Use != "client" || cmd.Flags().Lookup("lang").DefValue != "ts" || cmd.Flags().Lookup("build") == nil {
+		t.Errorf("unexpected command %+v", cmd)
+	}
+}
+
+func TestClientGenerateCommand(t *testing.T) {
+	cmd := newClientRootCommand()
+	generate, _, err := cmd.Find([]string{"generate"})
+	if err != nil || generate.Flags().Lookup("package").DefValue != "client" || generate.Flags().Lookup("build") == nil {
+		t.Fatalf("unexpected generate command %+v (%v)", generate, err)
+	}
+
+	runner := &clientRunner{}
+	var stdout bytes.Buffer
+	if err := generateClientWithRunner(&ClientConfig{Lang: "go", Package: "myapiclient", BuildPath: "./cmd/server"}, &stdout, runner); err != nil {
+		t.Fatal(err)
+	}
+	if runner.lang != "go" || runner.pkg != "myapiclient" {
+		t.Errorf("expected a Go client in package myapiclient, got %q %q", runner.lang, runner.pkg)
+	}
+	if _, ok := os.LookupEnv("GORK_CLIENT_PACKAGE"); ok {
+		t.Error("GORK_CLIENT_PACKAGE must not be set in the generating process")
+	}
+}
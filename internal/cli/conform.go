@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/gork-labs/gork/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+func newConformCommand() *cobra.Command {
+	var config ConformConfig
+
+	cmd := &cobra.Command{
+		Use:   "conform",
+		Short: "Check that the implemented API conforms to a hand-written OpenAPI document",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return ConformSpec(&config, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVar(&config.SpecPath, "spec", "", "Path to the design OpenAPI document (JSON or YAML)")
+	cmd.Flags().StringVar(&config.BuildPath, "build", "", "Path to main package to build with '-tags openapi'")
+	_ = cmd.MarkFlagRequired("spec")
+	_ = cmd.MarkFlagRequired("build")
+
+	return cmd
+}
+
+// ConformConfig holds configuration for the conform command.
+type ConformConfig struct {
+	SpecPath  string
+	BuildPath string
+}
+
+// ConformSpec builds the application, extracts its OpenAPI contract and
+// compares it with the design document. Every mismatch is written to out and
+// an error is returned when at least one was found.
+func ConformSpec(config *ConformConfig, out io.Writer) error {
+	data, err := os.ReadFile(filepath.Clean(config.SpecPath))
+	if err != nil {
+		return fmt.Errorf("read spec: %w", err)
+	}
+	design, err := api.ParseOpenAPIDocument(data)
+	if err != nil {
+		return err
+	}
+
+	implemented, err := buildAndExtract(config.BuildPath)
+	if err != nil {
+		return err
+	}
+
+	issues := api.CheckConformance(implemented, design)
+	for _, issue := range issues {
+		_, _ = fmt.Fprintln(out, issue.String())
+	}
+	if len(issues) > 0 {
+		return fmt.Errorf("%d conformance issue(s) against %s", len(issues), config.SpecPath)
+	}
+	_, _ = fmt.Fprintf(out, "API conforms to %s\n", config.SpecPath)
+	return nil
+}
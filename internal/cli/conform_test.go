@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const conformImplementedSpec = `{"openapi":"3.1.0","info":{"title":"Test","version":"1.0.0"},"paths":{
+	"/users/{id}":{"get":{"operationId":"GetUser","parameters":[{"name":"id","in":"path","required":true,"schema":{"type":"string"}}],
+	"responses":{"200":{"description":"OK","content":{"application/json":{"schema":{"type":"object","properties":{"name":{"type":"string"}}}}}}}}}}}`
+
+func writeConformDesign(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "design.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func withBuildRunner(t *testing.T, runner BuildRunner) {
+	t.Helper()
+	prev := defaultBuildRunner
+	defaultBuildRunner = runner
+	t.Cleanup(func() { defaultBuildRunner = prev })
+}
+
+func TestConformSpec(t *testing.T) {
+	conforming := `openapi: 3.1.0
+info: {title: Design, version: "1.0"}
+paths:
+  /users/{userID}:
+    get:
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+      responses:
+        200:
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  name: {type: string}
+`
+	mismatching := strings.Replace(conforming, "name: {type: string}", "name: {type: integer}", 1)
+
+	tests := []struct {
+		name        string
+		design      string
+		runner      *MockBuildRunner
+		wantErr     string
+		wantOutput  string
+		missingSpec bool
+	}{
+		{name: "conforming", design: conforming, runner: &MockBuildRunner{RunOutput: []byte(conformImplementedSpec)}, wantOutput: "API conforms to"},
+		{name: "mismatch", design: mismatching, runner: &MockBuildRunner{RunOutput: []byte(conformImplementedSpec)}, wantErr: "1 conformance issue(s)", wantOutput: "GET /users/{userID}: response 200 body.name: type is string, spec declares integer"},
+		{name: "missing spec", missingSpec: true, runner: &MockBuildRunner{}, wantErr: "read spec"},
+		{name: "invalid spec", design: "paths: [", runner: &MockBuildRunner{}, wantErr: "parse OpenAPI document"},
+		{name: "build failure", design: conforming, runner: &MockBuildRunner{RunError: os.ErrPermission}, wantErr: "run generated binary"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withBuildRunner(t, tt.runner)
+			specPath := filepath.Join(t.TempDir(), "missing.yaml")
+			if !tt.missingSpec {
+				specPath = writeConformDesign(t, tt.design)
+			}
+
+			var out bytes.Buffer
+			err := ConformSpec(&ConformConfig{SpecPath: specPath, BuildPath: "./app"}, &out)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if !strings.Contains(out.String(), tt.wantOutput) {
+				t.Errorf("expected output containing %q, got %q", tt.wantOutput, out.String())
+			}
+		})
+	}
+}
+
+func TestNewConformCommand(t *testing.T) {
+	withBuildRunner(t, &MockBuildRunner{RunOutput: []byte(conformImplementedSpec)})
+	design := writeConformDesign(t, conformImplementedSpec)
+
+	cmd := newConformCommand()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--spec", design, "--build", "./app"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "API conforms to") {
+		t.Errorf("unexpected output %q", out.String())
+	}
+
+	cmd = newConformCommand()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--build", "./app"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected error when --spec is missing")
+	}
+}
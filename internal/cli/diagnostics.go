@@ -0,0 +1,236 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"go/scanner"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/gork-labs/gork/pkg/api"
+)
+
+// Severity ranks a problem found while generating a spec.
+type Severity int
+
+// Severities in increasing order. SeverityNone is only meaningful as a
+// --fail-on threshold and disables failing on diagnostics.
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+	SeverityNone
+)
+
+var severityNames = []string{"info", "warning", "error", "none"}
+
+// String returns the lowercase severity name.
+func (s Severity) String() string {
+	if s < SeverityInfo || s > SeverityNone {
+		return fmt.Sprintf("severity(%d)", int(s))
+	}
+	return severityNames[s]
+}
+
+// ParseSeverity parses info, warning, error or none.
+func ParseSeverity(name string) (Severity, error) {
+	for i, n := range severityNames {
+		if strings.EqualFold(name, n) {
+			return Severity(i), nil
+		}
+	}
+	return SeverityError, fmt.Errorf("unknown severity %q (want %s)", name, strings.Join(severityNames, ", "))
+}
+
+// Pipeline stages, in the order they run and are reported.
+const (
+	stageParse    = "parse"
+	stageGenerate = "generate"
+	stageValidate = "validate"
+)
+
+var stageOrder = []string{stageParse, stageGenerate, stageValidate}
+
+// Diagnostic is a single problem reported by a pipeline stage.
+type Diagnostic struct {
+	Severity Severity
+	Stage    string
+	// Subject groups the report: a source directory, a route ("GET /users")
+	// or a schema pointer.
+	Subject string
+	File    string // empty when the location is unknown
+	Line    int
+	Message string
+}
+
+// location formats File:Line, or "" when unknown.
+func (d Diagnostic) location() string {
+	if d.File == "" {
+		return ""
+	}
+	if d.Line > 0 {
+		return fmt.Sprintf("%s:%d", d.File, d.Line)
+	}
+	return d.File
+}
+
+// Diagnostics collects the problems of every stage so that a single run
+// reports all of them instead of stopping at the first.
+type Diagnostics struct {
+	items []Diagnostic
+}
+
+// Add records a diagnostic.
+func (d *Diagnostics) Add(diag Diagnostic) {
+	d.items = append(d.items, diag)
+}
+
+// Items returns the recorded diagnostics in the order they were added.
+func (d *Diagnostics) Items() []Diagnostic {
+	return d.items
+}
+
+// Count returns the number of diagnostics at or above min.
+func (d *Diagnostics) Count(min Severity) int {
+	n := 0
+	for _, diag := range d.items {
+		if diag.Severity >= min {
+			n++
+		}
+	}
+	return n
+}
+
+// Err returns an error when a diagnostic reaches the threshold.
+func (d *Diagnostics) Err(threshold Severity) error {
+	if n := d.Count(threshold); n > 0 {
+		return fmt.Errorf("spec generation reported %d problem(s) at or above %s", n, threshold)
+	}
+	return nil
+}
+
+// WriteReport writes the diagnostics grouped by stage and subject, most
+// severe first within a subject. At most maxErrors diagnostics are listed
+// when maxErrors is positive.
+func (d *Diagnostics) WriteReport(w io.Writer, maxErrors int) error {
+	if len(d.items) == 0 {
+		return nil
+	}
+	var b strings.Builder
+	shown := 0
+	for _, stage := range stageOrder {
+		subjects, grouped := d.group(stage)
+		if len(subjects) == 0 || (maxErrors > 0 && shown >= maxErrors) {
+			continue
+		}
+		fmt.Fprintf(&b, "%s:\n", stage)
+		for _, subject := range subjects {
+			if maxErrors > 0 && shown >= maxErrors {
+				break
+			}
+			fmt.Fprintf(&b, "  %s\n", subject)
+			for _, diag := range grouped[subject] {
+				if maxErrors > 0 && shown >= maxErrors {
+					break
+				}
+				fmt.Fprintf(&b, "    %-7s ", diag.Severity)
+				if loc := diag.location(); loc != "" {
+					b.WriteString(loc + ": ")
+				}
+				b.WriteString(strings.ReplaceAll(diag.Message, "\n", "\n            "))
+				b.WriteString("\n")
+				shown++
+			}
+		}
+	}
+	if hidden := len(d.items) - shown; hidden > 0 {
+		fmt.Fprintf(&b, "... %d more not shown (raise --max-errors)\n", hidden)
+	}
+	counts := map[Severity]int{}
+	for _, diag := range d.items {
+		counts[diag.Severity]++
+	}
+	fmt.Fprintf(&b, "%d error(s), %d warning(s), %d info\n", counts[SeverityError], counts[SeverityWarning], counts[SeverityInfo])
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// group returns the subjects of a stage in first-seen order and their
+// diagnostics sorted by descending severity.
+func (d *Diagnostics) group(stage string) ([]string, map[string][]Diagnostic) {
+	var subjects []string
+	grouped := map[string][]Diagnostic{}
+	for _, diag := range d.items {
+		if diag.Stage != stage {
+			continue
+		}
+		if _, ok := grouped[diag.Subject]; !ok {
+			subjects = append(subjects, diag.Subject)
+		}
+		grouped[diag.Subject] = append(grouped[diag.Subject], diag)
+	}
+	for _, list := range grouped {
+		for i := 1; i < len(list); i++ {
+			for j := i; j > 0 && list[j].Severity > list[j-1].Severity; j-- {
+				list[j], list[j-1] = list[j-1], list[j]
+			}
+		}
+	}
+	return subjects, grouped
+}
+
+// addParseErrors reports the source files skipped during doc extraction,
+// grouped by directory.
+func (d *Diagnostics) addParseErrors(errs []error) {
+	for _, err := range errs {
+		var list scanner.ErrorList
+		if !errors.As(err, &list) || len(list) == 0 {
+			d.Add(Diagnostic{Severity: SeverityWarning, Stage: stageParse, Subject: "source", Message: err.Error()})
+			continue
+		}
+		for _, e := range list {
+			d.Add(Diagnostic{
+				Severity: SeverityWarning,
+				Stage:    stageParse,
+				Subject:  filepath.Dir(e.Pos.Filename),
+				File:     e.Pos.Filename,
+				Line:     e.Pos.Line,
+				Message:  e.Msg + " (file skipped for doc extraction)",
+			})
+		}
+	}
+}
+
+// addGenerationErrors reports the routes the built binary left out of the
+// spec as errors and its warnings, located at their handler declaration
+// when the source was parsed.
+func (d *Diagnostics) addGenerationErrors(genErrs *api.GenerationError, extractor *api.DocExtractor) {
+	if genErrs == nil {
+		return
+	}
+	for _, route := range genErrs.Routes {
+		d.addRouteDiagnostic(SeverityError, route, extractor)
+	}
+	for _, route := range genErrs.Warnings {
+		d.addRouteDiagnostic(SeverityWarning, route, extractor)
+	}
+}
+
+func (d *Diagnostics) addRouteDiagnostic(severity Severity, route api.RouteGenerationError, extractor *api.DocExtractor) {
+	diag := Diagnostic{
+		Severity: severity,
+		Stage:    stageGenerate,
+		Subject:  route.Operation,
+		Message:  route.Message,
+	}
+	if route.Handler != "" {
+		diag.Subject += " (" + route.Handler + ")"
+	}
+	if extractor != nil {
+		if pos, ok := extractor.Position(route.Handler); ok {
+			diag.File, diag.Line = pos.Filename, pos.Line
+		}
+	}
+	d.Add(diag)
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gork-labs/gork/pkg/api"
+)
+
+func TestParseSeverity(t *testing.T) {
+	for name, want := range map[string]Severity{"info": SeverityInfo, "WARNING": SeverityWarning, "error": SeverityError, "none": SeverityNone} {
+		if got, err := ParseSeverity(name); err != nil || got != want {
+			t.Errorf("ParseSeverity(%q) = %v, %v; want %v", name, got, err, want)
+		}
+	}
+	if _, err := ParseSeverity("fatal"); err == nil {
+		t.Error("expected an error for an unknown severity")
+	}
+}
+
+func TestDiagnosticsReport(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "broken.go"), []byte("package main\nfunc broken {\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "handlers.go"), []byte("package main\n\nfunc GetUser() {}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	extractor := api.NewDocExtractor()
+	if err := extractor.ParseDirectory(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	diags := &Diagnostics{}
+	diags.addParseErrors(extractor.ParseErrors())
+	diags.addGenerationErrors(&api.GenerationError{Routes: []api.RouteGenerationError{
+		{Operation: "GET /users", Handler: "GetUser", Message: "response type must use sections"},
+		{Operation: "POST /users", Handler: "CreateUser", Message: "response type must use sections"},
+	}, Warnings: []api.RouteGenerationError{
+		{Operation: "GET /users", Handler: "GetUser", Message: `tag "admin" is not registered with api.RegisterTag`},
+	}}, extractor)
+	if err := addValidatorResponse(diags, []byte(`{"messages":[{"level":"warning","message":"minor"}],`+
+		`"schemaValidationMessages":[{"level":"error","message":"bad type","schema":{"pointer":"/components/schemas/User"}}]}`), 200); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := diags.WriteReport(&out, 0); err != nil {
+		t.Fatal(err)
+	}
+	report := out.String()
+	for _, want := range []string{
+		"parse:\n  " + dir + "\n    warning " + filepath.Join(dir, "broken.go") + ":2: ",
+		"generate:\n  GET /users (GetUser)\n    error   " + filepath.Join(dir, "handlers.go") + ":3: response type must use sections\n" +
+			"    warning " + filepath.Join(dir, "handlers.go") + ":3: tag \"admin\" is not registered with api.RegisterTag\n",
+		"  POST /users (CreateUser)\n    error   response type must use sections\n",
+		"validate:\n  spec\n    warning minor\n  schema /components/schemas/User\n    error   bad type\n",
+		"3 error(s), 3 warning(s), 0 info\n",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report is missing %q:\n%s", want, report)
+		}
+	}
+
+	out.Reset()
+	_ = diags.WriteReport(&out, 2)
+	if strings.Contains(out.String(), "validate:") || !strings.Contains(out.String(), "... 4 more not shown") {
+		t.Errorf("expected the report to stop after 2 problems:\n%s", out.String())
+	}
+
+	if diags.Err(SeverityError) == nil || diags.Err(SeverityNone) != nil {
+		t.Error("expected only the error threshold to fail")
+	}
+}
+
+func TestGenerateSpecFailOnThreshold(t *testing.T) {
+	originalClient := defaultValidatorClient
+	defaultValidatorClient = &MockValidatorClient{
+		CallBody:       []byte(`{"messages":[{"level":"warning","message":"operationId missing"}]}`),
+		CallStatusCode: 200,
+	}
+	defer func() { defaultValidatorClient = originalClient }()
+
+	output := filepath.Join(t.TempDir(), "openapi.json")
+	var report bytes.Buffer
+	config := &GenerateConfig{OutputPath: output, Title: "API", Version: "1.0.0", Report: &report}
+	if err := GenerateSpec(config); err != nil {
+		t.Fatalf("warnings must not fail by default: %v", err)
+	}
+	if !strings.Contains(report.String(), "warning operationId missing") {
+		t.Errorf("expected the warning in the report, got %q", report.String())
+	}
+	if _, err := os.Stat(output); err != nil {
+		t.Errorf("expected the spec to be written: %v", err)
+	}
+
+	config.FailOn = "warning"
+	if err := GenerateSpec(config); err == nil || !strings.Contains(err.Error(), "1 problem(s) at or above warning") {
+		t.Errorf("expected --fail-on=warning to fail, got %v", err)
+	}
+
+	config.FailOn = "loud"
+	if err := GenerateSpec(config); err == nil {
+		t.Error("expected an invalid --fail-on to fail")
+	}
+}
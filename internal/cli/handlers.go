@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+func newHandlersCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "handlers",
+		Short: "Handler code generation",
+	}
+	cmd.AddCommand(newHandlersGenerateCommand())
+	return cmd
+}
+
+func newHandlersGenerateCommand() *cobra.Command {
+	var config HandlersConfig
+
+	cmd := &cobra.Command{
+		Use:   "generate [package-dir...]",
+		Short: "Generate reflection-free request binders, validators and response encoders for handlers",
+		Long: `Generate a BindRequest method for the request type of each handler in the
+package directories, binding its Path, Query, Headers and Cookies sections
+without reflection, and a ValidateSection method checking the validate tags
+of its sections. Response types get an EncodeBody method writing their Body
+section as gorkson would. Routes use them with api.WithGeneratedBinders.
+
+Request types, sections and bodies the generator cannot handle are reported
+and keep using reflection.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config.Dirs = args
+			return GenerateHandlerBinderFiles(&config, func(skipped string) {
+				fmt.Fprintf(cmd.ErrOrStderr(), "skipping %s\n", skipped)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&config.Output, "output", handlersOutputFile, "Name of the generated file in each package directory")
+
+	return cmd
+}
+
+// HandlersConfig holds configuration for generating request binders,
+// validators and response encoders.
+type HandlersConfig struct {
+	// Dirs are the package directories to scan; the current directory when
+	// empty.
+	Dirs []string
+	// Output is the file the generated code of a package is written to.
+	Output string
+}
+
+// GenerateHandlerBinderFiles writes the request binders, validators and
+// response encoders of the configured package directories, reporting each
+// type or section left to reflection to skipped.
+func GenerateHandlerBinderFiles(config *HandlersConfig, skipped func(string)) error {
+	dirs := config.Dirs
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+	output := config.Output
+	if output == "" {
+		output = handlersOutputFile
+	}
+
+	for _, dir := range dirs {
+		binders, err := GenerateHandlerBinders(dir)
+		if err != nil {
+			return err
+		}
+		for _, s := range binders.Skipped {
+			skipped(s)
+		}
+		if binders.Source == nil {
+			continue
+		}
+		path := filepath.Join(dir, output)
+		if err := os.WriteFile(path, binders.Source, 0o600); err != nil {
+			return fmt.Errorf("write %s: %w", path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,913 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"math"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/gork-labs/gork/pkg/gorkson"
+)
+
+// handlersOutputFile is the default file name of generated binders.
+const handlersOutputFile = "handlers_gen.go"
+
+// handlersTemplateFile is the embedded binder template.
+const handlersTemplateFile = "templates/handlers.go.tmpl"
+
+// paramSections are the request sections generated binders bind, in parsing
+// order, with the adapter method and error label of each.
+var paramSections = []struct {
+	Name, Method, Label string
+}{
+	{"Path", "Path", "path parameter"},
+	{"Query", "Query", "query parameter"},
+	{"Headers", "Header", "header"},
+	{"Cookies", "Cookie", "cookie"},
+}
+
+// validatedSections are the request sections generated validators may
+// cover, in validation order.
+var validatedSections = []string{"Path", "Query", "Headers", "Cookies", "Body"}
+
+// handlersFileData is the data the "file" template renders.
+type handlersFileData struct {
+	Package  string
+	Binders  []binderData
+	Encoders []encoderData
+	// Imports the generated code needs besides net/http and api, which
+	// binders need.
+	Errors, Fmt, Strconv, Strings, Time, UTF8, Gorkson bool
+}
+
+// binderData is a request type rendered by the "binder" template, and by
+// the "validator" template when it has validated sections.
+type binderData struct {
+	Type   string
+	Fields []binderField
+	// Body hands the Body section to the ConventionParser.
+	Body bool
+	// Validated are the sections ValidateSection covers.
+	Validated []validatedSection
+}
+
+// validatedSection is a section whose validate tags are checked by
+// generated code.
+type validatedSection struct {
+	Name   string
+	Checks []validationCheck
+}
+
+// validationCheck is a field rendered by the "check" template.
+type validationCheck struct {
+	// Key is the error key, e.g. "query.limit".
+	Key string
+	// Guard is the condition the rules apply under, for omitempty.
+	Guard string
+	// Rules are checked in order; only the first failing one is reported.
+	Rules []validationRule
+}
+
+// validationRule is a validate tag rule, e.g. "min" for min=1, failing
+// when the Go expression Fail holds.
+type validationRule struct {
+	Tag, Fail string
+}
+
+// encoderData is a response type rendered by the "encoder" template.
+type encoderData struct {
+	Type   string
+	Fields []encodedField
+	// Err declares err for the fields' code.
+	Err bool
+}
+
+// encodedField is a Body field of an encoder.
+type encodedField struct {
+	// Key is the Go literal of the field's comma and JSON name.
+	Key string
+	// Guard is the condition the field is encoded under, for omitempty
+	// and omitzero.
+	Guard string
+	// Code appends the value to b.
+	Code string
+}
+
+// binderField is a parameter rendered by the "field" template.
+type binderField struct {
+	// Field is the path to the field, e.g. "Query.Limit".
+	Field string
+	// Method is the adapter method looking the parameter up, e.g. "Query".
+	Method string
+	// Keys are the parameter name followed by its aliases.
+	Keys []string
+	// Kind is "string", "int", "uint", "bool", "float" or "duration".
+	Kind string
+	// Type is the Go type of the value, or of the elements of slices.
+	Type  string
+	Slice bool
+	// Repeated binds slices from repeated query parameters and headers too.
+	Repeated bool
+	// Parse parses v into x and err, Convert converts x to Type.
+	Parse, Convert string
+	// Error prefixes parse errors, Invalid describes the value.
+	Error, Invalid string
+	Sensitive      bool
+	// Default is the Go expression assigned when the parameter is absent.
+	Default string
+}
+
+// HandlerBinders is the result of generating the binders of a package.
+type HandlerBinders struct {
+	// Source is the formatted generated file, nil when no request or
+	// response type could be handled.
+	Source []byte
+	// Skipped explains each request type left to the reflective parser,
+	// and each section left to the validator or body left to gorkson.
+	Skipped []string
+}
+
+// GenerateHandlerBinders renders binders and validators for the request
+// types of the handlers in the package in dir, the second parameter of
+// functions and methods taking a context.Context and a request struct as
+// routes are registered with, and encoders for their response types.
+// Request types with parameters other than basic kinds, time.Duration and
+// slices of those are skipped, as are sections using validate rules and
+// bodies using types that cannot be generated.
+func GenerateHandlerBinders(dir string) (*HandlerBinders, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", dir, err)
+	}
+
+	result := &HandlerBinders{}
+	for _, pkg := range pkgs {
+		structs := map[string]*ast.StructType{}
+		requests := map[string]bool{}
+		responses := map[string]bool{}
+		marshalers := map[string]bool{}
+		for _, file := range pkg.Files {
+			if ast.IsGenerated(file) {
+				continue
+			}
+			collectStructs(file, structs)
+			collectHandlerTypes(file, requests, responses)
+			collectMarshalers(file, marshalers)
+		}
+
+		data := &handlersFileData{Package: pkg.Name}
+		for _, name := range structNames(requests, structs) {
+			binder, err := buildBinder(name, structs)
+			if err != nil {
+				result.Skipped = append(result.Skipped, fmt.Sprintf("%s: %v", name, err))
+				continue
+			}
+			var skipped []string
+			binder.Validated, skipped = buildValidation(name, structs)
+			for _, reason := range skipped {
+				result.Skipped = append(result.Skipped, fmt.Sprintf("%s: %s", name, reason))
+			}
+			data.add(binder)
+		}
+		for _, name := range structNames(responses, structs) {
+			encoder, ok, err := buildEncoder(name, structs, marshalers)
+			if err != nil {
+				result.Skipped = append(result.Skipped, fmt.Sprintf("%s: %v", name, err))
+				continue
+			}
+			if ok {
+				data.addEncoder(encoder)
+			}
+		}
+		if len(data.Binders) == 0 && len(data.Encoders) == 0 {
+			continue
+		}
+
+		tmpl, err := template.ParseFS(defaultTemplates, handlersTemplateFile)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := tmpl.ExecuteTemplate(&buf, "file", data); err != nil {
+			return nil, fmt.Errorf("render binders: %w", err)
+		}
+		if result.Source, err = format.Source(buf.Bytes()); err != nil {
+			return nil, fmt.Errorf("format binders: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// structNames returns the sorted names in types declared as structs.
+func structNames(types map[string]bool, structs map[string]*ast.StructType) []string {
+	names := make([]string, 0, len(types))
+	for name := range types {
+		if structs[name] != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// add appends a binder, noting the imports it needs.
+func (d *handlersFileData) add(binder binderData) {
+	d.Binders = append(d.Binders, binder)
+	d.Fmt = d.Fmt || binder.Body
+	for _, section := range binder.Validated {
+		for _, check := range section.Checks {
+			for _, rule := range check.Rules {
+				d.UTF8 = d.UTF8 || strings.Contains(rule.Fail, "utf8.")
+			}
+		}
+	}
+	for _, f := range binder.Fields {
+		switch f.Kind {
+		case "string":
+		case "duration":
+			d.Errors, d.Time = true, true
+		default:
+			d.Errors, d.Strconv = true, true
+		}
+		if f.Slice {
+			d.Strings = true
+			d.Strconv = d.Strconv || (f.Kind != "string" && !f.Sensitive)
+		}
+	}
+}
+
+// collectStructs records the struct types declared in file.
+func collectStructs(file *ast.File, structs map[string]*ast.StructType) {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if st, ok := ts.Type.(*ast.StructType); ok && !ts.Assign.IsValid() && ts.TypeParams == nil {
+				structs[ts.Name.Name] = st
+			}
+		}
+	}
+}
+
+// addEncoder appends an encoder, noting the imports it needs.
+func (d *handlersFileData) addEncoder(encoder encoderData) {
+	d.Encoders = append(d.Encoders, encoder)
+	for _, f := range encoder.Fields {
+		d.Gorkson = d.Gorkson || strings.Contains(f.Code, "gorkson.")
+		d.Strconv = d.Strconv || strings.Contains(f.Code, "strconv.")
+	}
+}
+
+// collectHandlerTypes records the request and response types of the
+// handlers in file: functions and methods whose parameters are a
+// context.Context and a named type, returning an error last, after the
+// response if any.
+func collectHandlerTypes(file *ast.File, requests, responses map[string]bool) {
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Type.Results == nil {
+			continue
+		}
+		params := fieldTypes(fn.Type.Params)
+		results := fieldTypes(fn.Type.Results)
+		if len(params) != 2 || !isContextType(params[0]) || len(results) == 0 || exprString(results[len(results)-1]) != "error" {
+			continue
+		}
+		if id, ok := params[1].(*ast.Ident); ok {
+			requests[id.Name] = true
+		}
+		if len(results) != 2 {
+			continue
+		}
+		resp := results[0]
+		if star, ok := resp.(*ast.StarExpr); ok {
+			resp = star.X
+		}
+		if id, ok := resp.(*ast.Ident); ok {
+			responses[id.Name] = true
+		}
+	}
+}
+
+// collectMarshalers records the types of file with a MarshalJSON or
+// MarshalText method, which gorkson leaves to encode themselves.
+func collectMarshalers(file *ast.File, marshalers map[string]bool) {
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || len(fn.Recv.List) != 1 || (fn.Name.Name != "MarshalJSON" && fn.Name.Name != "MarshalText") {
+			continue
+		}
+		recv := fn.Recv.List[0].Type
+		if star, ok := recv.(*ast.StarExpr); ok {
+			recv = star.X
+		}
+		if id, ok := recv.(*ast.Ident); ok {
+			marshalers[id.Name] = true
+		}
+	}
+}
+
+// fieldTypes returns the type of each name in fields.
+func fieldTypes(fields *ast.FieldList) []ast.Expr {
+	var types []ast.Expr
+	for _, field := range fields.List {
+		n := len(field.Names)
+		if n == 0 {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			types = append(types, field.Type)
+		}
+	}
+	return types
+}
+
+func isContextType(expr ast.Expr) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Context" {
+		return false
+	}
+	id, ok := sel.X.(*ast.Ident)
+	return ok && id.Name == "context"
+}
+
+// buildBinder describes the binder of request type name, or explains why it
+// cannot have one.
+func buildBinder(name string, structs map[string]*ast.StructType) (binderData, error) {
+	binder := binderData{Type: name}
+	sections := sectionTypes(structs[name])
+	for _, section := range paramSections {
+		expr, ok := sections[section.Name]
+		if !ok {
+			continue
+		}
+		st, ok := sectionStruct(expr, structs)
+		if !ok {
+			return binderData{}, fmt.Errorf("section %s is not a struct", section.Name)
+		}
+		for _, field := range st.Fields.List {
+			if len(field.Names) == 0 {
+				return binderData{}, fmt.Errorf("%s: embedded structs are not supported", section.Name)
+			}
+			tag := fieldTag(field)
+			if tag == "" {
+				continue
+			}
+			if len(field.Names) != 1 || !field.Names[0].IsExported() {
+				return binderData{}, fmt.Errorf("%s: unexported parameters are not supported", section.Name)
+			}
+			f, err := buildBinderField(section.Name+"."+field.Names[0].Name, field.Type, tag)
+			if err != nil {
+				return binderData{}, fmt.Errorf("%s.%s: %w", section.Name, field.Names[0].Name, err)
+			}
+			f.Method = section.Method
+			f.Repeated = f.Slice && (section.Name == "Query" || section.Name == "Headers")
+			f.Error = fmt.Sprintf("failed to parse %s section: failed to set %s %s: ", section.Name, section.Label, f.Keys[0])
+			binder.Fields = append(binder.Fields, f)
+		}
+	}
+	_, binder.Body = sections["Body"]
+	return binder, nil
+}
+
+// sectionTypes returns the type of each named field of st.
+func sectionTypes(st *ast.StructType) map[string]ast.Expr {
+	sections := map[string]ast.Expr{}
+	for _, field := range st.Fields.List {
+		for _, id := range field.Names {
+			sections[id.Name] = field.Type
+		}
+	}
+	return sections
+}
+
+// sectionStruct resolves a section type declared inline or as a struct type
+// of the package.
+func sectionStruct(expr ast.Expr, structs map[string]*ast.StructType) (*ast.StructType, bool) {
+	switch e := expr.(type) {
+	case *ast.StructType:
+		return e, true
+	case *ast.Ident:
+		st, ok := structs[e.Name]
+		return st, ok
+	}
+	return nil, false
+}
+
+// fieldTag returns the gork tag of field.
+func fieldTag(field *ast.Field) string {
+	return structTag(field, "gork")
+}
+
+// structTag returns the tag of field under key.
+func structTag(field *ast.Field, key string) string {
+	if field.Tag == nil {
+		return ""
+	}
+	raw, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return ""
+	}
+	return reflect.StructTag(raw).Get(key)
+}
+
+// valueKind returns the kind of values of the type named typ as generated
+// code handles them: "string", "int", "uint", "bool", "float" or
+// "duration", or "" for other types.
+func valueKind(typ string) string {
+	switch typ {
+	case "string":
+		return "string"
+	case "int", "int8", "int16", "int32", "int64":
+		return "int"
+	case "uint", "uint8", "uint16", "uint32", "uint64":
+		return "uint"
+	case "bool":
+		return "bool"
+	case "float32", "float64":
+		return "float"
+	case "time.Duration":
+		return "duration"
+	}
+	return ""
+}
+
+// buildBinderField describes a parameter of type expr tagged tag.
+func buildBinderField(path string, expr ast.Expr, tag string) (binderField, error) {
+	f := binderField{Field: path}
+	if array, ok := expr.(*ast.ArrayType); ok && array.Len == nil {
+		f.Slice, expr = true, array.Elt
+	}
+	f.Type = exprString(expr)
+	f.Kind = valueKind(f.Type)
+	switch f.Kind {
+	case "string":
+	case "int":
+		f.Parse, f.Invalid = "strconv.ParseInt(v, 10, 64)", "invalid integer value"
+	case "uint":
+		if f.Slice && f.Type == "uint8" {
+			return f, fmt.Errorf("unsupported type []%s", f.Type)
+		}
+		f.Parse, f.Invalid = "strconv.ParseUint(v, 10, 64)", "invalid unsigned integer value"
+	case "bool":
+		f.Parse, f.Invalid = "strconv.ParseBool(v)", "invalid boolean value"
+	case "float":
+		f.Parse, f.Invalid = "strconv.ParseFloat(v, 64)", "invalid float value"
+	case "duration":
+		f.Parse, f.Invalid = "time.ParseDuration(v)", "invalid duration value"
+	default:
+		return f, fmt.Errorf("unsupported type %s", exprString(expr))
+	}
+	f.Convert = "x"
+	if f.Kind != "string" && f.Kind != "duration" && f.Type != f.Kind+"64" && f.Kind != "bool" {
+		f.Convert = f.Type + "(x)"
+	}
+
+	parts := gorkson.SplitTag(tag)
+	f.Keys = []string{strings.TrimSpace(parts[0])}
+	for _, part := range parts[1:] {
+		part = strings.TrimSpace(part)
+		key, val, hasValue := strings.Cut(part, "=")
+		switch {
+		case key == "alias" && hasValue:
+			for _, alias := range strings.Split(val, "|") {
+				if alias = strings.TrimSpace(alias); alias != "" {
+					f.Keys = append(f.Keys, alias)
+				}
+			}
+		case key == "default" && hasValue:
+			def, err := defaultLiteral(f, strings.Trim(strings.TrimSpace(val), "'"))
+			if err != nil {
+				return f, fmt.Errorf("invalid default %q: %w", val, err)
+			}
+			f.Default = def
+		case part == "sensitive":
+			f.Sensitive = true
+		}
+	}
+	return f, nil
+}
+
+// defaultLiteral returns the Go expression of a default value, parsed as
+// the ConventionParser would parse it at run time.
+func defaultLiteral(f binderField, value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	if !f.Slice {
+		return scalarLiteral(f, value)
+	}
+	var elems []string
+	for _, part := range strings.Split(value, ",") {
+		elem, err := scalarLiteral(f, strings.TrimSpace(part))
+		if err != nil {
+			return "", err
+		}
+		elems = append(elems, elem)
+	}
+	return "[]" + f.Type + "{" + strings.Join(elems, ", ") + "}", nil
+}
+
+// scalarLiteral returns the Go expression of a single value of f's type.
+func scalarLiteral(f binderField, value string) (string, error) {
+	switch f.Kind {
+	case "int":
+		n, err := strconv.ParseInt(value, 10, typeBits(f.Type))
+		return strconv.FormatInt(n, 10), err
+	case "uint":
+		n, err := strconv.ParseUint(value, 10, typeBits(f.Type))
+		return strconv.FormatUint(n, 10), err
+	case "bool":
+		b, err := strconv.ParseBool(value)
+		return strconv.FormatBool(b), err
+	case "float":
+		x, err := strconv.ParseFloat(value, typeBits(f.Type))
+		if err == nil && (math.IsInf(x, 0) || math.IsNaN(x)) {
+			err = fmt.Errorf("not a finite number")
+		}
+		return strconv.FormatFloat(x, 'g', -1, 64), err
+	case "duration":
+		d, err := time.ParseDuration(value)
+		return "time.Duration(" + strconv.FormatInt(int64(d), 10) + ")", err
+	}
+	return strconv.Quote(value), nil
+}
+
+// typeBits returns the size of a sized numeric type, 64 for int and uint.
+func typeBits(name string) int {
+	if bits, err := strconv.Atoi(strings.TrimLeft(name, "intuflao")); err == nil {
+		return bits
+	}
+	return 64
+}
+
+// buildValidation describes the generated validation of the sections of
+// request type name, explaining each section left to the validator. Only
+// struct sections whose fields have basic kinds, time.Duration or slices of
+// those are covered, as other types may hold fields validated in turn.
+func buildValidation(name string, structs map[string]*ast.StructType) ([]validatedSection, []string) {
+	var covered []validatedSection
+	var skipped []string
+	sections := sectionTypes(structs[name])
+	for _, section := range validatedSections {
+		expr, ok := sections[section]
+		if !ok {
+			continue
+		}
+		// Other bodies, such as []byte, maps and slices, are left to the
+		// validator quietly, like types of other packages
+		st, ok := sectionStruct(expr, structs)
+		if !ok {
+			continue
+		}
+		checks, err := buildChecks(section, st)
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s validation: %v", section, err))
+			continue
+		}
+		covered = append(covered, validatedSection{Name: section, Checks: checks})
+	}
+	return covered, skipped
+}
+
+// buildChecks describes the checks of the fields of st, the section named
+// section. Fields are named as the validator names them, by their gork tag
+// or Go name.
+func buildChecks(section string, st *ast.StructType) ([]validationCheck, error) {
+	var checks []validationCheck
+	for _, field := range st.Fields.List {
+		if len(field.Names) == 0 {
+			return nil, fmt.Errorf("embedded structs are not supported")
+		}
+		expr, slice := field.Type, false
+		if array, ok := expr.(*ast.ArrayType); ok && array.Len == nil {
+			expr, slice = array.Elt, true
+		}
+		kind := valueKind(exprString(expr))
+		tag := structTag(field, "validate")
+		for _, id := range field.Names {
+			// The validator skips unexported fields
+			if !id.IsExported() {
+				continue
+			}
+			if kind == "" {
+				return nil, fmt.Errorf("%s: unsupported type %s", id.Name, exprString(field.Type))
+			}
+			name := id.Name
+			if tagName := strings.TrimSpace(gorkson.SplitTag(fieldTag(field))[0]); tagName != "" {
+				name = tagName
+			}
+			if tag == "" || tag == "-" {
+				continue
+			}
+			check, err := buildCheck(strings.ToLower(section)+"."+name, "req."+section+"."+id.Name, kind, slice, tag)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", id.Name, err)
+			}
+			if len(check.Rules) > 0 {
+				checks = append(checks, check)
+			}
+		}
+	}
+	return checks, nil
+}
+
+// buildCheck describes the check of the field v, of kind, against the
+// rules of its validate tag, as go-playground/validator applies them:
+// omitempty leading the tag, then required, min, max, len, gt, gte, lt,
+// lte and oneof.
+func buildCheck(key, v, kind string, slice bool, tag string) (validationCheck, error) {
+	check := validationCheck{Key: key}
+	if strings.Contains(tag, "|") {
+		return check, fmt.Errorf("unsupported rule %s", tag)
+	}
+	for i, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		if name == "omitempty" && i == 0 {
+			check.Guard = emptyCondition(v, kind, slice, false)
+			continue
+		}
+		fail, err := ruleCondition(name, param, v, kind, slice)
+		if err != nil {
+			return check, err
+		}
+		check.Rules = append(check.Rules, validationRule{Tag: name, Fail: fail})
+	}
+	return check, nil
+}
+
+// emptyCondition returns the Go condition holding when v, of kind, has no
+// value for the validator (nil slices, zero values), or has one if not
+// empty.
+func emptyCondition(v, kind string, slice, empty bool) string {
+	op := "!="
+	if empty {
+		op = "=="
+	}
+	switch {
+	case slice:
+		return v + " " + op + " nil"
+	case kind == "string":
+		return v + " " + op + ` ""`
+	case kind == "bool" && empty:
+		return "!" + v
+	case kind == "bool":
+		return v
+	}
+	return v + " " + op + " 0"
+}
+
+// comparisons maps the comparison rules to the operator failing them.
+var comparisons = map[string]string{
+	"min": "<", "max": ">", "len": "!=",
+	"gt": "<=", "gte": "<", "lt": ">=", "lte": ">",
+}
+
+// ruleCondition returns the Go condition holding when v, of kind, fails
+// rule with param. Strings are measured in runes and slices in elements;
+// parameters are parsed as the validator parses them.
+func ruleCondition(rule, param, v, kind string, slice bool) (string, error) {
+	unsupported := fmt.Errorf("unsupported rule %s", rule)
+	if rule == "required" && param == "" {
+		return emptyCondition(v, kind, slice, true), nil
+	}
+	if rule == "oneof" && !slice {
+		return oneOfCondition(param, v, kind)
+	}
+	op, ok := comparisons[rule]
+	if !ok || (kind == "bool" && !slice) {
+		return "", unsupported
+	}
+	invalid := fmt.Errorf("invalid parameter %s=%s", rule, param)
+	switch {
+	case slice || kind == "string" || kind == "int" || kind == "duration":
+		n, err := strconv.ParseInt(param, 0, 64)
+		if kind == "duration" && !slice {
+			if d, derr := time.ParseDuration(param); derr == nil {
+				n, err = int64(d), nil
+			}
+		}
+		if err != nil {
+			return "", invalid
+		}
+		x := "int64(" + v + ")"
+		if slice {
+			x = "int64(len(" + v + "))"
+		} else if kind == "string" {
+			x = "int64(utf8.RuneCountInString(" + v + "))"
+		}
+		return x + " " + op + " " + strconv.FormatInt(n, 10), nil
+	case kind == "uint":
+		n, err := strconv.ParseUint(param, 0, 64)
+		if err != nil {
+			return "", invalid
+		}
+		return "uint64(" + v + ") " + op + " " + strconv.FormatUint(n, 10), nil
+	default:
+		x, err := strconv.ParseFloat(param, 64)
+		if err != nil || math.IsInf(x, 0) || math.IsNaN(x) {
+			return "", invalid
+		}
+		return "float64(" + v + ") " + op + " " + strconv.FormatFloat(x, 'g', -1, 64), nil
+	}
+}
+
+// oneOfCondition returns the Go condition holding when v, of kind, is none
+// of the space separated values of param. Like the validator, integers are
+// compared in their decimal form, so values such as "01" never match.
+func oneOfCondition(param, v, kind string) (string, error) {
+	if strings.Contains(param, "'") {
+		return "", fmt.Errorf("unsupported rule oneof with quoted values")
+	}
+	var conds []string
+	for _, value := range strings.Fields(param) {
+		switch kind {
+		case "string":
+			conds = append(conds, v+" != "+strconv.Quote(value))
+		case "int", "duration":
+			if n, err := strconv.ParseInt(value, 10, 64); err == nil && strconv.FormatInt(n, 10) == value {
+				conds = append(conds, "int64("+v+") != "+value)
+			}
+		case "uint":
+			if n, err := strconv.ParseUint(value, 10, 64); err == nil && strconv.FormatUint(n, 10) == value {
+				conds = append(conds, "uint64("+v+") != "+value)
+			}
+		default:
+			return "", fmt.Errorf("unsupported rule oneof")
+		}
+	}
+	if len(conds) == 0 {
+		return "true", nil
+	}
+	return strings.Join(conds, " && "), nil
+}
+
+// buildEncoder describes the encoder of response type name, reporting
+// false when it has no Body section, or explains why it cannot have one.
+// Bodies must be structs of the package whose named fields have basic
+// kinds, time.Duration, time.Time, or pointers or slices of those.
+func buildEncoder(name string, structs map[string]*ast.StructType, marshalers map[string]bool) (encoderData, bool, error) {
+	expr, ok := sectionTypes(structs[name])["Body"]
+	if !ok {
+		return encoderData{}, false, nil
+	}
+	const section = "Body"
+	st, ok := sectionStruct(expr, structs)
+	if !ok || marshalers[exprString(expr)] {
+		return encoderData{}, false, fmt.Errorf("%s: unsupported type %s", section, exprString(expr))
+	}
+	encoder := encoderData{Type: name}
+	names := map[string]bool{}
+	for _, field := range st.Fields.List {
+		if len(field.Names) == 0 {
+			return encoderData{}, false, fmt.Errorf("%s: embedded structs are not supported", section)
+		}
+		key, opts := encodedName(field)
+		for _, id := range field.Names {
+			// Like gorkson, the first field of a name wins
+			if !id.IsExported() || key == "" || key == "-" || names[key] || opts["writeonly"] {
+				continue
+			}
+			names[key] = true
+			v := "resp.Body." + id.Name
+			guard := omitGuard(v, field.Type, opts["omitempty"], opts["omitzero"])
+			code, err := encodeValue(v, field.Type, guard != "", &encoder)
+			if err != nil {
+				return encoderData{}, false, fmt.Errorf("%s.%s: %w", section, id.Name, err)
+			}
+			encoder.Fields = append(encoder.Fields, encodedField{
+				Key:   strconv.Quote("," + string(gorkson.AppendString(nil, key)) + ":"),
+				Guard: guard,
+				Code:  code,
+			})
+		}
+	}
+	// encoding/json writes map keys, and so gorkson writes fields, sorted
+	sort.Slice(encoder.Fields, func(i, j int) bool { return encoder.Fields[i].Key < encoder.Fields[j].Key })
+	return encoder, true, nil
+}
+
+// encodedName returns the name gorkson encodes field under, from its gork
+// tag or else its json tag, and the options of that tag.
+func encodedName(field *ast.Field) (string, map[string]bool) {
+	tag := fieldTag(field)
+	parts := gorkson.SplitTag(tag)
+	name := strings.TrimSpace(parts[0])
+	if name == "" {
+		parts = gorkson.SplitTag(structTag(field, "json"))
+		if name = strings.TrimSpace(parts[0]); name == "-" {
+			name = ""
+		}
+	}
+	opts := map[string]bool{}
+	for _, part := range parts[1:] {
+		opts[strings.TrimSpace(part)] = true
+	}
+	return name, opts
+}
+
+// encodeValue returns the code appending v, of type expr, to b as gorkson
+// encodes it: slices as arrays, nil slices included, and nil pointers as
+// null unless omitted, when v is only encoded if not nil.
+func encodeValue(v string, expr ast.Expr, omitted bool, encoder *encoderData) (string, error) {
+	switch e := expr.(type) {
+	case *ast.ArrayType:
+		if e.Len != nil {
+			break
+		}
+		elem, err := encodeScalar("e", exprString(e.Elt), encoder)
+		if err != nil {
+			return "", err
+		}
+		return "b = append(b, '[')\nfor i, e := range " + v + " {\nif i > 0 {\nb = append(b, ',')\n}\n" + elem + "\n}\nb = append(b, ']')", nil
+	case *ast.StarExpr:
+		if exprString(e.X) == "time.Time" {
+			break
+		}
+		elem, err := encodeScalar("(*"+v+")", exprString(e.X), encoder)
+		if err != nil || omitted {
+			return elem, err
+		}
+		return "if " + v + " == nil {\nb = append(b, \"null\"...)\n} else {\n" + elem + "\n}", nil
+	default:
+		return encodeScalar(v, exprString(expr), encoder)
+	}
+	return "", fmt.Errorf("unsupported type %s", exprString(expr))
+}
+
+// encodeScalar returns the code appending v, of the type named typ, to b.
+func encodeScalar(v, typ string, encoder *encoderData) (string, error) {
+	if typ == "time.Time" {
+		encoder.Err = true
+		return "b = append(b, '\"')\nif b, err = " + v + ".AppendText(b); err != nil {\nreturn nil, err\n}\nb = append(b, '\"')", nil
+	}
+	switch valueKind(typ) {
+	case "string":
+		return "b = gorkson.AppendString(b, " + v + ")", nil
+	case "int":
+		return "b = strconv.AppendInt(b, int64(" + v + "), 10)", nil
+	case "uint":
+		return "b = strconv.AppendUint(b, uint64(" + v + "), 10)", nil
+	case "bool":
+		return "b = strconv.AppendBool(b, " + v + ")", nil
+	case "float":
+		encoder.Err = true
+		return "if b, err = gorkson.AppendFloat(b, float64(" + v + "), " + strconv.Itoa(typeBits(typ)) + "); err != nil {\nreturn nil, err\n}", nil
+	case "duration":
+		return "b = gorkson.AppendString(b, " + v + ".String())", nil
+	}
+	return "", fmt.Errorf("unsupported type %s", typ)
+}
+
+// omitGuard returns the condition v, of type expr, is encoded under with
+// the omitempty and omitzero options, or "" when it always is.
+func omitGuard(v string, expr ast.Expr, omitEmpty, omitZero bool) string {
+	var conds []string
+	add := func(cond string) {
+		if cond != "" && (len(conds) == 0 || conds[0] != cond) {
+			conds = append(conds, cond)
+		}
+	}
+	switch e := expr.(type) {
+	case *ast.ArrayType:
+		if omitEmpty {
+			add("len(" + v + ") != 0")
+		}
+		if omitZero {
+			add(v + " != nil")
+		}
+	case *ast.StarExpr:
+		if omitEmpty || omitZero {
+			add(v + " != nil")
+		}
+	default:
+		typ := exprString(e)
+		if typ == "time.Time" {
+			// Structs are never empty
+			if omitZero {
+				add("!" + v + ".IsZero()")
+			}
+			break
+		}
+		if omitEmpty || omitZero {
+			add(emptyCondition(v, valueKind(typ), false, false))
+		}
+	}
+	return strings.Join(conds, " && ")
+}
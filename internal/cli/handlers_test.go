@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const handlersSource = `package shop
+
+import (
+	"context"
+	"time"
+)
+
+type ListOrdersRequest struct {
+	Path struct {
+		ShopID uint16 ` + "`gork:\"shop_id\"`" + `
+	}
+	Query struct {
+		Limit  int8          ` + "`gork:\"limit,alias=per_page|size,default=020\" validate:\"omitempty,min=1,max=100\"`" + `
+		Status []string      ` + "`gork:\"status,default='open,paid'\" validate:\"max=3\"`" + `
+		IDs    []int64       ` + "`gork:\"ids\"`" + `
+		Wait   time.Duration ` + "`gork:\"wait,default=1m\" validate:\"lte=5m\"`" + `
+		Sort   string        ` + "`gork:\"sort\" validate:\"oneof=asc desc\"`" + `
+		Ignore string
+	}
+	Headers struct {
+		PIN       int      ` + "`gork:\"X-Pin,sensitive\"`" + `
+		Languages []string ` + "`gork:\"Accept-Language\"`" + `
+	}
+	Cookies struct {
+		Session string ` + "`gork:\"session\" validate:\"required,len=32\"`" + `
+	}
+}
+
+type CreateOrderRequest struct {
+	Body struct {
+		Total float64 ` + "`gork:\"total\" validate:\"gt=0\"`" + `
+		Email string  ` + "`gork:\"email\" validate:\"required,email\"`" + `
+	}
+}
+
+type Order struct {
+	Body struct {
+		ID      int64     ` + "`gork:\"id\"`" + `
+		Note    *string   ` + "`gork:\"note,omitempty\"`" + `
+		Tags    []string  ` + "`gork:\"tags\"`" + `
+		Created time.Time ` + "`gork:\"created_at\"`" + `
+		Secret  string    ` + "`gork:\"secret,writeonly\"`" + `
+		Total   float32   ` + "`json:\"total\"`" + `
+		Wait    time.Duration
+	}
+}
+
+type Filters struct {
+	Body []Filter
+}
+
+type Filter struct{ Min int }
+
+type SearchRequest struct {
+	Query struct {
+		Filter Filter ` + "`gork:\"filter\"`" + `
+	}
+}
+
+type Unused struct {
+	Query struct {
+		Q string ` + "`gork:\"q\"`" + `
+	}
+}
+
+func ListOrders(ctx context.Context, req ListOrdersRequest) (*Order, error) { return nil, nil }
+
+func Search(ctx context.Context, req SearchRequest) (Filters, error) { return Filters{}, nil }
+
+type Handlers struct{}
+
+func (Handlers) CreateOrder(ctx context.Context, req CreateOrderRequest) (*struct{}, error) {
+	return nil, nil
+}
+`
+
+func TestGenerateHandlerBinders(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "orders.go"), []byte(handlersSource), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var skipped []string
+	if err := GenerateHandlerBinderFiles(&HandlersConfig{Dirs: []string{dir}}, func(s string) { skipped = append(skipped, s) }); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, handlersOutputFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := string(data)
+	for _, want := range []string{
+		"// Code generated by gork handlers generate. DO NOT EDIT.",
+		`"time"`,
+		"func (req *CreateOrderRequest) BindRequest(r *http.Request, params api.GenericParameterAdapter[*http.Request], bindBody func(body any) error) error {",
+		"if err := bindBody(&req.Body); err != nil {",
+		"func (req *ListOrdersRequest) BindRequest(",
+		`x, err := strconv.ParseUint(v, 10, 64)`,
+		"req.Path.ShopID = uint16(x)",
+		`v, ok = params.Query(r, "per_page")`,
+		`v, ok = params.Query(r, "size")`,
+		"req.Query.Limit = 20",
+		`req.Query.Status = []string{"open", "paid"}`,
+		`if vs := multi.QueryValues(r, "ids"); values == nil && len(vs) > 1 {`,
+		`"failed to parse Query section: failed to set query parameter ids: " + "element " + strconv.Itoa(i) + ": invalid integer value: " + v`,
+		"req.Query.Wait = time.Duration(60000000000)",
+		`"failed to parse Headers section: failed to set header X-Pin: invalid value"`,
+		`if vs := multi.HeaderValues(r, "Accept-Language"); values == nil && len(vs) > 1 {`,
+		"func (req *ListOrdersRequest) ValidateSection(section string, errs map[string][]string) bool {",
+		`if req.Query.Limit != 0 {`,
+		`if int64(req.Query.Limit) < 1 {`,
+		`} else if int64(req.Query.Limit) > 100 {`,
+		`errs["query.limit"] = append(errs["query.limit"], "max")`,
+		`if int64(len(req.Query.Status)) > 3 {`,
+		`if int64(req.Query.Wait) > 300000000000 {`,
+		`if req.Query.Sort != "asc" && req.Query.Sort != "desc" {`,
+		`case "Path":`,
+		`case "Headers":`,
+		`if req.Cookies.Session == "" {`,
+		`} else if int64(utf8.RuneCountInString(req.Cookies.Session)) != 32 {`,
+		"func (resp Order) EncodeBody() ([]byte, error) {",
+		`b = append(b, ",\"created_at\":"...)`,
+		`if b, err = resp.Body.Created.AppendText(b); err != nil {`,
+		"if resp.Body.Note != nil {\n\t\tb = append(b, \",\\\"note\\\":\"...)\n\t\tb = gorkson.AppendString(b, (*resp.Body.Note))",
+		`if b, err = gorkson.AppendFloat(b, float64(resp.Body.Total), 32); err != nil {`,
+		`"github.com/gork-labs/gork/pkg/gorkson"`,
+		`"unicode/utf8"`,
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("expected generated binders to contain %q:\n%s", want, src)
+		}
+	}
+	for _, unwanted := range []string{"SearchRequest) BindRequest", "Unused", "Ignore", "CreateOrderRequest) ValidateSection", "secret", "Wait.String", "Filters"} {
+		if strings.Contains(src, unwanted) {
+			t.Errorf("expected no %q in generated binders:\n%s", unwanted, src)
+		}
+	}
+	wantSkipped := []string{
+		"CreateOrderRequest: Body validation: Email: unsupported rule email",
+		"SearchRequest: Query.Filter: unsupported type Filter",
+		"Filters: Body: unsupported type []Filter",
+	}
+	if strings.Join(skipped, "\n") != strings.Join(wantSkipped, "\n") {
+		t.Errorf("expected skipped %q, got %q", wantSkipped, skipped)
+	}
+}
+
+func TestGenerateHandlerBindersInvalidDefault(t *testing.T) {
+	dir := t.TempDir()
+	src := "package shop\n\nimport \"context\"\n\ntype Req struct {\n\tQuery struct {\n\t\tLimit int8 `gork:\"limit,default=300\"`\n\t}\n}\n\nfunc H(ctx context.Context, req Req) error { return nil }\n"
+	if err := os.WriteFile(filepath.Join(dir, "h.go"), []byte(src), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	binders, err := GenerateHandlerBinders(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if binders.Source != nil || len(binders.Skipped) != 1 || !strings.Contains(binders.Skipped[0], `invalid default "300"`) {
+		t.Errorf("expected the request type to be skipped for its default, got %v", binders.Skipped)
+	}
+}
+
+func TestHandlersCommand(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "orders.go"), []byte(handlersSource), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cmd := newHandlersGenerateCommand()
+	var stderr bytes.Buffer
+	cmd.SetErr(&stderr)
+	cmd.SetArgs([]string{"--output", "binders.go", dir})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "binders.go")); err != nil {
+		t.Errorf("expected binders.go to be written: %v", err)
+	}
+	if !strings.Contains(stderr.String(), "skipping SearchRequest") {
+		t.Errorf("expected skipped request types on stderr, got %q", stderr.String())
+	}
+}
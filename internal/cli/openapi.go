@@ -9,6 +9,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 
 	"github.com/gork-labs/gork/pkg/api"
 	"github.com/spf13/cobra"
@@ -21,6 +22,8 @@ func newOpenAPICommand() *cobra.Command {
 		Short: "OpenAPI related utilities",
 	}
 	cmd.AddCommand(newGenerateCommand())
+	cmd.AddCommand(newConformCommand())
+	cmd.AddCommand(newClientCommand())
 	return cmd
 }
 
@@ -30,7 +33,8 @@ func newGenerateCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "generate",
 		Short: "Generate an OpenAPI specification",
-		RunE: func(_ *cobra.Command, _ []string) error {
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			config.Report = cmd.ErrOrStderr()
 			return GenerateSpec(&config)
 		},
 	}
@@ -38,45 +42,302 @@ func newGenerateCommand() *cobra.Command {
 	cmd.Flags().StringVar(&config.BuildPath, "build", "", "Path to main package to build with '-tags openapi'")
 	cmd.Flags().StringVar(&config.SourcePath, "source", ".", "Directory containing Go source code for documentation extraction")
 	cmd.Flags().StringVar(&config.OutputPath, "output", "openapi.json", "Path to output file or '-' for stdout")
+	cmd.Flags().StringVar(&config.InternalOutputPath, "internal-output", "", "Also write a spec including routes registered with api.WithInternal() to this file")
+	cmd.Flags().StringVar(&config.SplitBy, "split-by", "", "Write one spec per group of operations, by 'tag' or path 'prefix', sharing a components file named after --output")
 	cmd.Flags().StringVar(&config.Title, "title", "API", "API title")
 	cmd.Flags().StringVar(&config.Version, "version", "0.1.0", "API version")
+	cmd.Flags().StringVar(&config.SpecVersion, "spec-version", "3.1", "OpenAPI version of the spec: 3.1, or 3.0 for tooling that cannot read 3.1")
 	cmd.Flags().StringVar(&config.ConfigPath, "config", "", "Path to .gork.yml config file")
+	cmd.Flags().StringVar(&config.ExplainPath, "explain", "", "Write a report explaining component names, constraints, inline schemas and missing docs to this file or '-' for stdout")
+	cmd.Flags().StringVar(&config.ExamplesPath, "examples", "", "Publish request/response examples captured by apitest from this file")
+	cmd.Flags().IntVar(&config.MaxErrors, "max-errors", 50, "Maximum number of problems listed in the report (0 lists all)")
+	cmd.Flags().StringVar(&config.FailOn, "fail-on", "error", "Exit non-zero when a problem of at least this severity is found: info, warning, error or none")
 
 	return cmd
 }
 
 // GenerateConfig holds configuration for OpenAPI generation.
 type GenerateConfig struct {
-	BuildPath  string
-	SourcePath string
-	OutputPath string
-	Title      string
-	Version    string
-	ConfigPath string
+	BuildPath   string
+	SourcePath  string
+	OutputPath  string
+	Title       string
+	Version     string
+	ConfigPath  string
+	ExplainPath string
+	// InternalOutputPath, when set, receives a second spec that also
+	// documents the routes registered with api.WithInternal().
+	InternalOutputPath string
+	// SplitBy, when set, writes one spec per tag or path prefix (see
+	// api.ParseSplitMode) next to OutputPath, with the components in a
+	// shared file.
+	SplitBy string
+	// SpecVersion is the OpenAPI version written: "3.1" (the default) or
+	// "3.0" (see api.ParseSpecVersion).
+	SpecVersion string
+	// ExamplesPath is a file of examples captured in tests with the apitest
+	// package (GORK_RECORD_EXAMPLES=1 go test ./...).
+	ExamplesPath string
+
+	// MaxErrors limits the problems listed in the report; 0 lists all.
+	MaxErrors int
+	// FailOn is the lowest severity that fails generation (see
+	// ParseSeverity). Empty means "error".
+	FailOn string
+	// Report receives the grouped problem report. Defaults to os.Stderr.
+	Report io.Writer
+
+	// WebhookProviders documents x-webhook-provider for webhook paths whose
+	// handlers do not report provider metadata. Loaded from the config file.
+	WebhookProviders map[string]api.WebhookProviderInfo
+
+	// Spec metadata loaded from the config file. Set values replace the
+	// ones of the generated spec.
+	Servers        []api.Server
+	Contact        *api.Contact
+	License        *api.License
+	TermsOfService string
+	ExternalDocs   *api.ExternalDocs
 }
 
 // GenerateSpec generates an OpenAPI specification based on the provided configuration.
+// When InternalOutputPath is set, the built binary is run a second time with
+// GORK_INTERNAL set to write the internal spec as well.
 func GenerateSpec(config *GenerateConfig) error {
+	if err := generateSpec(config, nil); err != nil {
+		return err
+	}
+	if config.InternalOutputPath == "" {
+		return nil
+	}
+
+	internal := *config
+	internal.OutputPath = config.InternalOutputPath
+	internal.ExplainPath = ""
+	if err := generateSpec(&internal, []string{"GORK_INTERNAL=1"}); err != nil {
+		return fmt.Errorf("internal spec: %w", err)
+	}
+	return nil
+}
+
+// generateSpec generates the spec described by config, running the built
+// binary with the variables of env added to its environment.
+func generateSpec(config *GenerateConfig, env []string) error {
 	if err := loadConfigFile(config); err != nil {
 		return err
 	}
 
-	spec, err := generateBaseSpec(config)
+	specVersion := api.OpenAPIVersion31
+	if config.SpecVersion != "" {
+		var err error
+		if specVersion, err = api.ParseSpecVersion(config.SpecVersion); err != nil {
+			return err
+		}
+	}
+
+	if config.SplitBy != "" {
+		if _, err := api.ParseSplitMode(config.SplitBy); err != nil {
+			return err
+		}
+		if config.OutputPath == "-" {
+			return fmt.Errorf("--split-by writes several files and cannot write to stdout")
+		}
+	}
+
+	threshold := SeverityError
+	if config.FailOn != "" {
+		var err error
+		if threshold, err = ParseSeverity(config.FailOn); err != nil {
+			return err
+		}
+	}
+
+	// The variables the built binary runs with are never set in this
+	// process, so concurrent generations do not see each other's.
+	rawExplain, err := startExplain(config)
+	if err != nil {
+		return err
+	}
+	if rawExplain != "" {
+		env = append(env, "GORK_EXPLAIN="+rawExplain)
+		defer func() { _ = os.Remove(rawExplain) }()
+	}
+
+	rawErrors, err := startGenerationErrors(config)
+	if err != nil {
+		return err
+	}
+	if rawErrors != "" {
+		env = append(env, "GORK_ERRORS="+rawErrors)
+		defer func() { _ = os.Remove(rawErrors) }()
+	}
+
+	rawEnums, err := startEnums(config)
+	if err != nil {
+		return err
+	}
+	if rawEnums != "" {
+		env = append(env, "GORK_ENUMS="+rawEnums)
+		defer func() { _ = os.Remove(rawEnums) }()
+	}
+
+	spec, err := generateBaseSpec(config, env)
+	if err != nil {
+		return err
+	}
+
+	extractor, err := enrichWithDocs(spec, config.SourcePath)
+	if err != nil {
+		return err
+	}
+
+	diags := &Diagnostics{}
+	if extractor != nil {
+		diags.addParseErrors(extractor.ParseErrors())
+	}
+	genErrs, err := readGenerationErrors(rawErrors)
 	if err != nil {
 		return err
 	}
+	diags.addGenerationErrors(genErrs, extractor)
 
-	if err := enrichWithDocs(spec, config.SourcePath); err != nil {
+	applySpecMetadata(spec, config)
+	api.ApplyWebhookProviders(spec, config.WebhookProviders)
+	if config.ExamplesPath != "" {
+		examples, err := api.LoadCapturedExamples(config.ExamplesPath)
+		if err != nil {
+			return fmt.Errorf("load examples: %w", err)
+		}
+		api.ApplyCapturedExamples(spec, examples)
+	}
+	if specVersion == api.OpenAPIVersion30 {
+		api.ConvertToOpenAPI30(spec)
+	}
+
+	// The report is written before validation so that it is available
+	// when debugging a spec the validator rejects.
+	if err := writeExplainReport(spec, rawExplain, config.ExplainPath); err != nil {
 		return err
 	}
 
-	if err := validateSpec(spec); err != nil {
+	if err := validateSpecInto(spec, defaultValidatorClient, diags); err != nil {
 		return fmt.Errorf("spec validation failed: %w", err)
 	}
 
+	report := config.Report
+	if report == nil {
+		report = os.Stderr
+	}
+	_ = diags.WriteReport(report, config.MaxErrors)
+	if err := diags.Err(threshold); err != nil {
+		return err
+	}
+
 	return writeOutput(spec, config)
 }
 
+// startGenerationErrors prepares the file the built binary writes the routes
+// it could not generate to, passed to it as GORK_ERRORS, which makes the
+// binary collect them instead of panicking on the first. It returns "" when
+// no binary is built.
+func startGenerationErrors(config *GenerateConfig) (string, error) {
+	if config.BuildPath == "" {
+		return "", nil
+	}
+	f, err := os.CreateTemp("", "gork-errors-*.json")
+	if err != nil {
+		return "", fmt.Errorf("create generation errors file: %w", err)
+	}
+	_ = f.Close()
+	return f.Name(), nil
+}
+
+// startEnums writes the enums detected in the source directory (typed
+// constant blocks) to a file, passed to the built binary as GORK_ENUMS so
+// that it documents their values. It returns "" when no binary is
+// built or no source directory is given.
+func startEnums(config *GenerateConfig) (string, error) {
+	if config.BuildPath == "" || config.SourcePath == "" {
+		return "", nil
+	}
+	extractor := api.NewDocExtractor()
+	// Parse errors are reported once the spec is enriched with the docs.
+	_ = extractor.ParseDirectory(config.SourcePath)
+	data, err := json.Marshal(extractor.Enums())
+	if err != nil {
+		return "", err
+	}
+	f, err := os.CreateTemp("", "gork-enums-*.json")
+	if err != nil {
+		return "", fmt.Errorf("create enums file: %w", err)
+	}
+	_, err = f.Write(data)
+	_ = f.Close()
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// readGenerationErrors reads the routes recorded by the built binary. An
+// empty file, written by binaries predating error-collect mode, holds none.
+func readGenerationErrors(rawPath string) (*api.GenerationError, error) {
+	if rawPath == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(filepath.Clean(rawPath))
+	if err != nil || len(data) == 0 {
+		return nil, nil
+	}
+	genErrs := &api.GenerationError{}
+	if err := json.Unmarshal(data, genErrs); err != nil {
+		return nil, fmt.Errorf("parse generation errors: %w", err)
+	}
+	return genErrs, nil
+}
+
+// startExplain prepares the file the built binary writes its explain report
+// to, passed to it as GORK_EXPLAIN. It returns "" when no report was
+// requested.
+func startExplain(config *GenerateConfig) (string, error) {
+	if config.ExplainPath == "" {
+		return "", nil
+	}
+	f, err := os.CreateTemp("", "gork-explain-*.json")
+	if err != nil {
+		return "", fmt.Errorf("create explain file: %w", err)
+	}
+	_ = f.Close()
+	return f.Name(), nil
+}
+
+// writeExplainReport combines the generator decisions recorded by the built
+// binary with the doc comments still missing after enrichment and writes the
+// report as text.
+func writeExplainReport(spec *api.OpenAPISpec, rawPath, outPath string) error {
+	if outPath == "" {
+		return nil
+	}
+	report := &api.ExplainReport{}
+	if data, err := os.ReadFile(filepath.Clean(rawPath)); err == nil && len(data) > 0 {
+		if err := json.Unmarshal(data, report); err != nil {
+			return fmt.Errorf("parse explain report: %w", err)
+		}
+	}
+	report.AddMissingDocs(spec)
+
+	if outPath == "-" {
+		return report.WriteText(os.Stdout)
+	}
+	f, err := os.Create(filepath.Clean(outPath))
+	if err != nil {
+		return fmt.Errorf("write explain report: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	return report.WriteText(f)
+}
+
 func loadConfigFile(config *GenerateConfig) error {
 	if config.ConfigPath == "" {
 		return nil
@@ -89,11 +350,24 @@ func loadConfigFile(config *GenerateConfig) error {
 
 	var cfg struct {
 		OpenAPI struct {
-			Build   string `yaml:"build"`
-			Source  string `yaml:"source"`
-			Output  string `yaml:"output"`
-			Title   string `yaml:"title"`
-			Version string `yaml:"version"`
+			Build       string `yaml:"build"`
+			Source      string `yaml:"source"`
+			Output      string `yaml:"output"`
+			Title       string `yaml:"title"`
+			Version     string `yaml:"version"`
+			SpecVersion string `yaml:"specVersion"`
+
+			Servers        []api.Server      `yaml:"servers"`
+			Contact        *api.Contact      `yaml:"contact"`
+			License        *api.License      `yaml:"license"`
+			TermsOfService string            `yaml:"termsOfService"`
+			ExternalDocs   *api.ExternalDocs `yaml:"externalDocs"`
+
+			WebhookProviders map[string]struct {
+				Name    string `yaml:"name"`
+				Website string `yaml:"website"`
+				Docs    string `yaml:"docs"`
+			} `yaml:"webhookProviders"`
 		} `yaml:"openapi"`
 	}
 
@@ -117,27 +391,65 @@ func loadConfigFile(config *GenerateConfig) error {
 	if config.Version == "0.1.0" && cfg.OpenAPI.Version != "" {
 		config.Version = cfg.OpenAPI.Version
 	}
+	if (config.SpecVersion == "" || config.SpecVersion == "3.1") && cfg.OpenAPI.SpecVersion != "" {
+		config.SpecVersion = cfg.OpenAPI.SpecVersion
+	}
+	config.Servers = cfg.OpenAPI.Servers
+	config.Contact = cfg.OpenAPI.Contact
+	config.License = cfg.OpenAPI.License
+	config.TermsOfService = cfg.OpenAPI.TermsOfService
+	config.ExternalDocs = cfg.OpenAPI.ExternalDocs
+	for path, p := range cfg.OpenAPI.WebhookProviders {
+		if config.WebhookProviders == nil {
+			config.WebhookProviders = map[string]api.WebhookProviderInfo{}
+		}
+		config.WebhookProviders[path] = api.WebhookProviderInfo{Name: p.Name, Website: p.Website, DocsURL: p.Docs}
+	}
 
 	return nil
 }
 
-func generateBaseSpec(config *GenerateConfig) (*api.OpenAPISpec, error) {
+// applySpecMetadata sets the servers, contact, license, terms of service and
+// external docs given in the config file.
+func applySpecMetadata(spec *api.OpenAPISpec, config *GenerateConfig) {
+	if len(config.Servers) > 0 {
+		spec.Servers = config.Servers
+	}
+	if config.Contact != nil {
+		spec.Info.Contact = config.Contact
+	}
+	if config.License != nil {
+		spec.Info.License = config.License
+	}
+	if config.TermsOfService != "" {
+		spec.Info.TermsOfService = config.TermsOfService
+	}
+	if config.ExternalDocs != nil {
+		spec.ExternalDocs = config.ExternalDocs
+	}
+}
+
+// generateBaseSpec extracts the spec from the binary built from
+// config.BuildPath, run with the variables of env added to its environment.
+func generateBaseSpec(config *GenerateConfig, env []string) (*api.OpenAPISpec, error) {
 	if config.BuildPath == "" {
 		return &api.OpenAPISpec{
-			OpenAPI:    "3.1.0",
+			OpenAPI:    api.OpenAPIVersion31,
 			Info:       api.Info{Title: config.Title, Version: config.Version},
 			Paths:      map[string]*api.PathItem{},
 			Components: &api.Components{Schemas: map[string]*api.Schema{}},
 		}, nil
 	}
-	return buildAndExtract(config.BuildPath)
+	return buildAndExtract(config.BuildPath, env...)
 }
 
 // BuildRunner allows dependency injection for testing.
 type BuildRunner interface {
 	CreateTemp(pattern string) (*os.File, error)
 	BuildCommand(outputPath, buildPath string) error
-	RunCommand(exePath string) ([]byte, error)
+	// RunCommand runs the built binary with the "KEY=value" variables of
+	// env added to the environment.
+	RunCommand(exePath string, env []string) ([]byte, error)
 }
 
 // DefaultBuildRunner implements BuildRunner using real OS commands.
@@ -157,10 +469,10 @@ func (r *DefaultBuildRunner) BuildCommand(outputPath, buildPath string) error {
 }
 
 // RunCommand executes the built binary and returns its output.
-func (r *DefaultBuildRunner) RunCommand(exePath string) ([]byte, error) {
+func (r *DefaultBuildRunner) RunCommand(exePath string, env []string) ([]byte, error) {
 	var out bytes.Buffer
 	cmd := exec.Command(exePath) // #nosec G204
-	cmd.Env = append(os.Environ(), "GORK_EXPORT=1")
+	cmd.Env = append(append(os.Environ(), "GORK_EXPORT=1"), env...)
 	cmd.Stdout = &out
 	cmd.Stderr = os.Stderr
 	err := cmd.Run()
@@ -169,25 +481,14 @@ func (r *DefaultBuildRunner) RunCommand(exePath string) ([]byte, error) {
 
 var defaultBuildRunner BuildRunner = &DefaultBuildRunner{}
 
-func buildAndExtract(buildPath string) (*api.OpenAPISpec, error) {
-	return buildAndExtractWithRunner(buildPath, defaultBuildRunner)
+func buildAndExtract(buildPath string, env ...string) (*api.OpenAPISpec, error) {
+	return buildAndExtractWithRunner(buildPath, defaultBuildRunner, env...)
 }
 
-func buildAndExtractWithRunner(buildPath string, runner BuildRunner) (*api.OpenAPISpec, error) {
-	tmpExe, err := runner.CreateTemp("gork-build-*")
+func buildAndExtractWithRunner(buildPath string, runner BuildRunner, env ...string) (*api.OpenAPISpec, error) {
+	output, err := buildAndRunWithRunner(buildPath, runner, env)
 	if err != nil {
-		return nil, fmt.Errorf("create temp exe: %w", err)
-	}
-	_ = tmpExe.Close()
-	defer func() { _ = os.Remove(tmpExe.Name()) }()
-
-	if buildErr := runner.BuildCommand(tmpExe.Name(), buildPath); buildErr != nil {
-		return nil, fmt.Errorf("build failed: %w", buildErr)
-	}
-
-	output, err := runner.RunCommand(tmpExe.Name())
-	if err != nil {
-		return nil, fmt.Errorf("run generated binary: %w", err)
+		return nil, err
 	}
 
 	var spec api.OpenAPISpec
@@ -197,16 +498,40 @@ func buildAndExtractWithRunner(buildPath string, runner BuildRunner) (*api.OpenA
 	return &spec, nil
 }
 
-func enrichWithDocs(spec *api.OpenAPISpec, sourcePath string) error {
+// enrichWithDocs adds the doc comments found under sourcePath to the spec and
+// returns the extractor, or nil when no source path was given.
+func enrichWithDocs(spec *api.OpenAPISpec, sourcePath string) (*api.DocExtractor, error) {
 	if sourcePath == "" {
-		return nil
+		return nil, nil
 	}
 	extractor := api.NewDocExtractor()
 	if err := extractor.ParseDirectory(sourcePath); err != nil {
-		return fmt.Errorf("failed to parse source: %w", err)
+		return nil, fmt.Errorf("failed to parse source: %w", err)
 	}
 	api.EnhanceOpenAPISpecWithDocs(spec, extractor)
-	return nil
+	return extractor, nil
+}
+
+// buildAndRunWithRunner builds the application with the openapi tag and
+// returns what it writes to stdout in export mode, run with the variables
+// of env.
+func buildAndRunWithRunner(buildPath string, runner BuildRunner, env []string) ([]byte, error) {
+	tmpExe, err := runner.CreateTemp("gork-build-*")
+	if err != nil {
+		return nil, fmt.Errorf("create temp exe: %w", err)
+	}
+	_ = tmpExe.Close()
+	defer func() { _ = os.Remove(tmpExe.Name()) }()
+
+	if buildErr := runner.BuildCommand(tmpExe.Name(), buildPath); buildErr != nil {
+		return nil, fmt.Errorf("build failed: %w", buildErr)
+	}
+
+	output, err := runner.RunCommand(tmpExe.Name(), env)
+	if err != nil {
+		return nil, fmt.Errorf("run generated binary: %w", err)
+	}
+	return output, nil
 }
 
 // HTTPClient interface for dependency injection.
@@ -266,6 +591,16 @@ func validateSpec(spec *api.OpenAPISpec) error {
 }
 
 func validateSpecWithClient(spec *api.OpenAPISpec, client ValidatorClient) error {
+	diags := &Diagnostics{}
+	if err := validateSpecInto(spec, client, diags); err != nil {
+		return err
+	}
+	return validatorError(diags)
+}
+
+// validateSpecInto sends the spec to the validator and records every message
+// it returns. Only failures to reach the validator are returned as errors.
+func validateSpecInto(spec *api.OpenAPISpec, client ValidatorClient, diags *Diagnostics) error {
 	data, err := client.MarshalSpec(spec)
 	if err != nil {
 		return fmt.Errorf("marshal spec: %w", err)
@@ -276,10 +611,34 @@ func validateSpecWithClient(spec *api.OpenAPISpec, client ValidatorClient) error
 		return err
 	}
 
-	return parseValidatorResponse(body, statusCode)
+	return addValidatorResponse(diags, body, statusCode)
 }
 
 func parseValidatorResponse(body []byte, statusCode int) error {
+	diags := &Diagnostics{}
+	if err := addValidatorResponse(diags, body, statusCode); err != nil {
+		return err
+	}
+	return validatorError(diags)
+}
+
+// validatorError joins the error-level validator messages.
+func validatorError(diags *Diagnostics) error {
+	var messages []string
+	for _, diag := range diags.Items() {
+		if diag.Severity == SeverityError {
+			messages = append(messages, diag.Subject+": "+diag.Message)
+		}
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+	return fmt.Errorf("swagger validator errors: %s", strings.Join(messages, "; "))
+}
+
+// addValidatorResponse records the messages of a validator response: general
+// messages under "spec" and schema messages under the offending pointer.
+func addValidatorResponse(diags *Diagnostics, body []byte, statusCode int) error {
 	if statusCode != http.StatusOK {
 		return fmt.Errorf("validator returned status %d: %s", statusCode, string(body))
 	}
@@ -290,29 +649,47 @@ func parseValidatorResponse(body []byte, statusCode int) error {
 	}
 
 	if trimmed[0] == '{' {
+		type validatorMessage struct {
+			Level   string `json:"level"`
+			Message string `json:"message"`
+			Schema  struct {
+				Pointer string `json:"pointer"`
+			} `json:"schema"`
+		}
 		var result struct {
-			Messages []struct {
-				Level   string `json:"level"`
-				Message string `json:"message"`
-			} `json:"messages"`
+			Messages                 []validatorMessage `json:"messages"`
+			SchemaValidationMessages []validatorMessage `json:"schemaValidationMessages"`
 		}
 		if err := json.Unmarshal(trimmed, &result); err == nil {
-			for _, m := range result.Messages {
-				if m.Level == "error" {
-					return fmt.Errorf("swagger validator errors: %s", string(body))
+			for _, m := range append(result.Messages, result.SchemaValidationMessages...) {
+				subject := "spec"
+				if m.Schema.Pointer != "" {
+					subject = "schema " + m.Schema.Pointer
 				}
+				diags.Add(Diagnostic{Severity: validatorSeverity(m.Level), Stage: stageValidate, Subject: subject, Message: m.Message})
 			}
 			return nil
 		}
 	}
 
 	if bytes.Contains(bytes.ToLower(trimmed), []byte("error")) && !bytes.Contains(trimmed, []byte("schemaValidationMessages: null")) {
-		return fmt.Errorf("swagger validator returned errors: %s", string(body))
+		diags.Add(Diagnostic{Severity: SeverityError, Stage: stageValidate, Subject: "spec", Message: string(trimmed)})
 	}
 
 	return nil
 }
 
+func validatorSeverity(level string) Severity {
+	switch strings.ToLower(level) {
+	case "error", "fatal":
+		return SeverityError
+	case "warning", "warn":
+		return SeverityWarning
+	default:
+		return SeverityInfo
+	}
+}
+
 // FileSystem interface for dependency injection.
 type FileSystem interface {
 	Stat(name string) (os.FileInfo, error)
@@ -352,9 +729,68 @@ func getFormatFromPath(path string) string {
 }
 
 func writeOutput(spec *api.OpenAPISpec, config *GenerateConfig) error {
+	if config.SplitBy != "" {
+		return writeSplitOutput(spec, config)
+	}
 	return writeOutputWithFS(spec, config, defaultFileSystem)
 }
 
+// writeSplitOutput writes the documents of api.SplitSpec next to the output
+// path: openapi.json becomes openapi.components.json and one
+// openapi.<group>.json per group.
+func writeSplitOutput(spec *api.OpenAPISpec, config *GenerateConfig) error {
+	mode, err := api.ParseSplitMode(config.SplitBy)
+	if err != nil {
+		return err
+	}
+	componentsPath := splitOutputPath(config.OutputPath, "components")
+	groups, components, err := api.SplitSpec(spec, mode, filepath.Base(componentsPath))
+	if err != nil {
+		return err
+	}
+
+	docs := map[string]*api.OpenAPISpec{componentsPath: components}
+	for name, doc := range groups {
+		path := splitOutputPath(config.OutputPath, fileNamePart(name))
+		if path == componentsPath || docs[path] != nil {
+			return fmt.Errorf("split group %q clashes with another output file %s", name, path)
+		}
+		docs[path] = doc
+	}
+	for path, doc := range docs {
+		out := *config
+		out.OutputPath = path
+		if err := writeOutputWithFS(doc, &out, defaultFileSystem); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitOutputPath inserts part before the extension of path.
+func splitOutputPath(path, part string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + part + ext
+}
+
+// fileNamePart lowercases name and replaces the characters that do not
+// belong in a file name with dashes.
+func fileNamePart(name string) string {
+	part := strings.Trim(strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		}
+		return '-'
+	}, name), "-")
+	if part == "" {
+		return api.DefaultSplitGroup
+	}
+	return part
+}
+
 func writeOutputWithFS(spec *api.OpenAPISpec, config *GenerateConfig, fs FileSystem) error {
 	format := getFormatFromPath(config.OutputPath)
 
@@ -77,6 +77,28 @@ openapi:
   output: "custom-output.json"
   title: "Custom API"
   version: "2.0.0"
+  specVersion: "3.0"
+  termsOfService: https://acme.test/terms
+  contact:
+    name: API Team
+    email: api@acme.test
+  license:
+    name: Apache 2.0
+    identifier: Apache-2.0
+  externalDocs:
+    url: https://acme.test/docs
+  servers:
+    - url: https://{region}.acme.test
+      description: Production
+      variables:
+        region:
+          default: eu
+          enum: [eu, us]
+  webhookProviders:
+    /webhooks/acme:
+      name: Acme
+      website: https://acme.test
+      docs: https://acme.test/webhooks
 `
 
 	if err := os.WriteFile(configFile, []byte(configContent), 0o644); err != nil {
@@ -112,6 +134,32 @@ openapi:
 	if config.Version != "2.0.0" {
 		t.Errorf("Version: got %s, want 2.0.0", config.Version)
 	}
+	if config.SpecVersion != "3.0" {
+		t.Errorf("SpecVersion: got %s, want 3.0", config.SpecVersion)
+	}
+
+	spec := &api.OpenAPISpec{Info: api.Info{Title: "Built", Contact: &api.Contact{Name: "Built"}}}
+	applySpecMetadata(spec, config)
+	if spec.Info.TermsOfService != "https://acme.test/terms" || spec.Info.Title != "Built" {
+		t.Errorf("Info: got %+v", spec.Info)
+	}
+	if c := spec.Info.Contact; c == nil || c.Name != "API Team" || c.Email != "api@acme.test" {
+		t.Errorf("Contact: got %+v", c)
+	}
+	if l := spec.Info.License; l == nil || l.Name != "Apache 2.0" || l.Identifier != "Apache-2.0" {
+		t.Errorf("License: got %+v", l)
+	}
+	if d := spec.ExternalDocs; d == nil || d.URL != "https://acme.test/docs" {
+		t.Errorf("ExternalDocs: got %+v", d)
+	}
+	if len(spec.Servers) != 1 || spec.Servers[0].Description != "Production" ||
+		spec.Servers[0].Variables["region"].Default != "eu" || len(spec.Servers[0].Variables["region"].Enum) != 2 {
+		t.Errorf("Servers: got %+v", spec.Servers)
+	}
+	want := api.WebhookProviderInfo{Name: "Acme", Website: "https://acme.test", DocsURL: "https://acme.test/webhooks"}
+	if got := config.WebhookProviders["/webhooks/acme"]; got != want {
+		t.Errorf("WebhookProviders: got %+v, want %+v", got, want)
+	}
 }
 
 func TestLoadConfigFileWithInvalidYAML(t *testing.T) {
@@ -155,9 +203,9 @@ func TestGenerateBaseSpec(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			spec, err := generateBaseSpec(tt.config)
+			spec, err := generateBaseSpec(tt.config, nil)
 			if err != nil {
-				t.Errorf("generateBaseSpec() error = %v", err)
+				t.Errorf("generateBaseSpec(, nil) error = %v", err)
 				return
 			}
 
@@ -201,7 +249,7 @@ func TestEnrichWithDocs(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := enrichWithDocs(spec, tt.sourcePath)
+			_, err := enrichWithDocs(spec, tt.sourcePath)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("enrichWithDocs() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -460,6 +508,9 @@ func TestNewGenerateCommand(t *testing.T) {
 	if flags.Lookup("config") == nil {
 		t.Error("config flag not registered")
 	}
+	if flags.Lookup("spec-version") == nil {
+		t.Error("spec-version flag not registered")
+	}
 }
 
 func TestGenerateSpec(t *testing.T) {
@@ -690,7 +741,7 @@ func TestEnrichWithDocsErrorPath(t *testing.T) {
 
 	// Test with a directory that exists but has no Go files to parse
 	tmpDir := t.TempDir()
-	err := enrichWithDocs(spec, tmpDir)
+	_, err := enrichWithDocs(spec, tmpDir)
 	// This should not error since ParseDirectory handles empty directories
 	if err != nil {
 		t.Errorf("enrichWithDocs should handle empty directory: %v", err)
@@ -777,6 +828,11 @@ func TestGenerateSpecErrorPaths(t *testing.T) {
 	if err == nil {
 		t.Error("Expected error for nonexistent build path")
 	}
+
+	config = &GenerateConfig{OutputPath: "-", SpecVersion: "2.0"}
+	if err := GenerateSpec(config); err == nil || !strings.Contains(err.Error(), "unsupported OpenAPI version") {
+		t.Errorf("Expected unsupported version error, got %v", err)
+	}
 }
 
 func TestBuildAndExtractErrorCoverage(t *testing.T) {
@@ -838,7 +894,7 @@ func TestRemainingCoverage(t *testing.T) {
 		}
 
 		// Test with nonexistent path - this should trigger error in ParseDirectory
-		err := enrichWithDocs(spec, "/absolutely/nonexistent/path/that/cannot/exist")
+		_, err := enrichWithDocs(spec, "/absolutely/nonexistent/path/that/cannot/exist")
 		if err == nil {
 			t.Error("Expected error for nonexistent path")
 		}
@@ -887,6 +943,8 @@ type MockBuildRunner struct {
 	RunError        error
 	RunOutput       []byte
 	TempFile        *os.File
+	// Env records the variables the binary was last run with.
+	Env []string
 }
 
 func (m *MockBuildRunner) CreateTemp(pattern string) (*os.File, error) {
@@ -904,7 +962,8 @@ func (m *MockBuildRunner) BuildCommand(outputPath, buildPath string) error {
 	return m.BuildError
 }
 
-func (m *MockBuildRunner) RunCommand(exePath string) ([]byte, error) {
+func (m *MockBuildRunner) RunCommand(exePath string, env []string) ([]byte, error) {
+	m.Env = env
 	if m.RunError != nil {
 		return nil, m.RunError
 	}
@@ -1003,7 +1062,7 @@ func TestDefaultBuildRunner(t *testing.T) {
 	})
 
 	t.Run("RunCommand error", func(t *testing.T) {
-		_, err := runner.RunCommand("/nonexistent/binary")
+		_, err := runner.RunCommand("/nonexistent/binary", nil)
 		if err == nil {
 			t.Error("Expected run error for nonexistent binary")
 		}
@@ -1419,3 +1478,180 @@ func (m *mockJSONMarshaler) Unmarshal(data []byte, v any) error {
 	}
 	return json.Unmarshal(data, v)
 }
+
+func TestWriteExplainReport(t *testing.T) {
+	dir := t.TempDir()
+	config := &GenerateConfig{ExplainPath: filepath.Join(dir, "explain.txt")}
+
+	raw, err := startExplain(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Remove(raw) }()
+
+	recorded := `{"components":[{"operation":"POST /users","subject":"CreateUserBody","detail":"request type CreateUserRequest"}]}`
+	if err := os.WriteFile(raw, []byte(recorded), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	spec := &api.OpenAPISpec{
+		Paths: map[string]*api.PathItem{"/users": {Post: &api.Operation{OperationID: "CreateUser"}}},
+		Components: &api.Components{Schemas: map[string]*api.Schema{
+			"CreateUserBody": {Type: "object"},
+			"User":           {Type: "object", Description: "User account."},
+		}},
+	}
+	if err := writeExplainReport(spec, raw, config.ExplainPath); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(config.ExplainPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(data)
+	for _, want := range []string{
+		"CreateUserBody: request type CreateUserRequest [POST /users]",
+		"POST /users: no doc comment found for handler CreateUser",
+		"CreateUserBody: no doc comment found",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in report:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "User: no doc comment") {
+		t.Errorf("documented component reported as missing:\n%s", out)
+	}
+
+	if err := writeExplainReport(spec, raw, ""); err != nil {
+		t.Errorf("no report requested: unexpected error %v", err)
+	}
+	if err := os.WriteFile(raw, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeExplainReport(spec, raw, config.ExplainPath); err == nil {
+		t.Error("expected error for malformed recorded report")
+	}
+}
+
+func TestGenerateSpecExamplesFile(t *testing.T) {
+	originalClient := defaultValidatorClient
+	defaultValidatorClient = &MockValidatorClient{CallBody: []byte(`{}`), CallStatusCode: 200}
+	defer func() { defaultValidatorClient = originalClient }()
+
+	config := &GenerateConfig{
+		OutputPath:   filepath.Join(t.TempDir(), "openapi.json"),
+		ExamplesPath: filepath.Join(t.TempDir(), "missing.json"),
+		Report:       io.Discard,
+	}
+	if err := GenerateSpec(config); err == nil || !strings.Contains(err.Error(), "load examples") {
+		t.Errorf("expected a missing examples file to fail, got %v", err)
+	}
+}
+
+// envOf returns the value of key among the "KEY=value" variables of env.
+func envOf(env []string, key string) (string, bool) {
+	for _, kv := range env {
+		if k, v, _ := strings.Cut(kv, "="); k == key {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+func TestGenerateSpecPassesEnvToBinary(t *testing.T) {
+	originalClient := defaultValidatorClient
+	defaultValidatorClient = &MockValidatorClient{CallBody: []byte(`{}`), CallStatusCode: 200}
+	defer func() { defaultValidatorClient = originalClient }()
+	runner := &MockBuildRunner{}
+	withBuildRunner(t, runner)
+
+	dir := t.TempDir()
+	config := &GenerateConfig{
+		BuildPath:   "./cmd/server",
+		OutputPath:  filepath.Join(dir, "openapi.json"),
+		SourcePath:  dir,
+		ExplainPath: filepath.Join(dir, "explain.txt"),
+		Report:      io.Discard,
+	}
+	if err := GenerateSpec(config); err != nil {
+		t.Fatalf("GenerateSpec() error = %v", err)
+	}
+	for _, key := range []string{"GORK_EXPLAIN", "GORK_ERRORS", "GORK_ENUMS"} {
+		if raw, ok := envOf(runner.Env, key); !ok || raw == "" {
+			t.Errorf("expected %s in the binary's environment, got %v", key, runner.Env)
+		}
+		if _, ok := os.LookupEnv(key); ok {
+			t.Errorf("%s must not be set in the generating process", key)
+		}
+	}
+}
+
+func TestGenerateSpecInternalOutput(t *testing.T) {
+	originalClient := defaultValidatorClient
+	defaultValidatorClient = &MockValidatorClient{CallBody: []byte(`{}`), CallStatusCode: 200}
+	defer func() { defaultValidatorClient = originalClient }()
+
+	runner := &MockBuildRunner{}
+	withBuildRunner(t, runner)
+
+	dir := t.TempDir()
+	config := &GenerateConfig{
+		BuildPath:          "./cmd/server",
+		OutputPath:         filepath.Join(dir, "openapi.json"),
+		InternalOutputPath: filepath.Join(dir, "openapi.internal.json"),
+		Report:             io.Discard,
+	}
+	if err := GenerateSpec(config); err != nil {
+		t.Fatalf("GenerateSpec() error = %v", err)
+	}
+	for _, path := range []string{config.OutputPath, config.InternalOutputPath} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("expected %s to be written: %v", path, err)
+		}
+	}
+	if v, ok := envOf(runner.Env, "GORK_INTERNAL"); !ok || v != "1" {
+		t.Errorf("expected the internal spec to be built with GORK_INTERNAL=1, got %v", runner.Env)
+	}
+	if _, ok := os.LookupEnv("GORK_INTERNAL"); ok {
+		t.Error("GORK_INTERNAL must not be set in the generating process")
+	}
+}
+
+func TestGenerateSpecSplitBy(t *testing.T) {
+	dir := t.TempDir()
+	spec := &api.OpenAPISpec{
+		OpenAPI: api.OpenAPIVersion31,
+		Info:    api.Info{Title: "Test", Version: "1.0.0"},
+		Paths: map[string]*api.PathItem{
+			"/api/users": {Get: &api.Operation{Tags: []string{"Partner Users"}, Responses: map[string]*api.Response{
+				"200": {Description: "OK", Content: map[string]*api.MediaType{
+					"application/json": {Schema: &api.Schema{Ref: "#/components/schemas/User"}},
+				}},
+			}}},
+		},
+		Components: &api.Components{Schemas: map[string]*api.Schema{"User": {Type: "object"}}},
+	}
+	config := &GenerateConfig{OutputPath: filepath.Join(dir, "openapi.json"), SplitBy: "tag"}
+	if err := writeOutput(spec, config); err != nil {
+		t.Fatal(err)
+	}
+
+	group, err := os.ReadFile(filepath.Join(dir, "openapi.partner-users.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(group), `"openapi.components.json#/components/schemas/User"`) {
+		t.Errorf("group spec does not reference the components file: %s", group)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "openapi.components.json")); err != nil {
+		t.Errorf("components file not written: %v", err)
+	}
+
+	if err := GenerateSpec(&GenerateConfig{OutputPath: "-", SplitBy: "tag"}); err == nil {
+		t.Error("expected --split-by to reject stdout")
+	}
+	if err := GenerateSpec(&GenerateConfig{OutputPath: config.OutputPath, SplitBy: "service"}); err == nil {
+		t.Error("expected an unknown split mode to fail")
+	}
+}
@@ -0,0 +1,356 @@
+package cli
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gork-labs/gork/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+func newReportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Quality reports on the API surface",
+	}
+	cmd.AddCommand(newCoverageCommand())
+	return cmd
+}
+
+func newCoverageCommand() *cobra.Command {
+	var config CoverageConfig
+
+	cmd := &cobra.Command{
+		Use:   "coverage",
+		Short: "Report operations lacking tests, descriptions or examples",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return ReportCoverage(&config, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVar(&config.BuildPath, "build", "", "Path to main package to build with '-tags openapi'")
+	cmd.Flags().StringVar(&config.TestsPath, "tests", "./...", "Directory searched for _test.go files ('dir/...' searches recursively)")
+	cmd.Flags().StringVar(&config.SourcePath, "source", ".", "Directory containing Go source code for documentation extraction")
+	cmd.Flags().StringVar(&config.ExamplesPath, "examples", "", "Examples captured by apitest; their operations count as tested")
+	cmd.Flags().StringSliceVar(&config.Require, "require", nil, "Fail when an operation lacks any of: tests, docs, examples")
+	_ = cmd.MarkFlagRequired("build")
+
+	return cmd
+}
+
+// CoverageConfig holds configuration for the coverage report.
+type CoverageConfig struct {
+	BuildPath    string
+	TestsPath    string
+	SourcePath   string
+	ExamplesPath string
+	// Require lists the aspects every operation must have: "tests", "docs"
+	// and "examples".
+	Require []string
+}
+
+// OperationCoverage reports what an operation is covered by.
+type OperationCoverage struct {
+	Method      string
+	Path        string
+	OperationID string
+	Tests       bool
+	Docs        bool
+	Examples    bool
+}
+
+// has reports whether the operation has the aspect named by a --require value.
+func (c OperationCoverage) has(aspect string) bool {
+	switch aspect {
+	case "tests":
+		return c.Tests
+	case "docs":
+		return c.Docs
+	default:
+		return c.Examples
+	}
+}
+
+// ReportCoverage builds the application, cross-references its operations
+// with the test files, doc comments and examples, and writes a table of
+// every operation to out. An error is returned when an operation lacks an
+// aspect listed in config.Require.
+func ReportCoverage(config *CoverageConfig, out io.Writer) error {
+	for _, aspect := range config.Require {
+		if aspect != "tests" && aspect != "docs" && aspect != "examples" {
+			return fmt.Errorf("unknown --require value %q (use tests, docs or examples)", aspect)
+		}
+	}
+
+	spec, err := buildAndExtract(config.BuildPath)
+	if err != nil {
+		return err
+	}
+	if _, err := enrichWithDocs(spec, config.SourcePath); err != nil {
+		return err
+	}
+	var captured []api.CapturedExample
+	if config.ExamplesPath != "" {
+		if captured, err = api.LoadCapturedExamples(config.ExamplesPath); err != nil {
+			return fmt.Errorf("load examples: %w", err)
+		}
+		api.ApplyCapturedExamples(spec, captured)
+	}
+	refs, err := collectTestReferences(config.TestsPath)
+	if err != nil {
+		return err
+	}
+
+	coverage := operationCoverage(spec, refs, captured)
+	missing := writeCoverage(out, coverage)
+	var failing []string
+	for _, aspect := range config.Require {
+		if missing[aspect] > 0 {
+			failing = append(failing, fmt.Sprintf("%d without %s", missing[aspect], aspect))
+		}
+	}
+	if len(failing) > 0 {
+		return fmt.Errorf("coverage requirements not met: %s", strings.Join(failing, ", "))
+	}
+	return nil
+}
+
+// operationCoverage lists the operations of spec sorted by path and method.
+func operationCoverage(spec *api.OpenAPISpec, refs testReferences, captured []api.CapturedExample) []OperationCoverage {
+	tested := map[string]bool{}
+	for _, ex := range captured {
+		tested[ex.Operation] = true
+	}
+	var coverage []OperationCoverage
+	for path, item := range spec.Paths {
+		for method, op := range map[string]*api.Operation{
+			"GET": item.Get, "POST": item.Post, "PUT": item.Put, "PATCH": item.Patch, "DELETE": item.Delete,
+		} {
+			if op == nil {
+				continue
+			}
+			coverage = append(coverage, OperationCoverage{
+				Method:      method,
+				Path:        path,
+				OperationID: op.OperationID,
+				Tests:       tested[method+" "+path] || refs.covers(method, path, op.OperationID),
+				Docs:        op.Description != "" || op.Summary != "",
+				Examples:    hasExamples(op),
+			})
+		}
+	}
+	sort.Slice(coverage, func(i, j int) bool {
+		if coverage[i].Path != coverage[j].Path {
+			return coverage[i].Path < coverage[j].Path
+		}
+		return coverage[i].Method < coverage[j].Method
+	})
+	return coverage
+}
+
+// hasExamples reports whether the request or a response of op has examples.
+func hasExamples(op *api.Operation) bool {
+	for _, p := range op.Parameters {
+		if p.Example != nil || len(p.Examples) > 0 {
+			return true
+		}
+	}
+	var media []map[string]*api.MediaType
+	if op.RequestBody != nil {
+		media = append(media, op.RequestBody.Content)
+	}
+	for _, resp := range op.Responses {
+		media = append(media, resp.Content)
+	}
+	for _, content := range media {
+		for _, mt := range content {
+			if len(mt.Examples) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// writeCoverage writes the coverage table and returns how many operations
+// lack each aspect.
+func writeCoverage(out io.Writer, coverage []OperationCoverage) map[string]int {
+	mark := func(ok bool) string {
+		if ok {
+			return "yes"
+		}
+		return "no"
+	}
+	missing := map[string]int{}
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "OPERATION\tHANDLER\tTESTS\tDOCS\tEXAMPLES")
+	for _, c := range coverage {
+		_, _ = fmt.Fprintf(tw, "%s %s\t%s\t%s\t%s\t%s\n", c.Method, c.Path, c.OperationID, mark(c.Tests), mark(c.Docs), mark(c.Examples))
+		for _, aspect := range []string{"tests", "docs", "examples"} {
+			if !c.has(aspect) {
+				missing[aspect]++
+			}
+		}
+	}
+	_ = tw.Flush()
+	_, _ = fmt.Fprintf(out, "\n%d operation(s): %d without tests, %d without docs, %d without examples\n",
+		len(coverage), missing["tests"], missing["docs"], missing["examples"])
+	return missing
+}
+
+// testReferences holds the identifiers and request paths used in test files.
+type testReferences struct {
+	idents map[string]bool
+	// requests are the URL paths found in string literals with the HTTP
+	// method of the call they are passed to, or "" when it is unknown.
+	requests []testRequest
+}
+
+type testRequest struct {
+	method string
+	path   string
+}
+
+// covers reports whether a test refers to the handler of an operation or
+// requests a URL matching its path template.
+func (r testReferences) covers(method, template, operationID string) bool {
+	if operationID != "" && r.idents[operationID] {
+		return true
+	}
+	for _, req := range r.requests {
+		if (req.method == "" || req.method == method) && matchPathTemplate(template, req.path) {
+			return true
+		}
+	}
+	return false
+}
+
+// collectTestReferences parses the _test.go files under pattern, a
+// directory optionally followed by "/..." to include subdirectories.
+func collectTestReferences(pattern string) (testReferences, error) {
+	refs := testReferences{idents: map[string]bool{}}
+	root, recursive := strings.CutSuffix(pattern, "...")
+	root = filepath.Clean(strings.TrimSuffix(root, "/"))
+
+	fset := token.NewFileSet()
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != root && (!recursive || name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return fmt.Errorf("parse %s: %w", path, err)
+		}
+		refs.addFile(file)
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return refs, err
+	}
+	return refs, nil
+}
+
+// addFile records the references of a test file. A path literal takes the
+// method of the innermost call it is passed to that names one, e.g.
+// httptest.NewRequest(http.MethodGet, "/users/42", nil) or http.Post(...).
+func (r *testReferences) addFile(file *ast.File) {
+	methods := map[token.Pos]string{}
+	var paths []*ast.BasicLit
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Ident:
+			r.idents[n.Name] = true
+		case *ast.BasicLit:
+			if value, err := strconv.Unquote(n.Value); err == nil && n.Kind == token.STRING && strings.HasPrefix(value, "/") {
+				paths = append(paths, n)
+			}
+		case *ast.CallExpr:
+			if method := callMethod(n); method != "" {
+				for _, arg := range n.Args {
+					ast.Inspect(arg, func(n ast.Node) bool {
+						if lit, ok := n.(*ast.BasicLit); ok {
+							methods[lit.Pos()] = method
+						}
+						return true
+					})
+				}
+			}
+		}
+		return true
+	})
+	for _, lit := range paths {
+		value, _ := strconv.Unquote(lit.Value)
+		value, _, _ = strings.Cut(value, "?")
+		r.requests = append(r.requests, testRequest{method: methods[lit.Pos()], path: value})
+	}
+}
+
+// callMethod returns the HTTP method named by a call: a method argument
+// ("GET" or http.MethodGet) or a net/http style helper such as client.Get.
+func callMethod(call *ast.CallExpr) string {
+	for _, arg := range call.Args {
+		switch arg := arg.(type) {
+		case *ast.BasicLit:
+			if value, err := strconv.Unquote(arg.Value); err == nil && isHTTPMethod(value) {
+				return value
+			}
+		case *ast.SelectorExpr:
+			if name, ok := strings.CutPrefix(arg.Sel.Name, "Method"); ok && isHTTPMethod(strings.ToUpper(name)) {
+				return strings.ToUpper(name)
+			}
+		}
+	}
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+		switch sel.Sel.Name {
+		case "Get", "Head", "Post", "PostForm":
+			return strings.ToUpper(strings.TrimSuffix(sel.Sel.Name, "Form"))
+		}
+	}
+	return ""
+}
+
+func isHTTPMethod(s string) bool {
+	switch s {
+	case "GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS":
+		return true
+	}
+	return false
+}
+
+// matchPathTemplate reports whether path matches an OpenAPI path template.
+func matchPathTemplate(template, path string) bool {
+	tmpl := strings.Split(strings.Trim(template, "/"), "/")
+	segs := strings.Split(strings.Trim(path, "/"), "/")
+	if len(tmpl) != len(segs) {
+		return false
+	}
+	for i, t := range tmpl {
+		if strings.HasPrefix(t, "{") && strings.HasSuffix(t, "}") {
+			continue
+		}
+		if t != segs[i] {
+			return false
+		}
+	}
+	return true
+}
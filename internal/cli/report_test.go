@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const coverageSpec = `{"openapi":"3.1.0","info":{"title":"Test","version":"1.0.0"},"paths":{
+	"/api/users/{id}":{"get":{"operationId":"GetUser","responses":{"200":{"description":"OK"}}},
+		"delete":{"operationId":"DeleteUser","responses":{"204":{"description":"No Content"}}}},
+	"/api/users":{"post":{"operationId":"CreateUser","description":"Creates a user.",
+		"requestBody":{"content":{"application/json":{"examples":{"jane":{"value":{"name":"Jane"}}}}}},
+		"responses":{"201":{"description":"Created"}}}}}}`
+
+func TestReportCoverage(t *testing.T) {
+	withBuildRunner(t, &MockBuildRunner{RunOutput: []byte(coverageSpec)})
+
+	tests := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tests, "users"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tests, "users", "users_test.go"), []byte(`package users
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetUser(t *testing.T) {
+	_ = httptest.NewRequest(http.MethodGet, server.URL+"/api/users/42?expand=true", nil)
+}
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	examples := filepath.Join(t.TempDir(), "examples.json")
+	if err := os.WriteFile(examples, []byte(`[{"operation":"POST /api/users","name":"201","status":201}]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	config := &CoverageConfig{BuildPath: "./cmd/server", TestsPath: tests + "/...", SourcePath: "", ExamplesPath: examples}
+	if err := ReportCoverage(config, &out); err != nil {
+		t.Fatal(err)
+	}
+	report := out.String()
+	for _, want := range []string{
+		"POST /api/users         CreateUser  yes    yes   yes",
+		"DELETE /api/users/{id}  DeleteUser  no     no    no",
+		"GET /api/users/{id}     GetUser     yes    no    no",
+		"3 operation(s): 1 without tests, 2 without docs, 2 without examples",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report is missing %q:\n%s", want, report)
+		}
+	}
+
+	config.TestsPath = tests
+	out.Reset()
+	if err := ReportCoverage(config, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "2 without tests") {
+		t.Errorf("tests in subdirectories must only be found with /...:\n%s", out.String())
+	}
+
+	config.Require = []string{"tests", "docs"}
+	if err := ReportCoverage(config, &out); err == nil || err.Error() != "coverage requirements not met: 2 without tests, 2 without docs" {
+		t.Errorf("expected the requirements to fail, got %v", err)
+	}
+	config.Require = []string{"everything"}
+	if err := ReportCoverage(config, &out); err == nil || !strings.Contains(err.Error(), "unknown --require value") {
+		t.Errorf("expected an unknown aspect to fail, got %v", err)
+	}
+}
@@ -13,6 +13,12 @@ func Execute() error {
 	}
 
 	rootCmd.AddCommand(newOpenAPICommand())
+	rootCmd.AddCommand(newClientRootCommand())
+	rootCmd.AddCommand(newReportCommand())
+	rootCmd.AddCommand(newScaffoldCommand())
+	rootCmd.AddCommand(newUnionsCommand())
+	rootCmd.AddCommand(newHandlersCommand())
+	rootCmd.AddCommand(newWebhooksCommand())
 
 	return rootCmd.Execute()
 }
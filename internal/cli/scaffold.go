@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/gork-labs/gork/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+func newScaffoldCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "scaffold",
+		Short: "Generate server code skeletons",
+	}
+	cmd.AddCommand(newScaffoldFromSpecCommand())
+	return cmd
+}
+
+func newScaffoldFromSpecCommand() *cobra.Command {
+	var config ScaffoldConfig
+
+	cmd := &cobra.Command{
+		Use:   "from-spec <spec-file>",
+		Short: "Generate request/response types, handler stubs and route registration from an OpenAPI document",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			config.SpecPath = args[0]
+			return ScaffoldFromSpec(&config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.OutputDir, "output", ".", "Directory to write the generated package to")
+	cmd.Flags().StringVar(&config.Package, "package", "", "Go package name (defaults to the output directory name)")
+	cmd.Flags().BoolVar(&config.Force, "force", false, "Regenerate existing types.go and routes.go; handlers.go is never overwritten")
+
+	return cmd
+}
+
+// ScaffoldConfig holds configuration for scaffolding from a spec.
+type ScaffoldConfig struct {
+	SpecPath  string
+	OutputDir string
+	Package   string
+	Force     bool
+}
+
+// ScaffoldFromSpec reads an OpenAPI document and writes gork-convention
+// types (types.go), handler stubs (handlers.go) and a route registration
+// function (routes.go) to the output directory. Existing files are left
+// untouched unless config.Force is set, which regenerates types.go and
+// routes.go. An existing handlers.go holds the implemented handlers and is
+// never overwritten; stubs of operations added to the spec since must be
+// added to it by hand.
+func ScaffoldFromSpec(config *ScaffoldConfig) error {
+	data, err := os.ReadFile(filepath.Clean(config.SpecPath))
+	if err != nil {
+		return fmt.Errorf("read spec: %w", err)
+	}
+	spec, err := api.ParseOpenAPIDocument(data)
+	if err != nil {
+		return err
+	}
+
+	pkg := config.Package
+	if pkg == "" {
+		// An unresolvable working directory yields the "api" fallback.
+		abs, _ := filepath.Abs(config.OutputDir)
+		pkg = packageNameFromDir(abs)
+	}
+
+	files, err := GenerateScaffold(spec, pkg)
+	if err != nil {
+		return err
+	}
+	return writeScaffoldFiles(config, files)
+}
+
+// scaffoldHandlersFile is the scaffolded file users implement the handlers in.
+const scaffoldHandlersFile = "handlers.go"
+
+func writeScaffoldFiles(config *ScaffoldConfig, files map[string][]byte) error {
+	if err := os.MkdirAll(config.OutputDir, 0o750); err != nil {
+		return fmt.Errorf("create output directory: %w", err)
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Refuse before writing anything so that a partial scaffold is never
+	// left behind.
+	if !config.Force {
+		for _, name := range names {
+			path := filepath.Join(config.OutputDir, name)
+			if _, err := os.Stat(path); err == nil {
+				return fmt.Errorf("%s already exists (use --force to overwrite)", path)
+			}
+		}
+	}
+	for _, name := range names {
+		path := filepath.Join(config.OutputDir, name)
+		if _, err := os.Stat(path); err == nil && name == scaffoldHandlersFile {
+			continue
+		}
+		if err := os.WriteFile(path, files[name], 0o600); err != nil {
+			return fmt.Errorf("write %s: %w", path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,570 @@
+package cli
+
+import (
+	"fmt"
+	"go/format"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gork-labs/gork/pkg/api"
+)
+
+// GenerateScaffold renders the scaffold files for the spec. The result maps
+// file names to formatted Go source.
+func GenerateScaffold(spec *api.OpenAPISpec, pkg string) (map[string][]byte, error) {
+	g := newScaffoldGenerator(spec)
+	for _, op := range scaffoldOperations(spec) {
+		g.addOperation(op)
+	}
+
+	files := map[string][]byte{}
+	for name, src := range map[string]string{
+		"types.go":           g.typesFile(pkg),
+		scaffoldHandlersFile: g.handlersFile(pkg),
+		"routes.go":          g.routesFile(pkg),
+	} {
+		formatted, err := format.Source([]byte(src))
+		if err != nil {
+			return nil, fmt.Errorf("format %s: %w", name, err)
+		}
+		files[name] = formatted
+	}
+	return files, nil
+}
+
+// packageNameFromDir derives a Go package name from a directory path.
+func packageNameFromDir(dir string) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, filepath.Base(dir))
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		return "api"
+	}
+	return name
+}
+
+// specOperation is an operation of the source document with its location.
+type specOperation struct {
+	method string
+	path   string
+	op     *api.Operation
+}
+
+// scaffoldOperations lists the operations of the spec in path/method order.
+func scaffoldOperations(spec *api.OpenAPISpec) []specOperation {
+	paths := make([]string, 0, len(spec.Paths))
+	for p := range spec.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	var ops []specOperation
+	for _, p := range paths {
+		item := spec.Paths[p]
+		if item == nil {
+			continue
+		}
+		for _, mo := range []struct {
+			method string
+			op     *api.Operation
+		}{
+			{http.MethodGet, item.Get},
+			{http.MethodPost, item.Post},
+			{http.MethodPut, item.Put},
+			{http.MethodPatch, item.Patch},
+			{http.MethodDelete, item.Delete},
+		} {
+			if mo.op != nil {
+				ops = append(ops, specOperation{method: mo.method, path: p, op: mo.op})
+			}
+		}
+	}
+	return ops
+}
+
+// scaffoldHandler describes a generated handler.
+type scaffoldHandler struct {
+	name     string
+	method   string
+	path     string
+	summary  string
+	tags     []string
+	request  string
+	response string // empty for error-only handlers
+}
+
+// scaffoldGenerator accumulates type declarations and handlers.
+type scaffoldGenerator struct {
+	spec     *api.OpenAPISpec
+	decls    []string
+	declared map[string]bool
+	imports  map[string]bool
+	handlers []scaffoldHandler
+}
+
+func newScaffoldGenerator(spec *api.OpenAPISpec) *scaffoldGenerator {
+	return &scaffoldGenerator{spec: spec, declared: map[string]bool{}, imports: map[string]bool{}}
+}
+
+// operationName derives the handler name from the operationId or, when
+// absent, from the method and path.
+func operationName(op specOperation) string {
+	if op.op.OperationID != "" {
+		return goName(op.op.OperationID)
+	}
+	var b strings.Builder
+	b.WriteString(goName(strings.ToLower(op.method)))
+	for _, seg := range strings.Split(op.path, "/") {
+		if seg == "" {
+			continue
+		}
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			b.WriteString("By" + goName(strings.Trim(seg, "{}")))
+			continue
+		}
+		b.WriteString(goName(seg))
+	}
+	return b.String()
+}
+
+func (g *scaffoldGenerator) addOperation(op specOperation) {
+	name := g.uniqueName(operationName(op))
+	h := scaffoldHandler{
+		name:    name,
+		method:  op.method,
+		path:    op.path,
+		summary: op.op.Summary,
+		tags:    op.op.Tags,
+		request: name + "Request",
+	}
+	g.declareRequest(h.request, op.op)
+	if body := successSchema(op.op); body != nil {
+		h.response = name + "Response"
+		g.declare(h.response, fmt.Sprintf("type %s struct {\n\tBody %s\n}\n", h.response, g.goType(body, name+"ResponseBody")))
+	}
+	g.handlers = append(g.handlers, h)
+}
+
+// uniqueName appends a numeric suffix when name is already taken.
+func (g *scaffoldGenerator) uniqueName(name string) string {
+	candidate := name
+	for i := 2; g.declared[candidate] || g.declared[candidate+"Request"]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+	g.declared[candidate] = true
+	return candidate
+}
+
+func (g *scaffoldGenerator) declare(name, decl string) {
+	g.declared[name] = true
+	g.decls = append(g.decls, decl)
+}
+
+// parameterSections maps OpenAPI parameter locations to convention sections.
+var parameterSections = []struct{ in, section string }{
+	{"path", api.SectionPath},
+	{"query", api.SectionQuery},
+	{"header", api.SectionHeaders},
+	{"cookie", api.SectionCookies},
+}
+
+func (g *scaffoldGenerator) declareRequest(name string, op *api.Operation) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "type %s struct {\n", name)
+	idx := len(g.decls)
+	g.declared[name] = true
+	g.decls = append(g.decls, "") // reserve the slot so nested types follow
+	for _, s := range parameterSections {
+		var fields []string
+		for _, p := range op.Parameters {
+			if p.In != s.in {
+				continue
+			}
+			typ := g.goType(p.Schema, name+s.section+goName(p.Name))
+			fields = append(fields, g.field(p.Name, typ, p.Description, p.Required || p.In == "path", p.Schema))
+		}
+		if len(fields) > 0 {
+			fmt.Fprintf(&b, "\t%s struct {\n%s\t}\n", s.section, strings.Join(fields, ""))
+		}
+	}
+	if schema := requestSchema(op); schema != nil {
+		fmt.Fprintf(&b, "\t%s %s\n", api.SectionBody, g.goType(schema, name+api.SectionBody))
+	}
+	b.WriteString("}\n")
+	g.decls[idx] = b.String()
+}
+
+// requestSchema returns the JSON request body schema, if any.
+func requestSchema(op *api.Operation) *api.Schema {
+	if op.RequestBody == nil {
+		return nil
+	}
+	return jsonSchema(op.RequestBody.Content)
+}
+
+// successSchema returns the JSON body schema of the lowest 2xx response.
+func successSchema(op *api.Operation) *api.Schema {
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		if strings.HasPrefix(code, "2") && op.Responses[code] != nil {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		if s := jsonSchema(op.Responses[code].Content); s != nil {
+			return s
+		}
+	}
+	return nil
+}
+
+func jsonSchema(content map[string]*api.MediaType) *api.Schema {
+	if mt := content["application/json"]; mt != nil {
+		return mt.Schema
+	}
+	return nil
+}
+
+// field renders a struct field with gork and validate tags.
+func (g *scaffoldGenerator) field(name, typ, description string, required bool, schema *api.Schema) string {
+	var b strings.Builder
+	if description != "" {
+		for _, line := range strings.Split(strings.TrimSpace(description), "\n") {
+			fmt.Fprintf(&b, "\t// %s\n", line)
+		}
+	}
+	tag := fmt.Sprintf("gork:%q", name)
+	if rules := validateRules(schema, required); rules != "" {
+		tag += fmt.Sprintf(" validate:%q", rules)
+	}
+	fmt.Fprintf(&b, "\t%s %s `%s`\n", goName(name), typ, tag)
+	return b.String()
+}
+
+// validateRules infers go-playground/validator rules from schema constraints.
+func validateRules(s *api.Schema, required bool) string {
+	var rules []string
+	if required {
+		rules = append(rules, "required")
+	}
+	if s != nil {
+		rules = append(rules, schemaConstraintRules(s)...)
+	}
+	if !required && len(rules) > 0 {
+		rules = append([]string{"omitempty"}, rules...)
+	}
+	return strings.Join(rules, ",")
+}
+
+func schemaConstraintRules(s *api.Schema) []string {
+	var rules []string
+	if s.MinLength != nil {
+		rules = append(rules, "min="+strconv.Itoa(*s.MinLength))
+	}
+	if s.MaxLength != nil {
+		rules = append(rules, "max="+strconv.Itoa(*s.MaxLength))
+	}
+	if s.Minimum != nil {
+		rules = append(rules, "min="+strconv.FormatFloat(*s.Minimum, 'f', -1, 64))
+	}
+	if s.Maximum != nil {
+		rules = append(rules, "max="+strconv.FormatFloat(*s.Maximum, 'f', -1, 64))
+	}
+	if s.ExclusiveMinimum != nil {
+		rules = append(rules, "gt="+strconv.FormatFloat(*s.ExclusiveMinimum, 'f', -1, 64))
+	}
+	if s.ExclusiveMaximum != nil {
+		rules = append(rules, "lt="+strconv.FormatFloat(*s.ExclusiveMaximum, 'f', -1, 64))
+	}
+	if len(s.Enum) > 0 {
+		rules = append(rules, "oneof="+strings.Join(s.Enum, " "))
+	}
+	switch s.Format {
+	case "email":
+		rules = append(rules, "email")
+	case "uuid":
+		rules = append(rules, "uuid")
+	case "uri", "url":
+		rules = append(rules, "url")
+	}
+	return rules
+}
+
+// goType returns the Go type for a schema, declaring named struct types for
+// objects. hint names inline object types.
+func (g *scaffoldGenerator) goType(s *api.Schema, hint string) string {
+	if s == nil {
+		return "interface{}"
+	}
+	if name, ok := strings.CutPrefix(s.Ref, "#/components/schemas/"); ok {
+		return g.componentType(name)
+	}
+	if members := unionMembers(s); len(members) > 0 {
+		return g.unionType(members, hint)
+	}
+
+	base, nullable := schemaBaseType(s)
+	typ := g.baseGoType(base, s, hint)
+	if nullable && !strings.HasPrefix(typ, "[]") && !strings.HasPrefix(typ, "map[") && typ != "interface{}" {
+		return "*" + typ
+	}
+	return typ
+}
+
+// schemaBaseType returns the non-null type and whether null is allowed.
+func schemaBaseType(s *api.Schema) (string, bool) {
+	if s.Type != "" {
+		return s.Type, s.Nullable
+	}
+	base, nullable := "", s.Nullable
+	for _, t := range s.Types {
+		if t == "null" {
+			nullable = true
+			continue
+		}
+		base = t
+	}
+	return base, nullable
+}
+
+func (g *scaffoldGenerator) baseGoType(base string, s *api.Schema, hint string) string {
+	switch base {
+	case "string":
+		return g.stringType(s.Format)
+	case "integer":
+		if s.Format == "int32" || s.Format == "int64" {
+			return s.Format
+		}
+		return "int"
+	case "number":
+		if s.Format == "float" {
+			return "float32"
+		}
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "[]" + g.goType(s.Items, hint+"Item")
+	}
+	if len(s.Properties) > 0 {
+		g.structType(hint, s)
+		return hint
+	}
+	if base == "object" {
+		return "map[string]interface{}"
+	}
+	return "interface{}"
+}
+
+func (g *scaffoldGenerator) stringType(format string) string {
+	switch format {
+	case "date-time":
+		g.imports["time"] = true
+		return "time.Time"
+	case "binary":
+		return "[]byte"
+	}
+	return "string"
+}
+
+// unionMembers returns the oneOf/anyOf alternatives of a schema.
+func unionMembers(s *api.Schema) []*api.Schema {
+	if len(s.OneOf) > 0 {
+		return s.OneOf
+	}
+	return s.AnyOf
+}
+
+// unionType maps 2-4 alternatives to unions.UnionN. Other arities fall back
+// to interface{}.
+func (g *scaffoldGenerator) unionType(members []*api.Schema, hint string) string {
+	if len(members) < 2 || len(members) > 4 {
+		return "interface{}"
+	}
+	args := make([]string, len(members))
+	for i, m := range members {
+		args[i] = g.goType(m, fmt.Sprintf("%sOption%d", hint, i+1))
+	}
+	g.imports["github.com/gork-labs/gork/pkg/unions"] = true
+	return fmt.Sprintf("unions.Union%d[%s]", len(members), strings.Join(args, ", "))
+}
+
+func (g *scaffoldGenerator) componentType(name string) string {
+	typeName := goName(name)
+	if g.declared[typeName] {
+		return typeName
+	}
+	g.declared[typeName] = true
+	var schema *api.Schema
+	if g.spec.Components != nil {
+		schema = g.spec.Components.Schemas[name]
+	}
+	if schema == nil || len(schema.Properties) == 0 {
+		underlying := "interface{}"
+		if schema != nil {
+			underlying = g.goType(schema, typeName+"Value")
+		}
+		g.decls = append(g.decls, fmt.Sprintf("type %s %s\n", typeName, underlying))
+		return typeName
+	}
+	g.structType(typeName, schema)
+	return typeName
+}
+
+// structType declares a struct for an object schema.
+func (g *scaffoldGenerator) structType(name string, s *api.Schema) {
+	g.declared[name] = true
+	required := map[string]bool{}
+	for _, r := range s.Required {
+		required[r] = true
+	}
+	props := make([]string, 0, len(s.Properties))
+	for p := range s.Properties {
+		props = append(props, p)
+	}
+	sort.Strings(props)
+
+	var b strings.Builder
+	if s.Description != "" {
+		fmt.Fprintf(&b, "// %s %s\n", name, strings.TrimSpace(s.Description))
+	}
+	fmt.Fprintf(&b, "type %s struct {\n", name)
+	idx := len(g.decls)
+	g.decls = append(g.decls, "") // reserve the slot so nested types follow
+	for _, p := range props {
+		ps := s.Properties[p]
+		typ := g.goType(ps, name+goName(p))
+		b.WriteString(g.field(p, typ, ps.Description, required[p], ps))
+	}
+	b.WriteString("}\n")
+	g.decls[idx] = b.String()
+}
+
+// commonInitialisms are rendered in upper case like the Go standard library.
+var commonInitialisms = map[string]bool{
+	"API": true, "HTML": true, "HTTP": true, "HTTPS": true, "ID": true, "IP": true,
+	"JSON": true, "SQL": true, "URI": true, "URL": true, "UUID": true, "XML": true,
+}
+
+// goName converts an identifier such as "user_id" or "userId" into an
+// exported Go name ("UserID").
+func goName(s string) string {
+	var words []string
+	var cur []rune
+	flush := func() {
+		if len(cur) > 0 {
+			words = append(words, string(cur))
+			cur = nil
+		}
+	}
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && i > 0 && unicode.IsLower(runes[i-1]):
+			flush()
+			cur = append(cur, r)
+		default:
+			cur = append(cur, r)
+		}
+	}
+	flush()
+
+	var b strings.Builder
+	for _, w := range words {
+		if up := strings.ToUpper(w); commonInitialisms[up] {
+			b.WriteString(up)
+			continue
+		}
+		rs := []rune(strings.ToLower(w))
+		rs[0] = unicode.ToUpper(rs[0])
+		b.WriteString(string(rs))
+	}
+	name := b.String()
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "X" + name
+	}
+	return name
+}
+
+const scaffoldGeneratedHeader = "// Code generated by gork scaffold from-spec. DO NOT EDIT.\n\n"
+
+func (g *scaffoldGenerator) typesFile(pkg string) string {
+	var b strings.Builder
+	b.WriteString(scaffoldGeneratedHeader)
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	if len(g.imports) > 0 {
+		var std, other []string
+		for imp := range g.imports {
+			if strings.Contains(imp, ".") {
+				other = append(other, strconv.Quote(imp))
+			} else {
+				std = append(std, strconv.Quote(imp))
+			}
+		}
+		sort.Strings(std)
+		sort.Strings(other)
+		groups := []string{strings.Join(std, "\n"), strings.Join(other, "\n")}
+		fmt.Fprintf(&b, "import (\n%s\n)\n\n", strings.TrimSpace(strings.Join(groups, "\n\n")))
+	}
+	for _, decl := range g.decls {
+		b.WriteString(decl)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (g *scaffoldGenerator) handlersFile(pkg string) string {
+	var b strings.Builder
+	b.WriteString("// Handler stubs generated by gork scaffold from-spec. Implement the\n// business logic here; gork scaffold never overwrites this file.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	if len(g.handlers) > 0 {
+		b.WriteString("import (\n\"context\"\n\"errors\"\n)\n\n")
+		b.WriteString("var errNotImplemented = errors.New(\"not implemented\")\n\n")
+	}
+	for _, h := range g.handlers {
+		fmt.Fprintf(&b, "// %s handles %s %s.\n", h.name, h.method, h.path)
+		if summary := strings.TrimSpace(h.summary); summary != "" {
+			fmt.Fprintf(&b, "//\n// %s\n", summary)
+		}
+		if h.response == "" {
+			fmt.Fprintf(&b, "func %s(_ context.Context, _ %s) error {\n\treturn errNotImplemented\n}\n\n", h.name, h.request)
+			continue
+		}
+		fmt.Fprintf(&b, "func %s(_ context.Context, _ %s) (*%s, error) {\n\treturn nil, errNotImplemented\n}\n\n", h.name, h.request, h.response)
+	}
+	return b.String()
+}
+
+func (g *scaffoldGenerator) routesFile(pkg string) string {
+	var b strings.Builder
+	b.WriteString(scaffoldGeneratedHeader)
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	b.WriteString("import \"github.com/gork-labs/gork/pkg/api\"\n\n")
+	b.WriteString("// Router is satisfied by every gork adapter router.\n")
+	b.WriteString("type Router interface {\n\tRegister(method, path string, handler interface{}, opts ...api.Option)\n}\n\n")
+	b.WriteString("// RegisterRoutes registers all operations of the specification on r.\n")
+	b.WriteString("func RegisterRoutes(r Router) {\n")
+	for _, h := range g.handlers {
+		opts := ""
+		if len(h.tags) > 0 {
+			quoted := make([]string, len(h.tags))
+			for i, t := range h.tags {
+				quoted[i] = strconv.Quote(t)
+			}
+			opts = ", api.WithTags(" + strings.Join(quoted, ", ") + ")"
+		}
+		fmt.Fprintf(&b, "\tr.Register(%q, %q, %s%s)\n", h.method, h.path, h.name, opts)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
@@ -0,0 +1,311 @@
+package cli
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gork-labs/gork/pkg/api"
+)
+
+const scaffoldDesign = `openapi: 3.1.0
+info: {title: Pets, version: "1.0"}
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      tags: [pets]
+      parameters:
+        - {name: limit, in: query, schema: {type: integer, format: int32, minimum: 1, maximum: 100}}
+        - {name: X-Request-Id, in: header, required: true, description: "Correlation ID.", schema: {type: string, format: uuid}}
+      responses:
+        200:
+          description: OK
+          content:
+            application/json:
+              schema: {type: array, items: {$ref: '#/components/schemas/Pet'}}
+    post:
+      summary: Create a pet.
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name: {type: string, minLength: 1, maxLength: 50}
+                kind: {type: string, enum: [cat, dog]}
+                owner_email: {type: string, format: email}
+                homepage: {type: string, format: uri}
+      responses:
+        400: {description: Bad request}
+        201:
+          description: Created
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Pet'}
+  /pets/{petId}:
+    delete:
+      parameters:
+        - {name: petId, in: path, required: true, schema: {type: string}}
+        - {name: session, in: cookie, schema: {type: string}}
+      responses:
+        204: {description: Deleted}
+  /empty: null
+components:
+  schemas:
+    Pet:
+      type: object
+      description: is a pet.
+      required: [id, name]
+      properties:
+        id: {type: string}
+        name: {type: string}
+        born: {type: string, format: date-time}
+        photo: {type: string, format: binary}
+        nickname: {type: [string, "null"]}
+        aliases: {type: [array, "null"], items: {type: string}}
+        age: {type: integer}
+        weight: {type: number, format: float}
+        score: {type: number}
+        count: {type: integer, format: int64}
+        vaccinated: {type: boolean}
+        attrs: {type: object}
+        extra: {}
+        owner: {$ref: '#/components/schemas/Owner'}
+        code: {$ref: '#/components/schemas/Code'}
+        missing: {$ref: '#/components/schemas/Missing'}
+        toy:
+          oneOf:
+            - {$ref: '#/components/schemas/Ball'}
+            - type: object
+              properties:
+                length: {type: number}
+        any:
+          anyOf:
+            - {type: string}
+        many:
+          oneOf: [{type: string}, {type: integer}, {type: boolean}, {type: number}, {type: object}]
+    Ball:
+      type: object
+      properties:
+        color: {type: string}
+    Owner:
+      type: object
+      properties:
+        name: {type: string}
+    Code: {type: string}
+`
+
+func generateTestScaffold(t *testing.T, design string) map[string]string {
+	t.Helper()
+	spec, err := api.ParseOpenAPIDocument([]byte(design))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := GenerateScaffold(spec, "pets")
+	if err != nil {
+		t.Fatalf("GenerateScaffold: %v", err)
+	}
+	out := map[string]string{}
+	for name, src := range files {
+		if _, err := parser.ParseFile(token.NewFileSet(), name, src, parser.AllErrors); err != nil {
+			t.Fatalf("%s does not parse: %v\n%s", name, err, src)
+		}
+		out[name] = string(src)
+	}
+	return out
+}
+
+func TestGenerateScaffold(t *testing.T) {
+	files := generateTestScaffold(t, scaffoldDesign)
+
+	for name, want := range map[string][]string{
+		"types.go": {
+			"// Code generated by gork scaffold from-spec. DO NOT EDIT.",
+			"import (\n\t\"time\"\n\n\t\"github.com/gork-labs/gork/pkg/unions\"\n)",
+			"type ListPetsRequest struct {\n\tQuery struct {\n\t\tLimit int32 `gork:\"limit\" validate:\"omitempty,min=1,max=100\"`",
+			"\t\t// Correlation ID.\n\t\tXRequestID string `gork:\"X-Request-Id\" validate:\"required,uuid\"`",
+			"type ListPetsResponse struct {\n\tBody []Pet\n}",
+			"// Pet is a pet.\ntype Pet struct {",
+			"Aliases    []string",
+			"Age        int ",
+			"Any        interface{}",
+			"Attrs      map[string]interface{}",
+			"Born       time.Time",
+			"Code       Code",
+			"Count      int64",
+			"Extra      interface{}",
+			"ID         string                             `gork:\"id\" validate:\"required\"`",
+			"Many       interface{}",
+			"Missing    Missing",
+			"Nickname   *string",
+			"Owner      Owner",
+			"Photo      []byte",
+			"Score      float64",
+			"Toy        unions.Union2[Ball, PetToyOption2]",
+			"Vaccinated bool",
+			"Weight     float32",
+			"type Code string",
+			"type Missing interface{}",
+			"type PetToyOption2 struct {\n\tLength float64 `gork:\"length\"`\n}",
+			"type PostPetsRequest struct {\n\tBody PostPetsRequestBody\n}",
+			"Homepage   string `gork:\"homepage\" validate:\"omitempty,url\"`",
+			"Kind       string `gork:\"kind\" validate:\"omitempty,oneof=cat dog\"`",
+			"Name       string `gork:\"name\" validate:\"required,min=1,max=50\"`",
+			"OwnerEmail string `gork:\"owner_email\" validate:\"omitempty,email\"`",
+			"type DeletePetsByPetIDRequest struct {\n\tPath struct {\n\t\tPetID string `gork:\"petId\" validate:\"required\"`\n\t}\n\tCookies struct {\n\t\tSession string `gork:\"session\"`",
+		},
+		"handlers.go": {
+			"// ListPets handles GET /pets.\nfunc ListPets(_ context.Context, _ ListPetsRequest) (*ListPetsResponse, error) {",
+			"// PostPets handles POST /pets.\n//\n// Create a pet.\nfunc PostPets(",
+			"func DeletePetsByPetID(_ context.Context, _ DeletePetsByPetIDRequest) error {\n\treturn errNotImplemented\n}",
+		},
+		"routes.go": {
+			"Register(method, path string, handler interface{}, opts ...api.Option)",
+			"r.Register(\"GET\", \"/pets\", ListPets, api.WithTags(\"pets\"))",
+			"r.Register(\"POST\", \"/pets\", PostPets)",
+			"r.Register(\"DELETE\", \"/pets/{petId}\", DeletePetsByPetID)",
+		},
+	} {
+		for _, w := range want {
+			if !strings.Contains(files[name], w) {
+				t.Errorf("%s: missing %q in:\n%s", name, w, files[name])
+			}
+		}
+	}
+}
+
+func TestGenerateScaffoldNamingAndEmptySpec(t *testing.T) {
+	files := generateTestScaffold(t, `paths:
+  /a:
+    get: {operationId: getA}
+  /b:
+    get:
+      operationId: get_a
+      parameters: [{name: q, in: query}]
+`)
+	if !strings.Contains(files["handlers.go"], "func GetA2(") || !strings.Contains(files["types.go"], "type GetARequest struct {\n}") {
+		t.Errorf("expected unique names, got:\n%s\n%s", files["handlers.go"], files["types.go"])
+	}
+
+	files = generateTestScaffold(t, `paths: {}`)
+	if strings.Contains(files["handlers.go"], "import") || strings.Contains(files["types.go"], "import") {
+		t.Errorf("expected no imports for empty spec:\n%s\n%s", files["handlers.go"], files["types.go"])
+	}
+}
+
+func TestGenerateScaffoldFormatError(t *testing.T) {
+	spec := &api.OpenAPISpec{Paths: map[string]*api.PathItem{
+		"/a": {Get: &api.Operation{Tags: []string{"x"}, Parameters: []api.Parameter{{Name: "a", In: "query", Schema: &api.Schema{Enum: []string{"`"}}}}}},
+	}}
+	if _, err := GenerateScaffold(spec, "pets"); err == nil || !strings.Contains(err.Error(), "format types.go") {
+		t.Errorf("expected format error, got %v", err)
+	}
+}
+
+func TestGoName(t *testing.T) {
+	for in, want := range map[string]string{
+		"user_id":      "UserID",
+		"userId":       "UserID",
+		"X-Request-Id": "XRequestID",
+		"HTTPServer":   "Httpserver",
+		"2fa":          "X2fa",
+		"":             "X",
+		"api-url":      "APIURL",
+	} {
+		if got := goName(in); got != want {
+			t.Errorf("goName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestPackageNameFromDir(t *testing.T) {
+	for in, want := range map[string]string{
+		"/src/internal/api": "api",
+		"/src/my-service":   "myservice",
+		"/src/2024":         "api",
+		"/":                 "api",
+	} {
+		if got := packageNameFromDir(in); got != want {
+			t.Errorf("packageNameFromDir(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestScaffoldFromSpec(t *testing.T) {
+	dir := t.TempDir()
+	design := filepath.Join(dir, "design.yaml")
+	if err := os.WriteFile(design, []byte(scaffoldDesign), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(dir, "internal", "petapi")
+
+	cmd := newScaffoldCommand()
+	cmd.SetArgs([]string{"from-spec", design, "--output", out})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("scaffold: %v", err)
+	}
+	for _, name := range []string{"types.go", "handlers.go", "routes.go"} {
+		data, err := os.ReadFile(filepath.Join(out, name))
+		if err != nil {
+			t.Fatalf("read %s: %v", name, err)
+		}
+		if !strings.Contains(string(data), "package petapi") {
+			t.Errorf("%s: expected package derived from output directory", name)
+		}
+	}
+
+	// Existing files are protected unless forced.
+	err := ScaffoldFromSpec(&ScaffoldConfig{SpecPath: design, OutputDir: out})
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("expected already exists error, got %v", err)
+	}
+	if err := ScaffoldFromSpec(&ScaffoldConfig{SpecPath: design, OutputDir: out, Package: "custom", Force: true}); err != nil {
+		t.Fatalf("forced scaffold: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(out, "routes.go")); !strings.Contains(string(data), "package custom") {
+		t.Error("expected forced scaffold to overwrite files")
+	}
+	if data, _ := os.ReadFile(filepath.Join(out, "handlers.go")); !strings.Contains(string(data), "package petapi") {
+		t.Error("expected forced scaffold to keep the implemented handlers")
+	}
+}
+
+func TestScaffoldFromSpecErrors(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "design.yaml")
+	invalid := filepath.Join(dir, "invalid.yaml")
+	badFormat := filepath.Join(dir, "bad.json")
+	_ = os.WriteFile(valid, []byte(scaffoldDesign), 0o600)
+	_ = os.WriteFile(invalid, []byte("paths: ["), 0o600)
+	_ = os.WriteFile(badFormat, []byte(`{"paths":{"/a":{"get":{"parameters":[{"name":"a","in":"query","schema":{"enum":["`+"`"+`"]}}]}}}}`), 0o600)
+	blocker := filepath.Join(dir, "file")
+	_ = os.WriteFile(blocker, nil, 0o600)
+	readOnlyTarget := filepath.Join(dir, "target")
+	_ = os.MkdirAll(filepath.Join(readOnlyTarget, "types.go"), 0o750)
+
+	tests := []struct {
+		name string
+		cfg  ScaffoldConfig
+		want string
+	}{
+		{"missing spec", ScaffoldConfig{SpecPath: filepath.Join(dir, "missing.yaml"), OutputDir: dir}, "read spec"},
+		{"invalid spec", ScaffoldConfig{SpecPath: invalid, OutputDir: dir}, "parse OpenAPI document"},
+		{"format error", ScaffoldConfig{SpecPath: badFormat, OutputDir: dir, Package: "x"}, "format types.go"},
+		{"output is a file", ScaffoldConfig{SpecPath: valid, OutputDir: filepath.Join(blocker, "sub"), Package: "x"}, "create output directory"},
+		{"write failure", ScaffoldConfig{SpecPath: valid, OutputDir: readOnlyTarget, Package: "x", Force: true}, "write "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ScaffoldFromSpec(&tt.cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
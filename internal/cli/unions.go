@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+func newUnionsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unions",
+		Short: "Union type utilities",
+	}
+	cmd.AddCommand(newUnionsGenerateCommand())
+	cmd.AddCommand(newUnionsTemplatesCommand())
+	return cmd
+}
+
+func newUnionsGenerateCommand() *cobra.Command {
+	var config UnionsConfig
+
+	cmd := &cobra.Command{
+		Use:   "generate [package-dir...]",
+		Short: "Generate accessors for types defined as unions.UnionN",
+		RunE: func(_ *cobra.Command, args []string) error {
+			config.Dirs = args
+			return GenerateUnionAccessorFiles(&config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.Output, "output", unionsOutputFile, "Name of the generated file in each package directory")
+	cmd.Flags().BoolVar(&config.Colocated, "colocated", false, "Write the accessors of each source file next to it as <file>_unions.go")
+	cmd.Flags().StringVar(&config.TemplateDir, "templates", "", "Directory of *.tmpl files overriding the default templates")
+
+	return cmd
+}
+
+func newUnionsTemplatesCommand() *cobra.Command {
+	var dir string
+
+	cmd := &cobra.Command{
+		Use:   "templates",
+		Short: "Write the default accessor templates as a starting point for --templates",
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return writeDefaultUnionTemplates(dir)
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "output", "templates", "Directory to write the templates to")
+
+	return cmd
+}
+
+// UnionsConfig holds configuration for generating union accessors.
+type UnionsConfig struct {
+	// Dirs are the package directories to scan; the current directory when
+	// empty.
+	Dirs []string
+	// Output is the file the accessors of a package are written to.
+	Output string
+	// Colocated writes the accessors of each source file to a file next to
+	// it instead.
+	Colocated bool
+	// TemplateDir holds *.tmpl files overriding the default templates.
+	TemplateDir string
+}
+
+// GenerateUnionAccessorFiles scans the configured package directories for
+// types defined as unions.UnionN and writes their accessors.
+func GenerateUnionAccessorFiles(config *UnionsConfig) error {
+	tmpl, err := loadUnionTemplates(config.TemplateDir)
+	if err != nil {
+		return err
+	}
+	dirs := config.Dirs
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+	output := config.Output
+	if output == "" {
+		output = unionsOutputFile
+	}
+
+	for _, dir := range dirs {
+		files, err := GenerateUnionAccessors(dir, tmpl, config.Colocated, output)
+		if err != nil {
+			return err
+		}
+		names := make([]string, 0, len(files))
+		for name := range files {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			path := filepath.Join(dir, name)
+			if err := os.WriteFile(path, files[name], 0o600); err != nil {
+				return fmt.Errorf("write %s: %w", path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// writeDefaultUnionTemplates copies the embedded templates to dir.
+func writeDefaultUnionTemplates(dir string) error {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("create template directory: %w", err)
+	}
+	data, err := fs.ReadFile(defaultTemplates, unionsTemplateFile)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, filepath.Base(unionsTemplateFile))
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,363 @@
+package cli
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// unionsImportPath is the import path of the union types.
+const unionsImportPath = "github.com/gork-labs/gork/pkg/unions"
+
+// unionsOutputFile is the default file name of generated accessors.
+const unionsOutputFile = "unions_gen.go"
+
+// unionsTemplateFile is the embedded default template.
+const unionsTemplateFile = "templates/unions.go.tmpl"
+
+//go:embed templates/unions.go.tmpl templates/handlers.go.tmpl
+var defaultTemplates embed.FS
+
+// unionFieldNames are the variant fields of unions.UnionN, in order.
+var unionFieldNames = []string{"A", "B", "C", "D", "E", "F", "G", "H", "I"}
+
+// unionsFileData is the data the "file" template renders.
+type unionsFileData struct {
+	Package string
+	Imports []unionsImport
+	Unions  []unionTypeData
+}
+
+// unionsImport is an import of a generated file.
+type unionsImport struct {
+	Name    string
+	Path    string
+	Renamed bool
+}
+
+// unionTypeData is a type defined as unions.UnionN, rendered by the "union"
+// template.
+type unionTypeData struct {
+	// Name is the defined type, e.g. "PaymentMethod".
+	Name string
+	// Base is the union type it is defined as, e.g.
+	// "unions.Union2[Card, Bank]".
+	Base string
+	// Recv is the receiver name, chosen not to shadow an import.
+	Recv     string
+	Variants []unionVariantData
+}
+
+// unionVariantData is a variant of a union, rendered by the "variant"
+// template.
+type unionVariantData struct {
+	// Union is the defined union type.
+	Union string
+	// Recv and Param are the receiver and parameter names, chosen not to
+	// shadow an import.
+	Recv  string
+	Param string
+	// Field is the union field holding the variant, e.g. "A".
+	Field string
+	// Name is used in accessor names, e.g. "Card" for IsCard.
+	Name string
+	// Type is the variant type, e.g. "Card".
+	Type string
+}
+
+// loadUnionTemplates parses the default templates and then the *.tmpl files
+// of dir, whose definitions replace the defaults of the same name.
+func loadUnionTemplates(dir string) (*template.Template, error) {
+	tmpl, err := template.ParseFS(defaultTemplates, unionsTemplateFile)
+	if err != nil {
+		return nil, err
+	}
+	if dir == "" {
+		return tmpl, nil
+	}
+	matches, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no *.tmpl files in %s", dir)
+	}
+	if tmpl, err = tmpl.ParseFiles(matches...); err != nil {
+		return nil, fmt.Errorf("parse templates: %w", err)
+	}
+	return tmpl, nil
+}
+
+// GenerateUnionAccessors renders the accessors of the unions defined in the
+// package in dir. The result maps file names to formatted Go source: a
+// single output file, or with colocated one <file>_unions.go per source file
+// defining unions.
+func GenerateUnionAccessors(dir string, tmpl *template.Template, colocated bool, output string) (map[string][]byte, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", dir, err)
+	}
+
+	groups := map[string]*unionsFileData{}
+	for _, pkg := range pkgs {
+		for path, file := range pkg.Files {
+			if ast.IsGenerated(file) {
+				continue
+			}
+			unions, imports := collectUnions(file)
+			if len(unions) == 0 {
+				continue
+			}
+			name := output
+			if colocated {
+				name = strings.TrimSuffix(filepath.Base(path), ".go") + "_unions.go"
+			}
+			data := groups[name]
+			if data == nil {
+				data = &unionsFileData{Package: pkg.Name}
+				groups[name] = data
+			}
+			data.Unions = append(data.Unions, unions...)
+			var err error
+			if data.Imports, err = mergeUnionImports(data.Imports, imports); err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+		}
+	}
+
+	files := map[string][]byte{}
+	for name, data := range groups {
+		sort.Slice(data.Unions, func(i, j int) bool { return data.Unions[i].Name < data.Unions[j].Name })
+		var buf bytes.Buffer
+		if err := tmpl.ExecuteTemplate(&buf, "file", data); err != nil {
+			return nil, fmt.Errorf("render %s: %w", name, err)
+		}
+		formatted, err := format.Source(buf.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("format %s: %w", name, err)
+		}
+		files[name] = formatted
+	}
+	return files, nil
+}
+
+// collectUnions returns the types of file defined as unions.UnionN and the
+// imports their variants need.
+func collectUnions(file *ast.File) ([]unionTypeData, []unionsImport) {
+	imports := map[string]unionsImport{}
+	unionsName := ""
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		imp := unionsImport{Name: importName(path), Path: path}
+		if spec.Name != nil {
+			imp.Name, imp.Renamed = spec.Name.Name, true
+		}
+		name := imp.Name
+		imports[name] = imp
+		if path == unionsImportPath {
+			unionsName = name
+		}
+	}
+	if unionsName == "" {
+		return nil, nil
+	}
+
+	used := map[string]bool{unionsName: true}
+	var unions []unionTypeData
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if ts.Assign.IsValid() || ts.TypeParams != nil {
+				continue
+			}
+			args, ok := unionTypeArgs(ts.Type, unionsName)
+			if !ok {
+				continue
+			}
+			union := unionTypeData{Name: ts.Name.Name, Base: exprString(ts.Type), Recv: freeName(imports, "u", "x", "union")}
+			param := freeName(imports, "v", "value", "variant")
+			for i, arg := range args {
+				union.Variants = append(union.Variants, unionVariantData{
+					Union: ts.Name.Name,
+					Recv:  union.Recv,
+					Param: param,
+					Field: unionFieldNames[i],
+					Name:  variantName(arg),
+					Type:  exprString(arg),
+				})
+				ast.Inspect(arg, func(n ast.Node) bool {
+					if sel, ok := n.(*ast.SelectorExpr); ok {
+						if id, ok := sel.X.(*ast.Ident); ok {
+							used[id.Name] = true
+						}
+					}
+					return true
+				})
+			}
+			uniqueVariantNames(union.Variants)
+			unions = append(unions, union)
+		}
+	}
+
+	var needed []unionsImport
+	for name := range used {
+		if imp, ok := imports[name]; ok {
+			needed = append(needed, imp)
+		}
+	}
+	return unions, needed
+}
+
+// unionTypeArgs returns the type arguments of expr when it is an
+// instantiation of unions.UnionN.
+func unionTypeArgs(expr ast.Expr, unionsName string) ([]ast.Expr, bool) {
+	var base ast.Expr
+	var args []ast.Expr
+	switch e := expr.(type) {
+	case *ast.IndexExpr:
+		base, args = e.X, []ast.Expr{e.Index}
+	case *ast.IndexListExpr:
+		base, args = e.X, e.Indices
+	default:
+		return nil, false
+	}
+	sel, ok := base.(*ast.SelectorExpr)
+	if !ok {
+		return nil, false
+	}
+	if id, ok := sel.X.(*ast.Ident); !ok || id.Name != unionsName {
+		return nil, false
+	}
+	if sel.Sel.Name != "Union"+strconv.Itoa(len(args)) || len(args) > len(unionFieldNames) {
+		return nil, false
+	}
+	return args, true
+}
+
+// variantName derives the accessor name of a variant from its type:
+// "Card" for Card and *billing.Card, "CardList" for []Card, "CardMap" for
+// map[string]Card and "String" for string.
+func variantName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return exportName(e.Name)
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	case *ast.StarExpr:
+		return variantName(e.X)
+	case *ast.ArrayType:
+		return suffixName(variantName(e.Elt), "List")
+	case *ast.MapType:
+		return suffixName(variantName(e.Value), "Map")
+	case *ast.IndexExpr:
+		return variantName(e.X)
+	case *ast.IndexListExpr:
+		return variantName(e.X)
+	}
+	return ""
+}
+
+// suffixName appends suffix to a derived name, keeping unnamed types
+// unnamed.
+func suffixName(name, suffix string) string {
+	if name == "" {
+		return ""
+	}
+	return name + suffix
+}
+
+// uniqueVariantNames falls back to the field names, e.g. "VariantA", when
+// variant names are missing or collide.
+func uniqueVariantNames(variants []unionVariantData) {
+	seen := map[string]bool{}
+	unique := true
+	for _, v := range variants {
+		if v.Name == "" || seen[v.Name] {
+			unique = false
+		}
+		seen[v.Name] = true
+	}
+	if unique {
+		return
+	}
+	for i := range variants {
+		variants[i].Name = "Variant" + variants[i].Field
+	}
+}
+
+// mergeUnionImports adds imports to existing, rejecting a name used for two
+// paths.
+func mergeUnionImports(existing, imports []unionsImport) ([]unionsImport, error) {
+	paths := map[string]string{}
+	for _, imp := range existing {
+		paths[imp.Name] = imp.Path
+	}
+	for _, imp := range imports {
+		if path, ok := paths[imp.Name]; ok {
+			if path != imp.Path {
+				return nil, fmt.Errorf("import name %s refers to both %s and %s", imp.Name, path, imp.Path)
+			}
+			continue
+		}
+		paths[imp.Name] = imp.Path
+		existing = append(existing, imp)
+	}
+	sort.Slice(existing, func(i, j int) bool { return existing[i].Path < existing[j].Path })
+	return existing, nil
+}
+
+// importName guesses the package name of an import path that is not
+// renamed: "yaml" for gopkg.in/yaml.v3 and "chi" for .../chi/v5.
+func importName(path string) string {
+	parts := strings.Split(path, "/")
+	name := parts[len(parts)-1]
+	if len(parts) > 1 && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = parts[len(parts)-2]
+	}
+	name, _, _ = strings.Cut(name, ".")
+	return strings.ReplaceAll(strings.TrimPrefix(name, "go-"), "-", "")
+}
+
+// freeName returns the first candidate that is not an import name.
+func freeName(imports map[string]unionsImport, candidates ...string) string {
+	for _, name := range candidates {
+		if _, ok := imports[name]; !ok {
+			return name
+		}
+	}
+	return candidates[len(candidates)-1] + "_"
+}
+
+func exportName(name string) string {
+	if name == "" {
+		return ""
+	}
+	r := []rune(name)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+// exprString prints a type expression as Go source.
+func exprString(expr ast.Expr) string {
+	var buf bytes.Buffer
+	_ = format.Node(&buf, token.NewFileSet(), expr)
+	return buf.String()
+}
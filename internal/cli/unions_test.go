@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const unionsSource = `package billing
+
+import (
+	"time"
+
+	u "github.com/gork-labs/gork/pkg/unions"
+)
+
+type Card struct{ Number string }
+type bank struct{ IBAN string }
+
+// Payment is a payment method.
+type Payment u.Union3[Card, *bank, []time.Duration]
+
+type Dup u.Union2[string, string]
+
+type Alias = u.Union2[Card, bank]
+
+type Many u.Union6[Card, bank, string, int, bool, float64]
+`
+
+func writeUnionsPackage(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"payment.go":      unionsSource,
+		"other.go":        "package billing\n\nimport \"github.com/gork-labs/gork/pkg/unions\"\n\ntype Login unions.Union2[Card, string]\n",
+		"plain.go":        "package billing\n\ntype Plain struct{}\n",
+		"payment_test.go": "package billing\n\nimport \"github.com/gork-labs/gork/pkg/unions\"\n\ntype Fixture unions.Union2[Card, string]\n",
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestGenerateUnionAccessors(t *testing.T) {
+	dir := writeUnionsPackage(t)
+	if err := GenerateUnionAccessorFiles(&UnionsConfig{Dirs: []string{dir}}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, unionsOutputFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := string(data)
+	for _, want := range []string{
+		"// Code generated by gork unions generate. DO NOT EDIT.",
+		`u "github.com/gork-labs/gork/pkg/unions"`,
+		`"github.com/gork-labs/gork/pkg/unions"`,
+		`"time"`,
+		"func (x *Payment) UnmarshalJSON(data []byte) error {\n\treturn (*u.Union3[Card, *bank, []time.Duration])(x).UnmarshalJSON(data)",
+		"func (x Payment) AsBank() (**bank, bool) {",
+		"func (x *Payment) SetDurationList(v []time.Duration) {\n\t*x = Payment{C: &v}",
+		"func (x Dup) IsVariantB() bool {",
+		"func (u Login) IsCard() bool {",
+		"func (x *Many) SetFloat64(v float64) {\n\t*x = Many{F: &v}",
+		"func (x Many) AsString() (*string, bool) {",
+		"type PaymentVisitor interface {\n\tVisitCard(Card)\n\tVisitBank(*bank)\n\tVisitDurationList([]time.Duration)\n}",
+		"func (x Payment) Match(onCard func(Card), onBank func(*bank), onDurationList func([]time.Duration)) {\n\tu.Union3[Card, *bank, []time.Duration](x).Match(onCard, onBank, onDurationList)",
+		"func (x Payment) Visit(visitor PaymentVisitor) {\n\tx.Match(visitor.VisitCard, visitor.VisitBank, visitor.VisitDurationList)",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("expected %q in:\n%s", want, src)
+		}
+	}
+	for _, unwanted := range []string{"Alias", "Fixture"} {
+		if strings.Contains(src, unwanted) {
+			t.Errorf("unexpected accessors for %s", unwanted)
+		}
+	}
+
+	// Generated files are skipped when regenerating.
+	if err := GenerateUnionAccessorFiles(&UnionsConfig{Dirs: []string{dir}}); err != nil {
+		t.Fatalf("regenerating: %v", err)
+	}
+}
+
+func TestGenerateUnionAccessorsColocated(t *testing.T) {
+	dir := writeUnionsPackage(t)
+	tmpl, err := loadUnionTemplates("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := GenerateUnionAccessors(dir, tmpl, true, unionsOutputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 || files["payment_unions.go"] == nil || files["other_unions.go"] == nil {
+		t.Fatalf("expected one file per source file defining unions, got %d", len(files))
+	}
+	if strings.Contains(string(files["other_unions.go"]), "time") {
+		t.Errorf("expected only the imports the file needs, got:\n%s", files["other_unions.go"])
+	}
+}
+
+func TestUnionTemplateOverrides(t *testing.T) {
+	dir := writeUnionsPackage(t)
+	templates := t.TempDir()
+	if err := writeDefaultUnionTemplates(templates); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(templates, "unions.go.tmpl")); err != nil {
+		t.Fatalf("expected the default templates to be written: %v", err)
+	}
+	override := `{{define "variant"}}// {{.Union}} variant {{.Name}} in field {{.Field}}.{{end}}`
+	if err := os.WriteFile(filepath.Join(templates, "variant.tmpl"), []byte(override), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(templates, "unions.go.tmpl")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := GenerateUnionAccessorFiles(&UnionsConfig{Dirs: []string{dir}, TemplateDir: templates, Output: "accessors.go"}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "accessors.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := string(data)
+	if !strings.Contains(src, "// Payment variant Card in field A.") || strings.Contains(src, "IsCard") {
+		t.Errorf("expected the variant template to be replaced, got:\n%s", src)
+	}
+	if !strings.Contains(src, "func (x *Payment) UnmarshalJSON") {
+		t.Errorf("expected the other templates to keep their defaults, got:\n%s", src)
+	}
+
+	if err := GenerateUnionAccessorFiles(&UnionsConfig{Dirs: []string{dir}, TemplateDir: t.TempDir()}); err == nil {
+		t.Error("expected an error for a template directory without templates")
+	}
+}
+
+func TestImportName(t *testing.T) {
+	for path, want := range map[string]string{
+		"time":                        "time",
+		"gopkg.in/yaml.v3":            "yaml",
+		"github.com/go-chi/chi/v5":    "chi",
+		"github.com/labstack/go-echo": "echo",
+	} {
+		if got := importName(path); got != want {
+			t.Errorf("importName(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/gork-labs/gork/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+func newWebhooksCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "webhooks",
+		Short: "Webhook related utilities",
+	}
+	cmd.AddCommand(newCatalogCommand())
+	return cmd
+}
+
+func newCatalogCommand() *cobra.Command {
+	var config CatalogConfig
+
+	cmd := &cobra.Command{
+		Use:   "catalog",
+		Short: "Export a catalog of webhook routes, providers, events and payload schemas",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return ExportWebhookCatalog(&config, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVar(&config.BuildPath, "build", "", "Path to main package to build with '-tags openapi'")
+	cmd.Flags().StringVar(&config.SourcePath, "source", "", "Directory containing Go source code for event handler descriptions")
+	cmd.Flags().StringVar(&config.OutputPath, "output", "events.json", "Path to output file or '-' for stdout")
+	_ = cmd.MarkFlagRequired("build")
+
+	return cmd
+}
+
+// CatalogConfig holds configuration for the webhooks catalog command.
+type CatalogConfig struct {
+	BuildPath  string
+	SourcePath string
+	OutputPath string
+}
+
+// ExportWebhookCatalog builds the application, extracts its OpenAPI spec and
+// writes the webhook catalog derived from it as JSON to the output path, or
+// to stdout when the path is "-".
+func ExportWebhookCatalog(config *CatalogConfig, stdout io.Writer) error {
+	spec, err := buildAndExtract(config.BuildPath)
+	if err != nil {
+		return err
+	}
+
+	catalog := api.BuildWebhookCatalog(spec)
+	if err := describeCatalogEvents(catalog, config.SourcePath); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(catalog, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal catalog: %w", err)
+	}
+	data = append(data, '\n')
+
+	if config.OutputPath == "-" {
+		_, err = stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(filepath.Clean(config.OutputPath), data, 0o600); err != nil {
+		return fmt.Errorf("write catalog: %w", err)
+	}
+	return nil
+}
+
+// describeCatalogEvents fills in event descriptions from the doc comments of
+// the registered event handler functions.
+func describeCatalogEvents(catalog *api.WebhookCatalog, sourcePath string) error {
+	if sourcePath == "" {
+		return nil
+	}
+	extractor := api.NewDocExtractor()
+	if err := extractor.ParseDirectory(sourcePath); err != nil {
+		return fmt.Errorf("failed to parse source: %w", err)
+	}
+	for i := range catalog.Webhooks {
+		for j := range catalog.Webhooks[i].Events {
+			ev := &catalog.Webhooks[i].Events[j]
+			if ev.Description == "" && ev.Handler != "" {
+				ev.Description = extractor.ExtractFunctionDoc(ev.Handler).Description
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gork-labs/gork/pkg/api"
+)
+
+const catalogImplementedSpec = `{"openapi":"3.1.0","info":{"title":"Test","version":"1.0.0"},"paths":{
+	"/users":{"get":{"operationId":"ListUsers","responses":{}}},
+	"/webhooks/stripe":{"post":{"operationId":"StripeWebhook","responses":{},
+		"x-webhook-provider":{"name":"Stripe","website":"https://stripe.com","docs":"https://stripe.com/docs/webhooks"},
+		"x-webhook-events":[{"event":"payment_intent.succeeded","operationId":"HandlePaymentSucceeded","userPayloadSchema":{"$ref":"#/components/schemas/PaymentMetadata"}}]}}},
+	"components":{"schemas":{"PaymentMetadata":{"type":"object","properties":{"user_id":{"type":"string"}}}}}}`
+
+func TestExportWebhookCatalog(t *testing.T) {
+	withBuildRunner(t, &MockBuildRunner{RunOutput: []byte(catalogImplementedSpec)})
+
+	src := t.TempDir()
+	handler := "package handlers\n\n// HandlePaymentSucceeded marks the order as paid.\nfunc HandlePaymentSucceeded() {}\n"
+	if err := os.WriteFile(filepath.Join(src, "handlers.go"), []byte(handler), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	out := filepath.Join(t.TempDir(), "events.json")
+	if err := ExportWebhookCatalog(&CatalogConfig{BuildPath: "./cmd/server", SourcePath: src, OutputPath: out}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var catalog api.WebhookCatalog
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		t.Fatal(err)
+	}
+	if len(catalog.Webhooks) != 1 || catalog.Webhooks[0].Provider["name"] != "Stripe" {
+		t.Fatalf("unexpected catalog %s", data)
+	}
+	ev := catalog.Webhooks[0].Events[0]
+	if ev.Event != "payment_intent.succeeded" || !strings.Contains(ev.Description, "marks the order as paid") {
+		t.Errorf("unexpected event %+v", ev)
+	}
+	if catalog.Schemas["PaymentMetadata"] == nil {
+		t.Errorf("expected payload schema in catalog, got %v", catalog.Schemas)
+	}
+
+	var stdout bytes.Buffer
+	if err := ExportWebhookCatalog(&CatalogConfig{BuildPath: "./cmd/server", OutputPath: "-"}, &stdout); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stdout.String(), `"path": "/webhooks/stripe"`) {
+		t.Errorf("expected catalog on stdout, got %s", stdout.String())
+	}
+}
+
+func TestExportWebhookCatalogErrors(t *testing.T) {
+	withBuildRunner(t, &MockBuildRunner{BuildError: errors.New("boom")})
+	if err := ExportWebhookCatalog(&CatalogConfig{BuildPath: "./cmd/server", OutputPath: "-"}, &bytes.Buffer{}); err == nil {
+		t.Error("expected build error")
+	}
+
+	withBuildRunner(t, &MockBuildRunner{RunOutput: []byte(catalogImplementedSpec)})
+	err := ExportWebhookCatalog(&CatalogConfig{BuildPath: "./cmd/server", OutputPath: filepath.Join(t.TempDir(), "missing", "events.json")}, nil)
+	if err == nil || !strings.Contains(err.Error(), "write catalog") {
+		t.Errorf("expected write error, got %v", err)
+	}
+}
+
+func TestNewWebhooksCommand(t *testing.T) {
+	cmd := newWebhooksCommand()
+	catalog, _, err := cmd.Find([]string{"catalog"})
+	if err != nil || catalog.Use != "catalog" {
+		t.Fatalf("expected catalog subcommand, got %v %v", catalog, err)
+	}
+	if f := catalog.Flags().Lookup("output"); f == nil || f.DefValue != "events.json" {
+		t.Errorf("unexpected output flag %+v", f)
+	}
+}
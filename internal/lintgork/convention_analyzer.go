@@ -5,6 +5,7 @@ import (
 	"go/token"
 	"strings"
 
+	"github.com/gork-labs/gork/pkg/gorkson"
 	"golang.org/x/tools/go/analysis"
 )
 
@@ -23,6 +24,9 @@ func analyzeConventionStructure(file *ast.File, pass *analysis.Pass) {
 		switch node := n.(type) {
 		case *ast.FuncDecl:
 			analyzeHandler(node)
+			if pass != nil {
+				validateValidateMethod(node, pass)
+			}
 		case *ast.TypeSpec:
 			if st, ok := node.Type.(*ast.StructType); ok {
 				analyzeRequestStructure(node.Name.Name, st, pass)
@@ -111,6 +115,32 @@ func analyzeRequestStructure(structName string, st *ast.StructType, pass *analys
 	}
 }
 
+// validateValidateMethod checks that a Validate method of a request struct has
+// one of the signatures the request validator calls, Validate() error or
+// Validate(context.Context) error; otherwise it is silently never called.
+func validateValidateMethod(fn *ast.FuncDecl, reporter Reporter) {
+	if reporter == nil || fn.Recv == nil || len(fn.Recv.List) != 1 || fn.Name.Name != "Validate" {
+		return
+	}
+	recvName := extractTypeName(fn.Recv.List[0].Type)
+	if !isRequestStruct(recvName) {
+		return
+	}
+
+	var params []ast.Expr
+	for _, param := range fn.Type.Params.List {
+		for n := 0; n < len(param.Names) || n == 0 && len(param.Names) == 0; n++ {
+			params = append(params, param.Type)
+		}
+	}
+	validParams := len(params) == 0 || len(params) == 1 && isContextType(params[0])
+	validResults := fn.Type.Results != nil && len(fn.Type.Results.List) == 1 &&
+		len(fn.Type.Results.List[0].Names) <= 1 && isErrorType(fn.Type.Results.List[0].Type)
+	if !validParams || !validResults {
+		reporter.Reportf(fn.Pos(), "method '%s.Validate' must have signature Validate(context.Context) error or Validate() error", recvName)
+	}
+}
+
 // Reporter interface for dependency injection in testing.
 type Reporter interface {
 	Reportf(pos token.Pos, format string, args ...interface{})
@@ -177,7 +207,7 @@ func validateGorkTag(sectionName, fieldName, tagValue string, field *ast.Field,
 	}
 
 	// Parse gork tag
-	parts := strings.Split(gorkTag, ",")
+	parts := gorkson.SplitTag(gorkTag)
 
 	wireFormat := strings.TrimSpace(parts[0])
 	if wireFormat == "" {
@@ -199,7 +229,11 @@ func validateGorkTagOption(sectionName, fieldName, option string, field *ast.Fie
 	}
 
 	if !strings.Contains(option, "=") {
-		reporter.Reportf(field.Pos(), "field '%s.%s' has invalid gork tag option '%s'", sectionName, fieldName, option)
+		switch option {
+		case "sensitive", "omitempty", "omitzero", "nocase", "readonly", "writeonly":
+		default:
+			reporter.Reportf(field.Pos(), "field '%s.%s' has invalid gork tag option '%s'", sectionName, fieldName, option)
+		}
 		return
 	}
 
@@ -213,6 +247,14 @@ func validateGorkTagOption(sectionName, fieldName, option string, field *ast.Fie
 		if value == "" {
 			reporter.Reportf(field.Pos(), "field '%s.%s' discriminator value cannot be empty", sectionName, fieldName)
 		}
+	case "default":
+		if value == "" {
+			reporter.Reportf(field.Pos(), "field '%s.%s' default value cannot be empty", sectionName, fieldName)
+		}
+	case "alias", "example", "audience":
+		if value == "" {
+			reporter.Reportf(field.Pos(), "field '%s.%s' %s value cannot be empty", sectionName, fieldName, key)
+		}
 	default:
 		reporter.Reportf(field.Pos(), "field '%s.%s' unknown gork tag option '%s'", sectionName, fieldName, key)
 	}
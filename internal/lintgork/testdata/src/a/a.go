@@ -28,11 +28,20 @@ type ValidRequest struct {
 // Test struct with Convention Over Configuration sections
 type ConventionRequest struct {
 	Query struct {
-		Limit  int `gork:"limit"`
+		Limit  int `gork:"limit,default=20"`
 		Offset int `gork:"offset"`
 	}
 	Body struct {
-		Data string `gork:"data"`
+		Data     string   `gork:"data"`
+		Password string   `gork:"password,sensitive"`
+		UserID   string   `gork:"user_id,alias=userId|uid"`
+		Tags     []string `gork:"tags,example='a,b'"`
+		Internal string   `gork:"internal,audience=admin"`
+		Note     string   `gork:"note,omitempty"`
+		Count    int      `gork:"count,omitzero"`
+		Email    string   `gork:"email,nocase"`
+		ID       string   `gork:"id,readonly"`
+		Secret   string   `gork:"secret,writeonly"`
 	}
 }
 
@@ -43,9 +52,44 @@ type InvalidRequest struct {
 		BadTag2 string `gork:""`                    // want "field 'Query.BadTag2' has empty gork tag"
 		BadTag3 string `gork:"name,invalid=option"` // want "field 'Query.BadTag3' unknown gork tag option 'invalid'"
 		BadTag4 string `gork:"name,discriminator="` // want "field 'Query.BadTag4' discriminator value cannot be empty"
+		BadTag5 string `gork:"name,default="`       // want "field 'Query.BadTag5' default value cannot be empty"
+		BadTag6 string `gork:"name,secret"`         // want "field 'Query.BadTag6' has invalid gork tag option 'secret'"
+		BadTag7 string `gork:"name,alias="`         // want "field 'Query.BadTag7' alias value cannot be empty"
 	}
 }
 
+// Cross-field validation methods
+type BookingRequest struct {
+	Body struct {
+		Start string `gork:"start"`
+		End   string `gork:"end"`
+	}
+}
+
+func (r *BookingRequest) Validate(ctx context.Context) error {
+	return nil
+}
+
+type RefundRequest struct {
+	Body struct {
+		Amount int `gork:"amount"`
+	}
+}
+
+func (r RefundRequest) Validate(ctx context.Context) bool { // want "method 'RefundRequest.Validate' must have signature Validate\\(context.Context\\) error or Validate\\(\\) error"
+	return true
+}
+
+type TransferRequest struct {
+	Body struct {
+		Amount int `gork:"amount"`
+	}
+}
+
+func (r *TransferRequest) Validate(ctx context.Context, strict bool) error { // want "method 'TransferRequest.Validate' must have signature"
+	return nil
+}
+
 // Test router method calls
 func setupRoutes(router TestRouter) {
 	// Valid router calls with path parameters
@@ -1,27 +1,43 @@
 package chi
 
-import "testing"
+import (
+	"testing"
 
-// TestChiPathFormatNative verifies that Chi uses the same path format
-// as the generic goapi format, so no conversion is needed.
-func TestChiPathFormatNative(t *testing.T) {
-	// Chi uses {param} format natively, which matches goapi's generic format.
-	// No path conversion function is needed or implemented.
+	"github.com/gork-labs/gork/pkg/api"
+)
 
-	testPaths := []string{
-		"/users/{id}",
-		"/api/v1/users/{id}/posts/{postId}",
-		"/docs/*",
-		"/static/files/*",
-		"/health",
+func TestPathConversion(t *testing.T) {
+	tests := []struct {
+		native   string
+		template string
+		patterns map[string]string
+	}{
+		{"/users/{id}", "/users/{id}", nil},
+		{"/users/{id:[0-9]+}", "/users/{id}", map[string]string{"id": "[0-9]+"}},
+		{"/codes/{code:[A-Z]{3}}/{n:\\d+}", "/codes/{code}/{n}", map[string]string{"code": "[A-Z]{3}", "n": "\\d+"}},
+		{"/static/files/*", "/static/files/{path}", map[string]string{"path": ".*"}},
+		{"/health", "/health", nil},
 	}
 
-	// These paths should work as-is with Chi router
-	for _, path := range testPaths {
-		// Chi accepts these paths directly without conversion
-		t.Logf("Chi path format: %s (no conversion needed)", path)
+	for _, tt := range tests {
+		template, opts := fromNativePath(tt.native)
+		if template != tt.template {
+			t.Errorf("fromNativePath(%q) = %q, want %q", tt.native, template, tt.template)
+		}
+		info := &api.RouteInfo{Options: &api.HandlerOption{}}
+		for _, opt := range opts {
+			opt(info.Options)
+		}
+		if len(info.Options.PathPatterns) != len(tt.patterns) {
+			t.Errorf("%q: patterns = %v, want %v", tt.native, info.Options.PathPatterns, tt.patterns)
+		}
+		for name, pattern := range tt.patterns {
+			if info.Options.PathPatterns[name] != pattern {
+				t.Errorf("%q: pattern of %s = %q, want %q", tt.native, name, info.Options.PathPatterns[name], pattern)
+			}
+		}
+		if native := toNativePath(template, info); native != tt.native {
+			t.Errorf("toNativePath(%q) = %q, want %q", template, native, tt.native)
+		}
 	}
-
-	// This test serves as documentation that Chi doesn't need
-	// a toNativePath conversion function like other adapters.
 }
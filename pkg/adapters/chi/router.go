@@ -1,7 +1,9 @@
 package chi
 
 import (
+	"io/fs"
 	"net/http"
+	"strings"
 
 	chibase "github.com/go-chi/chi/v5"
 
@@ -13,6 +15,9 @@ type chiParamAdapter struct{ api.HTTPParameterAdapter }
 
 func (chiParamAdapter) Path(r *http.Request, k string) (string, bool) {
 	v := chibase.URLParamFromCtx(r.Context(), k)
+	if v == "" && k == WildcardParam {
+		v = chibase.URLParamFromCtx(r.Context(), "*")
+	}
 	return v, v != ""
 }
 
@@ -51,8 +56,8 @@ func NewRouter(mux *chibase.Mux, opts ...api.Option) *Router {
 	}
 	registry := api.NewRouteRegistry()
 
-	registerFn := func(method, path string, handler http.HandlerFunc, _ *api.RouteInfo) {
-		mux.Method(method, path, handler)
+	registerFn := func(method, path string, handler http.HandlerFunc, info *api.RouteInfo) {
+		mux.Method(method, toNativePath(path, info), handler)
 	}
 
 	r := &Router{
@@ -75,16 +80,22 @@ func NewRouter(mux *chibase.Mux, opts ...api.Option) *Router {
 }
 
 // Group creates a sub-router with a path prefix that shares the same registry.
-func (r *Router) Group(prefix string) *Router {
-	newPrefix := r.prefix + prefix
-
-	registerFn := func(method, path string, handler http.HandlerFunc, _ *api.RouteInfo) {
-		r.mux.Method(method, newPrefix+path, handler)
+// The prefix may use chi's "{param:regex}" syntax like route paths. The
+// group's routes also inherit opts (tags, security, middleware and other
+// options) on top of the options of r.
+func (r *Router) Group(prefix string, opts ...api.Option) *Router {
+	template, patterns := fromNativePath(prefix)
+	newPrefix := r.prefix + template
+
+	registerFn := func(method, path string, handler http.HandlerFunc, info *api.RouteInfo) {
+		r.mux.Method(method, toNativePath(newPrefix+path, info), handler)
 	}
 
 	// Create a defensive copy of middleware slice to prevent aliasing
-	middlewareCopy := make([]api.Option, len(r.middleware))
+	middlewareCopy := make([]api.Option, len(r.middleware), len(r.middleware)+len(opts)+len(patterns))
 	copy(middlewareCopy, r.middleware)
+	middlewareCopy = append(middlewareCopy, opts...)
+	middlewareCopy = append(middlewareCopy, patterns...)
 
 	return &Router{
 		mux:        r.mux,
@@ -115,32 +126,61 @@ func (r *Router) Unwrap() *chibase.Mux {
 
 // Get registers a GET route.
 func (r *Router) Get(path string, handler interface{}, opts ...api.Option) {
-	r.typedRouter.Register("GET", path, handler, opts...)
+	r.Register(http.MethodGet, path, handler, opts...)
 }
 
 // Post registers a POST route.
 func (r *Router) Post(path string, handler interface{}, opts ...api.Option) {
-	r.typedRouter.Register("POST", path, handler, opts...)
+	r.Register(http.MethodPost, path, handler, opts...)
 }
 
 // Put registers a PUT route.
 func (r *Router) Put(path string, handler interface{}, opts ...api.Option) {
-	r.typedRouter.Register("PUT", path, handler, opts...)
+	r.Register(http.MethodPut, path, handler, opts...)
 }
 
 // Delete registers a DELETE route.
 func (r *Router) Delete(path string, handler interface{}, opts ...api.Option) {
-	r.typedRouter.Register("DELETE", path, handler, opts...)
+	r.Register(http.MethodDelete, path, handler, opts...)
 }
 
 // Patch registers a PATCH route.
 func (r *Router) Patch(path string, handler interface{}, opts ...api.Option) {
-	r.typedRouter.Register("PATCH", path, handler, opts...)
+	r.Register(http.MethodPatch, path, handler, opts...)
 }
 
 // Register registers a route with the given HTTP method, path and handler.
+// Regular expressions of "{param:regex}" placeholders are stripped from the
+// registered path and documented as the parameter's pattern, and a trailing
+// "/*" catch-all is registered as the WildcardParam path parameter.
 func (r *Router) Register(method, path string, handler interface{}, opts ...api.Option) {
-	r.typedRouter.Register(method, path, handler, opts...)
+	template, patterns := fromNativePath(path)
+	r.typedRouter.Register(method, template, handler, append(opts, patterns...)...)
+}
+
+// Mount serves the routes of registry, such as one exposed by a library,
+// under prefix, which may use chi's "{param:regex}" syntax. See
+// api.TypedRouter.Mount.
+func (r *Router) Mount(prefix string, registry *api.RouteRegistry, opts ...api.Option) {
+	template, patterns := fromNativePath(prefix)
+	r.typedRouter.Mount(template, registry, append(opts, patterns...)...)
+}
+
+// Static serves the files of fsys under prefix. See api.TypedRouter.Static.
+func (r *Router) Static(prefix string, fsys fs.FS, opts ...api.Option) {
+	r.typedRouter.Static(prefix, fsys, opts...)
+}
+
+// SPA serves a single-page application from fsys under prefix. See
+// api.TypedRouter.SPA.
+func (r *Router) SPA(prefix string, fsys fs.FS, index string, opts ...api.Option) {
+	r.typedRouter.SPA(prefix, fsys, index, opts...)
+}
+
+// MetricsHandler serves the metrics of the router's api.WithMetrics. See
+// api.TypedRouter.MetricsHandler.
+func (r *Router) MetricsHandler() http.Handler {
+	return r.typedRouter.MetricsHandler()
 }
 
 // DocsRoute delegates to the TypedRouter's DocsRoute method.
@@ -152,3 +192,58 @@ func (r *Router) DocsRoute(path string, cfg ...api.DocsConfig) {
 func (r *Router) ExportOpenAPIAndExit(opts ...api.OpenAPIOption) {
 	r.typedRouter.ExportOpenAPIAndExit(opts...)
 }
+
+// WildcardParam names the path parameter a trailing "/*" catch-all segment is
+// documented and bound as.
+const WildcardParam = "path"
+
+// wildcardPattern is the pattern documented for the WildcardParam.
+const wildcardPattern = ".*"
+
+// fromNativePath converts a chi path to the registry's path template,
+// returning options documenting the patterns it strips.
+func fromNativePath(p string) (string, []api.Option) {
+	var opts []api.Option
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		switch {
+		case segment == "*" && i == len(segments)-1:
+			segments[i] = "{" + WildcardParam + "}"
+			opts = append(opts, api.WithPathPattern(WildcardParam, wildcardPattern))
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}"):
+			name, pattern, ok := strings.Cut(segment[1:len(segment)-1], ":")
+			if !ok {
+				continue
+			}
+			segments[i] = "{" + name + "}"
+			opts = append(opts, api.WithPathPattern(name, pattern))
+		}
+	}
+	return strings.Join(segments, "/"), opts
+}
+
+// toNativePath restores the chi path of a registry path template from the
+// patterns of its route. Routes registered without route information, such
+// as the docs routes, are used as-is.
+func toNativePath(p string, info *api.RouteInfo) string {
+	if info == nil || info.Options == nil || len(info.Options.PathPatterns) == 0 {
+		return p
+	}
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") {
+			continue
+		}
+		name := segment[1 : len(segment)-1]
+		pattern, ok := info.Options.PathPatterns[name]
+		if !ok {
+			continue
+		}
+		if name == WildcardParam && pattern == wildcardPattern && i == len(segments)-1 {
+			segments[i] = "*"
+		} else {
+			segments[i] = "{" + name + ":" + pattern + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
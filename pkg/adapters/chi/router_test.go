@@ -4,6 +4,8 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 
 	chibase "github.com/go-chi/chi/v5"
@@ -436,6 +438,71 @@ func TestRouterRegistration(t *testing.T) {
 	})
 }
 
+func TestRegexAndWildcardRoutes(t *testing.T) {
+	mux := chibase.NewRouter()
+	router := NewRouter(mux)
+	orgs := router.Group("/orgs/{org:[a-z]+}")
+
+	type getUserRequest struct {
+		Path struct {
+			Org string `gork:"org"`
+			ID  int    `gork:"id"`
+		}
+	}
+	type fileRequest struct {
+		Path struct {
+			Path string `gork:"path"`
+		}
+	}
+	type echoResponse struct {
+		Body struct {
+			Value string `gork:"value"`
+		}
+	}
+	orgs.Get("/users/{id:[0-9]+}", func(_ context.Context, req getUserRequest) (*echoResponse, error) {
+		resp := &echoResponse{}
+		resp.Body.Value = req.Path.Org + "/" + strconv.Itoa(req.Path.ID)
+		return resp, nil
+	})
+	router.Get("/files/*", func(_ context.Context, req fileRequest) (*echoResponse, error) {
+		resp := &echoResponse{}
+		resp.Body.Value = req.Path.Path
+		return resp, nil
+	})
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+	if rec := get("/orgs/acme/users/7"); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"acme/7"`) {
+		t.Errorf("regex route: %d %s", rec.Code, rec.Body.String())
+	}
+	if rec := get("/orgs/acme/users/abc"); rec.Code != http.StatusNotFound {
+		t.Errorf("regex route must not match non-digits, got %d", rec.Code)
+	}
+	if rec := get("/files/a/b.txt"); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"a/b.txt"`) {
+		t.Errorf("wildcard route: %d %s", rec.Code, rec.Body.String())
+	}
+
+	spec := api.GenerateOpenAPI(router.GetRegistry())
+	user := spec.Paths["/orgs/{org}/users/{id}"]
+	if user == nil {
+		t.Fatalf("expected regex-free template, got %v", spec.Paths)
+	}
+	patterns := map[string]string{}
+	for _, p := range user.Get.Parameters {
+		patterns[p.Name] = p.Schema.Pattern
+	}
+	if patterns["org"] != "[a-z]+" || patterns["id"] != "[0-9]+" {
+		t.Errorf("expected documented patterns, got %v", patterns)
+	}
+	files := spec.Paths["/files/{path}"]
+	if files == nil || len(files.Get.Parameters) != 1 || files.Get.Parameters[0].Name != WildcardParam {
+		t.Errorf("expected documented wildcard parameter, got %+v", files)
+	}
+}
+
 func TestRouterExportOpenAPIAndExit(t *testing.T) {
 	router := NewRouter(nil)
 
@@ -18,3 +18,21 @@ func TestToNativePath(t *testing.T) {
 		}
 	}
 }
+
+func TestFromNativePath(t *testing.T) {
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{"/users/:id", "/users/{id}"},
+		{"/orgs/:org/users/:id", "/orgs/{org}/users/{id}"},
+		{"/users/{id}", "/users/{id}"},
+		{"/docs/*", "/docs/*"},
+	}
+
+	for _, tt := range tests {
+		if got := fromNativePath(tt.in); got != tt.out {
+			t.Fatalf("fromNativePath(%q) = %q, want %q", tt.in, got, tt.out)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package echo
 
 import (
 	"context"
+	"io/fs"
 	"net/http"
 	"strings"
 
@@ -61,13 +62,7 @@ func NewRouter(e *echosdk.Echo, opts ...api.Option) *Router {
 	registry := api.NewRouteRegistry()
 
 	registerFn := func(method, path string, handler http.HandlerFunc, _ *api.RouteInfo) {
-		nativePath := toNativePath(path)
-		e.Add(method, nativePath, func(ec echosdk.Context) error {
-			// store echo.Context
-			reqWith := ec.Request().WithContext(context.WithValue(ec.Request().Context(), echoCtxKey{}, ec))
-			handler.ServeHTTP(ec.Response().Writer, reqWith)
-			return nil
-		})
+		e.Add(method, toNativePath(path), wrapHandler(handler))
 	}
 
 	r := &Router{
@@ -89,24 +84,29 @@ func NewRouter(e *echosdk.Echo, opts ...api.Option) *Router {
 	return r
 }
 
-// Group creates a sub-router with prefix sharing the same registry.
-func (r *Router) Group(prefix string) *Router {
-	newPrefix := r.prefix + prefix
+// Group creates a sub-router backed by an Echo group with prefix, sharing the
+// same registry. The prefix may use Echo's ":param" syntax; the registry
+// records it as "{param}". The group's routes also inherit opts (tags,
+// security, middleware and other options) on top of the options of r.
+func (r *Router) Group(prefix string, opts ...api.Option) *Router {
+	newPrefix := r.prefix + fromNativePath(prefix)
 	var g *echosdk.Group
 	if r.group != nil {
-		g = r.group.Group(prefix)
+		g = r.group.Group(toNativePath(prefix))
 	} else {
-		g = r.echo.Group(prefix)
+		g = r.echo.Group(toNativePath(prefix))
 	}
 
+	// The Echo group adds its own prefix, so routes are added by their
+	// path relative to it.
 	registerFn := func(method, path string, handler http.HandlerFunc, _ *api.RouteInfo) {
-		nativePath := toNativePath(newPrefix + path)
-		g.Add(method, nativePath, echosdk.WrapHandler(handler))
+		g.Add(method, toNativePath(path), wrapHandler(handler))
 	}
 
 	// Create a defensive copy of middleware slice to prevent aliasing
-	middlewareCopy := make([]api.Option, len(r.middleware))
+	middlewareCopy := make([]api.Option, len(r.middleware), len(r.middleware)+len(opts))
 	copy(middlewareCopy, r.middleware)
+	middlewareCopy = append(middlewareCopy, opts...)
 
 	return &Router{
 		echo:       r.echo,
@@ -138,32 +138,58 @@ func (r *Router) Unwrap() *echosdk.Echo {
 
 // Get registers a GET route.
 func (r *Router) Get(path string, handler interface{}, opts ...api.Option) {
-	r.typedRouter.Register("GET", path, handler, opts...)
+	r.Register(http.MethodGet, path, handler, opts...)
 }
 
 // Post registers a POST route.
 func (r *Router) Post(path string, handler interface{}, opts ...api.Option) {
-	r.typedRouter.Register("POST", path, handler, opts...)
+	r.Register(http.MethodPost, path, handler, opts...)
 }
 
 // Put registers a PUT route.
 func (r *Router) Put(path string, handler interface{}, opts ...api.Option) {
-	r.typedRouter.Register("PUT", path, handler, opts...)
+	r.Register(http.MethodPut, path, handler, opts...)
 }
 
 // Delete registers a DELETE route.
 func (r *Router) Delete(path string, handler interface{}, opts ...api.Option) {
-	r.typedRouter.Register("DELETE", path, handler, opts...)
+	r.Register(http.MethodDelete, path, handler, opts...)
 }
 
 // Patch registers a PATCH route.
 func (r *Router) Patch(path string, handler interface{}, opts ...api.Option) {
-	r.typedRouter.Register("PATCH", path, handler, opts...)
+	r.Register(http.MethodPatch, path, handler, opts...)
 }
 
 // Register registers a route with the given HTTP method, path and handler.
+// The path may use Echo's ":param" syntax; the registry records it as
+// "{param}" so the generated spec shows the OpenAPI template.
 func (r *Router) Register(method, path string, handler interface{}, opts ...api.Option) {
-	r.typedRouter.Register(method, path, handler, opts...)
+	r.typedRouter.Register(method, fromNativePath(path), handler, opts...)
+}
+
+// Mount serves the routes of registry, such as one exposed by a library,
+// under prefix, which may use Echo's ":param" syntax. See
+// api.TypedRouter.Mount.
+func (r *Router) Mount(prefix string, registry *api.RouteRegistry, opts ...api.Option) {
+	r.typedRouter.Mount(fromNativePath(prefix), registry, opts...)
+}
+
+// Static serves the files of fsys under prefix. See api.TypedRouter.Static.
+func (r *Router) Static(prefix string, fsys fs.FS, opts ...api.Option) {
+	r.typedRouter.Static(prefix, fsys, opts...)
+}
+
+// SPA serves a single-page application from fsys under prefix. See
+// api.TypedRouter.SPA.
+func (r *Router) SPA(prefix string, fsys fs.FS, index string, opts ...api.Option) {
+	r.typedRouter.SPA(prefix, fsys, index, opts...)
+}
+
+// MetricsHandler serves the metrics of the router's api.WithMetrics. See
+// api.TypedRouter.MetricsHandler.
+func (r *Router) MetricsHandler() http.Handler {
+	return r.typedRouter.MetricsHandler()
 }
 
 // DocsRoute registers documentation routes.
@@ -176,6 +202,16 @@ func (r *Router) ExportOpenAPIAndExit(opts ...api.OpenAPIOption) {
 	r.typedRouter.ExportOpenAPIAndExit(opts...)
 }
 
+// wrapHandler adapts handler to Echo, storing the echo.Context so path
+// parameters can be read from it.
+func wrapHandler(handler http.HandlerFunc) echosdk.HandlerFunc {
+	return func(ec echosdk.Context) error {
+		req := ec.Request().WithContext(context.WithValue(ec.Request().Context(), echoCtxKey{}, ec))
+		handler.ServeHTTP(ec.Response().Writer, req)
+		return nil
+	}
+}
+
 // toNativePath converts {param} placeholders to :param expected by Echo.
 func toNativePath(p string) string {
 	// Convert named params {id} -> :id
@@ -189,3 +225,15 @@ func toNativePath(p string) string {
 
 	return s
 }
+
+// fromNativePath converts Echo's :param segments to the {param} placeholders
+// used in the registry. Other segments are left untouched.
+func fromNativePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") && len(segment) > 1 {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gork-labs/gork/pkg/api"
@@ -452,6 +453,45 @@ func TestRouterIntegration(t *testing.T) {
 	}
 }
 
+func TestGroupRoutesWithEchoPaths(t *testing.T) {
+	e := echo.New()
+	router := NewRouter(e)
+	users := router.Group("/orgs/:org").Group("/users")
+
+	type getUserRequest struct {
+		Path struct {
+			Org string `gork:"org"`
+			ID  string `gork:"id"`
+		}
+	}
+	type getUserResponse struct {
+		Body struct {
+			ID string `gork:"id"`
+		}
+	}
+	users.Get("/:id", func(_ context.Context, req getUserRequest) (*getUserResponse, error) {
+		resp := &getUserResponse{}
+		resp.Body.ID = req.Path.Org + "/" + req.Path.ID
+		return resp, nil
+	})
+
+	routes := router.GetRegistry().GetRoutes()
+	if len(routes) != 1 || routes[0].Path != "/orgs/{org}/users/{id}" {
+		t.Fatalf("expected registry path /orgs/{org}/users/{id}, got %+v", routes)
+	}
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orgs/acme/users/7", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"acme/7"`) {
+		t.Fatalf("expected group route with path params, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	spec := api.GenerateOpenAPI(router.GetRegistry())
+	if spec.Paths["/orgs/{org}/users/{id}"] == nil {
+		t.Errorf("expected templated path in spec, got %v", spec.Paths)
+	}
+}
+
 func TestRouterExportOpenAPIAndExit(t *testing.T) {
 	router := NewRouter(echo.New())
 
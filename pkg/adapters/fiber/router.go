@@ -3,6 +3,7 @@ package fiber
 import (
 	"context"
 	"io"
+	"io/fs"
 	"net/http"
 	"strings"
 
@@ -42,6 +43,21 @@ func (fiberParamAdapter) Query(r *http.Request, k string) (string, bool) {
 	return v, v != ""
 }
 
+func (fiberParamAdapter) QueryValues(r *http.Request, k string) []string {
+	// Extract fiber context from request context
+	if ctx := r.Context().Value(fiberCtxKey{}); ctx != nil {
+		if c, ok := ctx.(*fiber.Ctx); ok {
+			var values []string
+			for _, v := range c.Context().QueryArgs().PeekMulti(k) {
+				values = append(values, string(v))
+			}
+			return values
+		}
+	}
+	// Fallback to regular query parsing
+	return r.URL.Query()[k]
+}
+
 func (fiberParamAdapter) Header(r *http.Request, k string) (string, bool) {
 	// Extract fiber context from request context
 	if ctx := r.Context().Value(fiberCtxKey{}); ctx != nil {
@@ -55,6 +71,21 @@ func (fiberParamAdapter) Header(r *http.Request, k string) (string, bool) {
 	return v, v != ""
 }
 
+func (fiberParamAdapter) HeaderValues(r *http.Request, k string) []string {
+	// Extract fiber context from request context
+	if ctx := r.Context().Value(fiberCtxKey{}); ctx != nil {
+		if c, ok := ctx.(*fiber.Ctx); ok {
+			var values []string
+			for _, v := range c.Request().Header.PeekAll(k) {
+				values = append(values, string(v))
+			}
+			return values
+		}
+	}
+	// Fallback to regular header parsing
+	return r.Header.Values(k)
+}
+
 func (fiberParamAdapter) Cookie(r *http.Request, k string) (string, bool) {
 	// Extract fiber context from request context
 	if ctx := r.Context().Value(fiberCtxKey{}); ctx != nil {
@@ -172,14 +203,17 @@ func createRegisterFn(g fiber.Router, newPrefix string) func(method, path string
 }
 
 // Group creates a sub-router with prefix sharing the same registry.
-func (r *Router) Group(prefix string) *Router {
+// The group's routes also inherit opts (tags, security, middleware and other
+// options) on top of the options of r.
+func (r *Router) Group(prefix string, opts ...api.Option) *Router {
 	newPrefix := r.prefix + prefix
 	g := r.app.Group(prefix)
 	registerFn := createRegisterFn(g, newPrefix)
 
 	// Create a defensive copy of middleware slice to prevent aliasing
-	middlewareCopy := make([]api.Option, len(r.middleware))
+	middlewareCopy := make([]api.Option, len(r.middleware), len(r.middleware)+len(opts))
 	copy(middlewareCopy, r.middleware)
+	middlewareCopy = append(middlewareCopy, opts...)
 
 	return &Router{
 		app:        r.app,
@@ -238,6 +272,29 @@ func (r *Router) Register(method, path string, handler interface{}, opts ...api.
 	r.typedRouter.Register(method, path, handler, opts...)
 }
 
+// Mount serves the routes of registry, such as one exposed by a library,
+// under prefix. See api.TypedRouter.Mount.
+func (r *Router) Mount(prefix string, registry *api.RouteRegistry, opts ...api.Option) {
+	r.typedRouter.Mount(prefix, registry, opts...)
+}
+
+// Static serves the files of fsys under prefix. See api.TypedRouter.Static.
+func (r *Router) Static(prefix string, fsys fs.FS, opts ...api.Option) {
+	r.typedRouter.Static(prefix, fsys, opts...)
+}
+
+// SPA serves a single-page application from fsys under prefix. See
+// api.TypedRouter.SPA.
+func (r *Router) SPA(prefix string, fsys fs.FS, index string, opts ...api.Option) {
+	r.typedRouter.SPA(prefix, fsys, index, opts...)
+}
+
+// MetricsHandler serves the metrics of the router's api.WithMetrics. See
+// api.TypedRouter.MetricsHandler.
+func (r *Router) MetricsHandler() http.Handler {
+	return r.typedRouter.MetricsHandler()
+}
+
 // DocsRoute registers documentation routes.
 func (r *Router) DocsRoute(path string, cfg ...api.DocsConfig) {
 	r.typedRouter.DocsRoute(path, cfg...)
@@ -1,6 +1,7 @@
 package gorilla
 
 import (
+	"io/fs"
 	"net/http"
 	"strings"
 
@@ -79,7 +80,9 @@ func NewRouter(r *muxpkg.Router, opts ...api.Option) *Router {
 }
 
 // Group creates a sub-router with prefix sharing the same registry.
-func (wr *Router) Group(prefix string) *Router {
+// The group's routes also inherit opts (tags, security, middleware and other
+// options) on top of the options of r.
+func (wr *Router) Group(prefix string, opts ...api.Option) *Router {
 	newPrefix := wr.prefix + prefix
 	sub := wr.router.PathPrefix(prefix).Subrouter()
 
@@ -88,8 +91,9 @@ func (wr *Router) Group(prefix string) *Router {
 	}
 
 	// Create a defensive copy of middleware slice to prevent aliasing
-	middlewareCopy := make([]api.Option, len(wr.middleware))
+	middlewareCopy := make([]api.Option, len(wr.middleware), len(wr.middleware)+len(opts))
 	copy(middlewareCopy, wr.middleware)
+	middlewareCopy = append(middlewareCopy, opts...)
 
 	return &Router{
 		router:     sub,
@@ -143,6 +147,29 @@ func (wr *Router) Register(method, path string, handler interface{}, opts ...api
 	wr.typedRouter.Register(method, path, handler, opts...)
 }
 
+// Mount serves the routes of registry, such as one exposed by a library,
+// under prefix. See api.TypedRouter.Mount.
+func (wr *Router) Mount(prefix string, registry *api.RouteRegistry, opts ...api.Option) {
+	wr.typedRouter.Mount(prefix, registry, opts...)
+}
+
+// Static serves the files of fsys under prefix. See api.TypedRouter.Static.
+func (wr *Router) Static(prefix string, fsys fs.FS, opts ...api.Option) {
+	wr.typedRouter.Static(prefix, fsys, opts...)
+}
+
+// SPA serves a single-page application from fsys under prefix. See
+// api.TypedRouter.SPA.
+func (wr *Router) SPA(prefix string, fsys fs.FS, index string, opts ...api.Option) {
+	wr.typedRouter.SPA(prefix, fsys, index, opts...)
+}
+
+// MetricsHandler serves the metrics of the router's api.WithMetrics. See
+// api.TypedRouter.MetricsHandler.
+func (wr *Router) MetricsHandler() http.Handler {
+	return wr.typedRouter.MetricsHandler()
+}
+
 // DocsRoute registers documentation routes.
 func (wr *Router) DocsRoute(path string, cfg ...api.DocsConfig) {
 	wr.typedRouter.DocsRoute(path, cfg...)
@@ -1,6 +1,7 @@
 package stdlib
 
 import (
+	"io/fs"
 	"net/http"
 	"strings"
 
@@ -81,7 +82,9 @@ func NewRouter(mux *http.ServeMux, opts ...api.Option) *Router {
 }
 
 // Group creates a sub-router that shares the same registry and path prefix.
-func (r *Router) Group(prefix string) *Router {
+// The group's routes also inherit opts (tags, security, middleware and other
+// options) on top of the options of r.
+func (r *Router) Group(prefix string, opts ...api.Option) *Router {
 	newPrefix := r.prefix + prefix
 
 	registerFn := func(method, path string, handler http.HandlerFunc, _ *api.RouteInfo) {
@@ -90,8 +93,9 @@ func (r *Router) Group(prefix string) *Router {
 	}
 
 	// Create a defensive copy of middleware slice to prevent aliasing
-	middlewareCopy := make([]api.Option, len(r.middleware))
+	middlewareCopy := make([]api.Option, len(r.middleware), len(r.middleware)+len(opts))
 	copy(middlewareCopy, r.middleware)
+	middlewareCopy = append(middlewareCopy, opts...)
 
 	return &Router{
 		mux:        r.mux,
@@ -145,6 +149,29 @@ func (r *Router) Register(method, path string, handler interface{}, opts ...api.
 	r.typedRouter.Register(method, path, handler, opts...)
 }
 
+// Mount serves the routes of registry, such as one exposed by a library,
+// under prefix. See api.TypedRouter.Mount.
+func (r *Router) Mount(prefix string, registry *api.RouteRegistry, opts ...api.Option) {
+	r.typedRouter.Mount(prefix, registry, opts...)
+}
+
+// Static serves the files of fsys under prefix. See api.TypedRouter.Static.
+func (r *Router) Static(prefix string, fsys fs.FS, opts ...api.Option) {
+	r.typedRouter.Static(prefix, fsys, opts...)
+}
+
+// SPA serves a single-page application from fsys under prefix. See
+// api.TypedRouter.SPA.
+func (r *Router) SPA(prefix string, fsys fs.FS, index string, opts ...api.Option) {
+	r.typedRouter.SPA(prefix, fsys, index, opts...)
+}
+
+// MetricsHandler serves the metrics of the router's api.WithMetrics. See
+// api.TypedRouter.MetricsHandler.
+func (r *Router) MetricsHandler() http.Handler {
+	return r.typedRouter.MetricsHandler()
+}
+
 // DocsRoute registers documentation routes.
 func (r *Router) DocsRoute(path string, cfg ...api.DocsConfig) {
 	r.typedRouter.DocsRoute(path, cfg...)
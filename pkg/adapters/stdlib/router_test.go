@@ -324,6 +324,35 @@ func TestRouterGroup(t *testing.T) {
 			t.Error("Route was not registered with correct group prefix")
 		}
 	})
+
+	t.Run("group options", func(t *testing.T) {
+		router := NewRouter(nil)
+		var calls []string
+		mark := func(name string) func(http.Handler) http.Handler {
+			return func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					calls = append(calls, name)
+					next.ServeHTTP(w, r)
+				})
+			}
+		}
+		v1 := router.Group("/v1", api.WithTags("v1"), api.WithMiddleware(mark("v1")))
+		admin := v1.Group("/admin", api.WithMiddleware(mark("admin")))
+		admin.Get("/stats", func(context.Context, struct{}) (*struct{ Body struct{} }, error) {
+			return &struct{ Body struct{} }{}, nil
+		}, api.WithTags("stats"))
+
+		w := httptest.NewRecorder()
+		router.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/admin/stats", nil))
+		if w.Code != http.StatusOK || len(calls) != 2 || calls[0] != "v1" || calls[1] != "admin" {
+			t.Errorf("expected group middleware in order, got %d %v", w.Code, calls)
+		}
+
+		op := api.GenerateOpenAPI(router.GetRegistry()).Paths["/v1/admin/stats"].Get
+		if op == nil || len(op.Tags) != 2 || op.Tags[0] != "v1" || op.Tags[1] != "stats" {
+			t.Errorf("expected inherited tags in the spec, got %+v", op)
+		}
+	})
 }
 
 func TestRouterRegister(t *testing.T) {
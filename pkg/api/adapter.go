@@ -2,30 +2,144 @@
 package api
 
 import (
-	"encoding/json"
 	"log"
 	"net/http"
 	"reflect"
 	"runtime"
 	"strings"
+	"time"
 )
 
 // HandlerOption represents an option for configuring a handler.
 type HandlerOption struct {
 	Tags     []string
 	Security []SecurityRequirement
+
+	// Summary and Description document the operation in place of the
+	// handler's doc comment. Set with WithSummary and WithDescription.
+	Summary     string
+	Description string
+
+	// LoadShedder rejects excess requests before parsing when set.
+	LoadShedder *LoadShedder
+
+	// RateLimiter rejects requests it denies with 429 before parsing when
+	// set. Set with WithRateLimit.
+	RateLimiter RateLimiter
+
+	// Examples holds named example requests registered with WithExample.
+	Examples []RequestExample
+
+	// ResponseExamples holds named example responses registered with
+	// WithResponseExample.
+	ResponseExamples []ResponseExample
+
+	// WebhookProvider overrides the provider metadata reported by a webhook
+	// handler. Set with WithWebhookProvider.
+	WebhookProvider *WebhookProviderInfo
+
+	// FormBody documents form-urlencoded request bodies. Set with WithFormBody.
+	FormBody bool
+
+	// StrictBody rejects JSON bodies with unknown fields. Set with
+	// WithStrictBody.
+	StrictBody bool
+
+	// GeneratedBinders binds and validates requests and encodes responses
+	// with the code generated by `gork handlers generate` where available.
+	// Set with WithGeneratedBinders.
+	GeneratedBinders bool
+
+	// Decompression decodes gzip/deflate request bodies when set. Set with
+	// WithRequestDecompression.
+	Decompression *DecompressionConfig
+
+	// MaxBodySize limits request bodies in bytes when positive. Set with
+	// WithMaxBodySize.
+	MaxBodySize int64
+
+	// ErrorResponses maps domain error types to status codes. Set with
+	// WithErrorResponse.
+	ErrorResponses []ErrorResponseMapping
+
+	// Callbacks documents requests the route sends to client-supplied URLs.
+	// Set with WithCallback.
+	Callbacks []Callback
+
+	// Audience is the default audience responses are rendered for. Set with
+	// WithAudience.
+	Audience string
+	// Middleware wraps the route's handler, first entry outermost. Set with
+	// WithMiddleware.
+	Middleware []func(http.Handler) http.Handler
+	// TypedMiddleware wraps the handler call with access to the parsed
+	// request and the response, first entry outermost. Set with
+	// WithTypedMiddleware.
+	TypedMiddleware []TypedMiddleware
+
+	// PanicHandler receives panics recovered from the handler. Set with
+	// WithPanicHandler.
+	PanicHandler PanicHandler
+
+	// ProblemJSON reports generated errors as application/problem+json. Set
+	// with WithProblemJSON.
+	ProblemJSON bool
+
+	// AutoHead answers HEAD requests for GET routes. Set with WithAutoHead.
+	AutoHead bool
+	// AutoOptions answers OPTIONS requests with the path's Allow header. Set
+	// with WithAutoOptions.
+	AutoOptions bool
+
+	// CORS answers cross-origin and preflight requests when set. Set with
+	// WithCORS.
+	CORS *CORSConfig
+
+	// PathPatterns maps path parameter names to the regular expressions
+	// they must match. Set with WithPathPattern.
+	PathPatterns map[string]string
+
+	// DocumentStatic documents Static and SPA routes. Set with
+	// WithStaticDocs.
+	DocumentStatic bool
+
+	// Timeout bounds the time the handler may take. Set with WithTimeout.
+	Timeout time.Duration
+
+	// RequestID assigns every request an ID. Set with WithRequestID.
+	RequestID bool
+
+	// Metrics records the route's requests. Set with WithMetrics.
+	Metrics Metrics
+
+	// Cache sets caching headers and optionally caches responses in
+	// memory. Set with WithCache.
+	Cache *CachePolicy
+
+	// Extensions holds vendor extensions added to the route's operation.
+	// Set with WithExtension.
+	Extensions map[string]interface{}
+
+	// Internal leaves the route out of specs not generated with
+	// WithIncludeInternal. Set with WithInternal.
+	Internal bool
+
+	// TokenVerifier verifies the bearer tokens populating Auth sections.
+	// Set with WithTokenVerifier.
+	TokenVerifier TokenVerifier
 }
 
 // SecurityRequirement represents a security requirement for an operation.
 type SecurityRequirement struct {
 	Type   string   // "basic", "bearer", "apiKey"
+	Scheme string   // Name of a declared security scheme; set with WithSecurity
 	Scopes []string // For OAuth2
 }
 
 // Option is a function that modifies HandlerOption.
 type Option func(*HandlerOption)
 
-// WithTags adds tags to the handler.
+// WithTags adds tags to the handler. Describe them with RegisterTag.
 func WithTags(tags ...string) Option {
 	return func(h *HandlerOption) {
 		h.Tags = append(h.Tags, tags...)
@@ -74,11 +188,7 @@ func writeError(w http.ResponseWriter, code int, message string) {
 		log.Printf("http %d: %s", code, message)
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(code)
-	_ = json.NewEncoder(w).Encode(map[string]string{
-		"error": clientMessage,
-	})
+	writeErrorResponse(w, code, ErrorResponse{Error: clientMessage})
 }
 
 // FunctionNameExtractor allows dependency injection for testing.
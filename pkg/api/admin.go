@@ -0,0 +1,276 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"runtime"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AdminConfig configures AdminRoutes.
+type AdminConfig struct {
+	// Authenticate guards every admin endpoint. Without it all requests are
+	// refused with 403, so the router state is never exposed by accident.
+	Authenticate Authenticator
+	// TypeParsers is reported under typeParsers when set.
+	TypeParsers *TypeParserRegistry
+	// OpenAPIOptions are passed to GenerateOpenAPI when collecting the
+	// generation metadata.
+	OpenAPIOptions []OpenAPIOption
+}
+
+// AdminSnapshot is the runtime state served by AdminRoutes.
+type AdminSnapshot struct {
+	Routes      []AdminRoute    `json:"routes"`
+	Middleware  AdminOptions    `json:"middleware"`
+	TypeParsers []string        `json:"typeParsers"`
+	Generation  AdminGeneration `json:"generation"`
+}
+
+// AdminRoute describes a registered route and its effective options.
+type AdminRoute struct {
+	RouteTableEntry
+	Options AdminOptions `json:"options"`
+}
+
+// AdminOptions summarizes a HandlerOption. Options are functions and cannot
+// be listed themselves, so the router middleware is reported by its effect.
+type AdminOptions struct {
+	Tags             []string `json:"tags,omitempty"`
+	Security         []string `json:"security,omitempty"`
+	TokenVerifier    string   `json:"tokenVerifier,omitempty"`
+	LoadShedLimit    int      `json:"loadShedLimit,omitempty"`
+	RateLimited      bool     `json:"rateLimited,omitempty"`
+	Decompression    bool     `json:"decompression,omitempty"`
+	MaxBodySize      int64    `json:"maxBodySize,omitempty"`
+	StrictBody       bool     `json:"strictBody,omitempty"`
+	GeneratedBinders bool     `json:"generatedBinders,omitempty"`
+	Audience         string   `json:"audience,omitempty"`
+	ErrorResponses   []string `json:"errorResponses,omitempty"`
+	Examples         []string `json:"examples,omitempty"`
+	FormBody         bool     `json:"formBody,omitempty"`
+	HTTPMiddleware   int      `json:"httpMiddleware,omitempty"`
+	TypedMiddleware  int      `json:"typedMiddleware,omitempty"`
+	PanicHandler     bool     `json:"panicHandler,omitempty"`
+	ProblemJSON      bool     `json:"problemJSON,omitempty"`
+	AutoHead         bool     `json:"autoHead,omitempty"`
+	AutoOptions      bool     `json:"autoOptions,omitempty"`
+	CORSOrigins      []string `json:"corsOrigins,omitempty"`
+	Timeout          string   `json:"timeout,omitempty"`
+	RequestID        bool     `json:"requestID,omitempty"`
+	Metrics          bool     `json:"metrics,omitempty"`
+	Cache            string   `json:"cache,omitempty"`
+}
+
+// AdminGeneration reports how the OpenAPI spec is currently generated.
+// Undocumented lists the HEAD and OPTIONS methods the router answers, e.g.
+// "HEAD /users", WithAutoHead and WithAutoOptions included: path items hold
+// no such operations, so they are not counted in Operations.
+type AdminGeneration struct {
+	OpenAPIVersion  string    `json:"openapiVersion"`
+	Paths           int       `json:"paths"`
+	Operations      int       `json:"operations"`
+	Undocumented    []string  `json:"undocumented,omitempty"`
+	Schemas         int       `json:"schemas"`
+	SecuritySchemes []string  `json:"securitySchemes,omitempty"`
+	DurationMillis  float64   `json:"durationMillis"`
+	GeneratedAt     time.Time `json:"generatedAt"`
+	GoVersion       string    `json:"goVersion"`
+}
+
+// AdminRoutes mounts read-only JSON endpoints describing the router for
+// debugging deployed services: the full snapshot at path, and its sections at
+// path/routes, path/middleware, path/type-parsers and path/generation. The
+// endpoints are not added to the route registry and thus never documented.
+func (r *TypedRouter[T]) AdminRoutes(path string, cfg ...AdminConfig) {
+	var conf AdminConfig
+	if len(cfg) > 0 {
+		conf = cfg[0]
+	}
+	if r.registerFn == nil {
+		return
+	}
+	base := strings.TrimSuffix(path, "/")
+	sections := []struct {
+		suffix string
+		render func(AdminSnapshot) interface{}
+	}{
+		{"", func(s AdminSnapshot) interface{} { return s }},
+		{"/routes", func(s AdminSnapshot) interface{} { return s.Routes }},
+		{"/middleware", func(s AdminSnapshot) interface{} { return s.Middleware }},
+		{"/type-parsers", func(s AdminSnapshot) interface{} { return s.TypeParsers }},
+		{"/generation", func(s AdminSnapshot) interface{} { return s.Generation }},
+	}
+	for _, section := range sections {
+		r.registerFn(http.MethodGet, base+section.suffix, r.adminHandler(conf, section.render), nil)
+	}
+}
+
+// adminHandler authenticates the request and serves one section of the snapshot.
+func (r *TypedRouter[T]) adminHandler(conf AdminConfig, section func(AdminSnapshot) interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if conf.Authenticate == nil {
+			writeError(w, http.StatusForbidden, "admin endpoints require an authenticator")
+			return
+		}
+		if _, err := conf.Authenticate(req, nil); err != nil {
+			writeAuthError(w, err, "")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		_ = json.NewEncoder(w).Encode(section(r.AdminSnapshot(conf)))
+	}
+}
+
+// AdminSnapshot collects the runtime state served by AdminRoutes.
+func (r *TypedRouter[T]) AdminSnapshot(conf AdminConfig) AdminSnapshot {
+	snapshot := AdminSnapshot{
+		Routes:      []AdminRoute{},
+		TypeParsers: []string{},
+	}
+
+	options := map[string]*HandlerOption{}
+	for _, route := range r.registry.GetRoutes() {
+		options[route.Method+" "+route.Path] = route.Options
+	}
+	for _, entry := range r.registry.RouteTable() {
+		snapshot.Routes = append(snapshot.Routes, AdminRoute{
+			RouteTableEntry: entry,
+			Options:         describeOptions(options[entry.key()]),
+		})
+	}
+
+	middleware := &HandlerOption{}
+	for _, opt := range r.middleware {
+		opt(middleware)
+	}
+	snapshot.Middleware = describeOptions(middleware)
+
+	if conf.TypeParsers != nil {
+		for _, t := range conf.TypeParsers.ListRegisteredTypes() {
+			snapshot.TypeParsers = append(snapshot.TypeParsers, typeName(t))
+		}
+		sort.Strings(snapshot.TypeParsers)
+	}
+
+	snapshot.Generation = describeGeneration(r.registry, conf.OpenAPIOptions)
+	return snapshot
+}
+
+// describeOptions summarizes the options of a route or of the router middleware.
+func describeOptions(h *HandlerOption) AdminOptions {
+	var out AdminOptions
+	if h == nil {
+		return out
+	}
+	out.Tags = h.Tags
+	for _, sec := range h.Security {
+		name := sec.Scheme
+		if name == "" {
+			name = sec.Type
+		}
+		if len(sec.Scopes) > 0 {
+			name += " (" + strings.Join(sec.Scopes, ", ") + ")"
+		}
+		out.Security = append(out.Security, name)
+	}
+	if h.TokenVerifier != nil {
+		out.TokenVerifier = fmt.Sprintf("%T", h.TokenVerifier)
+	}
+	if h.LoadShedder != nil {
+		out.LoadShedLimit = h.LoadShedder.Limit()
+	}
+	out.RateLimited = h.RateLimiter != nil
+	out.Decompression = h.Decompression != nil
+	out.MaxBodySize = h.MaxBodySize
+	out.StrictBody = h.StrictBody
+	out.GeneratedBinders = h.GeneratedBinders
+	out.Audience = h.Audience
+	for _, m := range h.ErrorResponses {
+		out.ErrorResponses = append(out.ErrorResponses, strconv.Itoa(m.Status)+" "+m.Type.String())
+	}
+	for _, ex := range h.Examples {
+		out.Examples = append(out.Examples, ex.Name)
+	}
+	out.FormBody = h.FormBody
+	out.HTTPMiddleware = len(h.Middleware)
+	out.TypedMiddleware = len(h.TypedMiddleware)
+	out.PanicHandler = h.PanicHandler != nil
+	out.ProblemJSON = h.ProblemJSON
+	out.AutoHead = h.AutoHead
+	out.AutoOptions = h.AutoOptions
+	if h.CORS != nil {
+		out.CORSOrigins = h.CORS.AllowedOrigins
+		if h.CORS.AllowOriginFunc != nil {
+			out.CORSOrigins = append(slices.Clone(out.CORSOrigins), "(func)")
+		}
+	}
+	if h.Timeout > 0 {
+		out.Timeout = h.Timeout.String()
+	}
+	out.RequestID = h.RequestID
+	out.Metrics = h.Metrics != nil
+	if h.Cache != nil {
+		out.Cache = h.Cache.String()
+	}
+	return out
+}
+
+// describeGeneration generates the spec and reports its size and cost.
+func describeGeneration(registry *RouteRegistry, opts []OpenAPIOption) AdminGeneration {
+	start := time.Now()
+	spec := GenerateOpenAPI(registry, opts...)
+	gen := AdminGeneration{
+		OpenAPIVersion: spec.OpenAPI,
+		Paths:          len(spec.Paths),
+		DurationMillis: float64(time.Since(start).Microseconds()) / 1000,
+		GeneratedAt:    start.UTC(),
+		GoVersion:      runtime.Version(),
+	}
+	for _, item := range spec.Paths {
+		for _, op := range []*Operation{item.Get, item.Post, item.Put, item.Patch, item.Delete} {
+			if op != nil {
+				gen.Operations++
+			}
+		}
+	}
+	gen.Undocumented = registry.headAndOptionsRoutes()
+	if spec.Components != nil {
+		gen.Schemas = len(spec.Components.Schemas)
+		for name := range spec.Components.SecuritySchemes {
+			gen.SecuritySchemes = append(gen.SecuritySchemes, name)
+		}
+		sort.Strings(gen.SecuritySchemes)
+	}
+	return gen
+}
+
+// headAndOptionsRoutes lists the HEAD and OPTIONS methods answered by the
+// router, registered explicitly or by WithAutoHead, WithAutoOptions and
+// WithCORS, sorted.
+func (r *RouteRegistry) headAndOptionsRoutes() []string {
+	seen := map[string]bool{}
+	for _, route := range r.GetRoutes() {
+		switch {
+		case route.Method == http.MethodHead || route.Method == http.MethodOptions:
+			seen[route.Method+" "+route.Path] = true
+		case route.Method == http.MethodGet && route.Options != nil && route.Options.AutoHead:
+			seen[http.MethodHead+" "+route.Path] = true
+		}
+	}
+	r.mu.RLock()
+	for path := range r.autoOptions {
+		seen[http.MethodOptions+" "+path] = true
+	}
+	r.mu.RUnlock()
+	routes := slices.Collect(maps.Keys(seen))
+	sort.Strings(routes)
+	return routes
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func adminAuthenticator(r *http.Request, _ []string) (context.Context, error) {
+	if r.Header.Get("X-Admin-Token") != "secret" {
+		return nil, errors.New("admin token required")
+	}
+	return nil, nil
+}
+
+func TestAdminRoutes(t *testing.T) {
+	router, _, handlers := newLoadShedRouter(WithTags("orders"), WithAudience("internal"))
+	router.DocumentSecurityScheme("bearerAuth", SecurityScheme{Type: "http", Scheme: "bearer"})
+	router.Get("/orders", func(context.Context, loadShedRequest) (*loadShedResponse, error) { return nil, nil },
+		WithSecurity("bearerAuth", "orders:read"), WithErrorResponse[*userNotFoundError](http.StatusNotFound))
+
+	parsers := NewTypeParserRegistry()
+	if err := parsers.Register(func(_ context.Context, s string) (*time.Duration, error) {
+		d, err := time.ParseDuration(s)
+		return &d, err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	router.AdminRoutes("/_gork/", AdminConfig{Authenticate: adminAuthenticator, TypeParsers: parsers})
+
+	for _, path := range []string{"/_gork", "/_gork/routes", "/_gork/middleware", "/_gork/type-parsers", "/_gork/generation"} {
+		if handlers["GET "+path] == nil {
+			t.Fatalf("expected %s to be mounted", path)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	handlers["GET /_gork"](w, httptest.NewRequest(http.MethodGet, "/_gork", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without token, got %d", w.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/_gork", nil)
+	req.Header.Set("X-Admin-Token", "secret")
+	w = httptest.NewRecorder()
+	handlers["GET /_gork"](w, req)
+	var snapshot AdminSnapshot
+	if err := json.Unmarshal(w.Body.Bytes(), &snapshot); err != nil {
+		t.Fatalf("invalid snapshot %q: %v", w.Body.String(), err)
+	}
+
+	if len(snapshot.Routes) != 1 {
+		t.Fatalf("expected one route, got %+v", snapshot.Routes)
+	}
+	route := snapshot.Routes[0]
+	if route.Method != "GET" || route.Path != "/api/orders" || route.Options.Audience != "internal" ||
+		len(route.Options.Security) != 1 || route.Options.Security[0] != "bearerAuth (orders:read)" ||
+		len(route.Options.ErrorResponses) != 1 || route.Options.ErrorResponses[0] != "404 *api.userNotFoundError" {
+		t.Errorf("unexpected route %+v", route)
+	}
+	if len(snapshot.Middleware.Tags) != 1 || snapshot.Middleware.Audience != "internal" {
+		t.Errorf("unexpected middleware %+v", snapshot.Middleware)
+	}
+	if len(snapshot.TypeParsers) != 1 || snapshot.TypeParsers[0] != "time.Duration" {
+		t.Errorf("unexpected type parsers %v", snapshot.TypeParsers)
+	}
+	gen := snapshot.Generation
+	if gen.OpenAPIVersion != "3.1.0" || gen.Operations != 1 || len(gen.SecuritySchemes) != 1 || gen.GoVersion == "" {
+		t.Errorf("unexpected generation metadata %+v", gen)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/_gork/type-parsers", nil)
+	req.Header.Set("X-Admin-Token", "secret")
+	w = httptest.NewRecorder()
+	handlers["GET /_gork/type-parsers"](w, req)
+	if w.Body.String() != "[\"time.Duration\"]\n" {
+		t.Errorf("unexpected section body %q", w.Body.String())
+	}
+}
+
+func TestAdminRoutesRequireAuthenticator(t *testing.T) {
+	router, _, handlers := newLoadShedRouter()
+	router.AdminRoutes("/_gork")
+
+	w := httptest.NewRecorder()
+	handlers["GET /_gork/routes"](w, httptest.NewRequest(http.MethodGet, "/_gork/routes", nil))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected 403 without an authenticator, got %d", w.Code)
+	}
+}
+
+func TestAdminOptionsDescribeWrappers(t *testing.T) {
+	verifier := NewJWTVerifier(JWTConfig{HMACKey: []byte("secret")})
+	router, _, handlers := newLoadShedRouter(
+		WithTokenVerifier(verifier), WithProblemJSON(), WithRequestID(), WithAutoHead(), WithAutoOptions(),
+		WithCORS(CORSConfig{AllowedOrigins: []string{"https://app.example.com"}}),
+		WithPanicHandler(func(*http.Request, any, []byte) string { return "" }),
+	)
+	router.Get("/orders", func(context.Context, loadShedRequest) (*loadShedResponse, error) { return nil, nil },
+		WithTimeout(2*time.Second), WithMetrics(&recordingMetrics{}), WithCache(CachePolicy{MaxAge: time.Minute, Public: true}),
+		WithTypedMiddleware(func(ctx context.Context, req any, next Next) (any, error) { return next(ctx, req) }))
+	router.AdminRoutes("/_gork", AdminConfig{Authenticate: adminAuthenticator})
+
+	req := httptest.NewRequest(http.MethodGet, "/_gork", nil)
+	req.Header.Set("X-Admin-Token", "secret")
+	w := httptest.NewRecorder()
+	handlers["GET /_gork"](w, req)
+	var snapshot AdminSnapshot
+	if err := json.Unmarshal(w.Body.Bytes(), &snapshot); err != nil {
+		t.Fatalf("invalid snapshot %q: %v", w.Body.String(), err)
+	}
+
+	mw := snapshot.Middleware
+	if mw.TokenVerifier != "*api.JWTVerifier" || !mw.ProblemJSON || !mw.RequestID || !mw.AutoHead || !mw.AutoOptions ||
+		!mw.PanicHandler || len(mw.CORSOrigins) != 1 {
+		t.Errorf("unexpected middleware %+v", mw)
+	}
+	if len(snapshot.Routes) != 1 {
+		t.Fatalf("expected one route, got %+v", snapshot.Routes)
+	}
+	route := snapshot.Routes[0].Options
+	if route.Timeout != "2s" || !route.Metrics || route.Cache != "public, max-age=60" || route.TypedMiddleware != 1 || !route.ProblemJSON {
+		t.Errorf("unexpected route options %+v", route)
+	}
+	if got := snapshot.Generation.Undocumented; len(got) != 2 || got[0] != "HEAD /api/orders" || got[1] != "OPTIONS /api/orders" {
+		t.Errorf("expected the HEAD and OPTIONS routes, got %v", got)
+	}
+}
@@ -0,0 +1,186 @@
+// Package apitest records the requests and responses served in tests so
+// that they can be published as examples in the OpenAPI document. Because
+// the examples come from passing tests they stay accurate as the API
+// changes.
+package apitest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gork-labs/gork/pkg/api"
+)
+
+// RecordEnv is the environment variable enabling Recorder.Save, e.g.
+// GORK_RECORD_EXAMPLES=1 go test ./...
+const RecordEnv = "GORK_RECORD_EXAMPLES"
+
+type exampleNameKey struct{}
+
+// Named names the example recorded for req. Requests without a name are
+// recorded under their status code, e.g. "200".
+func Named(req *http.Request, name string) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), exampleNameKey{}, name))
+}
+
+// Recorder captures request/response pairs of the routes of a registry.
+type Recorder struct {
+	registry *api.RouteRegistry
+
+	mu       sync.Mutex
+	examples []api.CapturedExample
+}
+
+// NewRecorder returns a recorder for the routes of registry.
+func NewRecorder(registry *api.RouteRegistry) *Recorder {
+	return &Recorder{registry: registry}
+}
+
+// Middleware records every request served by next that matches a route of
+// the registry. Only JSON bodies are kept; the first example recorded
+// under a name wins.
+func (r *Recorder) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		route := r.match(req)
+		if route == nil {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		var reqBody []byte
+		if req.Body != nil {
+			reqBody, _ = io.ReadAll(req.Body)
+			req.Body = io.NopCloser(bytes.NewReader(reqBody))
+		}
+		rec := httptest.NewRecorder()
+		next.ServeHTTP(rec, req)
+
+		for k, v := range rec.Header() {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(rec.Code)
+		_, _ = w.Write(rec.Body.Bytes())
+
+		name, _ := req.Context().Value(exampleNameKey{}).(string)
+		if name == "" {
+			name = strconv.Itoa(rec.Code)
+		}
+		r.add(api.CapturedExample{
+			Operation:    route.Method + " " + route.Path,
+			Name:         name,
+			RequestBody:  jsonBody(reqBody),
+			Status:       rec.Code,
+			ResponseBody: jsonBody(rec.Body.Bytes()),
+		})
+	})
+}
+
+// Examples returns the recorded examples sorted by operation and name.
+func (r *Recorder) Examples() []api.CapturedExample {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	examples := append([]api.CapturedExample(nil), r.examples...)
+	sortExamples(examples)
+	return examples
+}
+
+// Save writes the recorded examples to file when RecordEnv is set and does
+// nothing otherwise, so that a regular test run leaves the file untouched.
+// Examples already in the file are kept unless they were recorded again.
+// Call it from TestMain after m.Run or from t.Cleanup.
+func (r *Recorder) Save(file string) error {
+	if os.Getenv(RecordEnv) == "" {
+		return nil
+	}
+	merged := map[string]api.CapturedExample{}
+	if existing, err := api.LoadCapturedExamples(file); err == nil {
+		for _, ex := range existing {
+			merged[ex.Operation+"\x00"+ex.Name] = ex
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	for _, ex := range r.Examples() {
+		merged[ex.Operation+"\x00"+ex.Name] = ex
+	}
+	examples := make([]api.CapturedExample, 0, len(merged))
+	for _, ex := range merged {
+		examples = append(examples, ex)
+	}
+	sortExamples(examples)
+
+	data, err := json.MarshalIndent(examples, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, append(data, '\n'), 0o600)
+}
+
+func (r *Recorder) add(ex api.CapturedExample) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.examples {
+		if existing.Operation == ex.Operation && existing.Name == ex.Name {
+			return
+		}
+	}
+	r.examples = append(r.examples, ex)
+}
+
+// match returns the route whose method and path template match req.
+func (r *Recorder) match(req *http.Request) *api.RouteInfo {
+	for _, route := range r.registry.GetRoutes() {
+		if route.Method == req.Method && matchTemplate(route.Path, req.URL.Path) {
+			return route
+		}
+	}
+	return nil
+}
+
+// matchTemplate reports whether path matches a route template. "{name}"
+// and ":name" match one segment, "{name...}" and "*" the rest of the path.
+func matchTemplate(template, path string) bool {
+	tmpl := strings.Split(strings.Trim(template, "/"), "/")
+	segs := strings.Split(strings.Trim(path, "/"), "/")
+	for i, t := range tmpl {
+		if t == "*" || (strings.HasPrefix(t, "{") && strings.HasSuffix(t, "...}")) {
+			return true
+		}
+		if i >= len(segs) {
+			return false
+		}
+		if strings.HasPrefix(t, ":") || (strings.HasPrefix(t, "{") && strings.HasSuffix(t, "}")) {
+			continue
+		}
+		if t != segs[i] {
+			return false
+		}
+	}
+	return len(tmpl) == len(segs)
+}
+
+// jsonBody returns body when it is a JSON document.
+func jsonBody(body []byte) json.RawMessage {
+	if len(bytes.TrimSpace(body)) == 0 || !json.Valid(body) {
+		return nil
+	}
+	return json.RawMessage(bytes.TrimSpace(body))
+}
+
+func sortExamples(examples []api.CapturedExample) {
+	sort.Slice(examples, func(i, j int) bool {
+		if examples[i].Operation != examples[j].Operation {
+			return examples[i].Operation < examples[j].Operation
+		}
+		return examples[i].Name < examples[j].Name
+	})
+}
@@ -0,0 +1,131 @@
+package apitest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gork-labs/gork/pkg/api"
+)
+
+type pathAdapter struct{ api.HTTPParameterAdapter }
+
+func (pathAdapter) Path(r *http.Request, key string) (string, bool) {
+	v := r.PathValue(key)
+	return v, v != ""
+}
+
+type UpdateUserRequest struct {
+	Path struct {
+		ID string `gork:"id"`
+	}
+	Body struct {
+		Name string `gork:"name"`
+	}
+}
+
+type UpdateUserResponse struct {
+	Body struct {
+		ID   string `gork:"id"`
+		Name string `gork:"name"`
+	}
+}
+
+func UpdateUser(_ context.Context, req UpdateUserRequest) (*UpdateUserResponse, error) {
+	resp := &UpdateUserResponse{}
+	resp.Body.ID = req.Path.ID
+	resp.Body.Name = req.Body.Name
+	return resp, nil
+}
+
+func newServer() (http.Handler, *api.RouteRegistry) {
+	mux := http.NewServeMux()
+	registry := api.NewRouteRegistry()
+	router := api.NewTypedRouter(mux, registry, "/api", nil, pathAdapter{},
+		func(method, _ string, h http.HandlerFunc, info *api.RouteInfo) {
+			mux.HandleFunc(method+" "+info.Path, h)
+		})
+	router.Put("/users/{id}", UpdateUser)
+	return mux, registry
+}
+
+func TestRecorderPublishesExamples(t *testing.T) {
+	mux, registry := newServer()
+	recorder := NewRecorder(registry)
+	server := recorder.Middleware(mux)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/users/42", strings.NewReader(`{"name":"Ada"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, Named(req, "rename"))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"Ada"`) {
+		t.Fatalf("the response must reach the client unchanged, got %d %s", rec.Code, rec.Body.String())
+	}
+	server.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown", nil))
+
+	examples := recorder.Examples()
+	if len(examples) != 1 || examples[0].Operation != "PUT /api/users/{id}" || examples[0].Name != "rename" || examples[0].Status != 200 {
+		t.Fatalf("unexpected examples %+v", examples)
+	}
+
+	spec := api.GenerateOpenAPI(registry, api.WithCapturedExamples(examples...))
+	op := spec.Paths["/api/users/{id}"].Put
+	if got := op.RequestBody.Content["application/json"].Examples["rename"]; got == nil || got.Value.(map[string]interface{})["name"] != "Ada" {
+		t.Errorf("expected the request example, got %+v", got)
+	}
+	if got := op.Responses["200"].Content["application/json"].Examples["rename"]; got == nil || got.Value.(map[string]interface{})["id"] != "42" {
+		t.Errorf("expected the response example, got %+v", got)
+	}
+}
+
+func TestRecorderSaveIsBehindEnv(t *testing.T) {
+	mux, registry := newServer()
+	recorder := NewRecorder(registry)
+	server := recorder.Middleware(mux)
+	server.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPut, "/api/users/7", strings.NewReader(`{"name":"Bo"}`)))
+
+	file := filepath.Join(t.TempDir(), "examples.json")
+	t.Setenv(RecordEnv, "")
+	if err := recorder.Save(file); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Fatalf("Save must not write without %s", RecordEnv)
+	}
+
+	t.Setenv(RecordEnv, "1")
+	if err := os.WriteFile(file, []byte(`[{"operation":"GET /api/other","name":"kept","status":200}]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := recorder.Save(file); err != nil {
+		t.Fatal(err)
+	}
+	examples, err := api.LoadCapturedExamples(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(examples) != 2 || examples[0].Name != "kept" || examples[1].Name != "200" {
+		t.Errorf("expected existing and new examples, got %+v", examples)
+	}
+}
+
+func TestMatchTemplate(t *testing.T) {
+	for _, tc := range []struct {
+		template, path string
+		want           bool
+	}{
+		{"/users/{id}", "/users/42", true},
+		{"/users/:id", "/users/42", true},
+		{"/users/{id}", "/users/42/posts", false},
+		{"/files/{path...}", "/files/a/b", true},
+		{"/users", "/accounts", false},
+	} {
+		if got := matchTemplate(tc.template, tc.path); got != tc.want {
+			t.Errorf("matchTemplate(%q, %q) = %v", tc.template, tc.path, got)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+type audienceContextKey struct{}
+
+// ContextWithAudience returns a context whose responses are rendered for
+// audience. Middleware identifying internal callers uses it so that fields
+// tagged `gork:"...,audience=internal"` are included in their responses.
+func ContextWithAudience(ctx context.Context, audience string) context.Context {
+	return context.WithValue(ctx, audienceContextKey{}, audience)
+}
+
+// AudienceFromContext returns the audience set with ContextWithAudience, or
+// "" (the public audience) when none was set.
+func AudienceFromContext(ctx context.Context) string {
+	audience, _ := ctx.Value(audienceContextKey{}).(string)
+	return audience
+}
+
+// WithAudience renders the responses of a route (or of every route, when
+// passed as router middleware) for audience unless the request context
+// already carries one.
+func WithAudience(audience string) Option {
+	return func(h *HandlerOption) {
+		h.Audience = audience
+	}
+}
+
+// WithSpecAudience generates the spec variant for audience. Without it the
+// spec is public: fields and parameters restricted to an audience are left out.
+func WithSpecAudience(audience string) OpenAPIOption {
+	return func(spec *OpenAPISpec) { spec.audience = audience }
+}
+
+// visibleTo reports whether a field tagged with fieldAudience is shown to audience.
+func visibleTo(fieldAudience, audience string) bool {
+	return fieldAudience == "" || fieldAudience == audience
+}
+
+// wrapAudience applies a route's default audience to requests without one.
+func wrapAudience(audience string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Value(audienceContextKey{}).(string); !ok {
+			r = r.WithContext(ContextWithAudience(r.Context(), audience))
+		}
+		next(w, r)
+	}
+}
+
+// audienceTypes caches whether a type contains audience-restricted fields.
+var audienceTypes sync.Map // reflect.Type -> bool
+
+// hasAudienceFields reports whether values of t contain fields restricted to
+// an audience, so that unrestricted bodies skip filtering entirely.
+func hasAudienceFields(t reflect.Type) bool {
+	if cached, ok := audienceTypes.Load(t); ok {
+		return cached.(bool)
+	}
+	found := scanAudienceFields(t, map[reflect.Type]bool{})
+	audienceTypes.Store(t, found)
+	return found
+}
+
+func scanAudienceFields(t reflect.Type, seen map[reflect.Type]bool) bool {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || seen[t] {
+		return false
+	}
+	seen[t] = true
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if parseGorkTag(field.Tag.Get("gork")).Audience != "" || scanAudienceFields(field.Type, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterAudience removes the fields of data, the encoding of v, that are
+// not visible to audience.
+func filterAudience(data []byte, v reflect.Value, audience string) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	pruneAudience(value, v, audience)
+	return json.Marshal(value)
+}
+
+// pruneAudience walks a decoded JSON value alongside the Go value it was
+// encoded from, following the active variant of unions.
+func pruneAudience(value interface{}, v reflect.Value, audience string) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		items, _ := value.([]interface{})
+		for i := 0; i < len(items) && i < v.Len(); i++ {
+			pruneAudience(items[i], v.Index(i), audience)
+		}
+	case reflect.Map:
+		entries, _ := value.(map[string]interface{})
+		for iter := v.MapRange(); iter.Next(); {
+			if entry, ok := entries[fmt.Sprint(iter.Key().Interface())]; ok {
+				pruneAudience(entry, iter.Value(), audience)
+			}
+		}
+	case reflect.Struct:
+		t := v.Type()
+		if isUnionType(t) {
+			// A union encodes as its only set variant.
+			for i := 0; i < v.NumField(); i++ {
+				if variant := v.Field(i); variant.Kind() == reflect.Ptr && !variant.IsNil() {
+					pruneAudience(value, variant, audience)
+					return
+				}
+			}
+			return
+		}
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return
+		}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if _, ok := embeddedStructType(field); ok {
+				pruneAudience(object, v.Field(i), audience)
+				continue
+			}
+			tagInfo := parseGorkTag(field.Tag.Get("gork"))
+			name := tagInfo.Name
+			if name == "" {
+				name = strings.Split(field.Tag.Get("json"), ",")[0]
+			}
+			if name == "" || name == "-" {
+				continue
+			}
+			if !visibleTo(tagInfo.Audience, audience) {
+				delete(object, name)
+				continue
+			}
+			pruneAudience(object[name], v.Field(i), audience)
+		}
+	}
+}
+
+// setPropertyAudience records that a property of s is restricted to audience.
+func (s *Schema) setPropertyAudience(audience string, names ...string) {
+	if audience == "" {
+		return
+	}
+	if s.propertyAudiences == nil {
+		s.propertyAudiences = map[string]string{}
+	}
+	for _, name := range names {
+		s.propertyAudiences[name] = audience
+	}
+}
+
+// copyPropertyAudiences carries the audiences of properties copied from another schema.
+func (s *Schema) copyPropertyAudiences(from *Schema) {
+	for name, audience := range from.propertyAudiences {
+		s.setPropertyAudience(audience, name)
+	}
+}
+
+// applySpecAudience removes the properties and parameters of the spec that
+// are not visible to its audience.
+func applySpecAudience(spec *OpenAPISpec) {
+	seen := map[*Schema]bool{}
+	if spec.Components != nil {
+		for _, schema := range spec.Components.Schemas {
+			pruneSchemaAudience(schema, spec.audience, seen)
+		}
+	}
+	items := slices.Collect(maps.Values(spec.Paths))
+	items = slices.AppendSeq(items, maps.Values(spec.Webhooks))
+	for _, item := range items {
+		for _, op := range []*Operation{item.Get, item.Post, item.Put, item.Patch, item.Delete} {
+			if op == nil {
+				continue
+			}
+			params := op.Parameters[:0]
+			for _, p := range op.Parameters {
+				if visibleTo(p.audience, spec.audience) {
+					params = append(params, p)
+				}
+			}
+			op.Parameters = params
+			if op.RequestBody != nil {
+				for _, media := range op.RequestBody.Content {
+					pruneSchemaAudience(media.Schema, spec.audience, seen)
+				}
+			}
+			for _, resp := range op.Responses {
+				for _, media := range resp.Content {
+					pruneSchemaAudience(media.Schema, spec.audience, seen)
+				}
+			}
+		}
+	}
+}
+
+func pruneSchemaAudience(s *Schema, audience string, seen map[*Schema]bool) {
+	if s == nil || seen[s] {
+		return
+	}
+	seen[s] = true
+	for name, propAudience := range s.propertyAudiences {
+		if visibleTo(propAudience, audience) {
+			continue
+		}
+		delete(s.Properties, name)
+		// Required may be shared with the schema the properties were copied from.
+		var required []string
+		for _, r := range s.Required {
+			if r != name {
+				required = append(required, r)
+			}
+		}
+		s.Required = required
+	}
+	for _, prop := range s.Properties {
+		pruneSchemaAudience(prop, audience, seen)
+	}
+	pruneSchemaAudience(s.Items, audience, seen)
+	pruneSchemaAudience(s.AdditionalProperties, audience, seen)
+	for _, sub := range s.OneOf {
+		pruneSchemaAudience(sub, audience, seen)
+	}
+	for _, sub := range s.AnyOf {
+		pruneSchemaAudience(sub, audience, seen)
+	}
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gork-labs/gork/pkg/unions"
+)
+
+type audienceLine struct {
+	SKU  string  `gork:"sku"`
+	Cost float64 `gork:"cost,audience=internal"`
+}
+
+type audienceRequest struct {
+	Query struct {
+		Debug bool `gork:"debug,audience=internal"`
+	}
+}
+
+type audienceResponse struct {
+	Body struct {
+		ID     string         `gork:"id"`
+		Margin float64        `gork:"margin,audience=internal" validate:"required"`
+		Lines  []audienceLine `gork:"lines"`
+	}
+}
+
+func audienceHandler(context.Context, audienceRequest) (*audienceResponse, error) {
+	resp := &audienceResponse{}
+	resp.Body.ID = "o-1"
+	resp.Body.Margin = 0.25
+	resp.Body.Lines = []audienceLine{{SKU: "a", Cost: 1.5}}
+	return resp, nil
+}
+
+func TestAudienceResponseFiltering(t *testing.T) {
+	router, _, handlers := newLoadShedRouter()
+	router.Get("/orders", audienceHandler)
+	router.Get("/internal/orders", audienceHandler, WithAudience("internal"))
+
+	decode := func(h http.HandlerFunc, r *http.Request) map[string]interface{} {
+		w := httptest.NewRecorder()
+		h(w, r)
+		var body map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("invalid body %q: %v", w.Body.String(), err)
+		}
+		return body
+	}
+
+	public := decode(handlers["GET /orders"], httptest.NewRequest(http.MethodGet, "/api/orders", nil))
+	line := public["lines"].([]interface{})[0].(map[string]interface{})
+	if _, ok := public["margin"]; ok || public["id"] != "o-1" || line["cost"] != nil || line["sku"] != "a" {
+		t.Errorf("internal fields must be stripped for the public audience, got %v", public)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/orders", nil)
+	req = req.WithContext(ContextWithAudience(req.Context(), "internal"))
+	internal := decode(handlers["GET /orders"], req)
+	if internal["margin"] != 0.25 || internal["lines"].([]interface{})[0].(map[string]interface{})["cost"] != 1.5 {
+		t.Errorf("context audience must see internal fields, got %v", internal)
+	}
+
+	routeScoped := decode(handlers["GET /internal/orders"], httptest.NewRequest(http.MethodGet, "/api/internal/orders", nil))
+	if routeScoped["margin"] != 0.25 {
+		t.Errorf("route audience must see internal fields, got %v", routeScoped)
+	}
+	req = httptest.NewRequest(http.MethodGet, "/api/internal/orders", nil)
+	req = req.WithContext(ContextWithAudience(req.Context(), ""))
+	if overridden := decode(handlers["GET /internal/orders"], req); overridden["margin"] != nil {
+		t.Errorf("an audience in the context takes precedence, got %v", overridden)
+	}
+}
+
+type audienceRefund struct {
+	Amount float64 `gork:"amount"`
+	Reason string  `gork:"reason,audience=internal"`
+}
+
+type audienceUnionResponse struct {
+	Body struct {
+		Event unions.Union2[audienceLine, audienceRefund] `gork:"event"`
+	}
+}
+
+type audienceError struct {
+	Message string `gork:"message"`
+	Trace   string `gork:"trace,audience=internal"`
+}
+
+func (e *audienceError) Error() string { return e.Message }
+
+func TestAudienceFilteringUnionsAndErrors(t *testing.T) {
+	router, _, handlers := newLoadShedRouter()
+	router.Get("/events", func(context.Context, struct{}) (*audienceUnionResponse, error) {
+		resp := &audienceUnionResponse{}
+		resp.Body.Event.B = &audienceRefund{Amount: 3, Reason: "fraud"}
+		return resp, nil
+	})
+	router.Get("/fail", func(context.Context, struct{}) (*audienceUnionResponse, error) {
+		return nil, &audienceError{Message: "failed", Trace: "db.go:12"}
+	}, WithErrorResponse[*audienceError](http.StatusConflict))
+
+	for _, route := range []string{"/events", "/fail"} {
+		w := httptest.NewRecorder()
+		handlers["GET "+route](w, httptest.NewRequest(http.MethodGet, "/api"+route, nil))
+		if strings.Contains(w.Body.String(), "fraud") || strings.Contains(w.Body.String(), "db.go") {
+			t.Errorf("%s: internal fields must be stripped for the public audience, got %s", route, w.Body)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/api"+route, nil)
+		w = httptest.NewRecorder()
+		handlers["GET "+route](w, req.WithContext(ContextWithAudience(req.Context(), "internal")))
+		if !strings.Contains(w.Body.String(), "fraud") && !strings.Contains(w.Body.String(), "db.go") {
+			t.Errorf("%s: internal audience must see internal fields, got %s", route, w.Body)
+		}
+	}
+}
+
+func TestAudienceSpecVariants(t *testing.T) {
+	router, registry, _ := newLoadShedRouter()
+	router.Get("/orders", audienceHandler)
+
+	check := func(spec *OpenAPISpec, visible bool) {
+		t.Helper()
+		op := spec.Paths["/api/orders"].Get
+		body := componentSchema(spec, op.Responses["200"].Content["application/json"].Schema)
+		_, hasMargin := body.Properties["margin"]
+		requiresMargin := false
+		for _, r := range body.Required {
+			requiresMargin = requiresMargin || r == "margin"
+		}
+		line := spec.Components.Schemas["audienceLine"]
+		_, hasCost := line.Properties["cost"]
+		if hasMargin != visible || requiresMargin != visible || hasCost != visible || (len(op.Parameters) == 1) != visible {
+			t.Errorf("visible=%v: margin=%v required=%v cost=%v params=%v", visible, hasMargin, requiresMargin, hasCost, op.Parameters)
+		}
+	}
+	check(GenerateOpenAPI(registry), false)
+	check(GenerateOpenAPI(registry, WithSpecAudience("internal")), true)
+}
@@ -0,0 +1,148 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gork-labs/gork/pkg/gorkson"
+)
+
+// SectionAuth is the request section receiving the verified bearer token of
+// the request. Its Claims field is decoded from the token's claims like a
+// Body, and its Token field, if any, receives the raw token:
+//
+//	type GetMeRequest struct {
+//		Auth struct {
+//			Claims MyClaims
+//		}
+//	}
+//
+//	type MyClaims struct {
+//		Subject string `gork:"sub"`
+//		Scope   string `gork:"scope"`
+//	}
+//
+// Routes with an Auth section need a token verifier, set with
+// WithTokenVerifier. The section is parsed before the others; requests
+// without a valid token are answered with 401.
+const SectionAuth = "Auth"
+
+// ErrUnauthenticated is wrapped by the errors of requests whose bearer
+// token is missing or fails verification. They are answered with 401.
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// TokenVerifier verifies the bearer token of a request and returns its
+// claims as a JSON object. JWTVerifier verifies JSON Web Tokens.
+type TokenVerifier interface {
+	VerifyToken(ctx context.Context, token string) ([]byte, error)
+}
+
+// WithTokenVerifier verifies the bearer tokens of routes with an Auth
+// section with verifier. Pass it to the router to cover every route.
+//
+//	verifier := api.NewJWTVerifier(api.JWTConfig{JWKSURL: "https://issuer.example.com/.well-known/jwks.json"})
+//	router := stdlib.NewRouter(mux, api.WithTokenVerifier(verifier))
+func WithTokenVerifier(verifier TokenVerifier) Option {
+	return func(h *HandlerOption) {
+		h.TokenVerifier = verifier
+	}
+}
+
+// hasAuthSection reports whether requests of type t carry an Auth section.
+func hasAuthSection(t reflect.Type) bool {
+	if t == nil || t.Kind() != reflect.Struct {
+		return false
+	}
+	_, ok := t.FieldByName(SectionAuth)
+	return ok
+}
+
+// checkTokenVerifier panics when a route's request type has an Auth section
+// but no token verifier could populate it.
+func checkTokenVerifier(reqType reflect.Type, options *HandlerOption) {
+	if hasAuthSection(reqType) && options.TokenVerifier == nil {
+		panic(fmt.Sprintf("request type %s has an Auth section but the route has no token verifier: register it with api.WithTokenVerifier", reqType))
+	}
+}
+
+// withTokenVerifier returns a parser sharing p's type parsers that
+// populates Auth sections with verifier.
+func (p *ConventionParser) withTokenVerifier(verifier TokenVerifier) *ConventionParser {
+	authenticated := *p
+	authenticated.tokenVerifier = verifier
+	return &authenticated
+}
+
+// parseAuthSection verifies the bearer token of r and populates the Auth
+// section of reqStruct, if any, with it.
+func (p *ConventionParser) parseAuthSection(ctx context.Context, reqStruct reflect.Value, r *http.Request) error {
+	field, ok := reqStruct.Type().FieldByName(SectionAuth)
+	if !ok {
+		return nil
+	}
+	section := reqStruct.FieldByIndex(field.Index)
+	if section.Kind() != reflect.Struct {
+		return fmt.Errorf("section %s must be a struct", SectionAuth)
+	}
+	if p.tokenVerifier == nil {
+		return fmt.Errorf("%w: no token verifier configured", ErrUnauthenticated)
+	}
+
+	scheme, token, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+	token = strings.TrimSpace(token)
+	if !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return fmt.Errorf("%w: missing bearer token", ErrUnauthenticated)
+	}
+	claims, err := p.tokenVerifier.VerifyToken(ctx, token)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+
+	if tokenField := section.FieldByName("Token"); tokenField.IsValid() && tokenField.Kind() == reflect.String {
+		tokenField.SetString(token)
+	}
+	if claimsField := section.FieldByName("Claims"); claimsField.IsValid() {
+		if err := gorkson.Unmarshal(claims, claimsField.Addr().Interface()); err != nil {
+			return fmt.Errorf("%w: malformed token claims", ErrUnauthenticated)
+		}
+	}
+	return nil
+}
+
+// addTokenAuth documents the bearer authentication of routes with an Auth
+// section: the BearerAuth security scheme, unless the route declares its
+// own security requirements, and the 401 response.
+func (g *ConventionOpenAPIGenerator) addTokenAuth(route *RouteInfo, components *Components, op *Operation) {
+	if !hasAuthSection(route.RequestType) {
+		return
+	}
+	if route.Options == nil || len(route.Options.Security) == 0 {
+		if components.SecuritySchemes == nil {
+			components.SecuritySchemes = map[string]*SecurityScheme{}
+		}
+		if components.SecuritySchemes["BearerAuth"] == nil {
+			scheme := &SecurityScheme{Type: "http", Scheme: "bearer"}
+			if route.Options != nil {
+				if _, ok := route.Options.TokenVerifier.(*JWTVerifier); ok {
+					scheme.BearerFormat = "JWT"
+				}
+			}
+			components.SecuritySchemes["BearerAuth"] = scheme
+		}
+		op.Security = append(op.Security, map[string][]string{"BearerAuth": {}})
+	}
+	if op.Responses == nil {
+		op.Responses = map[string]*Response{}
+	}
+	g.ensureErrorSchemas(components)
+	op.Responses["401"] = &Response{
+		Description: "Unauthorized",
+		Content: map[string]*MediaType{
+			"application/json": {Schema: &Schema{Ref: "#/components/schemas/ErrorResponse"}},
+		},
+	}
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type authClaims struct {
+	Subject string   `gork:"sub"`
+	Roles   []string `gork:"roles"`
+}
+
+type authRequest struct {
+	Auth struct {
+		Claims authClaims
+		Token  string
+	}
+	Headers struct {
+		Locale string `gork:"Accept-Language"`
+	}
+}
+
+func TestAuthSection(t *testing.T) {
+	key := []byte("secret")
+	verifier := NewJWTVerifier(JWTConfig{HMACKey: key})
+	router, registry, handlers := newLoadShedRouter(WithTokenVerifier(verifier))
+	var got authRequest
+	router.Get("/me", func(_ context.Context, req authRequest) (*loadShedResponse, error) {
+		got = req
+		return &loadShedResponse{}, nil
+	})
+
+	token := signHS256(t, key, `{"sub":"user-1","roles":["admin"]}`)
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+	}{
+		{"valid", "Bearer " + token, http.StatusOK},
+		{"lowercase scheme", "bearer " + token, http.StatusOK},
+		{"missing", "", http.StatusUnauthorized},
+		{"basic", "Basic dXNlcjpwYXNz", http.StatusUnauthorized},
+		{"wrong key", "Bearer " + signHS256(t, []byte("other"), `{"sub":"user-1"}`), http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		got = authRequest{}
+		r := httptest.NewRequest(http.MethodGet, "/api/me", nil)
+		r.Header.Set("Accept-Language", "en")
+		if tt.authorization != "" {
+			r.Header.Set("Authorization", tt.authorization)
+		}
+		w := httptest.NewRecorder()
+		handlers["GET /me"](w, r)
+		if w.Code != tt.wantStatus {
+			t.Errorf("%s: status = %d, want %d: %s", tt.name, w.Code, tt.wantStatus, w.Body)
+			continue
+		}
+		if tt.wantStatus == http.StatusUnauthorized {
+			if w.Header().Get("WWW-Authenticate") != "Bearer" || !strings.Contains(w.Body.String(), "unauthenticated") {
+				t.Errorf("%s: challenge = %q, body = %s", tt.name, w.Header().Get("WWW-Authenticate"), w.Body)
+			}
+			continue
+		}
+		if got.Auth.Claims.Subject != "user-1" || len(got.Auth.Claims.Roles) != 1 || got.Auth.Token != token || got.Headers.Locale != "en" {
+			t.Errorf("%s: got %+v", tt.name, got)
+		}
+	}
+
+	spec := GenerateOpenAPI(registry)
+	op := spec.Paths["/api/me"].Get
+	if len(op.Security) != 1 || op.Security[0]["BearerAuth"] == nil {
+		t.Errorf("security = %v, want BearerAuth", op.Security)
+	}
+	if scheme := spec.Components.SecuritySchemes["BearerAuth"]; scheme == nil || scheme.Scheme != "bearer" || scheme.BearerFormat != "JWT" {
+		t.Errorf("BearerAuth scheme = %+v", scheme)
+	}
+	if op.Responses["401"] == nil {
+		t.Error("expected a documented 401 response")
+	}
+	for _, param := range op.Parameters {
+		if param.Name != "Accept-Language" {
+			t.Errorf("unexpected parameter %s", param.Name)
+		}
+	}
+}
+
+func TestAuthSectionNotCachedInMemory(t *testing.T) {
+	key := []byte("secret")
+	router, _, handlers := newLoadShedRouter(WithTokenVerifier(NewJWTVerifier(JWTConfig{HMACKey: key})))
+	router.Get("/me", func(context.Context, authRequest) (*loadShedResponse, error) {
+		return &loadShedResponse{}, nil
+	}, WithCache(CachePolicy{MaxAge: time.Minute, Public: true, MemoryEntries: 10}))
+
+	r := httptest.NewRequest(http.MethodGet, "/api/me", nil)
+	r.Header.Set("Authorization", "Bearer "+signHS256(t, key, `{"sub":"user-1"}`))
+	w := httptest.NewRecorder()
+	handlers["GET /me"](w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body)
+	}
+
+	w = httptest.NewRecorder()
+	handlers["GET /me"](w, httptest.NewRequest(http.MethodGet, "/api/me", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status without token = %d, want 401", w.Code)
+	}
+}
+
+func TestAuthSectionRequiresVerifier(t *testing.T) {
+	router, _, _ := newLoadShedRouter()
+	defer func() {
+		if recovered := recover(); recovered == nil || !strings.Contains(recovered.(string), "WithTokenVerifier") {
+			t.Errorf("recovered %v, want a panic asking for a token verifier", recovered)
+		}
+	}()
+	router.Get("/me", func(context.Context, authRequest) (*loadShedResponse, error) { return nil, nil })
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WithAutoHead answers HEAD requests for GET routes with the GET handler's
+// status and headers and no body. Pass it as a router option to cover every
+// GET route, or to a single GET route. Routes with an explicit HEAD handler
+// must not use it.
+func WithAutoHead() Option {
+	return func(h *HandlerOption) {
+		h.AutoHead = true
+	}
+}
+
+// WithAutoOptions answers OPTIONS requests with 204 No Content and an Allow
+// header listing the methods registered for the path. Pass it as a router
+// option; the Allow header is built from the registry when the request
+// arrives, so routes registered later are included.
+func WithAutoOptions() Option {
+	return func(h *HandlerOption) {
+		h.AutoOptions = true
+	}
+}
+
+// registerAutoMethods registers the HEAD and OPTIONS handlers requested for
+// a newly registered route; CORS routes get an OPTIONS handler for preflight
+// requests.
+func (r *TypedRouter[T]) registerAutoMethods(method, path string, handler http.HandlerFunc, info *RouteInfo) {
+	if r.registerFn == nil || info.Options == nil {
+		return
+	}
+	if method == http.MethodGet && info.Options.AutoHead && !r.registry.hasRoute(http.MethodHead, info.Path) {
+		r.registerFn(http.MethodHead, path, headHandler(handler), info)
+	}
+	autoOptions := info.Options.AutoOptions || info.Options.CORS != nil
+	if autoOptions && !r.registry.hasRoute(http.MethodOptions, info.Path) && r.registry.claimAutoOptions(info.Path) {
+		r.registerFn(http.MethodOptions, path, optionsHandler(r.registry, info.Path, info.Options.CORS), info)
+	}
+}
+
+// hasRoute reports whether a route for method and path is registered.
+func (r *RouteRegistry) hasRoute(method, path string) bool {
+	for _, route := range r.GetRoutes() {
+		if route.Method == method && route.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+// claimAutoOptions reports whether path has no OPTIONS handler yet and
+// records that it now has one.
+func (r *RouteRegistry) claimAutoOptions(path string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.autoOptions == nil {
+		r.autoOptions = map[string]bool{}
+	}
+	if r.autoOptions[path] {
+		return false
+	}
+	r.autoOptions[path] = true
+	return true
+}
+
+// allowedMethods lists the methods answered for path, sorted.
+func (r *RouteRegistry) allowedMethods(path string) []string {
+	seen := map[string]bool{http.MethodOptions: true}
+	for _, route := range r.GetRoutes() {
+		if route.Path != path {
+			continue
+		}
+		seen[route.Method] = true
+		if route.Method == http.MethodGet && route.Options != nil && route.Options.AutoHead {
+			seen[http.MethodHead] = true
+		}
+	}
+	methods := make([]string, 0, len(seen))
+	for method := range seen {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// optionsHandler answers OPTIONS requests for path with its Allow header,
+// and CORS preflight requests as configured by cors when it is set.
+func optionsHandler(registry *RouteRegistry, path string, cors *CORSConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		methods := registry.allowedMethods(path)
+		w.Header().Set("Allow", strings.Join(methods, ", "))
+		if cors != nil && isPreflight(r) {
+			cors.preflight(w, r, methods)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// headHandler runs a GET handler for a HEAD request, dropping the body but
+// reporting its length.
+func headHandler(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		hw := &headResponseWriter{ResponseWriter: w}
+		next(hw, r)
+		hw.flush()
+	}
+}
+
+// headResponseWriter counts the body instead of writing it and holds the
+// status back until the handler returns, so Content-Length can be set.
+type headResponseWriter struct {
+	http.ResponseWriter
+	status int
+	length int
+}
+
+func (w *headResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *headResponseWriter) Write(p []byte) (int, error) {
+	w.length += len(p)
+	return len(p), nil
+}
+
+func (w *headResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *headResponseWriter) flush() {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if w.length > 0 && w.ResponseWriter.Header().Get("Content-Length") == "" {
+		w.ResponseWriter.Header().Set("Content-Length", strconv.Itoa(w.length))
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestAutoHead(t *testing.T) {
+	router, registry, handlers := newLoadShedRouter(WithAutoHead())
+	router.Get("/items/{id}", func(context.Context, struct{}) (*loadShedResponse, error) {
+		resp := &loadShedResponse{}
+		resp.Body.Name = "widget"
+		return resp, nil
+	})
+	router.Post("/items", func(context.Context, struct{}) (*loadShedResponse, error) { return nil, nil })
+
+	head := handlers["HEAD /items/{id}"]
+	if head == nil {
+		t.Fatalf("expected a HEAD handler, got %v", handlers)
+	}
+	if handlers["HEAD /items"] != nil {
+		t.Error("HEAD must only be added for GET routes")
+	}
+
+	get := httptest.NewRecorder()
+	handlers["GET /items/{id}"](get, httptest.NewRequest(http.MethodGet, "/api/items/1", nil))
+	w := httptest.NewRecorder()
+	head(w, httptest.NewRequest(http.MethodHead, "/api/items/1", nil))
+	if w.Code != http.StatusOK || w.Body.Len() != 0 {
+		t.Errorf("HEAD: status = %d, body = %q", w.Code, w.Body)
+	}
+	if got, want := w.Header().Get("Content-Type"), get.Header().Get("Content-Type"); got != want {
+		t.Errorf("Content-Type = %q, want %q", got, want)
+	}
+	if got, want := w.Header().Get("Content-Length"), strconv.Itoa(get.Body.Len()); got != want {
+		t.Errorf("Content-Length = %q, want %q", got, want)
+	}
+
+	for _, route := range registry.GetRoutes() {
+		if route.Method == http.MethodHead {
+			t.Error("automatic HEAD routes must not be documented")
+		}
+	}
+}
+
+func TestAutoOptions(t *testing.T) {
+	router, _, handlers := newLoadShedRouter(WithAutoOptions(), WithAutoHead())
+	router.Get("/items/{id}", func(context.Context, struct{}) (*loadShedResponse, error) { return nil, nil })
+	router.Delete("/items/{id}", func(context.Context, struct{}) (*struct{}, error) { return nil, nil })
+	router.Post("/items", func(context.Context, struct{}) (*loadShedResponse, error) { return nil, nil })
+	// Registered after the OPTIONS handler, still listed
+	router.Patch("/items/{id}", func(context.Context, struct{}) (*loadShedResponse, error) { return nil, nil })
+
+	tests := map[string]string{
+		"OPTIONS /items/{id}": "DELETE, GET, HEAD, OPTIONS, PATCH",
+		"OPTIONS /items":      "OPTIONS, POST",
+	}
+	for key, want := range tests {
+		h := handlers[key]
+		if h == nil {
+			t.Fatalf("missing %s handler", key)
+		}
+		w := httptest.NewRecorder()
+		h(w, httptest.NewRequest(http.MethodOptions, "/api/items/1", nil))
+		if w.Code != http.StatusNoContent || w.Header().Get("Allow") != want {
+			t.Errorf("%s: status = %d, Allow = %q, want %q", key, w.Code, w.Header().Get("Allow"), want)
+		}
+	}
+}
+
+func TestAutoOptionsKeepsExplicitHandler(t *testing.T) {
+	registered := map[string]int{}
+	router := NewTypedRouter[*struct{}](nil, NewRouteRegistry(), "/api", []Option{WithAutoOptions()}, &mockTypedRouterAdapter{},
+		func(method, path string, _ http.HandlerFunc, _ *RouteInfo) {
+			registered[method+" "+path]++
+		})
+	router.Register(http.MethodOptions, "/items", func(context.Context, struct{}) (*struct{}, error) { return nil, nil })
+	router.Get("/items", func(context.Context, struct{}) (*loadShedResponse, error) { return nil, nil })
+	router.Post("/items", func(context.Context, struct{}) (*loadShedResponse, error) { return nil, nil })
+	if registered["OPTIONS /items"] != 1 {
+		t.Errorf("OPTIONS /items registered %d times, want the explicit handler only", registered["OPTIONS /items"])
+	}
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"reflect"
+	"sync"
+)
+
+// sectionOrder is the order sections are parsed in, as the spec requires.
+var sectionOrder = []string{SectionPath, SectionQuery, SectionHeaders, SectionCookies, SectionBody}
+
+// requestPlan locates the sections of a request type, in parsing order.
+type requestPlan struct {
+	sections []sectionRef
+}
+
+// sectionRef is a section of a request type and its field index.
+type sectionRef struct {
+	name  string
+	index int
+}
+
+// fieldBinding is a section field bound from the request, with its gork tag
+// parsed ahead of time.
+type fieldBinding struct {
+	field reflect.StructField
+	tag   GorkTagInfo
+	// keys is the field's name followed by its aliases.
+	keys []string
+	// deepObject marks query structs bound from name[field] keys.
+	deepObject bool
+}
+
+// Binding plans depend on the type alone, so they are built once per type,
+// when a route is registered or on the first request, instead of walking the
+// type and parsing its tags on every request.
+var (
+	requestPlans sync.Map // map[reflect.Type]*requestPlan
+	sectionPlans = map[string]*sync.Map{
+		SectionPath:    {},
+		SectionQuery:   {},
+		SectionHeaders: {},
+		SectionCookies: {},
+		SectionBody:    {},
+	}
+)
+
+// precompileBinding builds the binding plans of a request type and its
+// sections ahead of the first request.
+func precompileBinding(reqType reflect.Type) {
+	if reqType.Kind() != reflect.Struct {
+		return
+	}
+	for _, section := range planRequest(reqType).sections {
+		planSection(section.name, reqType.Field(section.index).Type)
+	}
+}
+
+// planRequest returns the plan of request struct type t.
+func planRequest(t reflect.Type) *requestPlan {
+	if plan, ok := requestPlans.Load(t); ok {
+		return plan.(*requestPlan)
+	}
+	plan := &requestPlan{}
+	for _, name := range sectionOrder {
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).Name == name {
+				plan.sections = append(plan.sections, sectionRef{name: name, index: i})
+				break
+			}
+		}
+	}
+	requestPlans.Store(t, plan)
+	return plan
+}
+
+// planSection returns the fields bound in a section of type t: the
+// gork-tagged fields of parameter sections, promoted ones included for
+// Query, and the fields with a default of a Body struct, which are set
+// before the body is decoded over them.
+func planSection(section string, t reflect.Type) []fieldBinding {
+	plans := sectionPlans[section]
+	if fields, ok := plans.Load(t); ok {
+		return fields.([]fieldBinding)
+	}
+	var fields []fieldBinding
+	if t.Kind() == reflect.Struct {
+		fields = buildSectionFields(section, t)
+	}
+	plans.Store(t, fields)
+	return fields
+}
+
+// buildSectionFields builds the fields planSection caches.
+func buildSectionFields(section string, t reflect.Type) []fieldBinding {
+	var candidates []reflect.StructField
+	switch section {
+	case SectionQuery, SectionBody:
+		candidates = sectionFields(t)
+	default:
+		for i := 0; i < t.NumField(); i++ {
+			candidates = append(candidates, t.Field(i))
+		}
+	}
+
+	var fields []fieldBinding
+	for _, field := range candidates {
+		gorkTag := field.Tag.Get("gork")
+		tagInfo := parseGorkTag(gorkTag)
+		if section == SectionBody {
+			if !field.IsExported() || tagInfo.Default == "" {
+				continue
+			}
+		} else if gorkTag == "" {
+			continue
+		}
+		fields = append(fields, fieldBinding{
+			field:      field,
+			tag:        tagInfo,
+			keys:       append([]string{tagInfo.Name}, tagInfo.Aliases...),
+			deepObject: section == SectionQuery && isDeepObjectType(field.Type),
+		})
+	}
+	return fields
+}
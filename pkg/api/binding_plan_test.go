@@ -0,0 +1,78 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+type bindingBenchRequest struct {
+	Path struct {
+		ID string `gork:"id"`
+	}
+	Query struct {
+		Limit  int      `gork:"limit,default=20"`
+		Offset int      `gork:"offset"`
+		Sort   string   `gork:"sort,alias=order_by"`
+		Tags   []string `gork:"tags"`
+	}
+	Headers struct {
+		RequestID string `gork:"X-Request-ID"`
+	}
+	Body struct {
+		Name  string `gork:"name"`
+		Email string `gork:"email"`
+	}
+}
+
+func BenchmarkConventionParserParseRequest(b *testing.B) {
+	parser := NewConventionParser()
+	adapter := &mockTypedRouterAdapter{
+		pathParams:  map[string]string{"id": "42"},
+		queryParams: map[string]string{"offset": "10", "order_by": "name", "tags": "a,b"},
+	}
+	payload := []byte(`{"name":"Jane","email":"jane@example.com"}`)
+	body := bytes.NewReader(payload)
+	r := httptest.NewRequest(http.MethodPost, "/users/42", nil)
+	r.Header.Set("X-Request-ID", "abc")
+	reqType := reflect.TypeOf(bindingBenchRequest{})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		body.Reset(payload)
+		r.Body = io.NopCloser(body)
+		if err := parser.ParseRequest(context.Background(), r, reflect.New(reqType), adapter); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestBindingPlan(t *testing.T) {
+	reqType := reflect.TypeOf(bindingBenchRequest{})
+	precompileBinding(reqType)
+
+	plan := planRequest(reqType)
+	if plan != planRequest(reqType) {
+		t.Fatal("expected the request plan to be cached")
+	}
+	var names []string
+	for _, section := range plan.sections {
+		names = append(names, section.name)
+	}
+	if want := []string{SectionPath, SectionQuery, SectionHeaders, SectionBody}; !reflect.DeepEqual(names, want) {
+		t.Errorf("expected sections %v in parsing order, got %v", want, names)
+	}
+
+	query := planSection(SectionQuery, reqType.Field(1).Type)
+	if len(query) != 4 || query[2].tag.Name != "sort" || !reflect.DeepEqual(query[2].keys, []string{"sort", "order_by"}) {
+		t.Errorf("unexpected query bindings %+v", query)
+	}
+	if body := planSection(SectionBody, reqType.Field(3).Type); len(body) != 0 {
+		t.Errorf("expected no defaulted body fields, got %+v", body)
+	}
+}
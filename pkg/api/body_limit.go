@@ -0,0 +1,52 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// WithMaxBodySize limits request bodies to n bytes. Requests declaring a
+// larger Content-Length are rejected with 413 Request Entity Too Large
+// before anything is read; bodies growing beyond the limit while they are
+// parsed fail with 413 as well. Passed to a router it applies to all of its
+// routes, and a route's own WithMaxBodySize overrides it. The limit applies
+// to the body as sent, before WithRequestDecompression inflates it.
+func WithMaxBodySize(n int64) Option {
+	return func(h *HandlerOption) {
+		h.MaxBodySize = n
+	}
+}
+
+// limitBody wraps next so that it reads at most limit bytes of the body.
+func limitBody(limit int64, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > limit {
+			writeError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("request body exceeds %d bytes", limit))
+			return
+		}
+		if r.Body != nil && r.Body != http.NoBody {
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+		}
+		next(w, r)
+	}
+}
+
+// addPayloadTooLargeResponse documents the 413 response of routes limiting
+// their body size.
+func addPayloadTooLargeResponse(route *RouteInfo, operation *Operation, components *Components) {
+	if route.Options == nil || (route.Options.MaxBodySize <= 0 && route.Options.Decompression == nil) {
+		return
+	}
+	if components.Responses == nil {
+		components.Responses = map[string]*Response{}
+	}
+	if _, ok := components.Responses["PayloadTooLarge"]; !ok {
+		components.Responses["PayloadTooLarge"] = &Response{
+			Description: "Payload Too Large - Request body exceeds the size limit",
+			Content: map[string]*MediaType{
+				"application/json": {Schema: &Schema{Ref: "#/components/schemas/ErrorResponse"}},
+			},
+		}
+	}
+	operation.Responses["413"] = &Response{Ref: "#/components/responses/PayloadTooLarge"}
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMaxBodySize(t *testing.T) {
+	router, registry, handlers := newLoadShedRouter(WithMaxBodySize(32))
+	echo := func(_ context.Context, req importRequest) (*importResponse, error) {
+		resp := &importResponse{}
+		resp.Body.Name = req.Body.Name
+		return resp, nil
+	}
+	router.Post("/imports", echo)
+	router.Post("/bulk", echo, WithMaxBodySize(1<<10))
+
+	send := func(path string, body io.Reader, contentLength int64) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/api"+path, body)
+		r.ContentLength = contentLength
+		w := httptest.NewRecorder()
+		handlers["POST "+path](w, r)
+		return w
+	}
+
+	if w := send("/imports", strings.NewReader(`{"name":"ok"}`), 13); w.Code != http.StatusOK {
+		t.Errorf("small body: status = %d: %s", w.Code, w.Body)
+	}
+	large := `{"name":"` + strings.Repeat("x", 40) + `"}`
+	w := send("/imports", strings.NewReader(large), int64(len(large)))
+	if w.Code != http.StatusRequestEntityTooLarge || !strings.Contains(w.Body.String(), `"error":"request body exceeds 32 bytes"`) {
+		t.Errorf("declared length: got %d %s", w.Code, w.Body)
+	}
+	// Chunked bodies have no Content-Length and are cut off while parsing.
+	if w := send("/imports", strings.NewReader(large), -1); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("chunked body: status = %d: %s", w.Code, w.Body)
+	}
+	if w := send("/bulk", strings.NewReader(large), int64(len(large))); w.Code != http.StatusOK {
+		t.Errorf("route limit must override the router limit, got %d: %s", w.Code, w.Body)
+	}
+
+	spec := GenerateOpenAPI(registry)
+	if resp := spec.Paths["/api/imports"].Post.Responses["413"]; resp == nil || resp.Ref != "#/components/responses/PayloadTooLarge" {
+		t.Errorf("expected a documented 413 response, got %+v", resp)
+	}
+	if spec.Components.Responses["PayloadTooLarge"] == nil {
+		t.Error("expected the PayloadTooLarge response component")
+	}
+}
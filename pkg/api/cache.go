@@ -0,0 +1,347 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CachePolicy describes how clients and shared caches may cache the
+// successful responses of a route. Set it with WithCache.
+type CachePolicy struct {
+	// MaxAge is the time responses stay fresh (max-age).
+	MaxAge time.Duration
+	// SharedMaxAge overrides MaxAge for shared caches (s-maxage).
+	SharedMaxAge time.Duration
+	// StaleWhileRevalidate lets caches serve stale responses while they
+	// revalidate in the background (stale-while-revalidate).
+	StaleWhileRevalidate time.Duration
+
+	Public         bool
+	Private        bool
+	NoCache        bool
+	NoStore        bool
+	MustRevalidate bool
+	Immutable      bool
+
+	// Expires also sets an Expires header MaxAge from the time of the
+	// response, for HTTP/1.0 caches.
+	Expires bool
+
+	// MemoryEntries caches up to that many GET responses in process for
+	// MaxAge when positive, keyed by request URI, audience, Accept and
+	// Accept-Encoding, and the request headers the response Varies on.
+	// Private, no-cache and no-store policies are never cached in memory,
+	// nor are requests with an Authorization or Cookie header unless the
+	// policy is Public, routes whose request has an Auth section, responses
+	// setting cookies, and responses whose own Cache-Control forbids shared
+	// caching.
+	MemoryEntries int
+}
+
+// String returns the Cache-Control header value of the policy.
+func (p CachePolicy) String() string {
+	var directives []string
+	add := func(ok bool, directive string) {
+		if ok {
+			directives = append(directives, directive)
+		}
+	}
+	seconds := func(d time.Duration) string {
+		return strconv.FormatInt(int64(d/time.Second), 10)
+	}
+	add(p.Public, "public")
+	add(p.Private, "private")
+	add(p.NoCache, "no-cache")
+	add(p.NoStore, "no-store")
+	add(p.MaxAge > 0, "max-age="+seconds(p.MaxAge))
+	add(p.SharedMaxAge > 0, "s-maxage="+seconds(p.SharedMaxAge))
+	add(p.MustRevalidate, "must-revalidate")
+	add(p.Immutable, "immutable")
+	add(p.StaleWhileRevalidate > 0, "stale-while-revalidate="+seconds(p.StaleWhileRevalidate))
+	return strings.Join(directives, ", ")
+}
+
+// WithCache sets Cache-Control (and optionally Expires) on the successful and
+// 304 responses of GET and HEAD requests, unless the handler set
+// Cache-Control itself, and documents the headers in the spec. Errors are
+// never marked cacheable.
+//
+//	router.Get("/countries", ListCountries, api.WithCache(api.CachePolicy{
+//		MaxAge:        time.Hour,
+//		Public:        true,
+//		MemoryEntries: 100,
+//	}))
+func WithCache(policy CachePolicy) Option {
+	return func(h *HandlerOption) {
+		h.Cache = &policy
+	}
+}
+
+// cacheable reports whether responses with status code may carry the
+// policy's headers.
+func cacheable(code int) bool {
+	return code >= 200 && code < 300 || code == http.StatusNotModified
+}
+
+// memoryCacheable reports whether the policy allows caching in process.
+func (p *CachePolicy) memoryCacheable(r *http.Request) bool {
+	if p.MemoryEntries <= 0 || p.MaxAge <= 0 || p.Private || p.NoCache || p.NoStore {
+		return false
+	}
+	credentials := r.Header.Get("Authorization") != "" || r.Header.Get("Cookie") != ""
+	return r.Method == http.MethodGet && (p.Public || !credentials)
+}
+
+// storable reports whether a response with status and header may be stored
+// in memory and replayed to other clients.
+func storable(status int, header http.Header) bool {
+	if status != http.StatusOK || len(header.Values("Set-Cookie")) > 0 || slices.Contains(varyHeaders(header), "*") {
+		return false
+	}
+	for _, directive := range strings.Split(strings.ToLower(header.Get("Cache-Control")), ",") {
+		switch strings.TrimSpace(directive) {
+		case "private", "no-store", "no-cache":
+			return false
+		}
+	}
+	return true
+}
+
+// varyHeaders returns the canonical names of the headers listed by Vary.
+func varyHeaders(header http.Header) []string {
+	var names []string
+	for _, value := range header.Values("Vary") {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, http.CanonicalHeaderKey(name))
+			}
+		}
+	}
+	return names
+}
+
+// cacheKey identifies the responses of r that may be shared: same audience,
+// URI and negotiated representation.
+func cacheKey(r *http.Request) string {
+	return strings.Join([]string{
+		AudienceFromContext(r.Context()),
+		r.URL.RequestURI(),
+		r.Header.Get("Accept"),
+		r.Header.Get("Accept-Encoding"),
+	}, "\n")
+}
+
+// cacheResponseWriter adds the policy's headers to cacheable responses and
+// records the response when it is to be stored in memory.
+type cacheResponseWriter struct {
+	http.ResponseWriter
+	policy      *CachePolicy
+	wroteHeader bool
+	status      int
+	body        *bytes.Buffer
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *cacheResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Flush supports streaming responses.
+func (w *cacheResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *cacheResponseWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.status = code
+	header := w.Header()
+	if cacheable(code) && header.Get("Cache-Control") == "" {
+		if directives := w.policy.String(); directives != "" {
+			header.Set("Cache-Control", directives)
+		}
+		if w.policy.Expires && w.policy.MaxAge > 0 {
+			header.Set("Expires", time.Now().Add(w.policy.MaxAge).UTC().Format(http.TimeFormat))
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *cacheResponseWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.body != nil {
+		w.body.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// cachedResponse is a response stored by a memoryCache.
+type cachedResponse struct {
+	header  http.Header
+	body    []byte
+	stored  time.Time
+	expires time.Time
+	// vary holds the values of the request headers the response Varies on,
+	// which requests must match to be served the entry.
+	vary map[string]string
+}
+
+// matches reports whether r sends the header values entry Varies on.
+func (entry *cachedResponse) matches(r *http.Request) bool {
+	for name, value := range entry.vary {
+		if strings.Join(r.Header.Values(name), ",") != value {
+			return false
+		}
+	}
+	return true
+}
+
+// memoryCache holds the GET responses of one route.
+type memoryCache struct {
+	mu      sync.Mutex
+	entries map[string]*cachedResponse
+	now     func() time.Time
+}
+
+func (c *memoryCache) get(key string) *cachedResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := c.entries[key]
+	if entry == nil {
+		return nil
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return nil
+	}
+	return entry
+}
+
+// put stores entry unless the cache is full of fresh entries.
+func (c *memoryCache) put(key string, entry *cachedResponse, limit int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= limit {
+		now := c.now()
+		for k, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= limit {
+			return
+		}
+	}
+	c.entries[key] = entry
+}
+
+// withCache applies policy to the responses of next and serves GET requests
+// from memory when the policy asks for it.
+func withCache(policy *CachePolicy, next http.HandlerFunc) http.HandlerFunc {
+	cache := &memoryCache{entries: map[string]*cachedResponse{}, now: time.Now}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			next(w, r)
+			return
+		}
+		cw := &cacheResponseWriter{ResponseWriter: w, policy: policy}
+		if !policy.memoryCacheable(r) {
+			next(cw, r)
+			return
+		}
+		key := cacheKey(r)
+		if entry := cache.get(key); entry != nil && entry.matches(r) {
+			serveCached(w, r, entry, cache.now())
+			return
+		}
+		outer := w.Header().Clone()
+		cw.body = &bytes.Buffer{}
+		next(cw, r)
+		if storable(cw.status, w.Header()) {
+			now := cache.now()
+			vary := map[string]string{}
+			for _, name := range varyHeaders(w.Header()) {
+				vary[name] = strings.Join(r.Header.Values(name), ",")
+			}
+			cache.put(key, &cachedResponse{
+				header:  headersAddedTo(outer, w.Header()),
+				body:    cw.body.Bytes(),
+				stored:  now,
+				expires: now.Add(policy.MaxAge),
+				vary:    vary,
+			}, policy.MemoryEntries)
+		}
+	}
+}
+
+// headersAddedTo returns the headers of header that differ from those in
+// outer, which outer wrappers set per request and must not be replayed.
+// Set-Cookie is never replayed.
+func headersAddedTo(outer, header http.Header) http.Header {
+	added := http.Header{}
+	for k, v := range header {
+		if k != "Set-Cookie" && !slices.Equal(outer[k], v) {
+			added[k] = slices.Clone(v)
+		}
+	}
+	return added
+}
+
+// serveCached answers r with entry, or with 304 when r's If-None-Match
+// matches the entry's ETag.
+func serveCached(w http.ResponseWriter, r *http.Request, entry *cachedResponse, now time.Time) {
+	header := w.Header()
+	for k, v := range entry.header {
+		header[k] = slices.Clone(v)
+	}
+	header.Set("Age", strconv.FormatInt(int64(now.Sub(entry.stored)/time.Second), 10))
+	if notModified(r, entry.header.Get("ETag")) {
+		header.Del("Content-Length")
+		header.Del("Content-Type")
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(entry.body)
+}
+
+// applyCache documents the Cache-Control and Expires headers of GET routes
+// using WithCache.
+func applyCache(route *RouteInfo, operation *Operation) {
+	if route.Options == nil || route.Options.Cache == nil || route.Method != http.MethodGet {
+		return
+	}
+	policy := route.Options.Cache
+	for code, resp := range operation.Responses {
+		status, err := strconv.Atoi(code)
+		if err != nil || !cacheable(status) || resp.Ref != "" {
+			continue
+		}
+		if resp.Headers == nil {
+			resp.Headers = map[string]*Header{}
+		}
+		if directives := policy.String(); directives != "" {
+			resp.Headers["Cache-Control"] = &Header{
+				Description: "Caching directives: " + directives,
+				Schema:      &Schema{Type: "string", Example: directives},
+			}
+		}
+		if policy.Expires && policy.MaxAge > 0 {
+			resp.Headers["Expires"] = &Header{
+				Description: "Time after which the response is stale",
+				Schema:      &Schema{Type: "string"},
+			}
+		}
+	}
+}
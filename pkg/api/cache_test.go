@@ -0,0 +1,176 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCachePolicyString(t *testing.T) {
+	policy := CachePolicy{Public: true, MaxAge: time.Hour, SharedMaxAge: 2 * time.Hour, MustRevalidate: true, StaleWhileRevalidate: time.Minute}
+	if got, want := policy.String(), "public, max-age=3600, s-maxage=7200, must-revalidate, stale-while-revalidate=60"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if got := (CachePolicy{NoStore: true}).String(); got != "no-store" {
+		t.Errorf("expected no-store, got %q", got)
+	}
+}
+
+func TestWithCacheHeaders(t *testing.T) {
+	router, registry, handlers := newLoadShedRouter()
+	fail := false
+	router.Get("/items", func(_ context.Context, _ struct{}) (*loadShedResponse, error) {
+		if fail {
+			return nil, errors.New("boom")
+		}
+		return &loadShedResponse{}, nil
+	}, WithCache(CachePolicy{MaxAge: time.Minute, Public: true, Expires: true}))
+
+	rec := httptest.NewRecorder()
+	handlers["GET /items"](rec, httptest.NewRequest(http.MethodGet, "/api/items", nil))
+	if got := rec.Header().Get("Cache-Control"); got != "public, max-age=60" {
+		t.Errorf("expected Cache-Control, got %q", got)
+	}
+	if expires, err := http.ParseTime(rec.Header().Get("Expires")); err != nil || expires.Before(time.Now()) {
+		t.Errorf("expected a future Expires header, got %q", rec.Header().Get("Expires"))
+	}
+
+	fail = true
+	rec = httptest.NewRecorder()
+	handlers["GET /items"](rec, httptest.NewRequest(http.MethodGet, "/api/items", nil))
+	if rec.Code != http.StatusInternalServerError || rec.Header().Get("Cache-Control") != "" {
+		t.Errorf("errors must not be cacheable, got %d %q", rec.Code, rec.Header().Get("Cache-Control"))
+	}
+
+	spec := GenerateOpenAPI(registry)
+	headers := spec.Paths["/api/items"].Get.Responses["200"].Headers
+	if headers["Cache-Control"] == nil || headers["Cache-Control"].Schema.Example != "public, max-age=60" || headers["Expires"] == nil {
+		t.Errorf("expected documented caching headers, got %+v", headers)
+	}
+}
+
+func TestWithCacheMemory(t *testing.T) {
+	router, _, handlers := newLoadShedRouter(WithRequestID())
+	calls := 0
+	router.Get("/items", func(_ context.Context, _ struct{}) (*loadShedResponse, error) {
+		calls++
+		return &loadShedResponse{}, nil
+	}, WithCache(CachePolicy{MaxAge: time.Minute, MemoryEntries: 1}))
+
+	get := func(target string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		rec := httptest.NewRecorder()
+		handlers["GET /items"](rec, req)
+		return rec
+	}
+
+	first := get("/api/items", nil)
+	second := get("/api/items", nil)
+	if calls != 1 || second.Code != http.StatusOK || second.Body.String() != first.Body.String() {
+		t.Fatalf("expected the second request to be served from memory, calls=%d body=%q", calls, second.Body.String())
+	}
+	if second.Header().Get("Age") == "" || second.Header().Get("Cache-Control") != "max-age=60" {
+		t.Errorf("expected Age and Cache-Control on the cached response, got %v", second.Header())
+	}
+	if second.Header().Get(RequestIDHeader) == first.Header().Get(RequestIDHeader) {
+		t.Error("per-request headers of outer wrappers must not be replayed")
+	}
+
+	get("/api/items?page=2", nil)
+	if calls != 2 {
+		t.Errorf("expected another query to miss, calls=%d", calls)
+	}
+	get("/api/items?page=2", nil)
+	if calls != 3 {
+		t.Errorf("expected a full cache to skip storing, calls=%d", calls)
+	}
+	get("/api/items", http.Header{"Authorization": {"Bearer x"}})
+	if calls != 4 {
+		t.Errorf("expected authorized requests to bypass a non-public cache, calls=%d", calls)
+	}
+	get("/api/items", http.Header{"Cookie": {"session=abc"}})
+	if calls != 5 {
+		t.Errorf("expected requests with cookies to bypass a non-public cache, calls=%d", calls)
+	}
+	get("/api/items", http.Header{"Accept": {"application/xml"}})
+	if calls != 6 {
+		t.Errorf("expected another Accept to miss, calls=%d", calls)
+	}
+}
+
+type cacheHeadersResponse struct {
+	Headers struct {
+		CacheControl string `gork:"Cache-Control"`
+		Vary         string `gork:"Vary"`
+	}
+	Cookies struct {
+		Session string `gork:"session"`
+	}
+	Body struct {
+		OK bool `gork:"ok"`
+	}
+}
+
+func TestWithCacheMemoryHonorsResponse(t *testing.T) {
+	tests := []struct {
+		name      string
+		resp      func(*cacheHeadersResponse)
+		header    http.Header
+		wantCalls int
+	}{
+		{"stored", func(*cacheHeadersResponse) {}, nil, 1},
+		{"set cookie", func(r *cacheHeadersResponse) { r.Cookies.Session = "abc" }, nil, 2},
+		{"private", func(r *cacheHeadersResponse) { r.Headers.CacheControl = "private, max-age=60" }, nil, 2},
+		{"no-store", func(r *cacheHeadersResponse) { r.Headers.CacheControl = "no-store" }, nil, 2},
+		{"vary all", func(r *cacheHeadersResponse) { r.Headers.Vary = "*" }, nil, 2},
+		{"vary same", func(r *cacheHeadersResponse) { r.Headers.Vary = "x-tenant" }, http.Header{"X-Tenant": {"a"}}, 1},
+	}
+	for _, tt := range tests {
+		router, _, handlers := newLoadShedRouter()
+		calls := 0
+		router.Get("/items", func(context.Context, struct{}) (*cacheHeadersResponse, error) {
+			calls++
+			resp := &cacheHeadersResponse{}
+			tt.resp(resp)
+			return resp, nil
+		}, WithCache(CachePolicy{MaxAge: time.Minute, MemoryEntries: 2}))
+		var second *httptest.ResponseRecorder
+		for range 2 {
+			req := httptest.NewRequest(http.MethodGet, "/api/items", nil)
+			for k, v := range tt.header {
+				req.Header[k] = v
+			}
+			second = httptest.NewRecorder()
+			handlers["GET /items"](second, req)
+		}
+		if calls != tt.wantCalls {
+			t.Errorf("%s: calls = %d, want %d", tt.name, calls, tt.wantCalls)
+		}
+		if calls == 1 && second.Header().Get("Set-Cookie") != "" {
+			t.Errorf("%s: replayed Set-Cookie", tt.name)
+		}
+	}
+
+	router, _, handlers := newLoadShedRouter()
+	calls := 0
+	router.Get("/items", func(context.Context, struct{}) (*cacheHeadersResponse, error) {
+		calls++
+		resp := &cacheHeadersResponse{}
+		resp.Headers.Vary = "X-Tenant"
+		return resp, nil
+	}, WithCache(CachePolicy{MaxAge: time.Minute, MemoryEntries: 2}))
+	for _, tenant := range []string{"a", "b", "b"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/items", nil)
+		req.Header.Set("X-Tenant", tenant)
+		handlers["GET /items"](httptest.NewRecorder(), req)
+	}
+	if calls != 2 {
+		t.Errorf("expected responses for another X-Tenant to miss, calls=%d", calls)
+	}
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Callback documents a request the API sends to a URL supplied by the
+// client, such as the completion notice of a long-running job.
+type Callback struct {
+	// Name is the key of the callback in the operation's callbacks.
+	Name string
+	// Expression is the runtime expression the callback URL is read from,
+	// e.g. "{$request.body#/callbackUrl}".
+	Expression string
+	// Request is the type of the request sent to the callback URL. Types
+	// with convention sections are documented like handler requests; other
+	// types are documented as the JSON body.
+	Request reflect.Type
+}
+
+// WithCallback documents that the route POSTs a request of req's type to
+// the URL expression evaluates to. The callback is listed under the
+// operation's callbacks with the given name:
+//
+//	router.Post("/jobs", CreateJob, api.WithCallback("onComplete", "{$request.body#/callbackUrl}", JobCompleted{}))
+func WithCallback(name, expression string, req interface{}) Option {
+	if req == nil {
+		panic(fmt.Sprintf("WithCallback %q requires a request value", name))
+	}
+	return func(h *HandlerOption) {
+		h.Callbacks = append(h.Callbacks, Callback{Name: name, Expression: expression, Request: reflect.TypeOf(req)})
+	}
+}
+
+// addCallbacks documents the callbacks declared with WithCallback.
+func (g *ConventionOpenAPIGenerator) addCallbacks(route *RouteInfo, operation *Operation, components *Components) {
+	if route.Options == nil || len(route.Options.Callbacks) == 0 {
+		return
+	}
+	operation.Callbacks = map[string]map[string]*PathItem{}
+	for _, cb := range route.Options.Callbacks {
+		if operation.Callbacks[cb.Name] == nil {
+			operation.Callbacks[cb.Name] = map[string]*PathItem{}
+		}
+		operation.Callbacks[cb.Name][cb.Expression] = &PathItem{Post: g.buildCallbackOperation(cb, components)}
+	}
+}
+
+// buildCallbackOperation documents the request sent to a callback URL.
+func (g *ConventionOpenAPIGenerator) buildCallbackOperation(cb Callback, components *Components) *Operation {
+	op := &Operation{
+		Responses: map[string]*Response{
+			"2XX": {Description: "Callback received"},
+		},
+	}
+	t := cb.Request
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if g.usesConventionSections(t) {
+		g.processRequestSections(t, op, components)
+		return op
+	}
+	op.RequestBody = &RequestBody{
+		Required: true,
+		Content: map[string]*MediaType{
+			"application/json": {Schema: g.generateSchemaFromType(t, "", components)},
+		},
+	}
+	return op
+}
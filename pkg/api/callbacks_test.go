@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type jobCompleted struct {
+	JobID  string  `gork:"jobId"`
+	Result *string `gork:"result"`
+}
+
+type jobFailedCallback struct {
+	Headers struct {
+		Signature string `gork:"X-Signature" validate:"required"`
+	}
+	Body struct {
+		JobID string `gork:"jobId"`
+		Error string `gork:"error"`
+	}
+}
+
+func TestCallbacksOpenAPI(t *testing.T) {
+	type createJobRequest struct {
+		Body struct {
+			CallbackURL string `gork:"callbackUrl"`
+		}
+	}
+	router, registry, _ := newLoadShedRouter()
+	router.Post("/jobs", func(context.Context, createJobRequest) (*loadShedResponse, error) { return nil, nil },
+		WithCallback("onComplete", "{$request.body#/callbackUrl}", jobCompleted{}),
+		WithCallback("onFailure", "{$request.body#/callbackUrl}/failed", &jobFailedCallback{}))
+
+	spec := GenerateOpenAPI(registry)
+	op := spec.Paths["/api/jobs"].Post
+
+	complete := op.Callbacks["onComplete"]["{$request.body#/callbackUrl}"]
+	if complete == nil || complete.Post == nil || complete.Post.RequestBody == nil {
+		t.Fatalf("onComplete callback not documented: %+v", op.Callbacks)
+	}
+	schema := complete.Post.RequestBody.Content["application/json"].Schema
+	if schema.Ref != "#/components/schemas/jobCompleted" || spec.Components.Schemas["jobCompleted"] == nil {
+		t.Errorf("callback body = %+v, want a reference to the jobCompleted component", schema)
+	}
+	if complete.Post.Responses["2XX"] == nil {
+		t.Errorf("callback responses = %+v, want 2XX", complete.Post.Responses)
+	}
+
+	failure := op.Callbacks["onFailure"]["{$request.body#/callbackUrl}/failed"]
+	if failure == nil || failure.Post == nil {
+		t.Fatalf("onFailure callback not documented: %+v", op.Callbacks)
+	}
+	if params := failure.Post.Parameters; len(params) != 1 || params[0].Name != "X-Signature" || params[0].In != "header" {
+		t.Errorf("callback parameters = %+v, want the X-Signature header", params)
+	}
+	if failure.Post.RequestBody == nil {
+		t.Error("callback Body section not documented")
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !strings.Contains(string(data), `"callbacks":{"onComplete":{"{$request.body#/callbackUrl}":{"post":`) {
+		t.Errorf("callbacks missing from the JSON output: %s", data)
+	}
+}
+
+func TestWithCallbackRequiresRequest(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic without a request value")
+		}
+	}()
+	WithCallback("onComplete", "{$request.body#/callbackUrl}", nil)
+}
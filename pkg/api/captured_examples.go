@@ -0,0 +1,108 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// CapturedExample is a request/response pair served by a passing test,
+// recorded with the apitest package. Operation is the method and path
+// template of the route, e.g. "POST /api/users/{id}".
+type CapturedExample struct {
+	Operation    string          `json:"operation"`
+	Name         string          `json:"name"`
+	RequestBody  json.RawMessage `json:"requestBody,omitempty"`
+	Status       int             `json:"status"`
+	ResponseBody json.RawMessage `json:"responseBody,omitempty"`
+}
+
+// LoadCapturedExamples reads the examples written by apitest.Recorder.Save.
+func LoadCapturedExamples(file string) ([]CapturedExample, error) {
+	data, err := os.ReadFile(file) // #nosec G304
+	if err != nil {
+		return nil, err
+	}
+	var examples []CapturedExample
+	if err := json.Unmarshal(data, &examples); err != nil {
+		return nil, fmt.Errorf("parse captured examples %s: %w", file, err)
+	}
+	return examples, nil
+}
+
+// WithCapturedExamples publishes captured request/response pairs as named
+// examples of the request body and of the response for the captured status.
+// Examples of routes that are not part of the spec are ignored.
+func WithCapturedExamples(examples ...CapturedExample) OpenAPIOption {
+	return func(spec *OpenAPISpec) {
+		spec.capturedExamples = append(spec.capturedExamples, examples...)
+	}
+}
+
+// ApplyCapturedExamples attaches captured examples to the operations of an
+// already generated spec, e.g. one produced by `gork openapi generate`.
+// Examples of operations missing from the spec are ignored.
+func ApplyCapturedExamples(spec *OpenAPISpec, examples []CapturedExample) {
+	if spec == nil {
+		return
+	}
+	for _, ex := range examples {
+		method, path, _ := strings.Cut(ex.Operation, " ")
+		item := spec.Paths[normalizePath(path)]
+		if item == nil {
+			continue
+		}
+		if op := pathItemOperations(item)[method]; op != nil {
+			applyCapturedExample(op, ex)
+		}
+	}
+}
+
+func applyCapturedExample(op *Operation, ex CapturedExample) {
+	if value, ok := capturedValue(ex.RequestBody); ok && op.RequestBody != nil {
+		for _, mt := range op.RequestBody.Content {
+			addNamedExample(mt, ex.Name, value)
+		}
+	}
+	value, ok := capturedValue(ex.ResponseBody)
+	if !ok {
+		return
+	}
+	if op.Responses == nil {
+		op.Responses = map[string]*Response{}
+	}
+	status := strconv.Itoa(ex.Status)
+	resp := op.Responses[status]
+	if resp == nil {
+		resp = &Response{Description: http.StatusText(ex.Status)}
+		op.Responses[status] = resp
+	}
+	if len(resp.Content) == 0 {
+		resp.Content = map[string]*MediaType{"application/json": {}}
+	}
+	for _, mt := range resp.Content {
+		addNamedExample(mt, ex.Name, value)
+	}
+}
+
+// capturedValue decodes a captured JSON body; non-JSON bodies are skipped.
+func capturedValue(body json.RawMessage) (interface{}, bool) {
+	if len(body) == 0 {
+		return nil, false
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+func addNamedExample(mt *MediaType, name string, value interface{}) {
+	if mt.Examples == nil {
+		mt.Examples = map[string]*Example{}
+	}
+	mt.Examples[name] = &Example{Value: value}
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestApplyCapturedExamples(t *testing.T) {
+	router, registry, _ := newLoadShedRouter()
+	router.Post("/accounts/{account_id}/payments", CreatePayment)
+	spec := GenerateOpenAPI(registry)
+
+	ApplyCapturedExamples(spec, []CapturedExample{
+		{Operation: "POST /api/accounts/{account_id}/payments", Name: "card", Status: 200,
+			RequestBody: json.RawMessage(`{"method":{"type":"card","number":"4242"}}`), ResponseBody: json.RawMessage(`{"id":"p1"}`)},
+		{Operation: "POST /api/accounts/{account_id}/payments", Name: "missing account", Status: 404,
+			ResponseBody: json.RawMessage(`{"error":"not found"}`)},
+		{Operation: "GET /api/unknown", Name: "ignored", Status: 200, ResponseBody: json.RawMessage(`{}`)},
+		{Operation: "POST /api/accounts/{account_id}/payments", Name: "text", Status: 200, ResponseBody: json.RawMessage(`ok`)},
+	})
+
+	op := spec.Paths["/api/accounts/{account_id}/payments"].Post
+	if op.RequestBody.Content["application/json"].Examples["card"] == nil {
+		t.Error("expected the request body example")
+	}
+	if ex := op.Responses["200"].Content["application/json"].Examples; ex["card"] == nil || ex["text"] != nil {
+		t.Errorf("expected only the JSON response example, got %v", ex)
+	}
+	notFound := op.Responses["404"]
+	if notFound == nil || notFound.Description != "Not Found" || notFound.Content["application/json"].Examples["missing account"] == nil {
+		t.Errorf("expected an undocumented status to be added with its example, got %+v", notFound)
+	}
+}
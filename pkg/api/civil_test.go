@@ -0,0 +1,105 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type civilTestRequest struct {
+	Query struct {
+		Day     Date          `gork:"day"`
+		Opens   TimeOfDay     `gork:"opens"`
+		Timeout time.Duration `gork:"timeout"`
+	}
+	Body struct {
+		Birthday Date           `gork:"birthday"`
+		Window   *time.Duration `gork:"window"`
+		At       time.Time      `gork:"at"`
+	}
+}
+
+func TestCivilTypesText(t *testing.T) {
+	d, err := ParseDate("2024-02-29")
+	if err != nil || d != (Date{Year: 2024, Month: time.February, Day: 29}) || d.String() != "2024-02-29" {
+		t.Errorf("ParseDate() = %v, %v", d, err)
+	}
+	if _, err := ParseDate("2023-02-29"); err == nil {
+		t.Error("expected an invalid date error")
+	}
+	if got := d.In(time.UTC); !got.Equal(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("In() = %v", got)
+	}
+
+	tod, err := ParseTimeOfDay("09:30:15.5")
+	if err != nil || tod != (TimeOfDay{Hour: 9, Minute: 30, Second: 15, Nanosecond: 500000000}) || tod.String() != "09:30:15.5" {
+		t.Errorf("ParseTimeOfDay() = %v, %v", tod, err)
+	}
+	if _, err := ParseTimeOfDay("25:00:00"); err == nil {
+		t.Error("expected an invalid time error")
+	}
+}
+
+func TestCivilTypesBinding(t *testing.T) {
+	var got civilTestRequest
+	registry := NewRouteRegistry()
+	handlers := map[string]http.HandlerFunc{}
+	router := NewTypedRouter[*struct{}](nil, registry, "/api", nil, &DefaultParameterAdapter{}, func(method, path string, h http.HandlerFunc, _ *RouteInfo) {
+		handlers[method+" "+path] = h
+	})
+	router.Post("/schedules", func(_ context.Context, req civilTestRequest) (*loadShedResponse, error) {
+		got = req
+		return &loadShedResponse{}, nil
+	})
+
+	body := `{"birthday":"1990-05-17","window":"1h30m","at":"2024-01-02T03:04:05Z"}`
+	w := httptest.NewRecorder()
+	handlers["POST /schedules"](w, httptest.NewRequest(http.MethodPost, "/api/schedules?day=2024-03-01&opens=08:00:00&timeout=30s", strings.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	want := civilTestRequest{}
+	want.Query.Day = Date{Year: 2024, Month: time.March, Day: 1}
+	want.Query.Opens = TimeOfDay{Hour: 8}
+	want.Query.Timeout = 30 * time.Second
+	want.Body.Birthday = Date{Year: 1990, Month: time.May, Day: 17}
+	window := 90 * time.Minute
+	want.Body.Window = &window
+	want.Body.At = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	for _, target := range []string{"/api/schedules?timeout=30", "/api/schedules?day=01/03/2024"} {
+		w := httptest.NewRecorder()
+		handlers["POST /schedules"](w, httptest.NewRequest(http.MethodPost, target, strings.NewReader(`{}`)))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", target, w.Code)
+		}
+	}
+}
+
+func TestCivilTypeSchemas(t *testing.T) {
+	router, registry, _ := newLoadShedRouter()
+	router.Post("/schedules", func(context.Context, civilTestRequest) (*loadShedResponse, error) { return nil, nil })
+	data, err := json.Marshal(GenerateOpenAPI(registry))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`{"in":"query","name":"day","required":false,"schema":{"format":"date","type":"string"}}`,
+		`{"in":"query","name":"opens","required":false,"schema":{"format":"time","type":"string"}}`,
+		`{"in":"query","name":"timeout","required":false,"schema":{"format":"duration","type":"string"}}`,
+		`"birthday":{"type":"string","format":"date"}`,
+		`"window":{"type":["string","null"],"format":"duration"}`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("spec is missing %s:\n%s", want, data)
+		}
+	}
+}
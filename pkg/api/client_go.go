@@ -0,0 +1,188 @@
+package api
+
+import (
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// GenerateGoClient writes a Go client package for the routes of registry.
+// Every method takes and returns the route's own request and response
+// structs and sends them with client.Do from pkg/client, so server and
+// client share one definition. Routes whose types cannot be referenced from
+// another package (declared in package main, unexported, generic or
+// anonymous) are listed in a comment and left out, as are webhook routes.
+func GenerateGoClient(registry *RouteRegistry, pkg string, w io.Writer) error {
+	if pkg == "" {
+		pkg = "client"
+	}
+	if !token.IsIdentifier(pkg) {
+		return fmt.Errorf("invalid Go package name %q", pkg)
+	}
+
+	imports := goImports{aliases: map[string]string{}, taken: map[string]bool{"context": true, "http": true, "gorkclient": true}}
+	var methods, operations, skipped strings.Builder
+	used := map[string]bool{}
+	for _, route := range registry.GetRoutes() {
+		if route.WebhookHandler != nil || route.RequestType == nil || route.ResponseType == nil {
+			continue
+		}
+		respType := route.ResponseType
+		for respType.Kind() == reflect.Ptr {
+			respType = respType.Elem()
+		}
+		err := referable(route.RequestType)
+		if err == nil {
+			err = referable(respType)
+		}
+		if err == nil {
+			name := clientMethodName(route, used, tsUpperFirst)
+			used[name] = true
+			fields := fmt.Sprintf("{ID: %q, Method: %q, Path: %q}", route.HandlerName, route.Method, catchAllPath(route))
+			respExpr := imports.typeExpr(respType)
+			fmt.Fprintf(&operations, "\t%s,\n", fields)
+			fmt.Fprintf(&methods, goClientMethod, name, route.Method, route.Path, name,
+				imports.typeExpr(route.RequestType), respExpr, respExpr, "gorkclient.Operation"+fields)
+			continue
+		}
+		fmt.Fprintf(&skipped, "//   - %s %s: %v\n", route.Method, route.Path, err)
+	}
+
+	var b strings.Builder
+	b.WriteString("// Code generated by gork client generate. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "// Package %s calls the API with the request and response types of its handlers.\n", pkg)
+	if skipped.Len() > 0 {
+		b.WriteString("//\n// Routes left out:\n")
+		b.WriteString(skipped.String())
+	}
+	fmt.Fprintf(&b, "package %s\n\nimport (\n\t\"context\"\n\t\"net/http\"\n\n\tgorkclient \"github.com/gork-labs/gork/pkg/client\"\n", pkg)
+	b.WriteString(imports.block())
+	b.WriteString(")\n")
+	fmt.Fprintf(&b, goClientRuntime, operations.String())
+	b.WriteString(methods.String())
+
+	src, err := format.Source([]byte(b.String()))
+	if err != nil {
+		return fmt.Errorf("format generated client: %w", err)
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+const goClientRuntime = `
+// Operations lists the operations of the client, e.g. for
+// gorkclient.NewTransport.
+var Operations = []gorkclient.Operation{
+%s}
+
+// Client calls the API at BaseURL.
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// New returns a client for the API at baseURL. A nil httpClient uses
+// http.DefaultClient.
+func New(baseURL string, httpClient *http.Client) *Client {
+	return &Client{BaseURL: baseURL, HTTPClient: httpClient}
+}
+`
+
+const goClientMethod = `
+// %s calls %s %s.
+func (c *Client) %s(ctx context.Context, req %s) (*%s, error) {
+	resp := new(%s)
+	if err := gorkclient.Do(ctx, c.HTTPClient, c.BaseURL, %s, &req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+`
+
+// goImports assigns import aliases to the packages of the referenced types.
+type goImports struct {
+	aliases map[string]string // package path -> alias
+	taken   map[string]bool
+}
+
+// referable reports why t cannot be referenced from the client package.
+func referable(t reflect.Type) error {
+	if t.Name() == "" {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice:
+			return referable(t.Elem())
+		case reflect.Struct:
+			if t.NumField() == 0 {
+				return nil
+			}
+		}
+		return fmt.Errorf("anonymous type %s cannot be referenced", t)
+	}
+	switch {
+	case t.PkgPath() == "main":
+		return fmt.Errorf("type %s is declared in package main", t)
+	case strings.Contains(t.Name(), "["):
+		return fmt.Errorf("generic type %s is not supported", t)
+	case t.PkgPath() != "" && !token.IsExported(t.Name()):
+		return fmt.Errorf("type %s is unexported", t)
+	}
+	return nil
+}
+
+// typeExpr returns the Go expression of a referable type t as seen from the
+// client package, importing its package.
+func (im *goImports) typeExpr(t reflect.Type) string {
+	switch {
+	case t.Name() == "" && t.Kind() == reflect.Ptr:
+		return "*" + im.typeExpr(t.Elem())
+	case t.Name() == "" && t.Kind() == reflect.Slice:
+		return "[]" + im.typeExpr(t.Elem())
+	case t.Name() == "":
+		return "struct{}"
+	case t.PkgPath() == "":
+		return t.Name()
+	}
+	return im.alias(t.PkgPath()) + "." + t.Name()
+}
+
+func (im *goImports) alias(path string) string {
+	if alias, ok := im.aliases[path]; ok {
+		return alias
+	}
+	base := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, path[strings.LastIndex(path, "/")+1:])
+	if base == "" || !token.IsIdentifier(base) || token.IsKeyword(base) {
+		base = "pkg" + base
+	}
+	alias := base
+	for i := 2; im.taken[alias]; i++ {
+		alias = base + strconv.Itoa(i)
+	}
+	im.taken[alias] = true
+	im.aliases[path] = alias
+	return alias
+}
+
+// block renders the aliased imports sorted by path.
+func (im *goImports) block() string {
+	paths := make([]string, 0, len(im.aliases))
+	for path := range im.aliases {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	var b strings.Builder
+	for _, path := range paths {
+		fmt.Fprintf(&b, "\t%s %q\n", im.aliases[path], path)
+	}
+	return b.String()
+}
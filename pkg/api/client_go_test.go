@@ -0,0 +1,66 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+type GoClientUserRequest struct {
+	Path struct {
+		ID string `gork:"id"`
+	}
+}
+
+type GoClientUserResponse struct {
+	Body struct {
+		Name string `gork:"name"`
+	}
+}
+
+func GetGoClientUser(context.Context, GoClientUserRequest) (*GoClientUserResponse, error) {
+	return nil, nil
+}
+
+func TestGenerateGoClient(t *testing.T) {
+	router, registry, _ := newLoadShedRouter()
+	router.Get("/users/{id}", GetGoClientUser)
+	router.Get("/payments", ListPayments)
+	// A chi "/*" catch-all, as the chi adapter registers it.
+	router.Get("/files/{id}", GetGoClientUser, WithPathPattern("id", ".*"))
+
+	var out bytes.Buffer
+	if err := GenerateClient(registry, ClientConfig{Lang: "go", Package: "usersclient"}, &out); err != nil {
+		t.Fatal(err)
+	}
+	src := out.String()
+	if _, err := parser.ParseFile(token.NewFileSet(), "client.go", src, 0); err != nil {
+		t.Fatalf("generated client does not parse: %v\n%s", err, src)
+	}
+	for _, want := range []string{
+		"package usersclient\n",
+		"\tapi \"github.com/gork-labs/gork/pkg/api\"\n",
+		"\t{ID: \"GetGoClientUser\", Method: \"GET\", Path: \"/api/users/{id}\"},\n",
+		"func (c *Client) GetGoClientUser(ctx context.Context, req api.GoClientUserRequest) (*api.GoClientUserResponse, error) {\n" +
+			"\tresp := new(api.GoClientUserResponse)\n" +
+			"\tif err := gorkclient.Do(ctx, c.HTTPClient, c.BaseURL, gorkclient.Operation{ID: \"GetGoClientUser\", Method: \"GET\", Path: \"/api/users/{id}\"}, &req, resp); err != nil {",
+		"Path: \"/api/files/{id...}\"}",
+		"// Routes left out:\n//   - GET /api/payments: type api.tsListRequest is unexported\n",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("client is missing %q:\n%s", want, src)
+		}
+	}
+	if strings.Contains(src, "ListPayments(") {
+		t.Error("routes with unexported types must be left out")
+	}
+}
+
+func TestGenerateGoClientInvalidPackage(t *testing.T) {
+	if err := GenerateGoClient(NewRouteRegistry(), "my-client", &bytes.Buffer{}); err == nil {
+		t.Error("expected an invalid package name to be rejected")
+	}
+}
@@ -0,0 +1,502 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ClientConfig selects the client GenerateClient writes.
+type ClientConfig struct {
+	// Lang is the client language, "ts" or "go".
+	Lang string
+	// Package names the generated Go package. Defaults to "client".
+	Package string
+}
+
+// clientGenerators maps the languages accepted by GenerateClient to their
+// generators.
+var clientGenerators = map[string]func(*RouteRegistry, ClientConfig, io.Writer) error{
+	"ts": func(registry *RouteRegistry, _ ClientConfig, w io.Writer) error {
+		return GenerateTypeScriptClient(registry, w)
+	},
+	"go": func(registry *RouteRegistry, cfg ClientConfig, w io.Writer) error {
+		return GenerateGoClient(registry, cfg.Package, w)
+	},
+}
+
+// SupportedClientLanguages lists the languages GenerateClient accepts.
+func SupportedClientLanguages() []string {
+	langs := make([]string, 0, len(clientGenerators))
+	for lang := range clientGenerators {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// GenerateClient writes a typed client for the routes of registry.
+func GenerateClient(registry *RouteRegistry, cfg ClientConfig, w io.Writer) error {
+	generate, ok := clientGenerators[cfg.Lang]
+	if !ok {
+		return fmt.Errorf("unsupported client language %q (supported: %s)", cfg.Lang, strings.Join(SupportedClientLanguages(), ", "))
+	}
+	return generate(registry, cfg, w)
+}
+
+// GenerateTypeScriptClient writes a fetch based TypeScript client for the
+// routes of registry. Interfaces are derived from the Go types themselves,
+// so field names, required fields and discriminated unions match what the
+// handlers parse and write. Webhook routes are left out.
+func GenerateTypeScriptClient(registry *RouteRegistry, w io.Writer) error {
+	g := &tsGenerator{names: map[reflect.Type]string{}, taken: map[string]bool{}}
+	var methods strings.Builder
+	methodNames := map[string]bool{}
+	for _, route := range registry.GetRoutes() {
+		if route.WebhookHandler != nil || route.RequestType == nil {
+			continue
+		}
+		methods.WriteString(g.method(route, methodNames))
+	}
+
+	var b strings.Builder
+	b.WriteString("// Code generated by gork openapi client --lang ts. DO NOT EDIT.\n\n")
+	for _, decl := range g.decls {
+		b.WriteString(decl)
+		b.WriteString("\n")
+	}
+	b.WriteString(tsClientRuntime)
+	b.WriteString(methods.String())
+	b.WriteString(tsClientRequest)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// tsGenerator declares an interface per named Go struct reachable from the
+// routes, in order of first use.
+type tsGenerator struct {
+	names map[reflect.Type]string
+	taken map[string]bool
+	decls []string
+}
+
+var (
+	timeType        = reflect.TypeOf(time.Time{})
+	rawMessageType  = reflect.TypeOf(json.RawMessage{})
+	tsPathParam     = regexp.MustCompile(`\{([^}/:.]+)(?:[^}]*)\}|:([A-Za-z_][A-Za-z0-9_]*)`)
+	tsIdentifier    = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+	tsAnonymousFunc = regexp.MustCompile(`^func\d+$`)
+)
+
+// typeOf returns the TypeScript type of values of t on the wire.
+func (g *tsGenerator) typeOf(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch {
+	case isNullableStruct(t):
+		value, _ := nullableStructValue(t)
+		return g.typeOf(value) + " | null"
+	case isUnionType(t):
+		var members []string
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).Type.Kind() == reflect.Ptr {
+				members = append(members, g.typeOf(t.Field(i).Type))
+			}
+		}
+		return strings.Join(members, " | ")
+	case t == rawMessageType:
+		return "unknown"
+	case isTextType(t):
+		return "string"
+	case (&SchemerTypeHandler{}).CanHandle(t):
+		return tsSchemaType((&SchemerTypeHandler{}).GenerateSchema(t, nil, false))
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "string" // base64 encoded
+		}
+		elem := g.typeOf(t.Elem())
+		if strings.Contains(elem, " ") {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]"
+	case reflect.Map:
+		return "Record<string, " + g.typeOf(t.Elem()) + ">"
+	case reflect.Struct:
+		if reflect.PointerTo(t).Implements(textMarshalerType) {
+			return "string"
+		}
+		if t.Name() == "" {
+			return g.object(t, false)
+		}
+		return g.declare(t)
+	default:
+		return "unknown"
+	}
+}
+
+// tsSchemaType returns the TypeScript type of a hand-written schema.
+func tsSchemaType(schema *Schema) string {
+	switch schema.Type {
+	case "string":
+		return "string"
+	case "integer", "number":
+		return "number"
+	case "boolean":
+		return "boolean"
+	case "array":
+		return "unknown[]"
+	default:
+		return "unknown"
+	}
+}
+
+// declare emits an interface for the named struct t once and returns its name.
+func (g *tsGenerator) declare(t reflect.Type) string {
+	return g.declareAs(t, tsTypeName(t))
+}
+
+// declareAs emits an interface for struct t named after base once.
+func (g *tsGenerator) declareAs(t reflect.Type, base string) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	name := g.uniqueName(base)
+	g.names[t] = name
+	// Reserve the slot before rendering so nested types follow their parent.
+	idx := len(g.decls)
+	g.decls = append(g.decls, "")
+	obj := g.object(t, false)
+	g.decls[idx] = "export interface " + name + " " + obj + "\n"
+	return name
+}
+
+func (g *tsGenerator) uniqueName(base string) string {
+	name := base
+	for i := 2; g.taken[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	g.taken[name] = true
+	return name
+}
+
+// object renders the fields of struct t as an object type literal. Path
+// parameters are always present, so allRequired is set for Path sections.
+func (g *tsGenerator) object(t reflect.Type, allRequired bool) string {
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, field := range sectionFields(t) {
+		if !field.IsExported() {
+			continue
+		}
+		tagInfo := parseGorkTag(field.Tag.Get("gork"))
+		name := tagInfo.Name
+		if name == "" {
+			name = strings.Split(field.Tag.Get("json"), ",")[0]
+		}
+		if name == "" || name == "-" {
+			continue
+		}
+		typ := g.typeOf(field.Type)
+		if tagInfo.Discriminator != "" {
+			typ = strconv.Quote(tagInfo.Discriminator)
+		} else if field.Type.Kind() == reflect.Ptr {
+			typ += " | null"
+		}
+		optional := "?"
+		if allRequired || (strings.Contains(field.Tag.Get("validate"), "required") && !tagInfo.omittable()) || tagInfo.Discriminator != "" {
+			optional = ""
+		}
+		fmt.Fprintf(&b, "  %s%s: %s;\n", tsPropertyName(name), optional, strings.ReplaceAll(typ, "\n", "\n  "))
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// method renders the client method of a route.
+func (g *tsGenerator) method(route *RouteInfo, used map[string]bool) string {
+	reqType := route.RequestType
+	for reqType.Kind() == reflect.Ptr {
+		reqType = reqType.Elem()
+	}
+
+	var sections []string
+	args := map[string]string{}
+	reqOptional := true
+	if reqType.Kind() == reflect.Struct {
+		for _, section := range []string{SectionPath, SectionQuery, SectionHeaders, SectionBody} {
+			field, ok := reqType.FieldByName(section)
+			if !ok {
+				continue
+			}
+			key := strings.ToLower(section)
+			typ := g.typeOf(field.Type)
+			if section == SectionPath && field.Type.Kind() == reflect.Struct && field.Type.Name() == "" {
+				typ = g.object(field.Type, true)
+			}
+			typ = strings.ReplaceAll(typ, "\n", "\n  ")
+			required := section == SectionPath || section == SectionBody || hasRequiredField(field.Type)
+			optional := "?"
+			if required {
+				optional = ""
+				reqOptional = false
+			}
+			sections = append(sections, fmt.Sprintf("  %s%s: %s;\n", key, optional, typ))
+			args[key] = "req." + key
+		}
+	}
+	if _, ok := args["path"]; !ok {
+		// Without a Path section the parameters are still needed to build the URL.
+		var params []string
+		for _, m := range tsPathParam.FindAllStringSubmatch(route.Path, -1) {
+			params = append(params, "    "+tsPropertyName(m[1]+m[2])+": string;\n")
+		}
+		if len(params) > 0 {
+			sections = append([]string{"  path: {\n" + strings.Join(params, "") + "  };\n"}, sections...)
+			args["path"] = "req.path"
+			reqOptional = false
+		}
+	}
+
+	reqName := ""
+	if len(sections) > 0 {
+		base := reqType.Name()
+		if base == "" || strings.Contains(base, "[") {
+			base = tsUpperFirst(tsMethodName(route, nil)) + "Request"
+		}
+		reqName = g.uniqueName(base)
+		g.decls = append(g.decls, "export interface "+reqName+" {\n"+strings.Join(sections, "")+"}\n")
+	}
+
+	respType := "void"
+	if rt := route.ResponseType; rt != nil {
+		for rt.Kind() == reflect.Ptr {
+			rt = rt.Elem()
+		}
+		if rt.Kind() == reflect.Struct {
+			if body, ok := rt.FieldByName(SectionBody); ok {
+				respType = g.responseBody(rt, body.Type, route)
+			}
+		}
+	}
+
+	name := tsMethodName(route, used)
+	used[name] = true
+
+	param := ""
+	if reqName != "" {
+		param = "req: " + reqName
+		if reqOptional {
+			param += " = {}"
+		}
+	}
+	call := []string{strconv.Quote(route.Method), tsPathTemplate(route.Path, args[strings.ToLower(SectionPath)])}
+	for _, key := range []string{"query", "headers", "body"} {
+		if arg, ok := args[key]; ok {
+			call = append(call, arg)
+		} else {
+			call = append(call, "undefined")
+		}
+	}
+	for len(call) > 2 && call[len(call)-1] == "undefined" {
+		call = call[:len(call)-1]
+	}
+
+	return fmt.Sprintf("\n  /** %s %s */\n  %s(%s): Promise<%s> {\n    return this.request<%s>(%s);\n  }\n",
+		route.Method, route.Path, name, param, respType, respType, strings.Join(call, ", "))
+}
+
+// responseBody returns the type of a response body, declaring anonymous body
+// structs under the name of the response type.
+func (g *tsGenerator) responseBody(respType, bodyType reflect.Type, route *RouteInfo) string {
+	if bodyType.Kind() != reflect.Struct || bodyType.Name() != "" || isUnionType(bodyType) {
+		return g.typeOf(bodyType)
+	}
+	base := respType.Name()
+	if base == "" || strings.Contains(base, "[") {
+		base = tsUpperFirst(tsMethodName(route, nil)) + "Response"
+	}
+	return g.declareAs(bodyType, base)
+}
+
+// hasRequiredField reports whether a section struct has a required field.
+func hasRequiredField(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if strings.Contains(t.Field(i).Tag.Get("validate"), "required") {
+			return true
+		}
+	}
+	return false
+}
+
+// tsMethodName derives the method name from the handler, or from the method
+// and path for anonymous handlers and duplicates.
+func tsMethodName(route *RouteInfo, used map[string]bool) string {
+	return clientMethodName(route, used, tsLowerFirst)
+}
+
+// clientMethodName derives a client method name with the case of the first
+// letter set by firstCase.
+func clientMethodName(route *RouteInfo, used map[string]bool, firstCase func(string) string) string {
+	name := strings.TrimSuffix(route.HandlerName, "-fm")
+	if name == "" || tsAnonymousFunc.MatchString(name) || used[firstCase(name)] {
+		name = strings.ToLower(route.Method)
+		for _, part := range strings.FieldsFunc(route.Path, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			name += tsUpperFirst(part)
+		}
+	}
+	name = firstCase(name)
+	base := name
+	for i := 2; used[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	return name
+}
+
+// tsPathTemplate turns a route pattern into a template literal filling the
+// parameters from pathArg.
+func tsPathTemplate(path, pathArg string) string {
+	path = strings.ReplaceAll(path, "`", "\\`")
+	return "`" + tsPathParam.ReplaceAllStringFunc(path, func(m string) string {
+		sub := tsPathParam.FindStringSubmatch(m)
+		name := sub[1] + sub[2]
+		return "${encodeURIComponent(String(" + pathArg + tsPropertyAccess(name) + "))}"
+	}) + "`"
+}
+
+// tsTypeName names the interface of a named Go type; generic arguments are
+// appended by their short names.
+func tsTypeName(t reflect.Type) string {
+	name := t.Name()
+	idx := strings.Index(name, "[")
+	if idx < 0 {
+		return name
+	}
+	base := name[:idx]
+	for _, arg := range strings.Split(name[idx+1:len(name)-1], ",") {
+		arg = arg[strings.LastIndexAny(arg, "./*]")+1:]
+		base += tsUpperFirst(arg)
+	}
+	return base
+}
+
+func tsPropertyName(name string) string {
+	if tsIdentifier.MatchString(name) {
+		return name
+	}
+	return strconv.Quote(name)
+}
+
+func tsPropertyAccess(name string) string {
+	if tsIdentifier.MatchString(name) {
+		return "." + name
+	}
+	return "[" + strconv.Quote(name) + "]"
+}
+
+func tsUpperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func tsLowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+const tsClientRuntime = `export interface ClientOptions {
+  /** Prepended to every route path, e.g. "https://api.example.com". */
+  baseUrl?: string;
+  /** Sent with every request. */
+  headers?: Record<string, string>;
+  /** Replaces the global fetch, e.g. to add authentication or retries. */
+  fetch?: typeof fetch;
+}
+
+/** Thrown for non-2xx responses; body holds the decoded error response. */
+export class ApiError extends Error {
+  readonly status: number;
+  readonly body: unknown;
+
+  constructor(status: number, body: unknown) {
+    super("request failed with status " + status);
+    this.status = status;
+    this.body = body;
+  }
+}
+
+export class Client {
+  private readonly baseUrl: string;
+  private readonly headers: Record<string, string>;
+  private readonly fetchFn: typeof fetch;
+
+  constructor(options: ClientOptions = {}) {
+    this.baseUrl = (options.baseUrl ?? "").replace(/\/$/, "");
+    this.headers = options.headers ?? {};
+    this.fetchFn = options.fetch ?? ((input, init) => fetch(input, init));
+  }
+`
+
+const tsClientRequest = `
+  private async request<T>(method: string, path: string, query?: object, headers?: object, body?: unknown): Promise<T> {
+    const params = new URLSearchParams();
+    for (const [key, value] of Object.entries(query ?? {})) {
+      if (value === undefined || value === null) continue;
+      if (typeof value === "object" && !Array.isArray(value)) {
+        for (const [field, item] of Object.entries(value)) {
+          if (item !== undefined && item !== null) params.set(key + "[" + field + "]", String(item));
+        }
+        continue;
+      }
+      params.set(key, Array.isArray(value) ? value.join(",") : String(value));
+    }
+    const search = params.toString();
+    const init: RequestInit = { method, headers: { ...this.headers } };
+    const requestHeaders = init.headers as Record<string, string>;
+    for (const [key, value] of Object.entries(headers ?? {})) {
+      if (value !== undefined && value !== null) requestHeaders[key] = String(value);
+    }
+    if (body !== undefined) {
+      requestHeaders["Content-Type"] = "application/json";
+      init.body = JSON.stringify(body);
+    }
+    const res = await this.fetchFn(this.baseUrl + path + (search ? "?" + search : ""), init);
+    const text = await res.text();
+    const data = text ? JSON.parse(text) : undefined;
+    if (!res.ok) {
+      throw new ApiError(res.status, data);
+    }
+    return data as T;
+  }
+}
+`
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gork-labs/gork/pkg/unions"
+)
+
+type tsCardPayment struct {
+	Type   string `gork:"type,discriminator=card" validate:"required"`
+	Number string `gork:"number" validate:"required"`
+}
+
+type tsBankPayment struct {
+	Type string `gork:"type,discriminator=bank" validate:"required"`
+	IBAN string `gork:"iban"`
+}
+
+type tsPaymentRequest struct {
+	Path struct {
+		AccountID string `gork:"account_id"`
+	}
+	Headers struct {
+		IdempotencyKey string `gork:"Idempotency-Key"`
+	}
+	Body struct {
+		Method unions.Union2[tsCardPayment, tsBankPayment] `gork:"method" validate:"required"`
+		Tags   []string                                    `gork:"tags"`
+		Note   *string                                     `gork:"note"`
+	}
+}
+
+type tsPaymentResponse struct {
+	Body struct {
+		ID        string            `gork:"id" validate:"required"`
+		CreatedAt time.Time         `gork:"created_at"`
+		Metadata  map[string]string `gork:"metadata"`
+		internal  string
+	}
+}
+
+type tsListRequest struct {
+	Query struct {
+		Limit int `gork:"limit"`
+	}
+}
+
+type tsListResponse struct {
+	Body []tsCardPayment
+}
+
+func CreatePayment(context.Context, tsPaymentRequest) (*tsPaymentResponse, error) { return nil, nil }
+
+func ListPayments(context.Context, tsListRequest) (*tsListResponse, error) { return nil, nil }
+
+func TestGenerateTypeScriptClient(t *testing.T) {
+	router, registry, _ := newLoadShedRouter()
+	router.Post("/accounts/{account_id}/payments", CreatePayment)
+	router.Get("/payments", ListPayments)
+	router.Delete("/payments/{id}", func(context.Context, struct{}) (*struct{}, error) { return nil, nil })
+
+	var out bytes.Buffer
+	if err := GenerateClient(registry, ClientConfig{Lang: "ts"}, &out); err != nil {
+		t.Fatal(err)
+	}
+	client := out.String()
+	for _, want := range []string{
+		"export interface tsCardPayment {\n  type: \"card\";\n  number: string;\n}",
+		"export interface tsBankPayment {\n  type: \"bank\";\n  iban?: string;\n}",
+		"    method: tsCardPayment | tsBankPayment;\n    tags?: string[];\n    note?: string | null;\n",
+		"export interface tsPaymentRequest {\n  path: {\n    account_id: string;\n  };\n  headers?: {\n    \"Idempotency-Key\"?: string;\n  };\n",
+		"export interface tsPaymentResponse {\n  id: string;\n  created_at?: string;\n  metadata?: Record<string, string>;\n}",
+		"  createPayment(req: tsPaymentRequest): Promise<tsPaymentResponse> {\n    return this.request<tsPaymentResponse>(\"POST\", `/api/accounts/${encodeURIComponent(String(req.path.account_id))}/payments`, undefined, req.headers, req.body);",
+		"  listPayments(req: tsListRequest = {}): Promise<tsCardPayment[]> {\n    return this.request<tsCardPayment[]>(\"GET\", `/api/payments`, req.query);",
+		"export interface DeleteApiPaymentsIdRequest {\n  path: {\n    id: string;\n  };\n}",
+		"  deleteApiPaymentsId(req: DeleteApiPaymentsIdRequest): Promise<void> {\n    return this.request<void>(\"DELETE\", `/api/payments/${encodeURIComponent(String(req.path.id))}`);",
+	} {
+		if !strings.Contains(client, want) {
+			t.Errorf("client is missing %q:\n%s", want, client)
+		}
+	}
+	if strings.Contains(client, "internal") {
+		t.Error("unexported fields must not be part of the client")
+	}
+	if strings.Count(client, "export interface tsCardPayment") != 1 {
+		t.Error("shared types must be declared once")
+	}
+}
+
+func TestGenerateClientUnsupportedLanguage(t *testing.T) {
+	if err := GenerateClient(NewRouteRegistry(), ClientConfig{Lang: "cobol"}, &bytes.Buffer{}); err == nil || !strings.Contains(err.Error(), "supported: go, ts") {
+		t.Errorf("expected an unsupported language error, got %v", err)
+	}
+}
+
+func TestExportWritesClient(t *testing.T) {
+	t.Setenv("GORK_CLIENT", "ts")
+	router, registry, _ := newLoadShedRouter()
+	router.Get("/payments", ListPayments)
+
+	var out bytes.Buffer
+	config := ExportConfig{Output: &out, ExitFunc: func(int) {}, LogFatalf: func(string, ...interface{}) {}}
+	if err := exportOpenAPISpec(registry, config); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "listPayments(") {
+		t.Errorf("expected the client instead of the spec, got %q", out.String())
+	}
+}
@@ -0,0 +1,416 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConformanceIssue describes a single difference between the contract derived
+// from the registered routes and a hand-written (spec-first) OpenAPI document.
+type ConformanceIssue struct {
+	Operation string // "METHOD /path" as written in the design document
+	Location  string // Where the mismatch was found, e.g. "response 200 body.name"
+	Message   string
+}
+
+// String formats the issue for reports.
+func (i ConformanceIssue) String() string {
+	if i.Location == "" {
+		return i.Operation + ": " + i.Message
+	}
+	return i.Operation + ": " + i.Location + ": " + i.Message
+}
+
+// ConformanceError is returned by Conform when the implementation does not
+// match the design document.
+type ConformanceError struct {
+	Issues []ConformanceIssue
+}
+
+// Error lists all conformance issues.
+func (e *ConformanceError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "API does not conform to spec (%d issues):", len(e.Issues))
+	for _, issue := range e.Issues {
+		b.WriteString("\n  - ")
+		b.WriteString(issue.String())
+	}
+	return b.String()
+}
+
+// Conform generates the OpenAPI contract for the registry and compares it
+// with the design document (JSON or YAML). It returns a *ConformanceError
+// listing every mismatch, or nil when the implementation conforms.
+func Conform(registry *RouteRegistry, designDoc []byte, opts ...OpenAPIOption) error {
+	design, err := ParseOpenAPIDocument(designDoc)
+	if err != nil {
+		return err
+	}
+	issues := CheckConformance(GenerateOpenAPI(registry, opts...), design)
+	if len(issues) > 0 {
+		return &ConformanceError{Issues: issues}
+	}
+	return nil
+}
+
+// MustConform is like Conform but panics on mismatch. It is intended as a
+// startup assertion for spec-first services.
+func MustConform(registry *RouteRegistry, designDoc []byte, opts ...OpenAPIOption) {
+	if err := Conform(registry, designDoc, opts...); err != nil {
+		panic(err)
+	}
+}
+
+// ParseOpenAPIDocument parses an OpenAPI document given as JSON or YAML.
+func ParseOpenAPIDocument(data []byte) (*OpenAPISpec, error) {
+	var spec OpenAPISpec
+	if err := json.Unmarshal(data, &spec); err == nil {
+		return &spec, nil
+	}
+
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse OpenAPI document: %w", err)
+	}
+	// YAML documents are normalised to JSON so that the json tags of the
+	// spec types ($ref, operationId, ...) apply.
+	jsonData, err := json.Marshal(normalizeYAMLValue(raw))
+	if err != nil {
+		return nil, fmt.Errorf("parse OpenAPI document: %w", err)
+	}
+	if err := json.Unmarshal(jsonData, &spec); err != nil {
+		return nil, fmt.Errorf("parse OpenAPI document: %w", err)
+	}
+	return &spec, nil
+}
+
+// normalizeYAMLValue converts maps with non-string keys (e.g. unquoted
+// response codes) into JSON-compatible maps.
+func normalizeYAMLValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, item := range val {
+			val[k] = normalizeYAMLValue(item)
+		}
+		return val
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			out[fmt.Sprint(k)] = normalizeYAMLValue(item)
+		}
+		return out
+	case []interface{}:
+		for i, item := range val {
+			val[i] = normalizeYAMLValue(item)
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+// CheckConformance compares the implemented contract with the design
+// document. Operations, parameters, request bodies and success (2xx)
+// responses must match in both directions. Error responses declared by both
+// documents are compared, but error responses declared by only one side are
+// ignored since handlers cannot express every error status statically.
+func CheckConformance(implemented, design *OpenAPISpec) []ConformanceIssue {
+	c := &conformanceChecker{impl: implemented, design: design, visited: map[string]bool{}}
+	c.checkOperations()
+	sort.Slice(c.issues, func(i, j int) bool {
+		a, b := c.issues[i], c.issues[j]
+		if a.Operation != b.Operation {
+			return a.Operation < b.Operation
+		}
+		if a.Location != b.Location {
+			return a.Location < b.Location
+		}
+		return a.Message < b.Message
+	})
+	return c.issues
+}
+
+// conformanceChecker accumulates issues while walking both documents.
+type conformanceChecker struct {
+	impl, design *OpenAPISpec
+	issues       []ConformanceIssue
+	visited      map[string]bool
+	operation    string
+}
+
+func (c *conformanceChecker) report(location, format string, args ...interface{}) {
+	c.issues = append(c.issues, ConformanceIssue{
+		Operation: c.operation,
+		Location:  location,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+// conformOperation is an operation together with its display name.
+type conformOperation struct {
+	name string
+	op   *Operation
+}
+
+var pathParamPattern = regexp.MustCompile(`\{[^}]*\}`)
+
+// collectOperations indexes operations by method and path with parameter
+// names erased so that "/users/{id}" matches "/users/{userID}".
+func collectOperations(spec *OpenAPISpec) map[string]conformOperation {
+	ops := map[string]conformOperation{}
+	for path, item := range spec.Paths {
+		if item == nil {
+			continue
+		}
+		for method, op := range pathItemOperations(item) {
+			key := method + " " + pathParamPattern.ReplaceAllString(path, "{}")
+			ops[key] = conformOperation{name: method + " " + path, op: op}
+		}
+	}
+	return ops
+}
+
+// pathItemOperations returns the operations defined on a path item.
+func pathItemOperations(item *PathItem) map[string]*Operation {
+	ops := map[string]*Operation{}
+	for method, op := range map[string]*Operation{
+		"GET": item.Get, "POST": item.Post, "PUT": item.Put, "PATCH": item.Patch, "DELETE": item.Delete,
+	} {
+		if op != nil {
+			ops[method] = op
+		}
+	}
+	return ops
+}
+
+func (c *conformanceChecker) checkOperations() {
+	implOps := collectOperations(c.impl)
+	designOps := collectOperations(c.design)
+
+	for key, d := range designOps {
+		c.operation = d.name
+		i, ok := implOps[key]
+		if !ok {
+			c.report("", "operation is declared in the spec but not implemented")
+			continue
+		}
+		c.checkParameters(i.op, d.op)
+		c.checkRequestBody(i.op, d.op)
+		c.checkResponses(i.op, d.op)
+	}
+	for key, i := range implOps {
+		if _, ok := designOps[key]; !ok {
+			c.operation = i.name
+			c.report("", "operation is implemented but not declared in the spec")
+		}
+	}
+}
+
+// parameterKey identifies a parameter by location and name. Header names
+// are case-insensitive.
+func parameterKey(p Parameter) string {
+	if p.In == "header" {
+		return p.In + "." + strings.ToLower(p.Name)
+	}
+	return p.In + "." + p.Name
+}
+
+func (c *conformanceChecker) checkParameters(impl, design *Operation) {
+	implParams := map[string]Parameter{}
+	for _, p := range impl.Parameters {
+		implParams[parameterKey(p)] = p
+	}
+	designParams := map[string]bool{}
+	for _, d := range design.Parameters {
+		key := parameterKey(d)
+		designParams[key] = true
+		location := "parameter " + d.In + "." + d.Name
+		i, ok := implParams[key]
+		if !ok {
+			c.report(location, "declared in the spec but not implemented")
+			continue
+		}
+		if i.Required != d.Required {
+			c.report(location, "required is %t, spec declares %t", i.Required, d.Required)
+		}
+		c.checkSchema(location, i.Schema, d.Schema)
+	}
+	for key, i := range implParams {
+		if !designParams[key] {
+			c.report("parameter "+i.In+"."+i.Name, "implemented but not declared in the spec")
+		}
+	}
+}
+
+func (c *conformanceChecker) checkRequestBody(impl, design *Operation) {
+	switch {
+	case impl.RequestBody == nil && design.RequestBody == nil:
+		return
+	case impl.RequestBody == nil:
+		c.report("request body", "declared in the spec but not implemented")
+		return
+	case design.RequestBody == nil:
+		c.report("request body", "implemented but not declared in the spec")
+		return
+	}
+	if impl.RequestBody.Required != design.RequestBody.Required {
+		c.report("request body", "required is %t, spec declares %t", impl.RequestBody.Required, design.RequestBody.Required)
+	}
+	c.checkContent("request body", impl.RequestBody.Content, design.RequestBody.Content)
+}
+
+func (c *conformanceChecker) checkResponses(impl, design *Operation) {
+	for code, d := range design.Responses {
+		i, ok := impl.Responses[code]
+		if !ok {
+			if isSuccessCode(code) {
+				c.report("response "+code, "declared in the spec but not implemented")
+			}
+			continue
+		}
+		c.checkContent("response "+code, resolveResponse(c.impl, i).Content, resolveResponse(c.design, d).Content)
+	}
+	for code := range impl.Responses {
+		if _, ok := design.Responses[code]; !ok && isSuccessCode(code) {
+			c.report("response "+code, "implemented but not declared in the spec")
+		}
+	}
+}
+
+func isSuccessCode(code string) bool {
+	return strings.HasPrefix(code, "2")
+}
+
+// resolveResponse follows a #/components/responses reference.
+func resolveResponse(spec *OpenAPISpec, r *Response) *Response {
+	if r == nil {
+		return &Response{}
+	}
+	name, ok := strings.CutPrefix(r.Ref, "#/components/responses/")
+	if !ok || spec.Components == nil || spec.Components.Responses[name] == nil {
+		return r
+	}
+	return spec.Components.Responses[name]
+}
+
+func (c *conformanceChecker) checkContent(location string, impl, design map[string]*MediaType) {
+	for mediaType, d := range design {
+		i, ok := impl[mediaType]
+		if !ok {
+			c.report(location, "content type %s is declared in the spec but not implemented", mediaType)
+			continue
+		}
+		c.checkSchema(location+" body", mediaSchema(i), mediaSchema(d))
+	}
+	for mediaType := range impl {
+		if _, ok := design[mediaType]; !ok {
+			c.report(location, "content type %s is implemented but not declared in the spec", mediaType)
+		}
+	}
+}
+
+func mediaSchema(m *MediaType) *Schema {
+	if m == nil {
+		return nil
+	}
+	return m.Schema
+}
+
+// resolveSchema follows #/components/schemas references. References that
+// cannot be resolved yield nil so that they are not compared.
+func resolveSchema(spec *OpenAPISpec, s *Schema) *Schema {
+	for s != nil && s.Ref != "" {
+		name, ok := strings.CutPrefix(s.Ref, "#/components/schemas/")
+		if !ok || spec.Components == nil {
+			return nil
+		}
+		s = spec.Components.Schemas[name]
+	}
+	return s
+}
+
+func (c *conformanceChecker) checkSchema(location string, impl, design *Schema) {
+	// Referenced schemas are compared once per operation, which also stops
+	// the walk on recursive types.
+	if ref := c.operation + "|" + schemaRef(impl) + "|" + schemaRef(design); ref != c.operation+"||" {
+		if c.visited[ref] {
+			return
+		}
+		c.visited[ref] = true
+	}
+
+	impl, design = resolveSchema(c.impl, impl), resolveSchema(c.design, design)
+	if impl == nil || design == nil {
+		return
+	}
+
+	implTypes, designTypes := schemaTypes(impl), schemaTypes(design)
+	if designTypes != "" && implTypes != "" && implTypes != designTypes {
+		c.report(location, "type is %s, spec declares %s", implTypes, designTypes)
+		return
+	}
+	c.checkProperties(location, impl, design)
+	if impl.Items != nil || design.Items != nil {
+		c.checkSchema(location+"[]", impl.Items, design.Items)
+	}
+	if impl.AdditionalProperties != nil && design.AdditionalProperties != nil {
+		c.checkSchema(location+"{}", impl.AdditionalProperties, design.AdditionalProperties)
+	}
+}
+
+func schemaRef(s *Schema) string {
+	if s == nil {
+		return ""
+	}
+	return s.Ref
+}
+
+// schemaTypes returns a canonical representation of the schema type(s).
+func schemaTypes(s *Schema) string {
+	types := append([]string{}, s.Types...)
+	if s.Type != "" {
+		types = append(types, s.Type)
+	}
+	if s.Nullable {
+		types = append(types, "null")
+	}
+	sort.Strings(types)
+	return strings.Join(types, "|")
+}
+
+func (c *conformanceChecker) checkProperties(location string, impl, design *Schema) {
+	prefix := location + "."
+	implRequired := stringSet(impl.Required)
+	designRequired := stringSet(design.Required)
+
+	for name, d := range design.Properties {
+		fieldLoc := prefix + name
+		i, ok := impl.Properties[name]
+		if !ok {
+			c.report(fieldLoc, "field is declared in the spec but not implemented")
+			continue
+		}
+		if implRequired[name] != designRequired[name] {
+			c.report(fieldLoc, "required is %t, spec declares %t", implRequired[name], designRequired[name])
+		}
+		c.checkSchema(fieldLoc, i, d)
+	}
+	for name := range impl.Properties {
+		if _, ok := design.Properties[name]; !ok {
+			c.report(prefix+name, "field is implemented but not declared in the spec")
+		}
+	}
+}
+
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
@@ -0,0 +1,255 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type conformGetUserRequest struct {
+	Path struct {
+		ID string `gork:"id"`
+	}
+}
+
+type conformUserResponse struct {
+	Body struct {
+		Name string `gork:"name"`
+	}
+}
+
+func conformGetUser(_ context.Context, _ conformGetUserRequest) (*conformUserResponse, error) {
+	return &conformUserResponse{}, nil
+}
+
+func newConformRegistry() *RouteRegistry {
+	router := NewTypedRouter[*struct{}](nil, NewRouteRegistry(), "", nil, &mockTypedRouterAdapter{}, nil)
+	router.Get("/users/{id}", conformGetUser)
+	return router.GetRegistry()
+}
+
+func parseConformSpec(t *testing.T, doc string) *OpenAPISpec {
+	t.Helper()
+	spec, err := ParseOpenAPIDocument([]byte(doc))
+	if err != nil {
+		t.Fatalf("parse %q: %v", doc, err)
+	}
+	return spec
+}
+
+func issueStrings(issues []ConformanceIssue) []string {
+	out := make([]string, len(issues))
+	for i, issue := range issues {
+		out[i] = issue.String()
+	}
+	return out
+}
+
+func TestConformWithRegistry(t *testing.T) {
+	registry := newConformRegistry()
+	generated, err := json.Marshal(GenerateOpenAPI(registry))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Conform(registry, generated); err != nil {
+		t.Fatalf("expected generated spec to conform, got %v", err)
+	}
+	MustConform(registry, generated)
+
+	design := `
+openapi: 3.1.0
+paths:
+  /users/{userID}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema: {type: string}
+      responses:
+        200:
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [name]
+                properties:
+                  name: {type: string}
+                  email: {type: string}
+        404:
+          description: Not found
+  /users:
+    post:
+      responses:
+        201: {description: Created}
+`
+	err = Conform(registry, []byte(design))
+	var confErr *ConformanceError
+	if !errors.As(err, &confErr) {
+		t.Fatalf("expected ConformanceError, got %v", err)
+	}
+	want := []string{
+		"GET /users/{userID}: response 200 body.email: field is declared in the spec but not implemented",
+		"GET /users/{userID}: response 200 body.name: required is false, spec declares true",
+		"POST /users: operation is declared in the spec but not implemented",
+	}
+	if got := issueStrings(confErr.Issues); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected issues:\n%s", strings.Join(got, "\n"))
+	}
+	if !strings.HasPrefix(err.Error(), "API does not conform to spec (3 issues):\n  - GET /users/{userID}") {
+		t.Errorf("unexpected error message %q", err.Error())
+	}
+
+	if err := Conform(registry, []byte("paths: [")); err == nil {
+		t.Error("expected parse error")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected MustConform to panic")
+		}
+	}()
+	MustConform(registry, []byte(design))
+}
+
+func TestCheckConformance(t *testing.T) {
+	tests := []struct {
+		name   string
+		impl   string
+		design string
+		want   []string
+	}{
+		{
+			name:   "operations",
+			impl:   `{"paths":{"/a":{"get":{}},"/b":{"delete":{}},"/nil":null}}`,
+			design: `{"paths":{"/a":{"get":{}},"/c":{"put":{}}}}`,
+			want: []string{
+				"DELETE /b: operation is implemented but not declared in the spec",
+				"PUT /c: operation is declared in the spec but not implemented",
+			},
+		},
+		{
+			name: "parameters",
+			impl: `{"paths":{"/a":{"patch":{"parameters":[
+				{"name":"X-Token","in":"header","required":true,"schema":{"type":"string"}},
+				{"name":"limit","in":"query","required":false,"schema":{"type":"integer"}},
+				{"name":"extra","in":"query","required":false}]}}}}`,
+			design: `{"paths":{"/a":{"patch":{"parameters":[
+				{"name":"x-token","in":"header","required":true,"schema":{"type":"string"}},
+				{"name":"limit","in":"query","required":true,"schema":{"type":"string"}},
+				{"name":"session","in":"cookie","required":false}]}}}}`,
+			want: []string{
+				"PATCH /a: parameter cookie.session: declared in the spec but not implemented",
+				"PATCH /a: parameter query.extra: implemented but not declared in the spec",
+				"PATCH /a: parameter query.limit: required is false, spec declares true",
+				"PATCH /a: parameter query.limit: type is integer, spec declares string",
+			},
+		},
+		{
+			name:   "request body presence",
+			impl:   `{"paths":{"/a":{"post":{"requestBody":{"content":{}}}},"/b":{"post":{}},"/c":{"get":{}}}}`,
+			design: `{"paths":{"/a":{"post":{}},"/b":{"post":{"requestBody":{"content":{}}}},"/c":{"get":{}}}}`,
+			want: []string{
+				"POST /a: request body: implemented but not declared in the spec",
+				"POST /b: request body: declared in the spec but not implemented",
+			},
+		},
+		{
+			name: "request body content",
+			impl: `{"paths":{"/a":{"put":{"requestBody":{"required":true,"content":{
+				"application/json":{"schema":{"$ref":"#/components/schemas/User"}},
+				"text/plain":{}}}}}},
+				"components":{"schemas":{"User":{"type":"object","properties":{
+					"tags":{"type":"array","items":{"type":"string"}},
+					"nick":{"type":["string","null"]},
+					"extra":{"type":"string"}}}}}}`,
+			design: `{"paths":{"/a":{"put":{"requestBody":{"content":{
+				"application/json":{"schema":{"type":"object","properties":{
+					"tags":{"type":"array","items":{"type":"integer"}},
+					"nick":{"type":["null","string"]}}}},
+				"application/xml":null}}}}}}`,
+			want: []string{
+				"PUT /a: request body: content type application/xml is declared in the spec but not implemented",
+				"PUT /a: request body: content type text/plain is implemented but not declared in the spec",
+				"PUT /a: request body: required is true, spec declares false",
+				"PUT /a: request body body.extra: field is implemented but not declared in the spec",
+				"PUT /a: request body body.tags[]: type is string, spec declares integer",
+			},
+		},
+		{
+			name: "responses",
+			impl: `{"paths":{"/a":{"get":{"responses":{
+				"200":{"$ref":"#/components/responses/Ok"},
+				"202":{},
+				"500":{"$ref":"#/components/responses/Missing"}}}}},
+				"components":{"responses":{"Ok":{"content":{"application/json":{"schema":{"type":"string"}}}}}}}`,
+			design: `{"paths":{"/a":{"get":{"responses":{
+				"200":{"content":{"application/json":{"schema":{"type":"integer"}}}},
+				"204":{},
+				"404":{},
+				"500":null}}}}}`,
+			want: []string{
+				"GET /a: response 200 body: type is string, spec declares integer",
+				"GET /a: response 202: implemented but not declared in the spec",
+				"GET /a: response 204: declared in the spec but not implemented",
+			},
+		},
+		{
+			name: "recursive and unresolved schemas",
+			impl: `{"paths":{"/a":{"get":{"responses":{"200":{"content":{
+				"application/json":{"schema":{"$ref":"#/components/schemas/Node"}},
+				"text/plain":{"schema":{"$ref":"external.json#/Thing"}},
+				"text/csv":null}}}}}},
+				"components":{"schemas":{"Node":{"type":"object","properties":{
+					"children":{"type":"array","items":{"$ref":"#/components/schemas/Node"}}}}}}}`,
+			design: `{"paths":{"/a":{"get":{"responses":{"200":{"content":{
+				"application/json":{"schema":{"$ref":"#/components/schemas/Tree"}},
+				"text/plain":{"schema":{"$ref":"#/components/schemas/Thing"}},
+				"text/csv":{}}}}}}}}`,
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := issueStrings(CheckConformance(parseConformSpec(t, tt.impl), parseConformSpec(t, tt.design)))
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("unexpected issues:\n got: %q\nwant: %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckConformanceRecursiveSchemas(t *testing.T) {
+	spec := `{"paths":{"/a":{"get":{"responses":{"200":{"content":{
+		"application/json":{"schema":{"$ref":"#/components/schemas/Node"}}}}}}}},
+		"components":{"schemas":{"Node":{"type":"object","properties":{
+			"next":{"$ref":"#/components/schemas/Node"}}}}}}`
+	if issues := CheckConformance(parseConformSpec(t, spec), parseConformSpec(t, spec)); len(issues) != 0 {
+		t.Errorf("expected no issues, got %v", issueStrings(issues))
+	}
+}
+
+func TestParseOpenAPIDocument(t *testing.T) {
+	spec, err := ParseOpenAPIDocument([]byte("paths:\n  /a:\n    get:\n      operationId: A\n      responses:\n        200: {description: OK}\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if op := spec.Paths["/a"].Get; op.OperationID != "A" || op.Responses["200"] == nil {
+		t.Errorf("unexpected operation %+v", op)
+	}
+
+	for _, doc := range []string{
+		"paths: [",         // invalid YAML
+		"value: .nan",      // not representable as JSON
+		"paths: [1, 2, 3]", // wrong shape
+	} {
+		if _, err := ParseOpenAPIDocument([]byte(doc)); err == nil || !strings.Contains(err.Error(), "parse OpenAPI document") {
+			t.Errorf("%q: expected parse error, got %v", doc, err)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -54,43 +55,65 @@ func (f *ConventionHandlerFactory) CreateHandler(adapter GenericParameterAdapter
 
 	// Prepare options and build RouteInfo
 	info := buildRouteInfo(handler, reqType, respType, opts)
+	if len(info.Options.TypedMiddleware) > 0 {
+		v = applyTypedMiddleware(info.Options.TypedMiddleware, v)
+	}
+
+	precompileBinding(reqType)
+	checkTokenVerifier(reqType, info.Options)
+
+	parser := f.parser
+	if info.Options.StrictBody {
+		parser = parser.strict()
+	}
+	if info.Options.FormBody {
+		parser = parser.withFormBody()
+	}
+	if info.Options.GeneratedBinders {
+		parser = parser.generated()
+	}
+	if info.Options.TokenVerifier != nil {
+		parser = parser.withTokenVerifier(info.Options.TokenVerifier)
+	}
 
 	// Build the http.HandlerFunc using Convention Over Configuration
 	httpHandler := func(w http.ResponseWriter, r *http.Request) {
-		f.executeConventionHandler(w, r, v, reqType, adapter)
+		f.executeConventionHandler(w, r, parser, v, reqType, adapter, info.Options.ErrorResponses)
 	}
 
-	return httpHandler, info
+	return recoverPanics(info.Options.PanicHandler, httpHandler), info
 }
 
 // executeConventionHandler executes a handler using the Convention Over Configuration approach.
-func (f *ConventionHandlerFactory) executeConventionHandler(w http.ResponseWriter, r *http.Request, handlerValue reflect.Value, reqType reflect.Type, adapter GenericParameterAdapter[*http.Request]) {
+func (f *ConventionHandlerFactory) executeConventionHandler(w http.ResponseWriter, r *http.Request, parser *ConventionParser, handlerValue reflect.Value, reqType reflect.Type, adapter GenericParameterAdapter[*http.Request], errorResponses []ErrorResponseMapping) {
 	// Instantiate request struct
 	reqPtr := reflect.New(reqType)
 
 	// Parse request using Convention Over Configuration
-	if err := f.parser.ParseRequest(r.Context(), r, reqPtr, adapter); err != nil {
-		writeError(w, http.StatusBadRequest, err.Error())
+	if err := parser.ParseRequest(r.Context(), r, reqPtr, adapter); err != nil {
+		if errors.Is(err, ErrUnauthenticated) {
+			writeAuthError(w, err, "Bearer")
+			return
+		}
+		writeError(w, requestErrorStatus(err), err.Error())
 		return
 	}
 
 	// Validate request using Convention Over Configuration
-	if err := f.validator.ValidateRequest(r.Context(), reqPtr.Interface()); err != nil {
+	if err := f.validator.validateRequest(r.Context(), reqPtr.Interface(), parser.generatedBinders); err != nil {
 		f.handleValidationError(w, err)
 		return
 	}
 
 	// Call handler and process response
-	f.processConventionResponse(w, r, handlerValue, reqPtr)
+	f.processConventionResponse(w, r, handlerValue, reqPtr, errorResponses, parser.generatedBinders)
 }
 
 // handleValidationError handles validation errors with proper HTTP status codes.
 func (f *ConventionHandlerFactory) handleValidationError(w http.ResponseWriter, err error) {
 	if IsValidationError(err) {
 		// Client validation error - HTTP 400 Bad Request
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(err)
+		writeValidationError(w, err)
 	} else {
 		// Server error - HTTP 500 Internal Server Error
 		writeError(w, http.StatusInternalServerError, "Request validation failed due to server error")
@@ -98,7 +121,9 @@ func (f *ConventionHandlerFactory) handleValidationError(w http.ResponseWriter,
 }
 
 // processConventionResponse processes the handler response using Convention Over Configuration.
-func (f *ConventionHandlerFactory) processConventionResponse(w http.ResponseWriter, r *http.Request, handlerValue reflect.Value, reqPtr reflect.Value) {
+// Handler errors matching errorResponses are reported with their declared status,
+// and generated selects the response's generated encoder, if any, for its body.
+func (f *ConventionHandlerFactory) processConventionResponse(w http.ResponseWriter, r *http.Request, handlerValue reflect.Value, reqPtr reflect.Value, errorResponses []ErrorResponseMapping, generated bool) {
 	// Call the handler via reflection
 	results := handlerValue.Call([]reflect.Value{
 		reflect.ValueOf(r.Context()),
@@ -111,10 +136,13 @@ func (f *ConventionHandlerFactory) processConventionResponse(w http.ResponseWrit
 		errInterface := results[0].Interface()
 		if errInterface != nil {
 			if errVal, ok := errInterface.(error); ok {
-				writeError(w, http.StatusInternalServerError, errVal.Error())
+				f.writeHandlerError(w, errVal, errorResponses, AudienceFromContext(r.Context()))
 				return
 			}
 		}
+		if writeStreamReport(w, reqPtr) {
+			return
+		}
 		// Success with no content
 		w.WriteHeader(http.StatusNoContent)
 		return
@@ -126,19 +154,33 @@ func (f *ConventionHandlerFactory) processConventionResponse(w http.ResponseWrit
 
 	if errInterface != nil {
 		if errVal, ok := errInterface.(error); ok {
-			writeError(w, http.StatusInternalServerError, errVal.Error())
+			f.writeHandlerError(w, errVal, errorResponses, AudienceFromContext(r.Context()))
 			return
 		}
 		writeError(w, http.StatusInternalServerError, "unknown error")
 		return
 	}
 
+	// Rejected NDJSON lines take precedence over the handler's response
+	if writeStreamReport(w, reqPtr) {
+		return
+	}
+
 	// Process response sections if the response follows Convention Over Configuration
-	f.processResponseSections(w, respVal)
+	f.writeResponse(w, r, respVal, generated)
 }
 
 // processResponseSections processes response sections (Body, Headers, Cookies).
 func (f *ConventionHandlerFactory) processResponseSections(w http.ResponseWriter, respVal reflect.Value) {
+	f.writeResponse(w, nil, respVal, false)
+}
+
+// writeResponse writes the response sections for request r (nil when
+// unknown), encoding slice bodies of flat structs as CSV when the client
+// asked for it and leaving out fields the request's audience may not see.
+// With generated, JSON bodies of responses implementing ResponseEncoder are
+// encoded by the generated code.
+func (f *ConventionHandlerFactory) writeResponse(w http.ResponseWriter, r *http.Request, respVal reflect.Value, generated bool) {
 	// Check if response is nil (only valid for pointer types)
 	if respVal.Kind() == reflect.Ptr && respVal.IsNil() {
 		w.WriteHeader(http.StatusNoContent)
@@ -146,8 +188,32 @@ func (f *ConventionHandlerFactory) processResponseSections(w http.ResponseWriter
 	}
 
 	respStruct, respType := f.extractResponseStructAndType(respVal)
+	// Undocumented status codes are a server bug, not something to send.
+	if status := undeclaredStatus(respStruct); status != 0 {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("response status %d is not declared by the %s section", status, SectionStatusCode))
+		return
+	}
 	bodyValue, hasBody := f.processConventionSections(w, respStruct, respType)
-	f.writeResponseBody(w, respVal, bodyValue, hasBody)
+	// Responses declaring an ETag header answer matching conditional GETs
+	// with 304 and no body.
+	if status := responseStatus(respStruct); (status == 0 || status == http.StatusOK) &&
+		hasETagHeader(respType) && notModified(r, w.Header().Get("ETag")) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	audience := ""
+	if r != nil {
+		audience = AudienceFromContext(r.Context())
+	}
+	if hasBody && wantsCSV(r) && isCSVBodyType(bodyValue.Type()) {
+		writeCSVBody(w, bodyValue, responseStatus(respStruct), audience)
+		return
+	}
+	var encoder ResponseEncoder
+	if generated && respStruct.CanInterface() {
+		encoder, _ = respStruct.Interface().(ResponseEncoder)
+	}
+	f.writeResponseBody(w, respVal, bodyValue, hasBody, responseStatus(respStruct), audience, encoder)
 }
 
 // extractResponseStructAndType extracts the struct and type from response value.
@@ -195,7 +261,7 @@ func (f *ConventionHandlerFactory) processConventionSections(w http.ResponseWrit
 func (f *ConventionHandlerFactory) hasConventionSections(respType reflect.Type) bool {
 	for i := 0; i < respType.NumField(); i++ {
 		field := respType.Field(i)
-		if field.Name == "Body" || field.Name == "Headers" || field.Name == "Cookies" {
+		if field.Name == "Body" || field.Name == "Headers" || field.Name == "Cookies" || field.Name == SectionStatusCode {
 			return true
 		}
 	}
@@ -203,23 +269,45 @@ func (f *ConventionHandlerFactory) hasConventionSections(respType reflect.Type)
 }
 
 // writeResponseBody writes the response body based on whether convention sections are used.
-func (f *ConventionHandlerFactory) writeResponseBody(w http.ResponseWriter, respVal reflect.Value, bodyValue reflect.Value, hasBody bool) {
+// A non-zero status comes from the StatusCode section.
+func (f *ConventionHandlerFactory) writeResponseBody(w http.ResponseWriter, respVal reflect.Value, bodyValue reflect.Value, hasBody bool, status int, audience string, encoder ResponseEncoder) {
 	if hasBody {
-		f.writeConventionBody(w, bodyValue)
+		f.writeConventionBody(w, bodyValue, status, audience, encoder)
+		return
+	}
+	if status != 0 {
+		w.WriteHeader(status)
 		return
 	}
 
 	f.writeNonConventionBody(w, respVal)
 }
 
-// writeConventionBody writes body from convention Body field.
-func (f *ConventionHandlerFactory) writeConventionBody(w http.ResponseWriter, bodyValue reflect.Value) {
+// writeConventionBody writes body from convention Body field, with encoder
+// when not nil, leaving out fields not visible to audience.
+func (f *ConventionHandlerFactory) writeConventionBody(w http.ResponseWriter, bodyValue reflect.Value, status int, audience string, encoder ResponseEncoder) {
+	if isStreamBodyType(bodyValue.Type()) {
+		writeStreamBody(w, bodyValue, status)
+		return
+	}
 	w.Header().Set("Content-Type", "application/json")
-	data, err := f.gorkMarshaler(bodyValue.Interface())
+	var data []byte
+	var err error
+	if encoder != nil {
+		data, err = encoder.EncodeBody()
+	} else {
+		data, err = f.gorkMarshaler(bodyValue.Interface())
+	}
+	if err == nil && hasAudienceFields(bodyValue.Type()) {
+		data, err = filterAudience(data, bodyValue, audience)
+	}
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "Failed to encode response")
 		return
 	}
+	if status != 0 {
+		w.WriteHeader(status)
+	}
 	_, _ = w.Write(data)
 }
 
@@ -283,6 +371,12 @@ func (f *ConventionHandlerFactory) setResponseCookies(w http.ResponseWriter, coo
 		}
 
 		cookieName := parseGorkTag(gorkTag).Name
+		if isCookieType(field.Type) {
+			if cookie := responseCookie(cookieName, fieldValue); cookie != nil {
+				http.SetCookie(w, cookie)
+			}
+			continue
+		}
 		cookieValue := f.getStringValue(fieldValue)
 
 		if cookieValue != "" {
@@ -529,7 +529,7 @@ func TestConventionHandlerFactory_ProcessConventionResponse_NilError(t *testing.
 	rr := httptest.NewRecorder()
 	req := httptest.NewRequest("POST", "/test", nil)
 
-	factory.processConventionResponse(rr, req, handlerValue, reqPtr)
+	factory.processConventionResponse(rr, req, handlerValue, reqPtr, nil, false)
 
 	if rr.Code != http.StatusOK {
 		t.Errorf("Status = %d, want %d", rr.Code, http.StatusOK)
@@ -553,7 +553,7 @@ func TestConventionHandlerFactory_ProcessConventionResponse_UnknownError(t *test
 	rr := httptest.NewRecorder()
 	req := httptest.NewRequest("POST", "/test", nil)
 
-	factory.processConventionResponse(rr, req, handlerValue, reqPtr)
+	factory.processConventionResponse(rr, req, handlerValue, reqPtr, nil, false)
 
 	// Should return 500 for unknown error type
 	if rr.Code != http.StatusInternalServerError {
@@ -2,7 +2,9 @@ package api
 
 import (
 	"fmt"
+	"net/http"
 	"reflect"
+	"strconv"
 	"strings"
 )
 
@@ -10,14 +12,19 @@ import (
 type ConventionOpenAPIGenerator struct {
 	spec      *OpenAPISpec
 	extractor *DocExtractor
+	explain   *ExplainReport
 }
 
 // NewConventionOpenAPIGenerator creates a new convention OpenAPI generator.
 func NewConventionOpenAPIGenerator(spec *OpenAPISpec, extractor *DocExtractor) *ConventionOpenAPIGenerator {
-	return &ConventionOpenAPIGenerator{
+	g := &ConventionOpenAPIGenerator{
 		spec:      spec,
 		extractor: extractor,
 	}
+	if spec != nil {
+		g.explain = spec.explain
+	}
+	return g
 }
 
 // buildConventionOperation builds an OpenAPI operation for Convention Over Configuration requests.
@@ -40,15 +47,36 @@ func (g *ConventionOpenAPIGenerator) buildConventionOperation(route *RouteInfo,
 		// Special handling for webhook operations
 		return g.buildWebhookOperation(route, components, operation)
 	}
+	applyOperationDocs(route, operation)
+
+	var before map[string]bool
+	if g.explain != nil {
+		before = componentNames(components)
+		defer g.explain.recordOperationComponents(before, components)
+		g.explain.operation = route.Method + " " + route.Path
+		g.explain.explainConstraints(route.RequestType.Name(), route.RequestType, map[reflect.Type]bool{})
+		if route.ResponseType != nil {
+			g.explain.explainConstraints(route.ResponseType.Name(), route.ResponseType, map[reflect.Type]bool{})
+		}
+	}
 
 	// Process request sections for regular handlers
 	if route.RequestType.Kind() == reflect.Struct {
 		g.processRequestSections(route.RequestType, operation, components)
+		applyFormBody(route, operation)
+		applyDecompression(route, operation)
+		applyRequestExamples(route, operation)
 	}
+	applyPathPatterns(route, operation)
 
 	// Process response sections
 	if route.ResponseType != nil {
 		g.processResponseSections(route.ResponseType, operation, components, route)
+		applyCSV(route, operation)
+		applyConditionalRequests(route, operation)
+		applyCache(route, operation)
+		applyCORS(route, operation)
+		applyResponseExamples(route, operation)
 	} else {
 		// Error-only handlers generate 204 No Content
 		operation.Responses["204"] = g.generateNoContentResponse()
@@ -56,6 +84,22 @@ func (g *ConventionOpenAPIGenerator) buildConventionOperation(route *RouteInfo,
 
 	// Add standard error responses to all operations
 	g.addStandardErrorResponses(operation, components)
+	g.addDeclaredErrorResponses(route, operation, components)
+	g.addCallbacks(route, operation, components)
+
+	if route.Options != nil && route.Options.LoadShedder != nil {
+		addServiceUnavailableResponse(operation, components)
+	}
+	if route.Options != nil && route.Options.RateLimiter != nil {
+		addTooManyRequestsResponse(operation, components)
+	}
+	if route.Options != nil && route.Options.Timeout > 0 {
+		addGatewayTimeoutResponse(operation, components)
+	}
+	addPayloadTooLargeResponse(route, operation, components)
+	applyRequestID(route, operation)
+	applyExtensions(route, operation)
+	markInternal(route, operation)
 
 	return operation
 }
@@ -73,6 +117,14 @@ func (g *ConventionOpenAPIGenerator) processRequestSections(reqType reflect.Type
 		case SectionHeaders:
 			g.processHeadersSection(field.Type, operation, components)
 		case SectionBody:
+			if isStreamBodyType(field.Type) {
+				operation.RequestBody = &RequestBody{Required: true, Content: binaryMediaType(field)}
+				continue
+			}
+			if isNDJSONStreamType(field.Type) {
+				g.processStreamBody(field.Type, operation, components)
+				continue
+			}
 			g.processBodySection(field.Type, reqType, operation, components)
 		case SectionCookies:
 			g.processCookiesSection(field.Type, operation, components)
@@ -86,8 +138,7 @@ func (g *ConventionOpenAPIGenerator) processQuerySection(sectionType reflect.Typ
 		return
 	}
 
-	for i := 0; i < sectionType.NumField(); i++ {
-		field := sectionType.Field(i)
+	for _, field := range sectionFields(sectionType) {
 		gorkTag := field.Tag.Get("gork")
 		validateTag := field.Tag.Get("validate")
 
@@ -103,8 +154,21 @@ func (g *ConventionOpenAPIGenerator) processQuerySection(sectionType reflect.Typ
 			Required: strings.Contains(validateTag, "required"),
 			Schema:   g.generateSchemaFromType(field.Type, validateTag, components),
 		}
+		applyQueryStyle(&param, field.Type)
+		if promotedFromPageRequest(sectionType, field) {
+			param.Description = pageParameterDescriptions[tagInfo.Name]
+		}
+		if tagInfo.Example != "" {
+			param.Example = exampleValue(field.Type, tagInfo.Example)
+		}
+		applyTagDefault(param.Schema, field.Type, tagInfo.Default)
+		param.audience = tagInfo.Audience
+		param.Sensitive = tagInfo.Sensitive
+		param.Extensions = tagExtensions(tagInfo)
+		param.Aliases = tagInfo.Aliases
 
 		operation.Parameters = append(operation.Parameters, param)
+		appendAliasParameters(operation, param, tagInfo.Aliases)
 	}
 }
 
@@ -131,8 +195,17 @@ func (g *ConventionOpenAPIGenerator) processPathSection(sectionType reflect.Type
 			Required: true, // Path parameters are always required
 			Schema:   g.generateSchemaFromType(field.Type, validateTag, components),
 		}
+		if tagInfo.Example != "" {
+			param.Example = exampleValue(field.Type, tagInfo.Example)
+		}
+		applyTagDefault(param.Schema, field.Type, tagInfo.Default)
+		param.audience = tagInfo.Audience
+		param.Sensitive = tagInfo.Sensitive
+		param.Extensions = tagExtensions(tagInfo)
+		param.Aliases = tagInfo.Aliases
 
 		operation.Parameters = append(operation.Parameters, param)
+		appendAliasParameters(operation, param, tagInfo.Aliases)
 	}
 }
 
@@ -154,13 +227,24 @@ func (g *ConventionOpenAPIGenerator) processHeadersSection(sectionType reflect.T
 		tagInfo := parseGorkTag(gorkTag)
 
 		param := Parameter{
-			Name:     tagInfo.Name,
+			Name:     headerName(tagInfo.Name),
 			In:       "header",
 			Required: strings.Contains(validateTag, "required"),
 			Schema:   g.generateSchemaFromType(field.Type, validateTag, components),
 		}
+		if tagInfo.Example != "" {
+			param.Example = exampleValue(field.Type, tagInfo.Example)
+		}
+		applyTagDefault(param.Schema, field.Type, tagInfo.Default)
+		param.audience = tagInfo.Audience
+		param.Sensitive = tagInfo.Sensitive
+		param.Extensions = tagExtensions(tagInfo)
+		for _, alias := range tagInfo.Aliases {
+			param.Aliases = append(param.Aliases, headerName(alias))
+		}
 
 		operation.Parameters = append(operation.Parameters, param)
+		appendAliasParameters(operation, param, param.Aliases)
 	}
 }
 
@@ -187,13 +271,61 @@ func (g *ConventionOpenAPIGenerator) processCookiesSection(sectionType reflect.T
 			Required: strings.Contains(validateTag, "required"),
 			Schema:   g.generateSchemaFromType(field.Type, validateTag, components),
 		}
+		if tagInfo.Example != "" {
+			param.Example = exampleValue(field.Type, tagInfo.Example)
+		}
+		applyTagDefault(param.Schema, field.Type, tagInfo.Default)
+		param.audience = tagInfo.Audience
+		param.Sensitive = tagInfo.Sensitive
+		param.Extensions = tagExtensions(tagInfo)
+		param.Aliases = tagInfo.Aliases
 
 		operation.Parameters = append(operation.Parameters, param)
+		appendAliasParameters(operation, param, tagInfo.Aliases)
+	}
+}
+
+// appendAliasParameters documents the old names of a renamed parameter as
+// deprecated, optional parameters. Path parameters are bound by the route
+// template, so their aliases are not documented.
+func appendAliasParameters(operation *Operation, param Parameter, aliases []string) {
+	if param.In == "path" {
+		return
+	}
+	for _, alias := range aliases {
+		aliasParam := param
+		aliasParam.Name = alias
+		aliasParam.Aliases = nil
+		aliasParam.Required = false
+		aliasParam.Deprecated = true
+		aliasParam.Description = "Deprecated: use " + param.Name + " instead."
+		operation.Parameters = append(operation.Parameters, aliasParam)
 	}
 }
 
 // processBodySection processes request body for OpenAPI.
 func (g *ConventionOpenAPIGenerator) processBodySection(sectionType reflect.Type, reqType reflect.Type, operation *Operation, components *Components) {
+	// Slices of flat structs are accepted as JSON arrays or CSV rows
+	if isCSVBodyType(sectionType) {
+		operation.RequestBody = &RequestBody{
+			Required: true,
+			Content: map[string]*MediaType{
+				"application/json": {Schema: g.generateSchemaFromType(sectionType, "", components)},
+				ContentTypeCSV:     csvMediaType(),
+			},
+		}
+		return
+	}
+
+	// Pass-through bodies accept any JSON object
+	if isFreeFormType(sectionType) {
+		operation.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{"application/json": {Schema: freeFormSchema()}},
+		}
+		return
+	}
+
 	if sectionType.Kind() != reflect.Struct {
 		return
 	}
@@ -201,10 +333,16 @@ func (g *ConventionOpenAPIGenerator) processBodySection(sectionType reflect.Type
 	// Generate component reference for the body section
 	schema := g.generateRequestBodyComponentSchema(sectionType, reqType, components)
 
+	// File uploads cannot be sent as JSON
+	mediaType := "application/json"
+	if hasUploadedFiles(sectionType) {
+		mediaType = ContentTypeMultipartFormData
+	}
+
 	operation.RequestBody = &RequestBody{
 		Required: true,
 		Content: map[string]*MediaType{
-			"application/json": {
+			mediaType: {
 				Schema: schema,
 			},
 		},
@@ -250,6 +388,7 @@ func (g *ConventionOpenAPIGenerator) processResponseSections(respType reflect.Ty
 	}
 
 	var bodySchema *Schema
+	var streamContent map[string]*MediaType
 
 	// Process response sections for headers, cookies, and body
 	for i := 0; i < respType.NumField(); i++ {
@@ -258,17 +397,21 @@ func (g *ConventionOpenAPIGenerator) processResponseSections(respType reflect.Ty
 		switch field.Name {
 		case SchemaSuffixBody.String():
 			// Generate body schema only if there's a Body field
-			if hasBody {
+			if isStreamBodyType(field.Type) {
+				streamContent = binaryMediaType(field)
+			} else if hasBody {
 				bodySchema = g.generateResponseComponentSchema(respType, components)
 			}
 		case SchemaSuffixHeaders.String():
 			g.processResponseHeaders(field.Type, response, components)
 		case SchemaSuffixCookies.String():
-			// Cookies are typically not documented in OpenAPI responses
-			// They are set via Set-Cookie header
+			g.processResponseCookies(field.Type, operation, components)
 		}
 	}
 
+	// Codes declared by a StatusCode section replace the default 200/204
+	codes, _ := responseStatusCodes(respType)
+
 	// If there's no Body field, return 204 No Content (but with headers processed)
 	if !hasBody {
 		// Use 204 No Content response but preserve any headers that were processed
@@ -277,12 +420,14 @@ func (g *ConventionOpenAPIGenerator) processResponseSections(respType reflect.Ty
 		if len(response.Headers) > 0 {
 			noContentResponse.Headers = response.Headers
 		}
-		operation.Responses["204"] = noContentResponse
+		successResponses(operation, codes, "204", noContentResponse)
 		return
 	}
 
-	// Add body content for 200 response
-	if bodySchema != nil {
+	// Add body content for the success response
+	if streamContent != nil {
+		response.Content = streamContent
+	} else if bodySchema != nil {
 		response.Content = map[string]*MediaType{
 			"application/json": {
 				Schema: bodySchema,
@@ -290,7 +435,7 @@ func (g *ConventionOpenAPIGenerator) processResponseSections(respType reflect.Ty
 		}
 	}
 
-	operation.Responses["200"] = response
+	successResponses(operation, codes, "200", response)
 }
 
 // generateResponseComponentSchema creates a component reference for a response type,
@@ -300,6 +445,7 @@ func (g *ConventionOpenAPIGenerator) generateResponseComponentSchema(respType re
 	if typeName == "" {
 		// For anonymous types, we can't create a component reference
 		// Fall back to inline schema generation
+		g.explain.inline(respType.String(), "anonymous response type has no name to use for a component")
 		return g.generateInlineResponseSchema(respType, components)
 	}
 
@@ -316,12 +462,22 @@ func (g *ConventionOpenAPIGenerator) generateResponseComponentSchema(respType re
 		if field.Name == SchemaSuffixBody.String() {
 			bodyType := field.Type
 
+			// Pass-through bodies are documented inline as free form objects
+			if isFreeFormType(bodyType) {
+				return freeFormSchema()
+			}
+
 			// If Body is a named struct type, reference it directly instead of creating a wrapper
 			if bodyType.Kind() == reflect.Struct && bodyType.Name() != "" && !isUnionType(bodyType) {
 				// Generate schema for the body type directly
 				return g.generateSchemaFromType(bodyType, "", components)
 			}
 
+			// Unions named via NameSchema are referenced under that name
+			if _, named := schemaNameOverride(bodyType); named && isUnionType(bodyType) {
+				return g.generateSchemaFromType(bodyType, "", components)
+			}
+
 			// For anonymous structs or other types, proceed with original logic
 			break
 		}
@@ -348,7 +504,9 @@ func (g *ConventionOpenAPIGenerator) generateResponseComponentSchema(respType re
 	// Store the component schema with a collision-safe name
 	unique := uniqueSchemaNameForType(respType, components.Schemas)
 	componentSchema.Title = unique
+	componentSchema.goType = respType
 	components.Schemas[unique] = componentSchema
+	g.explain.component(unique, "%s", explainComponentName(unique, respType))
 
 	// Return a reference to the component
 	return &Schema{
@@ -371,6 +529,7 @@ func (g *ConventionOpenAPIGenerator) generateInlineResponseSchema(respType refle
 // extractBodyPropertiesToResponseSchema extracts properties from a Body field type
 // and adds them directly to the response component schema.
 func (g *ConventionOpenAPIGenerator) extractBodyPropertiesToResponseSchema(bodyType reflect.Type, responseSchema *Schema, components *Components) {
+	defer dropAliasProperties(responseSchema)
 	// For union types, the response schema becomes the union directly (no Body wrapper)
 	// because the handler factory serializes only the Body field content, not the whole response
 	if isUnionType(bodyType) {
@@ -396,6 +555,7 @@ func (g *ConventionOpenAPIGenerator) extractBodyPropertiesToResponseSchema(bodyT
 			for propName, propSchema := range bodySchema.Properties {
 				responseSchema.Properties[propName] = propSchema
 			}
+			responseSchema.copyPropertyAudiences(bodySchema)
 			// Copy required fields too
 			if bodySchema.Required != nil {
 				responseSchema.Required = bodySchema.Required
@@ -414,26 +574,47 @@ func (g *ConventionOpenAPIGenerator) extractStructPropertiesToSchema(structType
 	for i := 0; i < structType.NumField(); i++ {
 		field := structType.Field(i)
 
+		// Promote the fields of embedded structs, which may be unexported
+		if embedded, ok := embeddedStructType(field); ok {
+			promoted := &Schema{Type: "object", Properties: map[string]*Schema{}}
+			g.extractStructPropertiesToSchema(embedded, promoted, components)
+			schema.mergePromoted(promoted)
+			continue
+		}
+
 		// Skip unexported fields
 		if !field.IsExported() {
 			continue
 		}
 
 		// Get field name from gork tag or use field name
-		fieldName := field.Tag.Get("gork")
+		tagInfo := parseGorkTag(field.Tag.Get("gork"))
+		fieldName := tagInfo.Name
 		if fieldName == "" {
 			fieldName = field.Name
 		}
 
 		// Generate schema for the field
 		fieldSchema := g.generateSchemaFromType(field.Type, field.Tag.Get("validate"), components)
+		// Pointer fields accept null, which decodes to nil like an absent field
+		if fieldSchema != nil && field.Type.Kind() == reflect.Ptr {
+			fieldSchema = makeNullableSchema(fieldSchema)
+		}
 		if fieldSchema != nil {
+			applyTagExample(fieldSchema, field.Type, tagInfo.Example)
+			applyTagDefault(fieldSchema, field.Type, tagInfo.Default)
+			fieldSchema.Sensitive = tagInfo.Sensitive
+			fieldSchema.ReadOnly = tagInfo.ReadOnly
+			fieldSchema.WriteOnly = tagInfo.WriteOnly
+			fieldSchema.Extensions = tagExtensions(tagInfo)
 			schema.Properties[fieldName] = fieldSchema
+			addAliasProperties(schema, fieldName, tagInfo.Aliases, fieldSchema)
+			schema.setPropertyAudience(tagInfo.Audience, append([]string{fieldName}, tagInfo.Aliases...)...)
 		}
 
-		// Check if field is required
+		// Check if field is required; fields Marshal may leave out never are
 		validateTag := field.Tag.Get("validate")
-		if strings.Contains(validateTag, "required") {
+		if strings.Contains(validateTag, "required") && !tagInfo.omittable() {
 			schema.Required = append(schema.Required, fieldName)
 		}
 	}
@@ -441,12 +622,18 @@ func (g *ConventionOpenAPIGenerator) extractStructPropertiesToSchema(structType
 
 // generateRequestBodyComponentSchema creates a component reference for a request body section.
 func (g *ConventionOpenAPIGenerator) generateRequestBodyComponentSchema(bodyType reflect.Type, reqType reflect.Type, components *Components) *Schema {
+	// Unions named via NameSchema are referenced under that name
+	if _, named := schemaNameOverride(bodyType); named && isUnionType(bodyType) {
+		return g.generateSchemaFromType(bodyType, "", components)
+	}
+
 	// For request bodies, we want to create a component schema for the body content
 	// We'll use the request context to generate a meaningful component name
 	componentName := g.generateRequestBodyComponentName(bodyType, reqType)
 
 	if componentName == "" {
 		// For anonymous types with no meaningful name, fall back to inline schema generation
+		g.explain.inline(bodyType.String(), "request body has no type name, request name or exported fields to derive a component name from")
 		return g.generateInlineRequestBodySchema(bodyType, components)
 	}
 
@@ -465,11 +652,21 @@ func (g *ConventionOpenAPIGenerator) generateRequestBodyComponentSchema(bodyType
 		Description: "",
 	}
 
-	// Extract properties from the body struct
-	g.extractStructPropertiesToSchema(bodyType, componentSchema, components)
+	// Extract properties from the body struct; union bodies become the
+	// union itself, as for responses
+	if isUnionType(bodyType) {
+		unionSchema := g.generateUnionSchema(bodyType, components)
+		componentSchema.Type = ""
+		componentSchema.Properties = nil
+		componentSchema.OneOf = unionSchema.OneOf
+		componentSchema.Discriminator = unionSchema.Discriminator
+	} else {
+		g.extractStructPropertiesToSchema(bodyType, componentSchema, components)
+	}
 
 	// Store the component schema
 	components.Schemas[componentName] = componentSchema
+	g.explain.component(componentName, "%s", g.explainRequestBodyComponentName(bodyType, reqType))
 
 	// Return a reference to the component
 	return &Schema{
@@ -518,6 +715,21 @@ func (g *ConventionOpenAPIGenerator) generateRequestBodyComponentName(bodyType r
 	return ""
 }
 
+// explainRequestBodyComponentName mirrors generateRequestBodyComponentName
+// for the explain report.
+func (g *ConventionOpenAPIGenerator) explainRequestBodyComponentName(bodyType reflect.Type, reqType reflect.Type) string {
+	switch {
+	case bodyType.Name() != "" && isUnionType(bodyType):
+		return fmt.Sprintf("concise union name for %s + %q", bodyType, SchemaSuffixBody.String())
+	case bodyType.Name() != "":
+		return fmt.Sprintf("body type %s + %q", bodyType, SchemaSuffixBody.String())
+	case reqType != nil && reqType.Name() != "":
+		return fmt.Sprintf("request type %s without \"Request\" suffix + %q", reqType, SchemaSuffixBody.String())
+	default:
+		return "anonymous body named after its first exported fields"
+	}
+}
+
 // generateInlineRequestBodySchema generates an inline schema for request body types
 // when no meaningful component name can be generated.
 func (g *ConventionOpenAPIGenerator) generateInlineRequestBodySchema(bodyType reflect.Type, components *Components) *Schema {
@@ -572,13 +784,25 @@ func (g *ConventionOpenAPIGenerator) generateSchemaFromType(fieldType reflect.Ty
 		return nil
 	}
 
+	if value, ok := nullableStructValue(fieldType); ok {
+		return optionalSchema(g.generateSchemaFromType(value, validateTag, components))
+	}
+
 	// Check if this is a union type
 	if isUnionType(fieldType) {
+		if name, ok := schemaNameOverride(fieldType); ok {
+			return g.namedUnionSchema(fieldType, name, components)
+		}
 		return g.generateUnionSchema(fieldType, components)
 	}
 
 	// Handle other types using existing logic
+	var before map[string]bool
+	if g.explain != nil {
+		before = componentNames(components)
+	}
 	schema := reflectTypeToSchema(fieldType, components.Schemas)
+	g.explain.recordNewComponents(before, components, fieldType)
 	if schema != nil && validateTag != "" {
 		// Create a dummy struct field for validation constraints
 		sf := reflect.StructField{
@@ -606,7 +830,7 @@ func (g *ConventionOpenAPIGenerator) usesConventionSections(t reflect.Type) bool
 	// Check if any field uses standard section names
 	for i := 0; i < t.NumField(); i++ {
 		field := t.Field(i)
-		if AllowedSections[field.Name] {
+		if AllowedSections[field.Name] || field.Name == SectionStatusCode {
 			return true
 		}
 	}
@@ -820,8 +1044,13 @@ func (g *ConventionOpenAPIGenerator) ensureErrorSchemas(components *Components)
 		}
 	}
 
-	// Add ValidationErrorResponse schema if it doesn't exist
+	// Add ValidationErrorResponse schema if it doesn't exist, in the shape
+	// of a custom validation error encoder when one is set
 	if _, exists := components.Schemas["ValidationErrorResponse"]; !exists {
+		if custom := customValidationErrorSchema(components); custom != nil {
+			components.Schemas["ValidationErrorResponse"] = custom
+			return
+		}
 		components.Schemas["ValidationErrorResponse"] = &Schema{
 			Type:        "object",
 			Title:       "ValidationErrorResponse",
@@ -833,7 +1062,7 @@ func (g *ConventionOpenAPIGenerator) ensureErrorSchemas(components *Components)
 				},
 				"details": {
 					Type:        "object",
-					Description: "Field-level validation errors (maps field names to arrays of error messages)",
+					Description: "Field-level validation errors, mapping field paths to arrays of error messages. Paths start with the request section and follow nested fields with dots and items with their index or map key: query.limit, body.address.zip, body.items[3].qty. Errors of request-level rules are keyed request. Routes using WithRequestID add the request's ID as request_id.",
 				},
 			},
 			Required: []string{"error"},
@@ -954,8 +1183,9 @@ func (g *ConventionOpenAPIGenerator) isWebhookHandler(route *RouteInfo) bool {
 func (g *ConventionOpenAPIGenerator) buildWebhookOperation(route *RouteInfo, components *Components, operation *Operation) *Operation {
 	// Webhooks typically have a more generic request body structure
 	// Set summary and description for webhook operations
-	operation.Summary = fmt.Sprintf("Webhook endpoint for %s", route.HandlerName)
-	operation.Description = "Webhook endpoint that receives events from external services"
+	operation.Summary = webhookOperationSummary(route.HandlerName)
+	operation.Description = webhookOperationDescription
+	applyOperationDocs(route, operation)
 
 	// Add webhook-specific extensions
 	if operation.Extensions == nil {
@@ -963,26 +1193,35 @@ func (g *ConventionOpenAPIGenerator) buildWebhookOperation(route *RouteInfo, com
 	}
 
 	// Attach provider metadata (route-provided or reflected from handler)
-	if p := g.getWebhookProviderInfo(route); p != nil {
+	if p := g.getWebhookProviderInfo(route); p != nil && p.Name != "" {
 		provider := map[string]string{"name": p.Name, "website": p.Website, "docs": p.DocsURL}
 		operation.Extensions["x-webhook-provider"] = provider
 		operation.XWebhookProvider = provider
 	}
-	// Always emit x-webhook-events as an array of objects with at least {"event": string}
+	// Always emit x-webhook-events as an array of objects with at least
+	// {"event": string}; it also marks the operation as a webhook, see
+	// isWebhookOperation.
 	eventEntries := g.buildWebhookEventEntries(route, components)
+	if eventEntries == nil {
+		eventEntries = []map[string]interface{}{}
+	}
+	operation.Extensions["x-webhook-events"] = eventEntries
 	if len(eventEntries) > 0 {
-		operation.Extensions["x-webhook-events"] = eventEntries
 		operation.XWebhookEvents = eventEntries
 	}
 
 	// Process webhook request body
 	g.processWebhookRequestBody(route.RequestType, operation, components)
+	applyDecompression(route, operation)
 
 	// Add webhook-specific responses using reflection on the actual webhook handler
 	g.addWebhookResponses(operation, components, route)
 
 	// Add standard error responses (but skip 400 since we have a webhook-specific one)
 	g.addStandardErrorResponsesForWebhook(operation, components)
+	addPayloadTooLargeResponse(route, operation, components)
+	applyExtensions(route, operation)
+	markInternal(route, operation)
 
 	return operation
 }
@@ -990,7 +1229,10 @@ func (g *ConventionOpenAPIGenerator) buildWebhookOperation(route *RouteInfo, com
 // getWebhookProvider determines the webhook provider from the request type.
 // Note: Provider detection is intentionally omitted. Configuration is user-driven.
 func (g *ConventionOpenAPIGenerator) getWebhookProviderInfo(route *RouteInfo) *WebhookProviderInfo {
-	if route.WebhookProviderInfo != nil {
+	if route.Options != nil && route.Options.WebhookProvider != nil {
+		return route.Options.WebhookProvider
+	}
+	if route.WebhookProviderInfo != nil && route.WebhookProviderInfo.Name != "" {
 		return route.WebhookProviderInfo
 	}
 	if route.WebhookHandler == nil {
@@ -1107,7 +1349,7 @@ func (g *ConventionOpenAPIGenerator) renameGenericWebhookRequestIfNeeded(reqType
 	} else {
 		toStore = *schema
 	}
-	toStore.Title = compName
+	toStore.Title, toStore.goType = compName, nil
 	components.Schemas[compName] = &toStore
 	delete(components.Schemas, "WebhookRequest")
 	return &Schema{Ref: "#/components/schemas/" + compName}
@@ -1152,8 +1394,10 @@ func (g *ConventionOpenAPIGenerator) addWebhookResponses(operation *Operation, c
 	if webhookHandler == nil {
 		// Fallback to basic responses if no webhook handler is available
 		g.addFallbackWebhookResponses(operation, components)
+		g.applyWebhookSuccessStatus(operation, route)
 		return
 	}
+	defer g.applyWebhookSuccessStatus(operation, route)
 
 	// Use reflection to get the success response type
 	successResponseType := g.getWebhookResponseType(webhookHandler, "SuccessResponse")
@@ -1198,6 +1442,23 @@ func (g *ConventionOpenAPIGenerator) addWebhookResponses(operation *Operation, c
 	}
 }
 
+// applyWebhookSuccessStatus moves the documented success response to the
+// status configured with WithSuccessStatus, dropping its body when
+// acknowledgements are empty.
+func (g *ConventionOpenAPIGenerator) applyWebhookSuccessStatus(operation *Operation, route *RouteInfo) {
+	success := operation.Responses["200"]
+	if success == nil {
+		return
+	}
+	if route.WebhookEmptySuccessBody {
+		success.Content = nil
+	}
+	if code := route.WebhookSuccessStatus; code != 0 && code != http.StatusOK {
+		delete(operation.Responses, "200")
+		operation.Responses[strconv.Itoa(code)] = success
+	}
+}
+
 // providerSpecificWebhookTypeRef ensures provider-prefixed component names for generic webhook types.
 func (g *ConventionOpenAPIGenerator) providerSpecificWebhookTypeRef(t reflect.Type, schema *Schema, components *Components, suffix string) *Schema {
 	pkgPath := t.PkgPath()
@@ -1221,7 +1482,7 @@ func (g *ConventionOpenAPIGenerator) providerSpecificWebhookTypeRef(t reflect.Ty
 		} else {
 			toStore = *schema
 		}
-		toStore.Title = compName
+		toStore.Title, toStore.goType = compName, nil
 		components.Schemas[compName] = &toStore
 		// Remove generic component counterpart if present
 		delete(components.Schemas, suffix)
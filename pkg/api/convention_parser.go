@@ -2,9 +2,11 @@ package api
 
 import (
 	"context"
+	"encoding"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"reflect"
 	"strconv"
 	"strings"
@@ -36,14 +38,62 @@ var AllowedSections = map[string]bool{
 type ConventionParser struct {
 	typeRegistry *TypeParserRegistry
 	validator    *validator.Validate
+	// strictBody rejects JSON and form bodies with unknown fields.
+	strictBody bool
+	// formBody accepts application/x-www-form-urlencoded Body sections.
+	formBody bool
+	// generatedBinders binds request types implementing RequestBinder with
+	// their generated code.
+	generatedBinders bool
+	// tokenVerifier verifies the bearer tokens populating Auth sections.
+	tokenVerifier TokenVerifier
+}
+
+// ParserOption configures a ConventionParser.
+type ParserOption func(*ConventionParser)
+
+// DisallowUnknownFields makes the parser reject JSON bodies with keys that
+// match no field, listing them in the error, instead of ignoring them.
+func DisallowUnknownFields() ParserOption {
+	return func(p *ConventionParser) {
+		p.strictBody = true
+	}
 }
 
 // NewConventionParser creates a new convention parser.
-func NewConventionParser() *ConventionParser {
-	return &ConventionParser{
+func NewConventionParser(opts ...ParserOption) *ConventionParser {
+	p := &ConventionParser{
 		typeRegistry: NewTypeParserRegistry(),
 		validator:    validator.New(),
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// strict returns a parser sharing p's type parsers that rejects unknown
+// body fields.
+func (p *ConventionParser) strict() *ConventionParser {
+	strict := *p
+	strict.strictBody = true
+	return &strict
+}
+
+// withFormBody returns a parser sharing p's type parsers that accepts
+// form-urlencoded bodies.
+func (p *ConventionParser) withFormBody() *ConventionParser {
+	form := *p
+	form.formBody = true
+	return &form
+}
+
+// generated returns a parser sharing p's type parsers that prefers
+// generated binders.
+func (p *ConventionParser) generated() *ConventionParser {
+	generated := *p
+	generated.generatedBinders = true
+	return &generated
 }
 
 // ParseRequest provides a public API for parsing HTTP requests using convention over configuration.
@@ -105,41 +155,30 @@ func (p *ConventionParser) RegisterTypeParser(parserFunc interface{}) error {
 }
 
 // ParseRequest parses an HTTP request into the given request struct using convention over configuration.
-// Follows spec parsing order: Path, Query, Headers, Cookies, Body.
+// Follows spec parsing order: Auth, Path, Query, Headers, Cookies, Body.
 func (p *ConventionParser) ParseRequest(ctx context.Context, r *http.Request, reqPtr reflect.Value, adapter GenericParameterAdapter[*http.Request]) error {
 	if reqPtr.Kind() != reflect.Ptr || reqPtr.Elem().Kind() != reflect.Struct {
 		return fmt.Errorf("request must be a pointer to struct")
 	}
 
 	reqStruct := reqPtr.Elem()
-	reqType := reqStruct.Type()
+	if err := p.parseAuthSection(ctx, reqStruct, r); err != nil {
+		return err
+	}
 
-	// Parse sections in the order specified by the spec
-	sectionOrder := []string{SectionPath, SectionQuery, SectionHeaders, SectionCookies, SectionBody}
+	if bound, err := p.bindGenerated(r, reqPtr, adapter); bound {
+		return err
+	}
 
-	for _, sectionName := range sectionOrder {
-		field, fieldValue := p.findSection(reqType, reqStruct, sectionName)
-		if field != nil {
-			if err := p.parseSection(ctx, sectionName, fieldValue, r, adapter); err != nil {
-				return fmt.Errorf("failed to parse %s section: %w", sectionName, err)
-			}
+	for _, section := range planRequest(reqStruct.Type()).sections {
+		if err := p.parseSection(ctx, section.name, reqStruct.Field(section.index), r, adapter); err != nil {
+			return fmt.Errorf("failed to parse %s section: %w", section.name, err)
 		}
 	}
 
 	return nil
 }
 
-// findSection finds a section field in the struct.
-func (p *ConventionParser) findSection(reqType reflect.Type, reqStruct reflect.Value, sectionName string) (*reflect.StructField, reflect.Value) {
-	for i := 0; i < reqType.NumField(); i++ {
-		field := reqType.Field(i)
-		if field.Name == sectionName {
-			return &field, reqStruct.Field(i)
-		}
-	}
-	return nil, reflect.Value{}
-}
-
 // parseSection parses a specific section of the request.
 func (p *ConventionParser) parseSection(ctx context.Context, sectionName string, sectionValue reflect.Value, r *http.Request, adapter GenericParameterAdapter[*http.Request]) error {
 	// Special case for Body field - allow []byte for raw body parsing (webhook support)
@@ -152,24 +191,42 @@ func (p *ConventionParser) parseSection(ctx context.Context, sectionName string,
 		return fmt.Errorf("section %s must be a struct", sectionName)
 	}
 
-	sectionType := sectionValue.Type()
+	if _, ok := sectionPlans[sectionName]; !ok {
+		return nil
+	}
+	fields := planSection(sectionName, sectionValue.Type())
 
 	switch sectionName {
 	case SectionPath:
-		return p.parsePathSection(ctx, sectionValue, sectionType, r, adapter)
+		return p.parsePathSection(ctx, sectionValue, fields, r, adapter)
 	case SectionQuery:
-		return p.parseQuerySection(ctx, sectionValue, sectionType, r, adapter)
+		return p.parseQuerySection(ctx, sectionValue, fields, r, adapter)
 	case SectionHeaders:
-		return p.parseHeadersSection(ctx, sectionValue, sectionType, r, adapter)
+		return p.parseHeadersSection(ctx, sectionValue, fields, r, adapter)
 	case SectionCookies:
-		return p.parseCookiesSection(ctx, sectionValue, sectionType, r, adapter)
+		return p.parseCookiesSection(ctx, sectionValue, fields, r, adapter)
 	}
 
 	return nil
 }
 
-// parseBodySection parses the request body using gork JSON or raw bytes.
+// parseBodySection parses the request body using gork JSON, form-urlencoded
+// or multipart values, raw bytes, or hands it over as a stream.
 func (p *ConventionParser) parseBodySection(sectionValue reflect.Value, r *http.Request) error {
+	// Streamed bodies are handed over unread
+	if isStreamBodyType(sectionValue.Type()) {
+		setStreamBody(sectionValue, r)
+		return nil
+	}
+	if stream, ok := asStreamSection(sectionValue); ok {
+		if r.Body == nil {
+			stream.bindBody(http.NoBody)
+		} else {
+			stream.bindBody(r.Body)
+		}
+		return nil
+	}
+
 	// Check if this is a direct []byte field instead of a struct
 	if sectionValue.Kind() == reflect.Slice && sectionValue.Type().Elem().Kind() == reflect.Uint8 {
 		return p.parseRawBodyField(sectionValue, r)
@@ -184,23 +241,69 @@ func (p *ConventionParser) parseBodySection(sectionValue reflect.Value, r *http.
 		return nil
 	}
 
+	if isCSVRequest(r) && isCSVBodyType(sectionValue.Type()) {
+		return p.decodeCSVBody(r.Context(), sectionValue, r.Body)
+	}
+
+	if isMultipartRequest(r) && sectionValue.Kind() == reflect.Struct {
+		// Multipart bodies spool file parts to disk and are sent cross-site
+		// without a preflight, so only upload routes accept them.
+		if !hasUploadedFiles(sectionValue.Type()) {
+			return fmt.Errorf("%w %q", errUnsupportedMediaType, ContentTypeMultipartFormData)
+		}
+		if err := r.ParseMultipartForm(DefaultMultipartMemory); err != nil {
+			return fmt.Errorf("failed to decode multipart body: %w", err)
+		}
+		return p.decodeFormSection(r.Context(), sectionValue, r.MultipartForm.Value, r.MultipartForm.File)
+	}
+
 	// Read the body first
 	bodyBytes, err := io.ReadAll(r.Body)
 	if err != nil {
 		return fmt.Errorf("failed to read request body: %w", err)
 	}
 
+	if isFormRequest(r) && sectionValue.Kind() == reflect.Struct {
+		// Forms are CORS-safelisted and sent cross-site without a
+		// preflight, so routes opt in to them.
+		if !p.formBody {
+			return fmt.Errorf("%w %q", errUnsupportedMediaType, ContentTypeFormURLEncoded)
+		}
+		values, err := url.ParseQuery(string(bodyBytes))
+		if err != nil {
+			return fmt.Errorf("failed to decode form body: %w", err)
+		}
+		return p.decodeFormSection(r.Context(), sectionValue, values, nil)
+	}
+
+	// Create a pointer to the section struct for JSON decoding, starting
+	// from the defaults of fields the body may leave out
+	sectionPtr := reflect.New(sectionValue.Type())
+	defaulted, err := p.setBodyDefaults(r.Context(), sectionPtr.Elem())
+	if err != nil {
+		return err
+	}
+
 	// Use gork JSON unmarshaling if body is not empty
 	if len(bodyBytes) > 0 {
-		// Create a pointer to the section struct for JSON decoding
-		sectionPtr := reflect.New(sectionValue.Type())
-		if err := gorkson.Unmarshal(bodyBytes, sectionPtr.Interface()); err != nil {
+		if hasReadOnlyFields(sectionValue.Type()) {
+			if err := gorkson.CheckReadOnly(bodyBytes, sectionPtr.Interface()); err != nil {
+				return fmt.Errorf("failed to decode JSON body: %w", err)
+			}
+		}
+		unmarshal := gorkson.Unmarshal
+		if p.strictBody {
+			unmarshal = gorkson.UnmarshalStrict
+		}
+		if err := unmarshal(bodyBytes, sectionPtr.Interface()); err != nil {
 			return fmt.Errorf("failed to decode JSON body: %w", err)
 		}
-
-		// Copy the decoded values back to the original struct
-		sectionValue.Set(sectionPtr.Elem())
+	} else if !defaulted {
+		return nil
 	}
+
+	// Copy the decoded values back to the original struct
+	sectionValue.Set(sectionPtr.Elem())
 	return nil
 }
 
@@ -224,25 +327,22 @@ func (p *ConventionParser) parseRawBodyField(sectionValue reflect.Value, r *http
 }
 
 // parsePathSection parses path parameters.
-func (p *ConventionParser) parsePathSection(ctx context.Context, sectionValue reflect.Value, sectionType reflect.Type, r *http.Request, adapter GenericParameterAdapter[*http.Request]) error {
+func (p *ConventionParser) parsePathSection(ctx context.Context, sectionValue reflect.Value, fields []fieldBinding, r *http.Request, adapter GenericParameterAdapter[*http.Request]) error {
 	if adapter == nil {
 		return nil
 	}
 
-	for i := 0; i < sectionType.NumField(); i++ {
-		field := sectionType.Field(i)
-		fieldValue := sectionValue.Field(i)
-
-		gorkTag := field.Tag.Get("gork")
-		if gorkTag == "" {
-			continue
-		}
+	for _, binding := range fields {
+		field, tagInfo := binding.field, binding.tag
+		fieldValue := sectionValue.Field(field.Index[0])
 
-		paramName := parseGorkTag(gorkTag).Name
-		if val, ok := adapter.Path(r, paramName); ok {
+		paramName := tagInfo.Name
+		if val, ok := lookupParam(tagInfo, func(k string) (string, bool) { return adapter.Path(r, k) }); ok {
 			if err := p.setFieldValue(ctx, fieldValue, field, val); err != nil {
-				return fmt.Errorf("failed to set path parameter %s: %w", paramName, err)
+				return fmt.Errorf("failed to set path parameter %s: %w", paramName, redactError(tagInfo, err))
 			}
+		} else if err := p.setDefault(ctx, fieldValue, field, tagInfo); err != nil {
+			return fmt.Errorf("failed to set path parameter %s: %w", paramName, err)
 		}
 	}
 
@@ -250,25 +350,34 @@ func (p *ConventionParser) parsePathSection(ctx context.Context, sectionValue re
 }
 
 // parseQuerySection parses query parameters.
-func (p *ConventionParser) parseQuerySection(ctx context.Context, sectionValue reflect.Value, sectionType reflect.Type, r *http.Request, adapter GenericParameterAdapter[*http.Request]) error {
+func (p *ConventionParser) parseQuerySection(ctx context.Context, sectionValue reflect.Value, fields []fieldBinding, r *http.Request, adapter GenericParameterAdapter[*http.Request]) error {
 	if adapter == nil {
 		return nil
 	}
 
-	for i := 0; i < sectionType.NumField(); i++ {
-		field := sectionType.Field(i)
-		fieldValue := sectionValue.Field(i)
+	for _, binding := range fields {
+		field, tagInfo := binding.field, binding.tag
+		fieldValue := sectionValue.FieldByIndex(field.Index)
 
-		gorkTag := field.Tag.Get("gork")
-		if gorkTag == "" {
+		paramName := tagInfo.Name
+		if binding.deepObject && p.typeRegistry.GetParser(field.Type) == nil {
+			if err := p.parseDeepObject(ctx, fieldValue, paramName, r, adapter); err != nil {
+				return err
+			}
 			continue
 		}
-
-		paramName := parseGorkTag(gorkTag).Name
-		if val, ok := adapter.Query(r, paramName); ok {
+		if repeated, err := p.parseRepeatedQuery(ctx, fieldValue, binding, r, adapter); repeated || err != nil {
+			if err != nil {
+				return fmt.Errorf("failed to set query parameter %s: %w", paramName, redactError(tagInfo, err))
+			}
+			continue
+		}
+		if val, ok := lookupParam(tagInfo, func(k string) (string, bool) { return adapter.Query(r, k) }); ok {
 			if err := p.setFieldValue(ctx, fieldValue, field, val); err != nil {
-				return fmt.Errorf("failed to set query parameter %s: %w", paramName, err)
+				return fmt.Errorf("failed to set query parameter %s: %w", paramName, redactError(tagInfo, err))
 			}
+		} else if err := p.setDefault(ctx, fieldValue, field, tagInfo); err != nil {
+			return fmt.Errorf("failed to set query parameter %s: %w", paramName, err)
 		}
 	}
 
@@ -276,25 +385,28 @@ func (p *ConventionParser) parseQuerySection(ctx context.Context, sectionValue r
 }
 
 // parseHeadersSection parses HTTP headers.
-func (p *ConventionParser) parseHeadersSection(ctx context.Context, sectionValue reflect.Value, sectionType reflect.Type, r *http.Request, adapter GenericParameterAdapter[*http.Request]) error {
+func (p *ConventionParser) parseHeadersSection(ctx context.Context, sectionValue reflect.Value, fields []fieldBinding, r *http.Request, adapter GenericParameterAdapter[*http.Request]) error {
 	if adapter == nil {
 		return nil
 	}
 
-	for i := 0; i < sectionType.NumField(); i++ {
-		field := sectionType.Field(i)
-		fieldValue := sectionValue.Field(i)
+	for _, binding := range fields {
+		field, tagInfo := binding.field, binding.tag
+		fieldValue := sectionValue.Field(field.Index[0])
 
-		gorkTag := field.Tag.Get("gork")
-		if gorkTag == "" {
+		headerName := tagInfo.Name
+		if repeated, err := p.parseRepeatedHeader(ctx, fieldValue, binding, r, adapter); repeated || err != nil {
+			if err != nil {
+				return fmt.Errorf("failed to set header %s: %w", headerName, redactError(tagInfo, err))
+			}
 			continue
 		}
-
-		headerName := parseGorkTag(gorkTag).Name
-		if val, ok := adapter.Header(r, headerName); ok {
+		if val, ok := lookupParam(tagInfo, func(k string) (string, bool) { return adapter.Header(r, k) }); ok {
 			if err := p.setFieldValue(ctx, fieldValue, field, val); err != nil {
-				return fmt.Errorf("failed to set header %s: %w", headerName, err)
+				return fmt.Errorf("failed to set header %s: %w", headerName, redactError(tagInfo, err))
 			}
+		} else if err := p.setDefault(ctx, fieldValue, field, tagInfo); err != nil {
+			return fmt.Errorf("failed to set header %s: %w", headerName, err)
 		}
 	}
 
@@ -302,25 +414,22 @@ func (p *ConventionParser) parseHeadersSection(ctx context.Context, sectionValue
 }
 
 // parseCookiesSection parses HTTP cookies.
-func (p *ConventionParser) parseCookiesSection(ctx context.Context, sectionValue reflect.Value, sectionType reflect.Type, r *http.Request, adapter GenericParameterAdapter[*http.Request]) error {
+func (p *ConventionParser) parseCookiesSection(ctx context.Context, sectionValue reflect.Value, fields []fieldBinding, r *http.Request, adapter GenericParameterAdapter[*http.Request]) error {
 	if adapter == nil {
 		return nil
 	}
 
-	for i := 0; i < sectionType.NumField(); i++ {
-		field := sectionType.Field(i)
-		fieldValue := sectionValue.Field(i)
+	for _, binding := range fields {
+		field, tagInfo := binding.field, binding.tag
+		fieldValue := sectionValue.Field(field.Index[0])
 
-		gorkTag := field.Tag.Get("gork")
-		if gorkTag == "" {
-			continue
-		}
-
-		cookieName := parseGorkTag(gorkTag).Name
-		if val, ok := adapter.Cookie(r, cookieName); ok {
+		cookieName := tagInfo.Name
+		if val, ok := lookupParam(tagInfo, func(k string) (string, bool) { return adapter.Cookie(r, k) }); ok {
 			if err := p.setFieldValue(ctx, fieldValue, field, val); err != nil {
-				return fmt.Errorf("failed to set cookie %s: %w", cookieName, err)
+				return fmt.Errorf("failed to set cookie %s: %w", cookieName, redactError(tagInfo, err))
 			}
+		} else if err := p.setDefault(ctx, fieldValue, field, tagInfo); err != nil {
+			return fmt.Errorf("failed to set cookie %s: %w", cookieName, err)
 		}
 	}
 
@@ -339,22 +448,44 @@ func (p *ConventionParser) setFieldValue(ctx context.Context, fieldValue reflect
 		return nil
 	}
 
+	// Text unmarshalers (uuid.UUID, netip.Addr, custom IDs) parse themselves
+	if field.Type != timeType && fieldValue.CanAddr() {
+		if u, ok := fieldValue.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			return u.UnmarshalText([]byte(value))
+		}
+	}
+
+	// Pointers stay nil when the parameter is absent, so they are only
+	// allocated once there is a value to parse
+	if field.Type.Kind() == reflect.Ptr {
+		elemValue := reflect.New(field.Type.Elem())
+		elemField := reflect.StructField{Name: field.Name, Type: field.Type.Elem()}
+		if err := p.setFieldValue(ctx, elemValue.Elem(), elemField, value); err != nil {
+			return err
+		}
+		fieldValue.Set(elemValue)
+		return nil
+	}
+
+	// Handle slices element by element
+	if field.Type.Kind() == reflect.Slice {
+		return p.setSliceFieldValue(ctx, fieldValue, field, value)
+	}
+
 	// Fall back to basic type conversion
 	return p.setBasicFieldValue(fieldValue, field, value)
 }
 
 // setBasicFieldValue handles basic type conversions.
 func (p *ConventionParser) setBasicFieldValue(fieldValue reflect.Value, field reflect.StructField, value string) error {
+	if field.Type == durationType {
+		return p.setDurationFieldValue(fieldValue, value)
+	}
 	kind := field.Type.Kind()
 	if p.isBasicKind(kind) {
 		return p.setBasicFieldValueForKind(fieldValue, kind, value)
 	}
 
-	// Handle special cases
-	if kind == reflect.Slice {
-		return p.setSliceFieldValue(fieldValue, field, value)
-	}
-
 	return p.setSpecialFieldValue(fieldValue, field, value)
 }
 
@@ -427,6 +558,16 @@ func (p *ConventionParser) setFloatFieldValue(fieldValue reflect.Value, value st
 	return nil
 }
 
+// setDurationFieldValue handles time.Duration fields given as "30s" or "1h30m".
+func (p *ConventionParser) setDurationFieldValue(fieldValue reflect.Value, value string) error {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("invalid duration value: %s", value)
+	}
+	fieldValue.SetInt(int64(d))
+	return nil
+}
+
 // setSpecialFieldValue handles special types like time.Time.
 func (p *ConventionParser) setSpecialFieldValue(fieldValue reflect.Value, field reflect.StructField, value string) error {
 	// Try to handle time.Time specially
@@ -441,30 +582,112 @@ func (p *ConventionParser) setSpecialFieldValue(fieldValue reflect.Value, field
 	return fmt.Errorf("unsupported field type: %s", field.Type.Kind())
 }
 
-// setSliceFieldValue handles slice field conversions - simplified for string slices only.
-func (p *ConventionParser) setSliceFieldValue(fieldValue reflect.Value, field reflect.StructField, value string) error {
-	if field.Type.Elem().Kind() != reflect.String {
-		return fmt.Errorf("only string slices are supported in query/path/header parameters")
-	}
-
+// setSliceFieldValue handles slice field conversions of comma-separated values.
+func (p *ConventionParser) setSliceFieldValue(ctx context.Context, fieldValue reflect.Value, field reflect.StructField, value string) error {
 	if value == "" {
 		return nil // Empty value, leave slice as zero value
 	}
 
 	// Simple comma-separated parsing
 	parts := strings.Split(value, ",")
-	sliceVal := reflect.MakeSlice(field.Type, len(parts), len(parts))
 	for idx, part := range parts {
-		sliceVal.Index(idx).SetString(strings.TrimSpace(part))
+		parts[idx] = strings.TrimSpace(part)
+	}
+	return p.setSliceValues(ctx, fieldValue, field, parts)
+}
+
+// setSliceValues sets a slice field to values, one element each. Elements
+// are parsed like single fields of the element type: registered type
+// parsers, text unmarshalers (uuid.UUID, netip.Addr), time.Time and basic
+// kinds.
+func (p *ConventionParser) setSliceValues(ctx context.Context, fieldValue reflect.Value, field reflect.StructField, values []string) error {
+	elemField := reflect.StructField{Name: field.Name, Type: field.Type.Elem()}
+	if elemField.Type.Kind() == reflect.Slice {
+		return fmt.Errorf("nested slices are not supported in query/path/header parameters")
+	}
+	sliceVal := reflect.MakeSlice(field.Type, len(values), len(values))
+	for idx, value := range values {
+		if err := p.setFieldValue(ctx, sliceVal.Index(idx), elemField, value); err != nil {
+			return fmt.Errorf("element %d: %w", idx, err)
+		}
 	}
 	fieldValue.Set(sliceVal)
 	return nil
 }
 
+// lookupParam returns the parameter value stored under the field's name,
+// falling back to its aliases so clients still sending an old name keep
+// working during a rename.
+func lookupParam(tagInfo GorkTagInfo, get func(key string) (string, bool)) (string, bool) {
+	if val, ok := get(tagInfo.Name); ok {
+		return val, true
+	}
+	for _, alias := range tagInfo.Aliases {
+		if val, ok := get(alias); ok {
+			return val, true
+		}
+	}
+	return "", false
+}
+
 // GorkTagInfo represents parsed gork tag information.
 type GorkTagInfo struct {
 	Name          string
 	Discriminator string
+	// Aliases lists previous names accepted while a field rename is being
+	// rolled out (`gork:"new_name,alias=old_name"`, or several as
+	// `alias=oldName|old_name`).
+	Aliases []string
+	// Example is the documented example value (`gork:"email,example=a@b.c"`).
+	// Values containing commas are single-quoted (`example='a,b'`).
+	Example string
+	// Audience restricts a field to one audience (`gork:"cost,audience=internal"`).
+	Audience string
+	// Default is the value used when the field is absent (`gork:"limit,default=20"`).
+	// Values containing commas are single-quoted (`default='a,b'`).
+	Default string
+	// Sensitive masks the value in logs, error details and panic reports
+	// (`gork:"password,sensitive"`).
+	Sensitive bool
+	// ReadOnly marks fields set by the server only, such as IDs and
+	// timestamps, which request bodies may not contain (`gork:"id,readonly"`).
+	ReadOnly bool
+	// WriteOnly marks fields sent by clients only, such as passwords
+	// (`gork:"password,writeonly"`).
+	WriteOnly bool
+	// OmitEmpty and OmitZero drop empty or zero values from responses
+	// (`gork:"tags,omitempty"`, `gork:"deleted_at,omitzero"`).
+	OmitEmpty bool
+	OmitZero  bool
+	// Extensions holds the vendor extensions of the field's parameter or
+	// property (`gork:"id,x-internal"`, `gork:"id,x-order=1"`), by name.
+	Extensions map[string]string
+}
+
+// omittable reports whether the field may be left out of encoded bodies, so
+// that the spec never lists it as required.
+func (t GorkTagInfo) omittable() bool {
+	return t.OmitEmpty || t.OmitZero
+}
+
+// addExtension records a vendor extension option; other unknown options
+// are ignored.
+func (t *GorkTagInfo) addExtension(name, value string) {
+	if !strings.HasPrefix(name, "x-") {
+		return
+	}
+	if t.Extensions == nil {
+		t.Extensions = map[string]string{}
+	}
+	t.Extensions[name] = value
+}
+
+// unquoteTagValue removes the single quotes around an option value.
+func unquoteTagValue(val string) string {
+	if len(val) >= 2 && val[0] == '\'' && val[len(val)-1] == '\'' {
+		return val[1 : len(val)-1]
+	}
+	return val
 }
 
 // parseGorkTag parses a gork tag: "field_name[,discriminator=value,...]".
@@ -474,7 +697,7 @@ func parseGorkTag(tag string) GorkTagInfo {
 		return info
 	}
 
-	parts := strings.Split(tag, ",")
+	parts := gorkson.SplitTag(tag)
 	if len(parts) > 0 {
 		info.Name = strings.TrimSpace(parts[0])
 	}
@@ -483,9 +706,39 @@ func parseGorkTag(tag string) GorkTagInfo {
 		part := strings.TrimSpace(parts[i])
 		if kv := strings.SplitN(part, "=", 2); len(kv) == 2 {
 			key := strings.TrimSpace(kv[0])
-			val := strings.TrimSpace(kv[1])
-			if key == "discriminator" {
+			val := unquoteTagValue(strings.TrimSpace(kv[1]))
+			switch key {
+			case "discriminator":
 				info.Discriminator = val
+			case "alias":
+				for _, alias := range strings.Split(val, "|") {
+					if alias = strings.TrimSpace(alias); alias != "" {
+						info.Aliases = append(info.Aliases, alias)
+					}
+				}
+			case "example":
+				info.Example = val
+			case "audience":
+				info.Audience = val
+			case "default":
+				info.Default = val
+			default:
+				info.addExtension(key, val)
+			}
+		} else {
+			switch part {
+			case "sensitive":
+				info.Sensitive = true
+			case "omitempty":
+				info.OmitEmpty = true
+			case "omitzero":
+				info.OmitZero = true
+			case "readonly":
+				info.ReadOnly = true
+			case "writeonly":
+				info.WriteOnly = true
+			default:
+				info.addExtension(part, "true")
 			}
 		}
 	}
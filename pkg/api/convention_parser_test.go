@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -10,6 +11,9 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/gork-labs/gork/pkg/gorkson"
+	"github.com/gork-labs/gork/pkg/unions"
 )
 
 // Mock adapter for testing convention parser
@@ -296,6 +300,15 @@ func TestParseGorkTag(t *testing.T) {
 				Discriminator: "oauth",
 			},
 		},
+		{
+			tag: "tags,example='a,b',default='c, d',omitempty",
+			expected: GorkTagInfo{
+				Name:      "tags",
+				Example:   "a,b",
+				Default:   "c, d",
+				OmitEmpty: true,
+			},
+		},
 		{
 			tag:      "",
 			expected: GorkTagInfo{},
@@ -311,6 +324,9 @@ func TestParseGorkTag(t *testing.T) {
 			if result.Discriminator != tt.expected.Discriminator {
 				t.Errorf("Discriminator = %v, want %v", result.Discriminator, tt.expected.Discriminator)
 			}
+			if result.Example != tt.expected.Example || result.Default != tt.expected.Default || result.OmitEmpty != tt.expected.OmitEmpty {
+				t.Errorf("got %+v, want %+v", result, tt.expected)
+			}
 		})
 	}
 }
@@ -419,12 +435,12 @@ func TestConventionParser_SetSliceFieldValue_ErrorPaths(t *testing.T) {
 	field := reflect.StructField{Type: sliceType}
 	fieldValue := reflect.New(sliceType).Elem()
 
-	err := parser.setSliceFieldValue(fieldValue, field, "value1,value2")
+	err := parser.setSliceFieldValue(context.Background(), fieldValue, field, "value1,value2")
 	if err == nil {
 		t.Error("setSliceFieldValue() expected error for unsupported slice element type")
 	}
-	if !strings.Contains(err.Error(), "only string slices are supported") {
-		t.Errorf("setSliceFieldValue() error = %v, want to contain 'only string slices are supported'", err.Error())
+	if !strings.Contains(err.Error(), "unsupported field type") {
+		t.Errorf("setSliceFieldValue() error = %v, want to contain 'unsupported field type'", err.Error())
 	}
 }
 
@@ -437,7 +453,7 @@ func TestConventionParser_SetSliceFieldValue_EmptyString(t *testing.T) {
 	field := reflect.StructField{Type: sliceType}
 	fieldValue := reflect.New(sliceType).Elem()
 
-	err := parser.setSliceFieldValue(fieldValue, field, "")
+	err := parser.setSliceFieldValue(context.Background(), fieldValue, field, "")
 	if err != nil {
 		t.Errorf("setSliceFieldValue() with empty string returned error: %v", err)
 	}
@@ -1224,3 +1240,73 @@ func TestConventionParser_SetBasicFieldValueForKind(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRequestUnionBodyListsMismatches(t *testing.T) {
+	type cardBody struct {
+		Number string `gork:"number" validate:"required"`
+	}
+	type bankBody struct {
+		IBAN string `gork:"iban" validate:"required"`
+	}
+	router, _, handlers := newLoadShedRouter()
+	router.Post("/payments", func(_ context.Context, _ struct {
+		Body unions.Union2[cardBody, bankBody]
+	}) (*struct{}, error) {
+		return nil, nil
+	})
+
+	rec := httptest.NewRecorder()
+	handlers["POST /payments"](rec, httptest.NewRequest(http.MethodPost, "/api/payments", strings.NewReader(`{"number":"4242","iban":"DE00"}`)))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+	for _, want := range []string{`A (api.cardBody): unknown field \"iban\"`, `B (api.bankBody): unknown field \"number\"`} {
+		if !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("expected %s in %s", want, rec.Body.String())
+		}
+	}
+}
+
+func TestStrictBody(t *testing.T) {
+	type signupRequest struct {
+		Body struct {
+			Email   string `gork:"email"`
+			Address struct {
+				Street string `gork:"street"`
+			} `gork:"address"`
+		}
+	}
+	router, _, handlers := newLoadShedRouter()
+	handler := func(_ context.Context, _ signupRequest) (*struct{}, error) {
+		return nil, nil
+	}
+	router.Post("/strict", handler, WithStrictBody())
+	router.Post("/lenient", handler)
+
+	body := `{"emial":"a@example.com","address":{"stret":"Main St"}}`
+	rec := httptest.NewRecorder()
+	handlers["POST /strict"](rec, httptest.NewRequest(http.MethodPost, "/api/strict", strings.NewReader(body)))
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "unknown fields: address.stret, emial") {
+		t.Errorf("expected 400 listing the unknown fields, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handlers["POST /strict"](rec, httptest.NewRequest(http.MethodPost, "/api/strict", strings.NewReader(`{"email":"a@example.com","address":{"street":"Main St"}}`)))
+	if rec.Code >= 300 {
+		t.Errorf("expected known fields to be accepted, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handlers["POST /lenient"](rec, httptest.NewRequest(http.MethodPost, "/api/lenient", strings.NewReader(body)))
+	if rec.Code >= 300 {
+		t.Errorf("expected unknown fields to be ignored without WithStrictBody, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	var req signupRequest
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	err := NewConventionParser(DisallowUnknownFields()).ParseRequest(r.Context(), r, reflect.ValueOf(&req), &DefaultParameterAdapter{})
+	var unknown *gorkson.UnknownFieldsError
+	if !errors.As(err, &unknown) || len(unknown.Fields) != 2 {
+		t.Errorf("expected the parser option to reject unknown fields, got %v", err)
+	}
+}
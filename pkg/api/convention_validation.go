@@ -153,6 +153,12 @@ func NewConventionValidator() *ConventionValidator {
 
 // ValidateRequest validates a request using the Convention Over Configuration approach.
 func (v *ConventionValidator) ValidateRequest(ctx context.Context, reqPtr interface{}) error {
+	return v.validateRequest(ctx, reqPtr, false)
+}
+
+// validateRequest is ValidateRequest, checking the sections covered by the
+// request's generated RequestValidator with it when generated is set.
+func (v *ConventionValidator) validateRequest(ctx context.Context, reqPtr interface{}, generated bool) error {
 	reqValue := reflect.ValueOf(reqPtr)
 	if reqValue.Kind() != reflect.Ptr || reqValue.Elem().Kind() != reflect.Struct {
 		return fmt.Errorf("request must be a pointer to struct")
@@ -163,7 +169,11 @@ func (v *ConventionValidator) ValidateRequest(ctx context.Context, reqPtr interf
 	validationErrors := make(map[string][]string)
 
 	// Step 1: Validate sections
-	if err := v.validateSections(ctx, reqStruct, reqType, validationErrors); err != nil {
+	var sectionValidator RequestValidator
+	if generated {
+		sectionValidator, _ = reqPtr.(RequestValidator)
+	}
+	if err := v.validateSections(ctx, reqStruct, reqType, sectionValidator, validationErrors); err != nil {
 		return err // Server error
 	}
 
@@ -207,8 +217,9 @@ func (v *ConventionValidator) ValidateRequest(ctx context.Context, reqPtr interf
 	return nil
 }
 
-// validateSections validates all sections in the request.
-func (v *ConventionValidator) validateSections(ctx context.Context, reqStruct reflect.Value, reqType reflect.Type, validationErrors map[string][]string) error {
+// validateSections validates all sections in the request, leaving the field
+// tags of those generated covers (when not nil) to it.
+func (v *ConventionValidator) validateSections(ctx context.Context, reqStruct reflect.Value, reqType reflect.Type, generated RequestValidator, validationErrors map[string][]string) error {
 	for i := 0; i < reqType.NumField(); i++ {
 		field := reqType.Field(i)
 		fieldValue := reqStruct.Field(i)
@@ -217,6 +228,13 @@ func (v *ConventionValidator) validateSections(ctx context.Context, reqStruct re
 			continue // Skip non-standard sections
 		}
 
+		if generated != nil && generated.ValidateSection(field.Name, validationErrors) {
+			if err := v.validateCustomLevel(ctx, fieldValue, strings.ToLower(field.Name), validationErrors); err != nil {
+				return err // Server error
+			}
+			continue
+		}
+
 		if err := v.validateSection(ctx, field, fieldValue, validationErrors); err != nil {
 			return err // Server error
 		}
@@ -228,10 +246,19 @@ func (v *ConventionValidator) validateSections(ctx context.Context, reqStruct re
 func (v *ConventionValidator) validateSection(ctx context.Context, field reflect.StructField, fieldValue reflect.Value, validationErrors map[string][]string) (err error) {
 	sectionName := strings.ToLower(field.Name)
 
+	// NDJSON streams are validated line by line while the handler reads them
+	if stream, ok := asStreamSection(fieldValue); ok && field.Name == "Body" {
+		stream.bindValidator(func(item interface{}) (map[string][]string, error) {
+			return v.validateStreamItem(ctx, item)
+		})
+		return nil
+	}
+
 	// Field-level validation for the section using go-playground/validator
 	if err := v.validateFieldLevel(field, fieldValue, sectionName, validationErrors); err != nil {
 		return err
 	}
+	enumViolations(fieldValue, sectionName, validationErrors)
 
 	// Custom section-level validation (context-aware or regular)
 	return v.validateCustomLevel(ctx, fieldValue, sectionName, validationErrors)
@@ -239,10 +266,26 @@ func (v *ConventionValidator) validateSection(ctx context.Context, field reflect
 
 // validateFieldLevel handles field-level validation using go-playground/validator.
 func (v *ConventionValidator) validateFieldLevel(field reflect.StructField, fieldValue reflect.Value, sectionName string, validationErrors map[string][]string) error {
+	// Streamed bodies are not read before the handler runs
+	if field.Name == "Body" && isStreamBodyType(field.Type) {
+		return nil
+	}
+
 	if v.isByteSliceBodyField(field, fieldValue) {
 		return v.validateByteSliceField(field, fieldValue, sectionName, validationErrors)
 	}
 
+	// Free-form map bodies have no fields; like []byte bodies only the
+	// section's own validate tag applies
+	if field.Name == "Body" && fieldValue.Kind() == reflect.Map {
+		return v.validateByteSliceField(field, fieldValue, sectionName, validationErrors)
+	}
+
+	// Slice bodies (JSON arrays, CSV rows) are validated element by element
+	if field.Name == "Body" && fieldValue.Kind() == reflect.Slice {
+		return v.validateSliceBody(fieldValue, sectionName, validationErrors)
+	}
+
 	return v.validateStructField(fieldValue, sectionName, validationErrors)
 }
 
@@ -277,6 +320,27 @@ func (v *ConventionValidator) validateByteSliceField(field reflect.StructField,
 	return validationErr
 }
 
+// validateSliceBody validates each struct element of a slice body, reporting
+// errors as section[index].field.
+func (v *ConventionValidator) validateSliceBody(fieldValue reflect.Value, sectionName string, validationErrors map[string][]string) error {
+	for i := 0; i < fieldValue.Len(); i++ {
+		elem := fieldValue.Index(i)
+		if elem.Kind() == reflect.Ptr {
+			if elem.IsNil() {
+				continue
+			}
+			elem = elem.Elem()
+		}
+		if elem.Kind() != reflect.Struct {
+			continue
+		}
+		if err := v.validateStructField(elem, fmt.Sprintf("%s[%d]", sectionName, i), validationErrors); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // validateStructField validates regular struct fields.
 func (v *ConventionValidator) validateStructField(fieldValue reflect.Value, sectionName string, validationErrors map[string][]string) (err error) {
 	defer func() {
@@ -293,8 +357,8 @@ func (v *ConventionValidator) validateStructField(fieldValue reflect.Value, sect
 	var verrs validator.ValidationErrors
 	if errors.As(validationErr, &verrs) {
 		for _, ve := range verrs {
-			// Simple field path: section.field (as per spec)
-			fieldPath := fmt.Sprintf("%s.%s", sectionName, ve.Field())
+			// Nested fields keep their path: section.address.zip
+			fieldPath := sectionName + "." + validationErrorPath(fieldValue.Type(), ve)
 			validationErrors[fieldPath] = append(validationErrors[fieldPath], ve.Tag())
 		}
 		return nil
@@ -304,56 +368,73 @@ func (v *ConventionValidator) validateStructField(fieldValue reflect.Value, sect
 	return validationErr
 }
 
-// validateCustomLevel handles custom section-level validation.
+// validateCustomLevel handles custom section-level validation. Field errors
+// are reported relative to the section, e.g. "end_date" as "body.end_date".
 func (v *ConventionValidator) validateCustomLevel(ctx context.Context, fieldValue reflect.Value, sectionName string, validationErrors map[string][]string) error {
-	verrs, serverErr := invokeCustomValidation(ctx, fieldValue.Interface())
-	if serverErr != nil {
-		return serverErr
-	}
-
-	if len(verrs) > 0 {
-		validationErrors[sectionName] = append(validationErrors[sectionName], verrs...)
+	section := fieldValue.Interface()
+	if fieldValue.CanAddr() {
+		// Let pointer receivers validate too
+		section = fieldValue.Addr().Interface()
 	}
-
-	return nil
+	return mergeCustomValidation(ctx, section, sectionName, validationErrors)
 }
 
 // validateRequestLevel validates at the request level.
 func (v *ConventionValidator) validateRequestLevel(ctx context.Context, reqPtr interface{}, validationErrors map[string][]string) error {
-	if verrs, serverErr := invokeCustomValidation(ctx, reqPtr); serverErr != nil {
-		return serverErr
-	} else if len(verrs) > 0 {
-		validationErrors["request"] = append(validationErrors["request"], verrs...)
+	return mergeCustomValidation(ctx, reqPtr, "", validationErrors)
+}
+
+// mergeCustomValidation runs the Validate method of v and records its client
+// errors: FieldErrors field by field below section, other validation errors
+// under section (or "request"). Any other error is a server error.
+func mergeCustomValidation(ctx context.Context, v interface{}, section string, validationErrors map[string][]string) error {
+	err := callCustomValidation(ctx, v)
+	if err == nil {
+		return nil
+	}
+	var fieldErrs FieldErrors
+	if errors.As(err, &fieldErrs) {
+		for field, msgs := range fieldErrs {
+			if section != "" {
+				field = section + "." + field
+			}
+			validationErrors[field] = append(validationErrors[field], msgs...)
+		}
+		return nil
+	}
+	var valErr ValidationError
+	if !errors.As(err, &valErr) {
+		return err
 	}
+	if section == "" {
+		section = "request"
+	}
+	validationErrors[section] = append(validationErrors[section], valErr.GetErrors()...)
 	return nil
 }
 
 // invokeCustomValidation runs either context-aware or regular validation, normalizing outputs.
 // Returns a slice of field-agnostic validation error strings (client errors) or a server error.
 func invokeCustomValidation(ctx context.Context, v interface{}) ([]string, error) {
-	if v == nil {
-		return nil, nil
+	if err := callCustomValidation(ctx, v); err != nil {
+		var valErr ValidationError
+		if errors.As(err, &valErr) {
+			return valErr.GetErrors(), nil
+		}
+		return nil, err
 	}
+	return nil, nil
+}
+
+// callCustomValidation calls the ContextValidator or Validator method of v.
+func callCustomValidation(ctx context.Context, v interface{}) error {
 	if cv, ok := v.(ContextValidator); ok {
-		if err := cv.Validate(ctx); err != nil {
-			var valErr ValidationError
-			if errors.As(err, &valErr) {
-				return valErr.GetErrors(), nil
-			}
-			return nil, err
-		}
-		return nil, nil
+		return cv.Validate(ctx)
 	}
 	if rv, ok := v.(Validator); ok {
-		if err := rv.Validate(); err != nil {
-			var valErr ValidationError
-			if errors.As(err, &valErr) {
-				return valErr.GetErrors(), nil
-			}
-			return nil, err
-		}
+		return rv.Validate()
 	}
-	return nil, nil
+	return nil
 }
 
 // IsValidationError checks if an error is a client validation error (HTTP 400).
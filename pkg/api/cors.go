@@ -0,0 +1,142 @@
+package api
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSConfig configures cross-origin requests for WithCORS.
+type CORSConfig struct {
+	// AllowedOrigins lists the origins allowed to call the API; "*" allows
+	// any origin.
+	AllowedOrigins []string
+	// AllowOriginFunc, when set, decides for origins not listed in
+	// AllowedOrigins.
+	AllowOriginFunc func(origin string) bool
+	// AllowedHeaders lists the request headers preflight requests may ask
+	// for. When empty the requested headers are allowed.
+	AllowedHeaders []string
+	// ExposedHeaders lists the response headers browsers expose to scripts.
+	ExposedHeaders []string
+	// AllowCredentials lets browsers send cookies and credentials. The
+	// origin is then echoed instead of answering "*".
+	AllowCredentials bool
+	// MaxAge is how long browsers may cache a preflight response.
+	MaxAge time.Duration
+	// DocumentHeaders adds the CORS response headers to the documented
+	// success responses.
+	DocumentHeaders bool
+}
+
+// WithCORS answers cross-origin requests allowed by cfg. Preflight OPTIONS
+// requests are answered with the methods actually registered for the path
+// rather than a wildcard, and disallowed origins get no CORS headers. Pass it
+// as a router option so every path gets its preflight handler.
+func WithCORS(cfg CORSConfig) Option {
+	return func(h *HandlerOption) {
+		h.CORS = &cfg
+	}
+}
+
+// allowOrigin returns the Access-Control-Allow-Origin value for origin, or
+// "" when it is not allowed.
+func (c *CORSConfig) allowOrigin(origin string) string {
+	if origin == "" {
+		return ""
+	}
+	if slices.Contains(c.AllowedOrigins, "*") {
+		if c.AllowCredentials {
+			return origin
+		}
+		return "*"
+	}
+	if slices.Contains(c.AllowedOrigins, origin) || (c.AllowOriginFunc != nil && c.AllowOriginFunc(origin)) {
+		return origin
+	}
+	return ""
+}
+
+// setOrigin sets the headers shared by preflight and actual responses,
+// reporting whether the request's origin is allowed.
+func (c *CORSConfig) setOrigin(w http.ResponseWriter, r *http.Request) bool {
+	header := w.Header()
+	header.Add("Vary", "Origin")
+	allowed := c.allowOrigin(r.Header.Get("Origin"))
+	if allowed == "" {
+		return false
+	}
+	header.Set("Access-Control-Allow-Origin", allowed)
+	if c.AllowCredentials {
+		header.Set("Access-Control-Allow-Credentials", "true")
+	}
+	return true
+}
+
+// wrap adds the CORS headers to responses for allowed origins.
+func (c *CORSConfig) wrap(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if c.setOrigin(w, r) && len(c.ExposedHeaders) > 0 {
+			w.Header().Set("Access-Control-Expose-Headers", strings.Join(c.ExposedHeaders, ", "))
+		}
+		next(w, r)
+	}
+}
+
+// preflight answers a CORS preflight request for a path answering methods.
+func (c *CORSConfig) preflight(w http.ResponseWriter, r *http.Request, methods []string) {
+	header := w.Header()
+	header.Add("Vary", "Access-Control-Request-Method")
+	header.Add("Vary", "Access-Control-Request-Headers")
+	requested := r.Header.Get("Access-Control-Request-Method")
+	if !c.setOrigin(w, r) || !slices.Contains(methods, requested) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	header.Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+	if len(c.AllowedHeaders) > 0 {
+		header.Set("Access-Control-Allow-Headers", strings.Join(c.AllowedHeaders, ", "))
+	} else if headers := r.Header.Get("Access-Control-Request-Headers"); headers != "" {
+		header.Set("Access-Control-Allow-Headers", headers)
+	}
+	if c.MaxAge > 0 {
+		header.Set("Access-Control-Max-Age", strconv.Itoa(int(c.MaxAge.Seconds())))
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// isPreflight reports whether r is a CORS preflight request.
+func isPreflight(r *http.Request) bool {
+	return r.Method == http.MethodOptions && r.Header.Get("Origin") != "" && r.Header.Get("Access-Control-Request-Method") != ""
+}
+
+// applyCORS documents the CORS response headers of routes registered with
+// WithCORS and DocumentHeaders.
+func applyCORS(route *RouteInfo, operation *Operation) {
+	if route.Options == nil || route.Options.CORS == nil || !route.Options.CORS.DocumentHeaders {
+		return
+	}
+	cfg := route.Options.CORS
+	headers := map[string]*Header{
+		"Access-Control-Allow-Origin": {Description: "Origin allowed to read the response", Schema: &Schema{Type: "string"}},
+	}
+	if cfg.AllowCredentials {
+		headers["Access-Control-Allow-Credentials"] = &Header{Description: "Whether credentials may be sent", Schema: &Schema{Type: "string", Enum: []string{"true"}}}
+	}
+	if len(cfg.ExposedHeaders) > 0 {
+		headers["Access-Control-Expose-Headers"] = &Header{Description: "Response headers exposed to scripts", Schema: &Schema{Type: "string"}}
+	}
+	for code, resp := range operation.Responses {
+		if resp.Ref != "" || !strings.HasPrefix(code, "2") {
+			continue
+		}
+		if resp.Headers == nil {
+			resp.Headers = map[string]*Header{}
+		}
+		for name, header := range headers {
+			resp.Headers[name] = header
+		}
+	}
+}
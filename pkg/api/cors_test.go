@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCORSPreflight(t *testing.T) {
+	router, _, handlers := newLoadShedRouter(WithCORS(CORSConfig{
+		AllowedOrigins: []string{"https://app.example.com"},
+		MaxAge:         10 * time.Minute,
+	}))
+	router.Get("/items/{id}", func(context.Context, struct{}) (*loadShedResponse, error) { return nil, nil })
+	router.Delete("/items/{id}", func(context.Context, struct{}) (*struct{}, error) { return nil, nil })
+
+	preflight := func(origin, method string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodOptions, "/api/items/1", nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", method)
+		req.Header.Set("Access-Control-Request-Headers", "X-Trace")
+		w := httptest.NewRecorder()
+		handlers["OPTIONS /items/{id}"](w, req)
+		return w
+	}
+
+	w := preflight("https://app.example.com", http.MethodDelete)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d", w.Code)
+	}
+	want := map[string]string{
+		"Access-Control-Allow-Origin":  "https://app.example.com",
+		"Access-Control-Allow-Methods": "DELETE, GET, OPTIONS",
+		"Access-Control-Allow-Headers": "X-Trace",
+		"Access-Control-Max-Age":       "600",
+	}
+	for name, value := range want {
+		if got := w.Header().Get(name); got != value {
+			t.Errorf("%s = %q, want %q", name, got, value)
+		}
+	}
+
+	if w := preflight("https://app.example.com", http.MethodPut); w.Header().Get("Access-Control-Allow-Methods") != "" {
+		t.Error("unregistered methods must not be allowed")
+	}
+	if w := preflight("https://evil.example.com", http.MethodGet); w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Error("unknown origins must not be allowed")
+	}
+}
+
+func TestCORSActualRequest(t *testing.T) {
+	router, _, handlers := newLoadShedRouter(WithCORS(CORSConfig{
+		AllowedOrigins:   []string{"*"},
+		ExposedHeaders:   []string{"ETag"},
+		AllowCredentials: true,
+	}))
+	router.Get("/items", func(context.Context, struct{}) (*loadShedResponse, error) {
+		return &loadShedResponse{}, nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/items", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	w := httptest.NewRecorder()
+	handlers["GET /items"](w, req)
+
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://app.example.com" {
+		t.Errorf("credentialed requests must echo the origin, got %q", got)
+	}
+	if w.Header().Get("Access-Control-Allow-Credentials") != "true" || w.Header().Get("Access-Control-Expose-Headers") != "ETag" {
+		t.Errorf("unexpected headers %v", w.Header())
+	}
+	if w.Header().Get("Vary") != "Origin" {
+		t.Errorf("Vary = %q", w.Header().Get("Vary"))
+	}
+}
+
+func TestCORSOpenAPI(t *testing.T) {
+	router, registry, _ := newLoadShedRouter(WithCORS(CORSConfig{AllowedOrigins: []string{"*"}, DocumentHeaders: true}))
+	router.Get("/items", func(context.Context, struct{}) (*loadShedResponse, error) { return nil, nil })
+
+	spec := GenerateOpenAPI(registry)
+	item := spec.Paths["/api/items"]
+	if item.Get.Responses["200"].Headers["Access-Control-Allow-Origin"] == nil {
+		t.Errorf("expected documented CORS header, got %v", item.Get.Responses["200"].Headers)
+	}
+}
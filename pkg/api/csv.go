@@ -0,0 +1,275 @@
+package api
+
+import (
+	"context"
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gork-labs/gork/pkg/gorkson"
+)
+
+// ContentTypeCSV is the media type of comma-separated values.
+const ContentTypeCSV = "text/csv"
+
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// csvColumn maps a CSV column onto a field of a row struct.
+type csvColumn struct {
+	index    int
+	name     string
+	aliases  []string
+	audience string
+	readOnly bool
+}
+
+// csvColumns returns the columns of a row struct in field order, named by
+// their gork tags. Fields without a gork tag are not part of the CSV.
+func csvColumns(rowType reflect.Type) []csvColumn {
+	var columns []csvColumn
+	for i := 0; i < rowType.NumField(); i++ {
+		field := rowType.Field(i)
+		gorkTag := field.Tag.Get("gork")
+		if gorkTag == "" || !field.IsExported() {
+			continue
+		}
+		tagInfo := parseGorkTag(gorkTag)
+		columns = append(columns, csvColumn{index: i, name: tagInfo.Name, aliases: tagInfo.Aliases, audience: tagInfo.Audience, readOnly: tagInfo.ReadOnly})
+	}
+	return columns
+}
+
+// isCSVCellType reports whether values of t fit in a single CSV cell.
+func isCSVCellType(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return t == reflect.TypeOf(time.Time{})
+}
+
+// isCSVBodyType reports whether a Body section of type t is a slice of flat
+// structs that can be encoded as CSV rows.
+func isCSVBodyType(t reflect.Type) bool {
+	if t.Kind() != reflect.Slice || t.Elem().Kind() != reflect.Struct || isUnionType(t.Elem()) {
+		return false
+	}
+	columns := csvColumns(t.Elem())
+	for _, c := range columns {
+		if !isCSVCellType(t.Elem().Field(c.index).Type) {
+			return false
+		}
+	}
+	return len(columns) > 0
+}
+
+// isCSVRequest reports whether the request body is CSV.
+func isCSVRequest(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == ContentTypeCSV
+}
+
+// wantsCSV reports whether the client asked for a CSV response, either with
+// the Accept header or a format=csv query parameter.
+func wantsCSV(r *http.Request) bool {
+	if r == nil {
+		return false
+	}
+	if r.URL != nil && strings.EqualFold(r.URL.Query().Get("format"), "csv") {
+		return true
+	}
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		if mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted)); err == nil && mediaType == ContentTypeCSV {
+			return true
+		}
+	}
+	return false
+}
+
+// writeCSVBody writes a slice of row structs as CSV with a header row,
+// leaving out the columns not visible to audience.
+func writeCSVBody(w http.ResponseWriter, bodyValue reflect.Value, status int, audience string) {
+	var columns []csvColumn
+	for _, c := range csvColumns(bodyValue.Type().Elem()) {
+		if visibleTo(c.audience, audience) {
+			columns = append(columns, c)
+		}
+	}
+	w.Header().Set("Content-Type", ContentTypeCSV+"; charset=utf-8")
+	if status != 0 {
+		w.WriteHeader(status)
+	}
+
+	writer := csv.NewWriter(w)
+	record := make([]string, len(columns))
+	for i, c := range columns {
+		record[i] = c.name
+	}
+	_ = writer.Write(record)
+	for row := 0; row < bodyValue.Len(); row++ {
+		rowValue := bodyValue.Index(row)
+		for i, c := range columns {
+			record[i] = formatCSVCell(rowValue.Field(c.index))
+		}
+		_ = writer.Write(record)
+	}
+	writer.Flush()
+}
+
+// formatCSVCell renders a single field value; nil pointers become empty cells.
+func formatCSVCell(v reflect.Value) string {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if v.Type().Implements(textMarshalerType) {
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return ""
+		}
+		return string(text)
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// decodeCSVBody binds CSV rows into a slice of row structs. The header row
+// selects the columns by gork name (or alias); unknown columns are ignored
+// and empty cells leave fields at their zero value.
+func (p *ConventionParser) decodeCSVBody(ctx context.Context, sectionValue reflect.Value, body io.Reader) error {
+	rowType := sectionValue.Type().Elem()
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to decode CSV body: %w", err)
+	}
+	positions := map[string]int{}
+	for i, name := range header {
+		positions[strings.TrimSpace(name)] = i
+	}
+	// cells pairs each bound field index with its position in the record
+	var cells [][2]int
+	var readOnly []string
+	for _, c := range csvColumns(rowType) {
+		for _, name := range append([]string{c.name}, c.aliases...) {
+			if pos, ok := positions[name]; ok {
+				if c.readOnly {
+					readOnly = append(readOnly, name)
+				} else {
+					cells = append(cells, [2]int{c.index, pos})
+				}
+				break
+			}
+		}
+	}
+	// Columns of readonly fields are rejected like the JSON keys setting them.
+	if len(readOnly) > 0 {
+		sort.Strings(readOnly)
+		return fmt.Errorf("failed to decode CSV body: %w", &gorkson.ReadOnlyFieldsError{Fields: readOnly})
+	}
+
+	rows := reflect.MakeSlice(sectionValue.Type(), 0, 0)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to decode CSV body: %w", err)
+		}
+		row := reflect.New(rowType).Elem()
+		for _, cell := range cells {
+			index, pos := cell[0], cell[1]
+			if pos >= len(record) || record[pos] == "" {
+				continue
+			}
+			if err := p.setCSVCell(ctx, row.Field(index), rowType.Field(index), record[pos]); err != nil {
+				return fmt.Errorf("CSV line %d, column %s: %w", line, header[pos], err)
+			}
+		}
+		rows = reflect.Append(rows, row)
+	}
+	sectionValue.Set(rows)
+	return nil
+}
+
+// setCSVCell parses a cell into a field, allocating pointer fields.
+func (p *ConventionParser) setCSVCell(ctx context.Context, fieldValue reflect.Value, field reflect.StructField, cell string) error {
+	if field.Type.Kind() != reflect.Ptr {
+		return p.setFieldValue(ctx, fieldValue, field, cell)
+	}
+	elem := reflect.New(field.Type.Elem())
+	field.Type = field.Type.Elem()
+	if err := p.setFieldValue(ctx, elem.Elem(), field, cell); err != nil {
+		return err
+	}
+	fieldValue.Set(elem)
+	return nil
+}
+
+// csvMediaType documents a CSV body.
+func csvMediaType() *MediaType {
+	return &MediaType{Schema: &Schema{Type: "string", Description: "CSV with a header row named after the gork tags"}}
+}
+
+// applyCSV documents the CSV representation of response bodies that are
+// slices of flat structs, and the format query parameter selecting it.
+func applyCSV(route *RouteInfo, operation *Operation) {
+	body, ok := bodySectionType(route.ResponseType)
+	if !ok || !isCSVBodyType(body) {
+		return
+	}
+	for code, response := range operation.Responses {
+		if !strings.HasPrefix(code, "2") || response.Content == nil {
+			continue
+		}
+		response.Content[ContentTypeCSV] = csvMediaType()
+	}
+	for _, p := range operation.Parameters {
+		if p.In == "query" && p.Name == "format" {
+			return
+		}
+	}
+	operation.Parameters = append(operation.Parameters, Parameter{
+		Name:        "format",
+		In:          "query",
+		Description: "Response format; csv is equivalent to Accept: text/csv",
+		Schema:      &Schema{Type: "string", Enum: []string{"json", "csv"}},
+	})
+}
+
+// bodySectionType returns the type of the Body section of a request or
+// response type.
+func bodySectionType(t reflect.Type) (reflect.Type, bool) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, false
+	}
+	field, ok := t.FieldByName(SectionBody)
+	if !ok {
+		return nil, false
+	}
+	return field.Type, true
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type csvContact struct {
+	Email  string    `gork:"email" validate:"required,email"`
+	Age    int       `gork:"age,alias=years"`
+	Joined time.Time `gork:"joined"`
+	Score  *float64  `gork:"score"`
+	Notes  string
+}
+
+type csvExportResponse struct {
+	Body []csvContact
+}
+
+type csvImportRequest struct {
+	Body []csvContact
+}
+
+func TestCSVResponse(t *testing.T) {
+	score := 9.5
+	joined := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	export := func(context.Context, loadShedRequest) (*csvExportResponse, error) {
+		return &csvExportResponse{Body: []csvContact{
+			{Email: "a@example.com", Age: 30, Joined: joined, Score: &score},
+			{Email: "b,c@example.com", Age: 41},
+		}}, nil
+	}
+	h, _ := NewConventionHandlerFactory().CreateHandler(&mockConventionParameterAdapter{}, export)
+
+	want := "email,age,joined,score\n" +
+		"a@example.com,30,2024-05-01T12:00:00Z,9.5\n" +
+		"\"b,c@example.com\",41,0001-01-01T00:00:00Z,\n"
+
+	r := httptest.NewRequest(http.MethodGet, "/contacts", nil)
+	r.Header.Set("Accept", "text/csv, application/json;q=0.5")
+	w := httptest.NewRecorder()
+	h(w, r)
+	if w.Body.String() != want || !strings.HasPrefix(w.Header().Get("Content-Type"), ContentTypeCSV) {
+		t.Errorf("unexpected CSV response %q (%s)", w.Body.String(), w.Header().Get("Content-Type"))
+	}
+
+	w = httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/contacts?format=csv", nil))
+	if w.Body.String() != want {
+		t.Errorf("format=csv: unexpected response %q", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/contacts", nil))
+	if w.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("expected JSON by default, got %s", w.Header().Get("Content-Type"))
+	}
+}
+
+func TestCSVRequest(t *testing.T) {
+	var got []csvContact
+	importContacts := func(_ context.Context, req csvImportRequest) error {
+		got = req.Body
+		return nil
+	}
+	h, _ := NewConventionHandlerFactory().CreateHandler(&mockConventionParameterAdapter{}, importContacts)
+	send := func(body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/contacts", strings.NewReader(body))
+		r.Header.Set("Content-Type", "text/csv; charset=utf-8")
+		w := httptest.NewRecorder()
+		h(w, r)
+		return w
+	}
+
+	w := send("email,years,score,ignored\na@example.com,30,1.5,x\nb@example.com,,,\n")
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("unexpected status %d: %s", w.Code, w.Body.String())
+	}
+	if len(got) != 2 || got[0].Age != 30 || got[0].Score == nil || *got[0].Score != 1.5 || got[1].Score != nil {
+		t.Errorf("unexpected rows %+v", got)
+	}
+
+	w = send("email,age\na@example.com,1\nnot-an-email,2\n,3\n")
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+	var resp ValidationErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Details["body[1].email"] == nil || resp.Details["body[2].email"] == nil || len(resp.Details) != 2 {
+		t.Errorf("expected per-row errors, got %v", resp.Details)
+	}
+
+	if w := send("email,age\na@example.com,old\n"); w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "line 2, column age") {
+		t.Errorf("expected cell error, got %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestCSVOpenAPI(t *testing.T) {
+	router, registry, _ := newLoadShedRouter()
+	router.Get("/contacts", func(context.Context, loadShedRequest) (*csvExportResponse, error) { return nil, nil })
+	router.Post("/contacts", func(context.Context, csvImportRequest) error { return nil })
+
+	spec := GenerateOpenAPI(registry)
+	get := spec.Paths["/api/contacts"].Get
+	if get.Responses["200"].Content[ContentTypeCSV] == nil || get.Responses["200"].Content["application/json"] == nil {
+		t.Errorf("expected JSON and CSV response content, got %v", get.Responses["200"].Content)
+	}
+	var format bool
+	for _, p := range get.Parameters {
+		format = format || (p.Name == "format" && p.In == "query")
+	}
+	if !format {
+		t.Errorf("expected format query parameter, got %+v", get.Parameters)
+	}
+
+	post := spec.Paths["/api/contacts"].Post
+	if post.RequestBody == nil || post.RequestBody.Content[ContentTypeCSV] == nil {
+		t.Fatalf("expected CSV request body, got %+v", post.RequestBody)
+	}
+	if schema := post.RequestBody.Content["application/json"].Schema; schema == nil || schema.Type != "array" {
+		t.Errorf("expected JSON array request body, got %+v", schema)
+	}
+}
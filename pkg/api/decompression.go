@@ -0,0 +1,137 @@
+package api
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+// DefaultMaxDecompressedBytes is the decompressed body limit used when
+// DecompressionConfig.MaxDecompressedBytes is not set.
+var DefaultMaxDecompressedBytes int64 = 10 << 20
+
+// DecompressionConfig configures decoding of compressed request bodies.
+type DecompressionConfig struct {
+	// MaxDecompressedBytes limits the size of the body after decompression,
+	// protecting handlers from compression bombs. Requests inflating beyond
+	// the limit are rejected with 413 Request Entity Too Large. Defaults to
+	// DefaultMaxDecompressedBytes.
+	MaxDecompressedBytes int64
+}
+
+// WithRequestDecompression accepts request bodies sent with a gzip, br
+// (Brotli) or deflate Content-Encoding. The body is decompressed before parsing, so
+// handlers (including webhook signature checks) see the original payload.
+// Unsupported encodings are rejected with 415 Unsupported Media Type.
+func WithRequestDecompression(cfg DecompressionConfig) Option {
+	return func(h *HandlerOption) {
+		if cfg.MaxDecompressedBytes <= 0 {
+			cfg.MaxDecompressedBytes = DefaultMaxDecompressedBytes
+		}
+		h.Decompression = &cfg
+	}
+}
+
+var errUnsupportedEncoding = errors.New("unsupported content encoding")
+
+// wrap returns a handler that replaces compressed request bodies with their
+// size-limited decompressed stream.
+func (c *DecompressionConfig) wrap(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		encoding := strings.ToLower(strings.TrimSpace(r.Header.Get("Content-Encoding")))
+		if encoding == "" || encoding == "identity" || r.Body == nil || r.Body == http.NoBody {
+			next(w, r)
+			return
+		}
+
+		body, err := newDecompressor(encoding, r.Body)
+		if errors.Is(err, errUnsupportedEncoding) {
+			writeError(w, http.StatusUnsupportedMediaType, err.Error())
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid %s request body: %v", encoding, err))
+			return
+		}
+
+		r = r.Clone(r.Context())
+		r.Header.Del("Content-Encoding")
+		r.Header.Del("Content-Length")
+		r.ContentLength = -1
+		r.Body = http.MaxBytesReader(w, body, c.MaxDecompressedBytes)
+		next(w, r)
+	}
+}
+
+// newDecompressor returns a reader decoding body with the given encoding.
+// Closing it also closes body.
+func newDecompressor(encoding string, body io.ReadCloser) (io.ReadCloser, error) {
+	var (
+		reader io.ReadCloser
+		err    error
+	)
+	switch encoding {
+	case "gzip", "x-gzip":
+		reader, err = gzip.NewReader(body)
+	case "br":
+		// Brotli has no header to check up front; corrupt streams fail
+		// while the body is parsed
+		reader = io.NopCloser(brotli.NewReader(body))
+	case "deflate":
+		// HTTP deflate is zlib-wrapped (RFC 9110, section 8.4.1.2)
+		reader, err = zlib.NewReader(body)
+	default:
+		return nil, fmt.Errorf("%w %q", errUnsupportedEncoding, encoding)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &decompressedBody{ReadCloser: reader, source: body}, nil
+}
+
+type decompressedBody struct {
+	io.ReadCloser
+	source io.Closer
+}
+
+func (b *decompressedBody) Close() error {
+	err := b.ReadCloser.Close()
+	if sourceErr := b.source.Close(); err == nil {
+		err = sourceErr
+	}
+	return err
+}
+
+// requestErrorStatus maps a request parsing error to its status code:
+// bodies exceeding a size limit get 413, bodies of a media type the route
+// does not accept 415, everything else 400.
+func requestErrorStatus(err error) int {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return http.StatusRequestEntityTooLarge
+	}
+	if errors.Is(err, errUnsupportedMediaType) {
+		return http.StatusUnsupportedMediaType
+	}
+	return http.StatusBadRequest
+}
+
+// applyDecompression documents the accepted Content-Encoding values for
+// routes registered with WithRequestDecompression.
+func applyDecompression(route *RouteInfo, operation *Operation) {
+	if route.Options == nil || route.Options.Decompression == nil || operation.RequestBody == nil {
+		return
+	}
+	operation.Parameters = append(operation.Parameters, Parameter{
+		Name:        "Content-Encoding",
+		In:          "header",
+		Description: "Compression applied to the request body",
+		Schema:      &Schema{Type: "string", Enum: []string{"gzip", "br", "deflate", "identity"}},
+	})
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+)
+
+type importRequest struct {
+	Body struct {
+		Name string `gork:"name"`
+	}
+}
+
+type importResponse struct {
+	Body struct {
+		Name string `gork:"name"`
+	}
+}
+
+func compressBody(t *testing.T, encoding, payload string) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch encoding {
+	case "deflate":
+		w = zlib.NewWriter(&buf)
+	case "br":
+		w = brotli.NewWriter(&buf)
+	default:
+		w = gzip.NewWriter(&buf)
+	}
+	if _, err := w.Write([]byte(payload)); err != nil {
+		t.Fatal(err)
+	}
+	_ = w.Close()
+	return &buf
+}
+
+func TestRequestDecompression(t *testing.T) {
+	router, _, handlers := newLoadShedRouter()
+	router.Post("/imports", func(_ context.Context, req importRequest) (*importResponse, error) {
+		resp := &importResponse{}
+		resp.Body.Name = req.Body.Name
+		return resp, nil
+	}, WithRequestDecompression(DecompressionConfig{MaxDecompressedBytes: 64}))
+	h := handlers["POST /imports"]
+
+	send := func(encoding string, body io.Reader) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/api/imports", body)
+		r.Header.Set("Content-Type", "application/json")
+		if encoding != "" {
+			r.Header.Set("Content-Encoding", encoding)
+		}
+		w := httptest.NewRecorder()
+		h(w, r)
+		return w
+	}
+
+	for _, encoding := range []string{"gzip", "br", "deflate"} {
+		w := send(encoding, compressBody(t, encoding, `{"name":"bulk"}`))
+		if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "bulk") {
+			t.Errorf("%s: unexpected response %d %s", encoding, w.Code, w.Body.String())
+		}
+	}
+	if w := send("", strings.NewReader(`{"name":"plain"}`)); w.Code != http.StatusOK {
+		t.Errorf("uncompressed body: unexpected status %d", w.Code)
+	}
+
+	bomb := compressBody(t, "gzip", `{"name":"`+strings.Repeat("a", 1000)+`"}`)
+	if w := send("gzip", bomb); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413 for oversized body, got %d", w.Code)
+	}
+	if w := send("br", compressBody(t, "br", `{"name":"`+strings.Repeat("a", 1000)+`"}`)); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413 for oversized br body, got %d", w.Code)
+	}
+	if w := send("zstd", strings.NewReader("x")); w.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("expected 415 for unsupported encoding, got %d", w.Code)
+	}
+	if w := send("gzip", strings.NewReader("not gzip")); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for corrupt body, got %d", w.Code)
+	}
+	if w := send("br", strings.NewReader("not brotli")); w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), `"error"`) {
+		t.Errorf("expected 400 for corrupt br body, got %d %s", w.Code, w.Body)
+	}
+}
+
+func TestWebhookRequestDecompression(t *testing.T) {
+	router, _, handlers := newLoadShedRouter()
+	router.Post("/webhooks/partner", WebhookHandlerFunc[TestWebhookRequest](NewTestWebhookHandler("valid-secret")),
+		WithRequestDecompression(DecompressionConfig{}))
+
+	r := httptest.NewRequest(http.MethodPost, "/api/webhooks/partner", compressBody(t, "gzip", `{"event":"payment"}`))
+	r.Header.Set("Content-Encoding", "gzip")
+	r.Header.Set("X-Test-Signature", "valid-secret")
+	w := httptest.NewRecorder()
+	handlers["POST /webhooks/partner"](w, r)
+	if w.Code != http.StatusOK {
+		t.Errorf("unexpected webhook response %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestRequestDecompressionOpenAPI(t *testing.T) {
+	router, registry, _ := newLoadShedRouter()
+	router.Post("/imports", func(context.Context, importRequest) (*importResponse, error) { return nil, nil },
+		WithRequestDecompression(DecompressionConfig{}))
+	router.Post("/plain", func(context.Context, importRequest) (*importResponse, error) { return nil, nil })
+
+	spec := GenerateOpenAPI(registry)
+	var found bool
+	for _, p := range spec.Paths["/api/imports"].Post.Parameters {
+		if p.Name == "Content-Encoding" && p.In == "header" && len(p.Schema.Enum) == 4 {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected Content-Encoding header parameter, got %+v", spec.Paths["/api/imports"].Post.Parameters)
+	}
+	if len(spec.Paths["/api/plain"].Post.Parameters) != 0 {
+		t.Errorf("unexpected parameters without decompression: %+v", spec.Paths["/api/plain"].Post.Parameters)
+	}
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// setDefault sets a field left out of the request to the default declared in
+// its gork tag, if any.
+func (p *ConventionParser) setDefault(ctx context.Context, fieldValue reflect.Value, field reflect.StructField, tagInfo GorkTagInfo) error {
+	if tagInfo.Default == "" {
+		return nil
+	}
+	if err := p.setFieldValue(ctx, fieldValue, field, tagInfo.Default); err != nil {
+		return fmt.Errorf("invalid default %q: %w", tagInfo.Default, err)
+	}
+	return nil
+}
+
+// setBodyDefaults sets the top-level fields of a JSON Body section, promoted
+// fields of embedded structs included, to their declared defaults before the
+// body is decoded over them, reporting whether any field has one.
+func (p *ConventionParser) setBodyDefaults(ctx context.Context, section reflect.Value) (bool, error) {
+	if section.Kind() != reflect.Struct {
+		return false, nil
+	}
+	fields := planSection(SectionBody, section.Type())
+	for _, binding := range fields {
+		if err := p.setDefault(ctx, section.FieldByIndex(binding.field.Index), binding.field, binding.tag); err != nil {
+			return false, fmt.Errorf("failed to set body field %s: %w", binding.tag.Name, err)
+		}
+	}
+	return len(fields) > 0, nil
+}
+
+// applyTagDefault documents the default declared in a gork tag on a schema.
+func applyTagDefault(schema *Schema, t reflect.Type, raw string) {
+	if raw == "" || schema == nil {
+		return
+	}
+	schema.Default = exampleValue(t, raw)
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type defaultsRequest struct {
+	Query struct {
+		Limit int    `gork:"limit,default=20"`
+		Sort  string `gork:"sort,default=name"`
+	}
+	Headers struct {
+		Locale string `gork:"Accept-Language,default=en"`
+	}
+	Body struct {
+		Name    string `gork:"name"`
+		Retries int    `gork:"retries,default=3"`
+	}
+}
+
+func TestTagDefaults(t *testing.T) {
+	var got defaultsRequest
+	registry := NewRouteRegistry()
+	handlers := map[string]http.HandlerFunc{}
+	router := NewTypedRouter[*struct{}](nil, registry, "/api", nil, &DefaultParameterAdapter{}, func(method, path string, h http.HandlerFunc, _ *RouteInfo) {
+		handlers[method+" "+path] = h
+	})
+	router.Post("/jobs", func(_ context.Context, req defaultsRequest) (*struct{}, error) {
+		got = req
+		return nil, nil
+	})
+
+	handlers["POST /jobs"](httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/jobs?sort=date", strings.NewReader(`{"name":"a"}`)))
+	if got.Query.Limit != 20 || got.Query.Sort != "date" || got.Headers.Locale != "en" || got.Body.Retries != 3 || got.Body.Name != "a" {
+		t.Errorf("expected defaults for absent values only, got %+v", got)
+	}
+
+	handlers["POST /jobs"](httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/jobs?limit=5", strings.NewReader(`{"retries":0}`)))
+	if got.Query.Limit != 5 || got.Body.Retries != 0 {
+		t.Errorf("sent values must win over defaults, got %+v", got)
+	}
+
+	spec := GenerateOpenAPI(registry)
+	params := map[string]*Schema{}
+	for _, p := range spec.Paths["/api/jobs"].Post.Parameters {
+		params[p.Name] = p.Schema
+	}
+	if params["limit"].Default != int64(20) || params["Accept-Language"].Default != "en" {
+		t.Errorf("expected parameter defaults, got limit=%v locale=%v", params["limit"].Default, params["Accept-Language"].Default)
+	}
+	var body *Schema
+	for _, schema := range spec.Components.Schemas {
+		if schema.Properties["retries"] != nil {
+			body = schema
+		}
+	}
+	if body == nil || body.Properties["retries"].Default != int64(3) {
+		t.Errorf("expected the body default to be documented, got %+v", body)
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"go/ast"
+	"go/constant"
 	"go/parser"
 	"go/token"
 	"os"
@@ -14,10 +15,20 @@ import (
 // Documentation holds extracted information from Go doc comments.
 type Documentation struct {
 	Description string
-	Fields      map[string]FieldDoc
-	Deprecated  bool
-	Example     string
-	Since       string
+	// Summary and Details split the doc comment of a function: the first
+	// line and the paragraphs following it. They document the operations
+	// of handlers.
+	Summary string
+	Details string
+	Fields  map[string]FieldDoc
+	// Sections holds the field docs of the anonymous convention sections
+	// (Query, Path, Headers, Cookies, Body) of a request or response type,
+	// keyed by section name, so that equally named fields of different
+	// sections keep their own description.
+	Sections   map[string]map[string]FieldDoc
+	Deprecated bool
+	Example    string
+	Since      string
 }
 
 // FieldDoc represents documentation information for a struct field.
@@ -30,12 +41,32 @@ type FieldDoc struct {
 // DocExtractor parses Go source files and indexes doc comments for later
 // lookup by name.
 type DocExtractor struct {
-	docs map[string]Documentation // fully-qualified name -> documentation
+	docs        map[string]Documentation  // fully-qualified name -> documentation
+	positions   map[string]token.Position // type or function name -> declaration
+	handlers    map[string]handlerTypes   // function name -> request/response types
+	parseErrors []error
+	fset        *token.FileSet
+
+	// Enum detection state; see Enums. pkgPath is the import path of the
+	// package being parsed, importPaths caches it by directory.
+	pkgPath     string
+	importPaths map[string]string
+	enumTypes   map[string]bool
+	enumConsts  map[string][]string
+	constValues map[string]constant.Value
 }
 
 // NewDocExtractor allocates a new instance.
 func NewDocExtractor() *DocExtractor {
-	return &DocExtractor{docs: map[string]Documentation{}}
+	return &DocExtractor{
+		docs:        map[string]Documentation{},
+		positions:   map[string]token.Position{},
+		handlers:    map[string]handlerTypes{},
+		importPaths: map[string]string{},
+		enumTypes:   map[string]bool{},
+		enumConsts:  map[string][]string{},
+		constValues: map[string]constant.Value{},
+	}
 }
 
 // ParseDirectory walks through the provided directory (recursively) and parses
@@ -73,7 +104,8 @@ func (d *DocExtractor) processDirectoryEntry(path string, de os.DirEntry, fset *
 
 		filePath := filepath.Join(path, entry.Name())
 		if err := d.parseFile(filePath, fset); err != nil {
-			// Skip files that fail to parse
+			// Skip files that fail to parse; the error is kept for ParseErrors.
+			d.parseErrors = append(d.parseErrors, err)
 			continue
 		}
 	}
@@ -87,22 +119,60 @@ func (d *DocExtractor) parseFile(filePath string, fset *token.FileSet) error {
 		return err
 	}
 
+	d.fset = fset
+	d.pkgPath = d.importPath(filepath.Dir(filePath), file.Name.Name)
 	ast.Inspect(file, d.inspectNode)
 	return nil
 }
 
+// ParseErrors returns the errors of the files ParseDirectory skipped because
+// they failed to parse. Syntax errors are scanner.ErrorList values carrying
+// the file and line.
+func (d *DocExtractor) ParseErrors() []error {
+	return d.parseErrors
+}
+
+// Position returns where the type or function name was declared.
+func (d *DocExtractor) Position(name string) (token.Position, bool) {
+	pos, ok := d.positions[name]
+	return pos, ok
+}
+
+func (d *DocExtractor) recordPosition(name *ast.Ident) {
+	if d.fset == nil || d.positions == nil {
+		return
+	}
+	if _, ok := d.positions[name.Name]; !ok {
+		d.positions[name.Name] = d.fset.Position(name.Pos())
+	}
+}
+
 func (d *DocExtractor) inspectNode(n ast.Node) bool {
 	switch decl := n.(type) {
 	case *ast.GenDecl:
+		for _, spec := range decl.Specs {
+			if ts, ok := spec.(*ast.TypeSpec); ok {
+				d.recordPosition(ts.Name)
+				if d.enumTypes != nil {
+					d.recordEnumType(ts)
+				}
+			}
+		}
+		if decl.Tok == token.CONST && d.enumConsts != nil {
+			d.processConstDecl(decl)
+		}
 		d.processGenDecl(decl)
 	case *ast.FuncDecl:
+		if decl.Recv == nil {
+			d.recordPosition(decl.Name)
+		}
 		d.processFuncDecl(decl)
 	}
 	return true // continue traversing children
 }
 
 func (d *DocExtractor) processGenDecl(decl *ast.GenDecl) {
-	if decl.Doc == nil || decl.Tok != token.TYPE {
+	if decl.Tok != token.TYPE {
 		return
 	}
 
@@ -121,28 +191,58 @@ func (d *DocExtractor) processTypeSpec(ts *ast.TypeSpec, docComment *ast.Comment
 	// Top-level type description (paragraph above `type X struct`)
 	if docComment != nil {
 		doc.Description = extractDescription(docComment.Text())
+		doc.Example = extractExample(docComment.Text())
 	}
 
 	// If the underlying type is a struct, iterate over its fields and grab
 	// their doc comments. We store them in doc.Fields keyed by the field
 	// identifier so that later integration can attach them to schema
 	// properties.
-	if st, ok := ts.Type.(*ast.StructType); ok {
+	if st, ok := ts.Type.(*ast.StructType); ok && st.Fields != nil {
 		d.processStructFields(st, &doc)
+		d.processSections(st, &doc)
 	}
 
+	// Undocumented types are only kept for the docs of their fields.
+	if _, known := d.docs[name]; !known && docComment == nil && len(doc.Fields) == 0 && len(doc.Sections) == 0 {
+		return
+	}
 	d.docs[name] = doc
 }
 
+// processSections records the field docs of anonymous convention sections
+// per section.
+func (d *DocExtractor) processSections(st *ast.StructType, doc *Documentation) {
+	for _, fld := range st.Fields.List {
+		section, ok := fld.Type.(*ast.StructType)
+		if !ok || len(fld.Names) != 1 || !conventionSections[fld.Names[0].Name] {
+			continue
+		}
+		sectionDoc := Documentation{}
+		d.processStructFields(section, &sectionDoc)
+		if len(sectionDoc.Fields) == 0 {
+			continue
+		}
+		if doc.Sections == nil {
+			doc.Sections = map[string]map[string]FieldDoc{}
+		}
+		doc.Sections[fld.Names[0].Name] = sectionDoc.Fields
+	}
+}
+
+// conventionSections are the section field names of request and response
+// types.
+var conventionSections = map[string]bool{"Query": true, "Path": true, "Headers": true, "Cookies": true, "Body": true}
+
 func (d *DocExtractor) processStructFields(st *ast.StructType, doc *Documentation) {
 	if doc.Fields == nil {
 		doc.Fields = map[string]FieldDoc{}
 	}
 
 	for _, fld := range st.Fields.List {
-		desc := d.extractFieldDescription(fld)
-		if desc != "" {
-			d.storeFieldDocumentation(fld, desc, doc)
+		fieldDoc := d.extractFieldDoc(fld)
+		if fieldDoc.Description != "" || fieldDoc.Example != "" {
+			d.storeFieldDocumentation(fld, fieldDoc, doc)
 		}
 
 		// Also process anonymous struct fields recursively
@@ -162,27 +262,39 @@ func (d *DocExtractor) processAnonymousStructFields(fld *ast.Field, doc *Documen
 	}
 }
 
+func (d *DocExtractor) extractFieldDoc(fld *ast.Field) FieldDoc {
+	return FieldDoc{
+		Description: d.extractFieldDescription(fld),
+		Example:     extractExample(fieldCommentText(fld)),
+	}
+}
+
 func (d *DocExtractor) extractFieldDescription(fld *ast.Field) string {
-	var desc string
+	return extractDescription(fieldCommentText(fld))
+}
+
+// fieldCommentText returns the doc comment of a field, or its line comment.
+func fieldCommentText(fld *ast.Field) string {
 	if fld.Doc != nil {
-		desc = extractDescription(fld.Doc.Text())
-	} else if fld.Comment != nil {
-		desc = extractDescription(fld.Comment.Text())
+		return fld.Doc.Text()
+	}
+	if fld.Comment != nil {
+		return fld.Comment.Text()
 	}
-	return desc
+	return ""
 }
 
-func (d *DocExtractor) storeFieldDocumentation(fld *ast.Field, desc string, doc *Documentation) {
+func (d *DocExtractor) storeFieldDocumentation(fld *ast.Field, fieldDoc FieldDoc, doc *Documentation) {
 	for _, ident := range fld.Names {
 		// Store by Go identifier
-		doc.Fields[ident.Name] = FieldDoc{Description: desc}
+		doc.Fields[ident.Name] = fieldDoc
 
 		// Also store by JSON tag name if present and differs
-		d.storeFieldDocByJSONTag(fld, desc, doc)
+		d.storeFieldDocByJSONTag(fld, fieldDoc, doc)
 	}
 }
 
-func (d *DocExtractor) storeFieldDocByJSONTag(fld *ast.Field, desc string, doc *Documentation) {
+func (d *DocExtractor) storeFieldDocByJSONTag(fld *ast.Field, fieldDoc FieldDoc, doc *Documentation) {
 	if fld.Tag == nil {
 		return
 	}
@@ -197,20 +309,67 @@ func (d *DocExtractor) storeFieldDocByJSONTag(fld *ast.Field, desc string, doc *
 			gorkTag = gorkTag[:comma]
 		}
 		if gorkTag != "" {
-			doc.Fields[gorkTag] = FieldDoc{Description: desc}
+			doc.Fields[gorkTag] = fieldDoc
 		}
 	}
 }
 
 func (d *DocExtractor) processFuncDecl(decl *ast.FuncDecl) {
+	if decl.Recv == nil && d.handlers != nil {
+		d.recordHandlerTypes(decl)
+	}
 	if decl.Doc != nil {
 		name := decl.Name.Name
+		summary, details := extractSummary(decl.Doc.Text())
 		d.docs[name] = Documentation{
 			Description: extractDescription(decl.Doc.Text()),
+			Summary:     summary,
+			Details:     details,
 		}
 	}
 }
 
+// handlerTypes are the request and response type names of a handler.
+type handlerTypes struct {
+	request, response string
+}
+
+// recordHandlerTypes remembers the request and response types of functions
+// shaped like handlers, func(ctx, Request) (*Response, error).
+func (d *DocExtractor) recordHandlerTypes(decl *ast.FuncDecl) {
+	params, results := decl.Type.Params, decl.Type.Results
+	if params == nil || len(params.List) == 0 || results == nil || len(results.List) != 2 {
+		return
+	}
+	last := params.List[len(params.List)-1]
+	if len(params.List) == 1 && len(last.Names) < 2 {
+		return
+	}
+	d.handlers[decl.Name.Name] = handlerTypes{request: typeIdentName(last.Type), response: typeIdentName(results.List[0].Type)}
+}
+
+// typeIdentName returns the type name of a (pointer to a) named type.
+func typeIdentName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return typeIdentName(e.X)
+	case *ast.Ident:
+		return e.Name
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	}
+	return ""
+}
+
+// requestTypeName returns the request type of the handler funcName, falling
+// back to the "<Handler>Request" naming convention.
+func (d *DocExtractor) requestTypeName(funcName string) string {
+	if types := d.handlers[funcName]; types.request != "" {
+		return types.request
+	}
+	return funcName + "Request"
+}
+
 // ExtractTypeDoc returns the extracted documentation for the given type name.
 func (d *DocExtractor) ExtractTypeDoc(typeName string) Documentation {
 	if doc, ok := d.docs[typeName]; ok {
@@ -241,9 +400,36 @@ func (d *DocExtractor) GetAllTypeNames() []string {
 	return names
 }
 
-// extractDescription returns the first paragraph (until double newline) trimmed.
+// exampleMarker starts a doc comment line holding an example value.
+const exampleMarker = "Example:"
+
+// extractExample returns the value of the first "Example:" line of a comment.
+func extractExample(comment string) string {
+	for _, line := range strings.Split(comment, "\n") {
+		line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "//"))
+		if strings.HasPrefix(line, exampleMarker) {
+			return strings.TrimSpace(strings.TrimPrefix(line, exampleMarker))
+		}
+	}
+	return ""
+}
+
+// withoutExampleLines removes "Example:" lines so they do not end up in descriptions.
+func withoutExampleLines(comment string) string {
+	lines := strings.Split(comment, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if !strings.HasPrefix(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "//")), exampleMarker) {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
+
+// extractDescription returns the first paragraph (until double newline) trimmed,
+// leaving out "Example:" lines.
 func extractDescription(comment string) string {
-	trimmed := strings.TrimSpace(comment)
+	trimmed := strings.TrimSpace(withoutExampleLines(comment))
 	if trimmed == "" {
 		return ""
 	}
@@ -258,3 +444,17 @@ func extractDescription(comment string) string {
 	}
 	return strings.TrimSpace(strings.Join(lines, " "))
 }
+
+// extractSummary splits a comment into its first line and the paragraphs
+// after it, leaving out "Example:" lines. The lines of each paragraph are
+// joined with spaces and paragraphs are separated by blank lines.
+func extractSummary(comment string) (summary, details string) {
+	first, rest, _ := strings.Cut(strings.TrimSpace(withoutExampleLines(comment)), "\n")
+	var paragraphs []string
+	for _, paragraph := range strings.Split(rest, "\n\n") {
+		if paragraph = strings.Join(strings.Fields(paragraph), " "); paragraph != "" {
+			paragraphs = append(paragraphs, paragraph)
+		}
+	}
+	return strings.TrimSpace(first), strings.Join(paragraphs, "\n\n")
+}
@@ -77,7 +77,7 @@ func TestStoreFieldDocByJSONTag_NoTag(t *testing.T) {
 		Tag:   nil,
 	}
 
-	extractor.storeFieldDocByJSONTag(field, "test description", doc)
+	extractor.storeFieldDocByJSONTag(field, FieldDoc{Description: "test description"}, doc)
 
 	// Should not add any JSON tag entries
 	if len(doc.Fields) != 0 {
@@ -95,7 +95,7 @@ func TestStoreFieldDocByJSONTag_EmptyJSONTag(t *testing.T) {
 		Tag:   &ast.BasicLit{Value: "`validate:\"required\"`"},
 	}
 
-	extractor.storeFieldDocByJSONTag(field, "test description", doc)
+	extractor.storeFieldDocByJSONTag(field, FieldDoc{Description: "test description"}, doc)
 
 	// Should not add any JSON tag entries since json tag is empty
 	if len(doc.Fields) != 0 {
@@ -113,7 +113,7 @@ func TestStoreFieldDocByJSONTag_WithJSONTag(t *testing.T) {
 		Tag:   &ast.BasicLit{Value: "`gork:\"test_field,omitempty\"`"},
 	}
 
-	extractor.storeFieldDocByJSONTag(field, "test description", doc)
+	extractor.storeFieldDocByJSONTag(field, FieldDoc{Description: "test description"}, doc)
 
 	// Should add entry for the gork tag name
 	if len(doc.Fields) != 1 {
@@ -137,7 +137,7 @@ func TestStoreFieldDocByJSONTag_WithJSONTagNoComma(t *testing.T) {
 		Tag:   &ast.BasicLit{Value: "`gork:\"test_field\"`"},
 	}
 
-	extractor.storeFieldDocByJSONTag(field, "test description", doc)
+	extractor.storeFieldDocByJSONTag(field, FieldDoc{Description: "test description"}, doc)
 
 	// Should add entry for the gork tag name
 	if len(doc.Fields) != 1 {
@@ -161,7 +161,7 @@ func TestStoreFieldDocByJSONTag_EmptyJSONName(t *testing.T) {
 		Tag:   &ast.BasicLit{Value: "`gork:\",omitempty\"`"},
 	}
 
-	extractor.storeFieldDocByJSONTag(field, "test description", doc)
+	extractor.storeFieldDocByJSONTag(field, FieldDoc{Description: "test description"}, doc)
 
 	// Should not add any entries since the gork name is empty
 	if len(doc.Fields) != 0 {
@@ -354,6 +354,14 @@ func MyFunc() {}
 	if doc.Description != "MyFunc is a valid function" {
 		t.Errorf("Expected 'MyFunc is a valid function', got %q", doc.Description)
 	}
+
+	// The skipped file is reported with its position
+	if errs := extractor.ParseErrors(); len(errs) != 1 || !strings.Contains(errs[0].Error(), "malformed.go:2") {
+		t.Errorf("Expected the malformed file in ParseErrors, got %v", errs)
+	}
+	if pos, ok := extractor.Position("MyFunc"); !ok || pos.Filename != validFilePath || pos.Line != 4 {
+		t.Errorf("Expected MyFunc declared at valid.go:4, got %v", pos)
+	}
 }
 
 func TestProcessGenDecl_NoDocComment(t *testing.T) {
@@ -461,7 +469,7 @@ func TestStoreFieldDocByJSONTag_WithGorkTag(t *testing.T) {
 		Tag:   &ast.BasicLit{Value: "`gork:\"userID\"`"},
 	}
 
-	extractor.storeFieldDocByJSONTag(field, "ID of the user", doc)
+	extractor.storeFieldDocByJSONTag(field, FieldDoc{Description: "ID of the user"}, doc)
 
 	// Should add entry for the gork tag name
 	if len(doc.Fields) != 1 {
@@ -485,7 +493,7 @@ func TestStoreFieldDocByJSONTag_WithGorkTagAndOptions(t *testing.T) {
 		Tag:   &ast.BasicLit{Value: "`gork:\"username,omitempty\"`"},
 	}
 
-	extractor.storeFieldDocByJSONTag(field, "Username of the user", doc)
+	extractor.storeFieldDocByJSONTag(field, FieldDoc{Description: "Username of the user"}, doc)
 
 	// Should add entry for the gork tag name (before comma)
 	if len(doc.Fields) != 1 {
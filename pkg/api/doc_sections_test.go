@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type sectionDocWidgetRequest struct {
+	Path struct {
+		ID string `gork:"id"`
+	}
+	Query struct {
+		DryRun bool `gork:"dry_run"`
+	}
+	Body struct {
+		ID    string `gork:"id"`
+		Label string `gork:"label"`
+	}
+}
+
+type sectionDocWidgetResponse struct {
+	Body struct {
+		Revision int `gork:"revision"`
+	}
+}
+
+func UpdateSectionDocWidget(context.Context, sectionDocWidgetRequest) (*sectionDocWidgetResponse, error) {
+	return nil, nil
+}
+
+const sectionDocSource = `package widgets
+
+type sectionDocWidgetRequest struct {
+	Path struct {
+		// ID identifies the widget to update.
+		ID string ` + "`gork:\"id\"`" + `
+	}
+	Query struct {
+		// DryRun validates the change without saving it.
+		DryRun bool ` + "`gork:\"dry_run\"`" + `
+	}
+	Body struct {
+		// ID replaces the widget's external identifier.
+		ID    string ` + "`gork:\"id\"`" + `
+		Label string ` + "`gork:\"label\"`" + ` // Label is shown in listings.
+	}
+}
+
+type sectionDocWidgetResponse struct {
+	Body struct {
+		// Revision counts the updates of the widget.
+		Revision int ` + "`gork:\"revision\"`" + `
+	}
+}
+
+func UpdateSectionDocWidget(ctx context.Context, req sectionDocWidgetRequest) (*sectionDocWidgetResponse, error) {
+	return nil, nil
+}
+`
+
+func TestSectionFieldDocs(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "widgets.go"), []byte(sectionDocSource), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	extractor := NewDocExtractor()
+	if err := extractor.ParseDirectory(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	router, registry, _ := newLoadShedRouter()
+	router.Put("/widgets/{id}", UpdateSectionDocWidget)
+	spec := GenerateOpenAPIWithDocs(registry, extractor)
+
+	op := spec.Paths["/api/widgets/{id}"].Put
+	params := map[string]string{}
+	for _, p := range op.Parameters {
+		params[p.In+" "+p.Name] = p.Description
+	}
+	if want := "ID identifies the widget to update."; params["path id"] != want {
+		t.Errorf("path id description = %q, want %q", params["path id"], want)
+	}
+	if want := "DryRun validates the change without saving it."; params["query dry_run"] != want {
+		t.Errorf("query dry_run description = %q, want %q", params["query dry_run"], want)
+	}
+
+	body := resolveComponentSchema(op.RequestBody.Content["application/json"].Schema, spec.Components)
+	if want := "ID replaces the widget's external identifier."; body.Properties["id"].Description != want {
+		t.Errorf("body id description = %q, want %q", body.Properties["id"].Description, want)
+	}
+	if want := "Label is shown in listings."; body.Properties["label"].Description != want {
+		t.Errorf("body label description = %q, want %q", body.Properties["label"].Description, want)
+	}
+
+	resp := resolveComponentSchema(op.Responses["200"].Content["application/json"].Schema, spec.Components)
+	if want := "Revision counts the updates of the widget."; resp.Properties["revision"].Description != want {
+		t.Errorf("response revision description = %q, want %q", resp.Properties["revision"].Description, want)
+	}
+}
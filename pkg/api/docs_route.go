@@ -1,7 +1,9 @@
 package api
 
 import (
+	"crypto/subtle"
 	"encoding/json"
+	"html"
 	"net/http"
 	"os"
 	"strings"
@@ -34,6 +36,31 @@ type DocsConfig struct {
 	// provided – StoplightUITemplate (default), SwaggerUITemplate and
 	// RedocUITemplate – but callers can supply any custom template string.
 	UITemplate UITemplate
+	// OpenAPIOptions are passed to GenerateOpenAPI for the spec served by
+	// this docs site. Together with a relative OpenAPIPath they allow several
+	// sites on one router, e.g. a public one and an internal one using
+	// WithRouteFilter or WithSpecAudience.
+	OpenAPIOptions []OpenAPIOption
+	// FaviconURL and CustomCSS brand the UI page. They are added to the head
+	// of the page, so they also apply to custom templates.
+	FaviconURL string
+	CustomCSS  string
+	// BasicAuth protects the UI and the spec with HTTP basic auth.
+	BasicAuth *DocsBasicAuth
+	// Authenticate protects the UI and the spec with an authenticator, as
+	// used for security schemes. It is checked after BasicAuth.
+	Authenticate Authenticator
+}
+
+// DocsBasicAuth holds the credentials of a docs site protected with basic auth.
+// An empty Username or Password refuses every request with 403, so a missing
+// secret never opens the docs.
+type DocsBasicAuth struct {
+	Username string
+	Password string
+	// Realm is sent in the WWW-Authenticate challenge. Defaults to the docs
+	// title.
+	Realm string
 }
 
 // UITemplate represents an HTML page template for serving API documentation.
@@ -80,11 +107,47 @@ func (r *TypedRouter[T]) DocsRoute(path string, cfg ...DocsConfig) {
 	staticSpec := LoadStaticSpec(conf.SpecFile)
 
 	// Register OpenAPI spec endpoint
-	r.registerOpenAPIEndpoint(openapiPath, staticSpec)
+	r.registerOpenAPIEndpoint(openapiPath, staticSpec, conf)
 
 	// Register UI route
 	if r.registerFn != nil {
-		r.registerFn(http.MethodGet, basePath+"/*", r.createDocsHandler(basePath, confWithFullPath), nil)
+		r.registerFn(http.MethodGet, basePath+"/*", protectDocs(conf, r.createDocsHandler(basePath, confWithFullPath)), nil)
+	}
+}
+
+// protectDocs guards a docs endpoint with the BasicAuth and Authenticate
+// settings of cfg.
+func protectDocs(cfg DocsConfig, next http.HandlerFunc) http.HandlerFunc {
+	if cfg.BasicAuth == nil && cfg.Authenticate == nil {
+		return next
+	}
+	return func(w http.ResponseWriter, req *http.Request) {
+		if auth := cfg.BasicAuth; auth != nil {
+			if auth.Username == "" || auth.Password == "" {
+				writeError(w, http.StatusForbidden, "docs basic auth requires a username and password")
+				return
+			}
+			user, pass, ok := req.BasicAuth()
+			// Compare both values to keep the timing independent of which one is wrong.
+			userOK := subtle.ConstantTimeCompare([]byte(user), []byte(auth.Username)) == 1
+			passOK := subtle.ConstantTimeCompare([]byte(pass), []byte(auth.Password)) == 1
+			if !ok || !userOK || !passOK {
+				realm := auth.Realm
+				if realm == "" {
+					realm = cfg.Title
+				}
+				w.Header().Set("WWW-Authenticate", `Basic realm="`+strings.ReplaceAll(realm, `"`, "")+`", charset="UTF-8"`)
+				writeError(w, http.StatusUnauthorized, "authentication required")
+				return
+			}
+		}
+		if cfg.Authenticate != nil {
+			if _, err := cfg.Authenticate(req, nil); err != nil {
+				writeAuthError(w, err, "")
+				return
+			}
+		}
+		next(w, req)
 	}
 }
 
@@ -181,21 +244,25 @@ func LoadStaticSpec(specFile string) *OpenAPISpec {
 }
 
 // openAPIHandler returns the OpenAPI spec, either from staticSpec or by generating it from registry.
-func (r *TypedRouter[T]) openAPIHandler(staticSpec *OpenAPISpec) (*OpenAPISpec, error) {
+func (r *TypedRouter[T]) openAPIHandler(staticSpec *OpenAPISpec, opts ...OpenAPIOption) (*OpenAPISpec, error) {
 	if staticSpec != nil {
 		return staticSpec, nil
 	}
-	spec := GenerateOpenAPI(r.registry)
+	spec := GenerateOpenAPI(r.registry, opts...)
 	return spec, nil
 }
 
-func (r *TypedRouter[T]) registerOpenAPIEndpoint(openapiPath string, staticSpec *OpenAPISpec) {
+func (r *TypedRouter[T]) registerOpenAPIEndpoint(openapiPath string, staticSpec *OpenAPISpec, cfg DocsConfig) {
 	// Register raw HTTP handler to bypass convention system for OpenAPI spec
 	if r.registerFn != nil {
-		r.registerFn(http.MethodGet, openapiPath, func(w http.ResponseWriter, req *http.Request) {
-			spec, _ := r.openAPIHandler(staticSpec)
+		cache := NewSpecCache(r.registry, cfg.OpenAPIOptions...)
+		r.registerFn(http.MethodGet, openapiPath, protectDocs(cfg, func(w http.ResponseWriter, req *http.Request) {
+			spec := staticSpec
+			if spec == nil {
+				spec = cache.Spec()
+			}
 			r.handleOpenAPIRequest(w, req, spec)
-		}, nil)
+		}), nil)
 	}
 }
 
@@ -231,7 +298,19 @@ func (r *TypedRouter[T]) generateDocsHTML(basePath string, cfg DocsConfig) strin
 		"{{.OpenAPIPath}}", cfg.OpenAPIPath,
 		"{{.BasePath}}", basePath,
 	)
-	return replacer.Replace(htmlTmpl)
+	page := replacer.Replace(htmlTmpl)
+
+	var head strings.Builder
+	if cfg.FaviconURL != "" {
+		head.WriteString(`    <link rel="icon" href="` + html.EscapeString(cfg.FaviconURL) + "\">\n")
+	}
+	if cfg.CustomCSS != "" {
+		head.WriteString("    <style>" + strings.ReplaceAll(cfg.CustomCSS, "</", `<\/`) + "</style>\n")
+	}
+	if head.Len() > 0 {
+		page = strings.Replace(page, "</head>", head.String()+"</head>", 1)
+	}
+	return page
 }
 
 // serveDocsHTML returns an http.HandlerFunc that serves the docs HTML content with proper headers.
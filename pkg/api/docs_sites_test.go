@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDocsRouteMultipleSites(t *testing.T) {
+	router, _, handlers := newLoadShedRouter()
+	router.Get("/payments", ListPayments)
+	router.Post("/accounts/{account_id}/payments", CreatePayment, WithTags("internal"))
+
+	public := func(route *RouteInfo) bool { return !slices.Contains(route.Options.Tags, "internal") }
+	router.DocsRoute("/docs", DocsConfig{OpenAPIPath: "openapi.json", OpenAPIOptions: []OpenAPIOption{WithRouteFilter(public)}})
+	router.DocsRoute("/internal/docs", DocsConfig{
+		Title:       "Internal API",
+		OpenAPIPath: "openapi.json",
+		FaviconURL:  "/static/favicon.png",
+		CustomCSS:   "body { color: red; }</style><script>",
+		BasicAuth:   &DocsBasicAuth{Username: "ops", Password: "secret"},
+	})
+
+	rec := httptest.NewRecorder()
+	handlers["GET /docs/openapi.json"](rec, httptest.NewRequest(http.MethodGet, "/docs/openapi.json", nil))
+	if !strings.Contains(rec.Body.String(), "/api/payments") || strings.Contains(rec.Body.String(), "account_id") {
+		t.Errorf("expected only public routes in the public spec, got %s", rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handlers["GET /internal/docs/openapi.json"](rec, httptest.NewRequest(http.MethodGet, "/internal/docs/openapi.json", nil))
+	if rec.Code != http.StatusUnauthorized || rec.Header().Get("WWW-Authenticate") != `Basic realm="Internal API", charset="UTF-8"` {
+		t.Errorf("expected a basic auth challenge, got %d %q", rec.Code, rec.Header().Get("WWW-Authenticate"))
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/internal/docs/openapi.json", nil)
+	req.SetBasicAuth("ops", "secret")
+	rec = httptest.NewRecorder()
+	handlers["GET /internal/docs/openapi.json"](rec, req)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "account_id") {
+		t.Errorf("expected the full spec, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/internal/docs/", nil)
+	req.SetBasicAuth("ops", "secret")
+	rec = httptest.NewRecorder()
+	handlers["GET /internal/docs/*"](rec, req)
+	page := rec.Body.String()
+	for _, want := range []string{
+		"<title>Internal API</title>",
+		`apiDescriptionUrl="/internal/docs/openapi.json"`,
+		`<link rel="icon" href="/static/favicon.png">`,
+		`<style>body { color: red; }<\/style><script></style>` + "\n</head>",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("page is missing %q:\n%s", want, page)
+		}
+	}
+}
+
+func TestDocsRouteBasicAuthWithoutPassword(t *testing.T) {
+	router, _, handlers := newLoadShedRouter()
+	router.DocsRoute("/docs", DocsConfig{OpenAPIPath: "openapi.json", BasicAuth: &DocsBasicAuth{Username: "ops"}})
+
+	for _, user := range []string{"ops", ""} {
+		req := httptest.NewRequest(http.MethodGet, "/docs/openapi.json", nil)
+		req.SetBasicAuth(user, "")
+		rec := httptest.NewRecorder()
+		handlers["GET /docs/openapi.json"](rec, req)
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("user %q: expected 403 without a configured password, got %d", user, rec.Code)
+		}
+	}
+}
+
+func TestDocsRouteAuthenticator(t *testing.T) {
+	router, _, handlers := newLoadShedRouter()
+	router.DocsRoute("/docs", DocsConfig{Authenticate: func(r *http.Request, _ []string) (context.Context, error) {
+		if r.Header.Get("X-Staff") == "" {
+			return nil, errors.New("staff only")
+		}
+		return r.Context(), nil
+	}})
+
+	rec := httptest.NewRecorder()
+	handlers["GET /docs/*"](rec, httptest.NewRequest(http.MethodGet, "/docs/", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", rec.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/docs/", nil)
+	req.Header.Set("X-Staff", "1")
+	rec = httptest.NewRecorder()
+	handlers["GET /docs/*"](rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected the docs page, got %d", rec.Code)
+	}
+}
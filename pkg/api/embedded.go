@@ -0,0 +1,46 @@
+package api
+
+import (
+	"reflect"
+	"strings"
+)
+
+// embeddedStructType returns the struct type whose fields field promotes
+// into its parent, as gorkson encodes it: an embedded struct, or pointer to
+// one, without a gork or json name.
+func embeddedStructType(field reflect.StructField) (reflect.Type, bool) {
+	if !field.Anonymous || parseGorkTag(field.Tag.Get("gork")).Name != "" || strings.Split(field.Tag.Get("json"), ",")[0] != "" {
+		return nil, false
+	}
+	t := field.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || isUnionType(t) {
+		return nil, false
+	}
+	return t, true
+}
+
+// mergePromoted adds the properties of promoted, the schema of an embedded
+// struct, to s. Properties s already has win, as fields of the outer struct
+// hide promoted ones on the wire.
+func (s *Schema) mergePromoted(promoted *Schema) {
+	if s.Properties == nil {
+		s.Properties = map[string]*Schema{}
+	}
+	for name, prop := range promoted.Properties {
+		if _, taken := s.Properties[name]; taken {
+			continue
+		}
+		s.Properties[name] = prop
+		if audience, ok := promoted.propertyAudiences[name]; ok {
+			s.setPropertyAudience(audience, name)
+		}
+		for _, required := range promoted.Required {
+			if required == name {
+				s.Required = append(s.Required, name)
+			}
+		}
+	}
+}
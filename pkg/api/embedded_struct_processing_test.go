@@ -1,7 +1,13 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"sort"
+	"strings"
 	"testing"
 )
 
@@ -223,3 +229,99 @@ func TestProcessEmbeddedStructComprehensive(t *testing.T) {
 		}
 	})
 }
+
+type embeddedAuditFields struct {
+	CreatedBy string `gork:"created_by"`
+	Cost      int    `gork:"cost,audience=internal"`
+}
+
+type EmbeddedTimestamps struct {
+	CreatedAt string `gork:"created_at" validate:"required"`
+	Region    string `gork:"region,default=eu"`
+}
+
+type embeddedOrderBody struct {
+	EmbeddedTimestamps
+	embeddedAuditFields
+	ID string `gork:"id" validate:"required"`
+}
+
+func TestEmbeddedBodyFieldsArePromoted(t *testing.T) {
+	registry := NewRouteRegistry()
+	handlers := map[string]http.HandlerFunc{}
+	router := NewTypedRouter[*struct{}](nil, registry, "/api", nil, &DefaultParameterAdapter{}, func(method, path string, h http.HandlerFunc, _ *RouteInfo) {
+		handlers[method+" "+path] = h
+	})
+	type orderRequest struct {
+		Body embeddedOrderBody
+	}
+	type orderResponse struct {
+		Body embeddedOrderBody
+	}
+	var got embeddedOrderBody
+	router.Post("/orders", func(_ context.Context, req orderRequest) (*orderResponse, error) {
+		got = req.Body
+		return &orderResponse{Body: req.Body}, nil
+	})
+
+	rec := httptest.NewRecorder()
+	body := `{"id":"o1","created_at":"monday","created_by":"ann","cost":5}`
+	handlers["POST /orders"](rec, httptest.NewRequest(http.MethodPost, "/api/orders", strings.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d %s", rec.Code, rec.Body.String())
+	}
+	if got.ID != "o1" || got.CreatedAt != "monday" || got.CreatedBy != "ann" || got.Cost != 5 || got.Region != "eu" {
+		t.Errorf("expected promoted fields to be decoded and defaulted, got %+v", got)
+	}
+	if want := `{"created_at":"monday","created_by":"ann","id":"o1","region":"eu"}`; strings.TrimSpace(rec.Body.String()) != want {
+		t.Errorf("expected promoted fields in the response without internal ones, got %s, want %s", rec.Body.String(), want)
+	}
+
+	spec := GenerateOpenAPI(registry)
+	data, _ := json.Marshal(spec.Components.Schemas)
+	if spec.Components.Schemas["EmbeddedTimestamps"] != nil {
+		t.Errorf("expected embedded structs not to become components, got %s", data)
+	}
+	for name, schema := range spec.Components.Schemas {
+		if schema.Properties["id"] == nil {
+			continue
+		}
+		// cost is internal and pruned from the spec
+		for _, prop := range []string{"created_at", "region", "created_by"} {
+			if schema.Properties[prop] == nil {
+				t.Errorf("%s: expected promoted property %s, got %s", name, prop, data)
+			}
+		}
+		sort.Strings(schema.Required)
+		if !reflect.DeepEqual(schema.Required, []string{"created_at", "id"}) {
+			t.Errorf("%s: expected promoted required fields, got %v", name, schema.Required)
+		}
+	}
+}
+
+func TestEmbeddedStructSchemaOuterFieldsWin(t *testing.T) {
+	type Base struct {
+		ID   int    `gork:"id"`
+		Note string `gork:"note"`
+	}
+	type Item struct {
+		ID string `gork:"id"`
+		Base
+		*EmbeddedTimestamps
+		Named Base `gork:"named"`
+	}
+
+	registry := map[string]*Schema{}
+	schema := buildStructSchema(reflect.TypeOf(Item{}), registry)
+	if schema.Ref != "" {
+		schema = registry["Item"]
+	}
+	if schema.Properties["id"] == nil || schema.Properties["id"].Type != "string" {
+		t.Errorf("expected the outer id to win, got %+v", schema.Properties["id"])
+	}
+	for _, prop := range []string{"note", "created_at", "region", "named"} {
+		if schema.Properties[prop] == nil {
+			t.Errorf("expected property %s, got %v", prop, schema.Properties)
+		}
+	}
+}
@@ -0,0 +1,332 @@
+package api
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// enumKind lists the underlying types an enum can have.
+type enumKind interface {
+	~string | ~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// enums holds the allowed values of enum types, registered by type or, for
+// enums detected in source code, by "import/path.Type" name.
+var enums = struct {
+	sync.RWMutex
+	byType map[reflect.Type][]string
+	byName map[string][]string
+}{byType: map[reflect.Type][]string{}, byName: map[string][]string{}}
+
+// RegisterEnum declares the allowed values of T. Fields of type T get an
+// `enum` in the generated schema and requests carrying any other non-zero
+// value are rejected with a validation error:
+//
+//	type Status string
+//
+//	const (
+//		StatusActive   Status = "active"
+//		StatusDisabled Status = "disabled"
+//	)
+//
+//	func init() { api.RegisterEnum(StatusActive, StatusDisabled) }
+func RegisterEnum[T enumKind](values ...T) {
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		formatted[i] = formatEnumValue(reflect.ValueOf(value))
+	}
+	enums.Lock()
+	defer enums.Unlock()
+	enums.byType[reflect.TypeOf(values).Elem()] = formatted
+}
+
+// RegisterEnumValues declares the allowed values of the type named
+// "import/path.Type", e.g. "example.com/shop/orders.Status" ("main.Type"
+// for the main package). It is how enums detected by DocExtractor reach the
+// generator and the validator; see RegisterEnumsFromDocs.
+func RegisterEnumValues(typeName string, values []string) {
+	enums.Lock()
+	defer enums.Unlock()
+	enums.byName[typeName] = append([]string(nil), values...)
+}
+
+// RegisterEnumsFromDocs registers every enum found by the extractor.
+func RegisterEnumsFromDocs(extractor *DocExtractor) {
+	if extractor == nil {
+		return
+	}
+	for name, values := range extractor.Enums() {
+		RegisterEnumValues(name, values)
+	}
+}
+
+// enumValues returns the allowed values of t, if t is an enum.
+func enumValues(t reflect.Type) ([]string, bool) {
+	if t == nil || t.Name() == "" || t.PkgPath() == "" {
+		return nil, false
+	}
+	enums.RLock()
+	defer enums.RUnlock()
+	if values, ok := enums.byType[t]; ok {
+		return values, true
+	}
+	values, ok := enums.byName[t.PkgPath()+"."+t.Name()]
+	return values, ok
+}
+
+// formatEnumValue renders an enum value the way it appears in the schema.
+// Kinds are used rather than fmt so that String methods are ignored.
+func formatEnumValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	default:
+		return v.String()
+	}
+}
+
+// EnumTypeHandler generates schemas for enum types.
+type EnumTypeHandler struct{}
+
+// CanHandle returns true if t is a registered enum.
+func (e *EnumTypeHandler) CanHandle(t reflect.Type) bool {
+	_, ok := enumValues(t)
+	return ok
+}
+
+// GenerateSchema generates the schema of the underlying kind with the
+// allowed values.
+func (e *EnumTypeHandler) GenerateSchema(t reflect.Type, _ map[string]*Schema, _ bool) *Schema {
+	values, _ := enumValues(t)
+	schema := buildBasicTypeSchema(t)
+	schema.Enum = append([]string(nil), values...)
+	return schema
+}
+
+// enumViolations reports the fields of v, a section value, holding values
+// outside their enum, keyed by their path like validator errors:
+// section.field, section.items[2].field or section.labels[key].field.
+func enumViolations(v reflect.Value, path string, errs map[string][]string) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			enumViolations(v.Elem(), path, errs)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			enumViolations(v.Index(i), fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			enumViolations(iter.Value(), fmt.Sprintf("%s[%v]", path, iter.Key()), errs)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			fieldPath := path + "." + defaultTagNameFunc(field)
+			if field.Anonymous && parseGorkTag(field.Tag.Get("gork")).Name == "" {
+				// Promoted fields keep the path of the struct embedding them
+				fieldPath = path
+			}
+			fv := v.Field(i)
+			for fv.Kind() == reflect.Ptr && !fv.IsNil() {
+				fv = fv.Elem()
+			}
+			if allowed, ok := enumValues(fv.Type()); ok {
+				if !fv.IsZero() && !slices.Contains(allowed, formatEnumValue(fv)) {
+					errs[fieldPath] = append(errs[fieldPath], "enum")
+				}
+				continue
+			}
+			enumViolations(fv, fieldPath, errs)
+		}
+	}
+}
+
+// recordEnumType records a type declared with a basic underlying type, the
+// only types whose constants make up an enum.
+func (d *DocExtractor) recordEnumType(ts *ast.TypeSpec) {
+	ident, ok := ts.Type.(*ast.Ident)
+	if !ok || ts.Assign != 0 {
+		return
+	}
+	switch ident.Name {
+	case "string", "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64", "byte":
+		d.enumTypes[d.pkgPath+"."+ts.Name.Name] = true
+	}
+}
+
+// processConstDecl collects the typed constants of a const block,
+// evaluating literals, iota and arithmetic on earlier constants.
+func (d *DocExtractor) processConstDecl(decl *ast.GenDecl) {
+	var typ ast.Expr
+	var values []ast.Expr
+	for iota, spec := range decl.Specs {
+		vs, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+		// A spec without values repeats the type and values of the previous one.
+		if len(vs.Values) > 0 {
+			typ, values = vs.Type, vs.Values
+		}
+		for i, name := range vs.Names {
+			if i >= len(values) {
+				break
+			}
+			value := d.evalConst(values[i], iota)
+			if value == nil {
+				continue
+			}
+			d.constValues[d.pkgPath+"."+name.Name] = value
+			typeIdent, ok := typ.(*ast.Ident)
+			if !ok {
+				// Untyped constants converted explicitly, e.g. Status("x").
+				call, isCall := values[i].(*ast.CallExpr)
+				if !isCall {
+					continue
+				}
+				if typeIdent, ok = call.Fun.(*ast.Ident); !ok {
+					continue
+				}
+			}
+			if name.Name == "_" {
+				continue
+			}
+			key := d.pkgPath + "." + typeIdent.Name
+			text := value.ExactString()
+			if value.Kind() == constant.String {
+				text = constant.StringVal(value)
+			}
+			if !slices.Contains(d.enumConsts[key], text) {
+				d.enumConsts[key] = append(d.enumConsts[key], text)
+			}
+		}
+	}
+}
+
+// importPath returns the import path of the package named name in dir: the
+// module path of the nearest go.mod followed by dir's path below it. The
+// main package is "main", as reflect reports it, and packages outside a
+// module fall back to their name.
+func (d *DocExtractor) importPath(dir, name string) string {
+	if name == "main" {
+		return name
+	}
+	if path, ok := d.importPaths[dir]; ok {
+		return path
+	}
+	path := name
+	if abs, err := filepath.Abs(dir); err == nil {
+		for root := abs; ; root = filepath.Dir(root) {
+			if module := modulePath(filepath.Join(root, "go.mod")); module != "" {
+				rel, _ := filepath.Rel(root, abs)
+				path = strings.TrimSuffix(module+"/"+filepath.ToSlash(rel), "/.")
+				break
+			}
+			if filepath.Dir(root) == root {
+				break
+			}
+		}
+	}
+	if d.importPaths == nil {
+		d.importPaths = map[string]string{}
+	}
+	d.importPaths[dir] = path
+	return path
+}
+
+// modulePath returns the module path declared by the go.mod file, or ""
+// when it cannot be read.
+func modulePath(gomod string) string {
+	data, err := os.ReadFile(gomod) // #nosec G304
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if module, ok := strings.CutPrefix(strings.TrimSpace(line), "module"); ok {
+			module, _, _ = strings.Cut(module, "//")
+			return strings.Trim(strings.TrimSpace(module), `"`)
+		}
+	}
+	return ""
+}
+
+// evalConst evaluates a constant expression, returning nil when it is not
+// supported.
+func (d *DocExtractor) evalConst(expr ast.Expr, iota int) constant.Value {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		value := constant.MakeFromLiteral(e.Value, e.Kind, 0)
+		if value.Kind() == constant.Unknown {
+			return nil
+		}
+		return value
+	case *ast.Ident:
+		if e.Name == "iota" {
+			return constant.MakeInt64(int64(iota))
+		}
+		return d.constValues[d.pkgPath+"."+e.Name]
+	case *ast.ParenExpr:
+		return d.evalConst(e.X, iota)
+	case *ast.CallExpr:
+		if len(e.Args) == 1 {
+			return d.evalConst(e.Args[0], iota)
+		}
+	case *ast.UnaryExpr:
+		if x := d.evalConst(e.X, iota); x != nil {
+			return constant.UnaryOp(e.Op, x, 0)
+		}
+	case *ast.BinaryExpr:
+		x, y := d.evalConst(e.X, iota), d.evalConst(e.Y, iota)
+		if x == nil || y == nil {
+			return nil
+		}
+		switch e.Op {
+		case token.SHL, token.SHR:
+			if shift, ok := constant.Uint64Val(y); ok {
+				return constant.Shift(x, e.Op, uint(shift))
+			}
+		case token.QUO:
+			if x.Kind() == constant.Int && y.Kind() == constant.Int {
+				return constant.BinaryOp(x, token.QUO_ASSIGN, y)
+			}
+			return constant.BinaryOp(x, e.Op, y)
+		case token.ADD, token.SUB, token.MUL, token.REM, token.AND, token.OR, token.XOR:
+			return constant.BinaryOp(x, e.Op, y)
+		}
+	}
+	return nil
+}
+
+// Enums returns the enums found in the parsed sources: the constants
+// declared for every type with a string or integer underlying type, keyed
+// by "import/path.Type" and in declaration order. Import paths are derived
+// from the go.mod above the sources; without one the package name is used.
+func (d *DocExtractor) Enums() map[string][]string {
+	out := map[string][]string{}
+	for name, values := range d.enumConsts {
+		if d.enumTypes[name] {
+			out[name] = values
+		}
+	}
+	return out
+}
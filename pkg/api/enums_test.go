@@ -0,0 +1,167 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type enumTestStatus string
+
+type enumTestPriority int
+
+type enumTestRequest struct {
+	Query struct {
+		Status enumTestStatus `gork:"status"`
+	}
+	Body struct {
+		Priority enumTestPriority   `gork:"priority"`
+		History  []enumTestStatus   `gork:"history"`
+		Next     *enumTestStatus    `gork:"next"`
+		Tasks    []enumTestTaskBody `gork:"tasks"`
+	}
+}
+
+type enumTestTaskBody struct {
+	Status enumTestStatus `gork:"status"`
+}
+
+func init() {
+	RegisterEnum[enumTestStatus]("open", "closed")
+	RegisterEnum[enumTestPriority](1, 2, 3)
+}
+
+func TestDocExtractorEnums(t *testing.T) {
+	dir := t.TempDir()
+	src := `package orders
+
+type Status string
+
+const (
+	StatusOpen   Status = "open"
+	StatusClosed Status = "closed"
+	defaultLimit        = 10
+)
+
+type Level int
+
+const (
+	LevelLow Level = iota + 1
+	LevelMid
+	_
+	LevelHigh
+)
+
+const LevelMax = Level(LevelHigh * 2)
+
+type Flags uint8
+
+const (
+	FlagA Flags = 1 << iota
+	FlagB
+)
+
+type Money float64
+
+const Cent Money = 0.01
+`
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/shop\n\ngo 1.24\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "orders"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "orders", "orders.go"), []byte(src), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	extractor := NewDocExtractor()
+	if err := extractor.ParseDirectory(dir); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]string{
+		"example.com/shop/orders.Status": {"open", "closed"},
+		"example.com/shop/orders.Level":  {"1", "2", "4", "8"},
+		"example.com/shop/orders.Flags":  {"1", "2"},
+	}
+	if got := extractor.Enums(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Enums() = %v, want %v", got, want)
+	}
+}
+
+func TestEnumSchemas(t *testing.T) {
+	router, registry, _ := newLoadShedRouter()
+	router.Post("/tasks", func(context.Context, enumTestRequest) (*struct{}, error) { return nil, nil })
+	spec := GenerateOpenAPI(registry)
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"enum":["open","closed"],"type":"string"`,
+		`"priority":{"type":"integer","enum":[1,2,3]}`,
+		`"items":{"type":"string","enum":["open","closed"]}`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("spec is missing %s:\n%s", want, data)
+		}
+	}
+
+	var parsed OpenAPISpec
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		t.Fatal(err)
+	}
+	for _, schema := range parsed.Components.Schemas {
+		if priority := schema.Properties["priority"]; priority != nil && !reflect.DeepEqual(priority.Enum, []string{"1", "2", "3"}) {
+			t.Errorf("numeric enums must survive a round trip, got %v", priority.Enum)
+		}
+	}
+}
+
+func TestEnumValidation(t *testing.T) {
+	v := NewConventionValidator()
+	req := &enumTestRequest{}
+	req.Query.Status = "open"
+	req.Body.Priority = 2
+	req.Body.History = []enumTestStatus{"closed"}
+	if err := v.ValidateRequest(context.Background(), req); err != nil {
+		t.Fatalf("valid values must pass, got %v", err)
+	}
+
+	next := enumTestStatus("later")
+	req.Query.Status = "pending"
+	req.Body.Priority = 7
+	req.Body.Next = &next
+	req.Body.Tasks = []enumTestTaskBody{{Status: "done"}}
+	err := v.ValidateRequest(context.Background(), req)
+	var resp *ValidationErrorResponse
+	if !errors.As(err, &resp) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	want := map[string][]string{
+		"query.status":         {"enum"},
+		"body.priority":        {"enum"},
+		"body.next":            {"enum"},
+		"body.tasks[0].status": {"enum"},
+	}
+	if !reflect.DeepEqual(resp.Details, want) {
+		t.Errorf("Details = %v, want %v", resp.Details, want)
+	}
+}
+
+func TestRegisterEnumsFromDocs(t *testing.T) {
+	extractor := NewDocExtractor()
+	extractor.enumTypes["github.com/gork-labs/gork/pkg/api.enumTestDetected"] = true
+	extractor.enumConsts["github.com/gork-labs/gork/pkg/api.enumTestDetected"] = []string{"a", "b"}
+	RegisterEnumsFromDocs(extractor)
+
+	type enumTestDetected string
+	if values, ok := enumValues(reflect.TypeOf(enumTestDetected(""))); !ok || !reflect.DeepEqual(values, []string{"a", "b"}) {
+		t.Errorf("expected the detected enum to be registered by name, got %v %v", values, ok)
+	}
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+)
+
+// ErrorResponseMapping maps a domain error type returned by a handler to
+// the HTTP status code it is reported with.
+type ErrorResponseMapping struct {
+	Type   reflect.Type
+	Status int
+}
+
+// WithErrorResponse declares that the handler may return errors of type E
+// (matched with errors.As, so wrapped errors are found too). Such errors are
+// answered with status and the error value encoded as the JSON body, using
+// gork tags like response bodies. The generated OpenAPI operation documents
+// the status with E's schema.
+//
+//	router.Get("/users/{id}", GetUser, api.WithErrorResponse[*NotFoundError](http.StatusNotFound))
+func WithErrorResponse[E error](status int) Option {
+	t := reflect.TypeOf((*E)(nil)).Elem()
+	if t.Kind() == reflect.Interface {
+		panic(fmt.Sprintf("WithErrorResponse requires a concrete error type, got %s", t))
+	}
+	if status < 400 || status > 599 {
+		panic(fmt.Sprintf("WithErrorResponse requires a 4xx or 5xx status, got %d", status))
+	}
+	return func(h *HandlerOption) {
+		h.ErrorResponses = append(h.ErrorResponses, ErrorResponseMapping{Type: t, Status: status})
+	}
+}
+
+// matchErrorResponse returns the first mapping whose type err wraps,
+// together with the matching error value.
+func matchErrorResponse(err error, mappings []ErrorResponseMapping) (ErrorResponseMapping, interface{}, bool) {
+	for _, m := range mappings {
+		target := reflect.New(m.Type)
+		if errors.As(err, target.Interface()) {
+			return m, target.Elem().Interface(), true
+		}
+	}
+	return ErrorResponseMapping{}, nil, false
+}
+
+// writeHandlerError reports an error returned by a handler, using the
+// declared error responses before falling back to 500. Fields of declared
+// error bodies not visible to audience are left out.
+func (f *ConventionHandlerFactory) writeHandlerError(w http.ResponseWriter, err error, mappings []ErrorResponseMapping, audience string) {
+	m, value, ok := matchErrorResponse(err, mappings)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	data, marshalErr := f.gorkMarshaler(value)
+	if marshalErr == nil && hasAudienceFields(m.Type) {
+		data, marshalErr = filterAudience(data, reflect.ValueOf(value), audience)
+	}
+	if marshalErr != nil {
+		writeError(w, m.Status, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(m.Status)
+	_, _ = w.Write(data)
+}
+
+// addDeclaredErrorResponses documents the error responses declared with
+// WithErrorResponse. Several types sharing a status are documented as oneOf.
+func (g *ConventionOpenAPIGenerator) addDeclaredErrorResponses(route *RouteInfo, operation *Operation, components *Components) {
+	if route.Options == nil || len(route.Options.ErrorResponses) == 0 {
+		return
+	}
+	schemas := map[int][]*Schema{}
+	var order []int
+	for _, m := range route.Options.ErrorResponses {
+		t := m.Type
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if _, seen := schemas[m.Status]; !seen {
+			order = append(order, m.Status)
+		}
+		schemas[m.Status] = append(schemas[m.Status], g.generateSchemaFromType(t, "", components))
+	}
+	for _, status := range order {
+		schema := schemas[status][0]
+		if len(schemas[status]) > 1 {
+			schema = &Schema{OneOf: schemas[status]}
+		}
+		operation.Responses[strconv.Itoa(status)] = &Response{
+			Description: http.StatusText(status),
+			Content: map[string]*MediaType{
+				"application/json": {Schema: schema},
+			},
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type userNotFoundError struct {
+	ID string `gork:"id"`
+}
+
+func (e *userNotFoundError) Error() string { return "user " + e.ID + " not found" }
+
+type userConflictError struct {
+	Email string `gork:"email"`
+}
+
+func (e userConflictError) Error() string { return "email " + e.Email + " taken" }
+
+type userLockedError struct {
+	Reason string `gork:"reason"`
+}
+
+func (e *userLockedError) Error() string { return e.Reason }
+
+func TestErrorResponsesRuntime(t *testing.T) {
+	var returned error
+	handler := func(context.Context, loadShedRequest) (*loadShedResponse, error) {
+		return nil, returned
+	}
+	router, _, handlers := newLoadShedRouter()
+	router.Get("/users", handler,
+		WithErrorResponse[*userNotFoundError](http.StatusNotFound),
+		WithErrorResponse[userConflictError](http.StatusConflict))
+	h := handlers["GET /users"]
+
+	cases := []struct {
+		err  error
+		code int
+		body string
+	}{
+		{&userNotFoundError{ID: "u1"}, http.StatusNotFound, `{"id":"u1"}`},
+		{fmt.Errorf("lookup: %w", &userNotFoundError{ID: "u2"}), http.StatusNotFound, `{"id":"u2"}`},
+		{userConflictError{Email: "a@example.com"}, http.StatusConflict, `{"email":"a@example.com"}`},
+		{errors.New("boom"), http.StatusInternalServerError, `Internal Server Error`},
+	}
+	for _, tc := range cases {
+		returned = tc.err
+		w := httptest.NewRecorder()
+		h(w, httptest.NewRequest(http.MethodGet, "/api/users", nil))
+		if w.Code != tc.code || !strings.Contains(w.Body.String(), tc.body) {
+			t.Errorf("%v: expected %d %s, got %d %s", tc.err, tc.code, tc.body, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestErrorResponsesOpenAPI(t *testing.T) {
+	router, registry, _ := newLoadShedRouter()
+	router.Get("/users", func(context.Context, loadShedRequest) (*loadShedResponse, error) { return nil, nil },
+		WithErrorResponse[*userNotFoundError](http.StatusNotFound),
+		WithErrorResponse[userConflictError](http.StatusConflict),
+		WithErrorResponse[*userLockedError](http.StatusConflict))
+
+	spec := GenerateOpenAPI(registry)
+	responses := spec.Paths["/api/users"].Get.Responses
+	notFound := responses["404"]
+	if notFound == nil || notFound.Description != "Not Found" || notFound.Content["application/json"].Schema.Ref != "#/components/schemas/userNotFoundError" {
+		t.Errorf("unexpected 404 response %+v", notFound)
+	}
+	if spec.Components.Schemas["userNotFoundError"] == nil || spec.Components.Schemas["userNotFoundError"].Properties["id"] == nil {
+		t.Errorf("expected error schema component, got %v", spec.Components.Schemas["userNotFoundError"])
+	}
+	if conflict := responses["409"]; conflict == nil || len(conflict.Content["application/json"].Schema.OneOf) != 2 {
+		t.Errorf("expected 409 oneOf, got %+v", conflict)
+	}
+	if responses["500"] == nil || responses["400"] == nil {
+		t.Error("standard error responses must be kept")
+	}
+}
+
+func TestWithErrorResponseRejectsInvalidDeclarations(t *testing.T) {
+	for name, declare := range map[string]func(){
+		"interface": func() { WithErrorResponse[error](http.StatusNotFound) },
+		"status":    func() { WithErrorResponse[*userNotFoundError](http.StatusOK) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			declare()
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gork-labs/gork/pkg/gorkson"
+)
+
+// ETag returns a strong entity tag for v derived from its gork JSON
+// encoding, ready to be assigned to an ETag response header:
+//
+//	type GetUserResponse struct {
+//		Headers struct {
+//			ETag string `gork:"ETag"`
+//		}
+//		Body User
+//	}
+//
+//	resp.Headers.ETag = api.ETag(resp.Body)
+//
+// Responses declaring an ETag header opt into conditional GET handling: a
+// request whose If-None-Match lists the tag gets 304 Not Modified without a
+// body.
+func ETag(v any) string {
+	data, err := gorkson.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return `"` + base64.RawURLEncoding.EncodeToString(sum[:18]) + `"`
+}
+
+// hasETagHeader reports whether the Headers section of response type t
+// declares an ETag header.
+func hasETagHeader(t reflect.Type) bool {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return false
+	}
+	headers, ok := t.FieldByName(SectionHeaders)
+	if !ok || headers.Type.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < headers.Type.NumField(); i++ {
+		name := parseGorkTag(headers.Type.Field(i).Tag.Get("gork")).Name
+		if http.CanonicalHeaderKey(name) == "Etag" {
+			return true
+		}
+	}
+	return false
+}
+
+// notModified reports whether a GET or HEAD request's If-None-Match matches
+// etag, using the weak comparison of RFC 9110, section 13.1.2.
+func notModified(r *http.Request, etag string) bool {
+	if r == nil || etag == "" || (r.Method != http.MethodGet && r.Method != http.MethodHead) {
+		return false
+	}
+	for _, candidate := range strings.Split(r.Header.Get("If-None-Match"), ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// applyConditionalRequests documents If-None-Match and the 304 response of
+// GET routes whose response declares an ETag header.
+func applyConditionalRequests(route *RouteInfo, operation *Operation) {
+	if route.Method != http.MethodGet || !hasETagHeader(route.ResponseType) {
+		return
+	}
+	operation.Parameters = append(operation.Parameters, Parameter{
+		Name:        "If-None-Match",
+		In:          "header",
+		Description: "ETag of a cached representation; a match returns 304 Not Modified",
+		Schema:      &Schema{Type: "string"},
+	})
+	notModified := &Response{Description: "Not Modified - The cached representation is current"}
+	for _, resp := range operation.Responses {
+		for name, header := range resp.Headers {
+			if strings.EqualFold(name, "ETag") {
+				notModified.Headers = map[string]*Header{name: header}
+			}
+		}
+	}
+	operation.Responses["304"] = notModified
+}
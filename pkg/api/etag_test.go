@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type etagTestRequest struct {
+	Path struct {
+		ID string `gork:"id"`
+	}
+}
+
+type etagTestResponse struct {
+	Headers struct {
+		ETag string `gork:"ETag"`
+	}
+	Body struct {
+		Name string `gork:"name"`
+	}
+}
+
+func TestConditionalGet(t *testing.T) {
+	router, registry, handlers := newLoadShedRouter()
+	router.Get("/profiles/{id}", func(context.Context, etagTestRequest) (*etagTestResponse, error) {
+		resp := &etagTestResponse{}
+		resp.Body.Name = "Ann"
+		resp.Headers.ETag = ETag(resp.Body)
+		return resp, nil
+	})
+
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/api/profiles/1", nil)
+		if ifNoneMatch != "" {
+			r.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		handlers["GET /profiles/{id}"](w, r)
+		return w
+	}
+
+	first := get("")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || len(etag) < 3 || etag[0] != '"' {
+		t.Fatalf("expected 200 with a quoted ETag, got %d %q", first.Code, etag)
+	}
+	for _, header := range []string{etag, `"other", ` + etag, "W/" + etag, "*"} {
+		w := get(header)
+		if w.Code != http.StatusNotModified || w.Body.Len() != 0 || w.Header().Get("ETag") != etag {
+			t.Errorf("If-None-Match %s: got %d %q with ETag %q", header, w.Code, w.Body, w.Header().Get("ETag"))
+		}
+	}
+	if w := get(`"stale"`); w.Code != http.StatusOK || w.Body.Len() == 0 {
+		t.Errorf("stale tag: got %d %q", w.Code, w.Body)
+	}
+
+	op := GenerateOpenAPI(registry).Paths["/api/profiles/{id}"].Get
+	resp := op.Responses["304"]
+	if resp == nil || resp.Headers["ETag"] == nil || resp.Content != nil {
+		t.Errorf("expected a documented 304 with the ETag header, got %+v", resp)
+	}
+	found := false
+	for _, p := range op.Parameters {
+		found = found || (p.Name == "If-None-Match" && p.In == "header")
+	}
+	if !found {
+		t.Error("expected the If-None-Match parameter")
+	}
+}
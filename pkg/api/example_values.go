@@ -0,0 +1,67 @@
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+)
+
+// exampleValue converts an example written in a tag or doc comment to a value
+// of the field's JSON type, so that `example=42` on an int is documented as
+// 42 rather than "42". Composite types take JSON examples. Values that do not
+// parse are documented verbatim.
+func exampleValue(t reflect.Type, raw string) interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return raw
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(raw); err == nil {
+			return b
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			return i
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if u, err := strconv.ParseUint(raw, 10, 64); err == nil {
+			return u
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, err := strconv.ParseFloat(raw, 64); err == nil {
+			return f
+		}
+	default:
+		return jsonExampleValue(raw)
+	}
+	return raw
+}
+
+// jsonExampleValue decodes a JSON example, falling back to the raw string.
+func jsonExampleValue(raw string) interface{} {
+	var v interface{}
+	if err := json.Unmarshal([]byte(raw), &v); err == nil {
+		return v
+	}
+	return raw
+}
+
+// applyTagExample sets the example declared in a gork tag on a field schema.
+func applyTagExample(schema *Schema, t reflect.Type, raw string) {
+	if raw == "" || schema == nil {
+		return
+	}
+	schema.Example = exampleValue(t, raw)
+}
+
+// docExampleValue converts a doc comment example for a schema whose Go type
+// is no longer known: string schemas keep the text, anything else is read as
+// JSON.
+func docExampleValue(schema *Schema, raw string) interface{} {
+	if schema != nil && schema.Type == "string" {
+		return raw
+	}
+	return jsonExampleValue(raw)
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type exampleTagRequest struct {
+	Query struct {
+		Limit int `gork:"limit,example=25"`
+	}
+	Body struct {
+		Email  string `gork:"email,example=user@example.com"`
+		Active bool   `gork:"active,example=true"`
+	}
+}
+
+type exampleTagResponse struct {
+	Body struct {
+		Score float64 `gork:"score,example=9.5"`
+	}
+}
+
+func TestTagExamples(t *testing.T) {
+	router, registry, _ := newLoadShedRouter()
+	router.Post("/examples", func(context.Context, exampleTagRequest) (*exampleTagResponse, error) { return nil, nil })
+
+	spec := GenerateOpenAPI(registry)
+	op := spec.Paths["/api/examples"].Post
+	if len(op.Parameters) != 1 || op.Parameters[0].Example != int64(25) {
+		t.Errorf("expected typed query example, got %+v", op.Parameters)
+	}
+
+	body := componentSchema(spec, op.RequestBody.Content["application/json"].Schema)
+	if body.Properties["email"].Example != "user@example.com" || body.Properties["active"].Example != true {
+		t.Errorf("unexpected body examples %+v", body.Properties)
+	}
+	resp := componentSchema(spec, op.Responses["200"].Content["application/json"].Schema)
+	if resp.Properties["score"].Example != 9.5 {
+		t.Errorf("unexpected response example %v", resp.Properties["score"].Example)
+	}
+}
+
+func componentSchema(spec *OpenAPISpec, schema *Schema) *Schema {
+	if schema.Ref == "" {
+		return schema
+	}
+	return spec.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+}
+
+func TestExampleValue(t *testing.T) {
+	cases := []struct {
+		t    reflect.Type
+		raw  string
+		want interface{}
+	}{
+		{reflect.TypeOf(""), "42", "42"},
+		{reflect.TypeOf(0), "42", int64(42)},
+		{reflect.TypeOf(new(uint)), "7", uint64(7)},
+		{reflect.TypeOf(0), "many", "many"},
+		{reflect.TypeOf(map[string]int{}), `{"a":1}`, map[string]interface{}{"a": float64(1)}},
+	}
+	for _, tc := range cases {
+		if got := exampleValue(tc.t, tc.raw); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("exampleValue(%s, %q) = %#v, want %#v", tc.t, tc.raw, got, tc.want)
+		}
+	}
+}
+
+func TestDocCommentExamples(t *testing.T) {
+	dir := t.TempDir()
+	src := `package fixture
+
+// Address is a postal address.
+// Example: {"city":"Berlin"}
+type Address struct {
+	// City name.
+	// Example: Berlin
+	City string ` + "`gork:\"city\"`" + `
+	Zip int ` + "`gork:\"zip\"`" + ` // Example: 10115
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "fixture.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	extractor := NewDocExtractor()
+	if err := extractor.ParseDirectory(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	doc := extractor.ExtractTypeDoc("Address")
+	if doc.Description != "Address is a postal address." || doc.Example != `{"city":"Berlin"}` {
+		t.Errorf("unexpected type doc %+v", doc)
+	}
+	if doc.Fields["city"].Description != "City name." || doc.Fields["city"].Example != "Berlin" || doc.Fields["zip"].Example != "10115" {
+		t.Errorf("unexpected field docs %+v", doc.Fields)
+	}
+
+	spec := &OpenAPISpec{Components: &Components{Schemas: map[string]*Schema{
+		"Address": {Type: "object", Properties: map[string]*Schema{
+			"city": {Type: "string"},
+			"zip":  {Type: "integer", Example: int64(1)},
+		}},
+	}}}
+	EnhanceOpenAPISpecWithDocs(spec, extractor)
+	address := spec.Components.Schemas["Address"]
+	if !reflect.DeepEqual(address.Example, map[string]interface{}{"city": "Berlin"}) {
+		t.Errorf("unexpected schema example %#v", address.Example)
+	}
+	if address.Properties["city"].Example != "Berlin" {
+		t.Errorf("unexpected city example %#v", address.Properties["city"].Example)
+	}
+	if address.Properties["zip"].Example != int64(1) {
+		t.Error("tag examples take precedence over doc comments")
+	}
+}
@@ -0,0 +1,288 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/gork-labs/gork/pkg/gorkson"
+)
+
+// RequestExample is a named, fully populated request registered for an
+// operation via WithExample.
+type RequestExample struct {
+	Name    string
+	Request interface{}
+}
+
+// WithExample registers a named example request for the route. The same
+// value is published in the generated OpenAPI document (request body and
+// parameter examples) and can be turned into an *http.Request for tests via
+// NewExampleRequest, so documentation and tests share one definition.
+//
+// req must have the handler's request type; Register panics otherwise.
+func WithExample(name string, req interface{}) Option {
+	return func(h *HandlerOption) {
+		h.Examples = append(h.Examples, RequestExample{Name: name, Request: req})
+	}
+}
+
+// ResponseExample is a named, fully populated response registered for an
+// operation via WithResponseExample.
+type ResponseExample struct {
+	Name     string
+	Response interface{}
+}
+
+// WithResponseExample registers a named example response for the route. It
+// is published in the generated OpenAPI document under the status it is
+// sent with and served by NewMockServer, which answers requests matching
+// the request example of the same name with it.
+//
+// resp must have the handler's response type, or be a pointer to it;
+// Register panics otherwise.
+func WithResponseExample(name string, resp interface{}) Option {
+	return func(h *HandlerOption) {
+		h.ResponseExamples = append(h.ResponseExamples, ResponseExample{Name: name, Response: resp})
+	}
+}
+
+// Example returns the example registered under name for the route.
+func (info *RouteInfo) Example(name string) (RequestExample, bool) {
+	if info == nil || info.Options == nil {
+		return RequestExample{}, false
+	}
+	for _, ex := range info.Options.Examples {
+		if ex.Name == name {
+			return ex, true
+		}
+	}
+	return RequestExample{}, false
+}
+
+// validateRouteExamples ensures every registered example matches the
+// handler's request or response type and that names are unique.
+func validateRouteExamples(info *RouteInfo) {
+	if info.Options == nil {
+		return
+	}
+	seen := map[string]bool{}
+	for _, ex := range info.Options.Examples {
+		if seen[ex.Name] {
+			panic(fmt.Sprintf("duplicate example %q for %s %s", ex.Name, info.Method, info.Path))
+		}
+		seen[ex.Name] = true
+		if reflect.TypeOf(ex.Request) != info.RequestType {
+			panic(fmt.Sprintf("example %q for %s %s has type %T, want %s", ex.Name, info.Method, info.Path, ex.Request, info.RequestType))
+		}
+	}
+	seen = map[string]bool{}
+	for _, ex := range info.Options.ResponseExamples {
+		if seen[ex.Name] {
+			panic(fmt.Sprintf("duplicate response example %q for %s %s", ex.Name, info.Method, info.Path))
+		}
+		seen[ex.Name] = true
+		v := reflect.ValueOf(ex.Response)
+		if v.Kind() == reflect.Ptr && !v.IsNil() {
+			v = v.Elem()
+		}
+		want := info.ResponseType
+		if want != nil && want.Kind() == reflect.Ptr {
+			want = want.Elem()
+		}
+		if want == nil || !v.IsValid() || v.Type() != want {
+			panic(fmt.Sprintf("response example %q for %s %s has type %T, want %v", ex.Name, info.Method, info.Path, ex.Response, want))
+		}
+	}
+}
+
+// encode returns the status and JSON body ex is sent with.
+func (ex ResponseExample) encode() (int, json.RawMessage, error) {
+	v := reflect.Indirect(reflect.ValueOf(ex.Response))
+	status := responseStatus(v)
+	if status == 0 {
+		status = http.StatusOK
+	}
+	body := v.FieldByName(SectionBody)
+	if !body.IsValid() {
+		return status, nil, nil
+	}
+	data, err := gorkson.Marshal(body.Interface())
+	return status, data, err
+}
+
+// NewExampleRequest builds an *http.Request from the example registered
+// under name: path placeholders are substituted, the query string, headers
+// and cookies are populated and the Body section is encoded as JSON.
+func NewExampleRequest(info *RouteInfo, name string) (*http.Request, error) {
+	ex, ok := info.Example(name)
+	if !ok {
+		return nil, fmt.Errorf("no example %q registered for %s %s", name, info.Method, info.Path)
+	}
+
+	v := reflect.ValueOf(ex.Request)
+	path := info.Path
+	query := url.Values{}
+	var body io.Reader
+	var headers, cookies map[string]string
+
+	for i := 0; i < v.NumField(); i++ {
+		section := v.Field(i)
+		switch v.Type().Field(i).Name {
+		case SectionPath:
+			for k, val := range exampleParamValues(section) {
+				path = strings.ReplaceAll(path, "{"+k+"}", url.PathEscape(val))
+			}
+		case SectionQuery:
+			for k, val := range exampleParamValues(section) {
+				query.Set(k, val)
+			}
+		case SectionHeaders:
+			headers = exampleParamValues(section)
+		case SectionCookies:
+			cookies = exampleParamValues(section)
+		case SectionBody:
+			data, err := gorkson.Marshal(section.Interface())
+			if err != nil {
+				return nil, fmt.Errorf("encode example body: %w", err)
+			}
+			body = bytes.NewReader(data)
+		}
+	}
+
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(info.Method, path, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, val := range headers {
+		req.Header.Set(k, val)
+	}
+	for k, val := range cookies {
+		req.AddCookie(&http.Cookie{Name: k, Value: val})
+	}
+	return req, nil
+}
+
+// exampleParamFields returns the non-zero fields of a parameter section keyed
+// by their gork tag name.
+func exampleParamFields(section reflect.Value) map[string]reflect.Value {
+	out := map[string]reflect.Value{}
+	if section.Kind() != reflect.Struct {
+		return out
+	}
+	for i := 0; i < section.NumField(); i++ {
+		gorkTag := section.Type().Field(i).Tag.Get("gork")
+		if gorkTag == "" || section.Field(i).IsZero() {
+			continue
+		}
+		out[parseGorkTag(gorkTag).Name] = section.Field(i)
+	}
+	return out
+}
+
+// exampleParamValues renders the parameter fields of a section the same way
+// response headers are rendered.
+func exampleParamValues(section reflect.Value) map[string]string {
+	f := &ConventionHandlerFactory{}
+	out := map[string]string{}
+	for name, v := range exampleParamFields(section) {
+		out[name] = f.getStringValue(v)
+	}
+	return out
+}
+
+// applyResponseExamples attaches the route's named example responses to
+// the response of the status they are sent with.
+func applyResponseExamples(route *RouteInfo, operation *Operation) {
+	if route.Options == nil {
+		return
+	}
+	for _, ex := range route.Options.ResponseExamples {
+		status, body, err := ex.encode()
+		if err != nil {
+			continue
+		}
+		applyCapturedExample(operation, CapturedExample{Name: ex.Name, Status: status, ResponseBody: body})
+	}
+}
+
+// applyRequestExamples attaches the route's named examples to the request
+// body media types and parameters of the operation.
+func applyRequestExamples(route *RouteInfo, operation *Operation) {
+	if route.Options == nil {
+		return
+	}
+	for _, ex := range route.Options.Examples {
+		v := reflect.ValueOf(ex.Request)
+		if v.Kind() != reflect.Struct {
+			continue
+		}
+		for i := 0; i < v.NumField(); i++ {
+			section := v.Field(i)
+			switch name := v.Type().Field(i).Name; name {
+			case SectionBody:
+				addBodyExample(operation, ex.Name, section)
+			case SectionPath, SectionQuery, SectionHeaders, SectionCookies:
+				addParameterExamples(operation, sectionLocation(name), ex.Name, section)
+			}
+		}
+	}
+}
+
+// sectionLocation maps a request section name to the OpenAPI "in" value.
+func sectionLocation(section string) string {
+	switch section {
+	case SectionHeaders:
+		return "header"
+	case SectionCookies:
+		return "cookie"
+	default:
+		return strings.ToLower(section)
+	}
+}
+
+func addBodyExample(operation *Operation, name string, body reflect.Value) {
+	if operation.RequestBody == nil {
+		return
+	}
+	data, err := gorkson.Marshal(body.Interface())
+	if err != nil {
+		return
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return
+	}
+	for _, mt := range operation.RequestBody.Content {
+		if mt.Examples == nil {
+			mt.Examples = map[string]*Example{}
+		}
+		mt.Examples[name] = &Example{Value: value}
+	}
+}
+
+func addParameterExamples(operation *Operation, in, name string, section reflect.Value) {
+	fields := exampleParamFields(section)
+	for i := range operation.Parameters {
+		param := &operation.Parameters[i]
+		val, ok := fields[param.Name]
+		if param.In != in || !ok {
+			continue
+		}
+		if param.Examples == nil {
+			param.Examples = map[string]*Example{}
+		}
+		param.Examples[name] = &Example{Value: val.Interface()}
+	}
+}
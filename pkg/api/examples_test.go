@@ -0,0 +1,192 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type exampleOrderRequest struct {
+	Path struct {
+		OrderID string `gork:"order_id"`
+	}
+	Query struct {
+		Notify bool `gork:"notify"`
+		Limit  int  `gork:"limit"`
+	}
+	Headers struct {
+		Tenant string `gork:"X-Tenant"`
+	}
+	Body struct {
+		Item     string `gork:"item" validate:"required"`
+		Quantity int    `gork:"quantity"`
+	}
+}
+
+type exampleOrderResponse struct {
+	Body struct {
+		Summary string `gork:"summary"`
+	}
+}
+
+func happyPathOrder() exampleOrderRequest {
+	var req exampleOrderRequest
+	req.Path.OrderID = "ord-1"
+	req.Query.Notify = true
+	req.Query.Limit = 5
+	req.Headers.Tenant = "acme"
+	req.Body.Item = "widget"
+	req.Body.Quantity = 3
+	return req
+}
+
+func echoOrder(_ context.Context, req exampleOrderRequest) (*exampleOrderResponse, error) {
+	resp := &exampleOrderResponse{}
+	resp.Body.Summary = strings.Join([]string{req.Path.OrderID, req.Headers.Tenant, req.Body.Item}, "/")
+	return resp, nil
+}
+
+func TestWithExampleOpenAPI(t *testing.T) {
+	registry := NewRouteRegistry()
+	router := NewTypedRouter[*struct{}](nil, registry, "", nil, &mockTypedRouterAdapter{}, nil)
+	router.Put("/orders/{order_id}", echoOrder, WithExample("happy-path", happyPathOrder()))
+
+	op := GenerateOpenAPI(registry).Paths["/orders/{order_id}"].Put
+	ex := op.RequestBody.Content["application/json"].Examples["happy-path"]
+	if ex == nil {
+		t.Fatal("expected request body example")
+	}
+	body, ok := ex.Value.(map[string]interface{})
+	if !ok || body["item"] != "widget" || body["quantity"] != float64(3) {
+		t.Errorf("unexpected body example %#v", ex.Value)
+	}
+
+	want := map[string]interface{}{"order_id": "ord-1", "notify": true, "limit": 5, "X-Tenant": "acme"}
+	for _, p := range op.Parameters {
+		got := p.Examples["happy-path"]
+		if got == nil || got.Value != want[p.Name] {
+			t.Errorf("parameter %s: expected example %v, got %+v", p.Name, want[p.Name], got)
+		}
+	}
+}
+
+func TestNewExampleRequestRoundTrip(t *testing.T) {
+	registry := NewRouteRegistry()
+	var handler http.HandlerFunc
+	router := NewTypedRouter[*struct{}](nil, registry, "/api", nil,
+		&mockTypedRouterAdapter{pathParams: map[string]string{"order_id": "ord-1"}, queryParams: map[string]string{}},
+		func(_, _ string, h http.HandlerFunc, _ *RouteInfo) { handler = h })
+	router.Put("/orders/{order_id}", echoOrder, WithExample("happy-path", happyPathOrder()))
+
+	info := registry.GetRoutes()[0]
+	req, err := NewExampleRequest(info, "happy-path")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Method != http.MethodPut || req.URL.Path != "/api/orders/ord-1" {
+		t.Errorf("unexpected request line %s %s", req.Method, req.URL.Path)
+	}
+	if q := req.URL.Query(); q.Get("notify") != "true" || q.Get("limit") != "5" {
+		t.Errorf("unexpected query %v", q)
+	}
+	if req.Header.Get("X-Tenant") != "acme" {
+		t.Errorf("expected tenant header, got %q", req.Header.Get("X-Tenant"))
+	}
+
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+	data, _ := io.ReadAll(rec.Body)
+	if rec.Code != http.StatusOK || !strings.Contains(string(data), "ord-1/acme/widget") {
+		t.Errorf("unexpected response %d %s", rec.Code, data)
+	}
+
+	if _, err := NewExampleRequest(info, "missing"); err == nil {
+		t.Error("expected error for unknown example")
+	}
+}
+
+func TestWithExampleValidation(t *testing.T) {
+	router := NewTypedRouter[*struct{}](nil, NewRouteRegistry(), "", nil, &mockTypedRouterAdapter{}, nil)
+
+	assertPanics := func(name string, fn func()) {
+		t.Helper()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s: expected panic", name)
+			}
+		}()
+		fn()
+	}
+
+	assertPanics("wrong type", func() {
+		router.Put("/orders/{order_id}", echoOrder, WithExample("bad", &exampleOrderRequest{}))
+	})
+	assertPanics("wrong response type", func() {
+		router.Put("/orders/{order_id}", echoOrder, WithResponseExample("bad", exampleOrderRequest{}))
+	})
+	assertPanics("nil response", func() {
+		router.Put("/orders/{order_id}", echoOrder, WithResponseExample("nil", (*exampleOrderResponse)(nil)))
+	})
+	assertPanics("duplicate name", func() {
+		router.Put("/orders/{order_id}", echoOrder,
+			WithExample("dup", happyPathOrder()), WithExample("dup", happyPathOrder()))
+	})
+}
+
+func TestMockServer(t *testing.T) {
+	registry := NewRouteRegistry()
+	router := NewTypedRouter[*struct{}](nil, registry, "/api", nil, &mockTypedRouterAdapter{}, nil)
+	shipped := &exampleOrderResponse{}
+	shipped.Body.Summary = "shipped"
+	pending := exampleOrderResponse{}
+	pending.Body.Summary = "pending"
+	other := happyPathOrder()
+	other.Body.Item = "gadget"
+	router.Put("/orders/{order_id}", echoOrder,
+		WithExample("happy-path", happyPathOrder()), WithExample("other", other),
+		WithResponseExample("other", pending), WithResponseExample("happy-path", shipped))
+	router.Get("/orders", func(context.Context, struct{}) (*exampleOrderResponse, error) { return nil, nil })
+
+	op := GenerateOpenAPI(registry).Paths["/api/orders/{order_id}"].Put
+	if ex := op.Responses["200"].Content["application/json"].Examples["happy-path"]; ex == nil || ex.Value.(map[string]interface{})["summary"] != "shipped" {
+		t.Errorf("expected the response example in the spec, got %+v", ex)
+	}
+
+	server := NewMockServer(registry)
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+		return rec
+	}
+	route := registry.GetRoutes()[0]
+	req, err := NewExampleRequest(route, "happy-path")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec := serve(req); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "shipped") {
+		t.Errorf("expected the example matching the request, got %d %s", rec.Code, rec.Body)
+	}
+
+	req = httptest.NewRequest(http.MethodPut, "/api/orders/unknown", strings.NewReader(`{"item":"x"}`))
+	if rec := serve(req); !strings.Contains(rec.Body.String(), "pending") {
+		t.Errorf("expected the first example for unmatched requests, got %s", rec.Body)
+	}
+	req.Header.Set("Prefer", "example=happy-path")
+	if rec := serve(req); !strings.Contains(rec.Body.String(), "shipped") {
+		t.Errorf("expected the preferred example, got %s", rec.Body)
+	}
+	req.Header.Set("Prefer", "example=missing")
+	if rec := serve(req); rec.Code != http.StatusNotImplemented {
+		t.Errorf("expected 501 for an unknown preferred example, got %d", rec.Code)
+	}
+	if rec := serve(httptest.NewRequest(http.MethodGet, "/api/orders", nil)); rec.Code != http.StatusNotImplemented {
+		t.Errorf("expected 501 without example responses, got %d", rec.Code)
+	}
+	if data, _ := json.Marshal(&Example{Value: 0}); string(data) != `{"value":0}` {
+		t.Errorf("zero example values must be kept, got %s", data)
+	}
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gork-labs/gork/pkg/gorkson"
+	"github.com/gork-labs/gork/pkg/unions"
+)
+
+type expandedCustomer struct {
+	ID    string `gork:"id" validate:"required"`
+	Email string `gork:"email"`
+}
+
+type chargeRequest struct {
+	Body struct {
+		Customer unions.Union2[string, expandedCustomer] `gork:"customer" validate:"required"`
+	}
+}
+
+type chargeResponse struct {
+	Body struct {
+		Customer unions.Union2[string, expandedCustomer] `gork:"customer"`
+	}
+}
+
+func TestStringOrObjectUnion(t *testing.T) {
+	registry := NewRouteRegistry()
+	handlers := map[string]http.HandlerFunc{}
+	router := NewTypedRouter[*struct{}](nil, registry, "/api", nil, &DefaultParameterAdapter{}, func(method, path string, h http.HandlerFunc, _ *RouteInfo) {
+		handlers[method+" "+path] = h
+	})
+	router.Post("/charges", func(_ context.Context, req chargeRequest) (*chargeResponse, error) {
+		resp := &chargeResponse{}
+		resp.Body.Customer = req.Body.Customer
+		return resp, nil
+	})
+
+	for body, want := range map[string]string{
+		`{"customer":"cus_1"}`:                                `{"customer":"cus_1"}`,
+		`{"customer":{"id":"cus_1","email":"a@example.com"}}`: `{"customer":{"email":"a@example.com","id":"cus_1"}}`,
+	} {
+		rec := httptest.NewRecorder()
+		handlers["POST /charges"](rec, httptest.NewRequest(http.MethodPost, "/api/charges", strings.NewReader(body)))
+		if rec.Code != http.StatusOK || strings.TrimSpace(rec.Body.String()) != want {
+			t.Errorf("%s: expected 200 %s, got %d %s", body, want, rec.Code, rec.Body.String())
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	handlers["POST /charges"](rec, httptest.NewRequest(http.MethodPost, "/api/charges", strings.NewReader(`{"customer":42}`)))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a number, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	spec := GenerateOpenAPI(registry)
+	data, _ := json.Marshal(spec.Components.Schemas)
+	var customer *Schema
+	for _, schema := range spec.Components.Schemas {
+		if prop := schema.Properties["customer"]; prop != nil && len(prop.OneOf) > 0 {
+			customer = prop
+		}
+	}
+	if customer == nil {
+		t.Fatalf("expected a oneOf customer property, got %s", data)
+	}
+	if len(customer.OneOf) != 2 || customer.OneOf[0].Type != "string" || !strings.HasSuffix(customer.OneOf[1].Ref, "/expandedCustomer") {
+		t.Errorf("expected oneOf string and a reference, got %s", data)
+	}
+}
+
+func TestStringOrObjectUnionGorkson(t *testing.T) {
+	var resp chargeResponse
+	id := "cus_1"
+	resp.Body.Customer.A = &id
+	data, err := gorkson.Marshal(resp.Body)
+	if err != nil || string(data) != `{"customer":"cus_1"}` {
+		t.Fatalf("unexpected encoding %s (%v)", data, err)
+	}
+	if err := gorkson.Unmarshal([]byte(`{"customer":{"id":"cus_2"}}`), &resp.Body); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Body.Customer.A != nil || resp.Body.Customer.B == nil || resp.Body.Customer.B.ID != "cus_2" {
+		t.Errorf("expected the expanded variant, got %+v", resp.Body.Customer)
+	}
+}
+
+type patchCustomerRequest struct {
+	Body struct {
+		Nickname unions.Optional[string]                                  `gork:"nickname"`
+		Customer unions.Optional[unions.Union2[string, expandedCustomer]] `gork:"customer"`
+	}
+}
+
+func TestOptionalSchema(t *testing.T) {
+	registry := NewRouteRegistry()
+	router := NewTypedRouter[*struct{}](nil, registry, "/api", nil, &DefaultParameterAdapter{}, func(string, string, http.HandlerFunc, *RouteInfo) {})
+	router.Patch("/customers", func(_ context.Context, _ patchCustomerRequest) (*struct{}, error) {
+		return nil, nil
+	})
+
+	spec := GenerateOpenAPI(registry)
+	data, _ := json.Marshal(spec.Components.Schemas)
+	var body *Schema
+	for _, schema := range spec.Components.Schemas {
+		if schema.Properties["nickname"] != nil {
+			body = schema
+		}
+	}
+	if body == nil {
+		t.Fatalf("expected the request body schema, got %s", data)
+	}
+	if types := body.Properties["nickname"].Types; len(types) != 2 || types[0] != "string" || types[1] != "null" {
+		t.Errorf("expected nickname to be a nullable string, got %s", data)
+	}
+	customer := body.Properties["customer"]
+	if customer == nil || len(customer.OneOf) != 3 || customer.OneOf[0].Type != "string" || customer.OneOf[2].Type != "null" {
+		t.Errorf("expected customer to be oneOf string, a reference and null, got %s", data)
+	}
+}
@@ -0,0 +1,277 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ExplainEntry is a single decision recorded while generating a spec.
+type ExplainEntry struct {
+	Operation string `json:"operation,omitempty"` // "METHOD /path" that triggered the decision
+	Subject   string `json:"subject"`             // Component name, Type.field or Go type
+	Detail    string `json:"detail"`
+}
+
+// ExplainReport records why the generator produced the output it did: how
+// components were named, which validate rules became schema constraints,
+// which types were inlined and which doc comments could not be found.
+type ExplainReport struct {
+	Components  []ExplainEntry `json:"components"`
+	Constraints []ExplainEntry `json:"constraints"`
+	Inline      []ExplainEntry `json:"inline"`
+	MissingDocs []ExplainEntry `json:"missingDocs"`
+
+	operation string
+	seen      map[string]bool
+}
+
+// WithExplain makes GenerateOpenAPI record its decisions into report.
+func WithExplain(report *ExplainReport) OpenAPIOption {
+	return func(spec *OpenAPISpec) {
+		spec.explain = report
+	}
+}
+
+func (r *ExplainReport) add(list *[]ExplainEntry, subject, format string, args ...interface{}) {
+	if r == nil {
+		return
+	}
+	if r.seen == nil {
+		r.seen = map[string]bool{}
+	}
+	// Component names are global; everything else is reported per operation.
+	key := fmt.Sprintf("%p|%s|%s", list, r.operation, subject)
+	if list == &r.Components {
+		key = "component|" + subject
+	}
+	if r.seen[key] {
+		return
+	}
+	r.seen[key] = true
+	*list = append(*list, ExplainEntry{Operation: r.operation, Subject: subject, Detail: fmt.Sprintf(format, args...)})
+}
+
+func (r *ExplainReport) component(name, format string, args ...interface{}) {
+	if r != nil {
+		r.add(&r.Components, name, format, args...)
+	}
+}
+
+// renameComponent moves the entries of the component old to its final name.
+func (r *ExplainReport) renameComponent(old, name string) {
+	if r == nil {
+		return
+	}
+	for i := range r.Components {
+		if r.Components[i].Subject == old {
+			r.Components[i].Subject = name
+			r.Components[i].Detail += "; renamed from " + old + " to keep names independent of generation order"
+		}
+	}
+	if r.seen != nil {
+		delete(r.seen, "component|"+old)
+		r.seen["component|"+name] = true
+	}
+}
+
+func (r *ExplainReport) inline(subject, format string, args ...interface{}) {
+	if r != nil {
+		r.add(&r.Inline, subject, format, args...)
+	}
+}
+
+// explainComponentName describes why a component registered while
+// generating t ended up with name.
+func explainComponentName(name string, t reflect.Type) string {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if override, ok := schemaNameOverride(t); ok && override == name {
+		return fmt.Sprintf("set with api.NameSchema for %s", t)
+	}
+	base := sanitizeSchemaName(t.Name())
+	switch {
+	case base == "":
+		return fmt.Sprintf("named type reached from %s", t)
+	case name == base:
+		return fmt.Sprintf("Go type name of %s", t)
+	case strings.HasPrefix(name, toPascalCase(lastPathComponent(t.PkgPath()))) && strings.Contains(name, base):
+		return fmt.Sprintf("%s was already taken; package prefix added for %s", base, t)
+	default:
+		return fmt.Sprintf("named type reached from %s", t)
+	}
+}
+
+// recordNewComponents reports every component that appeared in components
+// since before was taken.
+func (r *ExplainReport) recordNewComponents(before map[string]bool, components *Components, t reflect.Type) {
+	if r == nil {
+		return
+	}
+	for _, name := range addedComponents(before, components) {
+		r.component(name, "%s", explainComponentName(name, t))
+	}
+}
+
+// recordOperationComponents reports components added while generating an
+// operation that no more specific step accounted for, such as named types
+// reached through struct fields.
+func (r *ExplainReport) recordOperationComponents(before map[string]bool, components *Components) {
+	for _, name := range addedComponents(before, components) {
+		r.component(name, "Go type name of a type referenced by this operation")
+	}
+}
+
+// addedComponents returns the sorted names of components missing from before.
+func addedComponents(before map[string]bool, components *Components) []string {
+	var added []string
+	for name := range components.Schemas {
+		if !before[name] {
+			added = append(added, name)
+		}
+	}
+	sort.Strings(added)
+	return added
+}
+
+func componentNames(components *Components) map[string]bool {
+	names := make(map[string]bool, len(components.Schemas))
+	for name := range components.Schemas {
+		names[name] = true
+	}
+	return names
+}
+
+// explainConstraints records how the validate tags of every field reachable
+// from t map onto schema keywords.
+func (r *ExplainReport) explainConstraints(owner string, t reflect.Type, visited map[reflect.Type]bool) {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || visited[t] {
+		return
+	}
+	visited[t] = true
+	if t.Name() != "" {
+		owner = t.Name()
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		subject := owner + "." + field.Name
+		if tag := field.Tag.Get("validate"); tag != "" {
+			for _, rule := range strings.Split(tag, ",") {
+				r.add(&r.Constraints, subject+" "+rule, "%s", describeValidationRule(rule, field.Type))
+			}
+		}
+		ft := field.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct && ft.Name() == "" && !isUnionType(ft) {
+			r.inline(subject, "anonymous struct is rendered as an inline object schema")
+		}
+		r.explainConstraints(subject, field.Type, visited)
+	}
+}
+
+// describeValidationRule reports the schema keywords a single validate rule
+// produces for a field of type t.
+func describeValidationRule(rule string, t reflect.Type) string {
+	if rule == "required" {
+		return "added to the parent schema's required list"
+	}
+	key, val := parseValidationRule(rule)
+	s := &Schema{}
+	applyValidationRule(s, key, val, t)
+
+	data, _ := json.Marshal(s)
+	var keywords map[string]interface{}
+	_ = json.Unmarshal(data, &keywords)
+	if len(keywords) == 0 {
+		return "not represented in the schema (runtime validation only)"
+	}
+	parts := make([]string, 0, len(keywords))
+	for k, v := range keywords {
+		encoded, _ := json.Marshal(v)
+		parts = append(parts, k+"="+string(encoded))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
+
+// AddMissingDocs records operations and component schemas that still have
+// no description after doc comments were applied to spec.
+func (r *ExplainReport) AddMissingDocs(spec *OpenAPISpec) {
+	if r == nil || spec == nil {
+		return
+	}
+	r.operation = ""
+
+	paths := make([]string, 0, len(spec.Paths))
+	for p := range spec.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		item := spec.Paths[p]
+		for _, m := range []struct {
+			method string
+			op     *Operation
+		}{{"GET", item.Get}, {"POST", item.Post}, {"PUT", item.Put}, {"PATCH", item.Patch}, {"DELETE", item.Delete}} {
+			if m.op != nil && m.op.Description == "" {
+				r.add(&r.MissingDocs, m.method+" "+p, "no doc comment found for handler %s", m.op.OperationID)
+			}
+		}
+	}
+
+	if spec.Components == nil {
+		return
+	}
+	names := make([]string, 0, len(spec.Components.Schemas))
+	for name := range spec.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if spec.Components.Schemas[name].Description == "" {
+			r.add(&r.MissingDocs, name, "no doc comment found for the type behind this component")
+		}
+	}
+}
+
+// WriteText renders the report as plain text grouped by section.
+func (r *ExplainReport) WriteText(w io.Writer) error {
+	sections := []struct {
+		title   string
+		entries []ExplainEntry
+	}{
+		{"Component names", r.Components},
+		{"Validation constraints", r.Constraints},
+		{"Inline schemas", r.Inline},
+		{"Missing doc comments", r.MissingDocs},
+	}
+	var b strings.Builder
+	for i, s := range sections {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%s (%d)\n", s.title, len(s.entries))
+		for _, e := range s.entries {
+			fmt.Fprintf(&b, "  %s: %s", e.Subject, e.Detail)
+			if e.Operation != "" {
+				fmt.Fprintf(&b, " [%s]", e.Operation)
+			}
+			b.WriteString("\n")
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
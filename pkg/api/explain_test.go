@@ -0,0 +1,156 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type ExplainAddress struct {
+	City string `gork:"city" validate:"required,max=64"`
+}
+
+type ExplainCreateRequest struct {
+	Body struct {
+		Name    string         `gork:"name" validate:"required,min=2,email,lowercase"`
+		Address ExplainAddress `gork:"address"`
+		Meta    struct {
+			Source string `gork:"source"`
+		} `gork:"meta"`
+	}
+}
+
+type ExplainCreateResponse struct {
+	Body struct {
+		ID string `gork:"id"`
+	}
+}
+
+func explainCreate(_ context.Context, _ ExplainCreateRequest) (*ExplainCreateResponse, error) {
+	return &ExplainCreateResponse{}, nil
+}
+
+func findExplainEntry(entries []ExplainEntry, subject string) *ExplainEntry {
+	for i := range entries {
+		if entries[i].Subject == subject {
+			return &entries[i]
+		}
+	}
+	return nil
+}
+
+func newExplainRegistry() *RouteRegistry {
+	registry := NewRouteRegistry()
+	router := NewTypedRouter[*struct{}](nil, registry, "", nil, &mockTypedRouterAdapter{}, nil)
+	router.Post("/things", explainCreate)
+	return registry
+}
+
+func TestWithExplainRecordsDecisions(t *testing.T) {
+	report := &ExplainReport{}
+	spec := GenerateOpenAPI(newExplainRegistry(), WithExplain(report))
+
+	body := findExplainEntry(report.Components, "ExplainCreateBody")
+	if body == nil || !strings.Contains(body.Detail, "ExplainCreateRequest") {
+		t.Errorf("expected body component explanation, got %+v", report.Components)
+	}
+	if body != nil && body.Operation != "POST /things" {
+		t.Errorf("expected operation context, got %q", body.Operation)
+	}
+	if addr := findExplainEntry(report.Components, "ExplainAddress"); addr == nil {
+		t.Errorf("expected nested component explanation, got %+v", report.Components)
+	}
+
+	cases := map[string]string{
+		"ExplainCreateRequest.Body.Name min=2":     `minLength=2`,
+		"ExplainCreateRequest.Body.Name email":     `format="email"`,
+		"ExplainCreateRequest.Body.Name lowercase": "not represented",
+		"ExplainAddress.City max=64":               `maxLength=64`,
+		"ExplainCreateRequest.Body.Name required":  "required list",
+	}
+	for subject, want := range cases {
+		e := findExplainEntry(report.Constraints, subject)
+		if e == nil || !strings.Contains(e.Detail, want) {
+			t.Errorf("%s: expected detail containing %q, got %+v", subject, want, e)
+		}
+	}
+
+	if findExplainEntry(report.Inline, "ExplainCreateRequest.Body.Meta") == nil {
+		t.Errorf("expected anonymous struct to be reported as inline, got %+v", report.Inline)
+	}
+
+	report.AddMissingDocs(spec)
+	if findExplainEntry(report.MissingDocs, "POST /things") == nil {
+		t.Errorf("expected missing handler doc, got %+v", report.MissingDocs)
+	}
+
+	var out bytes.Buffer
+	if err := report.WriteText(&out); err != nil {
+		t.Fatal(err)
+	}
+	for _, section := range []string{"Component names", "Validation constraints", "Inline schemas", "Missing doc comments"} {
+		if !strings.Contains(out.String(), section) {
+			t.Errorf("expected section %q in\n%s", section, out.String())
+		}
+	}
+}
+
+func TestGenerateOpenAPIWithoutExplain(t *testing.T) {
+	spec := GenerateOpenAPI(newExplainRegistry())
+	if spec.explain != nil {
+		t.Error("explain report should only be set via WithExplain")
+	}
+}
+
+func TestExportWritesExplainReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "explain.json")
+	t.Setenv("GORK_EXPLAIN", path)
+
+	var out bytes.Buffer
+	config := ExportConfig{Output: &out, ExitFunc: func(int) {}, LogFatalf: func(string, ...interface{}) {}}
+	if err := exportOpenAPISpec(newExplainRegistry(), config); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report ExplainReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Components) == 0 || len(report.Constraints) == 0 {
+		t.Errorf("expected recorded decisions, got %+v", report)
+	}
+}
+
+func TestExportWritesGenerationErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.json")
+	t.Setenv("GORK_ERRORS", path)
+
+	router, registry, _ := newLoadShedRouter()
+	router.Get("/users", func(context.Context, loadShedRequest) (*MalformedTestUserResponse, error) { return nil, nil })
+
+	var out bytes.Buffer
+	config := ExportConfig{Output: &out, ExitFunc: func(int) {}, LogFatalf: func(string, ...interface{}) {}}
+	if err := exportOpenAPISpec(registry, config); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var genErrs GenerationError
+	if err := json.Unmarshal(data, &genErrs); err != nil {
+		t.Fatal(err)
+	}
+	if len(genErrs.Routes) != 1 || genErrs.Routes[0].Operation != "GET /api/users" {
+		t.Errorf("expected the malformed route, got %+v", genErrs)
+	}
+}
@@ -39,8 +39,84 @@ func SetExportConfig(config ExportConfig) {
 
 // exportOpenAPISpec generates and writes the OpenAPI spec using the provided configuration.
 func exportOpenAPISpec(registry *RouteRegistry, config ExportConfig, opts ...OpenAPIOption) error {
+	// GORK_CLIENT selects a client language; the client generated from the
+	// registry is written instead of the spec. It is set by
+	// `gork openapi client`.
+	if lang := os.Getenv("GORK_CLIENT"); lang != "" {
+		cfg := ClientConfig{Lang: lang, Package: os.Getenv("GORK_CLIENT_PACKAGE")}
+		if err := GenerateClient(registry, cfg, config.Output); err != nil {
+			config.LogFatalf("failed to generate client: %v", err)
+			return err
+		}
+		return nil
+	}
+
+	// GORK_EXPLAIN names a file that receives the generator's explain
+	// report; it is set by `gork openapi generate --explain`.
+	explainPath := os.Getenv("GORK_EXPLAIN")
+	var report *ExplainReport
+	if explainPath != "" {
+		report = &ExplainReport{}
+		opts = append(opts, WithExplain(report))
+	}
+
+	// GORK_INTERNAL includes the routes registered with WithInternal; it
+	// is set by `gork openapi generate --internal-output`.
+	if os.Getenv("GORK_INTERNAL") != "" {
+		opts = append(opts, WithIncludeInternal(true))
+	}
+
+	// GORK_ERRORS switches to error-collect mode and names a file that
+	// receives the routes left out of the spec; it is set by
+	// `gork openapi generate` to report every offending route at once.
+	errorsPath := os.Getenv("GORK_ERRORS")
+	var genErrs *GenerationError
+	if errorsPath != "" {
+		genErrs = &GenerationError{}
+		opts = append(opts, WithGenerationErrors(genErrs))
+	}
+
+	// GORK_ENUMS names a file with the enums detected in the sources, keyed
+	// by "import/path.Type"; it is set by `gork openapi generate --source`.
+	if enumsPath := os.Getenv("GORK_ENUMS"); enumsPath != "" {
+		var detected map[string][]string
+		data, err := os.ReadFile(enumsPath) // #nosec G304
+		if err == nil {
+			err = json.Unmarshal(data, &detected)
+		}
+		if err != nil {
+			config.LogFatalf("failed to read enums: %v", err)
+			return err
+		}
+		for name, values := range detected {
+			RegisterEnumValues(name, values)
+		}
+	}
+
 	spec := GenerateOpenAPI(registry, opts...)
 
+	if genErrs != nil {
+		data, err := json.Marshal(genErrs)
+		if err == nil {
+			err = os.WriteFile(errorsPath, data, 0o600)
+		}
+		if err != nil {
+			config.LogFatalf("failed to write generation errors: %v", err)
+			return err
+		}
+	}
+
+	if report != nil {
+		data, err := json.Marshal(report)
+		if err == nil {
+			err = os.WriteFile(explainPath, data, 0o600)
+		}
+		if err != nil {
+			config.LogFatalf("failed to write explain report: %v", err)
+			return err
+		}
+	}
+
 	enc := json.NewEncoder(config.Output)
 	enc.SetIndent("", "  ")
 	if err := enc.Encode(spec); err != nil {
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// WithExtension adds the vendor extension name with value to the route's
+// operation. Fields add extensions to their parameter or property with
+// x- options of the gork tag (`gork:"id,x-internal"`, `gork:"id,x-order=1"`).
+// name must start with "x-".
+//
+//	router.Get("/debug/vars", DebugVars, api.WithExtension("x-internal", true))
+func WithExtension(name string, value interface{}) Option {
+	if !strings.HasPrefix(name, "x-") {
+		panic(fmt.Sprintf("WithExtension requires a name starting with x-, got %q", name))
+	}
+	return func(h *HandlerOption) {
+		if h.Extensions == nil {
+			h.Extensions = map[string]interface{}{}
+		}
+		h.Extensions[name] = value
+	}
+}
+
+// applyExtensions adds the extensions declared with WithExtension.
+func applyExtensions(route *RouteInfo, operation *Operation) {
+	if route.Options == nil || len(route.Options.Extensions) == 0 {
+		return
+	}
+	if operation.Extensions == nil {
+		operation.Extensions = map[string]interface{}{}
+	}
+	for name, value := range route.Options.Extensions {
+		operation.Extensions[name] = value
+	}
+}
+
+// tagExtensions returns the vendor extensions of a gork tag. Values that
+// are JSON, such as true or 1, are documented as such; others as strings.
+func tagExtensions(tagInfo GorkTagInfo) map[string]interface{} {
+	if len(tagInfo.Extensions) == 0 {
+		return nil
+	}
+	extensions := make(map[string]interface{}, len(tagInfo.Extensions))
+	for name, raw := range tagInfo.Extensions {
+		var value interface{}
+		if err := json.Unmarshal([]byte(raw), &value); err != nil {
+			value = raw
+		}
+		extensions[name] = value
+	}
+	return extensions
+}
+
+// withExtensions adds extensions as top-level keys of the JSON object data.
+func withExtensions(data []byte, extensions map[string]interface{}) ([]byte, error) {
+	if len(extensions) == 0 {
+		return data, nil
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	for name, value := range extensions {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		object[name] = raw
+	}
+	return json.Marshal(object)
+}
+
+// extensionsOf returns the x- keys of the JSON object data except those
+// decoded into fields, or nil when there are none.
+func extensionsOf(data []byte, fields ...string) (map[string]interface{}, error) {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	var extensions map[string]interface{}
+	for name, raw := range object {
+		if !strings.HasPrefix(name, "x-") || slices.Contains(fields, name) {
+			continue
+		}
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.UseNumber()
+		var value interface{}
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+		if extensions == nil {
+			extensions = map[string]interface{}{}
+		}
+		extensions[name] = value
+	}
+	return extensions, nil
+}
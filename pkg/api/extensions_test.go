@@ -0,0 +1,62 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestExtensionsOpenAPI(t *testing.T) {
+	type listItemsRequest struct {
+		Query struct {
+			Cursor string `gork:"cursor,x-internal"`
+		}
+		Body struct {
+			Name  string `gork:"name,x-order=1,x-label=Item name"`
+			Count int    `gork:"count"`
+		}
+	}
+	router, registry, _ := newLoadShedRouter()
+	router.Post("/items", func(context.Context, listItemsRequest) (*loadShedResponse, error) { return nil, nil },
+		WithExtension("x-internal", true), WithExtension("x-rate-tier", "gold"))
+
+	spec := GenerateOpenAPI(registry)
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var decoded OpenAPISpec
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	op := decoded.Paths["/api/items"].Post
+	if want := map[string]interface{}{"x-internal": true, "x-rate-tier": "gold"}; !reflect.DeepEqual(op.Extensions, want) {
+		t.Errorf("operation extensions = %v, want %v", op.Extensions, want)
+	}
+	if len(op.Parameters) != 1 || !reflect.DeepEqual(op.Parameters[0].Extensions, map[string]interface{}{"x-internal": true}) {
+		t.Errorf("parameter extensions = %+v", op.Parameters)
+	}
+
+	body := resolveComponentSchema(op.RequestBody.Content["application/json"].Schema, decoded.Components)
+	if body == nil {
+		t.Fatal("request body schema not found")
+	}
+	name := body.Properties["name"].Extensions
+	if name["x-order"] != json.Number("1") || name["x-label"] != "Item name" {
+		t.Errorf("property extensions = %v", name)
+	}
+	if ext := body.Properties["count"].Extensions; ext != nil {
+		t.Errorf("property without x- options has extensions %v", ext)
+	}
+}
+
+func TestWithExtensionRequiresPrefix(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a name without the x- prefix")
+		}
+	}()
+	WithExtension("internal", true)
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type renameRequest struct {
+	Query struct {
+		PageSize int `gork:"page_size,alias=limit"`
+	}
+	Body struct {
+		DisplayName string `gork:"display_name,alias=name" validate:"required"`
+	}
+}
+
+type renameResponse struct {
+	Body struct {
+		DisplayName string `gork:"display_name,alias=name"`
+		PageSize    int    `gork:"page_size"`
+	}
+}
+
+func renameHandler(_ context.Context, req renameRequest) (*renameResponse, error) {
+	resp := &renameResponse{}
+	resp.Body.DisplayName = req.Body.DisplayName
+	resp.Body.PageSize = req.Query.PageSize
+	return resp, nil
+}
+
+func TestFieldAliasParsing(t *testing.T) {
+	factory := NewConventionHandlerFactory()
+	handler, _ := factory.CreateHandler(&mockTypedRouterAdapter{queryParams: map[string]string{"limit": "25"}}, renameHandler)
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":"Jane"}`)))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if got := rec.Body.String(); got != `{"display_name":"Jane","page_size":25}` {
+		t.Errorf("unexpected response %s", got)
+	}
+}
+
+func TestFieldAliasOpenAPI(t *testing.T) {
+	registry := NewRouteRegistry()
+	registry.Register(&RouteInfo{
+		Method:       "POST",
+		Path:         "/users",
+		HandlerName:  "Rename",
+		RequestType:  reflect.TypeOf(renameRequest{}),
+		ResponseType: reflect.TypeOf(&renameResponse{}),
+	})
+	spec := GenerateOpenAPI(registry)
+	op := spec.Paths["/users"].Post
+
+	if len(op.Parameters) != 2 {
+		t.Fatalf("expected current and aliased query parameters, got %+v", op.Parameters)
+	}
+	if p := op.Parameters[1]; p.Name != "limit" || !p.Deprecated || p.Required {
+		t.Errorf("unexpected alias parameter %+v", p)
+	}
+
+	body := spec.Components.Schemas["renameBody"]
+	if body == nil {
+		t.Fatalf("expected body component, got %v", spec.Components.Schemas)
+	}
+	if body.Properties["display_name"] == nil || body.Properties["display_name"].Deprecated {
+		t.Errorf("expected current name to be documented, got %+v", body.Properties["display_name"])
+	}
+	if alias := body.Properties["name"]; alias == nil || !alias.Deprecated || !alias.WriteOnly {
+		t.Errorf("expected deprecated writeOnly alias property, got %+v", alias)
+	}
+	if len(body.Required) != 1 || body.Required[0] != "display_name" {
+		t.Errorf("expected only the current name to be required, got %v", body.Required)
+	}
+	// Marshal never writes aliases, so responses do not document them.
+	resp := spec.Components.Schemas["renameResponse"]
+	if resp == nil || resp.Properties["display_name"] == nil || resp.Properties["name"] != nil {
+		t.Errorf("expected the response to document the current name only, got %+v", resp)
+	}
+}
+
+type aliasListRequest struct {
+	Query struct {
+		PageSize int `gork:"page_size,alias=limit|per_page"`
+	}
+	Body struct {
+		DisplayName string `gork:"display_name,alias=name|displayName"`
+	}
+}
+
+func aliasListHandler(_ context.Context, req aliasListRequest) (*renameResponse, error) {
+	resp := &renameResponse{}
+	resp.Body.DisplayName = req.Body.DisplayName
+	resp.Body.PageSize = req.Query.PageSize
+	return resp, nil
+}
+
+func TestFieldAliasLists(t *testing.T) {
+	factory := NewConventionHandlerFactory()
+	handler, _ := factory.CreateHandler(&mockTypedRouterAdapter{queryParams: map[string]string{"per_page": "10"}}, aliasListHandler)
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"displayName":"Jane"}`)))
+	if got := rec.Body.String(); got != `{"display_name":"Jane","page_size":10}` {
+		t.Errorf("unexpected response %d %s", rec.Code, got)
+	}
+
+	registry := NewRouteRegistry()
+	registry.Register(&RouteInfo{
+		Method:       "POST",
+		Path:         "/users",
+		HandlerName:  "AliasList",
+		RequestType:  reflect.TypeOf(aliasListRequest{}),
+		ResponseType: reflect.TypeOf(&renameResponse{}),
+	})
+	spec := GenerateOpenAPI(registry)
+	op := spec.Paths["/users"].Post
+
+	if len(op.Parameters) != 3 {
+		t.Fatalf("expected current and two aliased query parameters, got %+v", op.Parameters)
+	}
+	if got := op.Parameters[0].Aliases; !reflect.DeepEqual(got, []string{"limit", "per_page"}) {
+		t.Errorf("expected x-aliases on the current parameter, got %v", got)
+	}
+	if alias := op.Parameters[2]; alias.Name != "per_page" || !alias.Deprecated || alias.Aliases != nil {
+		t.Errorf("unexpected alias parameter %+v", alias)
+	}
+
+	body := spec.Components.Schemas["aliasListBody"]
+	if body == nil {
+		t.Fatalf("expected body component, got %v", spec.Components.Schemas)
+	}
+	if got := body.Properties["display_name"].Aliases; !reflect.DeepEqual(got, []string{"name", "displayName"}) {
+		t.Errorf("expected x-aliases on the current property, got %v", got)
+	}
+	if alias := body.Properties["displayName"]; alias == nil || !alias.Deprecated || alias.Aliases != nil {
+		t.Errorf("expected deprecated alias property without x-aliases, got %+v", alias)
+	}
+}
@@ -0,0 +1,227 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gork-labs/gork/pkg/gorkson"
+)
+
+// ContentTypeFormURLEncoded is the media type of HTML form submissions.
+const ContentTypeFormURLEncoded = "application/x-www-form-urlencoded"
+
+// errUnsupportedMediaType is returned for request bodies of a media type the
+// route does not accept, which are rejected with 415.
+var errUnsupportedMediaType = errors.New("unsupported media type")
+
+// WithFormBody accepts the route's Body section encoded as
+// application/x-www-form-urlencoded in addition to JSON, and documents the
+// form media type. Routes without it reject form bodies with 415, since
+// browsers send them cross-site without a CORS preflight.
+func WithFormBody() Option {
+	return func(h *HandlerOption) {
+		h.FormBody = true
+	}
+}
+
+// WithStrictBody rejects JSON and form bodies with fields the Body section
+// does not declare with a 400 listing them, e.g. "unknown fields: emial", so
+// that typos reach the client instead of being ignored.
+func WithStrictBody() Option {
+	return func(h *HandlerOption) {
+		h.StrictBody = true
+	}
+}
+
+// isFormRequest reports whether the request body is form-urlencoded.
+func isFormRequest(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == ContentTypeFormURLEncoded
+}
+
+// decodeFormSection decodes a form Body section, rejecting keys that set
+// readonly fields, and unknown keys for strict parsers, like JSON bodies.
+func (p *ConventionParser) decodeFormSection(ctx context.Context, v reflect.Value, values url.Values, files map[string][]*multipart.FileHeader) error {
+	if p.strictBody {
+		var unknown []string
+		for key := range values {
+			if !isFormFieldKey(v.Type(), key) {
+				unknown = append(unknown, key)
+			}
+		}
+		for key := range files {
+			if !isFormFieldKey(v.Type(), key) {
+				unknown = append(unknown, key)
+			}
+		}
+		if len(unknown) > 0 {
+			sort.Strings(unknown)
+			return fmt.Errorf("failed to decode form body: %w", &gorkson.UnknownFieldsError{Fields: unknown})
+		}
+	}
+	if hasReadOnlyFields(v.Type()) {
+		if readOnly := readOnlyFormKeys(v.Type(), values, files, ""); len(readOnly) > 0 {
+			sort.Strings(readOnly)
+			return fmt.Errorf("failed to decode form body: %w", &gorkson.ReadOnlyFieldsError{Fields: readOnly})
+		}
+	}
+	return p.decodeFormBody(ctx, v, values, files, "")
+}
+
+// readOnlyFormKeys returns the keys of values and files that set fields of
+// the struct t marked readonly.
+func readOnlyFormKeys(t reflect.Type, values url.Values, files map[string][]*multipart.FileHeader, prefix string) []string {
+	var found []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		gorkTag := field.Tag.Get("gork")
+		if gorkTag == "" || !field.IsExported() {
+			continue
+		}
+		tagInfo := parseGorkTag(gorkTag)
+		if tagInfo.ReadOnly {
+			for _, name := range append([]string{tagInfo.Name}, tagInfo.Aliases...) {
+				key := prefix + name
+				if _, ok := values[key]; ok || len(files[key]) > 0 || hasFormPrefix(values, key+".") {
+					found = append(found, key)
+				}
+			}
+			continue
+		}
+		nestedPrefix := prefix + tagInfo.Name + "."
+		if nested, ok := formNestedStruct(field.Type); ok && hasFormPrefix(values, nestedPrefix) {
+			found = append(found, readOnlyFormKeys(nested, values, files, nestedPrefix)...)
+		}
+	}
+	return found
+}
+
+// isFormFieldKey reports whether key, a possibly dotted form key, names a
+// field of the struct t or one of its aliases.
+func isFormFieldKey(t reflect.Type, key string) bool {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		gorkTag := field.Tag.Get("gork")
+		if gorkTag == "" || !field.IsExported() {
+			continue
+		}
+		tagInfo := parseGorkTag(gorkTag)
+		if key == tagInfo.Name || slices.Contains(tagInfo.Aliases, key) {
+			return true
+		}
+		if nested, ok := formNestedStruct(field.Type); ok {
+			if rest, found := strings.CutPrefix(key, tagInfo.Name+"."); found && isFormFieldKey(nested, rest) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// decodeFormBody fills the struct v from form values using gork tags.
+// Nested structs are flattened with dots ("address.city"), slices are read
+// from repeated keys and pointers to structs are only allocated when at
+// least one of their keys is present. UploadedFile fields are filled from
+// the multipart file parts in files.
+func (p *ConventionParser) decodeFormBody(ctx context.Context, v reflect.Value, values url.Values, files map[string][]*multipart.FileHeader, prefix string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		gorkTag := field.Tag.Get("gork")
+		if gorkTag == "" || !field.IsExported() {
+			continue
+		}
+		tagInfo := parseGorkTag(gorkTag)
+		fieldValue := v.Field(i)
+
+		if isUploadedFileField(field.Type) {
+			for _, name := range append([]string{tagInfo.Name}, tagInfo.Aliases...) {
+				if setUploadedFiles(fieldValue, files[prefix+name]) {
+					break
+				}
+			}
+			continue
+		}
+
+		if nested, ok := formNestedStruct(field.Type); ok && p.typeRegistry.GetParser(field.Type) == nil {
+			nestedPrefix := prefix + tagInfo.Name + "."
+			if field.Type.Kind() == reflect.Ptr {
+				if !hasFormPrefix(values, nestedPrefix) {
+					continue
+				}
+				fieldValue.Set(reflect.New(nested))
+				fieldValue = fieldValue.Elem()
+			}
+			if err := p.decodeFormBody(ctx, fieldValue, values, files, nestedPrefix); err != nil {
+				return err
+			}
+			continue
+		}
+
+		key, ok := formKey(values, prefix, tagInfo)
+		if !ok {
+			continue
+		}
+		// Repeated keys fill slices like repeated query parameters; single
+		// values are left to the comma-separated parsing.
+		if field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() != reflect.Uint8 && len(values[key]) > 1 {
+			if err := p.setSliceValues(ctx, fieldValue, field, values[key]); err != nil {
+				return fmt.Errorf("failed to set form field %s: %w", prefix+tagInfo.Name, redactError(tagInfo, err))
+			}
+			continue
+		}
+		if err := p.setFieldValue(ctx, fieldValue, field, values.Get(key)); err != nil {
+			return fmt.Errorf("failed to set form field %s: %w", prefix+tagInfo.Name, redactError(tagInfo, err))
+		}
+	}
+	return nil
+}
+
+// formNestedStruct reports whether t (or *t) is a struct that is flattened
+// into dotted form keys rather than decoded from a single value.
+func formNestedStruct(t reflect.Type) (reflect.Type, bool) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t, t.Kind() == reflect.Struct && t != reflect.TypeOf(time.Time{})
+}
+
+// formKey returns the key holding the field's value, honouring aliases.
+func formKey(values url.Values, prefix string, tagInfo GorkTagInfo) (string, bool) {
+	for _, name := range append([]string{tagInfo.Name}, tagInfo.Aliases...) {
+		if _, ok := values[prefix+name]; ok {
+			return prefix + name, true
+		}
+	}
+	return "", false
+}
+
+func hasFormPrefix(values url.Values, prefix string) bool {
+	for key := range values {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyFormBody advertises the form media type for routes registered with
+// WithFormBody, sharing the JSON body schema.
+func applyFormBody(route *RouteInfo, operation *Operation) {
+	if route.Options == nil || !route.Options.FormBody || operation.RequestBody == nil {
+		return
+	}
+	if jsonBody := operation.RequestBody.Content["application/json"]; jsonBody != nil {
+		operation.RequestBody.Content[ContentTypeFormURLEncoded] = &MediaType{Schema: jsonBody.Schema}
+	}
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type formSignupRequest struct {
+	Body struct {
+		Email   string   `gork:"email" validate:"required"`
+		Age     int      `gork:"age"`
+		Agree   bool     `gork:"agree"`
+		Tags    []string `gork:"tags"`
+		IDs     []int    `gork:"ids"`
+		Nick    string   `gork:"nickname,alias=nick"`
+		Address struct {
+			City string `gork:"city"`
+			Zip  string `gork:"zip"`
+		} `gork:"address"`
+		Billing *struct {
+			City string `gork:"city"`
+		} `gork:"billing"`
+	}
+}
+
+func parseFormBody(t *testing.T, body string) (formSignupRequest, error) {
+	t.Helper()
+	r := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	var req formSignupRequest
+	err := NewConventionParser().withFormBody().parseBodySection(reflect.ValueOf(&req).Elem().FieldByName("Body"), r)
+	return req, err
+}
+
+func TestParseFormURLEncodedBody(t *testing.T) {
+	req, err := parseFormBody(t, "email=a%40b.test&age=42&agree=true&tags=x&tags=y&ids=3&ids=5&nick=old&address.city=Oslo&address.zip=0150")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := req.Body
+	if b.Email != "a@b.test" || b.Age != 42 || !b.Agree || b.Nick != "old" {
+		t.Errorf("unexpected scalars %+v", b)
+	}
+	if len(b.Tags) != 2 || b.Tags[1] != "y" {
+		t.Errorf("expected repeated keys to fill slice, got %v", b.Tags)
+	}
+	if !reflect.DeepEqual(b.IDs, []int{3, 5}) {
+		t.Errorf("expected repeated keys to fill typed slice, got %v", b.IDs)
+	}
+	if b.Address.City != "Oslo" || b.Address.Zip != "0150" {
+		t.Errorf("expected flattened nested struct, got %+v", b.Address)
+	}
+	if b.Billing != nil {
+		t.Errorf("pointer struct without keys should stay nil, got %+v", b.Billing)
+	}
+
+	req, err = parseFormBody(t, "billing.city=Bergen")
+	if err != nil || req.Body.Billing == nil || req.Body.Billing.City != "Bergen" {
+		t.Errorf("expected pointer struct to be allocated, got %+v, %v", req.Body.Billing, err)
+	}
+
+	if _, err := parseFormBody(t, "age=old"); err == nil || !strings.Contains(err.Error(), "form field age") {
+		t.Errorf("expected conversion error, got %v", err)
+	}
+	if _, err := parseFormBody(t, "ids=1&ids=x"); err == nil || !strings.Contains(err.Error(), "form field ids: element 1") {
+		t.Errorf("expected element conversion error, got %v", err)
+	}
+	if _, err := parseFormBody(t, "bad=%zz"); err == nil {
+		t.Error("expected malformed form error")
+	}
+}
+
+func TestFormBodyRequiresWithFormBody(t *testing.T) {
+	router, _, handlers := newLoadShedRouter()
+	handler := func(context.Context, formSignupRequest) error { return nil }
+	router.Post("/signup", handler, WithFormBody(), WithStrictBody())
+	router.Post("/json-only", handler)
+
+	tests := []struct {
+		name, route, body string
+		wantStatus        int
+		wantError         string
+	}{
+		{"opted in", "POST /signup", "email=a%40b.test&address.city=Oslo&nick=old", http.StatusNoContent, ""},
+		{"strict", "POST /signup", "email=a%40b.test&emial=x&address.town=Oslo", http.StatusBadRequest, "unknown fields: address.town, emial"},
+		{"json only", "POST /json-only", "email=a%40b.test", http.StatusUnsupportedMediaType, "unsupported media type"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/api", strings.NewReader(tt.body))
+		r.Header.Set("Content-Type", ContentTypeFormURLEncoded)
+		handlers[tt.route](w, r)
+		if w.Code != tt.wantStatus || !strings.Contains(w.Body.String(), tt.wantError) {
+			t.Errorf("%s: got %d %s, want %d %q", tt.name, w.Code, w.Body, tt.wantStatus, tt.wantError)
+		}
+	}
+}
+
+func TestWithFormBodyOpenAPI(t *testing.T) {
+	registry := NewRouteRegistry()
+	router := NewTypedRouter[*struct{}](nil, registry, "", nil, &mockTypedRouterAdapter{}, nil)
+	handler := func(context.Context, formSignupRequest) (*exampleOrderResponse, error) { return nil, nil }
+	router.Post("/signup", handler, WithFormBody())
+	router.Post("/json-only", handler)
+
+	spec := GenerateOpenAPI(registry)
+	content := spec.Paths["/signup"].Post.RequestBody.Content
+	form := content[ContentTypeFormURLEncoded]
+	if form == nil || form.Schema != content["application/json"].Schema {
+		t.Errorf("expected form media type sharing the JSON schema, got %+v", content)
+	}
+	if _, ok := spec.Paths["/json-only"].Post.RequestBody.Content[ContentTypeFormURLEncoded]; ok {
+		t.Error("form media type should only be documented with WithFormBody")
+	}
+}
@@ -0,0 +1,32 @@
+package api
+
+import "reflect"
+
+// isFreeFormType reports whether t holds arbitrary JSON: json.RawMessage,
+// passed through undecoded, or a map of strings to empty interfaces.
+func isFreeFormType(t reflect.Type) bool {
+	if t == rawMessageType {
+		return true
+	}
+	return t.Kind() == reflect.Map && t.Key().Kind() == reflect.String &&
+		t.Elem().Kind() == reflect.Interface && t.Elem().NumMethod() == 0
+}
+
+// freeFormSchema describes an object with any properties.
+func freeFormSchema() *Schema {
+	return &Schema{Type: "object", AdditionalProperties: &Schema{}}
+}
+
+// FreeFormTypeHandler documents json.RawMessage and map[string]any as free
+// form objects.
+type FreeFormTypeHandler struct{}
+
+// CanHandle returns true if this handler can process the given type.
+func (h *FreeFormTypeHandler) CanHandle(t reflect.Type) bool {
+	return isFreeFormType(t)
+}
+
+// GenerateSchema generates an object schema allowing additional properties.
+func (h *FreeFormTypeHandler) GenerateSchema(_ reflect.Type, _ map[string]*Schema, _ bool) *Schema {
+	return freeFormSchema()
+}
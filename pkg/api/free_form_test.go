@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type freeFormRawRequest struct {
+	Body json.RawMessage
+}
+
+type freeFormMapRequest struct {
+	Body map[string]any
+}
+
+type freeFormResponse struct {
+	Body json.RawMessage
+}
+
+func TestFreeFormBodies(t *testing.T) {
+	router, _, handlers := newLoadShedRouter()
+	router.Post("/raw", func(_ context.Context, req freeFormRawRequest) (*freeFormResponse, error) {
+		return &freeFormResponse{Body: req.Body}, nil
+	})
+	var got map[string]any
+	router.Post("/map", func(_ context.Context, req freeFormMapRequest) (*freeFormResponse, error) {
+		got = req.Body
+		return &freeFormResponse{Body: json.RawMessage(`{"ok":true}`)}, nil
+	})
+
+	// Raw bodies are passed through byte for byte, whitespace included
+	body := `{"b": [1, 2], "a": {"nested": null}}`
+	w := httptest.NewRecorder()
+	handlers["POST /raw"](w, httptest.NewRequest(http.MethodPost, "/api/raw", strings.NewReader(body)))
+	if w.Code != http.StatusOK || w.Body.String() != body {
+		t.Errorf("raw: status = %d, body = %s", w.Code, w.Body)
+	}
+
+	w = httptest.NewRecorder()
+	handlers["POST /map"](w, httptest.NewRequest(http.MethodPost, "/api/map", strings.NewReader(`{"count":3,"tags":["x"]}`)))
+	if w.Code != http.StatusOK || strings.TrimSpace(w.Body.String()) != `{"ok":true}` {
+		t.Errorf("map: status = %d, body = %s", w.Code, w.Body)
+	}
+	if got["count"] != float64(3) || len(got["tags"].([]any)) != 1 {
+		t.Errorf("map body = %v", got)
+	}
+}
+
+func TestFreeFormSchemas(t *testing.T) {
+	router, registry, _ := newLoadShedRouter()
+	router.Post("/raw", func(context.Context, freeFormRawRequest) (*freeFormResponse, error) { return nil, nil })
+	router.Post("/map", func(context.Context, freeFormMapRequest) (*freeFormResponse, error) { return nil, nil })
+	spec := GenerateOpenAPI(registry)
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	freeForm := `{"schema":{"additionalProperties":true,"type":"object"}}`
+	if n := strings.Count(string(data), freeForm); n != 4 {
+		t.Errorf("expected free form request and response bodies for both routes, found %d:\n%s", n, data)
+	}
+	if spec.Components.Schemas["freeFormResponse"] != nil {
+		t.Error("raw response bodies must not become components")
+	}
+
+	var parsed Schema
+	if err := json.Unmarshal([]byte(`{"type":"object","additionalProperties":true}`), &parsed); err != nil {
+		t.Fatal(err)
+	}
+	if parsed.AdditionalProperties == nil {
+		t.Error("additionalProperties: true must survive a round trip")
+	}
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net/http"
+	"reflect"
+)
+
+// RequestBinder is implemented by request types with a binder generated by
+// `gork handlers generate`. BindRequest binds the Path, Query, Headers and
+// Cookies sections without reflection and hands the Body section, if any,
+// to bindBody, which decodes it as the ConventionParser does.
+type RequestBinder interface {
+	BindRequest(r *http.Request, params GenericParameterAdapter[*http.Request], bindBody func(body any) error) error
+}
+
+// RequestValidator is implemented by request types with validation generated
+// by `gork handlers generate`. ValidateSection checks the validate tags of
+// the fields of section ("Query", "Body", ...) without reflection, recording
+// failures in errs as the go-playground validator would, and reports whether
+// it covered the section. Sections it does not cover, such as those using
+// rules it cannot generate, are validated by reflection; Validate methods
+// and rules run either way.
+type RequestValidator interface {
+	ValidateSection(section string, errs map[string][]string) bool
+}
+
+// ResponseEncoder is implemented by response types with an encoder generated
+// by `gork handlers generate`. EncodeBody returns the JSON of the Body
+// section, the same bytes gorkson.Marshal returns for it.
+type ResponseEncoder interface {
+	EncodeBody() ([]byte, error)
+}
+
+// WithGeneratedBinders binds requests whose type implements RequestBinder,
+// validates those implementing RequestValidator and encodes responses
+// implementing ResponseEncoder with the generated code instead of
+// reflection. Pass it as router middleware to cover every route; types
+// without generated code keep using the ConventionParser, the
+// ConventionValidator and gorkson.
+func WithGeneratedBinders() Option {
+	return func(h *HandlerOption) {
+		h.GeneratedBinders = true
+	}
+}
+
+// PreferGeneratedBinders makes the parser hand requests whose type
+// implements RequestBinder to the generated binder. Handlers created with
+// such a parser also validate and encode with generated code.
+func PreferGeneratedBinders() ParserOption {
+	return func(p *ConventionParser) {
+		p.generatedBinders = true
+	}
+}
+
+// bindGenerated binds reqPtr with its generated binder, reporting false when
+// the parser does not prefer generated binders or the type has none.
+func (p *ConventionParser) bindGenerated(r *http.Request, reqPtr reflect.Value, adapter GenericParameterAdapter[*http.Request]) (bool, error) {
+	if !p.generatedBinders {
+		return false, nil
+	}
+	binder, ok := reqPtr.Interface().(RequestBinder)
+	if !ok {
+		return false, nil
+	}
+	return true, binder.BindRequest(r, adapter, func(body any) error {
+		return p.parseBodySection(reflect.ValueOf(body).Elem(), r)
+	})
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gork-labs/gork/pkg/gorkson"
+)
+
+type boundRequest struct {
+	Query struct {
+		Name string `gork:"name"`
+	}
+	Body struct {
+		Note string `gork:"note"`
+	}
+	generated bool
+}
+
+func (req *boundRequest) BindRequest(r *http.Request, params GenericParameterAdapter[*http.Request], bindBody func(body any) error) error {
+	req.generated = true
+	if v, ok := params.Query(r, "name"); ok {
+		req.Query.Name = v
+	}
+	return bindBody(&req.Body)
+}
+
+func TestWithGeneratedBinders(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{"reflective by default", nil, "false Jane hi"},
+		{"generated when preferred", []Option{WithGeneratedBinders()}, "true Jane hi"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, _ := NewConventionHandlerFactory().CreateHandler(&mockTypedRouterAdapter{queryParams: map[string]string{"name": "Jane"}},
+				func(_ context.Context, req boundRequest) (*renameResponse, error) {
+					resp := &renameResponse{}
+					resp.Body.DisplayName = strings.Join([]string{map[bool]string{true: "true", false: "false"}[req.generated], req.Query.Name, req.Body.Note}, " ")
+					return resp, nil
+				}, tt.opts...)
+
+			rec := httptest.NewRecorder()
+			handler(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"note":"hi"}`)))
+			if !strings.Contains(rec.Body.String(), `"display_name":"`+tt.want+`"`) {
+				t.Errorf("expected %q, got %d %s", tt.want, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+// generatedCalls counts the calls of the generated code below, written as
+// gork handlers generate writes it.
+var generatedCalls int
+
+type validatedRequest struct {
+	Query struct {
+		Limit int `gork:"limit" validate:"max=10"`
+	}
+}
+
+func (req *validatedRequest) ValidateSection(section string, errs map[string][]string) bool {
+	generatedCalls++
+	switch section {
+	case "Query":
+		if int64(req.Query.Limit) > 10 {
+			errs["query.limit"] = append(errs["query.limit"], "max")
+		}
+		return true
+	}
+	return false
+}
+
+type encodedResponse struct {
+	Body struct {
+		Name string   `gork:"name"`
+		Tags []string `gork:"tags,omitempty"`
+	}
+}
+
+func (resp encodedResponse) EncodeBody() ([]byte, error) {
+	generatedCalls++
+	var b []byte
+	b = append(b, ",\"name\":"...)
+	b = gorkson.AppendString(b, resp.Body.Name)
+	if len(resp.Body.Tags) != 0 {
+		b = append(b, ",\"tags\":"...)
+		b = append(b, '[')
+		for i, e := range resp.Body.Tags {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = gorkson.AppendString(b, e)
+		}
+		b = append(b, ']')
+	}
+	if b == nil {
+		return []byte("{}"), nil
+	}
+	b[0] = '{'
+	return append(b, '}'), nil
+}
+
+func TestGeneratedValidatorsAndEncoders(t *testing.T) {
+	serve := func(limit string, opts ...Option) (int, string) {
+		handler, _ := NewConventionHandlerFactory().CreateHandler(&mockTypedRouterAdapter{queryParams: map[string]string{"limit": limit}},
+			func(_ context.Context, req validatedRequest) (*encodedResponse, error) {
+				resp := &encodedResponse{}
+				resp.Body.Name = "<Jane>"
+				resp.Body.Tags = strings.Split(strings.Repeat("a&b,", req.Query.Limit), ",")[:req.Query.Limit]
+				return resp, nil
+			}, opts...)
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		return rec.Code, rec.Body.String()
+	}
+
+	for _, limit := range []string{"0", "2", "11"} {
+		generatedCalls = 0
+		wantCode, wantBody := serve(limit)
+		if generatedCalls != 0 {
+			t.Fatalf("limit=%s: expected reflection without WithGeneratedBinders", limit)
+		}
+		code, body := serve(limit, WithGeneratedBinders())
+		if generatedCalls == 0 {
+			t.Errorf("limit=%s: expected the generated code to run", limit)
+		}
+		if code != wantCode || body != wantBody {
+			t.Errorf("limit=%s: generated code answered %d %s, reflection %d %s", limit, code, body, wantCode, wantBody)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RouteGenerationError describes a route whose operation could not be
+// generated because it violates the conventions.
+type RouteGenerationError struct {
+	Operation string `json:"operation"` // "METHOD /path"
+	Handler   string `json:"handler"`
+	Message   string `json:"message"`
+}
+
+// String formats the error for reports.
+func (e RouteGenerationError) String() string {
+	return e.Operation + " (" + e.Handler + "): " + e.Message
+}
+
+// GenerationError lists every route left out of a spec generated in
+// error-collect mode, and the routes generated with problems worth
+// reviewing, such as unregistered tags.
+type GenerationError struct {
+	Routes   []RouteGenerationError `json:"routes"`
+	Warnings []RouteGenerationError `json:"warnings,omitempty"`
+}
+
+// Error lists all failing routes.
+func (e *GenerationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "OpenAPI generation failed for %d routes:", len(e.Routes))
+	for _, route := range e.Routes {
+		b.WriteString("\n  - ")
+		b.WriteString(strings.ReplaceAll(route.String(), "\n", "\n    "))
+	}
+	return b.String()
+}
+
+func (e *GenerationError) add(route *RouteInfo, recovered interface{}) {
+	e.Routes = append(e.Routes, routeGenerationError(route, fmt.Sprint(recovered)))
+}
+
+func (e *GenerationError) warn(route *RouteInfo, message string) {
+	e.Warnings = append(e.Warnings, routeGenerationError(route, message))
+}
+
+func routeGenerationError(route *RouteInfo, message string) RouteGenerationError {
+	return RouteGenerationError{
+		Operation: route.Method + " " + route.Path,
+		Handler:   route.HandlerName,
+		Message:   message,
+	}
+}
+
+// WithGenerationErrors switches GenerateOpenAPI to error-collect mode: a
+// route violating the conventions is recorded into errs and left out of the
+// spec instead of panicking, so one bad route does not take down generation
+// for the whole service.
+func WithGenerationErrors(errs *GenerationError) OpenAPIOption {
+	return func(spec *OpenAPISpec) {
+		spec.generationErrors = errs
+	}
+}
+
+// GenerateOpenAPIChecked generates the spec in error-collect mode. It returns
+// the spec of all valid routes together with a *GenerationError listing every
+// offending route, or a nil error when all routes were generated.
+func GenerateOpenAPIChecked(registry *RouteRegistry, opts ...OpenAPIOption) (*OpenAPISpec, error) {
+	errs := &GenerationError{}
+	spec := GenerateOpenAPI(registry, append(opts, WithGenerationErrors(errs))...)
+	if len(errs.Routes) > 0 {
+		return spec, errs
+	}
+	return spec, nil
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGenerateOpenAPICollectsRouteErrors(t *testing.T) {
+	router, registry, _ := newLoadShedRouter()
+	router.Get("/users", func(context.Context, loadShedRequest) (*MalformedTestUserResponse, error) { return nil, nil })
+	router.Get("/health", func(context.Context, loadShedRequest) (*loadShedResponse, error) { return nil, nil })
+	router.Post("/users", func(context.Context, loadShedRequest) (*MalformedTestUserResponse, error) { return nil, nil })
+
+	spec, err := GenerateOpenAPIChecked(registry)
+	var genErr *GenerationError
+	if !errors.As(err, &genErr) || len(genErr.Routes) != 2 {
+		t.Fatalf("expected both malformed routes to be reported, got %v", err)
+	}
+	if genErr.Routes[0].Operation != "GET /api/users" || genErr.Routes[1].Operation != "POST /api/users" ||
+		!strings.Contains(genErr.Routes[0].Message, "Convention Over Configuration sections") {
+		t.Errorf("unexpected route errors %+v", genErr.Routes)
+	}
+	if msg := err.Error(); !strings.HasPrefix(msg, "OpenAPI generation failed for 2 routes:\n  - GET /api/users") {
+		t.Errorf("unexpected message %q", msg)
+	}
+	if spec.Paths["/api/health"] == nil || spec.Paths["/api/users"] != nil {
+		t.Errorf("expected only the valid route in the spec, got %v", spec.Paths)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected GenerateOpenAPI to keep panicking without error-collect mode")
+		}
+	}()
+	GenerateOpenAPI(registry)
+}
+
+func TestGenerateOpenAPICheckedWithoutErrors(t *testing.T) {
+	router, registry, _ := newLoadShedRouter()
+	router.Get("/health", func(context.Context, loadShedRequest) (*loadShedResponse, error) { return nil, nil })
+	if _, err := GenerateOpenAPIChecked(registry); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
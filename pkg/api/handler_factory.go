@@ -110,6 +110,7 @@ func createHandlerFromHTTPFunc(handler http.HandlerFunc, opts []Option) (http.Ha
 		if handlersMeta := GetWebhookHandlersMetadata(handler); len(handlersMeta) > 0 {
 			info.WebhookHandlersMeta = handlersMeta
 		}
+		info.WebhookSuccessStatus, info.WebhookEmptySuccessBody = getWebhookSuccessStatus(handler)
 	}
 
 	return handler, info
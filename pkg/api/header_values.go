@@ -0,0 +1,64 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// HeaderValuesAdapter is implemented by parameter adapters that can return
+// every value of a repeated header (Accept-Language sent on several lines).
+// Without it slice fields only bind comma-separated values.
+// HTTPParameterAdapter, and so the built-in adapters, implement it.
+type HeaderValuesAdapter[T any] interface {
+	HeaderValues(ctx T, key string) []string
+}
+
+// HeaderValues returns every value of a header. Header names are
+// case-insensitive.
+func (HTTPParameterAdapter) HeaderValues(r *http.Request, k string) []string {
+	return r.Header.Values(k)
+}
+
+// HeaderValues returns every value of a header. Header names are
+// case-insensitive.
+func (d *DefaultParameterAdapter) HeaderValues(r *http.Request, key string) []string {
+	return r.Header.Values(key)
+}
+
+// parseRepeatedHeader binds a slice field from a header repeated under its
+// name or one of its aliases, reporting whether it was repeated. Each line
+// may itself hold a comma-separated list, as RFC 9110 makes both forms
+// equivalent. Single lines are left to the comma-separated parsing.
+func (p *ConventionParser) parseRepeatedHeader(ctx context.Context, fieldValue reflect.Value, binding fieldBinding, r *http.Request, adapter GenericParameterAdapter[*http.Request]) (bool, error) {
+	multi, ok := adapter.(HeaderValuesAdapter[*http.Request])
+	if !ok || binding.field.Type.Kind() != reflect.Slice {
+		return false, nil
+	}
+	for _, key := range binding.keys {
+		lines := multi.HeaderValues(r, key)
+		if len(lines) < 2 {
+			continue
+		}
+		var values []string
+		for _, line := range lines {
+			for _, value := range strings.Split(line, ",") {
+				values = append(values, strings.TrimSpace(value))
+			}
+		}
+		return true, p.setSliceValues(ctx, fieldValue, binding.field, values)
+	}
+	return false, nil
+}
+
+// headerName returns the name a header is documented under. Header names
+// are case-insensitive, so names tagged in lower case, such as
+// `gork:"accept-language"`, get their canonical form (Accept-Language);
+// other names keep the casing they were tagged with (X-API-Key).
+func headerName(name string) string {
+	if name == strings.ToLower(name) {
+		return http.CanonicalHeaderKey(name)
+	}
+	return name
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+type headerValuesRequest struct {
+	Headers struct {
+		Languages []string `gork:"accept-language"`
+		Tenant    string   `gork:"x-tenant,alias=x-org"`
+		APIKey    string   `gork:"X-API-Key"`
+	}
+}
+
+func TestHeaderValues(t *testing.T) {
+	var got headerValuesRequest
+	registry := NewRouteRegistry()
+	handlers := map[string]http.HandlerFunc{}
+	router := NewTypedRouter[*struct{}](nil, registry, "/api", nil, &DefaultParameterAdapter{}, func(method, path string, h http.HandlerFunc, _ *RouteInfo) {
+		handlers[method+" "+path] = h
+	})
+	router.Get("/greeting", func(_ context.Context, req headerValuesRequest) (*struct{}, error) {
+		got = req
+		return nil, nil
+	})
+
+	tests := []struct {
+		name      string
+		header    http.Header
+		languages []string
+		tenant    string
+	}{
+		{"repeated", http.Header{"Accept-Language": {"en-GB", "fr;q=0.8, de;q=0.5"}}, []string{"en-GB", "fr;q=0.8", "de;q=0.5"}, ""},
+		{"comma-separated", http.Header{"Accept-Language": {"en, fr"}}, []string{"en", "fr"}, ""},
+		{"case-insensitive", http.Header{"X-TENANT": {"acme"}}, nil, "acme"},
+		{"alias", http.Header{"x-org": {"acme"}}, nil, "acme"},
+	}
+	for _, tt := range tests {
+		got = headerValuesRequest{}
+		r := httptest.NewRequest(http.MethodGet, "/api/greeting", nil)
+		for name, values := range tt.header {
+			for _, value := range values {
+				r.Header.Add(name, value)
+			}
+		}
+		w := httptest.NewRecorder()
+		handlers["GET /greeting"](w, r)
+		if w.Code != http.StatusNoContent {
+			t.Errorf("%s: status = %d: %s", tt.name, w.Code, w.Body)
+			continue
+		}
+		if !reflect.DeepEqual(got.Headers.Languages, tt.languages) || got.Headers.Tenant != tt.tenant {
+			t.Errorf("%s: got %+v", tt.name, got.Headers)
+		}
+	}
+
+	spec := GenerateOpenAPI(registry)
+	var names []string
+	for _, param := range spec.Paths["/api/greeting"].Get.Parameters {
+		names = append(names, param.Name)
+	}
+	want := []string{"Accept-Language", "X-Tenant", "X-Org", "X-API-Key"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("header parameters = %v, want %v", names, want)
+	}
+}
@@ -0,0 +1,32 @@
+package api
+
+// WithInternal leaves the route, such as an admin or debug endpoint, out of
+// the generated spec while still serving it. Specs generated with
+// WithIncludeInternal document it, marked with x-internal.
+func WithInternal() Option {
+	return func(h *HandlerOption) {
+		h.Internal = true
+	}
+}
+
+// WithIncludeInternal documents the routes registered with WithInternal
+// when include is true, for an internal variant of the spec.
+func WithIncludeInternal(include bool) OpenAPIOption {
+	return func(spec *OpenAPISpec) { spec.includeInternal = include }
+}
+
+// isInternalRoute reports whether route was registered with WithInternal.
+func isInternalRoute(route *RouteInfo) bool {
+	return route.Options != nil && route.Options.Internal
+}
+
+// markInternal marks the operation of an internal route with x-internal.
+func markInternal(route *RouteInfo, operation *Operation) {
+	if !isInternalRoute(route) {
+		return
+	}
+	if operation.Extensions == nil {
+		operation.Extensions = map[string]interface{}{}
+	}
+	operation.Extensions["x-internal"] = true
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newInternalRoutesRouter() (map[string]http.HandlerFunc, *RouteRegistry) {
+	router, registry, handlers := newLoadShedRouter()
+	handler := func(context.Context, struct{}) (*loadShedResponse, error) { return &loadShedResponse{}, nil }
+	router.Get("/items", handler)
+	router.Get("/debug/vars", handler, WithInternal())
+	return handlers, registry
+}
+
+func TestInternalRoutes(t *testing.T) {
+	handlers, registry := newInternalRoutesRouter()
+
+	public := GenerateOpenAPI(registry)
+	if public.Paths["/api/items"] == nil || public.Paths["/api/debug/vars"] != nil {
+		t.Errorf("public spec paths = %v, want only /api/items", public.Paths)
+	}
+
+	for name, opts := range map[string][]OpenAPIOption{
+		"sequential": {WithIncludeInternal(true)},
+		"parallel":   {WithIncludeInternal(true), WithParallelGeneration(2)},
+	} {
+		internal := GenerateOpenAPI(registry, opts...)
+		op := internal.Paths["/api/debug/vars"]
+		if op == nil || op.Get == nil || op.Get.Extensions["x-internal"] != true {
+			t.Errorf("%s: internal spec does not document the route with x-internal: %+v", name, op)
+		}
+		if internal.Paths["/api/items"].Get.Extensions["x-internal"] != nil {
+			t.Errorf("%s: public route marked internal", name)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	handlers["GET /debug/vars"](w, httptest.NewRequest(http.MethodGet, "/api/debug/vars", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("internal route answered %d, want it served", w.Code)
+	}
+}
+
+func TestExportOpenAPISpecIncludesInternalRoutes(t *testing.T) {
+	_, registry := newInternalRoutesRouter()
+	t.Setenv("GORK_INTERNAL", "1")
+
+	var out bytes.Buffer
+	if err := exportOpenAPISpec(registry, ExportConfig{Output: &out, LogFatalf: t.Fatalf}); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	var spec OpenAPISpec
+	if err := json.Unmarshal(out.Bytes(), &spec); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if spec.Paths["/api/debug/vars"] == nil {
+		t.Errorf("GORK_INTERNAL export lacks the internal route: %v", spec.Paths)
+	}
+}
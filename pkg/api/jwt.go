@@ -0,0 +1,376 @@
+package api
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rsa"
+	_ "crypto/sha256" // SHA-256 for HS256, RS256, PS256 and ES256
+	_ "crypto/sha512" // SHA-384 and SHA-512 for the other algorithms
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// JWTConfig configures a JWTVerifier. At least one of HMACKey and JWKSURL
+// must be set; tokens are only accepted with the algorithms of the keys
+// configured.
+type JWTConfig struct {
+	// HMACKey verifies HS256, HS384 and HS512 tokens.
+	HMACKey []byte
+	// JWKSURL is the JSON Web Key Set of the issuer, whose RSA and EC keys
+	// verify RS*, PS* and ES* tokens by their kid.
+	JWKSURL string
+	// Issuer and Audience, when set, must match the iss and aud claims.
+	Issuer   string
+	Audience string
+	// Leeway tolerates clock skew when checking exp and nbf.
+	Leeway time.Duration
+	// JWKSCacheTTL is how long the key set is kept before it is fetched
+	// again. It defaults to one hour; tokens signed with an unknown key
+	// refresh it sooner, at most once a minute.
+	JWKSCacheTTL time.Duration
+	// Client fetches the key set. It defaults to a client with a 10 second
+	// timeout.
+	Client *http.Client
+}
+
+// JWTVerifier verifies bearer JSON Web Tokens signed with an HMAC key or
+// with a key of a JWKS. It implements TokenVerifier.
+type JWTVerifier struct {
+	config JWTConfig
+	now    func() time.Time
+
+	// fetches deduplicates concurrent fetches of the key set, which run
+	// without holding mu.
+	fetches   singleflight.Group
+	mu        sync.Mutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+// NewJWTVerifier returns a verifier of the tokens described by config. It
+// panics when neither an HMAC key nor a JWKS URL is configured.
+func NewJWTVerifier(config JWTConfig) *JWTVerifier {
+	if len(config.HMACKey) == 0 && config.JWKSURL == "" {
+		panic("NewJWTVerifier requires an HMACKey or a JWKSURL")
+	}
+	if config.JWKSCacheTTL <= 0 {
+		config.JWKSCacheTTL = time.Hour
+	}
+	if config.Client == nil {
+		config.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &JWTVerifier{config: config, now: time.Now}
+}
+
+// jwksRefreshInterval limits how often tokens signed with unknown keys
+// refetch the key set.
+const jwksRefreshInterval = time.Minute
+
+// jwksFetchTimeout bounds a fetch of the key set, which outlives the
+// request that started it so that its canceling does not fail the others
+// waiting for the same fetch.
+const jwksFetchTimeout = 10 * time.Second
+
+// jwtCurves maps the ES algorithms to the curve of their keys (RFC 7518).
+var jwtCurves = map[string]elliptic.Curve{
+	"ES256": elliptic.P256(), "ES384": elliptic.P384(), "ES512": elliptic.P521(),
+}
+
+// jwtAlgorithms maps the supported alg values to their hash.
+var jwtAlgorithms = map[string]crypto.Hash{
+	"HS256": crypto.SHA256, "HS384": crypto.SHA384, "HS512": crypto.SHA512,
+	"RS256": crypto.SHA256, "RS384": crypto.SHA384, "RS512": crypto.SHA512,
+	"PS256": crypto.SHA256, "PS384": crypto.SHA384, "PS512": crypto.SHA512,
+	"ES256": crypto.SHA256, "ES384": crypto.SHA384, "ES512": crypto.SHA512,
+}
+
+// VerifyToken checks the signature, expiry, issuer and audience of token
+// and returns its claims.
+func (v *JWTVerifier) VerifyToken(ctx context.Context, token string) ([]byte, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeJWTSegment(parts[0], &header); err != nil {
+		return nil, errors.New("malformed token header")
+	}
+	hash, ok := jwtAlgorithms[header.Alg]
+	if !ok {
+		return nil, fmt.Errorf("unsupported algorithm %q", header.Alg)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.New("malformed token signature")
+	}
+	if err := v.verifySignature(ctx, header.Alg, header.Kid, hash, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	claims, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, errors.New("malformed token claims")
+	}
+	if err := v.checkClaims(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// verifySignature checks the signature of signed with the key alg calls
+// for.
+func (v *JWTVerifier) verifySignature(ctx context.Context, alg, kid string, hash crypto.Hash, signed string, signature []byte) error {
+	if strings.HasPrefix(alg, "HS") {
+		if len(v.config.HMACKey) == 0 {
+			return fmt.Errorf("unsupported algorithm %q", alg)
+		}
+		mac := hmac.New(hash.New, v.config.HMACKey)
+		mac.Write([]byte(signed))
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	}
+	if v.config.JWKSURL == "" {
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+	key, err := v.key(ctx, kid)
+	if err != nil {
+		return err
+	}
+	h := hash.New()
+	h.Write([]byte(signed))
+	digest := h.Sum(nil)
+
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		switch alg[:2] {
+		case "RS":
+			err = rsa.VerifyPKCS1v15(key, hash, digest, signature)
+		case "PS":
+			err = rsa.VerifyPSS(key, hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		default:
+			err = fmt.Errorf("key %q does not match algorithm %q", kid, alg)
+		}
+		if err != nil {
+			return errors.New("invalid signature")
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+		if jwtCurves[alg] != key.Curve || len(signature) != 2*size {
+			return errors.New("invalid signature")
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(key, digest, r, s) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	}
+	return fmt.Errorf("key %q does not match algorithm %q", kid, alg)
+}
+
+// checkClaims checks the registered claims exp, nbf, iss and aud.
+func (v *JWTVerifier) checkClaims(data []byte) error {
+	var claims struct {
+		Exp *json.Number    `json:"exp"`
+		Nbf *json.Number    `json:"nbf"`
+		Iss string          `json:"iss"`
+		Aud json.RawMessage `json:"aud"`
+	}
+	if err := json.Unmarshal(data, &claims); err != nil {
+		return errors.New("malformed token claims")
+	}
+	now := v.now()
+	if claims.Exp != nil {
+		exp, err := claims.Exp.Float64()
+		if err != nil || now.After(unixTime(exp).Add(v.config.Leeway)) {
+			return errors.New("token expired")
+		}
+	}
+	if claims.Nbf != nil {
+		nbf, err := claims.Nbf.Float64()
+		if err != nil || now.Add(v.config.Leeway).Before(unixTime(nbf)) {
+			return errors.New("token not valid yet")
+		}
+	}
+	if v.config.Issuer != "" && claims.Iss != v.config.Issuer {
+		return errors.New("unexpected issuer")
+	}
+	if v.config.Audience != "" && !jwtAudienceContains(claims.Aud, v.config.Audience) {
+		return errors.New("unexpected audience")
+	}
+	return nil
+}
+
+// jwtAudienceContains reports whether the aud claim, a string or an array
+// of strings, contains audience.
+func jwtAudienceContains(aud json.RawMessage, audience string) bool {
+	var single string
+	if json.Unmarshal(aud, &single) == nil {
+		return single == audience
+	}
+	var many []string
+	if json.Unmarshal(aud, &many) == nil {
+		for _, a := range many {
+			if a == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func unixTime(seconds float64) time.Time {
+	return time.Unix(0, int64(seconds*float64(time.Second)))
+}
+
+func decodeJWTSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// key returns the key of the JWKS with the given kid, fetching the key set
+// when it is stale or does not know kid. Concurrent callers share a single
+// fetch; ctx only bounds how long this caller waits for it.
+func (v *JWTVerifier) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	v.mu.Lock()
+	now := v.now()
+	keys := v.keys
+	key, ok := keys[kid]
+	stale := keys == nil || now.Sub(v.fetchedAt) > v.config.JWKSCacheTTL
+	refresh := stale || (!ok && now.Sub(v.fetchedAt) > jwksRefreshInterval)
+	v.mu.Unlock()
+
+	if refresh {
+		fetched := v.fetches.DoChan("jwks", func() (interface{}, error) {
+			fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), jwksFetchTimeout)
+			defer cancel()
+			keys, err := v.fetchKeys(fetchCtx)
+			if err != nil {
+				return nil, err
+			}
+			v.mu.Lock()
+			v.keys, v.fetchedAt = keys, now
+			v.mu.Unlock()
+			return keys, nil
+		})
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case result := <-fetched:
+			if result.Err != nil {
+				return nil, result.Err
+			}
+			keys = result.Val.(map[string]crypto.PublicKey)
+		}
+		key, ok = keys[kid]
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q", kid)
+	}
+	return key, nil
+}
+
+// fetchKeys fetches the JWKS and returns its RSA and EC signing keys by
+// kid. Keys of other types or uses are skipped.
+func (v *JWTVerifier) fetchKeys(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.config.JWKSURL, nil)
+	if err != nil {
+		return nil, errors.New("fetch JWKS: invalid URL")
+	}
+	resp, err := v.config.Client.Do(req)
+	if err != nil {
+		return nil, errors.New("fetch JWKS: request failed")
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch JWKS: status %d", resp.StatusCode)
+	}
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, errors.New("fetch JWKS: malformed key set")
+	}
+	keys := map[string]crypto.PublicKey{}
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		if key, err := jwk.publicKey(); err == nil {
+			keys[jwk.Kid] = key
+		}
+	}
+	return keys, nil
+}
+
+// jsonWebKey is an RSA or EC public key of a JWKS (RFC 7517).
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeJWKInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeJWKInt(k.E)
+		if err != nil || !e.IsInt64() {
+			return nil, errors.New("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		curves := map[string]elliptic.Curve{"P-256": elliptic.P256(), "P-384": elliptic.P384(), "P-521": elliptic.P521()}
+		curve, ok := curves[k.Crv]
+		if !ok {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeJWKInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeJWKInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
+
+func decodeJWKInt(s string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(data) == 0 {
+		return nil, errors.New("invalid key parameter")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
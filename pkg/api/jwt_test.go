@@ -0,0 +1,240 @@
+package api
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func jwtSigningInput(header, claims string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(header)) + "." + base64.RawURLEncoding.EncodeToString([]byte(claims))
+}
+
+func signHS256(t *testing.T, key []byte, claims string) string {
+	t.Helper()
+	signed := jwtSigningInput(`{"alg":"HS256","typ":"JWT"}`, claims)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid, claims string) string {
+	t.Helper()
+	signed := jwtSigningInput(`{"alg":"RS256","kid":"`+kid+`"}`, claims)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func signES256(t *testing.T, key *ecdsa.PrivateKey, kid, claims string) string {
+	t.Helper()
+	signed := jwtSigningInput(`{"alg":"ES256","kid":"`+kid+`"}`, claims)
+	digest := sha256.Sum256([]byte(signed))
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestJWTVerifierJWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b64 := func(i *big.Int) string { return base64.RawURLEncoding.EncodeToString(i.Bytes()) }
+	var fetches atomic.Int32
+	jwks := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fetches.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{
+			{"kty": "RSA", "kid": "rsa", "use": "sig", "n": b64(rsaKey.N), "e": b64(big.NewInt(int64(rsaKey.E)))},
+			{"kty": "EC", "kid": "ec", "crv": "P-256", "x": b64(ecKey.X), "y": b64(ecKey.Y)},
+			{"kty": "RSA", "kid": "enc", "use": "enc", "n": b64(rsaKey.N), "e": "AQAB"},
+		}})
+	}))
+	defer jwks.Close()
+
+	verifier := NewJWTVerifier(JWTConfig{JWKSURL: jwks.URL, Issuer: "https://issuer.example.com", Audience: "api"})
+	now := time.Unix(1_700_000_000, 0)
+	verifier.now = func() time.Time { return now }
+
+	valid := `{"sub":"u1","iss":"https://issuer.example.com","aud":["web","api"],"exp":1700000060}`
+	signedValid := strings.Split(signRS256(t, rsaKey, "rsa", valid), ".")
+	tampered := signedValid[0] + "." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"admin"}`)) + "." + signedValid[2]
+	tests := []struct {
+		name    string
+		token   string
+		wantErr string
+	}{
+		{"RS256", signRS256(t, rsaKey, "rsa", valid), ""},
+		{"ES256", signES256(t, ecKey, "ec", valid), ""},
+		{"expired", signRS256(t, rsaKey, "rsa", `{"iss":"https://issuer.example.com","aud":"api","exp":1699999999}`), "token expired"},
+		{"not yet valid", signRS256(t, rsaKey, "rsa", `{"iss":"https://issuer.example.com","aud":"api","nbf":1700000100}`), "not valid yet"},
+		{"issuer", signRS256(t, rsaKey, "rsa", `{"iss":"https://evil.example.com","aud":"api"}`), "unexpected issuer"},
+		{"audience", signRS256(t, rsaKey, "rsa", `{"iss":"https://issuer.example.com","aud":"web"}`), "unexpected audience"},
+		{"unknown key", signRS256(t, rsaKey, "rotated", valid), "unknown key"},
+		{"encryption key", signRS256(t, rsaKey, "enc", valid), "unknown key"},
+		{"key type mismatch", signES256(t, ecKey, "rsa", valid), "invalid signature"},
+		{"tampered", tampered, "invalid signature"},
+		{"HMAC without key", signHS256(t, []byte("secret"), valid), "unsupported algorithm"},
+		{"none", jwtSigningInput(`{"alg":"none"}`, valid) + ".", "unsupported algorithm"},
+		{"malformed", "not-a-token", "malformed token"},
+	}
+	for _, tt := range tests {
+		claims, err := verifier.VerifyToken(context.Background(), tt.token)
+		if tt.wantErr == "" {
+			if err != nil || !strings.Contains(string(claims), `"sub":"u1"`) {
+				t.Errorf("%s: claims = %s, err = %v", tt.name, claims, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: err = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+	// Unknown keys within the refresh interval use the cached key set;
+	// after it they refetch it.
+	if n := fetches.Load(); n != 1 {
+		t.Errorf("JWKS fetched %d times, want 1", n)
+	}
+	now = now.Add(2 * jwksRefreshInterval)
+	if _, err := verifier.VerifyToken(context.Background(), signRS256(t, rsaKey, "rotated", valid)); err == nil {
+		t.Error("expected an unknown key error")
+	}
+	if n := fetches.Load(); n != 2 {
+		t.Errorf("JWKS fetched %d times, want 2", n)
+	}
+}
+
+func TestJWTVerifierCurveMismatch(t *testing.T) {
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b64 := func(i *big.Int) string { return base64.RawURLEncoding.EncodeToString(i.Bytes()) }
+	jwks := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{
+			{"kty": "EC", "kid": "p384", "crv": "P-384", "x": b64(p384.X), "y": b64(p384.Y)},
+		}})
+	}))
+	defer jwks.Close()
+
+	// An ES256 header with a P-384 key and a 96 byte signature.
+	signed := jwtSigningInput(`{"alg":"ES256","kid":"p384"}`, `{}`)
+	digest := sha256.Sum256([]byte(signed))
+	r, s, err := ecdsa.Sign(rand.Reader, p384, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature := make([]byte, 96)
+	r.FillBytes(signature[:48])
+	s.FillBytes(signature[48:])
+	token := signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+
+	verifier := NewJWTVerifier(JWTConfig{JWKSURL: jwks.URL})
+	if _, err := verifier.VerifyToken(context.Background(), token); err == nil || !strings.Contains(err.Error(), "invalid signature") {
+		t.Errorf("err = %v, want ES256 to reject a P-384 key", err)
+	}
+}
+
+func TestJWTVerifierSharesFetches(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b64 := func(i *big.Int) string { return base64.RawURLEncoding.EncodeToString(i.Bytes()) }
+	var fetches atomic.Int32
+	release := make(chan struct{})
+	jwks := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fetches.Add(1)
+		<-release
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{
+			{"kty": "RSA", "kid": "rsa", "n": b64(rsaKey.N), "e": b64(big.NewInt(int64(rsaKey.E)))},
+		}})
+	}))
+	defer jwks.Close()
+
+	verifier := NewJWTVerifier(JWTConfig{JWKSURL: jwks.URL})
+	token := signRS256(t, rsaKey, "rsa", `{"sub":"u1"}`)
+
+	// The caller starting the fetch gives up; the others still get the keys.
+	canceled, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := verifier.VerifyToken(canceled, token)
+		first <- err
+	}()
+	for fetches.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-first; err == nil {
+		t.Error("expected the canceled caller to fail")
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := verifier.VerifyToken(context.Background(), token)
+			errs <- err
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("VerifyToken() = %v", err)
+		}
+	}
+	if n := fetches.Load(); n != 1 {
+		t.Errorf("JWKS fetched %d times, want 1", n)
+	}
+}
+
+func TestJWTVerifierHMACLeeway(t *testing.T) {
+	verifier := NewJWTVerifier(JWTConfig{HMACKey: []byte("secret"), Leeway: time.Minute})
+	verifier.now = func() time.Time { return time.Unix(1_700_000_030, 0) }
+	token := signHS256(t, []byte("secret"), `{"exp":1700000000}`)
+	if _, err := verifier.VerifyToken(context.Background(), token); err != nil {
+		t.Errorf("expected the leeway to accept a token expired 30s ago: %v", err)
+	}
+}
+
+func TestNewJWTVerifierRequiresKey(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic without HMAC key or JWKS URL")
+		}
+	}()
+	NewJWTVerifier(JWTConfig{})
+}
@@ -0,0 +1,170 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// LoadSheddingConfig configures adaptive load shedding for routes.
+type LoadSheddingConfig struct {
+	// MaxConcurrent is the maximum number of requests processed at the same
+	// time. Excess requests are rejected with 503 Service Unavailable.
+	MaxConcurrent int
+	// MinConcurrent is the lower bound for the adaptive limit. Defaults to 1.
+	MinConcurrent int
+	// TargetLatency enables adaptive limiting when positive: the concurrency
+	// limit shrinks while observed handler latency exceeds the target and
+	// grows back towards MaxConcurrent once latency recovers.
+	TargetLatency time.Duration
+	// RetryAfter is advertised to rejected clients. Defaults to one second.
+	RetryAfter time.Duration
+	// OnShed, when set, is called for every rejected request with the route
+	// ("METHOD /path") and the total number of requests shed on that route.
+	// It is intended for exporting autoscaling signals and must not block.
+	OnShed func(route string, total uint64)
+}
+
+// LoadShedder rejects requests before they are parsed once the configured
+// concurrency limit is reached. A single LoadShedder may be shared by many
+// routes (e.g. as router-level middleware) in which case the limit applies
+// to all of them together while shed counts are tracked per route.
+//
+// The shedder is safe for concurrent use by multiple goroutines.
+type LoadShedder struct {
+	cfg LoadSheddingConfig
+
+	mu       sync.Mutex
+	inflight int
+	limit    float64
+	shed     map[string]uint64
+
+	now func() time.Time
+}
+
+// NewLoadShedder creates a load shedder with the given configuration.
+func NewLoadShedder(cfg LoadSheddingConfig) *LoadShedder {
+	if cfg.MaxConcurrent < 1 {
+		cfg.MaxConcurrent = 1
+	}
+	if cfg.MinConcurrent < 1 {
+		cfg.MinConcurrent = 1
+	}
+	if cfg.MinConcurrent > cfg.MaxConcurrent {
+		cfg.MinConcurrent = cfg.MaxConcurrent
+	}
+	if cfg.RetryAfter <= 0 {
+		cfg.RetryAfter = time.Second
+	}
+	return &LoadShedder{
+		cfg:   cfg,
+		limit: float64(cfg.MaxConcurrent),
+		shed:  map[string]uint64{},
+		now:   time.Now,
+	}
+}
+
+// WithLoadShedding protects the route with the given load shedder. Rejected
+// requests receive 503 Service Unavailable with a Retry-After header, which
+// is also documented in the generated OpenAPI operation.
+func WithLoadShedding(s *LoadShedder) Option {
+	return func(h *HandlerOption) {
+		h.LoadShedder = s
+	}
+}
+
+// Limit returns the current (possibly adapted) concurrency limit.
+func (s *LoadShedder) Limit() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return int(s.limit)
+}
+
+// ShedCounts returns a snapshot of rejected request counts per route.
+func (s *LoadShedder) ShedCounts() map[string]uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cp := make(map[string]uint64, len(s.shed))
+	for k, v := range s.shed {
+		cp[k] = v
+	}
+	return cp
+}
+
+// acquire reserves a processing slot. When none is available the rejection
+// is recorded for the route and the new total is returned with ok=false.
+func (s *LoadShedder) acquire(route string) (total uint64, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.inflight >= int(s.limit) {
+		s.shed[route]++
+		return s.shed[route], false
+	}
+	s.inflight++
+	return 0, true
+}
+
+// release frees a processing slot and adapts the limit to the observed
+// latency: multiplicative decrease above target, additive increase below.
+func (s *LoadShedder) release(latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inflight--
+	if s.cfg.TargetLatency <= 0 {
+		return
+	}
+	if latency > s.cfg.TargetLatency {
+		s.limit = max(float64(s.cfg.MinConcurrent), s.limit*0.9)
+		return
+	}
+	s.limit = min(float64(s.cfg.MaxConcurrent), s.limit+1/s.limit)
+}
+
+// wrap returns a handler that sheds load for the given route.
+func (s *LoadShedder) wrap(info *RouteInfo, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		route := info.Method + " " + info.Path
+		if total, ok := s.acquire(route); !ok {
+			if s.cfg.OnShed != nil {
+				s.cfg.OnShed(route, total)
+			}
+			s.reject(w)
+			return
+		}
+		start := s.now()
+		defer func() { s.release(s.now().Sub(start)) }()
+		next(w, r)
+	}
+}
+
+// reject writes the 503 response for a shed request.
+func (s *LoadShedder) reject(w http.ResponseWriter) {
+	secs := int((s.cfg.RetryAfter + time.Second - 1) / time.Second)
+	w.Header().Set("Retry-After", strconv.Itoa(secs))
+	writeErrorResponse(w, http.StatusServiceUnavailable, ErrorResponse{Error: http.StatusText(http.StatusServiceUnavailable)})
+}
+
+// addServiceUnavailableResponse documents the 503 response returned by the
+// load shedder, including the Retry-After header.
+func addServiceUnavailableResponse(operation *Operation, components *Components) {
+	if components.Responses == nil {
+		components.Responses = map[string]*Response{}
+	}
+	if _, ok := components.Responses["ServiceUnavailable"]; !ok {
+		components.Responses["ServiceUnavailable"] = &Response{
+			Description: "Service Unavailable - Server is overloaded, retry later",
+			Headers: map[string]*Header{
+				"Retry-After": {
+					Description: "Number of seconds to wait before retrying",
+					Required:    true,
+					Schema:      &Schema{Type: "integer"},
+				},
+			},
+			Content: map[string]*MediaType{
+				"application/json": {Schema: &Schema{Ref: "#/components/schemas/ErrorResponse"}},
+			},
+		}
+	}
+	operation.Responses["503"] = &Response{Ref: "#/components/responses/ServiceUnavailable"}
+}
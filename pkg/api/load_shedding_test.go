@@ -0,0 +1,193 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type loadShedRequest struct {
+	Query struct {
+		Name string `gork:"name"`
+	}
+}
+
+type loadShedResponse struct {
+	Body struct {
+		Name string `gork:"name"`
+	}
+}
+
+func newLoadShedRouter(opts ...Option) (TypedRouter[*struct{}], *RouteRegistry, map[string]http.HandlerFunc) {
+	registry := NewRouteRegistry()
+	handlers := map[string]http.HandlerFunc{}
+	router := NewTypedRouter[*struct{}](nil, registry, "/api", opts, &mockTypedRouterAdapter{},
+		func(method, path string, h http.HandlerFunc, _ *RouteInfo) {
+			handlers[method+" "+path] = h
+		})
+	return router, registry, handlers
+}
+
+func TestLoadSheddingRejectsExcessRequests(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		events []uint64
+	)
+	shedder := NewLoadShedder(LoadSheddingConfig{
+		MaxConcurrent: 1,
+		RetryAfter:    1500 * time.Millisecond,
+		OnShed: func(route string, total uint64) {
+			mu.Lock()
+			defer mu.Unlock()
+			if route != "GET /api/items" {
+				t.Errorf("unexpected route %q", route)
+			}
+			events = append(events, total)
+		},
+	})
+
+	entered := make(chan struct{})
+	unblock := make(chan struct{})
+	var first sync.Once
+	handler := func(_ context.Context, _ loadShedRequest) (*loadShedResponse, error) {
+		first.Do(func() {
+			close(entered)
+			<-unblock
+		})
+		return &loadShedResponse{}, nil
+	}
+
+	router, _, handlers := newLoadShedRouter()
+	router.Get("/items", handler, WithLoadShedding(shedder))
+	h := handlers["GET /items"]
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/items", nil))
+	}()
+	<-entered
+
+	for i := 0; i < 2; i++ {
+		rec := httptest.NewRecorder()
+		h(rec, httptest.NewRequest(http.MethodGet, "/api/items", nil))
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Fatalf("expected 503, got %d", rec.Code)
+		}
+		if got := rec.Header().Get("Retry-After"); got != "2" {
+			t.Errorf("expected Retry-After 2, got %q", got)
+		}
+		if rec.Body.String() != "{\"error\":\"Service Unavailable\"}\n" {
+			t.Errorf("unexpected body %q", rec.Body.String())
+		}
+	}
+
+	close(unblock)
+	<-done
+
+	rec := httptest.NewRecorder()
+	h(rec, httptest.NewRequest(http.MethodGet, "/api/items", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 after capacity freed, got %d", rec.Code)
+	}
+
+	if got := shedder.ShedCounts()["GET /api/items"]; got != 2 {
+		t.Errorf("expected 2 shed requests, got %d", got)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(events) != 2 || events[0] != 1 || events[1] != 2 {
+		t.Errorf("unexpected OnShed totals %v", events)
+	}
+}
+
+func TestLoadSheddingAdaptiveLimit(t *testing.T) {
+	shedder := NewLoadShedder(LoadSheddingConfig{
+		MaxConcurrent: 10,
+		MinConcurrent: 8,
+		TargetLatency: 10 * time.Millisecond,
+	})
+	now := time.Unix(0, 0)
+	latency := 50 * time.Millisecond
+	shedder.now = func() time.Time {
+		now = now.Add(latency / 2)
+		return now
+	}
+	h := shedder.wrap(&RouteInfo{Method: "GET", Path: "/"}, func(http.ResponseWriter, *http.Request) {})
+
+	h(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	if got := shedder.Limit(); got != 9 {
+		t.Fatalf("expected limit 9 after slow request, got %d", got)
+	}
+	for i := 0; i < 5; i++ {
+		h(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+	if got := shedder.Limit(); got != 8 {
+		t.Fatalf("expected limit to stop at MinConcurrent 8, got %d", got)
+	}
+
+	latency = time.Millisecond
+	for i := 0; i < 50; i++ {
+		h(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+	if got := shedder.Limit(); got != 10 {
+		t.Fatalf("expected limit to recover to MaxConcurrent 10, got %d", got)
+	}
+}
+
+func TestNewLoadShedderDefaults(t *testing.T) {
+	s := NewLoadShedder(LoadSheddingConfig{MinConcurrent: 5})
+	if s.cfg.MaxConcurrent != 1 || s.cfg.MinConcurrent != 1 {
+		t.Errorf("unexpected bounds %d/%d", s.cfg.MinConcurrent, s.cfg.MaxConcurrent)
+	}
+	if s.cfg.RetryAfter != time.Second {
+		t.Errorf("expected default RetryAfter 1s, got %v", s.cfg.RetryAfter)
+	}
+	if s.Limit() != 1 {
+		t.Errorf("expected limit 1, got %d", s.Limit())
+	}
+	if len(s.ShedCounts()) != 0 {
+		t.Errorf("expected no shed counts")
+	}
+}
+
+func TestLoadSheddingOpenAPIResponse(t *testing.T) {
+	shedder := NewLoadShedder(LoadSheddingConfig{MaxConcurrent: 4})
+	handler := func(_ context.Context, _ loadShedRequest) (*loadShedResponse, error) {
+		return &loadShedResponse{}, nil
+	}
+
+	router, registry, _ := newLoadShedRouter(WithLoadShedding(shedder))
+	router.Get("/a", handler)
+	router.Get("/b", handler)
+	plain, plainRegistry, _ := newLoadShedRouter()
+	plain.Get("/c", handler)
+
+	spec := GenerateOpenAPI(registry)
+	for _, p := range []string{"/api/a", "/api/b"} {
+		resp := spec.Paths[p].Get.Responses["503"]
+		if resp == nil || resp.Ref != "#/components/responses/ServiceUnavailable" {
+			t.Fatalf("expected 503 reference on %s, got %+v", p, resp)
+		}
+	}
+	component := spec.Components.Responses["ServiceUnavailable"]
+	if component == nil || component.Headers["Retry-After"] == nil {
+		t.Fatalf("expected ServiceUnavailable component with Retry-After header, got %+v", component)
+	}
+
+	plainSpec := GenerateOpenAPI(plainRegistry)
+	if _, ok := plainSpec.Paths["/api/c"].Get.Responses["503"]; ok {
+		t.Error("did not expect 503 response without load shedding")
+	}
+
+	// Components without a responses map are initialised on demand.
+	op := &Operation{Responses: map[string]*Response{}}
+	comps := &Components{}
+	addServiceUnavailableResponse(op, comps)
+	if comps.Responses["ServiceUnavailable"] == nil {
+		t.Error("expected component to be created")
+	}
+}
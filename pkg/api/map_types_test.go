@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type mapTestStock struct {
+	SKU      string `gork:"sku" validate:"required"`
+	Quantity int    `gork:"quantity"`
+}
+
+type mapTestRequest struct {
+	Body struct {
+		Stock  map[string]mapTestStock    `gork:"stock"`
+		Labels map[string]string          `gork:"labels"`
+		Groups map[string][]*mapTestStock `gork:"groups"`
+	}
+}
+
+type mapTestResponse struct {
+	Body struct {
+		Stock map[string]mapTestStock `gork:"stock"`
+	}
+}
+
+func TestMapSchemas(t *testing.T) {
+	router, registry, _ := newLoadShedRouter()
+	router.Put("/inventory", func(context.Context, mapTestRequest) (*mapTestResponse, error) { return nil, nil })
+	spec := GenerateOpenAPI(registry)
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"stock":{"type":"object","title":"map[string]mapTestStock","description":"Map of mapTestStock","additionalProperties":{"$ref":"#/components/schemas/mapTestStock"}}`,
+		`"labels":{"type":"object","title":"map[string]string","description":"Map of string","additionalProperties":{"type":"string"}}`,
+		`"groups":{"type":"object","additionalProperties":{"type":"array","items":{"anyOf":[{"$ref":"#/components/schemas/mapTestStock"},{"type":"null"}]}}}`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("spec is missing %s:\n%s", want, data)
+		}
+	}
+	if spec.Components.Schemas["mapTestStock"] == nil {
+		t.Error("map values must be registered as components")
+	}
+
+	var parsed OpenAPISpec
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		t.Fatal(err)
+	}
+	for _, schema := range parsed.Components.Schemas {
+		if stock := schema.Properties["stock"]; stock != nil && (stock.AdditionalProperties == nil || stock.AdditionalProperties.Ref == "") {
+			t.Errorf("additionalProperties must survive a round trip, got %+v", stock)
+		}
+	}
+}
+
+func TestMapBodiesRoundTrip(t *testing.T) {
+	router, _, handlers := newLoadShedRouter()
+	router.Put("/inventory", func(_ context.Context, req mapTestRequest) (*mapTestResponse, error) {
+		if req.Body.Groups["low"][0].SKU != "b-2" || req.Body.Labels["site"] != "north" {
+			t.Errorf("unexpected request body %+v", req.Body)
+		}
+		resp := &mapTestResponse{}
+		resp.Body.Stock = req.Body.Stock
+		return resp, nil
+	})
+
+	body := `{"stock":{"shelf-1":{"sku":"a-1","quantity":4}},"labels":{"site":"north"},"groups":{"low":[{"sku":"b-2","quantity":1}]}}`
+	w := httptest.NewRecorder()
+	handlers["PUT /inventory"](w, httptest.NewRequest(http.MethodPut, "/api/inventory", strings.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	if want := `{"stock":{"shelf-1":{"quantity":4,"sku":"a-1"}}}`; strings.TrimSpace(w.Body.String()) != want {
+		t.Errorf("body = %s, want %s", w.Body, want)
+	}
+}
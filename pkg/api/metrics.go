@@ -0,0 +1,138 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"time"
+)
+
+// Metrics records the requests of routes using WithMetrics. The
+// github.com/gork-labs/gork/pkg/metrics module implements it with
+// Prometheus.
+type Metrics interface {
+	// ObserveRequest is called once the response to a request to route has
+	// been written.
+	ObserveRequest(route *RouteInfo, obs RequestObservation)
+	// Handler serves the collected metrics, e.g. on /metrics.
+	Handler() http.Handler
+}
+
+// RequestObservation describes a served request.
+type RequestObservation struct {
+	Status   int
+	Duration time.Duration
+	// RequestSize counts the body bytes read by the handler.
+	RequestSize  int64
+	ResponseSize int64
+	// ValidationFailed is true when the request was answered with a 400
+	// because it did not pass validation.
+	ValidationFailed bool
+}
+
+// WithMetrics records every request of a route (or of every route, when
+// passed to a router or group) with m, labeled by the route's path template.
+// Serve the metrics with the router's MetricsHandler.
+func WithMetrics(m Metrics) Option {
+	return func(h *HandlerOption) {
+		h.Metrics = m
+	}
+}
+
+// MetricsHandler serves the metrics of the Metrics given to the router with
+// WithMetrics, or answers 404 when there is none:
+//
+//	router := stdlib.NewRouter(mux, api.WithMetrics(metrics.New(metrics.Config{})))
+//	mux.Handle("GET /metrics", router.MetricsHandler())
+func (r *TypedRouter[T]) MetricsHandler() http.Handler {
+	options := &HandlerOption{}
+	for _, o := range r.middleware {
+		o(options)
+	}
+	if options.Metrics == nil {
+		return http.NotFoundHandler()
+	}
+	return options.Metrics.Handler()
+}
+
+// metricsResponseWriter records the status and size of a response.
+type metricsResponseWriter struct {
+	http.ResponseWriter
+	status           int
+	size             int64
+	validationFailed bool
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *metricsResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Flush supports streaming responses.
+func (w *metricsResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *metricsResponseWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *metricsResponseWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// countingReader counts the bytes read from a request body.
+type countingReader struct {
+	io.ReadCloser
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+// withMetrics reports every request served by next to m.
+func withMetrics(m Metrics, info *RouteInfo, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		mw := &metricsResponseWriter{ResponseWriter: w}
+		body := &countingReader{ReadCloser: http.NoBody}
+		if r.Body != nil {
+			body.ReadCloser = r.Body
+		}
+		r.Body = body
+		defer func() {
+			status := mw.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			m.ObserveRequest(info, RequestObservation{
+				Status:           status,
+				Duration:         time.Since(start),
+				RequestSize:      body.n,
+				ResponseSize:     mw.size,
+				ValidationFailed: mw.validationFailed,
+			})
+		}()
+		next(mw, r)
+	}
+}
+
+// markValidationFailed records a validation failure on the metrics writer
+// among w and the writers it wraps.
+func markValidationFailed(w http.ResponseWriter) {
+	if mw, ok := findResponseWriter[*metricsResponseWriter](w); ok {
+		mw.validationFailed = true
+	}
+}
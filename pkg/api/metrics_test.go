@@ -0,0 +1,61 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type recordingMetrics struct {
+	observations []RequestObservation
+	routes       []string
+}
+
+func (m *recordingMetrics) ObserveRequest(route *RouteInfo, obs RequestObservation) {
+	m.routes = append(m.routes, route.Method+" "+route.Path)
+	m.observations = append(m.observations, obs)
+}
+
+func (m *recordingMetrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { _, _ = w.Write([]byte("metrics")) })
+}
+
+func TestMetricsObservations(t *testing.T) {
+	type createRequest struct {
+		Body struct {
+			Name string `gork:"name" validate:"required"`
+		}
+	}
+	m := &recordingMetrics{}
+	handlers := map[string]http.HandlerFunc{}
+	router := NewTypedRouter[*struct{}](nil, NewRouteRegistry(), "/api", []Option{WithMetrics(m)}, &DefaultParameterAdapter{},
+		func(method, path string, h http.HandlerFunc, _ *RouteInfo) {
+			handlers[method+" "+path] = h
+		})
+	router.Post("/items", func(context.Context, createRequest) (*loadShedResponse, error) {
+		return &loadShedResponse{}, nil
+	})
+
+	for _, body := range []string{`{"name":"a"}`, `{}`} {
+		handlers["POST /items"](httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/items", strings.NewReader(body)))
+	}
+
+	if len(m.observations) != 2 || m.routes[0] != "POST /api/items" {
+		t.Fatalf("observations = %+v, routes = %v", m.observations, m.routes)
+	}
+	ok, invalid := m.observations[0], m.observations[1]
+	if ok.Status != http.StatusOK || ok.RequestSize != 12 || ok.ResponseSize == 0 || ok.ValidationFailed {
+		t.Errorf("valid request: %+v", ok)
+	}
+	if invalid.Status != http.StatusBadRequest || !invalid.ValidationFailed {
+		t.Errorf("invalid request: %+v", invalid)
+	}
+
+	w := httptest.NewRecorder()
+	router.MetricsHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if w.Body.String() != "metrics" {
+		t.Errorf("MetricsHandler served %q", w.Body)
+	}
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// WithMiddleware wraps the handler of a route (or of every route, when passed
+// to a router or group) with standard net/http middleware. Middleware given
+// earlier, including middleware inherited from enclosing groups, runs first.
+//
+//	v1 := router.Group("/v1", api.WithTags("v1"), api.WithMiddleware(auth, logging))
+func WithMiddleware(middleware ...func(http.Handler) http.Handler) Option {
+	return func(h *HandlerOption) {
+		h.Middleware = append(h.Middleware, middleware...)
+	}
+}
+
+// applyMiddleware wraps next so that middleware[0] is the outermost layer.
+func applyMiddleware(middleware []func(http.Handler) http.Handler, next http.HandlerFunc) http.HandlerFunc {
+	var h http.Handler = next
+	for i := len(middleware) - 1; i >= 0; i-- {
+		h = middleware[i](h)
+	}
+	return h.ServeHTTP
+}
+
+// Group returns a sub-router whose routes are registered under prefix and
+// inherit the router's options followed by opts. Routes share the registry,
+// so they appear with their full path in the generated spec.
+func (r *TypedRouter[T]) Group(prefix string, opts ...Option) TypedRouter[T] {
+	middleware := append(r.CopyMiddleware(), opts...)
+	var registerFn func(method, path string, handler http.HandlerFunc, info *RouteInfo)
+	if parent := r.registerFn; parent != nil {
+		registerFn = func(method, path string, handler http.HandlerFunc, info *RouteInfo) {
+			parent(method, prefix+path, handler, info)
+		}
+	}
+	return NewTypedRouter[T](r.underlying, r.registry, r.prefix+prefix, middleware, r.adapter, registerFn)
+}
+
+// Next continues a typed middleware chain with req, ending in the route's
+// handler, and returns the handler's response.
+type Next func(ctx context.Context, req any) (any, error)
+
+// TypedMiddleware runs around a route's handler after the request has been
+// parsed and validated and before the response is written. req is a pointer
+// to the request struct (e.g. *CreateUserRequest) and the response is the
+// handler's own return value (nil for error-only handlers). Middleware may
+// replace either, as long as the replacement has the same type.
+type TypedMiddleware func(ctx context.Context, req any, next Next) (any, error)
+
+// WithTypedMiddleware wraps the handler of a route (or of every route, when
+// passed to a router or group) with typed middleware. Like WithMiddleware,
+// middleware given earlier runs first.
+//
+//	router.Post("/users", CreateUser, api.WithTypedMiddleware(func(ctx context.Context, req any, next api.Next) (any, error) {
+//		if r, ok := req.(*CreateUserRequest); ok {
+//			r.Body.Email = strings.ToLower(r.Body.Email)
+//		}
+//		return next(ctx, req)
+//	}))
+func WithTypedMiddleware(middleware ...TypedMiddleware) Option {
+	return func(h *HandlerOption) {
+		h.TypedMiddleware = append(h.TypedMiddleware, middleware...)
+	}
+}
+
+// applyTypedMiddleware returns a function of handler's type that runs
+// middleware around handler, middleware[0] outermost.
+func applyTypedMiddleware(middleware []TypedMiddleware, handler reflect.Value) reflect.Value {
+	t := handler.Type()
+	reqType := t.In(1)
+
+	var next Next = func(ctx context.Context, req any) (any, error) {
+		reqVal := reflect.ValueOf(req)
+		if !reqVal.IsValid() || reqVal.Type() != reflect.PtrTo(reqType) || reqVal.IsNil() {
+			return nil, fmt.Errorf("typed middleware passed %T to the handler, want *%s", req, reqType)
+		}
+		results := handler.Call([]reflect.Value{reflect.ValueOf(ctx), reqVal.Elem()})
+		err, _ := results[len(results)-1].Interface().(error)
+		if len(results) == 1 {
+			return nil, err
+		}
+		return results[0].Interface(), err
+	}
+	for i := len(middleware) - 1; i >= 0; i-- {
+		mw, inner := middleware[i], next
+		next = func(ctx context.Context, req any) (any, error) {
+			return mw(ctx, req, inner)
+		}
+	}
+
+	return reflect.MakeFunc(t, func(args []reflect.Value) []reflect.Value {
+		reqPtr := reflect.New(reqType)
+		reqPtr.Elem().Set(args[1])
+		resp, err := next(args[0].Interface().(context.Context), reqPtr.Interface())
+
+		out := make([]reflect.Value, t.NumOut())
+		for i := range out {
+			out[i] = reflect.New(t.Out(i)).Elem()
+		}
+		if len(out) == 2 && resp != nil {
+			if rv := reflect.ValueOf(resp); rv.Type().AssignableTo(t.Out(0)) {
+				out[0].Set(rv)
+			} else if err == nil {
+				err = fmt.Errorf("typed middleware returned %T, want %s", resp, t.Out(0))
+			}
+		}
+		if err != nil {
+			out[len(out)-1].Set(reflect.ValueOf(err))
+		}
+		return out
+	})
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func headerMiddleware(value string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("X-Trace", value)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func TestTypedRouterGroup(t *testing.T) {
+	router, registry, handlers := newLoadShedRouter(WithMiddleware(headerMiddleware("root")))
+	v1 := router.Group("/v1", WithTags("v1"), WithMiddleware(headerMiddleware("v1")))
+	users := v1.Group("/users", WithMiddleware(headerMiddleware("users")))
+	users.Get("/{id}", func(context.Context, loadShedRequest) (*loadShedResponse, error) {
+		return &loadShedResponse{}, nil
+	}, WithMiddleware(headerMiddleware("route")))
+
+	h := handlers["GET /v1/users/{id}"]
+	if h == nil {
+		t.Fatalf("expected the group prefix to reach the underlying router, got %v", handlers)
+	}
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/api/v1/users/1", nil))
+	if got := strings.Join(w.Header().Values("X-Trace"), ","); got != "root,v1,users,route" {
+		t.Errorf("expected middleware outermost first, got %q", got)
+	}
+
+	op := GenerateOpenAPI(registry).Paths["/api/v1/users/{id}"].Get
+	if op == nil || len(op.Tags) != 1 || op.Tags[0] != "v1" {
+		t.Errorf("expected the grouped route with inherited tags, got %+v", op)
+	}
+
+	router.Get("/health", func(context.Context, loadShedRequest) (*loadShedResponse, error) { return nil, nil })
+	if ops := GenerateOpenAPI(registry).Paths["/api/health"].Get; len(ops.Tags) != 0 {
+		t.Errorf("group options must not leak into the parent router, got %v", ops.Tags)
+	}
+}
+
+type typedMiddlewareRequest struct {
+	Body struct {
+		Name string `gork:"name" validate:"required"`
+	}
+}
+
+func TestTypedMiddleware(t *testing.T) {
+	var trace []string
+	record := func(name string) TypedMiddleware {
+		return func(ctx context.Context, req any, next Next) (any, error) {
+			trace = append(trace, name+":"+req.(*typedMiddlewareRequest).Body.Name)
+			return next(ctx, req)
+		}
+	}
+	upper := func(ctx context.Context, req any, next Next) (any, error) {
+		r := req.(*typedMiddlewareRequest)
+		r.Body.Name = strings.ToUpper(r.Body.Name)
+		resp, err := next(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		resp.(*loadShedResponse).Body.Name += "!"
+		return resp, nil
+	}
+	router, _, handlers := newLoadShedRouter(WithTypedMiddleware(record("root")))
+	router.Post("/greet", func(_ context.Context, req typedMiddlewareRequest) (*loadShedResponse, error) {
+		resp := &loadShedResponse{}
+		resp.Body.Name = "hello " + req.Body.Name
+		return resp, nil
+	}, WithTypedMiddleware(upper, record("route")))
+
+	w := httptest.NewRecorder()
+	handlers["POST /greet"](w, httptest.NewRequest(http.MethodPost, "/api/greet", strings.NewReader(`{"name":"ada"}`)))
+	if got := strings.TrimSpace(w.Body.String()); w.Code != http.StatusOK || got != `{"name":"hello ADA!"}` {
+		t.Errorf("got %d %s", w.Code, got)
+	}
+	if got := strings.Join(trace, ","); got != "root:ada,route:ADA" {
+		t.Errorf("expected middleware outermost first, got %q", got)
+	}
+
+	// Middleware only sees requests that parsed and validated.
+	trace = nil
+	w = httptest.NewRecorder()
+	handlers["POST /greet"](w, httptest.NewRequest(http.MethodPost, "/api/greet", strings.NewReader(`{}`)))
+	if w.Code != http.StatusBadRequest || len(trace) != 0 {
+		t.Errorf("got %d with trace %v", w.Code, trace)
+	}
+}
+
+func TestTypedMiddlewareErrors(t *testing.T) {
+	denied := errors.New("denied")
+	router, _, handlers := newLoadShedRouter()
+	router.Delete("/items", func(context.Context, struct{}) error {
+		t.Error("handler must not run")
+		return nil
+	}, WithTypedMiddleware(func(context.Context, any, Next) (any, error) { return nil, denied }))
+	router.Get("/items", func(context.Context, struct{}) (*loadShedResponse, error) {
+		return &loadShedResponse{}, nil
+	}, WithTypedMiddleware(func(ctx context.Context, req any, next Next) (any, error) {
+		_, _ = next(ctx, req)
+		return "not a response", nil
+	}))
+
+	for _, method := range []string{http.MethodDelete, http.MethodGet} {
+		w := httptest.NewRecorder()
+		handlers[method+" /items"](w, httptest.NewRequest(method, "/api/items", nil))
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("%s: expected 500, got %d %s", method, w.Code, w.Body)
+		}
+	}
+}
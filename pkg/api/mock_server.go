@@ -0,0 +1,98 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// NewMockServer returns a handler answering the routes of registry with
+// their example responses, registered with WithResponseExample, instead of
+// calling their handlers. A request is answered with:
+//
+//   - the example named by a `Prefer: example=<name>` header;
+//   - otherwise the example named like the request example (WithExample)
+//     the request binds to;
+//   - otherwise the first example of the route.
+//
+// Routes without example responses answer 501. Paths are matched with an
+// http.ServeMux, so the registered paths must be valid ServeMux patterns.
+func NewMockServer(registry *RouteRegistry) http.Handler {
+	mux := http.NewServeMux()
+	factory := NewConventionHandlerFactory()
+	parser := NewConventionParser()
+	for _, route := range registry.GetRoutes() {
+		mux.HandleFunc(route.Method+" "+route.Path, func(w http.ResponseWriter, r *http.Request) {
+			ex, ok := mockResponse(parser, route, r)
+			if !ok {
+				writeError(w, http.StatusNotImplemented, fmt.Sprintf("no example response for %s %s", route.Method, route.Path))
+				return
+			}
+			factory.writeResponse(w, r, reflect.ValueOf(ex.Response), false)
+		})
+	}
+	return mux
+}
+
+// mockResponse selects the example response answering r.
+func mockResponse(parser *ConventionParser, route *RouteInfo, r *http.Request) (ResponseExample, bool) {
+	if route.Options == nil || len(route.Options.ResponseExamples) == 0 {
+		return ResponseExample{}, false
+	}
+	examples := route.Options.ResponseExamples
+	name, preferred := preferredExample(r)
+	if !preferred {
+		name = matchRequestExample(parser, route, r)
+	}
+	for _, ex := range examples {
+		if ex.Name == name {
+			return ex, true
+		}
+	}
+	if preferred {
+		return ResponseExample{}, false
+	}
+	return examples[0], true
+}
+
+// preferredExample returns the example asked for with `Prefer: example=<name>`.
+func preferredExample(r *http.Request) (string, bool) {
+	for _, value := range r.Header.Values("Prefer") {
+		for _, pref := range strings.Split(value, ",") {
+			key, name, ok := strings.Cut(strings.TrimSpace(pref), "=")
+			if ok && strings.EqualFold(key, "example") {
+				return strings.Trim(name, `"`), true
+			}
+		}
+	}
+	return "", false
+}
+
+// matchRequestExample returns the name of the request example r binds to,
+// or "" when it binds to none of them.
+func matchRequestExample(parser *ConventionParser, route *RouteInfo, r *http.Request) string {
+	if len(route.Options.Examples) == 0 || route.RequestType.Kind() != reflect.Struct {
+		return ""
+	}
+	reqPtr := reflect.New(route.RequestType)
+	if err := parser.ParseRequest(context.Background(), r, reqPtr, mockParameterAdapter{}); err != nil {
+		return ""
+	}
+	for _, ex := range route.Options.Examples {
+		if reflect.DeepEqual(reqPtr.Elem().Interface(), ex.Request) {
+			return ex.Name
+		}
+	}
+	return ""
+}
+
+// mockParameterAdapter reads path parameters matched by the mock server's
+// http.ServeMux.
+type mockParameterAdapter struct{ HTTPParameterAdapter }
+
+func (mockParameterAdapter) Path(r *http.Request, key string) (string, bool) {
+	v := r.PathValue(key)
+	return v, v != ""
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"maps"
+	"slices"
+)
+
+// Mount serves the routes of registry, typically exposed by a library as
+//
+//	func Routes() *api.RouteRegistry
+//
+// under prefix. Each route is registered on r with its original handler and
+// options, followed by the router's options and opts, so it is served by the
+// host router and documented in the specs generated from r's registry. The
+// security schemes declared on registry are declared on r's registry too,
+// unless a scheme with the same name is already declared there.
+//
+// A library builds its registry with any router, for example:
+//
+//	func Routes() *api.RouteRegistry {
+//		router := stdlib.NewRouter(nil)
+//		router.Get("/invoices/{id}", GetInvoice)
+//		return router.GetRegistry()
+//	}
+func (r *TypedRouter[T]) Mount(prefix string, registry *RouteRegistry, opts ...Option) {
+	r.registry.mergeSecuritySchemes(registry)
+	for _, route := range registry.GetRoutes() {
+		allOpts := []Option{restoreOptions(route.Options)}
+		allOpts = append(allOpts, r.middleware...)
+		allOpts = append(allOpts, opts...)
+		r.register(route.Method, prefix+route.Path, route.Handler, allOpts)
+	}
+}
+
+// restoreOptions returns an Option resetting a route's options to o. Slices
+// and maps are copied so that options applied afterwards leave o untouched.
+func restoreOptions(o *HandlerOption) Option {
+	return func(h *HandlerOption) {
+		if o == nil {
+			return
+		}
+		*h = *o
+		h.Tags = slices.Clone(o.Tags)
+		h.Security = slices.Clone(o.Security)
+		h.Examples = slices.Clone(o.Examples)
+		h.ErrorResponses = slices.Clone(o.ErrorResponses)
+		h.Middleware = slices.Clone(o.Middleware)
+		h.TypedMiddleware = slices.Clone(o.TypedMiddleware)
+		h.PathPatterns = maps.Clone(o.PathPatterns)
+	}
+}
+
+// mergeSecuritySchemes declares the security schemes of other that r does
+// not declare yet.
+func (r *RouteRegistry) mergeSecuritySchemes(other *RouteRegistry) {
+	other.mu.RLock()
+	schemes := maps.Clone(other.securitySchemes)
+	other.mu.RUnlock()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for name, declared := range schemes {
+		if _, ok := r.securitySchemes[name]; ok {
+			continue
+		}
+		if r.securitySchemes == nil {
+			r.securitySchemes = map[string]declaredSecurityScheme{}
+		}
+		r.securitySchemes[name] = declared
+		r.revision++
+	}
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+// billingRoutes stands in for a library exposing its routes as a registry.
+func billingRoutes() *RouteRegistry {
+	registry := NewRouteRegistry()
+	router := NewTypedRouter[*struct{}](nil, registry, "", nil, &mockTypedRouterAdapter{}, nil)
+	router.DocumentSecurityScheme("billingKey", SecurityScheme{Type: "apiKey", In: "header", Name: "X-Billing-Key"})
+	router.Get("/invoices", func(context.Context, struct{}) (*loadShedResponse, error) {
+		resp := &loadShedResponse{}
+		resp.Body.Name = "invoice"
+		return resp, nil
+	}, WithTags("billing"), WithSecurity("billingKey"))
+	return registry
+}
+
+func TestMount(t *testing.T) {
+	billing := billingRoutes()
+	router, registry, handlers := newLoadShedRouter(WithTags("host"))
+	router.Get("/health", func(context.Context, struct{}) (*loadShedResponse, error) { return nil, nil })
+	router.Mount("/billing", billing)
+
+	h := handlers["GET /billing/invoices"]
+	if h == nil {
+		t.Fatalf("expected mounted handler, got %v", handlers)
+	}
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/api/billing/invoices", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, body = %s", w.Code, w.Body)
+	}
+
+	spec := GenerateOpenAPI(registry)
+	op := spec.Paths["/api/billing/invoices"]
+	if op == nil || spec.Paths["/api/health"] == nil {
+		t.Fatalf("expected both host and mounted routes, got %v", spec.Paths)
+	}
+	if !slices.Equal(op.Get.Tags, []string{"billing", "host"}) {
+		t.Errorf("tags = %v", op.Get.Tags)
+	}
+	if len(op.Get.Security) != 1 || spec.Components.SecuritySchemes["billingKey"] == nil {
+		t.Errorf("expected the mounted security scheme, got %v %v", op.Get.Security, spec.Components.SecuritySchemes)
+	}
+
+	// The library's registry is left as it was
+	routes := billing.GetRoutes()
+	if len(routes) != 1 || routes[0].Path != "/invoices" || !slices.Equal(routes[0].Options.Tags, []string{"billing"}) {
+		t.Errorf("mounted registry changed: %+v", routes[0])
+	}
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+)
+
+// ContentTypeMultipartFormData is the media type of multipart form uploads.
+const ContentTypeMultipartFormData = "multipart/form-data"
+
+// DefaultMultipartMemory is the number of bytes of a multipart body kept in
+// memory while parsing; larger file parts are stored in temporary files.
+var DefaultMultipartMemory int64 = 32 << 20
+
+// UploadedFile is a file received in a multipart/form-data Body section.
+// Fields of this type (or *UploadedFile, []UploadedFile) are populated from
+// the part named by the field's gork tag and documented as binary strings.
+type UploadedFile struct {
+	Filename    string
+	ContentType string
+	Size        int64
+
+	header *multipart.FileHeader
+}
+
+// Open returns the file contents. The caller must close it.
+func (f UploadedFile) Open() (multipart.File, error) {
+	if f.header == nil {
+		return nil, fmt.Errorf("uploaded file %q has no content", f.Filename)
+	}
+	return f.header.Open()
+}
+
+var uploadedFileType = reflect.TypeOf(UploadedFile{})
+
+func newUploadedFile(h *multipart.FileHeader) UploadedFile {
+	return UploadedFile{
+		Filename:    h.Filename,
+		ContentType: h.Header.Get("Content-Type"),
+		Size:        h.Size,
+		header:      h,
+	}
+}
+
+// isMultipartRequest reports whether the request body is multipart/form-data.
+func isMultipartRequest(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == ContentTypeMultipartFormData
+}
+
+// isUploadedFileField reports whether t is UploadedFile, *UploadedFile or
+// []UploadedFile.
+func isUploadedFileField(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	return t == uploadedFileType
+}
+
+// setUploadedFiles populates an UploadedFile field from the file parts
+// stored under key. It reports whether any part was found.
+func setUploadedFiles(fieldValue reflect.Value, files []*multipart.FileHeader) bool {
+	if len(files) == 0 {
+		return false
+	}
+	switch fieldValue.Kind() {
+	case reflect.Slice:
+		out := reflect.MakeSlice(fieldValue.Type(), len(files), len(files))
+		for i, h := range files {
+			out.Index(i).Set(reflect.ValueOf(newUploadedFile(h)))
+		}
+		fieldValue.Set(out)
+	case reflect.Ptr:
+		f := newUploadedFile(files[0])
+		fieldValue.Set(reflect.ValueOf(&f))
+	default:
+		fieldValue.Set(reflect.ValueOf(newUploadedFile(files[0])))
+	}
+	return true
+}
+
+// hasUploadedFiles reports whether a Body section type contains file fields,
+// in which case it is documented as multipart/form-data.
+func hasUploadedFiles(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if isUploadedFileField(t.Field(i).Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// BinaryTypeHandler documents UploadedFile as a binary string.
+type BinaryTypeHandler struct{}
+
+// CanHandle returns true if this handler can process the given type.
+func (b *BinaryTypeHandler) CanHandle(t reflect.Type) bool {
+	return t == uploadedFileType
+}
+
+// GenerateSchema generates a schema for binary types.
+func (b *BinaryTypeHandler) GenerateSchema(_ reflect.Type, _ map[string]*Schema, _ bool) *Schema {
+	return &Schema{Type: "string", Format: "binary"}
+}
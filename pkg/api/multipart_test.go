@@ -0,0 +1,115 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+type uploadAvatarRequest struct {
+	Body struct {
+		Caption     string         `gork:"caption"`
+		Avatar      UploadedFile   `gork:"avatar"`
+		Thumbnail   *UploadedFile  `gork:"thumbnail"`
+		Attachments []UploadedFile `gork:"attachments"`
+	}
+}
+
+func newMultipartRequest(t *testing.T, fields map[string]string, files map[string][]string) *http.Request {
+	t.Helper()
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for k, v := range fields {
+		_ = w.WriteField(k, v)
+	}
+	for k, contents := range files {
+		for _, c := range contents {
+			part, err := w.CreateFormFile(k, k+".txt")
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, _ = part.Write([]byte(c))
+		}
+	}
+	_ = w.Close()
+	r := httptest.NewRequest(http.MethodPost, "/avatar", &buf)
+	r.Header.Set("Content-Type", w.FormDataContentType())
+	return r
+}
+
+func TestParseMultipartBody(t *testing.T) {
+	r := newMultipartRequest(t,
+		map[string]string{"caption": "me"},
+		map[string][]string{"avatar": {"png-bytes"}, "attachments": {"a", "bb"}})
+
+	var req uploadAvatarRequest
+	if err := NewConventionParser().parseBodySection(reflect.ValueOf(&req).Elem().FieldByName("Body"), r); err != nil {
+		t.Fatal(err)
+	}
+	b := req.Body
+	if b.Caption != "me" {
+		t.Errorf("expected caption, got %q", b.Caption)
+	}
+	if b.Avatar.Filename != "avatar.txt" || b.Avatar.Size != int64(len("png-bytes")) {
+		t.Errorf("unexpected avatar %+v", b.Avatar)
+	}
+	f, err := b.Avatar.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(f)
+	_ = f.Close()
+	if string(data) != "png-bytes" {
+		t.Errorf("unexpected avatar contents %q", data)
+	}
+	if b.Thumbnail != nil {
+		t.Errorf("missing optional file should stay nil, got %+v", b.Thumbnail)
+	}
+	if len(b.Attachments) != 2 || b.Attachments[1].Size != 2 {
+		t.Errorf("unexpected attachments %+v", b.Attachments)
+	}
+
+	if _, err := (UploadedFile{Filename: "x"}).Open(); err == nil {
+		t.Error("expected error opening a file without content")
+	}
+
+	var noFiles formSignupRequest
+	err = NewConventionParser().parseBodySection(reflect.ValueOf(&noFiles).Elem().FieldByName("Body"), newMultipartRequest(t, map[string]string{"email": "a@b.test"}, nil))
+	if requestErrorStatus(err) != http.StatusUnsupportedMediaType {
+		t.Errorf("expected 415 for a multipart body without file fields, got %v", err)
+	}
+
+	bad := httptest.NewRequest(http.MethodPost, "/avatar", bytes.NewBufferString("garbage"))
+	bad.Header.Set("Content-Type", "multipart/form-data; boundary=nope")
+	if err := NewConventionParser().parseBodySection(reflect.ValueOf(&req).Elem().FieldByName("Body"), bad); err == nil {
+		t.Error("expected malformed multipart error")
+	}
+}
+
+func TestUploadedFileOpenAPI(t *testing.T) {
+	registry := NewRouteRegistry()
+	router := NewTypedRouter[*struct{}](nil, registry, "", nil, &mockTypedRouterAdapter{}, nil)
+	router.Post("/avatar", func(context.Context, uploadAvatarRequest) (*exampleOrderResponse, error) { return nil, nil })
+
+	spec := GenerateOpenAPI(registry)
+	content := spec.Paths["/avatar"].Post.RequestBody.Content
+	media := content[ContentTypeMultipartFormData]
+	if media == nil || content["application/json"] != nil {
+		t.Fatalf("expected multipart-only request body, got %v", content)
+	}
+	body := spec.Components.Schemas["uploadAvatarBody"]
+	if body == nil {
+		t.Fatalf("expected body component, got %v", spec.Components.Schemas)
+	}
+	if avatar := body.Properties["avatar"]; avatar == nil || avatar.Type != "string" || avatar.Format != "binary" {
+		t.Errorf("expected binary avatar schema, got %+v", avatar)
+	}
+	if att := body.Properties["attachments"]; att == nil || att.Items == nil || att.Items.Format != "binary" {
+		t.Errorf("expected array of binary attachments, got %+v", att)
+	}
+}
@@ -0,0 +1,285 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"reflect"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gork-labs/gork/pkg/gorkson"
+)
+
+// ContentTypeNDJSON is the media type of newline-delimited JSON bodies.
+const ContentTypeNDJSON = "application/x-ndjson"
+
+// DefaultMaxStreamLineBytes is the longest NDJSON line a Stream accepts.
+var DefaultMaxStreamLineBytes = 1 << 20
+
+// Stream is a Body section holding newline-delimited JSON (NDJSON / JSON
+// Lines). The body is not buffered: each line is decoded into T and
+// validated while the handler iterates over All. Lines that fail to decode
+// or validate are skipped and reported.
+//
+// When any line was rejected and the handler succeeds, the response is
+// replaced by 207 Multi-Status with a StreamReport body listing the
+// rejected lines.
+type Stream[T any] struct {
+	// state is shared by copies of the stream, as the handler receives the
+	// request by value.
+	state *streamState
+}
+
+type streamState struct {
+	scanner  *bufio.Scanner
+	validate func(item interface{}) (map[string][]string, error)
+
+	line     int
+	accepted int
+	errors   []StreamLineError
+	err      error
+}
+
+// StreamLineError describes a rejected NDJSON line.
+type StreamLineError struct {
+	Line    int                 `json:"line"`
+	Error   string              `json:"error"`
+	Details map[string][]string `json:"details,omitempty"`
+}
+
+// StreamReport summarizes the lines read from a Stream.
+type StreamReport struct {
+	Accepted int               `json:"accepted"`
+	Rejected int               `json:"rejected"`
+	Errors   []StreamLineError `json:"errors"`
+}
+
+// All iterates over the valid items of the stream together with their line
+// numbers (starting at 1). Blank lines are ignored. The stream can only be
+// iterated once.
+func (s *Stream[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		st := s.state
+		if st == nil {
+			return
+		}
+		for st.scanner.Scan() {
+			st.line++
+			data := bytes.TrimSpace(st.scanner.Bytes())
+			if len(data) == 0 {
+				continue
+			}
+			item, ok := decodeStreamLine[T](st, data)
+			if !ok {
+				continue
+			}
+			st.accepted++
+			if !yield(st.line, item) {
+				return
+			}
+		}
+		if err := st.scanner.Err(); err != nil {
+			st.err = err
+			st.reject(st.line+1, fmt.Sprintf("failed to read line: %v", err), nil)
+		}
+	}
+}
+
+// decodeStreamLine unmarshals and validates a single line.
+func decodeStreamLine[T any](st *streamState, data []byte) (T, bool) {
+	var item T
+	if err := gorkson.Unmarshal(data, &item); err != nil {
+		st.reject(st.line, fmt.Sprintf("invalid JSON: %v", err), nil)
+		return item, false
+	}
+	if st.validate != nil {
+		details, err := st.validate(item)
+		if err != nil {
+			st.reject(st.line, err.Error(), nil)
+			return item, false
+		}
+		if len(details) > 0 {
+			st.reject(st.line, "Validation failed", details)
+			return item, false
+		}
+	}
+	return item, true
+}
+
+func (st *streamState) reject(line int, msg string, details map[string][]string) {
+	st.errors = append(st.errors, StreamLineError{Line: line, Error: msg, Details: details})
+}
+
+// Err returns the error that stopped reading the body, if any.
+func (s *Stream[T]) Err() error {
+	if s.state == nil {
+		return nil
+	}
+	return s.state.err
+}
+
+// Errors returns the lines rejected so far.
+func (s *Stream[T]) Errors() []StreamLineError {
+	if s.state == nil {
+		return nil
+	}
+	return s.state.errors
+}
+
+// Report summarizes the lines read so far.
+func (s *Stream[T]) Report() StreamReport {
+	report := StreamReport{Errors: []StreamLineError{}}
+	if s.state == nil {
+		return report
+	}
+	report.Accepted, report.Rejected = s.state.accepted, len(s.state.errors)
+	if s.state.errors != nil {
+		report.Errors = s.state.errors
+	}
+	return report
+}
+
+func (s *Stream[T]) bindBody(body io.Reader) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, min(4096, DefaultMaxStreamLineBytes)), DefaultMaxStreamLineBytes)
+	s.state = &streamState{scanner: scanner}
+}
+
+func (s *Stream[T]) bindValidator(validate func(interface{}) (map[string][]string, error)) {
+	if s.state != nil {
+		s.state.validate = validate
+	}
+}
+
+func (s *Stream[T]) streamItemType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// streamSection is implemented by *Stream[T] for every T.
+type streamSection interface {
+	bindBody(body io.Reader)
+	bindValidator(validate func(interface{}) (map[string][]string, error))
+	streamItemType() reflect.Type
+	Report() StreamReport
+}
+
+var streamSectionType = reflect.TypeOf((*streamSection)(nil)).Elem()
+
+// isNDJSONStreamType reports whether a Body section of type t is a Stream.
+func isNDJSONStreamType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && reflect.PointerTo(t).Implements(streamSectionType)
+}
+
+// asStreamSection returns the Stream held by an addressable Body section.
+func asStreamSection(sectionValue reflect.Value) (streamSection, bool) {
+	if !sectionValue.CanAddr() || !isNDJSONStreamType(sectionValue.Type()) {
+		return nil, false
+	}
+	return sectionValue.Addr().Interface().(streamSection), true
+}
+
+// validateStreamItem validates a single stream item with validate tags and
+// custom Validate methods, returning client errors keyed by field.
+func (v *ConventionValidator) validateStreamItem(ctx context.Context, item interface{}) (map[string][]string, error) {
+	details := map[string][]string{}
+	itemValue := reflect.ValueOf(item)
+	if itemValue.Kind() == reflect.Ptr && !itemValue.IsNil() {
+		itemValue = itemValue.Elem()
+	}
+	if itemValue.Kind() == reflect.Struct {
+		var verrs validator.ValidationErrors
+		if err := v.fieldValidator.Struct(item); errors.As(err, &verrs) {
+			for _, ve := range verrs {
+				path := validationErrorPath(itemValue.Type(), ve)
+				details[path] = append(details[path], ve.Tag())
+			}
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	verrs, serverErr := invokeCustomValidation(ctx, item)
+	if serverErr != nil {
+		return nil, serverErr
+	}
+	if len(verrs) > 0 {
+		details["item"] = append(details["item"], verrs...)
+	}
+	return details, nil
+}
+
+// writeStreamReport answers with 207 Multi-Status when the request streamed
+// an NDJSON body with rejected lines. It reports whether it wrote a response.
+func writeStreamReport(w http.ResponseWriter, reqPtr reflect.Value) bool {
+	body := reqPtr.Elem().FieldByName(SectionBody)
+	if !body.IsValid() {
+		return false
+	}
+	stream, ok := asStreamSection(body)
+	if !ok {
+		return false
+	}
+	report := stream.Report()
+	if report.Rejected == 0 {
+		return false
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusMultiStatus)
+	_ = json.NewEncoder(w).Encode(report)
+	return true
+}
+
+// processStreamBody documents an NDJSON request body and the 207 response
+// listing rejected lines.
+func (g *ConventionOpenAPIGenerator) processStreamBody(sectionType reflect.Type, operation *Operation, components *Components) {
+	itemType := reflect.New(sectionType).Interface().(streamSection).streamItemType()
+	operation.RequestBody = &RequestBody{
+		Required:    true,
+		Description: "Newline-delimited JSON; each line is one item",
+		Content: map[string]*MediaType{
+			ContentTypeNDJSON: {Schema: g.generateSchemaFromType(itemType, "", components)},
+		},
+	}
+
+	if components.Schemas == nil {
+		components.Schemas = map[string]*Schema{}
+	}
+	if _, exists := components.Schemas["StreamReport"]; !exists {
+		components.Schemas["StreamReport"] = &Schema{
+			Type:        "object",
+			Title:       "StreamReport",
+			Description: "Result of an NDJSON request in which some lines were rejected",
+			Properties: map[string]*Schema{
+				"accepted": {Type: "integer", Description: "Number of lines processed"},
+				"rejected": {Type: "integer", Description: "Number of lines rejected"},
+				"errors": {
+					Type: "array",
+					Items: &Schema{
+						Type: "object",
+						Properties: map[string]*Schema{
+							"line":  {Type: "integer", Description: "Line number, starting at 1"},
+							"error": {Type: "string", Description: "Error message"},
+							"details": {
+								Type:        "object",
+								Description: "Field-level validation errors (maps field names to arrays of error messages)",
+							},
+						},
+						Required: []string{"line", "error"},
+					},
+				},
+			},
+			Required: []string{"accepted", "rejected", "errors"},
+		}
+	}
+	operation.Responses["207"] = &Response{
+		Description: "Multi-Status - Some lines were rejected",
+		Content: map[string]*MediaType{
+			"application/json": {Schema: &Schema{Ref: "#/components/schemas/StreamReport"}},
+		},
+	}
+}
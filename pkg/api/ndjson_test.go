@@ -0,0 +1,103 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type ndjsonContact struct {
+	Email string `gork:"email" validate:"required,email"`
+	Name  string `gork:"name"`
+}
+
+type ndjsonImportRequest struct {
+	Query struct {
+		DryRun bool `gork:"dry_run"`
+	}
+	Body Stream[ndjsonContact]
+}
+
+type ndjsonImportResponse struct {
+	Body struct {
+		Imported int `gork:"imported"`
+	}
+}
+
+func ndjsonImport(_ context.Context, req ndjsonImportRequest) (*ndjsonImportResponse, error) {
+	resp := &ndjsonImportResponse{}
+	for range req.Body.All() {
+		resp.Body.Imported++
+	}
+	return resp, nil
+}
+
+func TestNDJSONStream(t *testing.T) {
+	h, _ := NewConventionHandlerFactory().CreateHandler(&mockConventionParameterAdapter{}, ndjsonImport)
+	send := func(body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/contacts", strings.NewReader(body))
+		r.Header.Set("Content-Type", ContentTypeNDJSON)
+		w := httptest.NewRecorder()
+		h(w, r)
+		return w
+	}
+
+	w := send("{\"email\":\"a@example.com\"}\n\n{\"email\":\"b@example.com\",\"name\":\"B\"}\n")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"imported":2`) {
+		t.Fatalf("unexpected response %d %s", w.Code, w.Body.String())
+	}
+
+	w = send("{\"email\":\"a@example.com\"}\n{\"name\":\"missing\"}\nnot json\n{\"email\":\"c@example.com\"}\n")
+	if w.Code != http.StatusMultiStatus {
+		t.Fatalf("expected 207, got %d %s", w.Code, w.Body.String())
+	}
+	var report StreamReport
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Accepted != 2 || report.Rejected != 2 || len(report.Errors) != 2 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if e := report.Errors[0]; e.Line != 2 || e.Details["email"] == nil || e.Details["email"][0] != "required" {
+		t.Errorf("unexpected validation error %+v", e)
+	}
+	if e := report.Errors[1]; e.Line != 3 || !strings.Contains(e.Error, "invalid JSON") {
+		t.Errorf("unexpected decode error %+v", e)
+	}
+}
+
+func TestNDJSONStreamLineTooLong(t *testing.T) {
+	defer func(n int) { DefaultMaxStreamLineBytes = n }(DefaultMaxStreamLineBytes)
+	DefaultMaxStreamLineBytes = 16
+
+	var req ndjsonImportRequest
+	req.Body.bindBody(strings.NewReader(`{"email":"` + strings.Repeat("a", 64) + `"}`))
+	for range req.Body.All() {
+		t.Fatal("no item expected")
+	}
+	if req.Body.Err() == nil || len(req.Body.Errors()) != 1 || req.Body.Errors()[0].Line != 1 {
+		t.Errorf("expected read error on line 1, got %v %+v", req.Body.Err(), req.Body.Errors())
+	}
+}
+
+func TestNDJSONStreamOpenAPI(t *testing.T) {
+	registry := NewRouteRegistry()
+	router := NewTypedRouter[*struct{}](nil, registry, "", nil, &mockTypedRouterAdapter{}, nil)
+	router.Post("/contacts", ndjsonImport)
+
+	spec := GenerateOpenAPI(registry)
+	op := spec.Paths["/contacts"].Post
+	media := op.RequestBody.Content[ContentTypeNDJSON]
+	if media == nil || media.Schema == nil || media.Schema.Ref != "#/components/schemas/ndjsonContact" {
+		t.Fatalf("expected NDJSON item schema, got %+v", op.RequestBody.Content)
+	}
+	if multi := op.Responses["207"]; multi == nil || multi.Content["application/json"].Schema.Ref != "#/components/schemas/StreamReport" {
+		t.Errorf("expected 207 StreamReport response, got %+v", op.Responses)
+	}
+	if op.Responses["200"] == nil || spec.Components.Schemas["StreamReport"] == nil {
+		t.Errorf("expected 200 response and StreamReport component")
+	}
+}
@@ -29,9 +29,11 @@ const (
 
 // GenerateOpenAPIWithDocs combines route information from the given registry
 // with documentation parsed by DocExtractor to enrich operation and schema
-// descriptions. The function delegates the core generation work to
+// descriptions. Enums found by the extractor are registered first (see
+// RegisterEnumsFromDocs). The function delegates the core generation work to
 // GenerateOpenAPI and then post-processes the specification.
 func GenerateOpenAPIWithDocs(reg *RouteRegistry, extractor *DocExtractor, opts ...OpenAPIOption) *OpenAPISpec {
+	RegisterEnumsFromDocs(extractor)
 	spec := GenerateOpenAPI(reg, opts...)
 	if extractor == nil {
 		return spec
@@ -49,7 +51,13 @@ func GenerateOpenAPIWithDocs(reg *RouteRegistry, extractor *DocExtractor, opts .
 
 func enrichComponentSchemas(spec *OpenAPISpec, extractor *DocExtractor) {
 	for name, schema := range spec.Components.Schemas {
-		enrichSchemaWithTypeDoc(schema, name, extractor)
+		// Components renamed to avoid collisions keep the Go type name
+		// as their title.
+		typeName := name
+		if doc := extractor.ExtractTypeDoc(name); doc.Description == "" && len(doc.Fields) == 0 && schema.Title != "" {
+			typeName = schema.Title
+		}
+		enrichSchemaWithTypeDoc(schema, typeName, extractor)
 	}
 }
 
@@ -60,6 +68,9 @@ func enrichSchemaWithTypeDoc(schema *Schema, typeName string, extractor *DocExtr
 	if doc.Description != "" {
 		schema.Description = doc.Description
 	}
+	if doc.Example != "" && schema.Example == nil {
+		schema.Example = docExampleValue(schema, doc.Example)
+	}
 	enrichSchemaPropertiesWithDocs(schema, doc)
 
 	// Check if we still have properties without descriptions that might come from embedded types
@@ -80,10 +91,18 @@ func enrichSchemaPropertiesWithDocs(schema *Schema, doc Documentation) {
 			if propSchema.Description == "" {
 				propSchema.Description = fd.Description
 			}
+			applyDocExample(propSchema, fd)
 		}
 	}
 }
 
+// applyDocExample sets a field's doc comment example unless a tag already did.
+func applyDocExample(propSchema *Schema, fieldDoc FieldDoc) {
+	if fieldDoc.Example != "" && propSchema.Example == nil {
+		propSchema.Example = docExampleValue(propSchema, fieldDoc.Example)
+	}
+}
+
 // TypeDocExtractor defines the interface needed for enrichFromEmbeddedTypes.
 type TypeDocExtractor interface {
 	GetAllTypeNames() []string
@@ -190,6 +209,7 @@ func tryEnrichFromType(propsNeedingDocs map[string]*Schema, typeDoc Documentatio
 	for propName, propSchema := range propsNeedingDocs {
 		if fieldDoc, hasDoc := typeDoc.Fields[propName]; hasDoc {
 			propSchema.Description = fieldDoc.Description
+			applyDocExample(propSchema, fieldDoc)
 		}
 	}
 	return true
@@ -208,11 +228,10 @@ func countFieldMatches(propsNeedingDocs map[string]*Schema, typeDoc Documentatio
 
 func enrichPathOperations(spec *OpenAPISpec, extractor *DocExtractor) {
 	for _, item := range spec.Paths {
-		updateOperationWithDocs(item.Get, extractor)
-		updateOperationWithDocs(item.Post, extractor)
-		updateOperationWithDocs(item.Put, extractor)
-		updateOperationWithDocs(item.Patch, extractor)
-		updateOperationWithDocs(item.Delete, extractor)
+		for _, op := range []*Operation{item.Get, item.Post, item.Put, item.Patch, item.Delete} {
+			updateOperationWithDocs(op, extractor)
+			enrichBodiesWithSectionDocs(op, spec.Components, extractor)
+		}
 	}
 }
 
@@ -220,10 +239,7 @@ func updateOperationWithDocs(op *Operation, extractor *DocExtractor) {
 	if op == nil || extractor == nil {
 		return
 	}
-	doc := extractor.ExtractFunctionDoc(op.OperationID)
-	if doc.Description != "" {
-		op.Description = doc.Description
-	}
+	applyFunctionDocs(op, extractor.ExtractFunctionDoc(op.OperationID))
 
 	// Enhance parameters with documentation
 	enrichParametersWithDocs(op, extractor)
@@ -235,20 +251,94 @@ func enrichParametersWithDocs(op *Operation, extractor *DocExtractor) {
 		return
 	}
 
-	// Try to find request type documentation by looking for types that match the handler pattern
-	// Convention: handlers like "GetUser" typically have request types like "GetUserRequest"
-	requestTypeName := op.OperationID + "Request"
-	requestDoc := extractor.ExtractTypeDoc(requestTypeName)
+	requestDoc := extractor.ExtractTypeDoc(extractor.requestTypeName(op.OperationID))
 
 	if len(requestDoc.Fields) == 0 {
 		return // No field documentation available
 	}
 
-	// Enhance each parameter with field documentation
+	// Enhance each parameter with field documentation, preferring the doc of
+	// the field in the parameter's own section.
 	for i := range op.Parameters {
 		param := &op.Parameters[i]
-		if fieldDoc, hasDoc := requestDoc.Fields[param.Name]; hasDoc {
+		fieldDoc, hasDoc := parameterFieldDoc(requestDoc.Sections[parameterSections[param.In]], param)
+		if !hasDoc {
+			fieldDoc, hasDoc = parameterFieldDoc(requestDoc.Fields, param)
+		}
+		if hasDoc {
 			param.Description = fieldDoc.Description
+			if fieldDoc.Example != "" && param.Example == nil {
+				param.Example = docExampleValue(param.Schema, fieldDoc.Example)
+			}
+		}
+	}
+}
+
+// parameterFieldDoc returns the doc of the field bound to param. Header
+// names are case-insensitive, and documented in their canonical form
+// whatever the casing of the tag.
+func parameterFieldDoc(docs map[string]FieldDoc, param *Parameter) (FieldDoc, bool) {
+	if fieldDoc, ok := docs[param.Name]; ok || param.In != "header" {
+		return fieldDoc, ok
+	}
+	for name, fieldDoc := range docs {
+		if strings.EqualFold(name, param.Name) {
+			return fieldDoc, true
+		}
+	}
+	return FieldDoc{}, false
+}
+
+// parameterSections maps parameter locations to request sections.
+var parameterSections = map[string]string{"query": "Query", "path": "Path", "header": "Headers", "cookie": "Cookies"}
+
+// enrichBodiesWithSectionDocs describes the properties of the request and
+// success response bodies with the field docs of the handler's Body
+// sections. These are the most specific docs for a property, so they replace
+// descriptions guessed from other types.
+func enrichBodiesWithSectionDocs(op *Operation, components *Components, extractor *DocExtractor) {
+	if op == nil || extractor == nil {
+		return
+	}
+	types := extractor.handlers[op.OperationID]
+	if op.RequestBody != nil {
+		bodyDocs := extractor.ExtractTypeDoc(extractor.requestTypeName(op.OperationID)).Sections["Body"]
+		for _, media := range op.RequestBody.Content {
+			applySectionDocs(resolveComponentSchema(media.Schema, components), bodyDocs)
+		}
+	}
+	if types.response == "" {
+		return
+	}
+	bodyDocs := extractor.ExtractTypeDoc(types.response).Sections["Body"]
+	for status, resp := range op.Responses {
+		if resp == nil || !strings.HasPrefix(status, "2") {
+			continue
+		}
+		for _, media := range resp.Content {
+			applySectionDocs(resolveComponentSchema(media.Schema, components), bodyDocs)
+		}
+	}
+}
+
+// resolveComponentSchema follows a $ref into the components.
+func resolveComponentSchema(s *Schema, components *Components) *Schema {
+	if s == nil || s.Ref == "" || components == nil {
+		return s
+	}
+	return components.Schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+}
+
+func applySectionDocs(schema *Schema, fieldDocs map[string]FieldDoc) {
+	if schema == nil || len(fieldDocs) == 0 {
+		return
+	}
+	for propName, propSchema := range schema.Properties {
+		if fd, ok := fieldDocs[propName]; ok && propSchema != nil {
+			if fd.Description != "" {
+				propSchema.Description = fd.Description
+			}
+			applyDocExample(propSchema, fd)
 		}
 	}
 }
@@ -5,6 +5,9 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/gork-labs/gork/pkg/gorkson"
 )
 
 // defaultRouteFilter excludes the internal documentation endpoint (whose
@@ -23,8 +26,36 @@ func defaultRouteFilter(info *RouteInfo) bool {
 // specification. The implementation focuses on the essential structure needed
 // by clients; we will enrich it iteratively.
 func GenerateOpenAPI(registry *RouteRegistry, opts ...OpenAPIOption) *OpenAPISpec {
+	spec := newSpec(registry, opts)
+	routes := specRoutes(spec, registry)
+
+	if spec.parallelism > 1 && spec.explain == nil {
+		assembleFragments(spec, registry, buildFragments(spec, registry, routes, spec.Components.clone()))
+		return spec
+	}
+
+	for _, route := range routes {
+		op, ok := buildRouteOperation(spec, registry, route)
+		if !ok {
+			continue
+		}
+		addRouteOperation(spec, route, op)
+	}
+	settleSchemaNames(spec)
+	ApplyCapturedExamples(spec, spec.capturedExamples)
+	applySpecAudience(spec)
+	if isOpenAPI30(spec) {
+		ConvertToOpenAPI30(spec)
+	}
+
+	return spec
+}
+
+// newSpec returns an empty spec with the registry's security schemes and
+// opts applied.
+func newSpec(registry *RouteRegistry, opts []OpenAPIOption) *OpenAPISpec {
 	spec := &OpenAPISpec{
-		OpenAPI: "3.1.0",
+		OpenAPI: OpenAPIVersion31,
 		Info: Info{
 			Title:   "Generated API",
 			Version: "0.1.0",
@@ -33,36 +64,61 @@ func GenerateOpenAPI(registry *RouteRegistry, opts ...OpenAPIOption) *OpenAPISpe
 		Components: &Components{
 			Schemas: map[string]*Schema{},
 		},
+		Tags: registeredTags(),
+	}
+
+	if schemes := registry.SecuritySchemes(); len(schemes) > 0 {
+		spec.Components.SecuritySchemes = schemes
 	}
 
 	// apply user options
 	for _, o := range opts {
 		o(spec)
 	}
+	return spec
+}
 
-	// Determine active filter (user-provided or default)
+// specRoutes returns the routes of registry that pass the spec's route
+// filter (user-provided or default). Internal routes are left out unless
+// the spec includes them (see WithIncludeInternal).
+func specRoutes(spec *OpenAPISpec, registry *RouteRegistry) []*RouteInfo {
 	routeFilter := spec.routeFilter
 	if routeFilter == nil {
 		routeFilter = defaultRouteFilter
 	}
-
+	var routes []*RouteInfo
 	for _, route := range registry.GetRoutes() {
-		if !routeFilter(route) {
+		if isInternalRoute(route) && !spec.includeInternal {
 			continue
 		}
-		path := normalizePath(route.Path)
-		if spec.Paths[path] == nil {
-			spec.Paths[path] = &PathItem{}
+		if routeFilter(route) {
+			routes = append(routes, route)
 		}
-		generator := NewConventionOpenAPIGenerator(spec, NewDocExtractor())
-		op := generator.buildConventionOperation(route, spec.Components)
+	}
+	return routes
+}
 
-		// Security mapping
-		applySecurityToOperation(route, spec, op)
-		attachOperation(spec.Paths[path], strings.ToLower(route.Method), op)
+// buildRouteOperation generates the operation of a single route. In
+// error-collect mode (WithGenerationErrors) a route violating the conventions
+// is recorded and skipped instead of panicking.
+func buildRouteOperation(spec *OpenAPISpec, registry *RouteRegistry, route *RouteInfo) (op *Operation, ok bool) {
+	if spec.generationErrors != nil {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				spec.generationErrors.add(route, recovered)
+				op, ok = nil, false
+			}
+		}()
 	}
+	generator := NewConventionOpenAPIGenerator(spec, NewDocExtractor())
+	op = generator.buildConventionOperation(route, spec.Components)
 
-	return spec
+	// Security mapping
+	applySecurityToOperation(route, spec, op)
+	generator.addAuthResponses(registry, route, spec.Components, op)
+	generator.addTokenAuth(route, spec.Components, op)
+	applyProblemJSON(route, op, spec.Components)
+	return op, true
 }
 
 func applySecurityToOperation(route *RouteInfo, spec *OpenAPISpec, op *Operation) {
@@ -75,6 +131,12 @@ func applySecurityToOperation(route *RouteInfo, spec *OpenAPISpec, op *Operation
 	}
 
 	for _, sec := range route.Options.Security {
+		if sec.Scheme != "" {
+			// Declared schemes are already part of the components.
+			scopes := append([]string{}, sec.Scopes...)
+			op.Security = append(op.Security, map[string][]string{sec.Scheme: scopes})
+			continue
+		}
 		var schemeName string
 		var scheme SecurityScheme
 		switch sec.Type {
@@ -178,6 +240,7 @@ func makeNullableSchema(originalSchema *Schema) *Schema {
 			Types:       []string{originalSchema.Type, "null"},
 			Description: originalSchema.Description,
 			Title:       originalSchema.Title,
+			Format:      originalSchema.Format,
 			Minimum:     originalSchema.Minimum,
 			Maximum:     originalSchema.Maximum,
 			MinLength:   originalSchema.MinLength,
@@ -185,6 +248,16 @@ func makeNullableSchema(originalSchema *Schema) *Schema {
 			Pattern:     originalSchema.Pattern,
 			Enum:        originalSchema.Enum,
 			Items:       originalSchema.Items,
+
+			MinItems:      originalSchema.MinItems,
+			MaxItems:      originalSchema.MaxItems,
+			UniqueItems:   originalSchema.UniqueItems,
+			MinProperties: originalSchema.MinProperties,
+			MaxProperties: originalSchema.MaxProperties,
+
+			ExclusiveMinimum:     originalSchema.ExclusiveMinimum,
+			ExclusiveMaximum:     originalSchema.ExclusiveMaximum,
+			AdditionalProperties: originalSchema.AdditionalProperties,
 		}
 	}
 
@@ -210,6 +283,7 @@ func handleUnionType(t reflect.Type, registry map[string]*Schema) *Schema {
 	if typeName != "" {
 		// Choose a human-friendly unique name (guaranteed non-empty since typeName != "")
 		unique := uniqueSchemaNameForType(t, registry)
+		u.goType = t
 		registry[unique] = u
 		return &Schema{Ref: "#/components/schemas/" + unique}
 	}
@@ -217,24 +291,43 @@ func handleUnionType(t reflect.Type, registry map[string]*Schema) *Schema {
 }
 
 func checkExistingType(t reflect.Type, registry map[string]*Schema) *Schema {
+	if name, ok := schemaNameOverride(t); ok {
+		if _, exists := registry[name]; exists {
+			return &Schema{Ref: "#/components/schemas/" + name}
+		}
+		return nil
+	}
 	rawName := t.Name()
 	typeName := sanitizeSchemaName(rawName)
-	if typeName != "" {
-		if _, ok := registry[typeName]; ok {
-			return &Schema{Ref: "#/components/schemas/" + typeName}
-		}
-		// Also check the package-prefixed alternative used for collision avoidance
-		pkgPref := toPascalCase(lastPathComponent(t.PkgPath()))
-		if pkgPref != "" {
-			alt := pkgPref + typeName
-			if _, ok := registry[alt]; ok {
-				return &Schema{Ref: "#/components/schemas/" + alt}
-			}
+	if typeName == "" {
+		return nil
+	}
+	if s, ok := registry[typeName]; ok && generatedFrom(s, t) {
+		return &Schema{Ref: "#/components/schemas/" + typeName}
+	}
+	// Also check the package-prefixed alternative used for collision avoidance
+	pkgPref := toPascalCase(lastPathComponent(t.PkgPath()))
+	if pkgPref != "" {
+		alt := pkgPref + typeName
+		if s, ok := registry[alt]; ok && generatedFrom(s, t) {
+			return &Schema{Ref: "#/components/schemas/" + alt}
+		}
+	}
+	// Numbered names are only found by their type
+	for name, s := range registry {
+		if s != nil && s.goType == t {
+			return &Schema{Ref: "#/components/schemas/" + name}
 		}
 	}
 	return nil
 }
 
+// generatedFrom reports whether the component s may stand for t: it was
+// generated from t, or its type is unknown.
+func generatedFrom(s *Schema, t reflect.Type) bool {
+	return s == nil || s.goType == nil || s.goType == t
+}
+
 func buildStructSchema(t reflect.Type, registry map[string]*Schema) *Schema {
 	// Use the refactored builder for better testability
 	builder := NewStructSchemaBuilder()
@@ -242,24 +335,18 @@ func buildStructSchema(t reflect.Type, registry map[string]*Schema) *Schema {
 }
 
 func processEmbeddedStruct(f reflect.StructField, s *Schema, registry map[string]*Schema) {
-	embeddedSchema := reflectTypeToSchemaInternal(f.Type, registry, true)
-
-	// If embeddedSchema is a reference, resolve to actual for property extraction.
-	if embeddedSchema.Ref != "" {
-		refName := strings.TrimPrefix(embeddedSchema.Ref, "#/components/schemas/")
-		if resolved, ok := registry[refName]; ok {
-			embeddedSchema = resolved
-		}
-	}
-
-	if embeddedSchema.Properties != nil {
-		for propName, propSchema := range embeddedSchema.Properties {
-			s.Properties[propName] = propSchema
-		}
+	t := f.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
 	}
-	if len(embeddedSchema.Required) > 0 {
-		s.Required = append(s.Required, embeddedSchema.Required...)
+	// Reuse the embedded struct's component if it has one, and otherwise
+	// build it inline rather than adding a component nothing references
+	if existing := registry[sanitizeSchemaName(t.Name())]; existing != nil && existing.Properties != nil && generatedFrom(existing, t) {
+		s.mergePromoted(existing)
+		return
 	}
+	builder := NewStructSchemaBuilderWithProcessors(&defaultFieldProcessor{}, &defaultEmbeddedStructProcessor{}, inlineTypeRegistrar{})
+	s.mergePromoted(builder.BuildSchema(t, registry))
 }
 
 func processStructField(f reflect.StructField, s *Schema, registry map[string]*Schema) {
@@ -277,15 +364,50 @@ func processStructField(f reflect.StructField, s *Schema, registry map[string]*S
 	}
 
 	// Try gork tag first, then fall back to field name
-	gorkTag := f.Tag.Get("gork")
-	var fieldName string
-	if gorkTag != "" {
-		fieldName = parseGorkTag(gorkTag).Name
-	}
+	tagInfo := parseGorkTag(f.Tag.Get("gork"))
+	fieldName := tagInfo.Name
 	if fieldName == "" {
 		fieldName = f.Name
 	}
+	applyTagExample(fieldSchema, f.Type, tagInfo.Example)
+	applyTagDefault(fieldSchema, f.Type, tagInfo.Default)
+	fieldSchema.Sensitive = tagInfo.Sensitive
+	fieldSchema.ReadOnly = tagInfo.ReadOnly
+	fieldSchema.WriteOnly = tagInfo.WriteOnly
+	fieldSchema.Extensions = tagExtensions(tagInfo)
 	s.Properties[fieldName] = fieldSchema
+	addAliasProperties(s, fieldName, tagInfo.Aliases, fieldSchema)
+	s.setPropertyAudience(tagInfo.Audience, append([]string{fieldName}, tagInfo.Aliases...)...)
+}
+
+// addAliasProperties documents the old names of a renamed field as
+// deprecated properties sharing the field's schema, and lists them under
+// x-aliases of the field's property. Aliases are only accepted in requests,
+// so the properties are writeOnly; response bodies leave them out, see
+// dropAliasProperties.
+func addAliasProperties(s *Schema, fieldName string, aliases []string, fieldSchema *Schema) {
+	if len(aliases) > 0 {
+		fieldSchema.Aliases = aliases
+	}
+	for _, alias := range aliases {
+		aliasSchema := *fieldSchema
+		aliasSchema.Aliases = nil
+		aliasSchema.Deprecated = true
+		aliasSchema.WriteOnly = true
+		aliasSchema.Description = "Deprecated: use " + fieldName + " instead."
+		s.Properties[alias] = &aliasSchema
+	}
+}
+
+// dropAliasProperties removes the alias properties of s, a response body
+// schema: Marshal only writes the current names.
+func dropAliasProperties(s *Schema) {
+	for _, property := range s.Properties {
+		for _, alias := range property.Aliases {
+			delete(s.Properties, alias)
+			delete(s.propertyAudiences, alias)
+		}
+	}
 }
 
 func buildArraySchema(t reflect.Type, registry map[string]*Schema) *Schema {
@@ -299,6 +421,18 @@ func buildArraySchema(t reflect.Type, registry map[string]*Schema) *Schema {
 	return &Schema{Title: title, Description: desc, Type: "array", Items: itemSchema}
 }
 
+// buildMapSchema describes a map as an object whose additional properties
+// are the map values; JSON object keys are always strings.
+func buildMapSchema(t reflect.Type, registry map[string]*Schema) *Schema {
+	valueSchema := reflectTypeToSchemaInternal(t.Elem(), registry, true)
+	var title, desc string
+	if elemName := t.Elem().Name(); elemName != "" {
+		title = "map[" + t.Key().String() + "]" + elemName
+		desc = "Map of " + elemName
+	}
+	return &Schema{Title: title, Description: desc, Type: "object", AdditionalProperties: valueSchema}
+}
+
 // BasicTypeMapper defines the interface for mapping Go types to OpenAPI schemas.
 type BasicTypeMapper interface {
 	MapType(reflect.Kind) *Schema
@@ -412,6 +546,36 @@ func isUnionType(t reflect.Type) bool {
 	return matched
 }
 
+// isNullableStruct reports whether t is unions.Optional[T] or a
+// database/sql Null type.
+func isNullableStruct(t reflect.Type) bool {
+	_, ok := nullableStructValue(t)
+	return ok
+}
+
+// nullableStructValue returns the type of the values of t, a struct encoded
+// as null when unset: unions.Optional[T] or a database/sql Null type. It
+// resolves nullability like gorkson does, so that specs match the wire
+// format; pointers are made nullable by the fields holding them.
+func nullableStructValue(t reflect.Type) (reflect.Type, bool) {
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, false
+	}
+	return gorkson.NullableValueType(t)
+}
+
+// optionalSchema documents unions.Optional[T] and the other nullable
+// structs from the schema of T: unions get {type: null} added to their
+// oneOf, other schemas are made nullable.
+func optionalSchema(value *Schema) *Schema {
+	if value != nil && value.OneOf != nil && value.Ref == "" {
+		nullable := *value
+		nullable.OneOf = append(append([]*Schema{}, value.OneOf...), &Schema{Type: "null"})
+		return &nullable
+	}
+	return makeNullableSchema(value)
+}
+
 // isUnionStruct checks if the provided type is a user-defined union struct.
 // This is a placeholder and would require a more sophisticated check.
 func isUnionStruct(t reflect.Type) bool {
@@ -437,33 +601,87 @@ func isUnionStruct(t reflect.Type) bool {
 // applyValidationConstraints maps struct tag validation rules into OpenAPI schema fields.
 // Supported rules (subset):
 //
-//	required          -> adds field to parent.Required
-//	min / gt / gte    -> minimum / minLength
-//	max / lt / lte    -> maximum / maxLength
+//	required               -> adds field to parent.Required
+//	min / gte, max / lte   -> minimum / maximum, minLength / maxLength,
+//	                          minItems / maxItems or minProperties / maxProperties
+//	gt / lt                -> exclusiveMinimum / exclusiveMaximum, or the
+//	                          size bounds above off by one
+//	len / eq               -> equal size bounds; eq on strings and numbers -> enum
+//	oneof                  -> enum
+//	unique                 -> uniqueItems
+//	startswith / endswith / contains, alpha / alphanum / numeric / ... -> pattern
+//	email / uuid / url / ip / ipv4 / hostname / ... -> format
+//	datetime=<layout>      -> format date-time, date or time for those layouts
+//
+// Rules after dive apply to the items of a slice or the values of a map;
+// the rules between keys and endkeys check map keys and are left out.
+// A schema has a single pattern: the first rule producing one wins.
+// Alternatives (uuid|email) cannot be documented and are left out too.
 func applyValidationConstraints(fieldSchema *Schema, validateTag string, fieldType reflect.Type, parent *Schema, sf reflect.StructField) {
 	if fieldSchema == nil {
 		return
 	}
 
 	parts := strings.Split(validateTag, ",")
-	for _, p := range parts {
+	for i, p := range parts {
 		if p == "required" {
 			addRequiredField(parent, sf)
 			continue
 		}
+		if p == "dive" {
+			applyDiveConstraints(fieldSchema, parts[i+1:], fieldType)
+			return
+		}
+		if strings.Contains(p, "|") {
+			continue
+		}
 
 		key, val := parseValidationRule(p)
 		applyValidationRule(fieldSchema, key, val, fieldType)
 	}
 }
 
+// applyDiveConstraints applies the rules following dive to the items or
+// map values of fieldSchema.
+func applyDiveConstraints(fieldSchema *Schema, rules []string, fieldType reflect.Type) {
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	var elemSchema *Schema
+	switch fieldType.Kind() {
+	case reflect.Slice, reflect.Array:
+		elemSchema = fieldSchema.Items
+	case reflect.Map:
+		elemSchema = fieldSchema.AdditionalProperties
+	default:
+		return
+	}
+
+	var elemRules []string
+	inKeys := false
+	for _, rule := range rules {
+		switch {
+		case rule == "keys":
+			inKeys = true
+		case rule == "endkeys":
+			inKeys = false
+		case !inKeys:
+			elemRules = append(elemRules, rule)
+		}
+	}
+	// Items cannot be required: the rule only rejects zero values
+	elemField := reflect.StructField{Name: "Item", Type: fieldType.Elem()}
+	applyValidationConstraints(elemSchema, strings.Join(elemRules, ","), fieldType.Elem(), &Schema{}, elemField)
+}
+
 func addRequiredField(parent *Schema, sf reflect.StructField) {
 	// Try gork tag first, then fall back to field name
-	gorkTag := sf.Tag.Get("gork")
-	var fieldName string
-	if gorkTag != "" {
-		fieldName = parseGorkTag(gorkTag).Name
+	tagInfo := parseGorkTag(sf.Tag.Get("gork"))
+	if tagInfo.omittable() {
+		// Marshal may leave the field out
+		return
 	}
+	fieldName := tagInfo.Name
 	if fieldName == "" {
 		fieldName = sf.Name
 	}
@@ -484,27 +702,135 @@ func parseValidationRule(p string) (key, val string) {
 	return p, ""
 }
 
+// validationFormats maps the validators checking a string format to the
+// format documenting it.
+var validationFormats = map[string]string{
+	"email":            "email",
+	"url":              "uri",
+	"http_url":         "uri",
+	"uri":              "uri",
+	"uuid":             "uuid",
+	"uuid3":            "uuid",
+	"uuid4":            "uuid",
+	"uuid5":            "uuid",
+	"uuid_rfc4122":     "uuid",
+	"uuid4_rfc4122":    "uuid",
+	"hostname":         "hostname",
+	"hostname_rfc1123": "hostname",
+	"fqdn":             "hostname",
+	"ip":               "ip",
+	"ipv4":             "ipv4",
+	"ipv6":             "ipv6",
+	"ip4_addr":         "ipv4",
+	"ip6_addr":         "ipv6",
+	"cidr":             "cidr",
+	"cidrv4":           "cidr",
+	"cidrv6":           "cidr",
+	"mac":              "mac",
+	"base64":           "byte",
+}
+
+// validationPatterns maps the validators checking the characters of a
+// string to the pattern documenting them.
+var validationPatterns = map[string]string{
+	"alpha":       `^[a-zA-Z]+$`,
+	"alphanum":    `^[a-zA-Z0-9]+$`,
+	"numeric":     `^[-+]?[0-9]+(?:\.[0-9]+)?$`,
+	"number":      `^[0-9]+$`,
+	"hexadecimal": `^(0[xX])?[0-9a-fA-F]+$`,
+	"e164":        `^\+[1-9]?[0-9]{7,14}$`,
+}
+
+// datetimeFormats maps the layouts of the datetime validator to the format
+// documenting them. Other layouts are only checked at runtime.
+var datetimeFormats = map[string]string{
+	time.RFC3339:     "date-time",
+	time.RFC3339Nano: "date-time",
+	time.DateOnly:    "date",
+	time.TimeOnly:    "time",
+}
+
 func applyValidationRule(fieldSchema *Schema, key, val string, fieldType reflect.Type) {
 	switch key {
-	case "min", "gte", "gt":
+	case "min", "gte":
 		applyMinConstraint(fieldSchema, val, fieldType)
-	case "max", "lte", "lt":
+	case "max", "lte":
 		applyMaxConstraint(fieldSchema, val, fieldType)
+	case "gt":
+		applyExclusiveMinConstraint(fieldSchema, val, fieldType)
+	case "lt":
+		applyExclusiveMaxConstraint(fieldSchema, val, fieldType)
 	case "len":
 		applyLenConstraint(fieldSchema, val, fieldType)
+	case "eq":
+		applyEqConstraint(fieldSchema, val, fieldType)
 	case "regexp":
 		fieldSchema.Pattern = val
+	case "startswith":
+		applyPatternConstraint(fieldSchema, "^"+regexp.QuoteMeta(val))
+	case "endswith":
+		applyPatternConstraint(fieldSchema, regexp.QuoteMeta(val)+"$")
+	case "contains":
+		applyPatternConstraint(fieldSchema, regexp.QuoteMeta(val))
 	case "oneof":
 		applyOneOfConstraint(fieldSchema, val)
+	case "unique":
+		if minimum, _, _ := sizeBounds(fieldSchema, fieldType); minimum == &fieldSchema.MinItems {
+			fieldSchema.UniqueItems = true
+		}
+	case "datetime":
+		if format, ok := datetimeFormats[val]; ok {
+			fieldSchema.Format = format
+		}
+	default:
+		if format, ok := validationFormats[key]; ok {
+			fieldSchema.Format = format
+		} else if pattern, ok := validationPatterns[key]; ok {
+			applyPatternConstraint(fieldSchema, pattern)
+		}
+	}
+}
+
+// sizeBounds returns the schema keywords bounding the size of values of t,
+// which the min, max and len validators check for strings, slices, arrays
+// and maps. numeric reports that t is a number, whose value is bounded
+// instead. Both are unset for other types, including []byte, which is sent
+// as a base64 string.
+func sizeBounds(s *Schema, t reflect.Type) (minimum, maximum **int, numeric bool) {
+	for {
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		} else if value, ok := nullableStructValue(t); ok {
+			t = value
+		} else {
+			break
+		}
 	}
+	switch t.Kind() {
+	case reflect.String:
+		return &s.MinLength, &s.MaxLength, false
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return nil, nil, false
+		}
+		return &s.MinItems, &s.MaxItems, false
+	case reflect.Map:
+		return &s.MinProperties, &s.MaxProperties, false
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return nil, nil, true
+	}
+	return nil, nil, false
 }
 
 func applyMinConstraint(fieldSchema *Schema, val string, fieldType reflect.Type) {
 	if num, err := strconv.ParseFloat(val, 64); err == nil {
-		if isStringKind(fieldType) {
+		minimum, _, numeric := sizeBounds(fieldSchema, fieldType)
+		if minimum != nil {
 			v := int(num)
-			fieldSchema.MinLength = &v
-		} else {
+			*minimum = &v
+		} else if numeric {
 			fieldSchema.Minimum = &num
 		}
 	}
@@ -512,31 +838,86 @@ func applyMinConstraint(fieldSchema *Schema, val string, fieldType reflect.Type)
 
 func applyMaxConstraint(fieldSchema *Schema, val string, fieldType reflect.Type) {
 	if num, err := strconv.ParseFloat(val, 64); err == nil {
-		if isStringKind(fieldType) {
+		_, maximum, numeric := sizeBounds(fieldSchema, fieldType)
+		if maximum != nil {
 			v := int(num)
-			fieldSchema.MaxLength = &v
-		} else {
+			*maximum = &v
+		} else if numeric {
 			fieldSchema.Maximum = &num
 		}
 	}
 }
 
+func applyExclusiveMinConstraint(fieldSchema *Schema, val string, fieldType reflect.Type) {
+	if num, err := strconv.ParseFloat(val, 64); err == nil {
+		minimum, _, numeric := sizeBounds(fieldSchema, fieldType)
+		if minimum != nil {
+			v := int(num) + 1
+			*minimum = &v
+		} else if numeric {
+			fieldSchema.ExclusiveMinimum = &num
+		}
+	}
+}
+
+func applyExclusiveMaxConstraint(fieldSchema *Schema, val string, fieldType reflect.Type) {
+	if num, err := strconv.ParseFloat(val, 64); err == nil {
+		_, maximum, numeric := sizeBounds(fieldSchema, fieldType)
+		if maximum != nil {
+			v := int(num) - 1
+			*maximum = &v
+		} else if numeric {
+			fieldSchema.ExclusiveMaximum = &num
+		}
+	}
+}
+
 func applyLenConstraint(fieldSchema *Schema, val string, fieldType reflect.Type) {
 	if num, err := strconv.Atoi(val); err == nil {
-		if isStringKind(fieldType) {
-			fieldSchema.MinLength = &num
-			fieldSchema.MaxLength = &num
+		if minimum, maximum, _ := sizeBounds(fieldSchema, fieldType); minimum != nil {
+			lower, upper := num, num
+			*minimum, *maximum = &lower, &upper
 		}
 	}
 }
 
+// applyEqConstraint documents eq, which compares strings and numbers and
+// the size of other values.
+func applyEqConstraint(fieldSchema *Schema, val string, fieldType reflect.Type) {
+	minimum, _, numeric := sizeBounds(fieldSchema, fieldType)
+	if numeric || minimum == &fieldSchema.MinLength {
+		fieldSchema.Enum = []string{val}
+		return
+	}
+	applyLenConstraint(fieldSchema, val, fieldType)
+}
+
+// applyPatternConstraint sets the pattern unless an earlier rule did.
+func applyPatternConstraint(fieldSchema *Schema, pattern string) {
+	if fieldSchema.Pattern == "" {
+		fieldSchema.Pattern = pattern
+	}
+}
+
+// applyOneOfConstraint lists the values of oneof, separated by spaces, or
+// single-quoted when they contain spaces: oneof='new york' paris.
 func applyOneOfConstraint(fieldSchema *Schema, val string) {
-	opts := strings.Fields(val)
+	var opts []string
+	for _, match := range oneOfValue.FindAllStringSubmatch(val, -1) {
+		if match[1] != "" {
+			opts = append(opts, match[1])
+		} else {
+			opts = append(opts, match[2])
+		}
+	}
 	if len(opts) > 0 {
 		fieldSchema.Enum = opts
 	}
 }
 
+// oneOfValue matches the values of the oneof validator.
+var oneOfValue = regexp.MustCompile(`'([^']*)'|(\S+)`)
+
 func isStringKind(t reflect.Type) bool {
 	for t.Kind() == reflect.Ptr {
 		t = t.Elem()
@@ -638,6 +1019,9 @@ func lastPathComponent(p string) string {
 // 2) PackageName + TypeName (PascalCase prefix)
 // 3) PackageName + TypeName + numeric suffix.
 func uniqueSchemaNameForType(t reflect.Type, registry map[string]*Schema) string {
+	if name, ok := schemaNameOverride(t); ok {
+		return name
+	}
 	base := sanitizeSchemaName(t.Name())
 	if base == "" {
 		return ""
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSpecMetadataOptions(t *testing.T) {
+	opts := []OpenAPIOption{
+		WithServers(Server{
+			URL:         "https://{region}.api.example.com",
+			Description: "Production",
+			Variables:   map[string]*ServerVariable{"region": {Default: "eu", Enum: []string{"eu", "us"}}},
+		}),
+		WithServers(Server{URL: "http://localhost:8080"}),
+		WithContact(Contact{Name: "API Team", Email: "api@example.com"}),
+		WithLicense(License{Name: "Apache 2.0", Identifier: "Apache-2.0"}),
+		WithTermsOfService("https://example.com/terms"),
+		WithExternalDocs(ExternalDocs{Description: "Guides", URL: "https://docs.example.com"}),
+	}
+
+	spec := GenerateOpenAPI(NewRouteRegistry(), opts...)
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Info         json.RawMessage `json:"info"`
+		Servers      json.RawMessage `json:"servers"`
+		ExternalDocs json.RawMessage `json:"externalDocs"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct{ got, want string }{
+		"info":         {string(doc.Info), `{"title":"Generated API","termsOfService":"https://example.com/terms","contact":{"name":"API Team","email":"api@example.com"},"license":{"name":"Apache 2.0","identifier":"Apache-2.0"},"version":"0.1.0"}`},
+		"servers":      {string(doc.Servers), `[{"url":"https://{region}.api.example.com","description":"Production","variables":{"region":{"enum":["eu","us"],"default":"eu"}}},{"url":"http://localhost:8080"}]`},
+		"externalDocs": {string(doc.ExternalDocs), `{"description":"Guides","url":"https://docs.example.com"}`},
+	}
+	for name, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %s, want %s", name, tt.got, tt.want)
+		}
+	}
+
+	legacy := GenerateOpenAPI(NewRouteRegistry(), append(opts, WithSpecVersion("3.0"))...)
+	if legacy.Info.License.Identifier != "" {
+		t.Errorf("expected OpenAPI 3.0 to drop the license identifier, got %q", legacy.Info.License.Identifier)
+	}
+	if spec.Info.License.Identifier != "Apache-2.0" {
+		t.Error("expected specs not to share the license")
+	}
+}
@@ -1,6 +1,11 @@
 package api
 
-import "encoding/json"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
 
 // testable JSON helpers (can be stubbed in tests).
 var (
@@ -14,15 +19,46 @@ var (
 
 // OpenAPISpec represents the root of an OpenAPI 3.1 document.
 type OpenAPISpec struct {
-	OpenAPI    string               `json:"openapi"`
-	Info       Info                 `json:"info"`
-	Paths      map[string]*PathItem `json:"paths"`
-	Components *Components          `json:"components,omitempty"`
+	OpenAPI string               `json:"openapi"`
+	Info    Info                 `json:"info"`
+	Servers []Server             `json:"servers,omitempty"`
+	Paths   map[string]*PathItem `json:"paths"`
+	// Webhooks documents webhook routes when selected with
+	// WithWebhooksSection. It is OpenAPI 3.1 only.
+	Webhooks     map[string]*PathItem `json:"webhooks,omitempty"`
+	Components   *Components          `json:"components,omitempty"`
+	Tags         []Tag                `json:"tags,omitempty"`
+	ExternalDocs *ExternalDocs        `json:"externalDocs,omitempty"`
 
 	// routeFilter allows callers to skip specific RouteInfo entries during
 	// spec generation. It is internal-only and therefore excluded from JSON
 	// and YAML output.
 	routeFilter func(*RouteInfo) bool `json:"-"`
+	// explain receives generator decisions when set via WithExplain.
+	explain *ExplainReport `json:"-"`
+	// audience selects the spec variant. Set via WithSpecAudience.
+	audience string
+	// generationErrors collects failing routes instead of panicking. Set via
+	// WithGenerationErrors.
+	generationErrors *GenerationError
+	// capturedExamples are attached to their operations. Set via
+	// WithCapturedExamples.
+	capturedExamples []CapturedExample
+	// parallelism is the number of goroutines generating operations. Set
+	// via WithParallelGeneration.
+	parallelism int
+	// webhookPlacement selects where webhook routes are documented. Set
+	// via WithWebhooksSection.
+	webhookPlacement WebhookPlacement
+	// includeInternal documents the routes registered with WithInternal.
+	// Set via WithIncludeInternal.
+	includeInternal bool
+	// schemaNaming names the components generated from Go types. Set via
+	// WithSchemaNaming.
+	schemaNaming SchemaNaming
+	// schemaCollisionErrors fails generation on component name collisions.
+	// Set via WithSchemaNameCollisionErrors.
+	schemaCollisionErrors bool
 }
 
 // MarshalJSON implements a custom marshaler for OpenAPISpec to ensure that
@@ -35,8 +71,46 @@ func (s *OpenAPISpec) MarshalJSON() ([]byte, error) {
 
 // Info represents the OpenAPI info section containing metadata about the API.
 type Info struct {
-	Title   string `json:"title,omitempty"`
-	Version string `json:"version,omitempty"`
+	Title          string   `json:"title,omitempty"`
+	TermsOfService string   `json:"termsOfService,omitempty"`
+	Contact        *Contact `json:"contact,omitempty"`
+	License        *License `json:"license,omitempty"`
+	Version        string   `json:"version,omitempty"`
+}
+
+// Contact is the contact information of the API.
+type Contact struct {
+	Name  string `json:"name,omitempty"`
+	URL   string `json:"url,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// License is the license of the API. Identifier, an SPDX expression, is
+// OpenAPI 3.1 only and an alternative to URL.
+type License struct {
+	Name       string `json:"name"`
+	Identifier string `json:"identifier,omitempty"`
+	URL        string `json:"url,omitempty"`
+}
+
+// Server is a base URL of the API. The URL may hold {variables}.
+type Server struct {
+	URL         string                     `json:"url"`
+	Description string                     `json:"description,omitempty"`
+	Variables   map[string]*ServerVariable `json:"variables,omitempty"`
+}
+
+// ServerVariable is a variable of a server URL.
+type ServerVariable struct {
+	Enum        []string `json:"enum,omitempty"`
+	Default     string   `json:"default"`
+	Description string   `json:"description,omitempty"`
+}
+
+// ExternalDocs points to documentation of the API outside the spec.
+type ExternalDocs struct {
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url"`
 }
 
 // Components represents the OpenAPI components section containing reusable objects.
@@ -70,6 +144,9 @@ type Operation struct {
 	// Explicit vendor extension fields to ensure emission
 	XWebhookProvider map[string]string        `json:"x-webhook-provider,omitempty"`
 	XWebhookEvents   []map[string]interface{} `json:"x-webhook-events,omitempty"`
+	// Callbacks maps callback names to the runtime expressions of their
+	// URLs and the requests sent to them. Set with WithCallback.
+	Callbacks map[string]map[string]*PathItem `json:"callbacks,omitempty"`
 }
 
 // MarshalJSON ensures Operation.Extensions are emitted as top-level x-* fields.
@@ -99,13 +176,58 @@ func (o *Operation) MarshalJSON() ([]byte, error) {
 	return json.Marshal(base)
 }
 
+// UnmarshalJSON reads the x-* fields of the operation into Extensions.
+func (o *Operation) UnmarshalJSON(data []byte) error {
+	type Alias Operation
+	if err := json.Unmarshal(data, (*Alias)(o)); err != nil {
+		return err
+	}
+	var err error
+	o.Extensions, err = extensionsOf(data, "x-webhook-provider", "x-webhook-events")
+	return err
+}
+
 // Parameter represents an OpenAPI parameter object describing a single operation parameter.
 type Parameter struct {
-	Name        string  `json:"name"`
-	In          string  `json:"in"` // "query", "header", "path", "cookie"
-	Required    bool    `json:"required"`
-	Description string  `json:"description,omitempty"`
-	Schema      *Schema `json:"schema,omitempty"`
+	Name        string              `json:"name"`
+	In          string              `json:"in"` // "query", "header", "path", "cookie"
+	Required    bool                `json:"required"`
+	Description string              `json:"description,omitempty"`
+	Schema      *Schema             `json:"schema,omitempty"`
+	Example     interface{}         `json:"example,omitempty"`
+	Examples    map[string]*Example `json:"examples,omitempty"`
+	Deprecated  bool                `json:"deprecated,omitempty"`
+	Style       string              `json:"style,omitempty"`
+	Explode     *bool               `json:"explode,omitempty"`
+	Sensitive   bool                `json:"x-sensitive,omitempty"`
+	// Aliases are previous names still accepted (`gork:"name,alias=old"`).
+	Aliases []string `json:"x-aliases,omitempty"`
+	// Extensions are emitted as top-level x-* fields (`gork:"name,x-internal"`).
+	Extensions map[string]interface{} `json:"-"`
+
+	// audience restricts the parameter to one audience of the spec.
+	audience string
+}
+
+// MarshalJSON emits Extensions as top-level x-* fields.
+func (p Parameter) MarshalJSON() ([]byte, error) {
+	type Alias Parameter
+	data, err := json.Marshal(Alias(p))
+	if err != nil {
+		return nil, err
+	}
+	return withExtensions(data, p.Extensions)
+}
+
+// UnmarshalJSON reads the x-* fields of the parameter into Extensions.
+func (p *Parameter) UnmarshalJSON(data []byte) error {
+	type Alias Parameter
+	if err := json.Unmarshal(data, (*Alias)(p)); err != nil {
+		return err
+	}
+	var err error
+	p.Extensions, err = extensionsOf(data, "x-sensitive", "x-aliases")
+	return err
 }
 
 // RequestBody represents an OpenAPI request body object.
@@ -117,7 +239,14 @@ type RequestBody struct {
 
 // MediaType represents an OpenAPI media type object containing schema information.
 type MediaType struct {
-	Schema *Schema `json:"schema,omitempty"`
+	Schema   *Schema             `json:"schema,omitempty"`
+	Examples map[string]*Example `json:"examples,omitempty"`
+}
+
+// Example represents an OpenAPI example object.
+type Example struct {
+	Summary string      `json:"summary,omitempty"`
+	Value   interface{} `json:"value"`
 }
 
 // Response represents an OpenAPI response object describing a single response from an API operation.
@@ -158,7 +287,48 @@ type Schema struct {
 	Pattern       string             `json:"pattern,omitempty"`
 	Enum          []string           `json:"enum,omitempty"`
 	Items         *Schema            `json:"items,omitempty"`
+	MinItems      *int               `json:"minItems,omitempty"`
+	MaxItems      *int               `json:"maxItems,omitempty"`
+	UniqueItems   bool               `json:"uniqueItems,omitempty"`
+	MinProperties *int               `json:"minProperties,omitempty"`
+	MaxProperties *int               `json:"maxProperties,omitempty"`
 	Format        string             `json:"format,omitempty"`
+	Deprecated    bool               `json:"deprecated,omitempty"`
+	Example       interface{}        `json:"example,omitempty"`
+	Default       interface{}        `json:"default,omitempty"`
+	// ExclusiveMinimum and ExclusiveMaximum are exclusive bounds, written
+	// as numbers in OpenAPI 3.1 and as flags on minimum and maximum in
+	// OpenAPI 3.0.
+	ExclusiveMinimum *float64 `json:"-"`
+	ExclusiveMaximum *float64 `json:"-"`
+	// Examples is the JSON Schema examples keyword of OpenAPI 3.1.
+	Examples []interface{} `json:"examples,omitempty"`
+	// Nullable is the OpenAPI 3.0 form of a type allowing null, which 3.1
+	// lists as a "null" type.
+	Nullable bool `json:"nullable,omitempty"`
+	// Sensitive marks values to keep out of logs (`gork:"name,sensitive"`).
+	Sensitive bool `json:"x-sensitive,omitempty"`
+	// ReadOnly and WriteOnly mark properties only sent in responses or only
+	// in requests (`gork:"id,readonly"`, `gork:"password,writeonly"`).
+	ReadOnly  bool `json:"readOnly,omitempty"`
+	WriteOnly bool `json:"writeOnly,omitempty"`
+	// Aliases are previous names of the property still accepted when
+	// decoding (`gork:"name,alias=old|older"`).
+	Aliases []string `json:"x-aliases,omitempty"`
+	// Extensions are emitted as top-level x-* fields (`gork:"name,x-internal"`).
+	Extensions map[string]interface{} `json:"-"`
+
+	// AdditionalProperties describes the values of maps. An empty schema
+	// allows any value and is written as additionalProperties: true.
+	AdditionalProperties *Schema `json:"additionalProperties,omitempty"`
+
+	// propertyAudiences maps properties restricted to an audience to it.
+	propertyAudiences map[string]string
+	// boundFlags writes exclusive bounds in the OpenAPI 3.0 form.
+	boundFlags bool
+	// goType is the Go type a component was generated from and named
+	// after. It is nil for components with fixed names.
+	goType reflect.Type
 }
 
 // MarshalJSON implements custom JSON marshaling for Schema to handle the type field correctly.
@@ -166,10 +336,41 @@ type Schema struct {
 func (s *Schema) MarshalJSON() ([]byte, error) {
 	type Alias Schema
 	aux := &struct {
-		Type interface{} `json:"type,omitempty"`
+		Type interface{}   `json:"type,omitempty"`
+		Enum []interface{} `json:"enum,omitempty"`
 		*Alias
+		AdditionalProperties interface{} `json:"additionalProperties,omitempty"`
+		Minimum              *float64    `json:"minimum,omitempty"`
+		Maximum              *float64    `json:"maximum,omitempty"`
+		ExclusiveMinimum     interface{} `json:"exclusiveMinimum,omitempty"`
+		ExclusiveMaximum     interface{} `json:"exclusiveMaximum,omitempty"`
 	}{
-		Alias: (*Alias)(s),
+		Alias:   (*Alias)(s),
+		Minimum: s.Minimum,
+		Maximum: s.Maximum,
+	}
+
+	// OpenAPI 3.0 flags minimum and maximum as exclusive; 3.1 gives the
+	// exclusive bounds as numbers.
+	if s.ExclusiveMinimum != nil {
+		aux.ExclusiveMinimum = *s.ExclusiveMinimum
+		if s.boundFlags {
+			aux.Minimum, aux.ExclusiveMinimum = s.ExclusiveMinimum, true
+		}
+	}
+	if s.ExclusiveMaximum != nil {
+		aux.ExclusiveMaximum = *s.ExclusiveMaximum
+		if s.boundFlags {
+			aux.Maximum, aux.ExclusiveMaximum = s.ExclusiveMaximum, true
+		}
+	}
+
+	if ap := s.AdditionalProperties; ap != nil {
+		if reflect.DeepEqual(*ap, Schema{}) {
+			aux.AdditionalProperties = true
+		} else {
+			aux.AdditionalProperties = ap
+		}
 	}
 
 	if len(s.Types) > 0 {
@@ -178,20 +379,65 @@ func (s *Schema) MarshalJSON() ([]byte, error) {
 		aux.Type = s.Type
 	}
 
-	return json.Marshal(aux)
+	// Enum values are kept as strings; numeric schemas list them as numbers.
+	numeric := s.Type == "integer" || s.Type == "number"
+	for _, value := range s.Enum {
+		if numeric && json.Valid([]byte(value)) {
+			aux.Enum = append(aux.Enum, json.Number(value))
+		} else {
+			aux.Enum = append(aux.Enum, value)
+		}
+	}
+
+	data, err := json.Marshal(aux)
+	if err != nil {
+		return nil, err
+	}
+	return withExtensions(data, s.Extensions)
 }
 
 // UnmarshalJSON implements custom JSON unmarshaling for Schema to handle the type field correctly.
 func (s *Schema) UnmarshalJSON(data []byte) error {
 	type Alias Schema
 	aux := &struct {
-		Type interface{} `json:"type"`
+		Type                 interface{}     `json:"type"`
+		Enum                 []interface{}   `json:"enum"`
+		AdditionalProperties json.RawMessage `json:"additionalProperties"`
+		ExclusiveMinimum     json.RawMessage `json:"exclusiveMinimum"`
+		ExclusiveMaximum     json.RawMessage `json:"exclusiveMaximum"`
 		*Alias
 	}{
 		Alias: (*Alias)(s),
 	}
 
-	if err := json.Unmarshal(data, aux); err != nil {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(aux); err != nil {
+		return err
+	}
+	switch ap := bytes.TrimSpace(aux.AdditionalProperties); {
+	case len(ap) == 0, string(ap) == "false", string(ap) == "null":
+		s.AdditionalProperties = nil
+	case string(ap) == "true":
+		s.AdditionalProperties = &Schema{}
+	default:
+		s.AdditionalProperties = &Schema{}
+		if err := json.Unmarshal(ap, s.AdditionalProperties); err != nil {
+			return err
+		}
+	}
+	var err error
+	if s.ExclusiveMinimum, err = exclusiveBound(aux.ExclusiveMinimum, &s.Minimum, &s.boundFlags); err != nil {
+		return err
+	}
+	if s.ExclusiveMaximum, err = exclusiveBound(aux.ExclusiveMaximum, &s.Maximum, &s.boundFlags); err != nil {
+		return err
+	}
+	s.Enum = nil
+	for _, value := range aux.Enum {
+		s.Enum = append(s.Enum, fmt.Sprint(value))
+	}
+	if s.Extensions, err = extensionsOf(data, "x-sensitive", "x-aliases"); err != nil {
 		return err
 	}
 
@@ -213,6 +459,26 @@ func (s *Schema) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// exclusiveBound decodes an exclusiveMinimum or exclusiveMaximum keyword: a
+// number in OpenAPI 3.1, or in 3.0 a flag making the bound exclusive, in
+// which case the bound moves out of inclusive.
+func exclusiveBound(raw json.RawMessage, inclusive **float64, flags *bool) (*float64, error) {
+	switch raw := bytes.TrimSpace(raw); {
+	case len(raw) == 0, string(raw) == "false", string(raw) == "null":
+		return nil, nil
+	case string(raw) == "true":
+		bound := *inclusive
+		*inclusive, *flags = nil, true
+		return bound, nil
+	default:
+		var bound float64
+		if err := json.Unmarshal(raw, &bound); err != nil {
+			return nil, err
+		}
+		return &bound, nil
+	}
+}
+
 // Discriminator represents an OpenAPI discriminator object for polymorphic schemas.
 type Discriminator struct {
 	PropertyName string            `json:"propertyName"`
@@ -221,10 +487,30 @@ type Discriminator struct {
 
 // SecurityScheme represents an OpenAPI security scheme object defining authentication methods.
 type SecurityScheme struct {
-	Type   string `json:"type"`
-	In     string `json:"in,omitempty"`
-	Name   string `json:"name,omitempty"`
-	Scheme string `json:"scheme,omitempty"`
+	Type             string      `json:"type"`
+	Description      string      `json:"description,omitempty"`
+	In               string      `json:"in,omitempty"`
+	Name             string      `json:"name,omitempty"`
+	Scheme           string      `json:"scheme,omitempty"`
+	BearerFormat     string      `json:"bearerFormat,omitempty"`
+	Flows            *OAuthFlows `json:"flows,omitempty"`
+	OpenIDConnectURL string      `json:"openIdConnectUrl,omitempty"`
+}
+
+// OAuthFlows lists the OAuth2 flows supported by an oauth2 security scheme.
+type OAuthFlows struct {
+	Implicit          *OAuthFlow `json:"implicit,omitempty"`
+	Password          *OAuthFlow `json:"password,omitempty"`
+	ClientCredentials *OAuthFlow `json:"clientCredentials,omitempty"`
+	AuthorizationCode *OAuthFlow `json:"authorizationCode,omitempty"`
+}
+
+// OAuthFlow describes a single OAuth2 flow and the scopes it grants.
+type OAuthFlow struct {
+	AuthorizationURL string            `json:"authorizationUrl,omitempty"`
+	TokenURL         string            `json:"tokenUrl,omitempty"`
+	RefreshURL       string            `json:"refreshUrl,omitempty"`
+	Scopes           map[string]string `json:"scopes"`
 }
 
 // OpenAPIOption allows callers to tweak the generated specification.
@@ -240,6 +526,18 @@ func WithRouteFilter(f func(*RouteInfo) bool) OpenAPIOption {
 	}
 }
 
+// WithSecurityScheme adds a security scheme to components.securitySchemes.
+// Schemes declared on the registry with DeclareSecurityScheme are included
+// automatically; this option is for schemes only relevant to one spec.
+func WithSecurityScheme(name string, scheme SecurityScheme) OpenAPIOption {
+	return func(spec *OpenAPISpec) {
+		if spec.Components.SecuritySchemes == nil {
+			spec.Components.SecuritySchemes = map[string]*SecurityScheme{}
+		}
+		spec.Components.SecuritySchemes[name] = &scheme
+	}
+}
+
 // WithTitle sets the spec title.
 func WithTitle(title string) OpenAPIOption {
 	return func(spec *OpenAPISpec) { spec.Info.Title = title }
@@ -249,3 +547,37 @@ func WithTitle(title string) OpenAPIOption {
 func WithVersion(version string) OpenAPIOption {
 	return func(spec *OpenAPISpec) { spec.Info.Version = version }
 }
+
+// WithServers adds servers to the spec, in order.
+func WithServers(servers ...Server) OpenAPIOption {
+	return func(spec *OpenAPISpec) { spec.Servers = append(spec.Servers, servers...) }
+}
+
+// WithContact sets the contact information of the spec.
+func WithContact(contact Contact) OpenAPIOption {
+	return func(spec *OpenAPISpec) {
+		c := contact
+		spec.Info.Contact = &c
+	}
+}
+
+// WithLicense sets the license of the spec.
+func WithLicense(license License) OpenAPIOption {
+	return func(spec *OpenAPISpec) {
+		l := license
+		spec.Info.License = &l
+	}
+}
+
+// WithTermsOfService sets the URL of the terms of service of the API.
+func WithTermsOfService(url string) OpenAPIOption {
+	return func(spec *OpenAPISpec) { spec.Info.TermsOfService = url }
+}
+
+// WithExternalDocs links the spec to documentation outside of it.
+func WithExternalDocs(docs ExternalDocs) OpenAPIOption {
+	return func(spec *OpenAPISpec) {
+		d := docs
+		spec.ExternalDocs = &d
+	}
+}
@@ -1,7 +1,12 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -166,9 +171,9 @@ func TestApplyValidationRule(t *testing.T) {
 			val:       "5",
 			fieldType: reflect.TypeOf(0),
 			verify: func(s *Schema) bool {
-				return s.Minimum != nil && *s.Minimum == 5.0
+				return s.Minimum == nil && s.ExclusiveMinimum != nil && *s.ExclusiveMinimum == 5.0
 			},
-			desc: "should set Minimum to 5",
+			desc: "should set ExclusiveMinimum to 5",
 		},
 		{
 			name:      "lt constraint on float",
@@ -176,9 +181,9 @@ func TestApplyValidationRule(t *testing.T) {
 			val:       "10.5",
 			fieldType: reflect.TypeOf(0.0),
 			verify: func(s *Schema) bool {
-				return s.Maximum != nil && *s.Maximum == 10.5
+				return s.Maximum == nil && s.ExclusiveMaximum != nil && *s.ExclusiveMaximum == 10.5
 			},
-			desc: "should set Maximum to 10.5",
+			desc: "should set ExclusiveMaximum to 10.5",
 		},
 	}
 
@@ -483,6 +488,65 @@ func TestIsStringKind(t *testing.T) {
 	}
 }
 
+func TestValidationTagSchemaMapping(t *testing.T) {
+	tests := []struct {
+		tag       string
+		fieldType reflect.Type
+		want      string
+	}{
+		{"min=2,max=10", reflect.TypeOf(""), `{"maxLength":10,"minLength":2}`},
+		{"gte=1,lte=5", reflect.TypeOf(0), `{"maximum":5,"minimum":1}`},
+		{"gt=0,lt=100", reflect.TypeOf(0.0), `{"exclusiveMaximum":100,"exclusiveMinimum":0}`},
+		{"gt=2,lt=5", reflect.TypeOf(""), `{"maxLength":4,"minLength":3}`},
+		{"min=1,max=10,unique", reflect.TypeOf([]string{}), `{"maxItems":10,"minItems":1,"uniqueItems":true}`},
+		{"min=1", reflect.TypeOf(map[string]int{}), `{"minProperties":1}`},
+		{"len=2", reflect.TypeOf(""), `{"maxLength":2,"minLength":2}`},
+		{"len=3", reflect.TypeOf([]int{}), `{"maxItems":3,"minItems":3}`},
+		{"len=3", reflect.TypeOf(0), `{}`},
+		{"eq=card", reflect.TypeOf(""), `{"enum":["card"]}`},
+		{"oneof=red 'dark blue' green", reflect.TypeOf(""), `{"enum":["red","dark blue","green"]}`},
+		{"startswith=ord_", reflect.TypeOf(""), `{"pattern":"^ord_"}`},
+		{"endswith=.json", reflect.TypeOf(""), `{"pattern":"\\.json$"}`},
+		{"contains=@", reflect.TypeOf(""), `{"pattern":"@"}`},
+		{"alphanum,startswith=x", reflect.TypeOf(""), `{"pattern":"^[a-zA-Z0-9]+$"}`},
+		{"email", reflect.TypeOf(""), `{"format":"email"}`},
+		{"uuid4", reflect.TypeOf(""), `{"format":"uuid"}`},
+		{"url", reflect.TypeOf(""), `{"format":"uri"}`},
+		{"ip", reflect.TypeOf(""), `{"format":"ip"}`},
+		{"ipv4", reflect.TypeOf(""), `{"format":"ipv4"}`},
+		{"hostname", reflect.TypeOf(""), `{"format":"hostname"}`},
+		{"datetime=2006-01-02", reflect.TypeOf(""), `{"format":"date"}`},
+		{"datetime=2006-01-02T15:04:05Z07:00", reflect.TypeOf(""), `{"format":"date-time"}`},
+		{"datetime=02/01/2006", reflect.TypeOf(""), `{}`},
+		{"min=1", reflect.TypeOf((*string)(nil)), `{"minLength":1}`},
+		{"min=1", reflect.TypeOf([]byte{}), `{}`},
+		{"uuid|email", reflect.TypeOf(""), `{}`},
+		{"min=1,dive,max=5", reflect.TypeOf([]string{}), `{"minItems":1}`},
+		{"dive,max=5", reflect.TypeOf(map[string]int{}), `{}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag+" "+tt.fieldType.String(), func(t *testing.T) {
+			schema := &Schema{}
+			applyValidationConstraints(schema, tt.tag, tt.fieldType, &Schema{}, reflect.StructField{Name: "Field", Type: tt.fieldType})
+			data, err := json.Marshal(schema)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got, want map[string]interface{}
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("validate:%q on %s = %s, want %s", tt.tag, tt.fieldType, data, tt.want)
+			}
+		})
+	}
+}
+
 func TestApplyValidationConstraints_Integration(t *testing.T) {
 	// Test the integration with applyValidationConstraints function
 	schema := &Schema{}
@@ -577,3 +641,46 @@ func TestWithRouteFilter(t *testing.T) {
 		t.Error("Expected /excluded route to be filtered out")
 	}
 }
+
+type diveRequest struct {
+	Body struct {
+		Tags   []string          `gork:"tags" validate:"max=5,dive,min=1,max=10"`
+		Labels map[string]string `gork:"labels" validate:"dive,keys,alpha,endkeys,oneof=a b"`
+	}
+}
+
+func TestDiveValidation(t *testing.T) {
+	router, registry, handlers := newLoadShedRouter()
+	router.Post("/posts", func(context.Context, diveRequest) (*struct{}, error) { return nil, nil })
+
+	var body *Schema
+	for _, schema := range GenerateOpenAPI(registry).Components.Schemas {
+		if schema.Properties["tags"] != nil {
+			body = schema
+		}
+	}
+	if body == nil {
+		t.Fatal("missing the body component")
+	}
+	tags := body.Properties["tags"]
+	if tags.MaxItems == nil || *tags.MaxItems != 5 || tags.Items.MinLength == nil || *tags.Items.MinLength != 1 || tags.Items.MaxLength == nil || *tags.Items.MaxLength != 10 {
+		t.Errorf("expected the dive rules on the items, got %+v with items %+v", tags, tags.Items)
+	}
+	if labels := body.Properties["labels"].AdditionalProperties; labels == nil || len(labels.Enum) != 2 || labels.Pattern != "" {
+		t.Errorf("expected the dive rules on the map values only, got %+v", labels)
+	}
+
+	w := httptest.NewRecorder()
+	handlers["POST /posts"](w, httptest.NewRequest(http.MethodPost, "/posts", strings.NewReader(`{"tags":["go","api",""],"labels":{"env":"c"}}`)))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400: %s", w.Code, w.Body)
+	}
+	var resp ValidationErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]string{"body.tags[2]": {"min"}, "body.labels[env]": {"oneof"}}
+	if !reflect.DeepEqual(resp.Details, want) {
+		t.Errorf("details = %v, want %v", resp.Details, want)
+	}
+}
@@ -0,0 +1,60 @@
+package api
+
+import "fmt"
+
+// WithSummary sets the summary of the route's operation, replacing the first
+// line of the handler's doc comment.
+func WithSummary(summary string) Option {
+	return func(h *HandlerOption) {
+		h.Summary = summary
+	}
+}
+
+// WithDescription sets the description of the route's operation, replacing
+// the doc comment of the handler after its first line.
+func WithDescription(description string) Option {
+	return func(h *HandlerOption) {
+		h.Description = description
+	}
+}
+
+// webhookOperationDescription is the description of webhook operations
+// whose handler is not documented.
+const webhookOperationDescription = "Webhook endpoint that receives events from external services"
+
+// webhookOperationSummary is the summary of webhook operations whose handler
+// is not documented.
+func webhookOperationSummary(handlerName string) string {
+	return fmt.Sprintf("Webhook endpoint for %s", handlerName)
+}
+
+// applyOperationDocs sets the summary and description given with
+// WithSummary and WithDescription.
+func applyOperationDocs(route *RouteInfo, operation *Operation) {
+	if route.Options == nil {
+		return
+	}
+	if route.Options.Summary != "" {
+		operation.Summary = route.Options.Summary
+	}
+	if route.Options.Description != "" {
+		operation.Description = route.Options.Description
+	}
+}
+
+// applyFunctionDocs documents op with the doc comment of its handler: the
+// first line becomes the summary and the rest the description. Summaries
+// and descriptions set on the route are kept.
+func applyFunctionDocs(op *Operation, doc Documentation) {
+	summary, description := doc.Summary, doc.Details
+	if summary == "" {
+		// Documentation without a summary holds its text in Description.
+		description = doc.Description
+	}
+	if summary != "" && (op.Summary == "" || op.Summary == webhookOperationSummary(op.OperationID)) {
+		op.Summary = summary
+	}
+	if description != "" && (op.Description == "" || op.Description == webhookOperationDescription) {
+		op.Description = description
+	}
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestOperationDocsFromHandlerComment(t *testing.T) {
+	dir := t.TempDir()
+	src := `package fixtures
+
+// GetFoo returns a foo.
+// Foos are looked up by ID
+// and cached.
+//
+// Missing foos are reported as 404.
+// Example: ignored
+func GetFoo() {}
+
+// ListFoos lists foos.
+func ListFoos() {}
+`
+	if err := os.WriteFile(filepath.Join(dir, "fixture.go"), []byte(src), 0o644); err != nil {
+		t.Fatalf("write temp file: %v", err)
+	}
+	extractor := NewDocExtractor()
+	if err := extractor.ParseDirectory(dir); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	registry := NewRouteRegistry()
+	for _, route := range []*RouteInfo{
+		{Method: "GET", Path: "/foo", HandlerName: "GetFoo"},
+		{Method: "POST", Path: "/foo", HandlerName: "ListFoos"},
+		{Method: "PUT", Path: "/foo", HandlerName: "GetFoo", Options: &HandlerOption{Summary: "Replaces a foo."}},
+		{Method: "PATCH", Path: "/foo", HandlerName: "GetFoo", Options: &HandlerOption{Description: "Updates a foo."}},
+	} {
+		route.RequestType = reflect.TypeOf(Req{})
+		route.ResponseType = reflect.TypeOf((*Resp)(nil))
+		registry.Register(route)
+	}
+
+	item := GenerateOpenAPIWithDocs(registry, extractor).Paths["/foo"]
+	details := "Foos are looked up by ID and cached.\n\nMissing foos are reported as 404."
+	tests := []struct {
+		name                 string
+		op                   *Operation
+		summary, description string
+	}{
+		{"doc comment", item.Get, "GetFoo returns a foo.", details},
+		{"single line", item.Post, "ListFoos lists foos.", ""},
+		{"summary override", item.Put, "Replaces a foo.", details},
+		{"description override", item.Patch, "GetFoo returns a foo.", "Updates a foo."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.op.Summary != tt.summary || tt.op.Description != tt.description {
+				t.Errorf("summary = %q, description = %q, want %q, %q", tt.op.Summary, tt.op.Description, tt.summary, tt.description)
+			}
+		})
+	}
+}
+
+func TestOperationDocsOptionsWithoutExtractor(t *testing.T) {
+	router, registry, _ := newLoadShedRouter()
+	router.Get("/items", func(context.Context, struct{}) (*loadShedResponse, error) { return nil, nil },
+		WithSummary("List items"), WithDescription("Lists all items."))
+
+	op := GenerateOpenAPI(registry).Paths["/api/items"].Get
+	if op.Summary != "List items" || op.Description != "Lists all items." {
+		t.Errorf("summary = %q, description = %q", op.Summary, op.Description)
+	}
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+)
+
+// PageRequest holds the standard pagination query parameters: limit with
+// either an opaque cursor or an offset. Embed it in a request's Query
+// section next to the route's own parameters:
+//
+//	type ListUsersRequest struct {
+//		Query struct {
+//			api.PageRequest
+//			Role string `gork:"role"`
+//		}
+//	}
+type PageRequest struct {
+	Limit  int    `gork:"limit" validate:"omitempty,min=1"`
+	Cursor string `gork:"cursor"`
+	Offset int    `gork:"offset" validate:"omitempty,min=0"`
+}
+
+// PageLimit returns Limit, or defaultLimit when it is unset, capped at
+// maxLimit.
+func (p PageRequest) PageLimit(defaultLimit, maxLimit int) int {
+	limit := p.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if maxLimit > 0 && limit > maxLimit {
+		limit = maxLimit
+	}
+	return limit
+}
+
+// PageResponse is the envelope of one page of items. Use it as a response
+// Body; NextCursor is empty on the last page.
+type PageResponse[T any] struct {
+	Items      []T    `gork:"items" validate:"required"`
+	NextCursor string `gork:"next_cursor"`
+}
+
+// NewPage builds a page from items fetched with one more than limit: if the
+// extra item is there it is dropped and NextCursor is taken from the last
+// item kept.
+func NewPage[T any](items []T, limit int, cursor func(last T) string) PageResponse[T] {
+	page := PageResponse[T]{Items: items}
+	if page.Items == nil {
+		page.Items = []T{}
+	}
+	if limit > 0 && len(items) > limit {
+		page.Items = items[:limit]
+		page.NextCursor = cursor(page.Items[limit-1])
+	}
+	return page
+}
+
+// ErrInvalidCursor is returned by DecodeCursor for cursors it did not
+// encode.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// EncodeCursor encodes v as an opaque, URL-safe cursor.
+func EncodeCursor(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor decodes a cursor made by EncodeCursor into v.
+func DecodeCursor(cursor string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || json.Unmarshal(data, v) != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+var pageRequestType = reflect.TypeOf(PageRequest{})
+
+// pageParameterDescriptions describe the parameters promoted from an
+// embedded PageRequest.
+var pageParameterDescriptions = map[string]string{
+	"limit":  "Maximum number of items to return",
+	"cursor": "Cursor from the next_cursor of the previous page",
+	"offset": "Number of items to skip",
+}
+
+// sectionFields returns the fields of a parameter section with the fields
+// of untagged embedded structs, such as PageRequest, promoted. The Index of
+// a promoted field is its path from the section.
+func sectionFields(t reflect.Type) []reflect.StructField {
+	var fields []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if embedded, ok := embeddedStructType(field); ok && field.Type.Kind() == reflect.Struct {
+			for _, promoted := range sectionFields(embedded) {
+				promoted.Index = append([]int{i}, promoted.Index...)
+				fields = append(fields, promoted)
+			}
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// promotedFromPageRequest reports whether a field returned by sectionFields
+// comes from an embedded PageRequest.
+func promotedFromPageRequest(section reflect.Type, field reflect.StructField) bool {
+	return len(field.Index) > 1 && section.FieldByIndex(field.Index[:len(field.Index)-1]).Type == pageRequestType
+}
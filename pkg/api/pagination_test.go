@@ -0,0 +1,127 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type pageTestItem struct {
+	ID int `gork:"id"`
+}
+
+type pageTestRequest struct {
+	Query struct {
+		PageRequest
+		Kind string `gork:"kind"`
+	}
+}
+
+type pageTestResponse struct {
+	Body PageResponse[pageTestItem]
+}
+
+func listPageTestItems(_ context.Context, req pageTestRequest) (*pageTestResponse, error) {
+	after := 0
+	if req.Query.Cursor != "" {
+		if err := DecodeCursor(req.Query.Cursor, &after); err != nil {
+			return nil, err
+		}
+	}
+	limit := req.Query.PageLimit(2, 10)
+	var items []pageTestItem
+	for id := after + 1; id <= 5 && len(items) <= limit; id++ {
+		items = append(items, pageTestItem{ID: id})
+	}
+	return &pageTestResponse{Body: NewPage(items, limit, func(last pageTestItem) string {
+		cursor, _ := EncodeCursor(last.ID)
+		return cursor
+	})}, nil
+}
+
+func TestPagination(t *testing.T) {
+	registry := NewRouteRegistry()
+	handlers := map[string]http.HandlerFunc{}
+	router := NewTypedRouter[*struct{}](nil, registry, "/api", nil, &DefaultParameterAdapter{},
+		func(method, path string, h http.HandlerFunc, _ *RouteInfo) { handlers[method+" "+path] = h })
+	router.Get("/items", listPageTestItems)
+
+	var ids []int
+	cursor := ""
+	for pages := 0; pages < 5; pages++ {
+		w := httptest.NewRecorder()
+		handlers["GET /items"](w, httptest.NewRequest(http.MethodGet, "/api/items?kind=a&cursor="+cursor, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d: %s", w.Code, w.Body)
+		}
+		var page struct {
+			Items      []pageTestItem `json:"items"`
+			NextCursor string         `json:"next_cursor"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+			t.Fatal(err)
+		}
+		for _, item := range page.Items {
+			ids = append(ids, item.ID)
+		}
+		if cursor = page.NextCursor; cursor == "" {
+			break
+		}
+	}
+	if got, _ := json.Marshal(ids); string(got) != "[1,2,3,4,5]" {
+		t.Errorf("paged through %s, want [1,2,3,4,5]", got)
+	}
+
+	spec := GenerateOpenAPI(registry)
+	op := spec.Paths["/api/items"].Get
+	var params []string
+	for _, p := range op.Parameters {
+		params = append(params, p.Name+":"+p.Description)
+	}
+	if got := strings.Join(params, ","); got != "limit:Maximum number of items to return,cursor:Cursor from the next_cursor of the previous page,offset:Number of items to skip,kind:" {
+		t.Errorf("parameters = %s", got)
+	}
+	data, _ := json.Marshal(spec.Components.Schemas)
+	if !bytes.Contains(data, []byte(`"PageResponse_pageTestItem":{"type":"object","title":"PageResponse_pageTestItem","properties":{"items":{"type":"array","title":"[]pageTestItem","description":"Array of pageTestItem","items":{"$ref":"#/components/schemas/pageTestItem"}},"next_cursor":{"type":"string"}},"required":["items"]}`)) {
+		t.Errorf("expected the page envelope schema:\n%s", data)
+	}
+
+	var client bytes.Buffer
+	if err := GenerateClient(registry, ClientConfig{Lang: "ts"}, &client); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(client.String(), "  query?: {\n    limit?: number;\n    cursor?: string;\n    offset?: number;\n    kind?: string;\n  };") {
+		t.Errorf("client must promote the page parameters:\n%s", client.String())
+	}
+}
+
+func TestPageHelpers(t *testing.T) {
+	if got := (PageRequest{}).PageLimit(20, 100); got != 20 {
+		t.Errorf("default limit = %d", got)
+	}
+	if got := (PageRequest{Limit: 500}).PageLimit(20, 100); got != 100 {
+		t.Errorf("capped limit = %d", got)
+	}
+	if page := NewPage([]int(nil), 10, nil); page.Items == nil || page.NextCursor != "" {
+		t.Errorf("empty page = %+v", page)
+	}
+	var v int
+	if err := DecodeCursor("not a cursor!", &v); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("expected ErrInvalidCursor, got %v", err)
+	}
+}
+
+func TestPageRequestValidation(t *testing.T) {
+	req := &pageTestRequest{}
+	req.Query.Limit = -1
+	err := NewConventionValidator().ValidateRequest(context.Background(), req)
+	var resp *ValidationErrorResponse
+	if !errors.As(err, &resp) || len(resp.Details["query.limit"]) == 0 {
+		t.Errorf("expected a query.limit validation error, got %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"runtime/debug"
+)
+
+// PanicHandler receives a panic recovered from a route's handler together
+// with the request and the stack trace, e.g. to report it to an error
+// tracker. The returned correlation ID, if not empty, is included in the 500
+// response as details.correlation_id so clients can quote it.
+type PanicHandler func(r *http.Request, recovered any, stack []byte) (correlationID string)
+
+// WithPanicHandler reports panics of a route (or of every route, when passed
+// to a router or group) to handler. Panics are recovered and answered with
+// 500 either way; without a handler they are logged.
+func WithPanicHandler(handler PanicHandler) Option {
+	return func(h *HandlerOption) {
+		h.PanicHandler = handler
+	}
+}
+
+// recoverPanics answers panics in next with the standard 500 ErrorResponse.
+// Sensitive fields of the recovered value are redacted before it is logged or
+// passed to handler.
+// http.ErrAbortHandler is re-raised so that net/http aborts the response as
+// documented.
+func recoverPanics(handler PanicHandler, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+			stack := debug.Stack()
+			recovered = Redact(recovered)
+			resp := ErrorResponse{Error: http.StatusText(http.StatusInternalServerError)}
+			if handler == nil {
+				log.Printf("http 500: panic: %v\n%s", recovered, stack)
+			} else if id := handler(r, recovered, stack); id != "" {
+				resp.Details = map[string]interface{}{"correlation_id": id}
+			}
+			writeErrorResponse(w, http.StatusInternalServerError, resp)
+		}()
+		next(w, r)
+	}
+}
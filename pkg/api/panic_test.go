@@ -0,0 +1,50 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPanicRecovery(t *testing.T) {
+	var reported any
+	var stack []byte
+	router, _, handlers := newLoadShedRouter(WithPanicHandler(func(r *http.Request, recovered any, s []byte) string {
+		reported, stack = recovered, s
+		return "req-42"
+	}))
+	router.Get("/boom", func(context.Context, struct{}) (*loadShedResponse, error) { panic("kaboom") })
+
+	w := httptest.NewRecorder()
+	handlers["GET /boom"](w, httptest.NewRequest(http.MethodGet, "/api/boom", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d", w.Code)
+	}
+	if got, want := strings.TrimSpace(w.Body.String()), `{"error":"Internal Server Error","details":{"correlation_id":"req-42"}}`; got != want {
+		t.Errorf("body = %s, want %s", got, want)
+	}
+	if reported != "kaboom" || !strings.Contains(string(stack), "panic") {
+		t.Errorf("expected the panic and its stack to be reported, got %v", reported)
+	}
+}
+
+func TestPanicRecoveryWithoutHandler(t *testing.T) {
+	router, _, handlers := newLoadShedRouter()
+	router.Get("/boom", func(context.Context, struct{}) (*loadShedResponse, error) { panic("kaboom") })
+	router.Get("/abort", func(context.Context, struct{}) (*loadShedResponse, error) { panic(http.ErrAbortHandler) })
+
+	w := httptest.NewRecorder()
+	handlers["GET /boom"](w, httptest.NewRequest(http.MethodGet, "/api/boom", nil))
+	if got := strings.TrimSpace(w.Body.String()); w.Code != http.StatusInternalServerError || got != `{"error":"Internal Server Error"}` {
+		t.Errorf("got %d %s", w.Code, got)
+	}
+
+	defer func() {
+		if recover() != http.ErrAbortHandler {
+			t.Error("http.ErrAbortHandler must reach net/http")
+		}
+	}()
+	handlers["GET /abort"](httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/abort", nil))
+}
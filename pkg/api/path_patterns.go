@@ -0,0 +1,94 @@
+package api
+
+import "strings"
+
+// WithPathPattern documents the regular expression a path parameter must
+// match as its schema pattern. Adapters whose routers accept patterns in the
+// route template, such as chi's "{id:[0-9]+}", add it for every pattern they
+// strip from the registered path. Parameters of the route template not
+// bound by the Path section are documented as strings.
+func WithPathPattern(name, pattern string) Option {
+	return func(h *HandlerOption) {
+		if h.PathPatterns == nil {
+			h.PathPatterns = map[string]string{}
+		}
+		h.PathPatterns[name] = pattern
+	}
+}
+
+// applyPathPatterns adds the path patterns of route to its path parameters.
+func applyPathPatterns(route *RouteInfo, operation *Operation) {
+	if route.Options == nil || len(route.Options.PathPatterns) == 0 {
+		return
+	}
+	documented := map[string]bool{}
+	for i := range operation.Parameters {
+		param := &operation.Parameters[i]
+		pattern, ok := route.Options.PathPatterns[param.Name]
+		if param.In != "path" || !ok {
+			continue
+		}
+		documented[param.Name] = true
+		// Schemas may be shared with other parameters, so set the
+		// pattern on a copy.
+		schema := Schema{}
+		if param.Schema != nil {
+			schema = *param.Schema
+		}
+		schema.Pattern = pattern
+		param.Schema = &schema
+	}
+	for _, name := range pathTemplateParams(route.Path) {
+		pattern, ok := route.Options.PathPatterns[name]
+		if !ok || documented[name] {
+			continue
+		}
+		operation.Parameters = append(operation.Parameters, Parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string", Pattern: pattern},
+		})
+	}
+}
+
+// catchAllPattern is the pattern of path parameters that match the rest of
+// the path, such as the one chi's "/*" catch-all is registered with.
+const catchAllPattern = ".*"
+
+// isCatchAllParam reports whether the path parameter name of route matches
+// the rest of the path, slashes included: a ServeMux "{name...}" wildcard
+// or a parameter with the catch-all pattern.
+func isCatchAllParam(route *RouteInfo, name string) bool {
+	if strings.HasSuffix(name, "...") {
+		return true
+	}
+	return route.Options != nil && route.Options.PathPatterns[name] == catchAllPattern
+}
+
+// catchAllPath returns the path template of route with its catch-all
+// parameters written as "{name...}", the placeholder clients keep the
+// slashes of values in.
+func catchAllPath(route *RouteInfo) string {
+	segments := strings.Split(route.Path, "/")
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") {
+			continue
+		}
+		if name := segment[1 : len(segment)-1]; !strings.HasSuffix(name, "...") && isCatchAllParam(route, name) {
+			segments[i] = "{" + name + "...}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// pathTemplateParams returns the names of the "{name}" placeholders of path.
+func pathTemplateParams(path string) []string {
+	var names []string
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			names = append(names, segment[1:len(segment)-1])
+		}
+	}
+	return names
+}
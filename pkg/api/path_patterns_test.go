@@ -0,0 +1,28 @@
+package api
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPathPatternsOpenAPI(t *testing.T) {
+	type getItemRequest struct {
+		Path struct {
+			ID string `gork:"id"`
+		}
+	}
+	router, registry, _ := newLoadShedRouter()
+	router.Get("/items/{id}/files/{path}", func(context.Context, getItemRequest) (*loadShedResponse, error) { return nil, nil },
+		WithPathPattern("id", "[0-9]+"), WithPathPattern("path", ".*"))
+
+	params := GenerateOpenAPI(registry).Paths["/api/items/{id}/files/{path}"].Get.Parameters
+	if len(params) != 2 {
+		t.Fatalf("expected the bound and the unbound path parameter, got %+v", params)
+	}
+	for _, p := range params {
+		want := map[string]string{"id": "[0-9]+", "path": ".*"}[p.Name]
+		if p.In != "path" || !p.Required || p.Schema.Pattern != want {
+			t.Errorf("%s: in = %s, required = %v, pattern = %q, want %q", p.Name, p.In, p.Required, p.Schema.Pattern, want)
+		}
+	}
+}
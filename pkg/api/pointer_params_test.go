@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type pointerParamsRequest struct {
+	Query struct {
+		Limit  *int       `gork:"limit"`
+		Since  *time.Time `gork:"since"`
+		Active *bool      `gork:"active"`
+	}
+	Headers struct {
+		Tenant *string `gork:"X-Tenant"`
+	}
+	Body struct {
+		Note  *string `gork:"note"`
+		Count *int    `gork:"count" validate:"required"`
+	}
+}
+
+func TestPointerParameters(t *testing.T) {
+	var got pointerParamsRequest
+	registry := NewRouteRegistry()
+	handlers := map[string]http.HandlerFunc{}
+	router := NewTypedRouter[*struct{}](nil, registry, "/api", nil, &DefaultParameterAdapter{}, func(method, path string, h http.HandlerFunc, _ *RouteInfo) {
+		handlers[method+" "+path] = h
+	})
+	router.Post("/notes", func(_ context.Context, req pointerParamsRequest) (*loadShedResponse, error) {
+		got = req
+		return &loadShedResponse{}, nil
+	})
+
+	tests := []struct {
+		name   string
+		target string
+		body   string
+		check  func(t *testing.T)
+	}{
+		{
+			name:   "absent",
+			target: "/api/notes",
+			body:   `{"count":1}`,
+			check: func(t *testing.T) {
+				if got.Query.Limit != nil || got.Query.Since != nil || got.Query.Active != nil || got.Headers.Tenant != nil || got.Body.Note != nil {
+					t.Errorf("absent fields must stay nil, got %+v", got)
+				}
+			},
+		},
+		{
+			name:   "zero values",
+			target: "/api/notes?limit=0&active=false&since=2024-01-02T03:04:05Z",
+			body:   `{"note":"","count":0}`,
+			check: func(t *testing.T) {
+				if got.Query.Limit == nil || *got.Query.Limit != 0 || got.Query.Active == nil || *got.Query.Active {
+					t.Errorf("zero values must be set, got %+v", got.Query)
+				}
+				if got.Query.Since == nil || got.Query.Since.Year() != 2024 {
+					t.Errorf("since = %v", got.Query.Since)
+				}
+				if got.Body.Note == nil || *got.Body.Note != "" || got.Body.Count == nil {
+					t.Errorf("body zero values must be set, got %+v", got.Body)
+				}
+			},
+		},
+		{
+			name:   "null body field",
+			target: "/api/notes",
+			body:   `{"note":null,"count":2}`,
+			check: func(t *testing.T) {
+				if got.Body.Note != nil || *got.Body.Count != 2 {
+					t.Errorf("unexpected body %+v", got.Body)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = pointerParamsRequest{}
+			w := httptest.NewRecorder()
+			handlers["POST /notes"](w, httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader(tt.body)))
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", w.Code, w.Body)
+			}
+			tt.check(t)
+		})
+	}
+
+	t.Run("header", func(t *testing.T) {
+		got = pointerParamsRequest{}
+		r := httptest.NewRequest(http.MethodPost, "/api/notes", strings.NewReader(`{"count":1}`))
+		r.Header.Set("X-Tenant", "acme")
+		handlers["POST /notes"](httptest.NewRecorder(), r)
+		if got.Headers.Tenant == nil || *got.Headers.Tenant != "acme" {
+			t.Errorf("tenant = %v", got.Headers.Tenant)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handlers["POST /notes"](w, httptest.NewRequest(http.MethodPost, "/api/notes?limit=x", strings.NewReader(`{"count":1}`)))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want 400", w.Code)
+		}
+	})
+
+	t.Run("required", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handlers["POST /notes"](w, httptest.NewRequest(http.MethodPost, "/api/notes", strings.NewReader(`{}`)))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("a nil required pointer must fail validation, status = %d", w.Code)
+		}
+	})
+}
+
+func TestPointerParameterSchemas(t *testing.T) {
+	router, registry, _ := newLoadShedRouter()
+	router.Post("/notes", func(context.Context, pointerParamsRequest) (*loadShedResponse, error) { return nil, nil })
+	data, err := json.Marshal(GenerateOpenAPI(registry))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`{"in":"query","name":"limit","required":false,"schema":{"type":"integer"}}`,
+		`{"in":"header","name":"X-Tenant","required":false,"schema":{"type":"string"}}`,
+		`"note":{"type":["string","null"]}`,
+		`"count":{"type":["integer","null"]}`,
+		`"required":["count"]`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("spec is missing %s:\n%s", want, data)
+		}
+	}
+}
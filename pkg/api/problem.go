@@ -0,0 +1,188 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// Problem is an RFC 9457 (formerly RFC 7807) problem details object, the
+// error body of routes using WithProblemJSON.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// Errors maps request fields to validation messages (400 only).
+	Errors FieldErrors `json:"errors,omitempty"`
+	// Details carries additional information such as a correlation ID.
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// WithProblemJSON makes a route (or every route, when passed to a router or
+// group) answer the errors gork generates itself - validation, parsing,
+// authentication, rate limiting and server errors - with
+// application/problem+json Problem bodies instead of ErrorResponse. Errors
+// declared with WithErrorResponse keep their own types.
+func WithProblemJSON() Option {
+	return func(h *HandlerOption) {
+		h.ProblemJSON = true
+	}
+}
+
+// problemResponseWriter marks responses that report errors as problems.
+type problemResponseWriter struct {
+	http.ResponseWriter
+	instance string
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *problemResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Flush supports streaming responses.
+func (w *problemResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// withProblemJSON makes the errors written by next problems.
+func withProblemJSON(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		next(&problemResponseWriter{ResponseWriter: w, instance: r.URL.Path}, r)
+	}
+}
+
+// writeErrorResponse writes resp with status code, as a Problem when w
+// belongs to a route using WithProblemJSON.
+func writeErrorResponse(w http.ResponseWriter, code int, resp ErrorResponse) {
+	if rw, ok := findResponseWriter[*requestIDResponseWriter](w); ok {
+		details := make(map[string]interface{}, len(resp.Details)+1)
+		for k, v := range resp.Details {
+			details[k] = v
+		}
+		details["request_id"] = rw.id
+		resp.Details = details
+	}
+	if pw, ok := findResponseWriter[*problemResponseWriter](w); ok {
+		problem := pw.problem(code, resp.Error)
+		problem.Details = resp.Details
+		writeProblem(w, problem)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// findResponseWriter returns the writer of type W among w and the writers it
+// wraps, so markers such as problemResponseWriter survive writers added
+// further in, like middleware wrappers and the buffer of WithTimeout.
+func findResponseWriter[W http.ResponseWriter](w http.ResponseWriter) (W, bool) {
+	for w != nil {
+		if found, ok := w.(W); ok {
+			return found, true
+		}
+		switch u := w.(type) {
+		case *timeoutWriter:
+			w = u.outer
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = u.Unwrap()
+		default:
+			w = nil
+		}
+	}
+	var zero W
+	return zero, false
+}
+
+// problem returns the Problem for status code with message as detail.
+func (w *problemResponseWriter) problem(code int, message string) Problem {
+	problem := Problem{Type: "about:blank", Title: http.StatusText(code), Status: code, Instance: w.instance}
+	if message != problem.Title {
+		problem.Detail = message
+	}
+	return problem
+}
+
+func writeProblem(w http.ResponseWriter, problem Problem) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(problem.Status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+// applyProblemJSON documents the generated error responses of a route using
+// WithProblemJSON as application/problem+json Problem bodies. Shared
+// components get a Problem variant, e.g. BadRequestProblem.
+func applyProblemJSON(route *RouteInfo, operation *Operation, components *Components) {
+	if route.Options == nil || !route.Options.ProblemJSON {
+		return
+	}
+	ensureProblemSchema(components)
+	for status, resp := range operation.Responses {
+		if name := strings.TrimPrefix(resp.Ref, "#/components/responses/"); name != resp.Ref {
+			if shared := components.Responses[name]; shared != nil && reportsError(shared) {
+				variant := name + "Problem"
+				if _, ok := components.Responses[variant]; !ok {
+					components.Responses[variant] = asProblemResponse(shared)
+				}
+				operation.Responses[status] = &Response{Ref: "#/components/responses/" + variant}
+			}
+			continue
+		}
+		if reportsError(resp) {
+			operation.Responses[status] = asProblemResponse(resp)
+		}
+	}
+}
+
+// reportsError reports whether resp is documented with a gork error schema.
+func reportsError(resp *Response) bool {
+	media := resp.Content["application/json"]
+	if media == nil || media.Schema == nil {
+		return false
+	}
+	ref := media.Schema.Ref
+	return ref == "#/components/schemas/ErrorResponse" || ref == "#/components/schemas/ValidationErrorResponse"
+}
+
+// asProblemResponse returns a copy of resp with a problem+json body.
+func asProblemResponse(resp *Response) *Response {
+	out := *resp
+	out.Content = map[string]*MediaType{
+		"application/problem+json": {Schema: &Schema{Ref: "#/components/schemas/Problem"}},
+	}
+	return &out
+}
+
+// ensureProblemSchema adds the Problem schema to components.
+func ensureProblemSchema(components *Components) {
+	if components.Schemas == nil {
+		components.Schemas = map[string]*Schema{}
+	}
+	if _, ok := components.Schemas["Problem"]; ok {
+		return
+	}
+	components.Schemas["Problem"] = &Schema{
+		Type:        "object",
+		Title:       "Problem",
+		Description: "RFC 9457 problem details",
+		Properties: map[string]*Schema{
+			"type":     {Type: "string", Description: "URI reference identifying the problem type"},
+			"title":    {Type: "string", Description: "Short summary of the problem type"},
+			"status":   {Type: "integer", Description: "HTTP status code"},
+			"detail":   {Type: "string", Description: "Explanation specific to this occurrence"},
+			"instance": {Type: "string", Description: "URI reference identifying this occurrence"},
+			"errors": {
+				Type:                 "object",
+				Description:          "Field-level validation errors (maps field names to arrays of error messages)",
+				AdditionalProperties: &Schema{Type: "array", Items: &Schema{Type: "string"}},
+			},
+			"details": {Type: "object", Description: "Additional error details"},
+		},
+		Required: []string{"type", "title", "status"},
+	}
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProblemJSONResponses(t *testing.T) {
+	router, _, handlers := newLoadShedRouter(WithProblemJSON())
+	router.Post("/greet", func(context.Context, typedMiddlewareRequest) (*loadShedResponse, error) {
+		return nil, errors.New("database down")
+	})
+	router.Get("/limited", func(context.Context, struct{}) (*loadShedResponse, error) { return nil, nil },
+		WithRateLimit(RateLimiterFunc(func(*http.Request) (bool, time.Duration) { return false, time.Second })))
+
+	tests := []struct {
+		name, route, method, body string
+		status                    int
+		want                      string
+	}{
+		{"validation", "POST /greet", http.MethodPost, `{}`, http.StatusBadRequest,
+			`{"type":"about:blank","title":"Bad Request","status":400,"detail":"Validation failed","instance":"/api/greet","errors":{"body.name":["required"]}}`},
+		{"parsing", "POST /greet", http.MethodPost, `{`, http.StatusBadRequest, `"title":"Bad Request","status":400,"detail":"failed to parse Body section`},
+		{"handler error", "POST /greet", http.MethodPost, `{"name":"ada"}`, http.StatusInternalServerError,
+			`{"type":"about:blank","title":"Internal Server Error","status":500,"instance":"/api/greet"}`},
+		{"rate limited", "GET /limited", http.MethodGet, ``, http.StatusTooManyRequests,
+			`{"type":"about:blank","title":"Too Many Requests","status":429,"instance":"/api/limited"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handlers[tt.route](w, httptest.NewRequest(tt.method, "/api"+strings.Fields(tt.route)[1], strings.NewReader(tt.body)))
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != "application/problem+json" {
+				t.Errorf("Content-Type = %q", ct)
+			}
+			if got := strings.TrimSpace(w.Body.String()); !strings.Contains(got, tt.want) {
+				t.Errorf("body = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProblemJSONSpec(t *testing.T) {
+	router, registry, _ := newLoadShedRouter()
+	router.Post("/greet", func(context.Context, typedMiddlewareRequest) (*loadShedResponse, error) { return nil, nil }, WithProblemJSON())
+	router.Post("/plain", func(context.Context, typedMiddlewareRequest) (*loadShedResponse, error) { return nil, nil },
+		WithRateLimit(RateLimiterFunc(func(*http.Request) (bool, time.Duration) { return true, 0 })))
+
+	spec := GenerateOpenAPI(registry)
+	op := spec.Paths["/api/greet"].Post
+	for status, want := range map[string]string{"400": "BadRequestProblem", "422": "UnprocessableEntityProblem", "500": "InternalServerErrorProblem"} {
+		if got := op.Responses[status].Ref; got != "#/components/responses/"+want {
+			t.Errorf("%s: ref = %q, want %s", status, got, want)
+		}
+	}
+	variant := spec.Components.Responses["BadRequestProblem"]
+	if variant == nil || variant.Content["application/problem+json"].Schema.Ref != "#/components/schemas/Problem" {
+		t.Errorf("expected a problem+json variant, got %+v", variant)
+	}
+	if spec.Components.Schemas["Problem"] == nil {
+		t.Error("expected the Problem schema")
+	}
+	if got := spec.Paths["/api/plain"].Post.Responses["400"].Ref; got != "#/components/responses/BadRequest" {
+		t.Errorf("routes without WithProblemJSON must keep ErrorResponse, got %q", got)
+	}
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+// QueryValuesAdapter is implemented by parameter adapters that can return
+// every value of a repeated query parameter (?tag=a&tag=b). Without it slice
+// fields only bind comma-separated values. HTTPParameterAdapter, and so the
+// built-in adapters, implement it.
+type QueryValuesAdapter[T any] interface {
+	QueryValues(ctx T, key string) []string
+}
+
+// QueryValues returns every value of a query parameter.
+func (HTTPParameterAdapter) QueryValues(r *http.Request, k string) []string {
+	return r.URL.Query()[k]
+}
+
+// QueryValues returns every value of a query parameter.
+func (d *DefaultParameterAdapter) QueryValues(r *http.Request, key string) []string {
+	return r.URL.Query()[key]
+}
+
+// isDeepObjectType reports whether a query field of type t is bound in the
+// deepObject style (?filter[status]=open&filter[min]=3): a struct, other
+// than time.Time and text marshalers, with gork-tagged fields.
+func isDeepObjectType(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || t == reflect.TypeOf(time.Time{}) || reflect.PtrTo(t).Implements(textMarshalerType) {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() && t.Field(i).Tag.Get("gork") != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRepeatedQuery binds a slice field from a query parameter repeated
+// under its name or one of its aliases, reporting whether it was repeated.
+// Single values are left to the comma-separated parsing.
+func (p *ConventionParser) parseRepeatedQuery(ctx context.Context, fieldValue reflect.Value, binding fieldBinding, r *http.Request, adapter GenericParameterAdapter[*http.Request]) (bool, error) {
+	multi, ok := adapter.(QueryValuesAdapter[*http.Request])
+	if !ok || binding.field.Type.Kind() != reflect.Slice {
+		return false, nil
+	}
+	for _, key := range binding.keys {
+		if values := multi.QueryValues(r, key); len(values) > 1 {
+			return true, p.setSliceValues(ctx, fieldValue, binding.field, values)
+		}
+	}
+	return false, nil
+}
+
+// parseDeepObject binds the fields of a deepObject query parameter from the
+// keys name[field].
+func (p *ConventionParser) parseDeepObject(ctx context.Context, fieldValue reflect.Value, name string, r *http.Request, adapter GenericParameterAdapter[*http.Request]) error {
+	t := fieldValue.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		gorkTag := field.Tag.Get("gork")
+		if !field.IsExported() || gorkTag == "" {
+			continue
+		}
+		tagInfo := parseGorkTag(gorkTag)
+		key := name + "[" + tagInfo.Name + "]"
+		if val, ok := adapter.Query(r, key); ok {
+			if err := p.setFieldValue(ctx, fieldValue.Field(i), field, val); err != nil {
+				return fmt.Errorf("failed to set query parameter %s: %w", key, err)
+			}
+		} else if err := p.setDefault(ctx, fieldValue.Field(i), field, tagInfo); err != nil {
+			return fmt.Errorf("failed to set query parameter %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// applyQueryStyle documents how a query parameter is serialized: repeated
+// keys for slices and deepObject for structs.
+func applyQueryStyle(param *Parameter, t reflect.Type) {
+	explode := true
+	switch {
+	case t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8:
+		param.Style, param.Explode = "form", &explode
+	case isDeepObjectType(t):
+		param.Style, param.Explode = "deepObject", &explode
+	}
+}
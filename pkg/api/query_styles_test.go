@@ -0,0 +1,116 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type queryStylesRequest struct {
+	Query struct {
+		Tags   []string `gork:"tag,alias=label"`
+		Filter struct {
+			Status string `gork:"status"`
+			Min    int    `gork:"min,default=1"`
+		} `gork:"filter"`
+		Since time.Time `gork:"since"`
+	}
+}
+
+func TestQueryStyles(t *testing.T) {
+	var got queryStylesRequest
+	registry := NewRouteRegistry()
+	handlers := map[string]http.HandlerFunc{}
+	router := NewTypedRouter[*struct{}](nil, registry, "/api", nil, &DefaultParameterAdapter{}, func(method, path string, h http.HandlerFunc, _ *RouteInfo) {
+		handlers[method+" "+path] = h
+	})
+	router.Get("/orders", func(_ context.Context, req queryStylesRequest) (*struct{}, error) {
+		got = req
+		return nil, nil
+	})
+
+	tests := []struct {
+		query      string
+		tags       []string
+		status     string
+		min        int
+		wantStatus int
+	}{
+		{"tag=a&tag=b,c", []string{"a", "b,c"}, "", 1, http.StatusNoContent},
+		{"tag=a,b", []string{"a", "b"}, "", 1, http.StatusNoContent},
+		{"label=x&label=y", []string{"x", "y"}, "", 1, http.StatusNoContent},
+		{"filter[status]=open&filter[min]=3", nil, "open", 3, http.StatusNoContent},
+		{"filter[min]=many", nil, "", 0, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		got = queryStylesRequest{}
+		w := httptest.NewRecorder()
+		handlers["GET /orders"](w, httptest.NewRequest(http.MethodGet, "/api/orders?"+tt.query, nil))
+		if w.Code != tt.wantStatus {
+			t.Errorf("%s: status = %d: %s", tt.query, w.Code, w.Body)
+			continue
+		}
+		if w.Code == http.StatusNoContent && (!reflect.DeepEqual(got.Query.Tags, tt.tags) || got.Query.Filter.Status != tt.status || got.Query.Filter.Min != tt.min) {
+			t.Errorf("%s: got %+v", tt.query, got.Query)
+		}
+	}
+
+	params := map[string]Parameter{}
+	for _, p := range GenerateOpenAPI(registry).Paths["/api/orders"].Get.Parameters {
+		params[p.Name] = p
+	}
+	if p := params["tag"]; p.Style != "form" || p.Explode == nil || !*p.Explode {
+		t.Errorf("expected exploded form style for slices, got %+v", p)
+	}
+	if p := params["filter"]; p.Style != "deepObject" || p.Explode == nil || !*p.Explode || p.Schema.Properties["status"] == nil {
+		t.Errorf("expected deepObject style for structs, got %+v", p)
+	}
+	if p := params["since"]; p.Style != "" || p.Explode != nil {
+		t.Errorf("time.Time must stay a plain parameter, got %+v", p)
+	}
+}
+
+type sliceTestSKU struct{ code string }
+
+type typedSlicesRequest struct {
+	Query struct {
+		IDs   []int64        `gork:"ids"`
+		Days  []time.Time    `gork:"days"`
+		Hosts []netip.Addr   `gork:"hosts"`
+		SKUs  []sliceTestSKU `gork:"skus"`
+		Flags []bool         `gork:"flags"`
+	}
+}
+
+func TestTypedSliceParameters(t *testing.T) {
+	parser := NewConventionParser()
+	if err := parser.RegisterTypeParser(func(_ context.Context, s string) (*sliceTestSKU, error) {
+		return &sliceTestSKU{code: strings.ToUpper(s)}, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	parse := func(query string) (typedSlicesRequest, error) {
+		var req typedSlicesRequest
+		r := httptest.NewRequest(http.MethodGet, "/items?"+query, nil)
+		return req, parser.ParseRequest(r.Context(), r, reflect.ValueOf(&req), &DefaultParameterAdapter{})
+	}
+
+	req, err := parse("ids=1,2,3&days=2026-01-02T00:00:00Z&hosts=10.0.0.1&hosts=::1&skus=a-1,b-2&flags=true,false")
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := req.Query
+	if !reflect.DeepEqual(q.IDs, []int64{1, 2, 3}) || q.Days[0].Day() != 2 || q.Hosts[1] != netip.IPv6Loopback() ||
+		!reflect.DeepEqual(q.SKUs, []sliceTestSKU{{"A-1"}, {"B-2"}}) || !reflect.DeepEqual(q.Flags, []bool{true, false}) {
+		t.Errorf("unexpected query %+v", q)
+	}
+
+	if _, err := parse("ids=1,x"); err == nil || !strings.Contains(err.Error(), "element 1: invalid integer value: x") {
+		t.Errorf("expected the failing element to be reported, got %v", err)
+	}
+}
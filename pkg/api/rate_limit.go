@@ -0,0 +1,72 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimiter decides whether a request may be served. Implementations
+// typically key their budget on the client (IP address, API key, user) and
+// must be safe for concurrent use.
+type RateLimiter interface {
+	// Allow reports whether r may proceed. When it may not, retryAfter tells
+	// the client how long to wait; zero leaves Retry-After out.
+	Allow(r *http.Request) (ok bool, retryAfter time.Duration)
+}
+
+// RateLimiterFunc adapts a function to the RateLimiter interface.
+type RateLimiterFunc func(r *http.Request) (bool, time.Duration)
+
+// Allow calls f(r).
+func (f RateLimiterFunc) Allow(r *http.Request) (bool, time.Duration) {
+	return f(r)
+}
+
+// WithRateLimit rejects requests the limiter denies with 429 Too Many
+// Requests and a Retry-After header before they are parsed. The response is
+// documented in the generated OpenAPI operation.
+func WithRateLimit(limiter RateLimiter) Option {
+	return func(h *HandlerOption) {
+		h.RateLimiter = limiter
+	}
+}
+
+// limitRate wraps next with the limiter's decision.
+func limitRate(limiter RateLimiter, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ok, retryAfter := limiter.Allow(r)
+		if ok {
+			next(w, r)
+			return
+		}
+		if retryAfter > 0 {
+			secs := int((retryAfter + time.Second - 1) / time.Second)
+			w.Header().Set("Retry-After", strconv.Itoa(secs))
+		}
+		writeErrorResponse(w, http.StatusTooManyRequests, ErrorResponse{Error: http.StatusText(http.StatusTooManyRequests)})
+	}
+}
+
+// addTooManyRequestsResponse documents the 429 response returned for rate
+// limited requests, including the Retry-After header.
+func addTooManyRequestsResponse(operation *Operation, components *Components) {
+	if components.Responses == nil {
+		components.Responses = map[string]*Response{}
+	}
+	if _, ok := components.Responses["TooManyRequests"]; !ok {
+		components.Responses["TooManyRequests"] = &Response{
+			Description: "Too Many Requests - Rate limit exceeded, retry later",
+			Headers: map[string]*Header{
+				"Retry-After": {
+					Description: "Number of seconds to wait before retrying",
+					Schema:      &Schema{Type: "integer"},
+				},
+			},
+			Content: map[string]*MediaType{
+				"application/json": {Schema: &Schema{Ref: "#/components/schemas/ErrorResponse"}},
+			},
+		}
+	}
+	operation.Responses["429"] = &Response{Ref: "#/components/responses/TooManyRequests"}
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimit(t *testing.T) {
+	var calls atomic.Int32
+	limiter := RateLimiterFunc(func(r *http.Request) (bool, time.Duration) {
+		return calls.Add(1) <= 2, 1500 * time.Millisecond
+	})
+	router, registry, handlers := newLoadShedRouter()
+	router.Get("/items", func(context.Context, struct{}) (*struct{}, error) { return nil, nil }, WithRateLimit(limiter))
+
+	var codes []int
+	for i := 0; i < 3; i++ {
+		w := httptest.NewRecorder()
+		handlers["GET /items"](w, httptest.NewRequest(http.MethodGet, "/api/items", nil))
+		codes = append(codes, w.Code)
+		if w.Code == http.StatusTooManyRequests {
+			if got := w.Header().Get("Retry-After"); got != "2" {
+				t.Errorf("Retry-After = %q, want 2", got)
+			}
+			if got := w.Body.String(); got != "{\"error\":\"Too Many Requests\"}\n" {
+				t.Errorf("body = %q", got)
+			}
+		}
+	}
+	if codes[0] != http.StatusNoContent || codes[1] != http.StatusNoContent || codes[2] != http.StatusTooManyRequests {
+		t.Errorf("codes = %v", codes)
+	}
+
+	spec := GenerateOpenAPI(registry)
+	if resp := spec.Paths["/api/items"].Get.Responses["429"]; resp == nil || resp.Ref != "#/components/responses/TooManyRequests" {
+		t.Errorf("expected a documented 429, got %+v", resp)
+	}
+	if c := spec.Components.Responses["TooManyRequests"]; c == nil || c.Headers["Retry-After"] == nil {
+		t.Errorf("expected the TooManyRequests component with Retry-After, got %+v", c)
+	}
+}
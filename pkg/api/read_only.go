@@ -0,0 +1,37 @@
+package api
+
+import (
+	"reflect"
+	"sync"
+)
+
+// readOnlyTypes caches whether a type contains fields tagged readonly.
+var readOnlyTypes sync.Map // reflect.Type -> bool
+
+// hasReadOnlyFields reports whether values of t contain fields tagged
+// `gork:"...,readonly"`, so that bodies without any skip the check for them.
+func hasReadOnlyFields(t reflect.Type) bool {
+	if cached, ok := readOnlyTypes.Load(t); ok {
+		return cached.(bool)
+	}
+	found := scanReadOnlyFields(t, map[reflect.Type]bool{})
+	readOnlyTypes.Store(t, found)
+	return found
+}
+
+func scanReadOnlyFields(t reflect.Type, seen map[reflect.Type]bool) bool {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || seen[t] {
+		return false
+	}
+	seen[t] = true
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if parseGorkTag(field.Tag.Get("gork")).ReadOnly || scanReadOnlyFields(field.Type, seen) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type readOnlyAccount struct {
+	ID        string `gork:"id,readonly"`
+	Email     string `gork:"email"`
+	Password  string `gork:"password,writeonly"`
+	CreatedAt string `gork:"created_at,readonly"`
+}
+
+type readOnlyRequest struct {
+	Body readOnlyAccount
+}
+
+type readOnlyResponse struct {
+	Body readOnlyAccount
+}
+
+func TestReadOnlyWriteOnlySchema(t *testing.T) {
+	router, registry, _ := newLoadShedRouter()
+	router.Post("/accounts", func(_ context.Context, req readOnlyRequest) (*readOnlyResponse, error) {
+		return &readOnlyResponse{Body: req.Body}, nil
+	})
+	spec := GenerateOpenAPI(registry)
+
+	account := spec.Components.Schemas["readOnlyAccount"]
+	if account == nil {
+		t.Fatalf("missing readOnlyAccount component: %v", spec.Components.Schemas)
+	}
+	for name, want := range map[string][2]bool{
+		"id":         {true, false},
+		"created_at": {true, false},
+		"password":   {false, true},
+		"email":      {false, false},
+	} {
+		prop := account.Properties[name]
+		if prop.ReadOnly != want[0] || prop.WriteOnly != want[1] {
+			t.Errorf("%s: readOnly=%v writeOnly=%v, want %v", name, prop.ReadOnly, prop.WriteOnly, want)
+		}
+	}
+
+	data, err := json.Marshal(account.Properties["id"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"readOnly":true`) {
+		t.Errorf("expected readOnly in %s", data)
+	}
+}
+
+func TestReadOnlyFieldsRejectedInRequestBody(t *testing.T) {
+	router, _, handlers := newLoadShedRouter()
+	router.Post("/accounts", func(_ context.Context, req readOnlyRequest) (*readOnlyResponse, error) {
+		return &readOnlyResponse{Body: req.Body}, nil
+	})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/accounts", strings.NewReader(`{"id":"a1","email":"a@b.c","created_at":"now"}`))
+	handlers["POST /accounts"](w, r)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400: %s", w.Code, w.Body)
+	}
+	if !strings.Contains(w.Body.String(), "read-only fields: created_at, id") {
+		t.Errorf("unexpected error body %s", w.Body)
+	}
+
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodPost, "/accounts", strings.NewReader(`{"email":"a@b.c","password":"secret"}`))
+	handlers["POST /accounts"](w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body)
+	}
+}
+
+func TestReadOnlyFieldsRejectedInFormAndCSVBodies(t *testing.T) {
+	type importRequest struct {
+		Body []readOnlyAccount
+	}
+	router, _, handlers := newLoadShedRouter()
+	router.Post("/accounts", func(_ context.Context, req readOnlyRequest) (*readOnlyResponse, error) {
+		return &readOnlyResponse{Body: req.Body}, nil
+	}, WithFormBody())
+	router.Post("/imports", func(context.Context, importRequest) error { return nil })
+
+	tests := []struct {
+		name, route, contentType, body string
+		wantStatus                     int
+	}{
+		{"form", "POST /accounts", ContentTypeFormURLEncoded, "id=a1&email=a%40b.c", http.StatusBadRequest},
+		{"form without readonly keys", "POST /accounts", ContentTypeFormURLEncoded, "email=a%40b.c", http.StatusOK},
+		{"csv", "POST /imports", ContentTypeCSV, "email,id\na@b.c,a1\n", http.StatusBadRequest},
+		{"csv without readonly columns", "POST /imports", ContentTypeCSV, "email\na@b.c\n", http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/api", strings.NewReader(tt.body))
+		r.Header.Set("Content-Type", tt.contentType)
+		handlers[tt.route](w, r)
+		if w.Code != tt.wantStatus {
+			t.Errorf("%s: status = %d, want %d: %s", tt.name, w.Code, tt.wantStatus, w.Body)
+			continue
+		}
+		if tt.wantStatus == http.StatusBadRequest && !strings.Contains(w.Body.String(), "read-only fields: id") {
+			t.Errorf("%s: unexpected error body %s", tt.name, w.Body)
+		}
+	}
+}
+
+func TestHasReadOnlyFields(t *testing.T) {
+	type nested struct {
+		Items []readOnlyAccount `gork:"items"`
+	}
+	if !hasReadOnlyFields(reflect.TypeOf(nested{})) {
+		t.Error("expected nested read-only fields to be found")
+	}
+	if hasReadOnlyFields(reflect.TypeOf(loadShedResponse{})) {
+		t.Error("expected no read-only fields")
+	}
+}
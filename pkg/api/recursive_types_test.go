@@ -0,0 +1,73 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type recursiveTestNode struct {
+	Name     string                            `gork:"name" validate:"required"`
+	Children []recursiveTestNode               `gork:"children"`
+	Parent   *recursiveTestNode                `gork:"parent"`
+	Index    map[string]*recursiveTestCategory `gork:"index"`
+}
+
+type recursiveTestCategory struct {
+	Root recursiveTestNode `gork:"root"`
+}
+
+type recursiveTestRequest struct {
+	Body recursiveTestNode
+}
+
+type recursiveTestResponse struct {
+	Body struct {
+		Tree recursiveTestNode `gork:"tree"`
+	}
+}
+
+func TestRecursiveTypeSchemas(t *testing.T) {
+	router, registry, _ := newLoadShedRouter()
+	router.Post("/trees", func(context.Context, recursiveTestRequest) (*recursiveTestResponse, error) { return nil, nil })
+	spec := GenerateOpenAPI(registry)
+
+	data, err := json.Marshal(spec.Components.Schemas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"children":{"type":"array","title":"[]recursiveTestNode","description":"Array of recursiveTestNode","items":{"$ref":"#/components/schemas/recursiveTestNode"}}`,
+		`"parent":{"anyOf":[{"$ref":"#/components/schemas/recursiveTestNode"},{"type":"null"}]}`,
+		`"recursiveTestCategory":{"type":"object","title":"recursiveTestCategory","properties":{"root":{"$ref":"#/components/schemas/recursiveTestNode"}}}`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("schemas are missing %s:\n%s", want, data)
+		}
+	}
+	for name := range spec.Components.Schemas {
+		if strings.HasPrefix(name, "Api") || strings.HasSuffix(name, "2") {
+			t.Errorf("recursive types must be registered once, found %s", name)
+		}
+	}
+
+	var client bytes.Buffer
+	if err := GenerateClient(registry, ClientConfig{Lang: "ts"}, &client); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(client.String(), "  children?: recursiveTestNode[];\n  parent?: recursiveTestNode | null;\n") {
+		t.Errorf("client must reference the recursive interface:\n%s", client.String())
+	}
+}
+
+func TestRecursiveTypeValidation(t *testing.T) {
+	req := &recursiveTestRequest{}
+	req.Body.Name = "root"
+	req.Body.Children = []recursiveTestNode{{Name: "leaf"}}
+	req.Body.Parent = &recursiveTestNode{Name: "up"}
+	if err := NewConventionValidator().ValidateRequest(context.Background(), req); err != nil {
+		t.Errorf("expected a valid tree, got %v", err)
+	}
+}
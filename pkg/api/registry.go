@@ -26,6 +26,10 @@ type RouteInfo struct {
 	WebhookHandledEvents []string
 	// WebhookHandlersMeta contains detailed metadata about each registered handler for documentation.
 	WebhookHandlersMeta []RegisteredEventHandler
+	// WebhookSuccessStatus is the status acknowledging processed events (0 means 200).
+	WebhookSuccessStatus int
+	// WebhookEmptySuccessBody reports that acknowledgements carry no body.
+	WebhookEmptySuccessBody bool
 	// Middleware can hold router specific middleware descriptors. For now we
 	// simply keep them as raw Option values so that future work can refine the
 	// representation without breaking the API.
@@ -38,8 +42,14 @@ type RouteInfo struct {
 //
 // The registry is safe for concurrent use by multiple goroutines.
 type RouteRegistry struct {
-	mu     sync.RWMutex
-	routes []*RouteInfo
+	mu              sync.RWMutex
+	routes          []*RouteInfo
+	securitySchemes map[string]declaredSecurityScheme
+	// autoOptions records the paths given an OPTIONS handler by
+	// WithAutoOptions.
+	autoOptions map[string]bool
+	// revision counts the changes that affect generated specs.
+	revision uint64
 }
 
 // NewRouteRegistry creates a new, empty registry.
@@ -54,9 +64,19 @@ func (r *RouteRegistry) Register(info *RouteInfo) {
 	}
 	r.mu.Lock()
 	r.routes = append(r.routes, info)
+	r.revision++
 	r.mu.Unlock()
 }
 
+// Revision returns a number that changes whenever a route or security scheme
+// is registered, so that specs generated from the registry can be cached
+// until it changes.
+func (r *RouteRegistry) Revision() uint64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.revision
+}
+
 // GetRoutes returns a copy of all registered routes so callers can freely
 // modify the returned slice without affecting the internal state.
 func (r *RouteRegistry) GetRoutes() []*RouteInfo {
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// RequestIDHeader is the header WithRequestID reads and echoes.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds the request IDs accepted from clients.
+const maxRequestIDLength = 128
+
+type requestIDKey struct{}
+
+// WithRequestID gives every request an ID: the client's X-Request-ID when it
+// sends a valid one, or a generated one. The ID is stored in the request
+// context, see RequestIDFromContext, echoed as the X-Request-ID response
+// header and added as "request_id" to the details of error responses. The
+// header is documented on the generated operations.
+func WithRequestID() Option {
+	return func(h *HandlerOption) {
+		h.RequestID = true
+	}
+}
+
+// RequestIDFromContext returns the ID WithRequestID gave the request, or ""
+// for routes without it.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// requestIDResponseWriter marks responses of routes using WithRequestID.
+type requestIDResponseWriter struct {
+	http.ResponseWriter
+	id string
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *requestIDResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Flush supports streaming responses.
+func (w *requestIDResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// withRequestID assigns the request its ID before calling next.
+func withRequestID(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		r = r.WithContext(context.WithValue(r.Context(), requestIDKey{}, id))
+		next(&requestIDResponseWriter{ResponseWriter: w, id: id}, r)
+	}
+}
+
+// validRequestID reports whether a client's request ID is safe to echo and
+// log: short and made of visible ASCII characters.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID returns a random 128-bit ID in hex.
+func newRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// applyRequestID documents the X-Request-ID request and response headers of
+// routes using WithRequestID.
+func applyRequestID(route *RouteInfo, operation *Operation) {
+	if route.Options == nil || !route.Options.RequestID {
+		return
+	}
+	maxLength := maxRequestIDLength
+	operation.Parameters = append(operation.Parameters, Parameter{
+		Name:        RequestIDHeader,
+		In:          "header",
+		Description: "Correlation ID of the request; generated when missing",
+		Schema:      &Schema{Type: "string", MaxLength: &maxLength},
+	})
+	for _, resp := range operation.Responses {
+		if resp.Ref != "" {
+			continue
+		}
+		if resp.Headers == nil {
+			resp.Headers = map[string]*Header{}
+		}
+		resp.Headers[RequestIDHeader] = &Header{
+			Description: "Correlation ID of the request",
+			Required:    true,
+			Schema:      &Schema{Type: "string"},
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRequestID(t *testing.T) {
+	var seen string
+	router, _, handlers := newLoadShedRouter(WithRequestID())
+	router.Get("/items", func(ctx context.Context, _ struct{}) (*loadShedResponse, error) {
+		seen = RequestIDFromContext(ctx)
+		return &loadShedResponse{}, nil
+	})
+
+	send := func(id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/items", nil)
+		if id != "" {
+			req.Header.Set(RequestIDHeader, id)
+		}
+		w := httptest.NewRecorder()
+		handlers["GET /items"](w, req)
+		return w
+	}
+
+	w := send("")
+	if id := w.Header().Get(RequestIDHeader); len(id) != 32 || id != seen {
+		t.Errorf("generated ID: header = %q, context = %q", id, seen)
+	}
+	if w := send("trace-123"); w.Header().Get(RequestIDHeader) != "trace-123" || seen != "trace-123" {
+		t.Errorf("client ID not kept: header = %q, context = %q", w.Header().Get(RequestIDHeader), seen)
+	}
+	for _, bad := range []string{"has space", "new\nline", strings.Repeat("x", 129)} {
+		if w := send(bad); w.Header().Get(RequestIDHeader) == bad || len(seen) != 32 {
+			t.Errorf("invalid ID %q was kept", bad)
+		}
+	}
+}
+
+func TestRequestIDInErrors(t *testing.T) {
+	router, _, handlers := newLoadShedRouter(WithRequestID())
+	router.Get("/fail", func(context.Context, struct{}) (*loadShedResponse, error) {
+		return nil, errors.New("boom")
+	})
+	router.Get("/problem", func(context.Context, struct{}) (*loadShedResponse, error) {
+		return nil, errors.New("boom")
+	}, WithProblemJSON(), WithTimeout(time.Second))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/fail", nil)
+	req.Header.Set(RequestIDHeader, "abc")
+	w := httptest.NewRecorder()
+	handlers["GET /fail"](w, req)
+	var resp ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || resp.Details["request_id"] != "abc" {
+		t.Errorf("expected request_id in details, got %s", w.Body)
+	}
+
+	router.Get("/validate", func(context.Context, struct {
+		Headers struct {
+			Tenant string `gork:"X-Tenant" validate:"required"`
+		}
+	}) (*loadShedResponse, error) {
+		return nil, nil
+	})
+	req = httptest.NewRequest(http.MethodGet, "/api/validate", nil)
+	req.Header.Set(RequestIDHeader, "ghi")
+	w = httptest.NewRecorder()
+	handlers["GET /validate"](w, req)
+	resp = ErrorResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || w.Code != http.StatusBadRequest || resp.Details["request_id"] != "ghi" || resp.Details["headers.X-Tenant"] == nil {
+		t.Errorf("expected request_id next to the field errors, got %d %s", w.Code, w.Body)
+	}
+
+	// The problem marker is found through the timeout buffer.
+	req = httptest.NewRequest(http.MethodGet, "/api/problem", nil)
+	req.Header.Set(RequestIDHeader, "def")
+	w = httptest.NewRecorder()
+	handlers["GET /problem"](w, req)
+	var problem Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil || w.Header().Get("Content-Type") != "application/problem+json" || problem.Details["request_id"] != "def" {
+		t.Errorf("expected problem with request_id, got %s %s", w.Header().Get("Content-Type"), w.Body)
+	}
+}
+
+func TestRequestIDOpenAPI(t *testing.T) {
+	router, registry, _ := newLoadShedRouter(WithRequestID())
+	router.Get("/items", func(context.Context, struct{}) (*loadShedResponse, error) { return nil, nil })
+
+	op := GenerateOpenAPI(registry).Paths["/api/items"].Get
+	var param bool
+	for _, p := range op.Parameters {
+		param = param || (p.Name == RequestIDHeader && p.In == "header" && !p.Required)
+	}
+	if !param {
+		t.Errorf("expected optional request ID header parameter, got %+v", op.Parameters)
+	}
+	if op.Responses["200"].Headers[RequestIDHeader] == nil {
+		t.Errorf("expected documented response header, got %v", op.Responses["200"].Headers)
+	}
+}
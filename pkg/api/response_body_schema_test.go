@@ -2,6 +2,7 @@ package api
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/gork-labs/gork/pkg/unions"
@@ -184,3 +185,76 @@ func TestConventionOpenAPIGenerator_ExtractBodyPropertiesToResponseSchema(t *tes
 		}
 	})
 }
+
+func TestGenerateUnionSchemaBeyondFourMembers(t *testing.T) {
+	type KindA struct {
+		Type string `gork:"type,discriminator=a"`
+	}
+	type KindB struct {
+		Type string `gork:"type,discriminator=b"`
+	}
+	type KindC struct {
+		Type string `gork:"type,discriminator=c"`
+	}
+	type KindD struct {
+		Type string `gork:"type,discriminator=d"`
+	}
+	type KindE struct {
+		Type string `gork:"type,discriminator=e"`
+	}
+	type KindF struct {
+		Type string `gork:"type,discriminator=f"`
+	}
+
+	generator := &ConventionOpenAPIGenerator{}
+	components := &Components{Schemas: map[string]*Schema{}}
+	unionType := reflect.TypeOf(unions.Union6[KindA, KindB, KindC, KindD, KindE, KindF]{})
+	if !isUnionType(unionType) {
+		t.Fatal("expected Union6 to be recognized as a union")
+	}
+
+	schema := generator.generateUnionSchema(unionType, components)
+	if len(schema.OneOf) != 6 {
+		t.Fatalf("expected 6 oneOf members, got %d", len(schema.OneOf))
+	}
+	if schema.Discriminator == nil || schema.Discriminator.PropertyName != "type" || len(schema.Discriminator.Mapping) != 6 {
+		t.Errorf("expected a discriminator mapping all members, got %+v", schema.Discriminator)
+	}
+	if ref := schema.Discriminator.Mapping["f"]; ref != "#/components/schemas/KindF" {
+		t.Errorf("expected the last member to be mapped, got %q", ref)
+	}
+}
+
+func TestOmittableFieldsAreNotRequired(t *testing.T) {
+	type Profile struct {
+		ID       string `gork:"id" validate:"required"`
+		Nickname string `gork:"nickname,omitempty" validate:"required"`
+		Bio      string `gork:"bio,omitzero" validate:"required"`
+	}
+	type ProfileBody struct {
+		ID      string  `gork:"id" validate:"required"`
+		Email   string  `gork:"email,omitempty" validate:"required,email"`
+		Profile Profile `gork:"profile" validate:"required"`
+	}
+
+	generator := &ConventionOpenAPIGenerator{}
+	components := &Components{Schemas: map[string]*Schema{}}
+	responseSchema := &Schema{Type: "object", Properties: map[string]*Schema{}, Required: []string{}}
+	generator.extractBodyPropertiesToResponseSchema(reflect.TypeOf(ProfileBody{}), responseSchema, components)
+	if !reflect.DeepEqual(responseSchema.Required, []string{"id", "profile"}) {
+		t.Errorf("expected omitempty fields not to be required, got %v", responseSchema.Required)
+	}
+	if responseSchema.Properties["email"] == nil {
+		t.Error("expected omitempty fields to stay documented")
+	}
+
+	profile := components.Schemas["Profile"]
+	if profile == nil || !reflect.DeepEqual(profile.Required, []string{"id"}) {
+		t.Errorf("expected only id to be required in nested structs, got %+v", profile)
+	}
+
+	ts := (&tsGenerator{names: map[reflect.Type]string{}, taken: map[string]bool{}}).object(reflect.TypeOf(Profile{}), false)
+	if !strings.Contains(ts, "  id: string;") || !strings.Contains(ts, "  nickname?: string;") {
+		t.Errorf("expected omitempty fields to be optional in TypeScript, got:\n%s", ts)
+	}
+}
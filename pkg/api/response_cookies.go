@@ -0,0 +1,103 @@
+package api
+
+import (
+	"net/http"
+	"reflect"
+)
+
+// Cookie is a response cookie set with its attributes. Fields of a response
+// Cookies section only set the cookie's value, unless they are a Cookie or a
+// *Cookie:
+//
+//	type LoginResponse struct {
+//		Cookies struct {
+//			Session api.Cookie `gork:"session"`
+//		}
+//	}
+//
+//	resp.Cookies.Session = api.Cookie{Value: token, Path: "/", MaxAge: 3600, Secure: true, HttpOnly: true, SameSite: http.SameSiteLaxMode}
+//
+// The cookie is named after the field's gork tag. Zero Cookies and nil
+// pointers set no cookie; set MaxAge to a negative value to delete one.
+type Cookie struct {
+	Value  string
+	Path   string
+	Domain string
+	// MaxAge is the lifetime of the cookie in seconds. Zero leaves it a
+	// session cookie, a negative value deletes it.
+	MaxAge   int
+	Secure   bool
+	HttpOnly bool
+	SameSite http.SameSite
+}
+
+var cookieType = reflect.TypeOf(Cookie{})
+
+// isCookieType reports whether fields of type t set cookies with their
+// attributes.
+func isCookieType(t reflect.Type) bool {
+	return t == cookieType || (t.Kind() == reflect.Ptr && t.Elem() == cookieType)
+}
+
+// responseCookie returns the cookie name set by v, a Cookie or a *Cookie,
+// or nil when v sets none.
+func responseCookie(name string, v reflect.Value) *http.Cookie {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	c := v.Interface().(Cookie)
+	if c == (Cookie{}) {
+		return nil
+	}
+	return &http.Cookie{
+		Name:     name,
+		Value:    c.Value,
+		Path:     c.Path,
+		Domain:   c.Domain,
+		MaxAge:   c.MaxAge,
+		Secure:   c.Secure,
+		HttpOnly: c.HttpOnly,
+		SameSite: c.SameSite,
+	}
+}
+
+// setCookieDoc documents a cookie set by an operation in x-set-cookies.
+type setCookieDoc struct {
+	Schema *Schema `json:"schema"`
+	// Attributes marks cookies set with their attributes (Path, Domain,
+	// Max-Age, Secure, HttpOnly, SameSite) by an api.Cookie field.
+	Attributes bool `json:"attributes,omitempty"`
+}
+
+// processResponseCookies documents the cookies of a response Cookies
+// section in the x-set-cookies extension of the operation: OpenAPI has no
+// way to describe the cookies of a Set-Cookie header.
+func (g *ConventionOpenAPIGenerator) processResponseCookies(cookiesType reflect.Type, operation *Operation, components *Components) {
+	if cookiesType.Kind() != reflect.Struct {
+		return
+	}
+	cookies := map[string]setCookieDoc{}
+	for i := 0; i < cookiesType.NumField(); i++ {
+		field := cookiesType.Field(i)
+		gorkTag := field.Tag.Get("gork")
+		if gorkTag == "" {
+			continue
+		}
+		name := parseGorkTag(gorkTag).Name
+		if isCookieType(field.Type) {
+			cookies[name] = setCookieDoc{Schema: &Schema{Type: "string"}, Attributes: true}
+			continue
+		}
+		cookies[name] = setCookieDoc{Schema: g.generateSchemaFromType(field.Type, "", components)}
+	}
+	if len(cookies) == 0 {
+		return
+	}
+	if operation.Extensions == nil {
+		operation.Extensions = map[string]interface{}{}
+	}
+	operation.Extensions["x-set-cookies"] = cookies
+}
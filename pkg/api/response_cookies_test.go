@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type loginResponse struct {
+	Cookies struct {
+		Session  Cookie  `gork:"session"`
+		Previous *Cookie `gork:"previous"`
+		Remember *Cookie `gork:"remember"`
+		Theme    string  `gork:"theme"`
+	}
+}
+
+func TestResponseCookies(t *testing.T) {
+	router, registry, handlers := newLoadShedRouter()
+	router.Post("/login", func(context.Context, struct{}) (*loginResponse, error) {
+		resp := &loginResponse{}
+		resp.Cookies.Session = Cookie{Value: "abc", Path: "/", MaxAge: 3600, Secure: true, HttpOnly: true, SameSite: http.SameSiteLaxMode}
+		resp.Cookies.Previous = &Cookie{MaxAge: -1}
+		resp.Cookies.Theme = "dark"
+		return resp, nil
+	})
+
+	w := httptest.NewRecorder()
+	handlers["POST /login"](w, httptest.NewRequest(http.MethodPost, "/api/login", nil))
+	cookies := map[string]*http.Cookie{}
+	for _, c := range w.Result().Cookies() {
+		cookies[c.Name] = c
+	}
+	if len(cookies) != 3 {
+		t.Fatalf("cookies = %v, want session, previous and theme", w.Header().Values("Set-Cookie"))
+	}
+	if c := cookies["session"]; c.Value != "abc" || c.Path != "/" || c.MaxAge != 3600 || !c.Secure || !c.HttpOnly || c.SameSite != http.SameSiteLaxMode {
+		t.Errorf("session cookie = %+v", c)
+	}
+	if c := cookies["previous"]; c.MaxAge >= 0 {
+		t.Errorf("previous cookie = %+v, want it deleted", c)
+	}
+	if c := cookies["theme"]; c.Value != "dark" {
+		t.Errorf("theme cookie = %+v", c)
+	}
+
+	op := GenerateOpenAPI(registry).Paths["/api/login"].Post
+	data, err := json.Marshal(op)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		SetCookies map[string]struct {
+			Schema     Schema `json:"schema"`
+			Attributes bool   `json:"attributes"`
+		} `json:"x-set-cookies"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.SetCookies) != 4 {
+		t.Fatalf("x-set-cookies = %+v", doc.SetCookies)
+	}
+	if c := doc.SetCookies["session"]; !c.Attributes || c.Schema.Type != "string" {
+		t.Errorf("session = %+v, want a string cookie with attributes", c)
+	}
+	if c := doc.SetCookies["theme"]; c.Attributes || c.Schema.Type != "string" {
+		t.Errorf("theme = %+v, want a string value", c)
+	}
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SectionStatusCode is the optional response section that selects the HTTP
+// status code. Its status tag lists the codes the handler may return; the
+// first one is used when the field is left at zero. The tag must declare at
+// least one code, and a handler setting a code it does not declare gets a 500
+// instead, so the OpenAPI spec documents every status the route returns:
+//
+//	type CreateUserResponse struct {
+//		StatusCode int `status:"201,200"`
+//		Body       User
+//	}
+//
+// Without a StatusCode section responses use 200 (or 204 without a Body).
+const SectionStatusCode = "StatusCode"
+
+// responseStatusCodes returns the status codes declared by the StatusCode
+// section of respType, if any.
+func responseStatusCodes(respType reflect.Type) ([]int, error) {
+	for respType != nil && respType.Kind() == reflect.Ptr {
+		respType = respType.Elem()
+	}
+	if respType == nil || respType.Kind() != reflect.Struct {
+		return nil, nil
+	}
+	field, ok := respType.FieldByName(SectionStatusCode)
+	if !ok {
+		return nil, nil
+	}
+	if field.Type.Kind() != reflect.Int {
+		return nil, fmt.Errorf("%s section of %s must be an int", SectionStatusCode, respType)
+	}
+
+	var codes []int
+	for _, part := range strings.Split(field.Tag.Get("status"), ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		code, err := strconv.Atoi(part)
+		if err != nil || code < 200 || code > 599 {
+			return nil, fmt.Errorf("%s section of %s declares invalid status %q", SectionStatusCode, respType, part)
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
+
+// validateResponseStatus panics when the StatusCode section of a response
+// type is malformed or declares no codes, so that mistakes surface at
+// registration.
+func validateResponseStatus(respType reflect.Type) {
+	codes, err := responseStatusCodes(respType)
+	if err != nil {
+		panic(err.Error())
+	}
+	if codes == nil && hasStatusSection(respType) {
+		panic(fmt.Sprintf("%s section of %s must list the codes it returns in its status tag", SectionStatusCode, respType))
+	}
+}
+
+// hasStatusSection reports whether respType has a StatusCode section.
+func hasStatusSection(respType reflect.Type) bool {
+	for respType != nil && respType.Kind() == reflect.Ptr {
+		respType = respType.Elem()
+	}
+	if respType == nil || respType.Kind() != reflect.Struct {
+		return false
+	}
+	_, ok := respType.FieldByName(SectionStatusCode)
+	return ok
+}
+
+// undeclaredStatus returns the status code set on a response value when its
+// StatusCode section does not declare it, or zero.
+func undeclaredStatus(respStruct reflect.Value) int {
+	if respStruct.Kind() != reflect.Struct {
+		return 0
+	}
+	field := respStruct.FieldByName(SectionStatusCode)
+	if !field.IsValid() || field.Kind() != reflect.Int || field.Int() == 0 {
+		return 0
+	}
+	codes, _ := responseStatusCodes(respStruct.Type())
+	if code := int(field.Int()); !slices.Contains(codes, code) {
+		return code
+	}
+	return 0
+}
+
+// responseStatus returns the status code selected by a response value, or
+// zero to use the default.
+func responseStatus(respStruct reflect.Value) int {
+	if respStruct.Kind() != reflect.Struct {
+		return 0
+	}
+	field := respStruct.FieldByName(SectionStatusCode)
+	if !field.IsValid() || field.Kind() != reflect.Int {
+		return 0
+	}
+	if code := int(field.Int()); code != 0 {
+		return code
+	}
+	if codes, _ := responseStatusCodes(respStruct.Type()); len(codes) > 0 {
+		return codes[0]
+	}
+	return 0
+}
+
+// successResponses documents response under every declared status code, or
+// under fallback when the response type declares none.
+func successResponses(operation *Operation, codes []int, fallback string, response *Response) {
+	if len(codes) == 0 {
+		operation.Responses[fallback] = response
+		return
+	}
+	for _, code := range codes {
+		documented := *response
+		if text := http.StatusText(code); text != "" {
+			documented.Description = text
+		}
+		operation.Responses[strconv.Itoa(code)] = &documented
+	}
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type createOrderStatusResponse struct {
+	StatusCode int `status:"201,200"`
+	Headers    struct {
+		Location string `gork:"Location"`
+	}
+	Body struct {
+		ID string `gork:"id"`
+	}
+}
+
+type acceptJobResponse struct {
+	StatusCode int `status:"202"`
+}
+
+func TestResponseStatusCode(t *testing.T) {
+	existing := false
+	create := func(context.Context, loadShedRequest) (*createOrderStatusResponse, error) {
+		resp := &createOrderStatusResponse{}
+		resp.Body.ID = "o-1"
+		resp.Headers.Location = "/orders/o-1"
+		if existing {
+			resp.StatusCode = http.StatusOK
+		}
+		return resp, nil
+	}
+	accept := func(context.Context, loadShedRequest) (*acceptJobResponse, error) {
+		return &acceptJobResponse{}, nil
+	}
+
+	factory := NewConventionHandlerFactory()
+	createHandler, _ := factory.CreateHandler(&mockConventionParameterAdapter{}, create)
+	acceptHandler, _ := factory.CreateHandler(&mockConventionParameterAdapter{}, accept)
+
+	w := httptest.NewRecorder()
+	createHandler(w, httptest.NewRequest(http.MethodGet, "/orders", nil))
+	if w.Code != http.StatusCreated || w.Header().Get("Location") != "/orders/o-1" || !strings.Contains(w.Body.String(), "o-1") {
+		t.Errorf("expected 201 with body and headers, got %d %v %s", w.Code, w.Header(), w.Body.String())
+	}
+
+	existing = true
+	w = httptest.NewRecorder()
+	createHandler(w, httptest.NewRequest(http.MethodGet, "/orders", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected explicit 200, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	acceptHandler(w, httptest.NewRequest(http.MethodGet, "/jobs", nil))
+	if w.Code != http.StatusAccepted || w.Body.Len() != 0 {
+		t.Errorf("expected bodiless 202, got %d %q", w.Code, w.Body.String())
+	}
+
+	undeclared := func(context.Context, loadShedRequest) (*acceptJobResponse, error) {
+		return &acceptJobResponse{StatusCode: http.StatusConflict}, nil
+	}
+	undeclaredHandler, _ := factory.CreateHandler(&mockConventionParameterAdapter{}, undeclared)
+	w = httptest.NewRecorder()
+	undeclaredHandler(w, httptest.NewRequest(http.MethodGet, "/jobs", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500 for undeclared status, got %d", w.Code)
+	}
+}
+
+func TestResponseStatusCodeOpenAPI(t *testing.T) {
+	router, registry, _ := newLoadShedRouter()
+	router.Post("/orders", func(context.Context, loadShedRequest) (*createOrderStatusResponse, error) { return nil, nil })
+	router.Post("/jobs", func(context.Context, loadShedRequest) (*acceptJobResponse, error) { return nil, nil })
+
+	spec := GenerateOpenAPI(registry)
+	orders := spec.Paths["/api/orders"].Post.Responses
+	for _, code := range []string{"201", "200"} {
+		if orders[code] == nil || orders[code].Content["application/json"] == nil || orders[code].Headers["Location"] == nil {
+			t.Errorf("expected %s response with body and headers, got %+v", code, orders[code])
+		}
+	}
+	if orders["201"].Description != "Created" {
+		t.Errorf("unexpected description %q", orders["201"].Description)
+	}
+	jobs := spec.Paths["/api/jobs"].Post.Responses
+	if jobs["202"] == nil || jobs["202"].Content != nil || jobs["204"] != nil || jobs["200"] != nil {
+		t.Errorf("expected only a bodiless 202, got %+v", jobs)
+	}
+}
+
+func TestResponseStatusCodeInvalid(t *testing.T) {
+	type badStatus struct {
+		StatusCode int `status:"created"`
+	}
+	type badKind struct {
+		StatusCode string
+	}
+	type undeclared struct {
+		StatusCode int
+	}
+	for name, fn := range map[string]interface{}{
+		"tag":        func(context.Context, loadShedRequest) (*badStatus, error) { return nil, nil },
+		"kind":       func(context.Context, loadShedRequest) (*badKind, error) { return nil, nil },
+		"undeclared": func(context.Context, loadShedRequest) (*undeclared, error) { return nil, nil },
+	} {
+		t.Run(name, func(t *testing.T) {
+			router, _, _ := newLoadShedRouter()
+			defer func() {
+				if recover() == nil {
+					t.Error("expected registration panic")
+				}
+			}()
+			router.Get("/bad", fn)
+		})
+	}
+}
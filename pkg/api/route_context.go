@@ -0,0 +1,25 @@
+package api
+
+import (
+	"context"
+	"net/http"
+)
+
+type routeContextKey struct{}
+
+// RouteFromContext returns the route serving the request, or nil outside a
+// registered route. Logging and metrics code uses it to label requests by
+// path template (info.Path), handler name and tags (info.Options.Tags)
+// instead of the raw URL. The returned RouteInfo is shared and must not be
+// modified.
+func RouteFromContext(ctx context.Context) *RouteInfo {
+	info, _ := ctx.Value(routeContextKey{}).(*RouteInfo)
+	return info
+}
+
+// withRoute makes info available to next through RouteFromContext.
+func withRoute(info *RouteInfo, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		next(w, r.WithContext(context.WithValue(r.Context(), routeContextKey{}, info)))
+	}
+}
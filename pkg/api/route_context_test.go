@@ -0,0 +1,38 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRouteFromContext(t *testing.T) {
+	var fromMiddleware, fromHandler *RouteInfo
+	middleware := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fromMiddleware = RouteFromContext(r.Context())
+			next.ServeHTTP(w, r)
+		})
+	}
+	router, _, handlers := newLoadShedRouter(WithMiddleware(middleware))
+	router.Get("/users/{id}", func(ctx context.Context, _ struct{}) (*struct{}, error) {
+		fromHandler = RouteFromContext(ctx)
+		return nil, nil
+	}, WithTags("users"))
+
+	w := httptest.NewRecorder()
+	handlers["GET /users/{id}"](w, httptest.NewRequest(http.MethodGet, "/api/users/42", nil))
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d", w.Code)
+	}
+	if fromMiddleware == nil || fromMiddleware != fromHandler {
+		t.Fatalf("middleware and handler must see the same route, got %v and %v", fromMiddleware, fromHandler)
+	}
+	if fromHandler.Path != "/api/users/{id}" || fromHandler.Method != http.MethodGet || fromHandler.Options.Tags[0] != "users" {
+		t.Errorf("unexpected route %+v", fromHandler)
+	}
+	if RouteFromContext(context.Background()) != nil {
+		t.Error("expected no route outside a request")
+	}
+}
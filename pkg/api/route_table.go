@@ -0,0 +1,223 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"reflect"
+	"sort"
+)
+
+// RouteTableEntry is the serialisable description of a single registered
+// route as logged at startup and persisted between deployments.
+type RouteTableEntry struct {
+	Method   string `json:"method"`
+	Path     string `json:"path"`
+	Handler  string `json:"handler"`
+	Request  string `json:"request,omitempty"`
+	Response string `json:"response,omitempty"`
+}
+
+// key identifies the route independently of its handler and types.
+func (e RouteTableEntry) key() string {
+	return e.Method + " " + e.Path
+}
+
+// RouteTableDiff lists the differences between two route tables.
+type RouteTableDiff struct {
+	Added   []RouteTableEntry // Routes present only in the current table
+	Removed []RouteTableEntry // Routes present only in the previous table
+	Changed []RouteTableEntry // Routes whose handler or types changed (current values)
+}
+
+// Empty reports whether the tables were identical.
+func (d RouteTableDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// RouteTableLogConfig configures LogRouteTable.
+type RouteTableLogConfig struct {
+	// Logger receives the structured records. Defaults to slog.Default().
+	Logger *slog.Logger
+	// PreviousFile is a JSON route table written by a previous deployment
+	// (see SaveFile). A missing file, e.g. on the first deployment, is
+	// silently ignored.
+	PreviousFile string
+	// PreviousEnv names an environment variable holding the previous route
+	// table as JSON. It takes precedence over PreviousFile when set.
+	PreviousEnv string
+	// SaveFile, when set, receives the current route table as JSON so that
+	// the next deployment can diff against it.
+	SaveFile string
+}
+
+// RouteTable returns the registered routes as table entries sorted by path
+// and method.
+func (r *RouteRegistry) RouteTable() []RouteTableEntry {
+	routes := r.GetRoutes()
+	table := make([]RouteTableEntry, 0, len(routes))
+	for _, route := range routes {
+		table = append(table, RouteTableEntry{
+			Method:   route.Method,
+			Path:     route.Path,
+			Handler:  route.HandlerName,
+			Request:  typeName(route.RequestType),
+			Response: typeName(route.ResponseType),
+		})
+	}
+	sort.SliceStable(table, func(i, j int) bool {
+		if table[i].Path != table[j].Path {
+			return table[i].Path < table[j].Path
+		}
+		return table[i].Method < table[j].Method
+	})
+	return table
+}
+
+// typeName returns the qualified name of t (pointers dereferenced) or an
+// empty string for nil.
+func typeName(t reflect.Type) string {
+	if t == nil {
+		return ""
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.String()
+}
+
+// DiffRouteTables compares the route table of a previous deployment with
+// the current one.
+func DiffRouteTables(previous, current []RouteTableEntry) RouteTableDiff {
+	prev := make(map[string]RouteTableEntry, len(previous))
+	for _, e := range previous {
+		prev[e.key()] = e
+	}
+
+	var diff RouteTableDiff
+	seen := make(map[string]bool, len(current))
+	for _, e := range current {
+		seen[e.key()] = true
+		old, ok := prev[e.key()]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, e)
+		case old != e:
+			diff.Changed = append(diff.Changed, e)
+		}
+	}
+	for _, e := range previous {
+		if !seen[e.key()] {
+			diff.Removed = append(diff.Removed, e)
+		}
+	}
+	return diff
+}
+
+// LogRouteTable logs every registered route and, when a previous table is
+// configured, the routes added, removed or changed since that deployment.
+func LogRouteTable(registry *RouteRegistry, cfg RouteTableLogConfig) error {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	current := registry.RouteTable()
+	for _, e := range current {
+		logger.Info("route", routeAttrs(e)...)
+	}
+
+	previous, hasPrevious, err := loadPreviousRouteTable(cfg)
+	if err != nil {
+		return err
+	}
+	if hasPrevious {
+		logRouteTableDiff(logger, DiffRouteTables(previous, current))
+	}
+
+	if cfg.SaveFile == "" {
+		return nil
+	}
+	return saveRouteTable(cfg.SaveFile, current)
+}
+
+// LogRouteTable logs the router's route table. See the package-level
+// LogRouteTable for details.
+func (r *TypedRouter[T]) LogRouteTable(cfg RouteTableLogConfig) error {
+	return LogRouteTable(r.registry, cfg)
+}
+
+// logRouteTableDiff emits one record per changed route plus a summary.
+func logRouteTableDiff(logger *slog.Logger, diff RouteTableDiff) {
+	for _, e := range diff.Added {
+		logger.Info("route added", routeAttrs(e)...)
+	}
+	for _, e := range diff.Removed {
+		logger.Warn("route removed", routeAttrs(e)...)
+	}
+	for _, e := range diff.Changed {
+		logger.Info("route changed", routeAttrs(e)...)
+	}
+	logger.Info("route table diff",
+		"added", len(diff.Added),
+		"removed", len(diff.Removed),
+		"changed", len(diff.Changed),
+	)
+}
+
+func routeAttrs(e RouteTableEntry) []any {
+	return []any{
+		"method", e.Method,
+		"path", e.Path,
+		"handler", e.Handler,
+		"request", e.Request,
+		"response", e.Response,
+	}
+}
+
+// loadPreviousRouteTable reads the previous table from the configured
+// environment variable or file. It reports false when none is configured.
+func loadPreviousRouteTable(cfg RouteTableLogConfig) ([]RouteTableEntry, bool, error) {
+	if cfg.PreviousEnv != "" {
+		if raw, ok := os.LookupEnv(cfg.PreviousEnv); ok {
+			table, err := parseRouteTable([]byte(raw))
+			if err != nil {
+				return nil, false, fmt.Errorf("route table from $%s: %w", cfg.PreviousEnv, err)
+			}
+			return table, true, nil
+		}
+	}
+	if cfg.PreviousFile == "" {
+		return nil, false, nil
+	}
+	data, err := os.ReadFile(cfg.PreviousFile)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read route table: %w", err)
+	}
+	table, err := parseRouteTable(data)
+	if err != nil {
+		return nil, false, fmt.Errorf("route table %s: %w", cfg.PreviousFile, err)
+	}
+	return table, true, nil
+}
+
+func parseRouteTable(data []byte) ([]RouteTableEntry, error) {
+	var table []RouteTableEntry
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, fmt.Errorf("invalid route table: %w", err)
+	}
+	return table, nil
+}
+
+func saveRouteTable(path string, table []RouteTableEntry) error {
+	// Encoding plain string fields cannot fail.
+	data, _ := json.MarshalIndent(table, "", "  ")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write route table: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type routeTableRequest struct {
+	Query struct {
+		Q string `gork:"q"`
+	}
+}
+
+type routeTableResponse struct {
+	Body struct {
+		OK bool `gork:"ok"`
+	}
+}
+
+func routeTableGet(_ context.Context, _ routeTableRequest) (*routeTableResponse, error) {
+	return &routeTableResponse{}, nil
+}
+
+func routeTableDelete(_ context.Context, _ routeTableRequest) error {
+	return nil
+}
+
+func newRouteTableRouter() *TypedRouter[*struct{}] {
+	router := NewTypedRouter[*struct{}](nil, NewRouteRegistry(), "", nil, &mockTypedRouterAdapter{}, nil)
+	router.Get("/users", routeTableGet)
+	router.Delete("/users", routeTableDelete)
+	router.Get("/items", routeTableGet)
+	return &router
+}
+
+func newRouteTableLogger() (*slog.Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	return slog.New(slog.NewJSONHandler(&buf, nil)), &buf
+}
+
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		records = append(records, rec)
+	}
+	return records
+}
+
+func TestRouteRegistryRouteTable(t *testing.T) {
+	table := newRouteTableRouter().GetRegistry().RouteTable()
+
+	want := []RouteTableEntry{
+		{Method: "GET", Path: "/items", Handler: "routeTableGet", Request: "api.routeTableRequest", Response: "api.routeTableResponse"},
+		{Method: "DELETE", Path: "/users", Handler: "routeTableDelete", Request: "api.routeTableRequest"},
+		{Method: "GET", Path: "/users", Handler: "routeTableGet", Request: "api.routeTableRequest", Response: "api.routeTableResponse"},
+	}
+	if len(table) != len(want) {
+		t.Fatalf("expected %d entries, got %d: %+v", len(want), len(table), table)
+	}
+	for i := range want {
+		if table[i] != want[i] {
+			t.Errorf("entry %d: expected %+v, got %+v", i, want[i], table[i])
+		}
+	}
+}
+
+func TestDiffRouteTables(t *testing.T) {
+	previous := []RouteTableEntry{
+		{Method: "GET", Path: "/a", Handler: "A"},
+		{Method: "GET", Path: "/b", Handler: "B"},
+		{Method: "GET", Path: "/c", Handler: "C", Request: "v1"},
+	}
+	current := []RouteTableEntry{
+		{Method: "GET", Path: "/a", Handler: "A"},
+		{Method: "GET", Path: "/c", Handler: "C", Request: "v2"},
+		{Method: "POST", Path: "/d", Handler: "D"},
+	}
+
+	diff := DiffRouteTables(previous, current)
+	if diff.Empty() {
+		t.Fatal("expected non-empty diff")
+	}
+	if len(diff.Added) != 1 || diff.Added[0].Path != "/d" {
+		t.Errorf("unexpected added routes %+v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].Path != "/b" {
+		t.Errorf("unexpected removed routes %+v", diff.Removed)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0].Request != "v2" {
+		t.Errorf("unexpected changed routes %+v", diff.Changed)
+	}
+
+	if !DiffRouteTables(current, current).Empty() {
+		t.Error("expected identical tables to produce an empty diff")
+	}
+}
+
+func TestLogRouteTableWithPreviousFile(t *testing.T) {
+	dir := t.TempDir()
+	prevFile := filepath.Join(dir, "routes.json")
+	previous := `[{"method":"GET","path":"/users","handler":"routeTableGet","request":"api.routeTableRequest","response":"api.routeTableResponse"},
+		{"method":"GET","path":"/legacy","handler":"Legacy"}]`
+	if err := os.WriteFile(prevFile, []byte(previous), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	logger, buf := newRouteTableLogger()
+	router := newRouteTableRouter()
+	if err := router.LogRouteTable(RouteTableLogConfig{Logger: logger, PreviousFile: prevFile, SaveFile: prevFile}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	counts := map[string]int{}
+	var summary map[string]any
+	for _, rec := range logRecords(t, buf) {
+		msg, _ := rec["msg"].(string)
+		counts[msg]++
+		if msg == "route table diff" {
+			summary = rec
+		}
+		if msg == "route removed" && (rec["path"] != "/legacy" || rec["level"] != "WARN") {
+			t.Errorf("unexpected removed record %v", rec)
+		}
+	}
+	if counts["route"] != 3 || counts["route added"] != 2 || counts["route removed"] != 1 {
+		t.Errorf("unexpected record counts %v", counts)
+	}
+	if summary == nil || summary["added"] != float64(2) || summary["removed"] != float64(1) || summary["changed"] != float64(0) {
+		t.Errorf("unexpected summary %v", summary)
+	}
+
+	// The saved table becomes the baseline for the next deployment.
+	data, err := os.ReadFile(prevFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved, err := parseRouteTable(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !DiffRouteTables(saved, router.GetRegistry().RouteTable()).Empty() {
+		t.Error("expected saved table to match current routes")
+	}
+}
+
+func TestLogRouteTableWithPreviousEnv(t *testing.T) {
+	t.Setenv("GORK_TEST_ROUTES", `[{"method":"GET","path":"/users","handler":"Old"}]`)
+
+	logger, buf := newRouteTableLogger()
+	err := LogRouteTable(newRouteTableRouter().GetRegistry(), RouteTableLogConfig{
+		Logger:       logger,
+		PreviousEnv:  "GORK_TEST_ROUTES",
+		PreviousFile: filepath.Join(t.TempDir(), "ignored.json"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	changed := 0
+	for _, rec := range logRecords(t, buf) {
+		if rec["msg"] == "route changed" {
+			changed++
+		}
+	}
+	if changed != 1 {
+		t.Errorf("expected 1 changed route, got %d", changed)
+	}
+}
+
+func TestLogRouteTableWithoutPrevious(t *testing.T) {
+	logger, buf := newRouteTableLogger()
+	registry := newRouteTableRouter().GetRegistry()
+
+	// Unset env var and missing file both mean "no previous deployment".
+	err := LogRouteTable(registry, RouteTableLogConfig{
+		Logger:       logger,
+		PreviousEnv:  "GORK_TEST_ROUTES_UNSET",
+		PreviousFile: filepath.Join(t.TempDir(), "missing.json"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, rec := range logRecords(t, buf) {
+		if rec["msg"] != "route" {
+			t.Errorf("unexpected record %v", rec)
+		}
+	}
+
+	// The default logger is used when none is configured.
+	var defaultBuf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&defaultBuf, nil)))
+	defer slog.SetDefault(prev)
+	if err := LogRouteTable(registry, RouteTableLogConfig{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(logRecords(t, &defaultBuf)) != 3 {
+		t.Errorf("expected 3 records on default logger, got %q", defaultBuf.String())
+	}
+}
+
+func TestLogRouteTableErrors(t *testing.T) {
+	registry := newRouteTableRouter().GetRegistry()
+	logger, _ := newRouteTableLogger()
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GORK_TEST_ROUTES_INVALID", "not json")
+
+	tests := []struct {
+		name string
+		cfg  RouteTableLogConfig
+		want string
+	}{
+		{"invalid env", RouteTableLogConfig{PreviousEnv: "GORK_TEST_ROUTES_INVALID"}, "route table from $GORK_TEST_ROUTES_INVALID"},
+		{"invalid file", RouteTableLogConfig{PreviousFile: invalid}, "invalid route table"},
+		{"unreadable file", RouteTableLogConfig{PreviousFile: dir}, "failed to read route table"},
+		{"unwritable save file", RouteTableLogConfig{SaveFile: filepath.Join(dir, "missing", "routes.json")}, "failed to write route table"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.Logger = logger
+			err := LogRouteTable(registry, tt.cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
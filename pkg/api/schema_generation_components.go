@@ -28,6 +28,21 @@ func (p *PointerTypeHandler) GenerateSchema(t reflect.Type, registry map[string]
 	return generator.GenerateSchema(t.Elem(), registry, true)
 }
 
+// NullableTypeHandler handles structs encoded as null when unset:
+// unions.Optional and the Null types of database/sql, such as sql.NullString.
+type NullableTypeHandler struct{}
+
+// CanHandle returns true if this handler can process the given type.
+func (n *NullableTypeHandler) CanHandle(t reflect.Type) bool {
+	return isNullableStruct(t)
+}
+
+// GenerateSchema generates a nullable schema for the value.
+func (n *NullableTypeHandler) GenerateSchema(t reflect.Type, registry map[string]*Schema, _ bool) *Schema {
+	value, _ := nullableStructValue(t)
+	return optionalSchema(NewSchemaGenerator().GenerateSchema(value, registry, true))
+}
+
 // UnionTypeHandler handles union types.
 type UnionTypeHandler struct{}
 
@@ -85,6 +100,19 @@ func (a *ArrayTypeHandler) GenerateSchema(t reflect.Type, registry map[string]*S
 	return buildArraySchema(t, registry)
 }
 
+// MapTypeHandler handles map types.
+type MapTypeHandler struct{}
+
+// CanHandle returns true if this handler can process the given type.
+func (m *MapTypeHandler) CanHandle(t reflect.Type) bool {
+	return t.Kind() == reflect.Map
+}
+
+// GenerateSchema generates a schema for map types.
+func (m *MapTypeHandler) GenerateSchema(t reflect.Type, registry map[string]*Schema, _ bool) *Schema {
+	return buildMapSchema(t, registry)
+}
+
 // BasicTypeHandler handles basic types (string, int, etc.).
 type BasicTypeHandler struct{}
 
@@ -109,9 +137,16 @@ func NewSchemaGenerator() *SchemaGenerator {
 	return &SchemaGenerator{
 		handlers: []TypeSchemaHandler{
 			&PointerTypeHandler{},
+			&SchemerTypeHandler{},
+			&EnumTypeHandler{},
+			&BinaryTypeHandler{},
+			&TextTypeHandler{},
+			&FreeFormTypeHandler{},
+			&NullableTypeHandler{},
 			&UnionTypeHandler{},
 			&StructTypeHandler{},
 			&ArrayTypeHandler{},
+			&MapTypeHandler{},
 			&BasicTypeHandler{}, // Must be last as it accepts everything
 		},
 	}
@@ -1,8 +1,16 @@
 package api
 
 import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"slices"
+	"strings"
 	"testing"
+
+	"github.com/gork-labs/gork/pkg/unions"
 )
 
 // Tests for individual schema handlers
@@ -435,3 +443,53 @@ func TestSchemaGeneratorWithMocks(t *testing.T) {
 		}
 	})
 }
+
+type nullableRecord struct {
+	Nickname *string                 `gork:"nickname,default=anon"`
+	Note     sql.NullString          `gork:"note"`
+	Seen     sql.NullTime            `gork:"seen"`
+	Score    sql.Null[float64]       `gork:"score"`
+	Alias    unions.Optional[string] `gork:"alias"`
+}
+
+type nullableRequest struct {
+	Body nullableRecord
+}
+
+func TestNullableFields(t *testing.T) {
+	var got nullableRecord
+	router, registry, handlers := newLoadShedRouter()
+	router.Post("/records", func(_ context.Context, req nullableRequest) (*struct{}, error) {
+		got = req.Body
+		return nil, nil
+	})
+
+	spec := GenerateOpenAPI(registry)
+	var record *Schema
+	for _, schema := range spec.Components.Schemas {
+		if schema.Properties["note"] != nil {
+			record = schema
+		}
+	}
+	if record == nil {
+		t.Fatalf("missing the body component: %v", spec.Components.Schemas)
+	}
+	for name, want := range map[string]string{"nickname": "string", "note": "string", "seen": "string", "score": "number", "alias": "string"} {
+		prop := record.Properties[name]
+		if prop == nil || !slices.Equal(prop.Types, []string{want, "null"}) {
+			t.Errorf("%s: expected type [%s, null], got %+v", name, want, prop)
+		}
+	}
+	if record.Properties["seen"].Format != "date-time" {
+		t.Errorf("expected sql.NullTime to keep the date-time format, got %+v", record.Properties["seen"])
+	}
+
+	w := httptest.NewRecorder()
+	handlers["POST /records"](w, httptest.NewRequest(http.MethodPost, "/records", strings.NewReader(`{"nickname":null,"note":"n","seen":null,"score":2.5,"alias":null}`)))
+	if w.Code >= http.StatusBadRequest {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	if got.Nickname != nil || got.Note != (sql.NullString{String: "n", Valid: true}) || got.Seen.Valid || got.Score.V != 2.5 || !got.Alias.Null {
+		t.Errorf("unexpected decoded record %+v", got)
+	}
+}
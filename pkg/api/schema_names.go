@@ -0,0 +1,55 @@
+package api
+
+import (
+	"reflect"
+	"sync"
+)
+
+// schemaNames holds component names registered via NameSchema.
+var schemaNames = struct {
+	sync.RWMutex
+	byType map[reflect.Type]string
+}{byType: map[reflect.Type]string{}}
+
+// NameSchema sets the component name used for t in generated specs,
+// replacing the derived name. It is mainly meant for unions, whose derived
+// names ("BankOrCredit", "Union3Options") are abbreviations:
+//
+//	api.NameSchema(reflect.TypeOf(unions.Union2[Bank, Card]{}), "PaymentMethod")
+//
+// The name is used wherever the type appears: as a request body, a response
+// body or a nested field. Call it before the spec is generated.
+func NameSchema(t reflect.Type, name string) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || sanitizeSchemaName(name) != name || name == "" {
+		panic("api.NameSchema: invalid type or component name " + name)
+	}
+	schemaNames.Lock()
+	defer schemaNames.Unlock()
+	schemaNames.byType[t] = name
+}
+
+// schemaNameOverride returns the component name registered for t.
+func schemaNameOverride(t reflect.Type) (string, bool) {
+	if t == nil {
+		return "", false
+	}
+	schemaNames.RLock()
+	defer schemaNames.RUnlock()
+	name, ok := schemaNames.byType[t]
+	return name, ok
+}
+
+// namedUnionSchema registers the union t under its NameSchema name and
+// returns a reference to it.
+func (g *ConventionOpenAPIGenerator) namedUnionSchema(t reflect.Type, name string, components *Components) *Schema {
+	if _, exists := components.Schemas[name]; !exists {
+		schema := g.generateUnionSchema(t, components)
+		schema.Title = name
+		components.Schemas[name] = schema
+		g.explain.component(name, "set with api.NameSchema for %s", t)
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
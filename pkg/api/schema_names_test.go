@@ -0,0 +1,110 @@
+package api
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/gork-labs/gork/pkg/unions"
+)
+
+type NamedBankMethod struct {
+	Type    string `gork:"type,discriminator=bank"`
+	Account string `gork:"account"`
+}
+
+type NamedCardMethod struct {
+	Type   string `gork:"type,discriminator=card"`
+	Number string `gork:"number"`
+}
+
+type namedPaymentUnion = unions.Union2[NamedBankMethod, NamedCardMethod]
+
+type NamedPayRequest struct {
+	Body namedPaymentUnion
+}
+
+type NamedPayResponse struct {
+	Body namedPaymentUnion
+}
+
+type NamedWalletResponse struct {
+	Body struct {
+		Default namedPaymentUnion `gork:"default"`
+	}
+}
+
+type UnnamedPayRequest struct {
+	Body unions.Union2[NamedCardMethod, NamedBankMethod]
+}
+
+func nameSchemaForTest(t *testing.T, typ reflect.Type, name string) {
+	t.Helper()
+	NameSchema(typ, name)
+	t.Cleanup(func() {
+		schemaNames.Lock()
+		delete(schemaNames.byType, typ)
+		schemaNames.Unlock()
+	})
+}
+
+func TestNameSchemaUnionAcrossPaths(t *testing.T) {
+	nameSchemaForTest(t, reflect.TypeOf(namedPaymentUnion{}), "PaymentMethod")
+
+	registry := NewRouteRegistry()
+	router := NewTypedRouter[*struct{}](nil, registry, "", nil, &mockTypedRouterAdapter{}, nil)
+	router.Post("/pay", func(context.Context, NamedPayRequest) (*NamedPayResponse, error) { return nil, nil })
+	router.Get("/wallet", func(context.Context, struct{}) (*NamedWalletResponse, error) { return nil, nil })
+
+	spec := GenerateOpenAPI(registry)
+	const ref = "#/components/schemas/PaymentMethod"
+
+	pay := spec.Paths["/pay"].Post
+	if got := pay.RequestBody.Content["application/json"].Schema.Ref; got != ref {
+		t.Errorf("request body: expected %s, got %q", ref, got)
+	}
+	if got := pay.Responses["200"].Content["application/json"].Schema.Ref; got != ref {
+		t.Errorf("response body: expected %s, got %q", ref, got)
+	}
+
+	wallet := spec.Components.Schemas["NamedWalletResponse"]
+	if wallet == nil || wallet.Properties["default"] == nil || wallet.Properties["default"].Ref != ref {
+		t.Errorf("nested field: expected %s, got %+v", ref, wallet)
+	}
+
+	named := spec.Components.Schemas["PaymentMethod"]
+	if named == nil || len(named.OneOf) != 2 || named.Discriminator == nil || named.Title != "PaymentMethod" {
+		t.Fatalf("expected PaymentMethod union component, got %+v", named)
+	}
+	for name := range spec.Components.Schemas {
+		if name == "BankOrCardBody" || name == "NamedPayResponse" {
+			t.Errorf("unexpected derived component %s", name)
+		}
+	}
+}
+
+func TestUnnamedUnionRequestBodyComponent(t *testing.T) {
+	registry := NewRouteRegistry()
+	router := NewTypedRouter[*struct{}](nil, registry, "", nil, &mockTypedRouterAdapter{}, nil)
+	router.Post("/pay", func(context.Context, UnnamedPayRequest) (*NamedPayResponse, error) { return nil, nil })
+
+	spec := GenerateOpenAPI(registry)
+	ref := spec.Paths["/pay"].Post.RequestBody.Content["application/json"].Schema.Ref
+	body := spec.Components.Schemas[ref[len("#/components/schemas/"):]]
+	if body == nil || len(body.OneOf) != 2 || body.Type != "" {
+		t.Errorf("expected union body component with oneOf, got %+v", body)
+	}
+}
+
+func TestNameSchemaRejectsInvalidNames(t *testing.T) {
+	for _, name := range []string{"", "Payment Method", "a/b"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for %q", name)
+				}
+			}()
+			NameSchema(reflect.TypeOf(NamedBankMethod{}), name)
+		}()
+	}
+}
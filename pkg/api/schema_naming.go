@@ -0,0 +1,194 @@
+package api
+
+import (
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SchemaNaming selects how the components generated from Go types are
+// named.
+type SchemaNaming int
+
+const (
+	// SchemaNamingShort names components after their type, e.g. User. When
+	// several types share a name, all of them get the package-prefixed
+	// name instead, e.g. BillingUser and UsersUser. This is the default.
+	SchemaNamingShort SchemaNaming = iota
+	// SchemaNamingPackagePrefixed always prefixes the package name:
+	// UsersUser.
+	SchemaNamingPackagePrefixed
+	// SchemaNamingPackageQualified prefixes the import path of the package:
+	// github.com_acme_app_users.User.
+	SchemaNamingPackageQualified
+	// SchemaNamingHashSuffixed appends a hash of the import path to the
+	// type name: User_5d41402a.
+	SchemaNamingHashSuffixed
+)
+
+// WithSchemaNaming names the components generated from Go types with
+// naming. With every strategy, names depend only on the types in the spec
+// and not on the order routes are registered in: types still sharing a name
+// are numbered in the order of their import paths. Types named with
+// NameSchema keep their name.
+func WithSchemaNaming(naming SchemaNaming) OpenAPIOption {
+	return func(spec *OpenAPISpec) { spec.schemaNaming = naming }
+}
+
+// WithSchemaNameCollisionErrors makes Go types given the same component
+// name an error instead of renaming them. Like a route violating the
+// conventions, a collision panics, or is recorded when collecting errors
+// with WithGenerationErrors.
+func WithSchemaNameCollisionErrors() OpenAPIOption {
+	return func(spec *OpenAPISpec) { spec.schemaCollisionErrors = true }
+}
+
+// schemaNameFor returns the component name of t under naming.
+func schemaNameFor(t reflect.Type, naming SchemaNaming) string {
+	base := sanitizeSchemaName(t.Name())
+	pkg := t.PkgPath()
+	if base == "" || pkg == "" {
+		return base
+	}
+	switch naming {
+	case SchemaNamingPackagePrefixed:
+		return toPascalCase(lastPathComponent(pkg)) + base
+	case SchemaNamingPackageQualified:
+		return sanitizeCharacters(strings.ReplaceAll(pkg, "/", "_")) + "." + base
+	case SchemaNamingHashSuffixed:
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(pkg))
+		return fmt.Sprintf("%s_%08x", base, h.Sum32())
+	}
+	return base
+}
+
+// settleSchemaNames renames the components generated from Go types after
+// the spec's naming strategy and updates the references to them. While
+// generating, a type is named after the types generated before it; settling
+// the names afterwards makes them independent of that order.
+func settleSchemaNames(spec *OpenAPISpec) {
+	if spec.Components == nil {
+		return
+	}
+	schemas := spec.Components.Schemas
+
+	// Components of a type are normally unique; a type found under several
+	// names is merged into the first.
+	current := map[reflect.Type][]string{}
+	taken := map[string]bool{}
+	for name, s := range schemas {
+		if s == nil || s.goType == nil {
+			taken[name] = true
+			continue
+		}
+		if _, named := schemaNameOverride(s.goType); named {
+			taken[name] = true
+			continue
+		}
+		current[s.goType] = append(current[s.goType], name)
+	}
+	if len(current) == 0 {
+		return
+	}
+	types := make([]reflect.Type, 0, len(current))
+	for t, names := range current {
+		sort.Strings(names)
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].PkgPath()+"."+types[i].Name() < types[j].PkgPath()+"."+types[j].Name()
+	})
+
+	wanted := map[reflect.Type]string{}
+	sharing := map[string][]reflect.Type{}
+	for _, t := range types {
+		wanted[t] = schemaNameFor(t, spec.schemaNaming)
+		sharing[wanted[t]] = append(sharing[wanted[t]], t)
+	}
+
+	renames := map[string]string{}
+	collisions := map[string][]reflect.Type{}
+	primary := map[reflect.Type]*Schema{}
+	for _, t := range types {
+		primary[t] = schemas[current[t][0]]
+		for _, name := range current[t] {
+			delete(schemas, name)
+		}
+	}
+	for _, t := range types {
+		name := wanted[t]
+		if len(sharing[name]) > 1 {
+			collisions[name] = sharing[name]
+			if spec.schemaNaming == SchemaNamingShort {
+				name = schemaNameFor(t, SchemaNamingPackagePrefixed)
+			}
+		}
+		unique := name
+		for i := 2; taken[unique]; i++ {
+			unique = name + strconv.Itoa(i)
+		}
+		if unique != name && !slices.Contains(collisions[wanted[t]], t) {
+			collisions[wanted[t]] = append(collisions[wanted[t]], t)
+		}
+		taken[unique] = true
+
+		// Titles keep the Go type name, whatever the component name
+		s := primary[t]
+		if slices.Contains(current[t], s.Title) {
+			s.Title = sanitizeSchemaName(t.Name())
+		}
+		schemas[unique] = s
+		for _, old := range current[t] {
+			if old != unique {
+				renames[old] = unique
+				spec.explain.renameComponent(old, unique)
+			}
+		}
+	}
+
+	if spec.schemaCollisionErrors {
+		reportSchemaCollisions(spec, collisions)
+	}
+	if len(renames) == 0 {
+		return
+	}
+	const prefix = "#/components/schemas/"
+	newRefRewriter(func(ref string) string {
+		if name, ok := strings.CutPrefix(ref, prefix); ok {
+			if renamed, ok := renames[name]; ok {
+				return prefix + renamed
+			}
+		}
+		return ref
+	}).spec(spec)
+}
+
+// reportSchemaCollisions fails generation for the component names given to
+// several Go types.
+func reportSchemaCollisions(spec *OpenAPISpec, collisions map[string][]reflect.Type) {
+	names := make([]string, 0, len(collisions))
+	for name := range collisions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var types []string
+		for _, t := range collisions[name] {
+			types = append(types, t.PkgPath()+"."+t.Name())
+		}
+		message := fmt.Sprintf("component name %s is taken by several types (%s): name them with api.NameSchema or choose another api.WithSchemaNaming strategy",
+			name, strings.Join(types, ", "))
+		if spec.generationErrors == nil {
+			panic(message)
+		}
+		spec.generationErrors.Routes = append(spec.generationErrors.Routes, RouteGenerationError{
+			Operation: "#/components/schemas/" + name,
+			Message:   message,
+		})
+	}
+}
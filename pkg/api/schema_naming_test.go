@@ -1,7 +1,10 @@
 package api
 
 import (
+	"context"
+	"maps"
 	"reflect"
+	"slices"
 	"strings"
 	"testing"
 
@@ -306,3 +309,112 @@ func TestGenerateResponseComponentSchema_NoBodyFieldCreatesEmptyComponent(t *tes
 		t.Fatalf("expected no properties for empty component, got %#v", stored.Properties)
 	}
 }
+
+type VariantMismatch struct {
+	Reason string `gork:"reason"`
+}
+
+type namingUser struct {
+	Name string `gork:"name"`
+}
+
+type localMismatchResponse struct {
+	Body struct {
+		Mismatch VariantMismatch `gork:"mismatch"`
+		User     namingUser      `gork:"user"`
+	}
+}
+
+type unionsMismatchResponse struct {
+	Body struct {
+		Mismatch unions.VariantMismatch `gork:"mismatch"`
+	}
+}
+
+func newNamingRegistry(localFirst bool) *RouteRegistry {
+	router, registry, _ := newLoadShedRouter()
+	local := func(context.Context, struct{}) (*localMismatchResponse, error) { return nil, nil }
+	other := func(context.Context, struct{}) (*unionsMismatchResponse, error) { return nil, nil }
+	if localFirst {
+		router.Get("/local", local)
+		router.Get("/unions", other)
+	} else {
+		router.Get("/unions", other)
+		router.Get("/local", local)
+	}
+	return registry
+}
+
+// assertRefsResolve fails when a $ref of spec names a missing component.
+func assertRefsResolve(t *testing.T, spec *OpenAPISpec) {
+	t.Helper()
+	newRefRewriter(func(ref string) string {
+		if name, ok := strings.CutPrefix(ref, "#/components/schemas/"); ok && spec.Components.Schemas[name] == nil {
+			t.Errorf("dangling reference %s", ref)
+		}
+		return ref
+	}).spec(spec)
+}
+
+func TestSchemaNamingIndependentOfRouteOrder(t *testing.T) {
+	for name, opts := range map[string][]OpenAPIOption{
+		"sequential": nil,
+		"parallel":   {WithParallelGeneration(2)},
+	} {
+		var names [][]string
+		for _, localFirst := range []bool{true, false} {
+			spec := GenerateOpenAPI(newNamingRegistry(localFirst), opts...)
+			assertRefsResolve(t, spec)
+			names = append(names, slices.Sorted(maps.Keys(spec.Components.Schemas)))
+			if s := spec.Components.Schemas["ApiVariantMismatch"]; s == nil || s.Title != "VariantMismatch" {
+				t.Errorf("%s: ApiVariantMismatch = %+v, want the Go type name as title", name, s)
+			}
+			if spec.Components.Schemas["UnionsVariantMismatch"] == nil || spec.Components.Schemas["VariantMismatch"] != nil {
+				t.Errorf("%s: components = %v, want both types package-prefixed", name, names[len(names)-1])
+			}
+		}
+		if !slices.Equal(names[0], names[1]) {
+			t.Errorf("%s: component names depend on route order: %v and %v", name, names[0], names[1])
+		}
+	}
+}
+
+func TestWithSchemaNaming(t *testing.T) {
+	tests := []struct {
+		naming SchemaNaming
+		want   string
+	}{
+		{SchemaNamingShort, "namingUser"},
+		{SchemaNamingPackagePrefixed, "ApinamingUser"},
+		{SchemaNamingPackageQualified, "github.com_gork-labs_gork_pkg_api.namingUser"},
+		{SchemaNamingHashSuffixed, "namingUser_"},
+	}
+	for _, tt := range tests {
+		spec := GenerateOpenAPI(newNamingRegistry(true), WithSchemaNaming(tt.naming))
+		assertRefsResolve(t, spec)
+		var found bool
+		for name := range spec.Components.Schemas {
+			found = found || strings.HasPrefix(name, tt.want) && (tt.naming == SchemaNamingHashSuffixed || name == tt.want)
+		}
+		if !found {
+			t.Errorf("naming %d: components = %v, want %s", tt.naming, slices.Sorted(maps.Keys(spec.Components.Schemas)), tt.want)
+		}
+	}
+}
+
+func TestWithSchemaNameCollisionErrors(t *testing.T) {
+	_, err := GenerateOpenAPIChecked(newNamingRegistry(true), WithSchemaNameCollisionErrors())
+	if err == nil || !strings.Contains(err.Error(), "component name VariantMismatch is taken by several types") {
+		t.Errorf("err = %v, want a collision error", err)
+	}
+	if _, err := GenerateOpenAPIChecked(newNamingRegistry(true), WithSchemaNameCollisionErrors(), WithSchemaNaming(SchemaNamingPackageQualified)); err != nil {
+		t.Errorf("qualified names should not collide: %v", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a collision to panic outside error-collect mode")
+		}
+	}()
+	GenerateOpenAPI(newNamingRegistry(true), WithSchemaNameCollisionErrors())
+}
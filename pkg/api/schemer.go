@@ -0,0 +1,37 @@
+package api
+
+import "reflect"
+
+// Schemer is implemented by types that document themselves with a
+// hand-written schema instead of their Go layout, such as money or decimal
+// types stored as structs but sent as strings. OpenAPISchema is called on
+// the zero value.
+type Schemer interface {
+	OpenAPISchema() *Schema
+}
+
+var schemerType = reflect.TypeOf((*Schemer)(nil)).Elem()
+
+// SchemerTypeHandler uses the schema a Schemer type returns.
+type SchemerTypeHandler struct{}
+
+// CanHandle returns true if t or *t implements Schemer.
+func (h *SchemerTypeHandler) CanHandle(t reflect.Type) bool {
+	return t.Kind() != reflect.Ptr && t.Kind() != reflect.Interface &&
+		(t.Implements(schemerType) || reflect.PointerTo(t).Implements(schemerType))
+}
+
+// GenerateSchema returns a copy of the type's schema, so that validation
+// constraints applied to one field do not leak into others.
+func (h *SchemerTypeHandler) GenerateSchema(t reflect.Type, _ map[string]*Schema, _ bool) *Schema {
+	value := reflect.New(t)
+	if t.Implements(schemerType) {
+		value = value.Elem()
+	}
+	schema := value.Interface().(Schemer).OpenAPISchema()
+	if schema == nil {
+		return &Schema{}
+	}
+	copied := *schema
+	return &copied
+}
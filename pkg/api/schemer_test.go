@@ -0,0 +1,67 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// schemerTestMoney is stored as minor units but sent as a decimal string.
+type schemerTestMoney struct {
+	units    int64
+	currency string
+}
+
+func (schemerTestMoney) OpenAPISchema() *Schema {
+	return &Schema{Type: "string", Pattern: `^\d+\.\d{2} [A-Z]{3}$`, Example: "12.50 EUR"}
+}
+
+// schemerTestID implements Schemer on its pointer.
+type schemerTestID struct{ raw string }
+
+func (*schemerTestID) OpenAPISchema() *Schema {
+	return &Schema{Type: "string", Format: "uuid"}
+}
+
+type schemerTestRequest struct {
+	Body struct {
+		Price    schemerTestMoney   `gork:"price"`
+		Discount *schemerTestMoney  `gork:"discount"`
+		Owner    schemerTestID      `gork:"owner" validate:"required"`
+		History  []schemerTestMoney `gork:"history"`
+	}
+}
+
+func TestSchemerOverridesSchema(t *testing.T) {
+	router, registry, _ := newLoadShedRouter()
+	router.Post("/prices", func(context.Context, schemerTestRequest) (*loadShedResponse, error) { return nil, nil })
+	spec := GenerateOpenAPI(registry)
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"price":{"type":"string","pattern":"^\\d+\\.\\d{2} [A-Z]{3}$","example":"12.50 EUR"}`,
+		`"discount":{"type":["string","null"],"pattern":"^\\d+\\.\\d{2} [A-Z]{3}$"}`,
+		`"owner":{"type":"string","format":"uuid"}`,
+		`"history":{"type":"array"`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("spec is missing %s:\n%s", want, data)
+		}
+	}
+	if spec.Components.Schemas["schemerTestMoney"] != nil {
+		t.Error("the struct layout of a Schemer must not be documented")
+	}
+
+	var client bytes.Buffer
+	if err := GenerateClient(registry, ClientConfig{Lang: "ts"}, &client); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(client.String(), "price?: string;") || !strings.Contains(client.String(), "owner: string;") {
+		t.Errorf("client must use the schema type:\n%s", client.String())
+	}
+}
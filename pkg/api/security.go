@@ -0,0 +1,203 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrForbidden is returned (or wrapped) by an Authenticator when the caller
+// is authenticated but lacks the required scopes. It is answered with 403;
+// any other authentication error is answered with 401.
+var ErrForbidden = errors.New("forbidden")
+
+// Authenticator verifies a request against a declared security scheme.
+// scopes are the scopes required by the route. The returned context, when
+// non-nil, replaces the request context so authenticators can hand the
+// authenticated principal to the handler.
+type Authenticator func(r *http.Request, scopes []string) (context.Context, error)
+
+// declaredSecurityScheme is a security scheme registered on a RouteRegistry.
+// Schemes without an authenticator are documentation only: they are
+// enforced outside the application.
+type declaredSecurityScheme struct {
+	scheme        SecurityScheme
+	authenticator Authenticator
+}
+
+// DeclareSecurityScheme registers a named security scheme. The scheme is
+// added to components.securitySchemes of every spec generated from the
+// registry, and routes refer to it with WithSecurity. Requests to those
+// routes are authenticated with authenticator before the handler runs. It
+// panics when authenticator is nil: schemes enforced elsewhere are declared
+// with DocumentSecurityScheme.
+func (r *RouteRegistry) DeclareSecurityScheme(name string, scheme SecurityScheme, authenticator Authenticator) {
+	if authenticator == nil {
+		panic(fmt.Sprintf("DeclareSecurityScheme(%q) requires an authenticator: use DocumentSecurityScheme for schemes enforced outside the application", name))
+	}
+	r.declareSecurityScheme(name, declaredSecurityScheme{scheme: scheme, authenticator: authenticator})
+}
+
+// DocumentSecurityScheme registers a named security scheme enforced outside
+// the application, e.g. by an API gateway. It is documented like the
+// schemes of DeclareSecurityScheme, but requests to the routes requiring it
+// are served without authentication.
+func (r *RouteRegistry) DocumentSecurityScheme(name string, scheme SecurityScheme) {
+	r.declareSecurityScheme(name, declaredSecurityScheme{scheme: scheme})
+}
+
+func (r *RouteRegistry) declareSecurityScheme(name string, declared declaredSecurityScheme) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.securitySchemes == nil {
+		r.securitySchemes = map[string]declaredSecurityScheme{}
+	}
+	r.securitySchemes[name] = declared
+	r.revision++
+}
+
+// SecuritySchemes returns a copy of the declared security schemes keyed by name.
+func (r *RouteRegistry) SecuritySchemes() map[string]*SecurityScheme {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.securitySchemes) == 0 {
+		return nil
+	}
+	schemes := make(map[string]*SecurityScheme, len(r.securitySchemes))
+	for name, declared := range r.securitySchemes {
+		scheme := declared.scheme
+		schemes[name] = &scheme
+	}
+	return schemes
+}
+
+// securityScheme returns the declared scheme registered under name.
+func (r *RouteRegistry) securityScheme(name string) (declaredSecurityScheme, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	declared, ok := r.securitySchemes[name]
+	return declared, ok
+}
+
+// DeclareSecurityScheme registers a named security scheme on the router's
+// registry. See RouteRegistry.DeclareSecurityScheme.
+func (r *TypedRouter[T]) DeclareSecurityScheme(name string, scheme SecurityScheme, authenticator Authenticator) {
+	r.registry.DeclareSecurityScheme(name, scheme, authenticator)
+}
+
+// DocumentSecurityScheme registers a named security scheme enforced outside
+// the application on the router's registry. See
+// RouteRegistry.DocumentSecurityScheme.
+func (r *TypedRouter[T]) DocumentSecurityScheme(name string, scheme SecurityScheme) {
+	r.registry.DocumentSecurityScheme(name, scheme)
+}
+
+// WithSecurity requires the security scheme declared under name, with the
+// given OAuth2 scopes. Several WithSecurity options are alternatives: a
+// request satisfying any of them is accepted.
+//
+//	router.DeclareSecurityScheme("bearerAuth", api.SecurityScheme{Type: "http", Scheme: "bearer"}, verifyToken)
+//	router.Get("/me", GetMe, api.WithSecurity("bearerAuth"))
+func WithSecurity(name string, scopes ...string) Option {
+	return func(h *HandlerOption) {
+		h.Security = append(h.Security, SecurityRequirement{Scheme: name, Scopes: scopes})
+	}
+}
+
+// namedSecurity returns the requirements of a route that refer to declared schemes.
+func namedSecurity(options *HandlerOption) []SecurityRequirement {
+	if options == nil {
+		return nil
+	}
+	var named []SecurityRequirement
+	for _, sec := range options.Security {
+		if sec.Scheme != "" {
+			named = append(named, sec)
+		}
+	}
+	return named
+}
+
+// wrapAuthentication enforces the named security requirements of a route.
+// Authenticators are looked up per request so schemes may be declared after
+// the routes using them. A requirement whose scheme is documentation only
+// (DocumentSecurityScheme) is enforced elsewhere, which lets the whole route
+// through. Requirements naming an undeclared scheme fail closed with 500.
+func wrapAuthentication(registry *RouteRegistry, requirements []SecurityRequirement, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var lastErr error
+		challenge := ""
+		for _, sec := range requirements {
+			declared, ok := registry.securityScheme(sec.Scheme)
+			if !ok {
+				writeError(w, http.StatusInternalServerError, fmt.Sprintf("security scheme %q is not declared", sec.Scheme))
+				return
+			}
+			if declared.authenticator == nil {
+				next(w, r)
+				return
+			}
+			ctx, err := declared.authenticator(r, sec.Scopes)
+			if err == nil {
+				if ctx != nil {
+					r = r.WithContext(ctx)
+				}
+				next(w, r)
+				return
+			}
+			if lastErr == nil || errors.Is(err, ErrForbidden) {
+				lastErr = err
+			}
+			if challenge == "" && declared.scheme.Type == "http" && declared.scheme.Scheme != "" {
+				challenge = strings.ToUpper(declared.scheme.Scheme[:1]) + declared.scheme.Scheme[1:]
+			}
+		}
+
+		writeAuthError(w, lastErr, challenge)
+	}
+}
+
+// writeAuthError answers a failed authentication with 403 for ErrForbidden
+// and 401 otherwise, sending challenge as WWW-Authenticate when set.
+func writeAuthError(w http.ResponseWriter, err error, challenge string) {
+	if errors.Is(err, ErrForbidden) {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	if challenge != "" {
+		w.Header().Set("WWW-Authenticate", challenge)
+	}
+	writeError(w, http.StatusUnauthorized, err.Error())
+}
+
+// addAuthResponses documents the 401 and 403 responses of routes whose
+// security requirements are enforced by an authenticator.
+func (g *ConventionOpenAPIGenerator) addAuthResponses(registry *RouteRegistry, route *RouteInfo, components *Components, op *Operation) {
+	requirements := namedSecurity(route.Options)
+	if len(requirements) == 0 {
+		return
+	}
+	for _, sec := range requirements {
+		declared, ok := registry.securityScheme(sec.Scheme)
+		if !ok {
+			panic(fmt.Sprintf("route %s %s requires security scheme %q, which is not declared: declare it with DeclareSecurityScheme or DocumentSecurityScheme",
+				route.Method, route.Path, sec.Scheme))
+		}
+		if declared.authenticator == nil {
+			return
+		}
+	}
+	if op.Responses == nil {
+		op.Responses = map[string]*Response{}
+	}
+	g.ensureErrorSchemas(components)
+	errorContent := func() map[string]*MediaType {
+		return map[string]*MediaType{
+			"application/json": {Schema: &Schema{Ref: "#/components/schemas/ErrorResponse"}},
+		}
+	}
+	op.Responses["401"] = &Response{Description: "Unauthorized", Content: errorContent()}
+	op.Responses["403"] = &Response{Description: "Forbidden", Content: errorContent()}
+}
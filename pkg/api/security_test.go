@@ -0,0 +1,132 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type principalKey struct{}
+
+func bearerAuthenticator(r *http.Request, scopes []string) (context.Context, error) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	switch {
+	case token == "":
+		return nil, errors.New("missing token")
+	case len(scopes) > 0 && token != "admin":
+		return nil, ErrForbidden
+	}
+	return context.WithValue(r.Context(), principalKey{}, token), nil
+}
+
+func TestWithSecurityEnforcement(t *testing.T) {
+	var principal interface{}
+	handler := func(ctx context.Context, _ loadShedRequest) (*loadShedResponse, error) {
+		principal = ctx.Value(principalKey{})
+		return &loadShedResponse{}, nil
+	}
+	router, _, handlers := newLoadShedRouter()
+	router.Get("/me", handler, WithSecurity("bearerAuth"))
+	router.Get("/admin", handler, WithSecurity("bearerAuth", "admin"))
+	router.Get("/docs-only", handler, WithSecurity("apiKeyAuth"))
+	// Declared after the routes: authenticators are resolved per request.
+	router.DeclareSecurityScheme("bearerAuth", SecurityScheme{Type: "http", Scheme: "bearer"}, bearerAuthenticator)
+	router.DocumentSecurityScheme("apiKeyAuth", SecurityScheme{Type: "apiKey", In: "header", Name: "X-API-Key"})
+
+	cases := []struct {
+		route, token string
+		code         int
+	}{
+		{"GET /me", "", http.StatusUnauthorized},
+		{"GET /me", "alice", http.StatusOK},
+		{"GET /admin", "alice", http.StatusForbidden},
+		{"GET /admin", "admin", http.StatusOK},
+		{"GET /docs-only", "", http.StatusOK},
+	}
+	for _, tc := range cases {
+		principal = nil
+		req := httptest.NewRequest(http.MethodGet, "/api"+strings.TrimPrefix(tc.route, "GET "), nil)
+		if tc.token != "" {
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+		}
+		w := httptest.NewRecorder()
+		handlers[tc.route](w, req)
+		if w.Code != tc.code {
+			t.Errorf("%s with %q: expected %d, got %d %s", tc.route, tc.token, tc.code, w.Code, w.Body.String())
+		}
+		if tc.code == http.StatusOK && tc.token != "" && principal != tc.token {
+			t.Errorf("%s: expected principal %q in handler context, got %v", tc.route, tc.token, principal)
+		}
+		if tc.code == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") != "Bearer" {
+			t.Errorf("expected bearer challenge, got %q", w.Header().Get("WWW-Authenticate"))
+		}
+	}
+}
+
+func TestWithSecurityOpenAPI(t *testing.T) {
+	router, registry, _ := newLoadShedRouter()
+	router.DeclareSecurityScheme("bearerAuth", SecurityScheme{Type: "http", Scheme: "bearer", BearerFormat: "JWT"}, bearerAuthenticator)
+	router.DocumentSecurityScheme("oauth", SecurityScheme{
+		Type: "oauth2",
+		Flows: &OAuthFlows{ClientCredentials: &OAuthFlow{
+			TokenURL: "https://auth.example.com/token",
+			Scopes:   map[string]string{"orders:read": "Read orders"},
+		}},
+	})
+	handler := func(context.Context, loadShedRequest) (*loadShedResponse, error) { return nil, nil }
+	router.Get("/me", handler, WithSecurity("bearerAuth"))
+	router.Get("/orders", handler, WithSecurity("oauth", "orders:read"), WithSecurity("bearerAuth"))
+
+	spec := GenerateOpenAPI(registry, WithSecurityScheme("cookieAuth", SecurityScheme{Type: "apiKey", In: "cookie", Name: "session"}))
+	schemes := spec.Components.SecuritySchemes
+	if schemes["bearerAuth"] == nil || schemes["bearerAuth"].BearerFormat != "JWT" || schemes["oauth"] == nil || schemes["cookieAuth"] == nil {
+		t.Fatalf("expected declared schemes, got %+v", schemes)
+	}
+
+	me := spec.Paths["/api/me"].Get
+	if len(me.Security) != 1 || me.Security[0]["bearerAuth"] == nil {
+		t.Errorf("unexpected /me security %v", me.Security)
+	}
+	if me.Responses["401"] == nil || me.Responses["403"] == nil {
+		t.Errorf("expected 401 and 403 for an enforced route, got %v", me.Responses)
+	}
+
+	orders := spec.Paths["/api/orders"].Get
+	if len(orders.Security) != 2 || len(orders.Security[0]["oauth"]) != 1 || orders.Security[0]["oauth"][0] != "orders:read" {
+		t.Errorf("unexpected /orders security %v", orders.Security)
+	}
+	if orders.Responses["401"] != nil {
+		t.Error("routes with an unenforced alternative must not document 401")
+	}
+}
+
+func TestWithSecurityFailsClosed(t *testing.T) {
+	router, registry, handlers := newLoadShedRouter()
+	handler := func(context.Context, loadShedRequest) (*loadShedResponse, error) { return &loadShedResponse{}, nil }
+	router.Get("/typo", handler, WithSecurity("bearerAut"))
+
+	w := httptest.NewRecorder()
+	handlers["GET /typo"](w, httptest.NewRequest(http.MethodGet, "/api/typo", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("undeclared scheme: expected 500, got %d %s", w.Code, w.Body.String())
+	}
+
+	func() {
+		defer func() {
+			if recovered := recover(); recovered == nil || !strings.Contains(recovered.(string), "bearerAut") {
+				t.Errorf("expected generation to panic on the undeclared scheme, got %v", recovered)
+			}
+		}()
+		GenerateOpenAPI(registry)
+	}()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected DeclareSecurityScheme to reject a nil authenticator")
+		}
+	}()
+	router.DeclareSecurityScheme("docs", SecurityScheme{Type: "http", Scheme: "bearer"}, nil)
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"errors"
+	"reflect"
+)
+
+// RedactedValue replaces sensitive string values in redacted copies.
+const RedactedValue = "[REDACTED]"
+
+// errSensitiveValue replaces conversion errors of sensitive parameters, which
+// would otherwise quote the offending value.
+var errSensitiveValue = errors.New("invalid value")
+
+// redactError hides err behind errSensitiveValue when the field is sensitive.
+func redactError(tagInfo GorkTagInfo, err error) error {
+	if tagInfo.Sensitive {
+		return errSensitiveValue
+	}
+	return err
+}
+
+// Redact returns a copy of v with every field tagged `gork:"...,sensitive"`
+// masked: strings are replaced with RedactedValue and other values are
+// zeroed. Nested structs, pointers, slices, arrays and maps are copied and
+// redacted recursively; v itself is never modified. Values without sensitive
+// fields are returned as is.
+func Redact(v any) any {
+	if v == nil {
+		return nil
+	}
+	rv := reflect.ValueOf(v)
+	if !containsSensitive(rv.Type(), map[reflect.Type]bool{}) {
+		return v
+	}
+	return redactValue(rv).Interface()
+}
+
+// containsSensitive reports whether values of t can hold sensitive fields.
+func containsSensitive(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return containsSensitive(t.Elem(), seen)
+	case reflect.Map:
+		return containsSensitive(t.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			if parseGorkTag(f.Tag.Get("gork")).Sensitive || containsSensitive(f.Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func redactValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type().Elem())
+		out.Elem().Set(redactValue(v.Elem()))
+		return out
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(redactValue(v.Elem()))
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(redactValue(v.Index(i)))
+		}
+		return out
+	case reflect.Array:
+		out := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(redactValue(v.Index(i)))
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), redactValue(iter.Value()))
+		}
+		return out
+	case reflect.Struct:
+		out := reflect.New(v.Type()).Elem()
+		out.Set(v)
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			field := out.Field(i)
+			if parseGorkTag(f.Tag.Get("gork")).Sensitive {
+				field.Set(maskedValue(f.Type))
+				continue
+			}
+			field.Set(redactValue(v.Field(i)))
+		}
+		return out
+	}
+	return v
+}
+
+// maskedValue is the stand-in for a sensitive value of type t.
+func maskedValue(t reflect.Type) reflect.Value {
+	if t.Kind() == reflect.String {
+		return reflect.ValueOf(RedactedValue).Convert(t)
+	}
+	return reflect.Zero(t)
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type sensitiveCredentials struct {
+	User     string `gork:"user"`
+	Password string `gork:"password,sensitive"`
+	PIN      int    `gork:"pin,sensitive"`
+}
+
+type sensitiveRequest struct {
+	Query struct {
+		Token int `gork:"token,sensitive"`
+		Page  int `gork:"page"`
+	}
+	Body struct {
+		Credentials sensitiveCredentials   `gork:"credentials"`
+		Backups     []sensitiveCredentials `gork:"backups"`
+	}
+}
+
+func newSensitiveRouter(opts ...Option) (*RouteRegistry, map[string]http.HandlerFunc, TypedRouter[*struct{}]) {
+	registry := NewRouteRegistry()
+	handlers := map[string]http.HandlerFunc{}
+	router := NewTypedRouter[*struct{}](nil, registry, "/api", opts, &DefaultParameterAdapter{}, func(method, path string, h http.HandlerFunc, _ *RouteInfo) {
+		handlers[method+" "+path] = h
+	})
+	return registry, handlers, router
+}
+
+func TestSensitiveParseErrorsHideValue(t *testing.T) {
+	_, handlers, router := newSensitiveRouter()
+	router.Post("/login", func(_ context.Context, _ sensitiveRequest) (*struct{}, error) {
+		return nil, nil
+	})
+
+	rec := httptest.NewRecorder()
+	handlers["POST /login"](rec, httptest.NewRequest(http.MethodPost, "/api/login?token=s3cr3t", strings.NewReader("{}")))
+	if rec.Code != http.StatusBadRequest || strings.Contains(rec.Body.String(), "s3cr3t") {
+		t.Errorf("expected 400 without the sensitive value, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handlers["POST /login"](rec, httptest.NewRequest(http.MethodPost, "/api/login?page=abc", strings.NewReader("{}")))
+	if !strings.Contains(rec.Body.String(), "abc") {
+		t.Errorf("expected non-sensitive values to be reported, got %s", rec.Body.String())
+	}
+}
+
+func TestRedact(t *testing.T) {
+	creds := sensitiveCredentials{User: "ann", Password: "hunter2", PIN: 1234}
+	var req sensitiveRequest
+	req.Query.Token = 42
+	req.Body.Credentials = creds
+	req.Body.Backups = []sensitiveCredentials{creds}
+
+	got := Redact(req).(sensitiveRequest)
+	if got.Query.Token != 0 || got.Query.Page != req.Query.Page {
+		t.Errorf("expected only the token to be zeroed, got %+v", got.Query)
+	}
+	want := sensitiveCredentials{User: "ann", Password: RedactedValue}
+	if got.Body.Credentials != want || got.Body.Backups[0] != want {
+		t.Errorf("expected nested credentials to be redacted, got %+v", got.Body)
+	}
+	if req.Body.Credentials.Password != "hunter2" || req.Body.Backups[0].Password != "hunter2" {
+		t.Error("Redact must not modify its argument")
+	}
+
+	ptr := Redact(&creds).(*sensitiveCredentials)
+	if ptr == &creds || ptr.Password != RedactedValue || creds.Password != "hunter2" {
+		t.Errorf("expected a redacted copy behind a new pointer, got %+v", ptr)
+	}
+
+	plain := map[string]string{"password": "x"}
+	if got := Redact(plain).(map[string]string); got["password"] != "x" {
+		t.Errorf("values without sensitive fields must pass through, got %v", got)
+	}
+	if Redact(nil) != nil {
+		t.Error("expected nil to pass through")
+	}
+}
+
+func TestSensitivePanicValueRedacted(t *testing.T) {
+	var reported any
+	_, handlers, router := newSensitiveRouter(WithPanicHandler(func(_ *http.Request, recovered any, _ []byte) string {
+		reported = recovered
+		return ""
+	}))
+	router.Get("/boom", func(_ context.Context, _ struct{}) (*struct{}, error) {
+		panic(sensitiveCredentials{User: "ann", Password: "hunter2"})
+	})
+
+	rec := httptest.NewRecorder()
+	handlers["GET /boom"](rec, httptest.NewRequest(http.MethodGet, "/api/boom", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", rec.Code)
+	}
+	if got, ok := reported.(sensitiveCredentials); !ok || got.Password != RedactedValue || got.User != "ann" {
+		t.Errorf("expected the panic handler to receive a redacted value, got %#v", reported)
+	}
+}
+
+func TestSensitiveDocumented(t *testing.T) {
+	registry, _, router := newSensitiveRouter()
+	router.Post("/login", func(_ context.Context, _ sensitiveRequest) (*struct{}, error) {
+		return nil, nil
+	})
+
+	spec := GenerateOpenAPI(registry)
+	for _, p := range spec.Paths["/api/login"].Post.Parameters {
+		if p.Sensitive != (p.Name == "token") {
+			t.Errorf("parameter %s: unexpected x-sensitive %v", p.Name, p.Sensitive)
+		}
+	}
+	creds := spec.Components.Schemas["sensitiveCredentials"]
+	if creds == nil || !creds.Properties["password"].Sensitive || creds.Properties["user"].Sensitive {
+		t.Fatalf("expected password to be marked sensitive, got %+v", creds)
+	}
+	data, err := json.Marshal(creds.Properties["password"])
+	if err != nil || !strings.Contains(string(data), `"x-sensitive":true`) {
+		t.Errorf("expected x-sensitive in the JSON schema, got %s (%v)", data, err)
+	}
+}
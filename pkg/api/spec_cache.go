@@ -0,0 +1,39 @@
+package api
+
+import "sync"
+
+// SpecCache holds the spec generated from a registry and regenerates it
+// only once the registry's revision has changed, so that serving the spec
+// does not rebuild every schema per request. The cached spec is shared and
+// must not be modified.
+//
+// SpecCache is safe for concurrent use by multiple goroutines.
+type SpecCache struct {
+	registry *RouteRegistry
+	opts     []OpenAPIOption
+
+	mu       sync.Mutex
+	revision uint64
+	spec     *OpenAPISpec
+}
+
+// NewSpecCache returns a cache of the spec GenerateOpenAPI builds from
+// registry with opts.
+func NewSpecCache(registry *RouteRegistry, opts ...OpenAPIOption) *SpecCache {
+	return &SpecCache{registry: registry, opts: opts}
+}
+
+// Spec returns the cached spec, generating it first when routes or security
+// schemes were registered since it was last generated.
+func (c *SpecCache) Spec() *OpenAPISpec {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Read the revision before generating, so that a route registered
+	// meanwhile triggers another generation on the next call.
+	revision := c.registry.Revision()
+	if c.spec == nil || revision != c.revision {
+		c.spec = GenerateOpenAPI(c.registry, c.opts...)
+		c.revision = revision
+	}
+	return c.spec
+}
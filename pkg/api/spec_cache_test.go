@@ -0,0 +1,40 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSpecCache(t *testing.T) {
+	registry := NewRouteRegistry()
+	cache := NewSpecCache(registry, WithTitle("Cached"))
+
+	first := cache.Spec()
+	if first.Info.Title != "Cached" {
+		t.Errorf("expected options to apply, got title %q", first.Info.Title)
+	}
+	if cache.Spec() != first {
+		t.Error("expected the spec to be reused while the registry is unchanged")
+	}
+
+	revision := registry.Revision()
+	registry.Register(&RouteInfo{
+		Method:       "POST",
+		Path:         "/users",
+		HandlerName:  "Rename",
+		RequestType:  reflect.TypeOf(renameRequest{}),
+		ResponseType: reflect.TypeOf(&renameResponse{}),
+	})
+	if registry.Revision() == revision {
+		t.Fatal("expected registering a route to change the revision")
+	}
+	second := cache.Spec()
+	if second == first || second.Paths["/users"] == nil {
+		t.Errorf("expected the spec to be regenerated with the new route, got %v", second.Paths)
+	}
+
+	registry.DocumentSecurityScheme("ApiKey", SecurityScheme{Type: "apiKey", In: "header", Name: "X-API-Key"})
+	if third := cache.Spec(); third == second || third.Components.SecuritySchemes["ApiKey"] == nil {
+		t.Error("expected declaring a security scheme to invalidate the spec")
+	}
+}
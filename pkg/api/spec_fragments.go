@@ -0,0 +1,287 @@
+package api
+
+import (
+	"maps"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// WithParallelGeneration generates the operations of the routes on up to
+// workers goroutines, GOMAXPROCS when workers is not positive, and then
+// assembles them in registration order. The spec is the same as the one
+// generated sequentially; it pays off for registries with hundreds of
+// routes. WithExplain turns it off, as its report is recorded in order.
+func WithParallelGeneration(workers int) OpenAPIOption {
+	return func(spec *OpenAPISpec) {
+		if workers <= 0 {
+			workers = runtime.GOMAXPROCS(0)
+		}
+		spec.parallelism = workers
+	}
+}
+
+// routeFragment is the part of a spec generated for a single route: its
+// operation and the components it added to the seed components it was
+// generated against.
+type routeFragment struct {
+	route *RouteInfo
+	// op is nil when the route failed in error-collect mode.
+	op         *Operation
+	components *Components
+	errors     []RouteGenerationError
+}
+
+// clone returns a copy of c whose maps can be modified independently.
+func (c *Components) clone() *Components {
+	return &Components{
+		Schemas:         maps.Clone(c.Schemas),
+		SecuritySchemes: maps.Clone(c.SecuritySchemes),
+		Responses:       maps.Clone(c.Responses),
+	}
+}
+
+// buildFragment generates the fragment of route against a copy of seed.
+// Captured examples and the spec audience are applied to the fragment, so
+// that assembling fragments needs no further processing.
+func buildFragment(spec *OpenAPISpec, registry *RouteRegistry, route *RouteInfo, seed *Components) *routeFragment {
+	frag := *spec
+	frag.Paths = map[string]*PathItem{}
+	frag.Components = seed.clone()
+	if spec.generationErrors != nil {
+		frag.generationErrors = &GenerationError{}
+	}
+
+	op, ok := buildRouteOperation(&frag, registry, route)
+	f := &routeFragment{route: route, components: componentsAddedTo(seed, frag.Components)}
+	if frag.generationErrors != nil {
+		f.errors = frag.generationErrors.Routes
+	}
+	if ok {
+		f.op = op
+		item := &PathItem{}
+		attachOperation(item, strings.ToLower(route.Method), op)
+		frag.Paths[normalizePath(route.Path)] = item
+	}
+	frag.Components = f.components
+	ApplyCapturedExamples(&frag, spec.capturedExamples)
+	applySpecAudience(&frag)
+	if isOpenAPI30(&frag) {
+		ConvertToOpenAPI30(&frag)
+	}
+	return f
+}
+
+// componentsAddedTo returns the entries of c that seed lacks or holds a
+// different value for.
+func componentsAddedTo(seed, c *Components) *Components {
+	return &Components{
+		Schemas:         addedEntries(seed.Schemas, c.Schemas),
+		SecuritySchemes: addedEntries(seed.SecuritySchemes, c.SecuritySchemes),
+		Responses:       addedEntries(seed.Responses, c.Responses),
+	}
+}
+
+func addedEntries[V comparable](seed, m map[string]V) map[string]V {
+	var added map[string]V
+	for name, v := range m {
+		if existing, ok := seed[name]; ok && existing == v {
+			continue
+		}
+		if added == nil {
+			added = map[string]V{}
+		}
+		added[name] = v
+	}
+	return added
+}
+
+// buildFragments generates the fragments of routes against seed on
+// spec.parallelism goroutines. A panic in one of them is raised again in
+// the caller, the first in route order winning.
+func buildFragments(spec *OpenAPISpec, registry *RouteRegistry, routes []*RouteInfo, seed *Components) []*routeFragment {
+	fragments := make([]*routeFragment, len(routes))
+	panics := make([]interface{}, len(routes))
+	workers := min(max(spec.parallelism, 1), len(routes))
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				func() {
+					defer func() { panics[i] = recover() }()
+					fragments[i] = buildFragment(spec, registry, routes[i], seed)
+				}()
+			}
+		}()
+	}
+	for i := range routes {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	for _, recovered := range panics {
+		if recovered != nil {
+			panic(recovered)
+		}
+	}
+	return fragments
+}
+
+// assembleFragments adds fragments to spec in order. A fragment naming a
+// component differently from the fragments before it, as when two types
+// share a name, is generated again against the assembled components, so
+// that names are resolved as sequential generation resolves them.
+func assembleFragments(spec *OpenAPISpec, registry *RouteRegistry, fragments []*routeFragment) {
+	// Fragments only prune and convert the components they add.
+	seen := map[*Schema]bool{}
+	for _, schema := range spec.Components.Schemas {
+		pruneSchemaAudience(schema, spec.audience, seen)
+	}
+	if isOpenAPI30(spec) {
+		ConvertToOpenAPI30(spec)
+	}
+	for _, f := range fragments {
+		if !fitsComponents(spec.Components, f.components) {
+			f = buildFragment(spec, registry, f.route, spec.Components)
+		}
+		mergeEntries(&spec.Components.Schemas, f.components.Schemas)
+		mergeEntries(&spec.Components.SecuritySchemes, f.components.SecuritySchemes)
+		mergeEntries(&spec.Components.Responses, f.components.Responses)
+		if spec.generationErrors != nil {
+			spec.generationErrors.Routes = append(spec.generationErrors.Routes, f.errors...)
+		}
+		if f.op == nil {
+			continue
+		}
+		addRouteOperation(spec, f.route, f.op)
+	}
+	settleSchemaNames(spec)
+}
+
+// fitsComponents reports whether every component of added is either
+// missing from c or equal to c's.
+func fitsComponents(c, added *Components) bool {
+	return fitsEntries(c.Schemas, added.Schemas) &&
+		fitsEntries(c.SecuritySchemes, added.SecuritySchemes) &&
+		fitsEntries(c.Responses, added.Responses)
+}
+
+func fitsEntries[V any](m, added map[string]V) bool {
+	for name, v := range added {
+		if existing, ok := m[name]; ok && !reflect.DeepEqual(existing, v) {
+			return false
+		}
+	}
+	return true
+}
+
+func mergeEntries[V any](dst *map[string]V, added map[string]V) {
+	if len(added) == 0 {
+		return
+	}
+	if *dst == nil {
+		*dst = map[string]V{}
+	}
+	for name, v := range added {
+		if _, ok := (*dst)[name]; !ok {
+			(*dst)[name] = v
+		}
+	}
+}
+
+// SpecGenerator generates specs from per-route fragments, the operation of
+// a route and the components it needs, built on demand and kept: after
+// routes are registered only their fragments are generated, and Operation
+// generates a single route. Fragments are built concurrently with
+// WithParallelGeneration. With WithExplain every spec is generated by
+// GenerateOpenAPI.
+//
+// Specs and operations share the kept fragments and must not be modified.
+// SpecGenerator is safe for concurrent use by multiple goroutines.
+type SpecGenerator struct {
+	registry *RouteRegistry
+	opts     []OpenAPIOption
+
+	mu sync.Mutex
+	// seed holds the components fragments were generated against; they
+	// are dropped when it changes, e.g. once a security scheme is
+	// declared.
+	seed      *Components
+	fragments map[*RouteInfo]*routeFragment
+}
+
+// NewSpecGenerator returns a generator of the specs GenerateOpenAPI builds
+// from registry with opts.
+func NewSpecGenerator(registry *RouteRegistry, opts ...OpenAPIOption) *SpecGenerator {
+	return &SpecGenerator{registry: registry, opts: opts}
+}
+
+// Spec assembles the spec of every route, generating the fragments of the
+// routes registered since the last call.
+func (g *SpecGenerator) Spec() *OpenAPISpec {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	spec := newSpec(g.registry, g.opts)
+	if spec.explain != nil {
+		return GenerateOpenAPI(g.registry, g.opts...)
+	}
+	routes := specRoutes(spec, g.registry)
+	g.reset(spec)
+
+	var missing []*RouteInfo
+	for _, route := range routes {
+		if g.fragments[route] == nil {
+			missing = append(missing, route)
+		}
+	}
+	for _, f := range buildFragments(spec, g.registry, missing, g.seed) {
+		g.fragments[f.route] = f
+	}
+
+	fragments := make([]*routeFragment, len(routes))
+	for i, route := range routes {
+		fragments[i] = g.fragments[route]
+	}
+	assembleFragments(spec, g.registry, fragments)
+	return spec
+}
+
+// Operation returns the operation of the route registered for method and
+// path, generating only that route's fragment. Its component references
+// resolve against the components of Spec.
+func (g *SpecGenerator) Operation(method, path string) (*Operation, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	spec := newSpec(g.registry, g.opts)
+	g.reset(spec)
+	for _, route := range specRoutes(spec, g.registry) {
+		if !strings.EqualFold(route.Method, method) || route.Path != path {
+			continue
+		}
+		f := g.fragments[route]
+		if f == nil {
+			f = buildFragment(spec, g.registry, route, g.seed)
+			g.fragments[route] = f
+		}
+		return f.op, f.op != nil
+	}
+	return nil, false
+}
+
+// reset drops the kept fragments when the components spec starts from
+// differ from the ones they were generated against.
+func (g *SpecGenerator) reset(spec *OpenAPISpec) {
+	if g.seed != nil && reflect.DeepEqual(g.seed, spec.Components) {
+		return
+	}
+	g.seed = spec.Components.clone()
+	g.fragments = map[*RouteInfo]*routeFragment{}
+}
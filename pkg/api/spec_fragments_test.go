@@ -0,0 +1,120 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// fragmentRegistry registers routes sharing components, and one whose
+// response type shares its name with another route's.
+func fragmentRegistry() *RouteRegistry {
+	registry := NewRouteRegistry()
+	for i := 0; i < 20; i++ {
+		registry.Register(&RouteInfo{
+			Method:       "POST",
+			Path:         fmt.Sprintf("/users/%d", i),
+			HandlerName:  fmt.Sprintf("Rename%d", i),
+			RequestType:  reflect.TypeOf(renameRequest{}),
+			ResponseType: reflect.TypeOf(&renameResponse{}),
+		})
+	}
+	registry.Register(&RouteInfo{
+		Method:       "GET",
+		Path:         "/orders/{id}",
+		HandlerName:  "GetOrder",
+		RequestType:  reflect.TypeOf(TestOpenAPIRequest{}),
+		ResponseType: reflect.TypeOf(&audienceResponse{}),
+	})
+	registry.Register(&RouteInfo{
+		Method:       "PUT",
+		Path:         "/users/{id}",
+		HandlerName:  "Rename",
+		RequestType:  reflect.TypeOf(renameRequest{}),
+		ResponseType: shadowedRenameResponse(),
+	})
+	return registry
+}
+
+// shadowedRenameResponse returns a response type named like
+// renameResponse.
+func shadowedRenameResponse() reflect.Type {
+	type renameResponse struct {
+		Body struct {
+			Code int `gork:"code"`
+		}
+	}
+	return reflect.TypeOf(&renameResponse{})
+}
+
+func specJSON(t *testing.T, spec *OpenAPISpec) string {
+	t.Helper()
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatalf("marshal spec: %v", err)
+	}
+	return string(data)
+}
+
+func TestParallelGeneration(t *testing.T) {
+	registry := fragmentRegistry()
+	for _, audience := range []string{"", "internal"} {
+		want := specJSON(t, GenerateOpenAPI(registry, WithSpecAudience(audience)))
+		got := specJSON(t, GenerateOpenAPI(registry, WithSpecAudience(audience), WithParallelGeneration(4)))
+		if got != want {
+			t.Errorf("audience %q: parallel spec differs from sequential:\n%s\nwant:\n%s", audience, got, want)
+		}
+	}
+}
+
+func TestSpecGenerator(t *testing.T) {
+	registry := fragmentRegistry()
+	generator := NewSpecGenerator(registry, WithParallelGeneration(0))
+
+	op, ok := generator.Operation("get", "/orders/{id}")
+	if !ok || op.OperationID != "GetOrder" {
+		t.Fatalf("expected the GetOrder operation, got %v, %v", op, ok)
+	}
+	if _, ok := generator.Operation("DELETE", "/orders/{id}"); ok {
+		t.Error("expected no operation for an unregistered route")
+	}
+
+	if got, want := specJSON(t, generator.Spec()), specJSON(t, GenerateOpenAPI(registry)); got != want {
+		t.Errorf("generator spec differs from GenerateOpenAPI:\n%s\nwant:\n%s", got, want)
+	}
+	if again, _ := generator.Operation("GET", "/orders/{id}"); again != op {
+		t.Error("expected the operation fragment to be kept")
+	}
+
+	registry.Register(&RouteInfo{
+		Method:       "DELETE",
+		Path:         "/orders/{id}",
+		HandlerName:  "DeleteOrder",
+		RequestType:  reflect.TypeOf(TestOpenAPIRequest{}),
+		ResponseType: reflect.TypeOf(&renameResponse{}),
+	})
+	registry.DocumentSecurityScheme("ApiKey", SecurityScheme{Type: "apiKey", In: "header", Name: "X-API-Key"})
+	if got, want := specJSON(t, generator.Spec()), specJSON(t, GenerateOpenAPI(registry)); got != want {
+		t.Errorf("generator spec differs after registering a route:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestParallelGenerationCollectsErrors(t *testing.T) {
+	registry := fragmentRegistry()
+	registry.Register(&RouteInfo{
+		Method:      "GET",
+		Path:        "/broken",
+		HandlerName: "Broken",
+	})
+
+	var sequential, parallel GenerationError
+	want := specJSON(t, GenerateOpenAPI(registry, WithGenerationErrors(&sequential)))
+	got := specJSON(t, GenerateOpenAPI(registry, WithGenerationErrors(&parallel), WithParallelGeneration(4)))
+	if got != want {
+		t.Errorf("parallel spec differs from sequential:\n%s\nwant:\n%s", got, want)
+	}
+	if len(sequential.Routes) == 0 || len(parallel.Routes) != len(sequential.Routes) {
+		t.Errorf("expected %d route errors, got %v", len(sequential.Routes), parallel.Routes)
+	}
+}
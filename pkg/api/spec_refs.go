@@ -0,0 +1,91 @@
+package api
+
+// refRewriter replaces the $ref of the schemas and responses it walks,
+// visiting each schema once so that shared schemas are not rewritten twice.
+type refRewriter struct {
+	rewrite func(ref string) string
+	seen    map[*Schema]bool
+}
+
+func newRefRewriter(rewrite func(ref string) string) *refRewriter {
+	return &refRewriter{rewrite: rewrite, seen: map[*Schema]bool{}}
+}
+
+// spec rewrites the references of every component, path and webhook.
+func (r *refRewriter) spec(spec *OpenAPISpec) {
+	if spec.Components != nil {
+		for _, schema := range spec.Components.Schemas {
+			r.schema(schema)
+		}
+		for _, resp := range spec.Components.Responses {
+			r.response(resp)
+		}
+	}
+	for _, items := range []map[string]*PathItem{spec.Paths, spec.Webhooks} {
+		for _, item := range items {
+			for _, op := range pathItemOperations(item) {
+				r.operation(op)
+			}
+		}
+	}
+}
+
+func (r *refRewriter) operation(op *Operation) {
+	for i := range op.Parameters {
+		r.schema(op.Parameters[i].Schema)
+	}
+	if op.RequestBody != nil {
+		for _, media := range op.RequestBody.Content {
+			r.schema(media.Schema)
+		}
+	}
+	for _, resp := range op.Responses {
+		r.response(resp)
+	}
+	for _, callback := range op.Callbacks {
+		for _, item := range callback {
+			for _, callbackOp := range pathItemOperations(item) {
+				r.operation(callbackOp)
+			}
+		}
+	}
+}
+
+func (r *refRewriter) response(resp *Response) {
+	if resp == nil {
+		return
+	}
+	resp.Ref = r.rewrite(resp.Ref)
+	for _, media := range resp.Content {
+		r.schema(media.Schema)
+	}
+	for _, header := range resp.Headers {
+		r.schema(header.Schema)
+	}
+}
+
+func (r *refRewriter) schema(s *Schema) {
+	if s == nil || r.seen[s] {
+		return
+	}
+	r.seen[s] = true
+	if s.Ref != "" {
+		s.Ref = r.rewrite(s.Ref)
+	}
+	if s.Discriminator != nil {
+		for value, ref := range s.Discriminator.Mapping {
+			s.Discriminator.Mapping[value] = r.rewrite(ref)
+		}
+	}
+	for _, prop := range s.Properties {
+		r.schema(prop)
+	}
+	for _, member := range s.OneOf {
+		r.schema(member)
+	}
+	for _, member := range s.AnyOf {
+		r.schema(member)
+	}
+	r.schema(s.Items)
+	r.schema(s.AdditionalProperties)
+}
@@ -0,0 +1,204 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// SplitMode selects how SplitSpec groups operations into documents.
+type SplitMode string
+
+const (
+	// SplitByTag groups operations by their first tag.
+	SplitByTag SplitMode = "tag"
+	// SplitByPrefix groups operations by the first path segment after the
+	// segments every path shares, e.g. "users" for /api/users/{id} when all
+	// paths start with /api.
+	SplitByPrefix SplitMode = "prefix"
+)
+
+// DefaultSplitGroup holds the operations SplitSpec cannot assign to a
+// group: untagged operations, or paths with no segment past the shared
+// prefix.
+const DefaultSplitGroup = "default"
+
+// ParseSplitMode returns the SplitMode named by mode: "tag" or "prefix".
+func ParseSplitMode(mode string) (SplitMode, error) {
+	switch SplitMode(mode) {
+	case SplitByTag, SplitByPrefix:
+		return SplitMode(mode), nil
+	}
+	return "", fmt.Errorf("unsupported split mode %q: use tag or prefix", mode)
+}
+
+// SplitSpec splits spec into one document per group of operations, for
+// teams publishing separate public, partner and internal docs. The schemas
+// and responses of the components move to a shared document, returned as
+// components, which the group documents reference at componentsURL (e.g.
+// "openapi.components.json"). Security schemes stay in every group
+// document, as security requirements cannot point to another document.
+//
+// Webhooks documented with WithWebhooksSection are grouped by tag, and kept
+// in DefaultSplitGroup when splitting by prefix. spec is not modified.
+func SplitSpec(spec *OpenAPISpec, mode SplitMode, componentsURL string) (map[string]*OpenAPISpec, *OpenAPISpec, error) {
+	if _, err := ParseSplitMode(string(mode)); err != nil {
+		return nil, nil, err
+	}
+	// Working on a copy gives every operation its own schemas, so that
+	// rewriting their references leaves the shared components untouched.
+	source, err := cloneSpec(spec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	components := &OpenAPISpec{
+		OpenAPI:    source.OpenAPI,
+		Info:       source.Info,
+		Paths:      map[string]*PathItem{},
+		Components: source.Components,
+	}
+
+	groups := map[string]*OpenAPISpec{}
+	group := func(name string) *OpenAPISpec {
+		doc := groups[name]
+		if doc == nil {
+			doc = &OpenAPISpec{
+				OpenAPI:      source.OpenAPI,
+				Info:         source.Info,
+				Servers:      source.Servers,
+				Paths:        map[string]*PathItem{},
+				ExternalDocs: source.ExternalDocs,
+			}
+			if source.Components != nil && len(source.Components.SecuritySchemes) > 0 {
+				doc.Components = &Components{SecuritySchemes: source.Components.SecuritySchemes}
+			}
+			groups[name] = doc
+		}
+		return doc
+	}
+	refs := newRefRewriter(func(ref string) string { return externalRef(ref, componentsURL) })
+	place := func(items map[string]*PathItem, key, method string, op *Operation) {
+		refs.operation(op)
+		item := items[key]
+		if item == nil {
+			item = &PathItem{}
+			items[key] = item
+		}
+		attachOperation(item, strings.ToLower(method), op)
+	}
+
+	shared := sharedPathSegments(source.Paths)
+	for path, item := range source.Paths {
+		for method, op := range pathItemOperations(item) {
+			name := operationTagGroup(op)
+			if mode == SplitByPrefix {
+				name = pathPrefixGroup(path, shared)
+			}
+			place(group(name).Paths, path, method, op)
+		}
+	}
+	for key, item := range source.Webhooks {
+		for method, op := range pathItemOperations(item) {
+			name := DefaultSplitGroup
+			if mode == SplitByTag {
+				name = operationTagGroup(op)
+			}
+			doc := group(name)
+			if doc.Webhooks == nil {
+				doc.Webhooks = map[string]*PathItem{}
+			}
+			place(doc.Webhooks, key, method, op)
+		}
+	}
+
+	for _, doc := range groups {
+		doc.Tags = usedTags(source.Tags, doc)
+	}
+	return groups, components, nil
+}
+
+// cloneSpec returns a deep copy of the documented parts of spec.
+func cloneSpec(spec *OpenAPISpec) (*OpenAPISpec, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("copy spec: %w", err)
+	}
+	var clone OpenAPISpec
+	if err := json.Unmarshal(data, &clone); err != nil {
+		return nil, fmt.Errorf("copy spec: %w", err)
+	}
+	return &clone, nil
+}
+
+// operationTagGroup returns the group of op when splitting by tag.
+func operationTagGroup(op *Operation) string {
+	if len(op.Tags) == 0 {
+		return DefaultSplitGroup
+	}
+	return op.Tags[0]
+}
+
+// sharedPathSegments returns the number of leading segments all paths have
+// in common. Path parameters never count as shared.
+func sharedPathSegments(paths map[string]*PathItem) int {
+	var common []string
+	first := true
+	for path := range paths {
+		segments := strings.Split(strings.Trim(path, "/"), "/")
+		if first {
+			common, first = segments, false
+			continue
+		}
+		n := 0
+		for n < len(common) && n < len(segments) && common[n] == segments[n] {
+			n++
+		}
+		common = common[:n]
+	}
+	for i, segment := range common {
+		if strings.HasPrefix(segment, "{") {
+			return i
+		}
+	}
+	return len(common)
+}
+
+// pathPrefixGroup returns the group of path when splitting by prefix.
+func pathPrefixGroup(path string, shared int) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if shared >= len(segments) || segments[shared] == "" || strings.HasPrefix(segments[shared], "{") {
+		return DefaultSplitGroup
+	}
+	return segments[shared]
+}
+
+// usedTags returns the tags of tags used by the operations of doc.
+func usedTags(tags []Tag, doc *OpenAPISpec) []Tag {
+	used := map[string]bool{}
+	for _, items := range []map[string]*PathItem{doc.Paths, doc.Webhooks} {
+		for _, item := range items {
+			for _, op := range pathItemOperations(item) {
+				for _, tag := range op.Tags {
+					used[tag] = true
+				}
+			}
+		}
+	}
+	var kept []Tag
+	for _, tag := range tags {
+		if used[tag.Name] {
+			kept = append(kept, tag)
+		}
+	}
+	return kept
+}
+
+// externalRef turns a local component reference into one to the components
+// document at componentsURL.
+func externalRef(ref, componentsURL string) string {
+	if strings.HasPrefix(ref, "#/components/") {
+		return componentsURL + ref
+	}
+	return ref
+}
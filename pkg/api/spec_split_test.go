@@ -0,0 +1,103 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type splitUser struct {
+	Name string `gork:"name"`
+}
+
+type splitUserResponse struct {
+	Body splitUser
+}
+
+func newSplitSpec(t *testing.T) *OpenAPISpec {
+	t.Helper()
+	router, registry, _ := newLoadShedRouter()
+	user := func(context.Context, struct{}) (*splitUserResponse, error) { return &splitUserResponse{}, nil }
+	other := func(context.Context, struct{}) (*loadShedResponse, error) { return &loadShedResponse{}, nil }
+	router.Get("/users", user, WithTags("users"))
+	router.Get("/users/{id}", user, WithTags("users"))
+	router.Get("/orders", other, WithTags("orders"))
+	router.Get("/health", other)
+	spec := GenerateOpenAPI(registry)
+	spec.Tags = []Tag{{Name: "users", Description: "Users"}, {Name: "orders"}}
+	spec.Components.SecuritySchemes = map[string]*SecurityScheme{"bearer": {Type: "http", Scheme: "bearer"}}
+	return spec
+}
+
+func TestSplitSpecByTag(t *testing.T) {
+	spec := newSplitSpec(t)
+	groups, components, err := SplitSpec(spec, SplitByTag, "components.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(groups) != 3 || groups["users"] == nil || groups["orders"] == nil || groups[DefaultSplitGroup] == nil {
+		t.Fatalf("groups = %v, want users, orders and default", groups)
+	}
+	users := groups["users"]
+	if len(users.Paths) != 2 || users.Paths["/api/users/{id}"] == nil {
+		t.Errorf("users paths = %v", users.Paths)
+	}
+	if len(users.Tags) != 1 || users.Tags[0].Description != "Users" {
+		t.Errorf("users tags = %+v, want only the users tag", users.Tags)
+	}
+	if users.Components == nil || users.Components.SecuritySchemes["bearer"] == nil || users.Components.Schemas != nil {
+		t.Errorf("users components = %+v, want only the security schemes", users.Components)
+	}
+
+	data, err := json.Marshal(users)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), `"#/components/`) || !strings.Contains(string(data), `"components.json#/components/schemas/splitUser"`) {
+		t.Errorf("users document does not reference the components document: %s", data)
+	}
+
+	if components.Components.Schemas["splitUser"] == nil || len(components.Paths) != 0 {
+		t.Errorf("components document = %+v", components)
+	}
+	data, err = json.Marshal(components)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "components.json") {
+		t.Errorf("components document references itself externally: %s", data)
+	}
+	if op := spec.Paths["/api/users"].Get; op.Responses["200"].Content["application/json"].Schema.Ref != "#/components/schemas/splitUser" {
+		t.Error("SplitSpec modified the spec")
+	}
+}
+
+func TestSplitSpecByPrefix(t *testing.T) {
+	groups, _, err := SplitSpec(newSplitSpec(t), SplitByPrefix, "components.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int{"users": 2, "orders": 1, "health": 1}
+	if len(groups) != len(want) {
+		t.Fatalf("groups = %v, want %v", groups, want)
+	}
+	for name, paths := range want {
+		if groups[name] == nil || len(groups[name].Paths) != paths {
+			t.Errorf("group %s = %+v, want %d paths", name, groups[name], paths)
+		}
+	}
+}
+
+func TestParseSplitMode(t *testing.T) {
+	if mode, err := ParseSplitMode("prefix"); err != nil || mode != SplitByPrefix {
+		t.Errorf("ParseSplitMode(prefix) = %q, %v", mode, err)
+	}
+	if _, err := ParseSplitMode("service"); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+	if _, _, err := SplitSpec(&OpenAPISpec{}, "service", "components.json"); err == nil {
+		t.Error("expected SplitSpec to reject an unknown mode")
+	}
+}
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OpenAPI versions the generator writes.
+const (
+	OpenAPIVersion31 = "3.1.0"
+	OpenAPIVersion30 = "3.0.3"
+)
+
+// ParseSpecVersion returns the OpenAPI version selected by version: "3.1"
+// or "3.1.0", and "3.0" or "3.0.3" for tooling that cannot read 3.1.
+func ParseSpecVersion(version string) (string, error) {
+	switch version {
+	case "3.1", OpenAPIVersion31:
+		return OpenAPIVersion31, nil
+	case "3.0", OpenAPIVersion30:
+		return OpenAPIVersion30, nil
+	}
+	return "", fmt.Errorf("unsupported OpenAPI version %q: use 3.1 or 3.0", version)
+}
+
+// WithSpecVersion generates the spec in the given OpenAPI version (see
+// ParseSpecVersion). The spec is generated as 3.1 and then converted with
+// ConvertToOpenAPI30 when version is 3.0. It panics on other versions.
+func WithSpecVersion(version string) OpenAPIOption {
+	v, err := ParseSpecVersion(version)
+	if err != nil {
+		panic(err)
+	}
+	return func(spec *OpenAPISpec) { spec.OpenAPI = v }
+}
+
+// isOpenAPI30 reports whether spec is to be written as OpenAPI 3.0.
+func isOpenAPI30(spec *OpenAPISpec) bool {
+	return strings.HasPrefix(spec.OpenAPI, "3.0")
+}
+
+// ConvertToOpenAPI30 rewrites an OpenAPI 3.1 spec in place as OpenAPI 3.0.3:
+//
+//   - "null" types become nullable: true, and null members of oneOf and
+//     anyOf are dropped in favor of nullable on the union;
+//   - exclusiveMinimum and exclusiveMaximum become flags on minimum and
+//     maximum;
+//   - the examples keyword of schemas becomes example, keeping the first;
+//   - the license identifier and the webhooks object are dropped.
+//
+// Converting a spec twice is harmless.
+func ConvertToOpenAPI30(spec *OpenAPISpec) {
+	spec.OpenAPI = OpenAPIVersion30
+	spec.Webhooks = nil
+	if license := spec.Info.License; license != nil && license.Identifier != "" {
+		spec.Info.License = &License{Name: license.Name, URL: license.URL}
+	}
+	seen := map[*Schema]bool{}
+	if spec.Components != nil {
+		for _, schema := range spec.Components.Schemas {
+			downgradeSchema(schema, seen)
+		}
+		for _, resp := range spec.Components.Responses {
+			downgradeResponse(resp, seen)
+		}
+	}
+	for _, item := range spec.Paths {
+		for _, op := range pathItemOperations(item) {
+			downgradeOperation(op, seen)
+		}
+	}
+}
+
+func downgradeOperation(op *Operation, seen map[*Schema]bool) {
+	for i := range op.Parameters {
+		downgradeSchema(op.Parameters[i].Schema, seen)
+	}
+	if op.RequestBody != nil {
+		for _, media := range op.RequestBody.Content {
+			downgradeSchema(media.Schema, seen)
+		}
+	}
+	for _, resp := range op.Responses {
+		downgradeResponse(resp, seen)
+	}
+	for _, callback := range op.Callbacks {
+		for _, item := range callback {
+			for _, callbackOp := range pathItemOperations(item) {
+				downgradeOperation(callbackOp, seen)
+			}
+		}
+	}
+}
+
+func downgradeResponse(resp *Response, seen map[*Schema]bool) {
+	if resp == nil {
+		return
+	}
+	for _, media := range resp.Content {
+		downgradeSchema(media.Schema, seen)
+	}
+	for _, header := range resp.Headers {
+		downgradeSchema(header.Schema, seen)
+	}
+}
+
+// downgradeSchema converts s and the schemas nested in it to OpenAPI 3.0.
+func downgradeSchema(s *Schema, seen map[*Schema]bool) {
+	if s == nil || seen[s] {
+		return
+	}
+	seen[s] = true
+
+	if len(s.Types) > 0 {
+		var types []string
+		for _, t := range s.Types {
+			if t == "null" {
+				s.Nullable = true
+			} else {
+				types = append(types, t)
+			}
+		}
+		s.Types = nil
+		if len(types) == 1 {
+			s.Type = types[0]
+		} else {
+			for _, t := range types {
+				s.AnyOf = append(s.AnyOf, &Schema{Type: t})
+			}
+		}
+	}
+	if s.Type == "null" {
+		s.Type, s.Nullable = "", true
+	}
+	s.OneOf = withoutNullSchemas(s, s.OneOf)
+	s.AnyOf = withoutNullSchemas(s, s.AnyOf)
+
+	if s.ExclusiveMinimum != nil || s.ExclusiveMaximum != nil {
+		s.boundFlags = true
+	}
+	if s.Example == nil && len(s.Examples) > 0 {
+		s.Example = s.Examples[0]
+	}
+	s.Examples = nil
+
+	for _, prop := range s.Properties {
+		downgradeSchema(prop, seen)
+	}
+	for _, member := range s.OneOf {
+		downgradeSchema(member, seen)
+	}
+	for _, member := range s.AnyOf {
+		downgradeSchema(member, seen)
+	}
+	downgradeSchema(s.Items, seen)
+	downgradeSchema(s.AdditionalProperties, seen)
+}
+
+// withoutNullSchemas drops the "null" members of a union, making s nullable
+// instead.
+func withoutNullSchemas(s *Schema, members []*Schema) []*Schema {
+	var kept []*Schema
+	for _, member := range members {
+		if member.Type == "null" && member.Ref == "" {
+			s.Nullable = true
+			continue
+		}
+		kept = append(kept, member)
+	}
+	return kept
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type specVersionManager struct {
+	Name string `gork:"name"`
+}
+
+type specVersionRequest struct {
+	Body struct {
+		Nickname *string             `gork:"nickname"`
+		Manager  *specVersionManager `gork:"manager"`
+	}
+}
+
+func specVersionRegistry() *RouteRegistry {
+	registry := NewRouteRegistry()
+	registry.Register(&RouteInfo{
+		Method:       "POST",
+		Path:         "/people",
+		HandlerName:  "CreatePerson",
+		RequestType:  reflect.TypeOf(specVersionRequest{}),
+		ResponseType: reflect.TypeOf(&renameResponse{}),
+	})
+	return registry
+}
+
+func schemaJSON(t *testing.T, s *Schema) string {
+	t.Helper()
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("marshal schema: %v", err)
+	}
+	return string(data)
+}
+
+func TestSpecVersion31(t *testing.T) {
+	spec := GenerateOpenAPI(specVersionRegistry())
+	body := spec.Components.Schemas["specVersionBody"]
+	if spec.OpenAPI != "3.1.0" || body == nil {
+		t.Fatalf("expected a 3.1.0 spec with the body component, got %q", spec.OpenAPI)
+	}
+	if got, want := schemaJSON(t, body.Properties["nickname"]), `{"type":["string","null"]}`; got != want {
+		t.Errorf("nickname: got %s, want %s", got, want)
+	}
+}
+
+func TestSpecVersion30(t *testing.T) {
+	spec := GenerateOpenAPI(specVersionRegistry(), WithSpecVersion("3.0"))
+	if spec.OpenAPI != "3.0.3" {
+		t.Fatalf("expected a 3.0.3 spec, got %q", spec.OpenAPI)
+	}
+	body := spec.Components.Schemas["specVersionBody"]
+
+	tests := map[string]string{
+		"nickname": `{"type":"string","nullable":true}`,
+		"manager":  `{"anyOf":[{"$ref":"#/components/schemas/specVersionManager"}],"nullable":true}`,
+	}
+	for name, want := range tests {
+		if got := schemaJSON(t, body.Properties[name]); got != want {
+			t.Errorf("%s: got %s, want %s", name, got, want)
+		}
+	}
+
+	parallel := GenerateOpenAPI(specVersionRegistry(), WithSpecVersion("3.0"), WithParallelGeneration(2))
+	if got, want := specJSON(t, parallel), specJSON(t, spec); got != want {
+		t.Errorf("parallel 3.0 spec differs:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestConvertToOpenAPI30(t *testing.T) {
+	zero, max := 0.0, 150.0
+	spec := &OpenAPISpec{
+		OpenAPI: "3.1.0",
+		Paths:   map[string]*PathItem{},
+		Components: &Components{Schemas: map[string]*Schema{
+			"Pet": {
+				Types:    []string{"string", "integer", "null"},
+				Examples: []interface{}{"rex", 7},
+			},
+			"Age":   {Type: "integer", ExclusiveMinimum: &zero, Maximum: &max},
+			"Shape": {OneOf: []*Schema{{Ref: "#/components/schemas/Circle"}, {Type: "null"}}},
+		}},
+	}
+	ConvertToOpenAPI30(spec)
+	ConvertToOpenAPI30(spec)
+
+	if got, want := schemaJSON(t, spec.Components.Schemas["Pet"]), `{"anyOf":[{"type":"string"},{"type":"integer"}],"example":"rex","nullable":true}`; got != want {
+		t.Errorf("Pet: got %s, want %s", got, want)
+	}
+	if got, want := schemaJSON(t, spec.Components.Schemas["Age"]), `{"type":"integer","minimum":0,"maximum":150,"exclusiveMinimum":true}`; got != want {
+		t.Errorf("Age: got %s, want %s", got, want)
+	}
+	if got, want := schemaJSON(t, spec.Components.Schemas["Shape"]), `{"oneOf":[{"$ref":"#/components/schemas/Circle"}],"nullable":true}`; got != want {
+		t.Errorf("Shape: got %s, want %s", got, want)
+	}
+}
+
+func TestSchemaExclusiveBoundsRoundTrip(t *testing.T) {
+	for _, data := range []string{
+		`{"type":"number","minimum":1,"maximum":9,"exclusiveMinimum":true,"exclusiveMaximum":true}`,
+		`{"type":"number","maximum":9,"exclusiveMinimum":1}`,
+	} {
+		var s Schema
+		if err := json.Unmarshal([]byte(data), &s); err != nil {
+			t.Fatalf("unmarshal %s: %v", data, err)
+		}
+		if s.ExclusiveMinimum == nil || *s.ExclusiveMinimum != 1 || s.Minimum != nil {
+			t.Errorf("%s: expected exclusive minimum 1, got %v / %v", data, s.ExclusiveMinimum, s.Minimum)
+		}
+		if got := schemaJSON(t, &s); got != data {
+			t.Errorf("round trip: got %s, want %s", got, data)
+		}
+	}
+}
+
+func TestParseSpecVersion(t *testing.T) {
+	for version, want := range map[string]string{"3.0": "3.0.3", "3.0.3": "3.0.3", "3.1": "3.1.0", "3.1.0": "3.1.0"} {
+		if got, err := ParseSpecVersion(version); err != nil || got != want {
+			t.Errorf("ParseSpecVersion(%q) = %q, %v; want %q", version, got, err, want)
+		}
+	}
+	if _, err := ParseSpecVersion("2.0"); err == nil || !strings.Contains(err.Error(), "unsupported OpenAPI version") {
+		t.Errorf("expected an unsupported version error, got %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"reflect"
+	"strings"
+)
+
+// staticFileRequest documents the file path of Static and SPA routes.
+type staticFileRequest struct {
+	Path struct {
+		Path string `gork:"path"`
+	}
+}
+
+// staticFileResponse documents the files served by Static and SPA routes.
+type staticFileResponse struct {
+	Body StreamBody `contentType:"*/*"`
+}
+
+// WithStaticDocs documents a Static or SPA route in the generated spec,
+// which leaves them out by default.
+func WithStaticDocs() Option {
+	return func(h *HandlerOption) {
+		h.DocumentStatic = true
+	}
+}
+
+// Static serves the files of fsys under prefix, e.g.
+//
+//	router.Static("/assets/", assets)
+//
+// Directories are served with their index.html, if any. Static routes are
+// left out of the generated spec unless WithStaticDocs is given; the
+// net/http middleware of the router and opts is applied.
+func (r *TypedRouter[T]) Static(prefix string, fsys fs.FS, opts ...Option) {
+	basePath := normalizeDocsPath(prefix)
+	files := http.StripPrefix(r.prefix+basePath, http.FileServerFS(fsys))
+	r.registerStatic("Static", basePath, files.ServeHTTP, opts)
+}
+
+// SPA serves a single-page application from fsys under prefix: existing
+// files are served as by Static and every other path, the application's
+// client-side routes, gets the index file, e.g.
+//
+//	router.SPA("/", dist, "index.html")
+//
+// Register it after the API routes when it shares their prefix. Like Static
+// routes, SPA routes are only documented with WithStaticDocs.
+func (r *TypedRouter[T]) SPA(prefix string, fsys fs.FS, index string, opts ...Option) {
+	basePath := normalizeDocsPath(prefix)
+	strip := r.prefix + basePath
+	files := http.StripPrefix(strip, http.FileServerFS(fsys))
+	handler := func(w http.ResponseWriter, req *http.Request) {
+		name := strings.TrimPrefix(path.Clean("/"+strings.TrimPrefix(req.URL.Path, strip)), "/")
+		if info, err := fs.Stat(fsys, name); err == nil && !info.IsDir() {
+			files.ServeHTTP(w, req)
+			return
+		}
+		serveIndex(w, req, fsys, index)
+	}
+	r.registerStatic("SPA", basePath, handler, opts)
+}
+
+// serveIndex writes the index file of an SPA. http.ServeFileFS is avoided
+// as it redirects requests for ".../index.html".
+func serveIndex(w http.ResponseWriter, req *http.Request, fsys fs.FS, index string) {
+	f, err := fsys.Open(index)
+	if err != nil {
+		http.NotFound(w, req)
+		return
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	content, ok := f.(io.ReadSeeker)
+	if err != nil || !ok {
+		http.NotFound(w, req)
+		return
+	}
+	http.ServeContent(w, req, index, info.ModTime(), content)
+}
+
+// registerStatic registers a catch-all GET route under basePath, recording
+// it in the registry when it is documented.
+func (r *TypedRouter[T]) registerStatic(kind, basePath string, handler http.HandlerFunc, opts []Option) {
+	options := &HandlerOption{}
+	for _, o := range append(append([]Option{}, r.middleware...), opts...) {
+		o(options)
+	}
+	if len(options.Middleware) > 0 {
+		handler = applyMiddleware(options.Middleware, handler)
+	}
+
+	if options.DocumentStatic {
+		if len(options.Tags) == 0 {
+			options.Tags = []string{"static"}
+		}
+		options.PathPatterns = map[string]string{"path": ".*"}
+		r.registry.Register(&RouteInfo{
+			Method:       http.MethodGet,
+			Path:         r.prefix + basePath + "/{path}",
+			Handler:      handler,
+			HandlerName:  kind + staticOperationSuffix(basePath),
+			RequestType:  reflect.TypeOf(staticFileRequest{}),
+			ResponseType: reflect.TypeOf(staticFileResponse{}),
+			Options:      options,
+		})
+	}
+
+	if r.registerFn != nil {
+		r.registerFn(http.MethodGet, basePath+"/*", handler, nil)
+	}
+}
+
+// staticOperationSuffix turns a base path like "/assets/img" into "AssetsImg"
+// so several static routes get distinct operation IDs.
+func staticOperationSuffix(basePath string) string {
+	var b strings.Builder
+	for _, segment := range strings.Split(basePath, "/") {
+		if segment != "" {
+			b.WriteString(strings.ToUpper(segment[:1]) + segment[1:])
+		}
+	}
+	return b.String()
+}
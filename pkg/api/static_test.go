@@ -0,0 +1,80 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+)
+
+var staticTestFS = fstest.MapFS{
+	"index.html":   {Data: []byte("<app>")},
+	"css/site.css": {Data: []byte("body{}")},
+}
+
+func TestStatic(t *testing.T) {
+	router, registry, handlers := newLoadShedRouter()
+	router.Static("/assets/", staticTestFS)
+
+	h := handlers["GET /assets/*"]
+	if h == nil {
+		t.Fatalf("expected a catch-all handler, got %v", handlers)
+	}
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/api/assets/css/site.css", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "body{}" {
+		t.Errorf("status = %d, body = %q", w.Code, w.Body)
+	}
+	w = httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/api/assets/missing.css", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("missing file: status = %d", w.Code)
+	}
+
+	if routes := registry.GetRoutes(); len(routes) != 0 {
+		t.Errorf("static routes must not be documented by default, got %+v", routes)
+	}
+}
+
+func TestSPA(t *testing.T) {
+	router, _, handlers := newLoadShedRouter()
+	router.SPA("/", staticTestFS, "index.html")
+
+	h := handlers["GET /*"]
+	if h == nil {
+		t.Fatalf("expected a catch-all handler, got %v", handlers)
+	}
+	tests := map[string]string{
+		"/api/css/site.css":    "body{}",
+		"/api/users/42/edit":   "<app>",
+		"/api/":                "<app>",
+		"/api/css/":            "<app>",
+		"/api/../../etc/hosts": "<app>",
+	}
+	for target, want := range tests {
+		w := httptest.NewRecorder()
+		h(w, httptest.NewRequest(http.MethodGet, target, nil))
+		if w.Code != http.StatusOK || w.Body.String() != want {
+			t.Errorf("%s: status = %d, body = %q, want %q", target, w.Code, w.Body, want)
+		}
+	}
+}
+
+func TestStaticDocs(t *testing.T) {
+	router, registry, _ := newLoadShedRouter()
+	router.Static("/assets", staticTestFS, WithStaticDocs())
+
+	op := GenerateOpenAPI(registry).Paths["/api/assets/{path}"]
+	if op == nil || op.Get == nil {
+		t.Fatal("expected documented static route")
+	}
+	if op.Get.OperationID != "StaticAssets" || len(op.Get.Tags) != 1 || op.Get.Tags[0] != "static" {
+		t.Errorf("operationId = %q, tags = %v", op.Get.OperationID, op.Get.Tags)
+	}
+	if len(op.Get.Parameters) != 1 || op.Get.Parameters[0].Schema.Pattern != ".*" {
+		t.Errorf("expected catch-all path parameter, got %+v", op.Get.Parameters)
+	}
+	if op.Get.Responses["200"].Content["*/*"] == nil {
+		t.Errorf("expected binary response, got %v", op.Get.Responses["200"].Content)
+	}
+}
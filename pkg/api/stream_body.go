@@ -0,0 +1,107 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+)
+
+// ContentTypeOctetStream is the media type used for streamed bodies that do
+// not declare one.
+const ContentTypeOctetStream = "application/octet-stream"
+
+// StreamBody is a Body section that is streamed instead of buffered.
+//
+// In a request, Reader reads directly from the request body and ContentType
+// and ContentLength are taken from the request headers (ContentLength is -1
+// when unknown). In a response, Reader is copied to the client, closing it
+// afterwards when it implements io.Closer; ContentType defaults to
+// application/octet-stream and ContentLength is sent when positive.
+//
+// A Body section may also be declared as a plain io.Reader. The media type
+// documented in the OpenAPI spec is set with a contentType tag on the Body
+// field:
+//
+//	Body api.StreamBody `contentType:"application/pdf"`
+type StreamBody struct {
+	Reader        io.Reader
+	ContentType   string
+	ContentLength int64
+}
+
+var (
+	streamBodyType = reflect.TypeOf(StreamBody{})
+	ioReaderType   = reflect.TypeOf((*io.Reader)(nil)).Elem()
+)
+
+// isStreamBodyType reports whether a Body section of type t is streamed.
+func isStreamBodyType(t reflect.Type) bool {
+	return t == streamBodyType || t == ioReaderType
+}
+
+// streamContentType returns the media type documented for a streamed Body field.
+func streamContentType(field reflect.StructField) string {
+	if ct := field.Tag.Get("contentType"); ct != "" {
+		return ct
+	}
+	return ContentTypeOctetStream
+}
+
+// binaryMediaType documents a streamed body as a binary string.
+func binaryMediaType(field reflect.StructField) map[string]*MediaType {
+	return map[string]*MediaType{
+		streamContentType(field): {Schema: &Schema{Type: "string", Format: "binary"}},
+	}
+}
+
+// setStreamBody hands the unread request body to a streamed Body section.
+func setStreamBody(sectionValue reflect.Value, r *http.Request) {
+	body := r.Body
+	if body == nil {
+		body = http.NoBody
+	}
+	if sectionValue.Type() == ioReaderType {
+		sectionValue.Set(reflect.ValueOf(body))
+		return
+	}
+	sectionValue.Set(reflect.ValueOf(StreamBody{
+		Reader:        body,
+		ContentType:   r.Header.Get("Content-Type"),
+		ContentLength: r.ContentLength,
+	}))
+}
+
+// writeStreamBody copies a streamed response Body to w, using status when
+// it is non-zero.
+func writeStreamBody(w http.ResponseWriter, bodyValue reflect.Value, status int) {
+	var stream StreamBody
+	if bodyValue.Type() == ioReaderType {
+		if !bodyValue.IsNil() {
+			stream.Reader = bodyValue.Interface().(io.Reader)
+		}
+	} else {
+		stream = bodyValue.Interface().(StreamBody)
+	}
+
+	if stream.ContentType == "" {
+		stream.ContentType = ContentTypeOctetStream
+	}
+	w.Header().Set("Content-Type", stream.ContentType)
+	if stream.ContentLength > 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(stream.ContentLength, 10))
+	}
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	if stream.Reader == nil {
+		return
+	}
+	if closer, ok := stream.Reader.(io.Closer); ok {
+		defer func() { _ = closer.Close() }()
+	}
+	// Headers are already sent once copying starts, so a failed copy can
+	// only be reported by the truncated body.
+	_, _ = io.Copy(w, stream.Reader)
+}
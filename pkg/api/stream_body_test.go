@@ -0,0 +1,113 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type streamUploadRequest struct {
+	Body StreamBody `contentType:"application/pdf"`
+}
+
+type streamDownloadRequest struct {
+	Body io.Reader
+}
+
+type streamDownloadResponse struct {
+	Headers struct {
+		Disposition string `gork:"Content-Disposition"`
+	}
+	Body StreamBody `contentType:"text/csv"`
+}
+
+type closeTracker struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeTracker) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestStreamBodyRequestAndResponse(t *testing.T) {
+	var got string
+	var gotType string
+	var gotLength int64
+	tracker := &closeTracker{Reader: strings.NewReader("a,b\n1,2\n")}
+	handler := func(_ context.Context, req streamUploadRequest) (*streamDownloadResponse, error) {
+		data, err := io.ReadAll(req.Body.Reader)
+		if err != nil {
+			return nil, err
+		}
+		got, gotType, gotLength = string(data), req.Body.ContentType, req.Body.ContentLength
+		resp := &streamDownloadResponse{Body: StreamBody{Reader: tracker, ContentType: "text/csv", ContentLength: 8}}
+		resp.Headers.Disposition = "attachment"
+		return resp, nil
+	}
+
+	h, _ := NewConventionHandlerFactory().CreateHandler(&mockConventionParameterAdapter{}, handler)
+	r := httptest.NewRequest(http.MethodPost, "/reports", strings.NewReader("%PDF-1.7"))
+	r.Header.Set("Content-Type", "application/pdf")
+	w := httptest.NewRecorder()
+	h(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d: %s", w.Code, w.Body.String())
+	}
+	if got != "%PDF-1.7" || gotType != "application/pdf" || gotLength != 8 {
+		t.Errorf("unexpected request stream %q %q %d", got, gotType, gotLength)
+	}
+	if w.Body.String() != "a,b\n1,2\n" {
+		t.Errorf("unexpected response body %q", w.Body.String())
+	}
+	if w.Header().Get("Content-Type") != "text/csv" || w.Header().Get("Content-Length") != "8" {
+		t.Errorf("unexpected response headers %v", w.Header())
+	}
+	if !tracker.closed {
+		t.Error("expected response reader to be closed")
+	}
+}
+
+func TestStreamBodyPlainReader(t *testing.T) {
+	handler := func(_ context.Context, req streamDownloadRequest) (*struct{ Body io.Reader }, error) {
+		return &struct{ Body io.Reader }{Body: req.Body}, nil
+	}
+
+	h, _ := NewConventionHandlerFactory().CreateHandler(&mockConventionParameterAdapter{}, handler)
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodPut, "/echo", strings.NewReader("raw bytes")))
+
+	if w.Body.String() != "raw bytes" || w.Header().Get("Content-Type") != ContentTypeOctetStream {
+		t.Errorf("unexpected echo %q with %v", w.Body.String(), w.Header())
+	}
+}
+
+func TestStreamBodyOpenAPI(t *testing.T) {
+	registry := NewRouteRegistry()
+	router := NewTypedRouter[*struct{}](nil, registry, "", nil, &mockTypedRouterAdapter{}, nil)
+	router.Post("/reports", func(context.Context, streamUploadRequest) (*streamDownloadResponse, error) { return nil, nil })
+	router.Put("/echo", func(context.Context, streamDownloadRequest) (*struct{ Body io.Reader }, error) { return nil, nil })
+
+	spec := GenerateOpenAPI(registry)
+	reports := spec.Paths["/reports"].Post
+	if media := reports.RequestBody.Content["application/pdf"]; media == nil || media.Schema.Format != "binary" {
+		t.Errorf("expected binary pdf request body, got %v", reports.RequestBody.Content)
+	}
+	ok := reports.Responses["200"]
+	if media := ok.Content["text/csv"]; media == nil || media.Schema.Type != "string" || media.Schema.Format != "binary" {
+		t.Errorf("expected binary csv response, got %v", ok.Content)
+	}
+	if ok.Headers["Content-Disposition"] == nil {
+		t.Errorf("expected response headers to be kept, got %v", ok.Headers)
+	}
+
+	echo := spec.Paths["/echo"].Put
+	if echo.RequestBody.Content[ContentTypeOctetStream] == nil || echo.Responses["200"].Content[ContentTypeOctetStream] == nil {
+		t.Errorf("expected octet-stream defaults, got %v / %v", echo.RequestBody.Content, echo.Responses["200"].Content)
+	}
+}
@@ -53,20 +53,35 @@ func (b *StructSchemaBuilder) BuildSchema(t reflect.Type, registry map[string]*S
 		Properties: map[string]*Schema{},
 	}
 
+	// Reserve the component name while the fields are processed so that
+	// self-referential types (type Node struct{ Children []Node }) resolve
+	// to a $ref instead of recursing forever.
+	var reserved string
+	if t.Name() != "" {
+		reserved = uniqueSchemaNameForType(t, registry)
+		if _, taken := registry[reserved]; reserved != "" && !taken {
+			s.goType = t
+			registry[reserved] = s
+		} else {
+			reserved = ""
+		}
+	}
+
 	for i := 0; i < t.NumField(); i++ {
 		f := t.Field(i)
-		if f.PkgPath != "" { // unexported
-			continue
-		}
 
-		// Handle embedded structs
-		if f.Anonymous && f.Type.Kind() == reflect.Struct && f.Tag.Get("json") == "" {
+		// Handle embedded structs, whose fields are promoted even when the
+		// struct type is unexported
+		if _, ok := embeddedStructType(f); ok {
 			if err := b.embeddedStructProcessor.ProcessEmbedded(f, s, registry); err != nil {
 				// Log error but continue processing other fields
 				continue
 			}
 			continue
 		}
+		if f.PkgPath != "" { // unexported
+			continue
+		}
 
 		// Process regular field
 		if err := b.fieldProcessor.ProcessField(f, s, registry); err != nil {
@@ -75,7 +90,11 @@ func (b *StructSchemaBuilder) BuildSchema(t reflect.Type, registry map[string]*S
 		}
 	}
 
-	// Register named types
+	// Register named types; the name reserved above is still the first
+	// free one and will be picked again.
+	if reserved != "" {
+		delete(registry, reserved)
+	}
 	return b.typeRegistrar.RegisterType(t, s, registry)
 }
 
@@ -104,8 +123,17 @@ func (r *defaultTypeRegistrar) RegisterType(t reflect.Type, schema *Schema, regi
 		// Pick a human-friendly unique name to avoid collisions
 		unique := uniqueSchemaNameForType(t, registry)
 		schema.Title = unique
+		schema.goType = t
 		registry[unique] = schema
 		return &Schema{Ref: "#/components/schemas/" + unique}
 	}
 	return schema
 }
+
+// inlineTypeRegistrar leaves schemas inline, for embedded structs whose
+// fields are promoted into the embedding struct's schema.
+type inlineTypeRegistrar struct{}
+
+func (r inlineTypeRegistrar) RegisterType(_ reflect.Type, schema *Schema, _ map[string]*Schema) *Schema {
+	return schema
+}
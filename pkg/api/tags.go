@@ -0,0 +1,63 @@
+package api
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Tag describes a tag of the spec's top-level tags array.
+type Tag struct {
+	Name         string        `json:"name"`
+	Description  string        `json:"description,omitempty"`
+	ExternalDocs *ExternalDocs `json:"externalDocs,omitempty"`
+}
+
+// tags holds the registered tags in registration order.
+var tags = struct {
+	sync.RWMutex
+	list []Tag
+}{}
+
+// RegisterTag declares a tag used with WithTags. Registered tags are listed
+// in the spec's top-level tags array in registration order, which
+// documentation UIs follow to order their sections; externalDocsURL may be
+// empty. Registering a tag again replaces its description and links but
+// keeps its place.
+//
+// Once a tag is registered, operations using a tag that is not are reported
+// as warnings in error-collect mode (see WithGenerationErrors) and by
+// `gork openapi generate`.
+func RegisterTag(name, description, externalDocsURL string) {
+	tag := Tag{Name: name, Description: description}
+	if externalDocsURL != "" {
+		tag.ExternalDocs = &ExternalDocs{URL: externalDocsURL}
+	}
+	tags.Lock()
+	defer tags.Unlock()
+	if i := slices.IndexFunc(tags.list, func(t Tag) bool { return t.Name == name }); i >= 0 {
+		tags.list[i] = tag
+		return
+	}
+	tags.list = append(tags.list, tag)
+}
+
+// registeredTags returns a copy of the registered tags.
+func registeredTags() []Tag {
+	tags.RLock()
+	defer tags.RUnlock()
+	return slices.Clone(tags.list)
+}
+
+// checkOperationTags warns about the tags of op that were not registered.
+// Nothing is reported while no tag is registered at all.
+func checkOperationTags(spec *OpenAPISpec, route *RouteInfo, op *Operation) {
+	if spec.generationErrors == nil || len(spec.Tags) == 0 {
+		return
+	}
+	for _, name := range op.Tags {
+		if !slices.ContainsFunc(spec.Tags, func(t Tag) bool { return t.Name == name }) {
+			spec.generationErrors.warn(route, fmt.Sprintf("tag %q is not registered with api.RegisterTag", name))
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRegisterTag(t *testing.T) {
+	saved := registeredTags()
+	t.Cleanup(func() {
+		tags.Lock()
+		tags.list = saved
+		tags.Unlock()
+	})
+	tags.Lock()
+	tags.list = nil
+	tags.Unlock()
+
+	registry := NewRouteRegistry()
+	for _, route := range []struct {
+		path string
+		tags []string
+	}{{"/users", []string{"users"}}, {"/orders", []string{"orders", "billing"}}} {
+		registry.Register(&RouteInfo{
+			Method:       "GET",
+			Path:         route.path,
+			HandlerName:  "List",
+			RequestType:  reflect.TypeOf(renameRequest{}),
+			ResponseType: reflect.TypeOf(&renameResponse{}),
+			Options:      &HandlerOption{Tags: route.tags},
+		})
+	}
+
+	var errs GenerationError
+	GenerateOpenAPI(registry, WithGenerationErrors(&errs))
+	if len(errs.Warnings) != 0 {
+		t.Errorf("expected no warnings without registered tags, got %v", errs.Warnings)
+	}
+
+	RegisterTag("users", "User management", "")
+	RegisterTag("orders", "Orders", "https://docs.example.com/orders")
+	RegisterTag("users", "User management and profiles", "")
+
+	for _, parallel := range []bool{false, true} {
+		errs = GenerationError{}
+		opts := []OpenAPIOption{WithGenerationErrors(&errs)}
+		if parallel {
+			opts = append(opts, WithParallelGeneration(2))
+		}
+		spec := GenerateOpenAPI(registry, opts...)
+
+		want := []Tag{
+			{Name: "users", Description: "User management and profiles"},
+			{Name: "orders", Description: "Orders", ExternalDocs: &ExternalDocs{URL: "https://docs.example.com/orders"}},
+		}
+		if !reflect.DeepEqual(spec.Tags, want) {
+			t.Errorf("expected tags %+v, got %+v", want, spec.Tags)
+		}
+		wantWarnings := []RouteGenerationError{{
+			Operation: "GET /orders",
+			Handler:   "List",
+			Message:   `tag "billing" is not registered with api.RegisterTag`,
+		}}
+		if !reflect.DeepEqual(errs.Warnings, wantWarnings) {
+			t.Errorf("expected warnings %v, got %v", wantWarnings, errs.Warnings)
+		}
+		if len(errs.Routes) != 0 {
+			t.Errorf("expected warnings not to fail routes, got %v", errs.Routes)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"encoding"
+	"reflect"
+	"time"
+)
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	durationType        = reflect.TypeOf(time.Duration(0))
+)
+
+// textFormats maps well-known text types to their OpenAPI string format.
+var textFormats = map[string]string{
+	"time.Time":                   "date-time",
+	"time.Duration":               "duration",
+	"net.IP":                      "ip",
+	"net/netip.Addr":              "ip",
+	"net/netip.AddrPort":          "ip-port",
+	"net/netip.Prefix":            "cidr",
+	"github.com/google/uuid.UUID": "uuid",
+	"github.com/gofrs/uuid.UUID":  "uuid",
+}
+
+// isTextType reports whether values of t travel as text: t marshals to text,
+// *t unmarshals from it or t is a time.Duration, sent like "1m30s".
+func isTextType(t reflect.Type) bool {
+	if t == durationType {
+		return true
+	}
+	if t.Kind() == reflect.Ptr || t.Kind() == reflect.Interface {
+		return false
+	}
+	return t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// textFormat returns the string format of a text type, "uuid" for any type
+// named UUID and no format for the rest.
+func textFormat(t reflect.Type) string {
+	if format, ok := textFormats[t.PkgPath()+"."+t.Name()]; ok {
+		return format
+	}
+	if t.Name() == "UUID" {
+		return "uuid"
+	}
+	return ""
+}
+
+// TextTypeHandler documents text marshalers, such as time.Time, uuid.UUID
+// and netip.Addr, and durations as strings.
+type TextTypeHandler struct{}
+
+// CanHandle returns true if this handler can process the given type.
+func (h *TextTypeHandler) CanHandle(t reflect.Type) bool {
+	return isTextType(t)
+}
+
+// GenerateSchema generates a string schema with the type's format.
+func (h *TextTypeHandler) GenerateSchema(t reflect.Type, _ map[string]*Schema, _ bool) *Schema {
+	return &Schema{Type: "string", Format: textFormat(t)}
+}
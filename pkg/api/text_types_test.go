@@ -0,0 +1,103 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// textTestOrderID only accepts IDs starting with "ord_".
+type textTestOrderID string
+
+func (id *textTestOrderID) UnmarshalText(text []byte) error {
+	if !strings.HasPrefix(string(text), "ord_") {
+		return fmt.Errorf("invalid order ID %q", text)
+	}
+	*id = textTestOrderID(text)
+	return nil
+}
+
+func (id textTestOrderID) MarshalText() ([]byte, error) { return []byte(id), nil }
+
+type textTestRequest struct {
+	Path struct {
+		ID textTestOrderID `gork:"id"`
+	}
+	Query struct {
+		Client netip.Addr  `gork:"client"`
+		Since  time.Time   `gork:"since"`
+		Peer   *netip.Addr `gork:"peer"`
+	}
+	Headers struct {
+		Network netip.Prefix `gork:"X-Network"`
+	}
+	Cookies struct {
+		Origin netip.AddrPort `gork:"origin"`
+	}
+}
+
+func TestTextUnmarshalerParameters(t *testing.T) {
+	var got textTestRequest
+	adapter := &mockConventionParameterAdapter{
+		pathParams:  map[string]string{"id": "ord_1"},
+		queryParams: map[string]string{"client": "10.0.0.1", "since": "2024-01-02T03:04:05Z", "peer": "::1"},
+		headers:     map[string]string{"X-Network": "10.0.0.0/8"},
+		cookies:     map[string]string{"origin": "192.168.1.1:443"},
+	}
+	parse := func() error {
+		got = textTestRequest{}
+		req := httptest.NewRequest(http.MethodGet, "/api/orders", nil)
+		return NewConventionParser().ParseRequest(context.Background(), req, reflect.ValueOf(&got), adapter)
+	}
+	if err := parse(); err != nil {
+		t.Fatal(err)
+	}
+	if got.Path.ID != "ord_1" || got.Query.Client != netip.MustParseAddr("10.0.0.1") || got.Query.Peer == nil || !got.Query.Peer.Is6() {
+		t.Errorf("unexpected path and query %+v %+v", got.Path, got.Query)
+	}
+	if got.Headers.Network.Bits() != 8 || got.Cookies.Origin.Port() != 443 {
+		t.Errorf("unexpected header and cookie %+v %+v", got.Headers, got.Cookies)
+	}
+
+	adapter.queryParams["client"] = "nope"
+	if err := parse(); err == nil || !strings.Contains(err.Error(), "query parameter client") {
+		t.Errorf("expected an invalid address error, got %v", err)
+	}
+
+	adapter.queryParams["client"] = "10.0.0.1"
+	adapter.pathParams["id"] = "42"
+	if err := parse(); err == nil || !strings.Contains(err.Error(), `invalid order ID "42"`) {
+		t.Errorf("expected the unmarshaler's error, got %v", err)
+	}
+}
+
+func TestTextTypeSchemas(t *testing.T) {
+	router, registry, _ := newLoadShedRouter()
+	router.Get("/orders/{id}", func(context.Context, textTestRequest) (*loadShedResponse, error) { return nil, nil })
+	data, err := json.Marshal(GenerateOpenAPI(registry))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`{"in":"path","name":"id","required":true,"schema":{"type":"string"}}`,
+		`{"in":"query","name":"client","required":false,"schema":{"format":"ip","type":"string"}}`,
+		`{"in":"query","name":"since","required":false,"schema":{"format":"date-time","type":"string"}}`,
+		`{"in":"query","name":"peer","required":false,"schema":{"format":"ip","type":"string"}}`,
+		`{"in":"header","name":"X-Network","required":false,"schema":{"format":"cidr","type":"string"}}`,
+		`{"in":"cookie","name":"origin","required":false,"schema":{"format":"ip-port","type":"string"}}`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("spec is missing %s:\n%s", want, data)
+		}
+	}
+	if strings.Contains(string(data), `"Addr"`) {
+		t.Error("text types must not become object components")
+	}
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// WithTimeout cancels the handler's context after d and answers 504 Gateway
+// Timeout with the standard error body if the handler has not returned by
+// then. The response is documented in the generated OpenAPI operation.
+//
+// The response is buffered until the handler returns, so streamed responses
+// are only sent once they are complete.
+func WithTimeout(d time.Duration) Option {
+	return func(h *HandlerOption) {
+		h.Timeout = d
+	}
+}
+
+// withTimeout runs next with a deadline of d, answering 504 when it is
+// exceeded. Writes made by next afterwards fail with http.ErrHandlerTimeout.
+func withTimeout(d time.Duration, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), d)
+		defer cancel()
+
+		tw := &timeoutWriter{outer: w, header: http.Header{}}
+		done := make(chan struct{})
+		panicked := make(chan any, 1)
+		go func() {
+			defer func() {
+				if p := recover(); p != nil {
+					panicked <- p
+				}
+			}()
+			next(tw, r.WithContext(ctx))
+			close(done)
+		}()
+
+		select {
+		case p := <-panicked:
+			// Re-panic in the serving goroutine so the server's own
+			// recovery sees it.
+			panic(p)
+		case <-done:
+			tw.mu.Lock()
+			defer tw.mu.Unlock()
+			for name, values := range tw.header {
+				w.Header()[name] = values
+			}
+			if tw.code == 0 {
+				tw.code = http.StatusOK
+			}
+			w.WriteHeader(tw.code)
+			_, _ = w.Write(tw.body.Bytes())
+		case <-ctx.Done():
+			tw.mu.Lock()
+			defer tw.mu.Unlock()
+			tw.timedOut = true
+			// A client gone away gets no answer.
+			if ctx.Err() == context.DeadlineExceeded {
+				writeError(w, http.StatusGatewayTimeout, "handler timed out after "+d.String())
+			}
+		}
+	}
+}
+
+// timeoutWriter buffers the response of a handler running with a timeout.
+type timeoutWriter struct {
+	// outer is only used to find marker writers; see findResponseWriter.
+	outer    http.ResponseWriter
+	mu       sync.Mutex
+	header   http.Header
+	body     bytes.Buffer
+	code     int
+	timedOut bool
+}
+
+func (tw *timeoutWriter) Header() http.Header { return tw.header }
+
+func (tw *timeoutWriter) WriteHeader(code int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut || tw.code != 0 {
+		return
+	}
+	tw.code = code
+}
+
+func (tw *timeoutWriter) Write(p []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	if tw.code == 0 {
+		tw.code = http.StatusOK
+	}
+	return tw.body.Write(p)
+}
+
+// addGatewayTimeoutResponse documents the 504 answered by WithTimeout.
+func addGatewayTimeoutResponse(operation *Operation, components *Components) {
+	if components.Responses == nil {
+		components.Responses = map[string]*Response{}
+	}
+	if _, ok := components.Responses["GatewayTimeout"]; !ok {
+		components.Responses["GatewayTimeout"] = &Response{
+			Description: "Gateway Timeout - The request took too long to process",
+			Content: map[string]*MediaType{
+				"application/json": {Schema: &Schema{Ref: "#/components/schemas/ErrorResponse"}},
+			},
+		}
+	}
+	operation.Responses["504"] = &Response{Ref: "#/components/responses/GatewayTimeout"}
+}
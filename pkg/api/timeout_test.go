@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTimeout(t *testing.T) {
+	canceled := make(chan error, 1)
+	router, registry, handlers := newLoadShedRouter(WithTimeout(20 * time.Millisecond))
+	router.Get("/slow", func(ctx context.Context, _ struct{}) (*loadShedResponse, error) {
+		<-ctx.Done()
+		canceled <- ctx.Err()
+		return nil, ctx.Err()
+	})
+	router.Get("/fast", func(context.Context, struct{}) (*loadShedResponse, error) {
+		resp := &loadShedResponse{}
+		resp.Body.Name = "quick"
+		return resp, nil
+	})
+
+	w := httptest.NewRecorder()
+	handlers["GET /slow"](w, httptest.NewRequest(http.MethodGet, "/api/slow", nil))
+	var body ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); w.Code != http.StatusGatewayTimeout || err != nil || body.Error == "" {
+		t.Errorf("status = %d, body = %s", w.Code, w.Body)
+	}
+	select {
+	case err := <-canceled:
+		if err != context.DeadlineExceeded {
+			t.Errorf("handler context error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("handler context was not canceled")
+	}
+
+	w = httptest.NewRecorder()
+	handlers["GET /fast"](w, httptest.NewRequest(http.MethodGet, "/api/fast", nil))
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/json" || w.Body.Len() == 0 {
+		t.Errorf("fast handler: status = %d, headers = %v, body = %q", w.Code, w.Header(), w.Body)
+	}
+
+	spec := GenerateOpenAPI(registry)
+	if resp := spec.Paths["/api/fast"].Get.Responses["504"]; resp == nil || spec.Components.Responses["GatewayTimeout"] == nil {
+		t.Errorf("expected documented 504, got %v", spec.Paths["/api/fast"].Get.Responses)
+	}
+}
+
+func TestTimeoutWriterAfterTimeout(t *testing.T) {
+	tw := &timeoutWriter{header: http.Header{}, timedOut: true}
+	if _, err := tw.Write([]byte("late")); err != http.ErrHandlerTimeout {
+		t.Errorf("Write after timeout: err = %v", err)
+	}
+}
@@ -76,22 +76,101 @@ func (r *TypedRouter[T]) Register(method, path string, handler interface{}, opts
 
 	allOpts := append([]Option{}, r.middleware...)
 	allOpts = append(allOpts, opts...)
+	r.register(method, path, handler, allOpts)
+}
+
+// register registers a route with the complete list of its options.
+func (r *TypedRouter[T]) register(method, path string, handler interface{}, allOpts []Option) {
 	httpHandler, info := createHandlerFromAny(r.adapter, handler, allOpts...)
 
 	// Validate that Body sections are not used with read-only HTTP methods
 	validateBodyUsageForMethod(method, info.RequestType)
+	validateResponseStatus(info.ResponseType)
 
 	// Fill remaining route information.
 	info.Method = method
 	info.Path = r.prefix + path
+	validateRouteExamples(info)
 
 	// Register metadata first so that generators can discover the route even
 	// if the underlying router delays internal registration.
 	r.registry.Register(info)
 
+	// Bound the time spent parsing the request and running the handler.
+	if info.Options != nil && info.Options.Timeout > 0 {
+		httpHandler = withTimeout(info.Options.Timeout, httpHandler)
+	}
+
+	if info.Options != nil && info.Options.Decompression != nil {
+		httpHandler = info.Options.Decompression.wrap(httpHandler)
+	}
+
+	// Limit the body as sent, before it is decompressed.
+	if info.Options != nil && info.Options.MaxBodySize > 0 {
+		httpHandler = limitBody(info.Options.MaxBodySize, httpHandler)
+	}
+
+	if info.Options != nil && info.Options.Audience != "" {
+		httpHandler = wrapAudience(info.Options.Audience, httpHandler)
+	}
+
+	// Cache inside authentication so that cached responses are only served
+	// to authenticated requests. Auth sections are verified by the handler,
+	// inside the cache, so their routes are never cached in memory.
+	if info.Options != nil && info.Options.Cache != nil {
+		policy := info.Options.Cache
+		if hasAuthSection(info.RequestType) && policy.MemoryEntries > 0 {
+			headersOnly := *policy
+			headersOnly.MemoryEntries = 0
+			policy = &headersOnly
+		}
+		httpHandler = withCache(policy, httpHandler)
+	}
+
+	// Authenticate before the body is read.
+	if requirements := namedSecurity(info.Options); len(requirements) > 0 {
+		httpHandler = wrapAuthentication(r.registry, requirements, httpHandler)
+	}
+
+	if info.Options != nil && info.Options.RateLimiter != nil {
+		httpHandler = limitRate(info.Options.RateLimiter, httpHandler)
+	}
+
+	// Shed load before any parsing or validation takes place.
+	if info.Options != nil && info.Options.LoadShedder != nil {
+		httpHandler = info.Options.LoadShedder.wrap(info, httpHandler)
+	}
+
+	if info.Options != nil && len(info.Options.Middleware) > 0 {
+		httpHandler = applyMiddleware(info.Options.Middleware, httpHandler)
+	}
+
+	if info.Options != nil && info.Options.ProblemJSON {
+		httpHandler = withProblemJSON(httpHandler)
+	}
+
+	// Add CORS headers to every response, errors included.
+	if info.Options != nil && info.Options.CORS != nil {
+		httpHandler = info.Options.CORS.wrap(httpHandler)
+	}
+
+	// Assign the ID first so every response, errors included, carries it.
+	if info.Options != nil && info.Options.RequestID {
+		httpHandler = withRequestID(httpHandler)
+	}
+
+	// Observe every request, including the ones rejected before parsing.
+	if info.Options != nil && info.Options.Metrics != nil {
+		httpHandler = withMetrics(info.Options.Metrics, info, httpHandler)
+	}
+
+	// Expose the route to middleware and handlers alike.
+	httpHandler = withRoute(info, httpHandler)
+
 	if r.registerFn != nil {
 		r.registerFn(method, path, httpHandler, info)
 	}
+	r.registerAutoMethods(method, path, httpHandler, info)
 }
 
 // Get registers a GET route with the given path and handler.
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Params holds the path parameters of a URL built with RouteRegistry.URL,
+// keyed by their name in the route's path template.
+type Params map[string]any
+
+// Query holds the query parameters of a URL built with RouteRegistry.URL.
+// Slice values are sent as repeated parameters.
+type Query map[string]any
+
+// URL builds the URL of the route whose handler is named name, the
+// operation ID of the generated spec, from its path template:
+//
+//	link, err := registry.URL("GetUser", api.Params{"user_id": id}, api.Query{"tab": "billing"})
+//	// "/v1/users/42?tab=billing"
+//
+// Values are formatted like parameters are parsed: text marshalers such as
+// time.Time with MarshalText, other values with fmt.Sprint. Path values are
+// escaped as a single segment, except for catch-all parameters ("{path...}"
+// or chi's "/*"), whose values keep their slashes. Every placeholder of the template needs a
+// value and every value a placeholder, so links break loudly when a route
+// changes.
+func (r *RouteRegistry) URL(name string, params Params, query Query) (string, error) {
+	route := r.routeNamed(name)
+	if route == nil {
+		return "", fmt.Errorf("api: no route named %q", name)
+	}
+
+	used := map[string]bool{}
+	segments := strings.Split(route.Path, "/")
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") {
+			continue
+		}
+		param := segment[1 : len(segment)-1]
+		catchAll := isCatchAllParam(route, param)
+		param = strings.TrimSuffix(param, "...")
+		value, ok := params[param]
+		if !ok {
+			return "", fmt.Errorf("api: route %s needs path parameter %q", name, param)
+		}
+		segments[i] = escapePathValue(formatURLValue(value), catchAll)
+		used[param] = true
+	}
+	for param := range params {
+		if !used[param] {
+			return "", fmt.Errorf("api: route %s has no path parameter %q", name, param)
+		}
+	}
+
+	link := strings.Join(segments, "/")
+	if len(query) == 0 {
+		return link, nil
+	}
+	values := url.Values{}
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		v := reflect.ValueOf(query[key])
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 {
+			for i := 0; i < v.Len(); i++ {
+				values.Add(key, formatURLValue(v.Index(i).Interface()))
+			}
+			continue
+		}
+		values.Add(key, formatURLValue(query[key]))
+	}
+	return link + "?" + values.Encode(), nil
+}
+
+// routeNamed returns the first route whose handler is named name.
+func (r *RouteRegistry) routeNamed(name string) *RouteInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, route := range r.routes {
+		// Method values are named like "GetUser-fm".
+		if strings.TrimSuffix(route.HandlerName, "-fm") == name {
+			return route
+		}
+	}
+	return nil
+}
+
+// escapePathValue escapes a path parameter value as a single segment or,
+// for catch-all parameters, segment by segment.
+func escapePathValue(value string, catchAll bool) string {
+	if !catchAll {
+		return url.PathEscape(value)
+	}
+	parts := strings.Split(value, "/")
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+	return strings.Join(parts, "/")
+}
+
+// formatURLValue formats a path or query parameter value.
+func formatURLValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case encoding.TextMarshaler:
+		if text, err := v.MarshalText(); err == nil {
+			return string(text)
+		}
+	}
+	return fmt.Sprint(value)
+}
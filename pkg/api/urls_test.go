@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func getLinkedUser(context.Context, struct{}) (*loadShedResponse, error) { return nil, nil }
+
+func listLinkedFiles(context.Context, struct{}) (*loadShedResponse, error) { return nil, nil }
+
+func getStaticFile(context.Context, struct{}) (*loadShedResponse, error) { return nil, nil }
+
+func TestRegistryURL(t *testing.T) {
+	router, registry, _ := newLoadShedRouter()
+	router.Get("/users/{user_id}", getLinkedUser)
+	router.Get("/users/{user_id}/files/{name}", listLinkedFiles)
+	// A chi "/*" catch-all, as the chi adapter registers it.
+	router.Get("/static/{path}", getStaticFile, WithPathPattern("path", ".*"))
+
+	tests := []struct {
+		name   string
+		params Params
+		query  Query
+		want   string
+	}{
+		{"getLinkedUser", Params{"user_id": 42}, nil, "/api/users/42"},
+		{"getLinkedUser", Params{"user_id": "a b/c"}, Query{"tab": "billing"}, "/api/users/a%20b%2Fc?tab=billing"},
+		{"listLinkedFiles", Params{"user_id": 1, "name": "report.pdf"},
+			Query{"tag": []string{"x", "y"}, "since": time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), "ttl": time.Minute},
+			"/api/users/1/files/report.pdf?since=2026-01-02T03%3A04%3A05Z&tag=x&tag=y&ttl=1m0s"},
+		{"getStaticFile", Params{"path": "css/site v2.css"}, nil, "/api/static/css/site%20v2.css"},
+	}
+	for _, tt := range tests {
+		got, err := registry.URL(tt.name, tt.params, tt.query)
+		if err != nil || got != tt.want {
+			t.Errorf("URL(%s, %v, %v) = %q, %v, want %q", tt.name, tt.params, tt.query, got, err, tt.want)
+		}
+	}
+
+	errors := []struct {
+		name   string
+		params Params
+		want   string
+	}{
+		{"GetNothing", nil, "no route"},
+		{"getLinkedUser", nil, `needs path parameter "user_id"`},
+		{"getLinkedUser", Params{"user_id": 1, "id": 1}, `no path parameter "id"`},
+	}
+	for _, tt := range errors {
+		if _, err := registry.URL(tt.name, tt.params, nil); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("URL(%s, %v): error = %v, want %q", tt.name, tt.params, err, tt.want)
+		}
+	}
+}
@@ -138,3 +138,55 @@ func TestValidateRequestLevel(t *testing.T) {
 		}
 	})
 }
+
+type bookingDates struct {
+	Start string `gork:"start" validate:"required"`
+	End   string `gork:"end" validate:"required"`
+}
+
+func (d *bookingDates) Validate(context.Context) error {
+	if d.End < d.Start {
+		return FieldErrors{"end": {"must not be before start"}}
+	}
+	return nil
+}
+
+type bookingRequest struct {
+	Query struct {
+		Guests int `gork:"guests"`
+	}
+	Body bookingDates
+}
+
+func (r *bookingRequest) Validate(context.Context) error {
+	if r.Query.Guests > 2 && r.Body.Start == r.Body.End {
+		return FieldErrors{"query.guests": {"day trips allow at most 2 guests"}}
+	}
+	return nil
+}
+
+func TestCrossFieldValidation(t *testing.T) {
+	validator := NewConventionValidator()
+
+	req := &bookingRequest{Body: bookingDates{Start: "2026-05-02", End: "2026-05-01"}}
+	var verr *ValidationErrorResponse
+	if err := validator.ValidateRequest(context.Background(), req); !errors.As(err, &verr) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	if got := verr.Details["body.end"]; len(got) != 1 || got[0] != "must not be before start" {
+		t.Errorf("section field errors must be merged below the section, got %v", verr.Details)
+	}
+
+	req = &bookingRequest{Body: bookingDates{Start: "2026-05-01", End: "2026-05-01"}}
+	req.Query.Guests = 3
+	if err := validator.ValidateRequest(context.Background(), req); !errors.As(err, &verr) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	if got := verr.Details["query.guests"]; len(got) != 1 {
+		t.Errorf("request field errors must be merged as is, got %v", verr.Details)
+	}
+
+	if got := (FieldErrors{"b": {"x"}, "a": {"y", "z"}}).Error(); got != "validation failed: a: y, a: z, b: x" {
+		t.Errorf("Error() = %q", got)
+	}
+}
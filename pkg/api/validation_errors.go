@@ -0,0 +1,197 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gork-labs/gork/pkg/gorkson"
+)
+
+// FieldErrors maps request fields ("body.email", "query.limit", or
+// "request" for request-level rules) to their validation messages.
+type FieldErrors map[string][]string
+
+// Error implements error so that Validate methods can return field errors;
+// they are merged into the 400 response field by field.
+func (e FieldErrors) Error() string {
+	return "validation failed: " + strings.Join(e.GetErrors(), ", ")
+}
+
+// GetErrors returns the errors as "field: message", sorted by field.
+func (e FieldErrors) GetErrors() []string {
+	fields := make([]string, 0, len(e))
+	for field := range e {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	var out []string
+	for _, field := range fields {
+		for _, msg := range e[field] {
+			out = append(out, field+": "+msg)
+		}
+	}
+	return out
+}
+
+// ValidationErrorEncoder turns the field errors of a rejected request into
+// the body of its 400 response.
+type ValidationErrorEncoder func(errs FieldErrors) any
+
+// validationErrorEncoder is the encoder set with SetValidationErrorEncoder.
+var validationErrorEncoder ValidationErrorEncoder
+
+// SetValidationErrorEncoder replaces the ValidationErrorResponse body of 400
+// responses with the value returned by encoder, encoded as gork JSON. The
+// generator documents the type the encoder returns (determined by calling it
+// with no errors) as the ValidationErrorResponse schema. A nil encoder
+// restores the default shape. It is meant to be called once during startup.
+//
+//	api.SetValidationErrorEncoder(func(errs api.FieldErrors) any {
+//		return ValidationProblem{Title: "Invalid request", Errors: errs}
+//	})
+func SetValidationErrorEncoder(encoder ValidationErrorEncoder) {
+	validationErrorEncoder = encoder
+}
+
+// writeValidationError writes the 400 response for a validation error.
+func writeValidationError(w http.ResponseWriter, err error) {
+	markValidationFailed(w)
+	if pw, ok := findResponseWriter[*problemResponseWriter](w); ok {
+		problem := pw.problem(http.StatusBadRequest, err.Error())
+		problem.Errors = fieldErrorsOf(err)
+		if rw, ok := findResponseWriter[*requestIDResponseWriter](w); ok {
+			problem.Details = map[string]interface{}{"request_id": rw.id}
+		}
+		writeProblem(w, problem)
+		return
+	}
+	if validationErrorEncoder == nil {
+		var body interface{} = err
+		if rw, ok := findResponseWriter[*requestIDResponseWriter](w); ok {
+			details := map[string]interface{}{"request_id": rw.id}
+			for field, messages := range fieldErrorsOf(err) {
+				details[field] = messages
+			}
+			body = ErrorResponse{Error: err.Error(), Details: details}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(body)
+		return
+	}
+	data, encErr := gorkson.Marshal(validationErrorEncoder(fieldErrorsOf(err)))
+	if encErr != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to encode validation error: "+encErr.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_, _ = w.Write(data)
+}
+
+// fieldErrorsOf returns the field errors carried by a validation error.
+func fieldErrorsOf(err error) FieldErrors {
+	var verr *ValidationErrorResponse
+	if errors.As(err, &verr) {
+		return FieldErrors(verr.Details)
+	}
+	return FieldErrors{"request": {err.Error()}}
+}
+
+// customValidationErrorSchema returns the schema of the encoder's output, or
+// nil without an encoder. Named types are registered as components and
+// referenced.
+func customValidationErrorSchema(components *Components) *Schema {
+	if validationErrorEncoder == nil {
+		return nil
+	}
+	t := reflect.TypeOf(validationErrorEncoder(FieldErrors{}))
+	if t == nil {
+		return nil
+	}
+	return reflectTypeToSchema(t, components.Schemas)
+}
+
+// validationErrorPath returns the path of the field ve reports, relative
+// to root, the struct type validated: dotted field names and indexed items
+// or map values, e.g. "address.zip" or "items[3].qty". Embedded structs are
+// left out, as their fields are promoted in JSON.
+func validationErrorPath(root reflect.Type, ve validator.FieldError) string {
+	namespace := strings.TrimPrefix(ve.Namespace(), root.Name()+".")
+	t := root
+	var path []string
+	for _, segment := range splitNamespace(namespace) {
+		name, _, _ := strings.Cut(segment, "[")
+		field, ok := validatedField(t, name)
+		if !ok {
+			// Past types the path cannot be followed in; keep the rest as is
+			t = nil
+			path = append(path, segment)
+			continue
+		}
+		t = field.Type
+		if field.Anonymous && parseGorkTag(field.Tag.Get("gork")).Name == "" && name == segment {
+			continue
+		}
+		path = append(path, segment)
+		for range strings.Count(segment, "[") {
+			t = elemType(t)
+		}
+	}
+	return strings.Join(path, ".")
+}
+
+// validatedField returns the field of the struct type t the validator
+// names name.
+func validatedField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return reflect.StructField{}, false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); (f.IsExported() || f.Anonymous) && defaultTagNameFunc(f) == name {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// elemType returns the type of the items or values of t, or nil when t has
+// none.
+func elemType(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || (t.Kind() != reflect.Slice && t.Kind() != reflect.Array && t.Kind() != reflect.Map) {
+		return nil
+	}
+	return t.Elem()
+}
+
+// splitNamespace splits a validator namespace on the dots outside of map
+// keys, which may contain dots themselves.
+func splitNamespace(namespace string) []string {
+	var segments []string
+	depth, start := 0, 0
+	for i, r := range namespace {
+		switch r {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case '.':
+			if depth == 0 {
+				segments = append(segments, namespace[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(segments, namespace[start:])
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type validationProblem struct {
+	Title  string              `gork:"title" validate:"required"`
+	Errors map[string][]string `gork:"errors"`
+}
+
+func TestSetValidationErrorEncoder(t *testing.T) {
+	SetValidationErrorEncoder(func(errs FieldErrors) any {
+		return validationProblem{Title: "Invalid request", Errors: errs}
+	})
+	t.Cleanup(func() { SetValidationErrorEncoder(nil) })
+
+	router, registry, handlers := newLoadShedRouter()
+	router.Post("/greet", func(context.Context, typedMiddlewareRequest) (*loadShedResponse, error) { return nil, nil })
+
+	w := httptest.NewRecorder()
+	handlers["POST /greet"](w, httptest.NewRequest(http.MethodPost, "/api/greet", strings.NewReader(`{}`)))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d", w.Code)
+	}
+	if got, want := strings.TrimSpace(w.Body.String()), `{"errors":{"body.name":["required"]},"title":"Invalid request"}`; got != want {
+		t.Errorf("body = %s, want %s", got, want)
+	}
+
+	spec := GenerateOpenAPI(registry)
+	schema := spec.Components.Schemas["ValidationErrorResponse"]
+	if schema == nil || schema.Ref != "#/components/schemas/validationProblem" {
+		t.Fatalf("expected the encoder's type to be documented, got %+v", schema)
+	}
+	if problem := spec.Components.Schemas["validationProblem"]; problem == nil || problem.Properties["errors"] == nil {
+		t.Errorf("expected the validationProblem component, got %+v", problem)
+	}
+}
+
+func TestDefaultValidationErrorFormat(t *testing.T) {
+	router, registry, handlers := newLoadShedRouter()
+	router.Post("/greet", func(context.Context, typedMiddlewareRequest) (*loadShedResponse, error) { return nil, nil })
+
+	w := httptest.NewRecorder()
+	handlers["POST /greet"](w, httptest.NewRequest(http.MethodPost, "/api/greet", strings.NewReader(`{}`)))
+	if got := strings.TrimSpace(w.Body.String()); got != `{"error":"Validation failed","details":{"body.name":["required"]}}` {
+		t.Errorf("body = %s", got)
+	}
+	if schema := GenerateOpenAPI(registry).Components.Schemas["ValidationErrorResponse"]; schema == nil || schema.Properties["details"] == nil {
+		t.Errorf("expected the default schema, got %+v", schema)
+	}
+}
+
+type nestedPathAudit struct {
+	Note string `gork:"note" validate:"max=3"`
+}
+
+type nestedPathRequest struct {
+	Body struct {
+		nestedPathAudit
+		Address struct {
+			Zip string `gork:"zip" validate:"len=5"`
+		} `gork:"address"`
+		Items []struct {
+			Qty int `gork:"qty" validate:"min=1"`
+		} `gork:"items" validate:"dive"`
+		Labels map[string]string `gork:"labels" validate:"dive,max=2"`
+	}
+}
+
+func TestNestedValidationErrorPaths(t *testing.T) {
+	router, registry, handlers := newLoadShedRouter()
+	router.Post("/orders", func(context.Context, nestedPathRequest) (*struct{}, error) { return nil, nil })
+
+	w := httptest.NewRecorder()
+	body := `{"note":"long","address":{"zip":"1"},"items":[{"qty":1},{"qty":0}],"labels":{"a.b":"long"}}`
+	handlers["POST /orders"](w, httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body)))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400: %s", w.Code, w.Body)
+	}
+	var resp ValidationErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]string{
+		"body.note":         {"max"},
+		"body.address.zip":  {"len"},
+		"body.items[1].qty": {"min"},
+		"body.labels[a.b]":  {"max"},
+	}
+	if !reflect.DeepEqual(resp.Details, want) {
+		t.Errorf("details = %v, want %v", resp.Details, want)
+	}
+
+	details := GenerateOpenAPI(registry).Components.Schemas["ValidationErrorResponse"].Properties["details"]
+	if !strings.Contains(details.Description, "body.items[3].qty") {
+		t.Errorf("expected the path convention in the details description, got %q", details.Description)
+	}
+}
@@ -33,6 +33,52 @@ type WebhookProviderInfo struct {
 	DocsURL string
 }
 
+// WithWebhookProvider sets the provider metadata documented for a webhook
+// route (x-webhook-provider). It takes precedence over the handler's
+// ProviderInfo method, which is useful for third-party handlers that return
+// empty metadata.
+func WithWebhookProvider(info WebhookProviderInfo) Option {
+	return func(h *HandlerOption) {
+		h.WebhookProvider = &info
+	}
+}
+
+// ApplyWebhookProviders fills in x-webhook-provider for the webhook
+// operations on each path that do not already document a provider. Other
+// operations on the path are left alone. It backs the webhookProviders
+// section of the gork config file.
+func ApplyWebhookProviders(spec *OpenAPISpec, providers map[string]WebhookProviderInfo) {
+	if spec == nil {
+		return
+	}
+	for path, info := range providers {
+		item := spec.Paths[path]
+		if item == nil {
+			continue
+		}
+		for _, op := range []*Operation{item.Get, item.Post, item.Put, item.Patch, item.Delete} {
+			if !isWebhookOperation(op) || op.XWebhookProvider["name"] != "" {
+				continue
+			}
+			provider := map[string]string{"name": info.Name, "website": info.Website, "docs": info.DocsURL}
+			op.XWebhookProvider = provider
+			if op.Extensions != nil {
+				op.Extensions["x-webhook-provider"] = provider
+			}
+		}
+	}
+}
+
+// isWebhookOperation reports whether op was generated for a webhook route,
+// which always documents x-webhook-events, possibly as an empty list.
+func isWebhookOperation(op *Operation) bool {
+	if op == nil {
+		return false
+	}
+	_, ok := op.Extensions["x-webhook-events"]
+	return ok || op.XWebhookEvents != nil
+}
+
 // WebhookHandler defines the interface for webhook providers.
 // T must be a type that implements WebhookRequest.
 // All providers MUST implement ProviderInfo() to expose basic metadata.
@@ -67,6 +113,11 @@ type WebhookHandlerOption struct {
 	// When true: user metadata validation/unmarshal errors produce 400 with ErrorResponse.
 	// When false (default): handlers receive a nil user metadata pointer on validation errors.
 	StrictUserValidation bool
+	// SuccessStatus is the status code acknowledging a processed event (default 200).
+	SuccessStatus int
+	// EmptySuccessBody omits the provider SuccessResponse from acknowledgements.
+	// It is implied by a 204 SuccessStatus.
+	EmptySuccessBody bool
 }
 
 // WebhookOption is a function that configures WebhookHandlerOption.
@@ -84,6 +135,34 @@ func WithEventHandler[P any, U any](eventType string, handler func(context.Conte
 	}
 }
 
+// WithSuccessStatus sets the 2xx status code used to acknowledge events, for
+// providers that expect e.g. 202 for asynchronous processing or 204.
+func WithSuccessStatus(code int) WebhookOption {
+	if code < 200 || code > 299 {
+		panic(fmt.Sprintf("webhook success status must be 2xx, got %d", code))
+	}
+	return func(h *WebhookHandlerOption) {
+		h.SuccessStatus = code
+	}
+}
+
+// WithEmptySuccessBody acknowledges events without a response body.
+func WithEmptySuccessBody() WebhookOption {
+	return func(h *WebhookHandlerOption) {
+		h.EmptySuccessBody = true
+	}
+}
+
+// successStatus returns the configured acknowledgement status and whether
+// it is sent without a body.
+func (h *WebhookHandlerOption) successStatus() (int, bool) {
+	status := h.SuccessStatus
+	if status == 0 {
+		status = http.StatusOK
+	}
+	return status, h.EmptySuccessBody || status == http.StatusNoContent
+}
+
 // webhookHandlerRegistry stores original webhook handlers by their address.
 // This allows the OpenAPI generator to access the handler's response methods via reflection.
 var webhookHandlerRegistry = make(map[uintptr]interface{})
@@ -116,6 +195,8 @@ type webhookRegistryEntry struct {
 	providerInfo  *WebhookProviderInfo
 	handledEvents []string
 	handlersMeta  []RegisteredEventHandler
+	successStatus int
+	emptySuccess  bool
 }
 
 // GetWebhookRouteMetadata returns provider info and handled events for a registered webhook handler.
@@ -149,6 +230,18 @@ func GetWebhookHandlersMetadata(handler http.HandlerFunc) []RegisteredEventHandl
 	return nil
 }
 
+// getWebhookSuccessStatus returns the acknowledgement status configured for a
+// registered webhook handler and whether it has no body.
+func getWebhookSuccessStatus(handler http.HandlerFunc) (int, bool) {
+	handlerPtr := reflect.ValueOf(handler).Pointer()
+	if entry, ok := webhookHandlerRegistry[handlerPtr]; ok {
+		if e, ok2 := entry.(webhookRegistryEntry); ok2 {
+			return e.successStatus, e.emptySuccess
+		}
+	}
+	return 0, false
+}
+
 // WebhookHandlerFunc creates an HTTP handler from a webhook handler using conventional request parsing.
 func WebhookHandlerFunc[T WebhookRequest](handler WebhookHandler[T], opts ...WebhookOption) http.HandlerFunc {
 	options := buildWebhookOptions(opts)
@@ -208,7 +301,7 @@ func processWebhookRequest[T WebhookRequest](w http.ResponseWriter, r *http.Requ
 	// 1. Parse request using Gork's conventional request parsing
 	var req T
 	if err := ParseRequest(r, &req); err != nil {
-		writeWebhookJSON(w, http.StatusBadRequest, handler.ErrorResponse(err))
+		writeWebhookJSON(w, requestErrorStatus(err), handler.ErrorResponse(err))
 		return err
 	}
 
@@ -229,7 +322,7 @@ func handleWebhookEvent[T WebhookRequest](w http.ResponseWriter, r *http.Request
 	if !exists {
 		// Log unhandled event type but return success
 		log.Printf("Unhandled webhook event type: %s", event.Type)
-		writeWebhookJSON(w, http.StatusOK, handler.SuccessResponse())
+		writeWebhookSuccess(w, handler, options)
 		return nil
 	}
 
@@ -245,22 +338,35 @@ func handleWebhookEvent[T WebhookRequest](w http.ResponseWriter, r *http.Request
 	}
 
 	// On success, write provider's standard success response
-	writeWebhookJSON(w, http.StatusOK, handler.SuccessResponse())
+	writeWebhookSuccess(w, handler, options)
 	return nil
 }
 
+// writeWebhookSuccess acknowledges an event with the configured status.
+func writeWebhookSuccess[T WebhookRequest](w http.ResponseWriter, handler WebhookHandler[T], options *WebhookHandlerOption) {
+	status, empty := options.successStatus()
+	if empty {
+		w.WriteHeader(status)
+		return
+	}
+	writeWebhookJSON(w, status, handler.SuccessResponse())
+}
+
 // registerWebhookMetadata registers the webhook handler and its metadata for OpenAPI reflection.
 func registerWebhookMetadata[T WebhookRequest](httpHandlerFunc http.HandlerFunc, handler WebhookHandler[T], options *WebhookHandlerOption) {
 	handled, handlersMeta := buildHandlerMetadata(options)
 
 	info := handler.ProviderInfo()
 	pinfo := &info
+	status, empty := options.successStatus()
 
 	registerWebhookHandler(httpHandlerFunc, webhookRegistryEntry{
 		original:      handler,
 		providerInfo:  pinfo,
 		handledEvents: handled,
 		handlersMeta:  handlersMeta,
+		successStatus: status,
+		emptySuccess:  empty,
 	})
 }
 
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// WebhookCatalog is a machine-readable list of the webhook routes of an API,
+// their providers, handled events and user payload schemas.
+type WebhookCatalog struct {
+	Webhooks []WebhookCatalogEntry `json:"webhooks"`
+	// Schemas holds the components referenced by the user payload schemas.
+	Schemas map[string]*Schema `json:"schemas,omitempty"`
+}
+
+// WebhookCatalogEntry describes a single webhook route.
+type WebhookCatalogEntry struct {
+	Method      string                `json:"method"`
+	Path        string                `json:"path"`
+	OperationID string                `json:"operationId,omitempty"`
+	Provider    map[string]string     `json:"provider,omitempty"`
+	Events      []WebhookCatalogEvent `json:"events"`
+}
+
+// WebhookCatalogEvent describes an event accepted by a webhook route.
+type WebhookCatalogEvent struct {
+	Event             string  `json:"event"`
+	Handler           string  `json:"handler,omitempty"`
+	Description       string  `json:"description,omitempty"`
+	UserPayloadSchema *Schema `json:"userPayloadSchema,omitempty"`
+}
+
+// BuildWebhookCatalog collects the webhook operations of a generated spec
+// (those carrying x-webhook-provider or x-webhook-events) into a catalog.
+// Routes are sorted by path and method.
+func BuildWebhookCatalog(spec *OpenAPISpec) *WebhookCatalog {
+	catalog := &WebhookCatalog{Webhooks: []WebhookCatalogEntry{}}
+	if spec == nil {
+		return catalog
+	}
+
+	paths := make([]string, 0, len(spec.Paths))
+	for p := range spec.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	refs := map[string]bool{}
+	for _, p := range paths {
+		item := spec.Paths[p]
+		for _, m := range []struct {
+			method string
+			op     *Operation
+		}{{"DELETE", item.Delete}, {"GET", item.Get}, {"PATCH", item.Patch}, {"POST", item.Post}, {"PUT", item.Put}} {
+			if m.op == nil || (m.op.XWebhookProvider == nil && m.op.XWebhookEvents == nil) {
+				continue
+			}
+			entry := WebhookCatalogEntry{
+				Method:      m.method,
+				Path:        p,
+				OperationID: m.op.OperationID,
+				Provider:    m.op.XWebhookProvider,
+				Events:      make([]WebhookCatalogEvent, 0, len(m.op.XWebhookEvents)),
+			}
+			for _, raw := range m.op.XWebhookEvents {
+				ev := catalogEvent(raw)
+				collectSchemaRefs(ev.UserPayloadSchema, spec.Components, refs)
+				entry.Events = append(entry.Events, ev)
+			}
+			catalog.Webhooks = append(catalog.Webhooks, entry)
+		}
+	}
+
+	if len(refs) > 0 {
+		catalog.Schemas = make(map[string]*Schema, len(refs))
+		for name := range refs {
+			catalog.Schemas[name] = spec.Components.Schemas[name]
+		}
+	}
+	return catalog
+}
+
+// catalogEvent converts an x-webhook-events entry, either as built by the
+// generator or as decoded from JSON, into a catalog event.
+func catalogEvent(raw map[string]interface{}) WebhookCatalogEvent {
+	ev := WebhookCatalogEvent{}
+	ev.Event, _ = raw["event"].(string)
+	ev.Handler, _ = raw["operationId"].(string)
+	ev.Description, _ = raw["description"].(string)
+	if schema, ok := raw["userPayloadSchema"]; ok && schema != nil {
+		data, err := json.Marshal(schema)
+		if err == nil {
+			var s Schema
+			if json.Unmarshal(data, &s) == nil {
+				ev.UserPayloadSchema = &s
+			}
+		}
+	}
+	return ev
+}
+
+// collectSchemaRefs records the component names referenced from s,
+// following references transitively.
+func collectSchemaRefs(s *Schema, components *Components, seen map[string]bool) {
+	if s == nil {
+		return
+	}
+	if s.Ref != "" {
+		name := strings.TrimPrefix(s.Ref, "#/components/schemas/")
+		if seen[name] || components == nil || components.Schemas[name] == nil {
+			return
+		}
+		seen[name] = true
+		collectSchemaRefs(components.Schemas[name], components, seen)
+		return
+	}
+	for _, p := range s.Properties {
+		collectSchemaRefs(p, components, seen)
+	}
+	for _, o := range s.OneOf {
+		collectSchemaRefs(o, components, seen)
+	}
+	for _, o := range s.AnyOf {
+		collectSchemaRefs(o, components, seen)
+	}
+	collectSchemaRefs(s.Items, components, seen)
+	collectSchemaRefs(s.AdditionalProperties, components, seen)
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestBuildWebhookCatalog(t *testing.T) {
+	spec := &OpenAPISpec{
+		Paths: map[string]*PathItem{
+			"/users": {Get: &Operation{OperationID: "ListUsers"}},
+			"/webhooks/stripe": {Post: &Operation{
+				OperationID:      "StripeWebhook",
+				XWebhookProvider: map[string]string{"name": "Stripe"},
+				XWebhookEvents: []map[string]interface{}{
+					{
+						"event":             "payment_intent.succeeded",
+						"operationId":       "HandlePaymentSucceeded",
+						"description":       "Marks the order paid.",
+						"userPayloadSchema": &Schema{Ref: "#/components/schemas/PaymentMetadata"},
+					},
+					{"event": "invoice.paid"},
+				},
+			}},
+		},
+		Components: &Components{Schemas: map[string]*Schema{
+			"PaymentMetadata": {Type: "object", Properties: map[string]*Schema{"order": {Ref: "#/components/schemas/OrderRef"}}},
+			"OrderRef":        {Type: "string"},
+			"Unrelated":       {Type: "string"},
+		}},
+	}
+
+	catalog := BuildWebhookCatalog(spec)
+	if len(catalog.Webhooks) != 1 {
+		t.Fatalf("expected one webhook route, got %+v", catalog.Webhooks)
+	}
+	route := catalog.Webhooks[0]
+	if route.Method != "POST" || route.Path != "/webhooks/stripe" || route.Provider["name"] != "Stripe" {
+		t.Errorf("unexpected route %+v", route)
+	}
+	if len(route.Events) != 2 {
+		t.Fatalf("expected 2 events, got %+v", route.Events)
+	}
+	ev := route.Events[0]
+	if ev.Handler != "HandlePaymentSucceeded" || ev.Description == "" || ev.UserPayloadSchema == nil {
+		t.Errorf("unexpected event %+v", ev)
+	}
+	if len(catalog.Schemas) != 2 || catalog.Schemas["OrderRef"] == nil || catalog.Schemas["Unrelated"] != nil {
+		t.Errorf("expected transitive payload schemas only, got %v", catalog.Schemas)
+	}
+}
+
+func TestBuildWebhookCatalogFromDecodedSpec(t *testing.T) {
+	data := []byte(`{"paths":{"/hooks":{"post":{"x-webhook-events":[{"event":"ping","userPayloadSchema":{"type":"object"}}]}}}}`)
+	var spec OpenAPISpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		t.Fatal(err)
+	}
+	catalog := BuildWebhookCatalog(&spec)
+	if len(catalog.Webhooks) != 1 || catalog.Webhooks[0].Events[0].UserPayloadSchema.Type != "object" {
+		t.Errorf("unexpected catalog %+v", catalog)
+	}
+	if got := BuildWebhookCatalog(nil); len(got.Webhooks) != 0 {
+		t.Errorf("expected empty catalog, got %+v", got)
+	}
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestWithWebhookProviderOverridesHandlerInfo(t *testing.T) {
+	spec := &OpenAPISpec{Components: &Components{Schemas: map[string]*Schema{}}}
+	gen := NewConventionOpenAPIGenerator(spec, nil)
+
+	opts := &HandlerOption{}
+	WithWebhookProvider(WebhookProviderInfo{Name: "Acme", Website: "https://acme.test", DocsURL: "https://acme.test/docs"})(opts)
+
+	route := &RouteInfo{
+		HandlerName:         "AcmeWebhook",
+		Method:              "POST",
+		Path:                "/webhooks/acme",
+		Options:             opts,
+		RequestType:         reflect.TypeOf((*WebhookRequest)(nil)).Elem(),
+		WebhookProviderInfo: &WebhookProviderInfo{},
+	}
+
+	op := gen.buildWebhookOperation(route, spec.Components, &Operation{Responses: map[string]*Response{}})
+	if op.XWebhookProvider["name"] != "Acme" || op.XWebhookProvider["docs"] != "https://acme.test/docs" {
+		t.Errorf("expected provider from option, got %#v", op.XWebhookProvider)
+	}
+
+	route.Options = &HandlerOption{}
+	op = gen.buildWebhookOperation(route, spec.Components, &Operation{Responses: map[string]*Response{}})
+	if op.XWebhookProvider != nil {
+		t.Errorf("empty handler info should not be documented, got %#v", op.XWebhookProvider)
+	}
+}
+
+func TestApplyWebhookProviders(t *testing.T) {
+	documented := &Operation{XWebhookProvider: map[string]string{"name": "Stripe"}, XWebhookEvents: []map[string]interface{}{}}
+	missing := &Operation{Extensions: map[string]interface{}{"x-webhook-events": []map[string]interface{}{}}}
+	status := &Operation{}
+	spec := &OpenAPISpec{Paths: map[string]*PathItem{
+		"/webhooks/stripe": {Post: documented},
+		"/webhooks/acme":   {Post: missing, Get: status},
+	}}
+
+	ApplyWebhookProviders(spec, map[string]WebhookProviderInfo{
+		"/webhooks/stripe":  {Name: "Other"},
+		"/webhooks/acme":    {Name: "Acme", Website: "https://acme.test"},
+		"/webhooks/unknown": {Name: "Ghost"},
+	})
+
+	if documented.XWebhookProvider["name"] != "Stripe" {
+		t.Errorf("existing provider should be kept, got %#v", documented.XWebhookProvider)
+	}
+	if missing.XWebhookProvider["name"] != "Acme" || missing.XWebhookProvider["website"] != "https://acme.test" {
+		t.Errorf("expected provider from config, got %#v", missing.XWebhookProvider)
+	}
+	if _, ok := missing.Extensions["x-webhook-provider"]; !ok {
+		t.Error("expected extension to be updated")
+	}
+	if status.XWebhookProvider != nil {
+		t.Errorf("operations other than webhooks should be left alone, got %#v", status.XWebhookProvider)
+	}
+
+	// Specs read back from JSON, as the CLI does, keep the marker.
+	data, err := json.Marshal(&OpenAPISpec{Paths: map[string]*PathItem{"/webhooks/acme": {Post: &Operation{Extensions: map[string]interface{}{"x-webhook-events": []map[string]interface{}{}}}}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var parsed OpenAPISpec
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		t.Fatal(err)
+	}
+	ApplyWebhookProviders(&parsed, map[string]WebhookProviderInfo{"/webhooks/acme": {Name: "Acme"}})
+	if got := parsed.Paths["/webhooks/acme"].Post.XWebhookProvider["name"]; got != "Acme" {
+		t.Errorf("expected the provider on the parsed webhook, got %q", got)
+	}
+	ApplyWebhookProviders(nil, nil)
+}
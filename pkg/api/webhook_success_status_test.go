@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func sendTestWebhook(h http.HandlerFunc, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	req.Header.Set("X-Test-Signature", "valid-secret")
+	rec := httptest.NewRecorder()
+	h(rec, req)
+	return rec
+}
+
+func TestWebhookSuccessStatus(t *testing.T) {
+	onPayment := func(context.Context, *map[string]string, *PaymentMetadata) error { return nil }
+	handle := WithEventHandler[map[string]string, PaymentMetadata]("payment.succeeded", onPayment)
+
+	accepted := WebhookHandlerFunc[TestWebhookRequest](NewTestWebhookHandler("valid-secret"), handle, WithSuccessStatus(http.StatusAccepted))
+	for _, body := range []string{`{"event":"payment"}`, `{"event":"other"}`} {
+		rec := sendTestWebhook(accepted, body)
+		if rec.Code != http.StatusAccepted || !strings.Contains(rec.Body.String(), "received") {
+			t.Errorf("%s: expected 202 with provider body, got %d %q", body, rec.Code, rec.Body.String())
+		}
+	}
+
+	noContent := WebhookHandlerFunc[TestWebhookRequest](NewTestWebhookHandler("valid-secret"), handle, WithSuccessStatus(http.StatusNoContent))
+	if rec := sendTestWebhook(noContent, `{"event":"payment"}`); rec.Code != http.StatusNoContent || rec.Body.Len() != 0 {
+		t.Errorf("expected empty 204, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	empty := WebhookHandlerFunc[TestWebhookRequest](NewTestWebhookHandler("valid-secret"), handle, WithEmptySuccessBody())
+	if rec := sendTestWebhook(empty, `{"event":"payment"}`); rec.Code != http.StatusOK || rec.Body.Len() != 0 {
+		t.Errorf("expected empty 200, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for non-2xx status")
+		}
+	}()
+	WithSuccessStatus(http.StatusFound)
+}
+
+func TestWebhookSuccessStatusOpenAPI(t *testing.T) {
+	registry := NewRouteRegistry()
+	router := NewTypedRouter[*struct{}](nil, registry, "", nil, &mockTypedRouterAdapter{}, nil)
+	router.Post("/webhooks/async", WebhookHandlerFunc[TestWebhookRequest](NewTestWebhookHandler("s"), WithSuccessStatus(http.StatusAccepted)))
+	router.Post("/webhooks/empty", WebhookHandlerFunc[TestWebhookRequest](NewTestWebhookHandler("s"), WithSuccessStatus(http.StatusNoContent)))
+	router.Post("/webhooks/default", WebhookHandlerFunc[TestWebhookRequest](NewTestWebhookHandler("s")))
+
+	spec := GenerateOpenAPI(registry)
+
+	async := spec.Paths["/webhooks/async"].Post.Responses
+	if async["200"] != nil || async["202"] == nil || async["202"].Content["application/json"] == nil {
+		t.Errorf("expected 202 with body, got %v", async)
+	}
+	empty := spec.Paths["/webhooks/empty"].Post.Responses
+	if empty["200"] != nil || empty["204"] == nil || empty["204"].Content != nil {
+		t.Errorf("expected bodiless 204, got %v", empty)
+	}
+	if spec.Paths["/webhooks/default"].Post.Responses["200"] == nil {
+		t.Error("expected default 200 response")
+	}
+}
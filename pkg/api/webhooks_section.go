@@ -0,0 +1,94 @@
+package api
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// WebhookPlacement selects where the routes of webhook handlers are
+// documented. See WithWebhooksSection.
+type WebhookPlacement int
+
+const (
+	// WebhooksInPaths documents webhook routes as operations of their
+	// paths, like other routes. It is the default.
+	WebhooksInPaths WebhookPlacement = iota
+	// WebhooksInWebhooks documents webhook routes in the top-level webhooks
+	// object only.
+	WebhooksInWebhooks
+	// WebhooksInPathsAndWebhooks documents webhook routes in both.
+	WebhooksInPathsAndWebhooks
+)
+
+// WithWebhooksSection documents the routes registered with
+// WebhookHandlerFunc in the OpenAPI 3.1 top-level webhooks object. Each
+// event a route handles gets its own entry keyed "<provider>/<event>", with
+// the handler name standing in for a provider without metadata; routes
+// without known events are keyed by the provider alone. OpenAPI 3.0 has no
+// webhooks object, so webhook routes stay in the paths of 3.0 specs.
+func WithWebhooksSection(placement WebhookPlacement) OpenAPIOption {
+	return func(spec *OpenAPISpec) { spec.webhookPlacement = placement }
+}
+
+// addRouteOperation adds the operation generated for route to the paths of
+// spec and, for webhook routes, to its webhooks as selected by
+// WithWebhooksSection.
+func addRouteOperation(spec *OpenAPISpec, route *RouteInfo, op *Operation) {
+	checkOperationTags(spec, route, op)
+	placement := spec.webhookPlacement
+	if route.WebhookHandler == nil || isOpenAPI30(spec) {
+		placement = WebhooksInPaths
+	}
+	if placement != WebhooksInWebhooks {
+		path := normalizePath(route.Path)
+		if spec.Paths[path] == nil {
+			spec.Paths[path] = &PathItem{}
+		}
+		attachOperation(spec.Paths[path], strings.ToLower(route.Method), op)
+	}
+	if placement != WebhooksInPaths {
+		addWebhookEntries(spec, route, op)
+	}
+}
+
+// addWebhookEntries adds a webhooks entry for every event documented on the
+// operation of a webhook route. The entries are copies of op narrowed to
+// their event; their operationId is the name of the event's handler, as op's
+// own operationId may already be used by its path.
+func addWebhookEntries(spec *OpenAPISpec, route *RouteInfo, op *Operation) {
+	if spec.Webhooks == nil {
+		spec.Webhooks = map[string]*PathItem{}
+	}
+	provider := op.XWebhookProvider["name"]
+	if provider == "" {
+		provider = route.HandlerName
+	}
+	method := strings.ToLower(route.Method)
+	if len(op.XWebhookEvents) == 0 {
+		entry := *op
+		entry.Parameters = slices.Clone(op.Parameters)
+		entry.OperationID = ""
+		spec.Webhooks[provider] = &PathItem{}
+		attachOperation(spec.Webhooks[provider], method, &entry)
+		return
+	}
+	for _, event := range op.XWebhookEvents {
+		name, _ := event["event"].(string)
+		entry := *op
+		entry.Parameters = slices.Clone(op.Parameters)
+		entry.OperationID, _ = event["operationId"].(string)
+		if description, ok := event["description"].(string); ok {
+			entry.Description = description
+		}
+		events := []map[string]interface{}{event}
+		entry.XWebhookEvents = events
+		entry.Extensions = maps.Clone(op.Extensions)
+		if entry.Extensions != nil {
+			entry.Extensions["x-webhook-events"] = events
+		}
+		key := provider + "/" + name
+		spec.Webhooks[key] = &PathItem{}
+		attachOperation(spec.Webhooks[key], method, &entry)
+	}
+}
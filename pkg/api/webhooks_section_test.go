@@ -0,0 +1,70 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newWebhooksSectionRegistry() *RouteRegistry {
+	registry := NewRouteRegistry()
+	registry.Register(&RouteInfo{
+		Method:               "POST",
+		Path:                 "/webhooks/api",
+		HandlerName:          "HandleAPIWebhook",
+		RequestType:          reflect.TypeOf(tReq{}),
+		WebhookHandler:       prov3{},
+		WebhookHandledEvents: []string{"evt.a", "evt.b"},
+	})
+	registry.Register(&RouteInfo{
+		Method:       "GET",
+		Path:         "/foo",
+		HandlerName:  "GetFoo",
+		RequestType:  reflect.TypeOf(Req{}),
+		ResponseType: reflect.TypeOf((*Resp)(nil)),
+	})
+	return registry
+}
+
+func TestWebhooksSection(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []OpenAPIOption
+		inPaths   bool
+		inSection bool
+	}{
+		{"default", nil, true, false},
+		{"webhooks only", []OpenAPIOption{WithWebhooksSection(WebhooksInWebhooks)}, false, true},
+		{"both", []OpenAPIOption{WithWebhooksSection(WebhooksInPathsAndWebhooks)}, true, true},
+		{"parallel", []OpenAPIOption{WithWebhooksSection(WebhooksInWebhooks), WithParallelGeneration(2)}, false, true},
+		{"openapi 3.0", []OpenAPIOption{WithWebhooksSection(WebhooksInWebhooks), WithSpecVersion("3.0")}, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := GenerateOpenAPI(newWebhooksSectionRegistry(), tt.opts...)
+			if spec.Paths["/foo"] == nil {
+				t.Error("regular routes must stay in paths")
+			}
+			if got := spec.Paths["/webhooks/api"] != nil; got != tt.inPaths {
+				t.Errorf("webhook route in paths = %v, want %v", got, tt.inPaths)
+			}
+			if got := len(spec.Webhooks) > 0; got != tt.inSection {
+				t.Errorf("webhooks = %v, want entries: %v", spec.Webhooks, tt.inSection)
+			}
+			if !tt.inSection {
+				return
+			}
+			for _, event := range []string{"evt.a", "evt.b"} {
+				item := spec.Webhooks["Api/"+event]
+				if item == nil || item.Post == nil {
+					t.Fatalf("missing webhook entry for %s: %v", event, spec.Webhooks)
+				}
+				if events := item.Post.XWebhookEvents; len(events) != 1 || events[0]["event"] != event {
+					t.Errorf("entry %s documents events %v", event, events)
+				}
+				if item.Post.OperationID != "" {
+					t.Errorf("entry %s reuses operationId %q", event, item.Post.OperationID)
+				}
+			}
+		})
+	}
+}
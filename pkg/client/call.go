@@ -0,0 +1,372 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gork-labs/gork/pkg/gorkson"
+)
+
+// Error is returned by Do for responses outside the 2xx range.
+type Error struct {
+	Operation  Operation
+	StatusCode int
+	Body       []byte
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s %s: unexpected status %d: %s", e.Operation.Method, e.Operation.Path, e.StatusCode, bytes.TrimSpace(e.Body))
+}
+
+// Do performs op with a convention request struct and decodes the response
+// into a convention response struct, mirroring how the server parses and
+// writes them: the Path section fills the path template, Query, Headers and
+// Cookies become URL parameters, headers and cookies, and Body is sent as
+// gork JSON (or as is when it is a []byte). Generated clients call Do for
+// every route. A nil httpClient uses http.DefaultClient.
+func Do(ctx context.Context, httpClient *http.Client, baseURL string, op Operation, req, resp any) error {
+	httpReq, err := newRequest(WithOperation(ctx, op), baseURL, op, req)
+	if err != nil {
+		return err
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = httpResp.Body.Close() }()
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+	if httpResp.StatusCode < 200 || httpResp.StatusCode > 299 {
+		return &Error{Operation: op, StatusCode: httpResp.StatusCode, Body: body}
+	}
+	return decodeResponse(httpResp, body, resp)
+}
+
+// newRequest builds the HTTP request for a convention request struct.
+func newRequest(ctx context.Context, baseURL string, op Operation, req any) (*http.Request, error) {
+	path := op.Path
+	query := url.Values{}
+	header := http.Header{}
+	var cookies []*http.Cookie
+	var body io.Reader
+
+	v := reflect.ValueOf(req)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Struct {
+		for i := 0; i < v.NumField(); i++ {
+			section := v.Field(i)
+			switch v.Type().Field(i).Name {
+			case "Path":
+				// Path parameters are always required, so zero values are
+				// sent too.
+				eachParam(section, false, func(name, value string) {
+					path = fillPathParam(path, name, value)
+				})
+			case "Query":
+				eachQueryParam(section, query)
+			case "Headers":
+				eachParam(section, true, header.Set)
+			case "Cookies":
+				eachParam(section, true, func(name, value string) {
+					cookies = append(cookies, &http.Cookie{Name: name, Value: value})
+				})
+			case "Body":
+				// The server only reads bodies of these methods.
+				if op.Method != http.MethodPost && op.Method != http.MethodPut && op.Method != http.MethodPatch {
+					continue
+				}
+				data, contentType, err := encodeBody(section)
+				if err != nil {
+					return nil, fmt.Errorf("encode body: %w", err)
+				}
+				if data != nil {
+					body = bytes.NewReader(data)
+					header.Set("Content-Type", contentType)
+				}
+			}
+		}
+	}
+
+	target := strings.TrimSuffix(baseURL, "/") + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, op.Method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		httpReq.Header[name] = values
+	}
+	for _, c := range cookies {
+		httpReq.AddCookie(c)
+	}
+	return httpReq, nil
+}
+
+// fillPathParam replaces the parameter name in a path template with the
+// escaped value, accepting "{id}", "{id:[0-9]+}", "{path...}" and ":id"
+// placeholders. Values of "{path...}" catch-alls keep their slashes.
+func fillPathParam(path, name, value string) string {
+	placeholder := regexp.MustCompile(`\{` + regexp.QuoteMeta(name) + `(?:[:.][^}]*)?\}|:` + regexp.QuoteMeta(name) + `\b`)
+	return placeholder.ReplaceAllStringFunc(path, func(match string) string {
+		if strings.HasSuffix(match, "...}") {
+			segments := strings.Split(value, "/")
+			for i, segment := range segments {
+				segments[i] = url.PathEscape(segment)
+			}
+			return strings.Join(segments, "/")
+		}
+		return url.PathEscape(value)
+	})
+}
+
+// encodeBody encodes a Body section; a nil result means there is no body.
+func encodeBody(section reflect.Value) ([]byte, string, error) {
+	if section.Kind() == reflect.Slice && section.Type().Elem().Kind() == reflect.Uint8 {
+		if section.IsNil() {
+			return nil, "", nil
+		}
+		return section.Bytes(), "application/octet-stream", nil
+	}
+	if section.Kind() == reflect.Ptr && section.IsNil() {
+		return nil, "", nil
+	}
+	data, err := gorkson.Marshal(section.Interface())
+	return data, "application/json", err
+}
+
+// eachParam calls fn with the wire name and formatted value of every set
+// field of a parameter section. With skipZero, optional fields holding their
+// zero value are left out, so that the server applies their defaults.
+func eachParam(section reflect.Value, skipZero bool, fn func(name, value string)) {
+	for section.Kind() == reflect.Ptr && !section.IsNil() {
+		section = section.Elem()
+	}
+	if section.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < section.NumField(); i++ {
+		field := section.Type().Field(i)
+		// Untagged embedded structs, such as api.PageRequest, contribute
+		// their fields.
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && field.Tag.Get("gork") == "" {
+			eachParam(section.Field(i), skipZero, fn)
+			continue
+		}
+		name := fieldName(field)
+		if name == "" || (skipZero && optionalZero(field, section.Field(i))) {
+			continue
+		}
+		if value, ok := formatParam(section.Field(i)); ok {
+			fn(name, value)
+		}
+	}
+}
+
+// eachQueryParam adds the set fields of a Query section to query like
+// eachParam with skipZero, sending structs with gork-tagged fields in the
+// deepObject style (name[field]).
+func eachQueryParam(section reflect.Value, query url.Values) {
+	for section.Kind() == reflect.Ptr && !section.IsNil() {
+		section = section.Elem()
+	}
+	if section.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < section.NumField(); i++ {
+		field := section.Type().Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && field.Tag.Get("gork") == "" {
+			eachQueryParam(section.Field(i), query)
+			continue
+		}
+		name := fieldName(field)
+		if name == "" || optionalZero(field, section.Field(i)) {
+			continue
+		}
+		if value := section.Field(i); isDeepObject(value) {
+			eachParam(value, true, func(key, item string) { query.Set(name+"["+key+"]", item) })
+		} else if item, ok := formatParam(value); ok {
+			query.Set(name, item)
+		}
+	}
+}
+
+// isDeepObject reports whether v is a struct bound in the deepObject style.
+func isDeepObject(v reflect.Value) bool {
+	if v.Kind() != reflect.Struct || v.Type() == reflect.TypeOf(time.Time{}) {
+		return false
+	}
+	if _, ok := v.Interface().(encoding.TextMarshaler); ok {
+		return false
+	}
+	for i := 0; i < v.NumField(); i++ {
+		if fieldName(v.Type().Field(i)) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// optionalZero reports whether v, the value of field, is the zero value of
+// a parameter that is not required. Pointers are left to formatParam, which
+// sends them whenever they are set.
+func optionalZero(field reflect.StructField, v reflect.Value) bool {
+	return v.Kind() != reflect.Ptr && v.IsZero() && !strings.Contains(field.Tag.Get("validate"), "required")
+}
+
+// fieldName returns the name of a section field on the wire.
+func fieldName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+	name := strings.Split(field.Tag.Get("gork"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// formatParam formats a parameter value the way the server parses it.
+// Unset pointers and empty slices are left out.
+func formatParam(v reflect.Value) (string, bool) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", false
+		}
+		v = v.Elem()
+	}
+	if v.CanInterface() {
+		switch x := v.Interface().(type) {
+		case time.Time:
+			return x.Format(time.RFC3339), true
+		case time.Duration:
+			return x.String(), true
+		case encoding.TextMarshaler:
+			text, err := x.MarshalText()
+			return string(text), err == nil
+		}
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), true
+	case reflect.Slice, reflect.Array:
+		if v.Len() == 0 {
+			return "", false
+		}
+		parts := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			if part, ok := formatParam(v.Index(i)); ok {
+				parts = append(parts, part)
+			}
+		}
+		return strings.Join(parts, ","), true
+	default:
+		return "", false
+	}
+}
+
+// decodeResponse fills the Body and Headers sections of a convention
+// response struct.
+func decodeResponse(httpResp *http.Response, body []byte, resp any) error {
+	v := reflect.ValueOf(resp)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return nil
+	}
+	v = v.Elem()
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	if section := v.FieldByName("Body"); section.IsValid() && len(body) > 0 {
+		if section.Kind() == reflect.Slice && section.Type().Elem().Kind() == reflect.Uint8 {
+			section.SetBytes(body)
+		} else if err := gorkson.Unmarshal(body, section.Addr().Interface()); err != nil {
+			return fmt.Errorf("decode response body: %w", err)
+		}
+	}
+	if section := v.FieldByName("Headers"); section.IsValid() && section.Kind() == reflect.Struct {
+		for i := 0; i < section.NumField(); i++ {
+			name := fieldName(section.Type().Field(i))
+			if value := httpResp.Header.Get(name); name != "" && value != "" {
+				if err := setParam(section.Field(i), value); err != nil {
+					return fmt.Errorf("decode response header %s: %w", name, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// setParam parses a header value into a field of a basic kind.
+func setParam(field reflect.Value, value string) error {
+	if field.Kind() == reflect.Ptr {
+		field.Set(reflect.New(field.Type().Elem()))
+		field = field.Elem()
+	}
+	if u, ok := field.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(value))
+	}
+	if field.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(n)
+	}
+	return nil
+}
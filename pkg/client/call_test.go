@@ -0,0 +1,154 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type callRequest struct {
+	Path struct {
+		OrgID string `gork:"org_id"`
+		ID    int    `gork:"id"`
+	}
+	Query struct {
+		callPage
+		Fields []string `gork:"fields"`
+		Limit  *int     `gork:"limit"`
+		Filter struct {
+			Status string `gork:"status"`
+		} `gork:"filter"`
+	}
+	Headers struct {
+		RequestID string `gork:"X-Request-ID"`
+	}
+	Cookies struct {
+		Session string `gork:"session"`
+	}
+	Body struct {
+		Name string `gork:"name"`
+	}
+}
+
+type callPage struct {
+	Cursor string `gork:"cursor"`
+}
+
+type callResponse struct {
+	Headers struct {
+		Version int `gork:"X-Version"`
+	}
+	Body struct {
+		ID   int    `gork:"id"`
+		Name string `gork:"name"`
+	}
+}
+
+func TestDo(t *testing.T) {
+	var got *http.Request
+	var gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		data, _ := io.ReadAll(r.Body)
+		gotBody = string(data)
+		if _, ok := OperationFromContext(r.Context()); ok {
+			t.Error("the operation must only travel in the client context")
+		}
+		w.Header().Set("X-Version", "3")
+		_, _ = w.Write([]byte(`{"id":7,"name":"renamed"}`))
+	}))
+	defer server.Close()
+
+	var req callRequest
+	req.Path.OrgID = "a b"
+	req.Path.ID = 7
+	req.Query.Fields = []string{"name", "email"}
+	req.Query.Cursor = "c1"
+	req.Query.Filter.Status = "open"
+	req.Headers.RequestID = "r-1"
+	req.Cookies.Session = "s-1"
+	req.Body.Name = "renamed"
+
+	op := Operation{ID: "UpdateUser", Method: http.MethodPut, Path: "/orgs/{org_id}/users/{id:[0-9]+}"}
+	var resp callResponse
+	if err := Do(context.Background(), server.Client(), server.URL+"/", op, &req, &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	if got.URL.EscapedPath() != "/orgs/a%20b/users/7" || got.URL.RawQuery != "cursor=c1&fields=name%2Cemail&filter%5Bstatus%5D=open" {
+		t.Errorf("unexpected URL %s", got.URL)
+	}
+	if got.Header.Get("X-Request-ID") != "r-1" || got.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("unexpected headers %v", got.Header)
+	}
+	if c, err := got.Cookie("session"); err != nil || c.Value != "s-1" {
+		t.Errorf("expected the session cookie, got %v", c)
+	}
+	if gotBody != `{"name":"renamed"}` {
+		t.Errorf("unexpected body %s", gotBody)
+	}
+	if resp.Body.ID != 7 || resp.Body.Name != "renamed" || resp.Headers.Version != 3 {
+		t.Errorf("unexpected response %+v", resp)
+	}
+}
+
+func TestDoErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > 0 {
+			t.Error("GET requests must not carry the body section")
+		}
+		http.Error(w, `{"error":"not found"}`, http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	var req callRequest
+	req.Body.Name = "ignored"
+	err := Do(context.Background(), nil, server.URL, Operation{Method: http.MethodGet, Path: "/users/:id"}, &req, &callResponse{})
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound || string(apiErr.Body) != "{\"error\":\"not found\"}\n" {
+		t.Errorf("expected a 404 Error, got %v", err)
+	}
+}
+
+func TestDoOptionalParamsAndCatchAll(t *testing.T) {
+	var got *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+	}))
+	defer server.Close()
+
+	var req struct {
+		Path struct {
+			Path string `gork:"path"`
+		}
+		Query struct {
+			Limit  int    `gork:"limit"`
+			Sort   string `gork:"sort"`
+			Page   int    `gork:"page" validate:"required"`
+			Offset *int   `gork:"offset"`
+		}
+		Headers struct {
+			Debug bool `gork:"X-Debug"`
+		}
+	}
+	req.Path.Path = "docs/a b/c.txt"
+	zero := 0
+	req.Query.Offset = &zero
+
+	op := Operation{Method: http.MethodGet, Path: "/files/{path...}"}
+	if err := Do(context.Background(), server.Client(), server.URL, op, &req, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got.URL.EscapedPath() != "/files/docs/a%20b/c.txt" {
+		t.Errorf("expected the catch-all to keep its slashes, got %s", got.URL.EscapedPath())
+	}
+	if got.URL.RawQuery != "offset=0&page=0" {
+		t.Errorf("expected optional zero values left out for the server defaults, got %s", got.URL.RawQuery)
+	}
+	if _, ok := got.Header["X-Debug"]; ok {
+		t.Errorf("unexpected X-Debug header %v", got.Header)
+	}
+}
@@ -0,0 +1,158 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// hedgeResult is the outcome of a single hedged attempt.
+type hedgeResult struct {
+	attempt int
+	resp    *http.Response
+	err     error
+	cancel  context.CancelFunc
+}
+
+// hedger tracks the in-flight attempts of a single hedged request.
+type hedger struct {
+	t       *Transport
+	req     *http.Request
+	policy  RetryPolicy
+	results chan hedgeResult
+	cancels []context.CancelFunc
+	pending int
+}
+
+// hedge races up to policy.MaxAttempts concurrent attempts. A new attempt is
+// started every policy.HedgeAfter, or after the retry delay (backoff or
+// Retry-After) when a running attempt fails with a retryable outcome. The
+// first non-retryable outcome wins, as does a retryable one asking to wait
+// longer than the policy allows, and all other in-flight attempts are
+// cancelled.
+func (t *Transport) hedge(req *http.Request, policy RetryPolicy) (*http.Response, error) {
+	h := &hedger{t: t, req: req, policy: policy, results: make(chan hedgeResult, policy.MaxAttempts)}
+	if err := h.launch(); err != nil {
+		return nil, err
+	}
+	timer := time.NewTimer(policy.HedgeAfter)
+	defer timer.Stop()
+
+	for {
+		select {
+		case res := <-h.results:
+			h.pending--
+			if !shouldRetry(policy, res.resp, res.err) || (h.pending == 0 && !h.canLaunch()) {
+				h.abandon(res.attempt)
+				return res.winner()
+			}
+			delay, ok := h.t.retryDelay(policy, len(h.cancels)+1, res.resp)
+			if !ok {
+				h.abandon(res.attempt)
+				return res.winner()
+			}
+			res.release()
+			// Back off before the next attempt, hedging included.
+			timer.Reset(delay)
+		case <-req.Context().Done():
+			h.abandon(-1)
+			return nil, req.Context().Err()
+		case <-timer.C:
+			if err := h.launchIfAllowed(); err != nil {
+				return nil, err
+			}
+			timer.Reset(policy.HedgeAfter)
+		}
+	}
+}
+
+// canLaunch reports whether another attempt may be started.
+func (h *hedger) canLaunch() bool {
+	return len(h.cancels) < h.policy.MaxAttempts
+}
+
+// launchIfAllowed starts another attempt unless the attempt budget is spent.
+// On failure all in-flight attempts are abandoned.
+func (h *hedger) launchIfAllowed() error {
+	if !h.canLaunch() {
+		return nil
+	}
+	if err := h.launch(); err != nil {
+		h.abandon(-1)
+		return err
+	}
+	return nil
+}
+
+// launch starts a new attempt in the background.
+func (h *hedger) launch() error {
+	ctx, cancel := context.WithCancel(h.req.Context())
+	attemptReq, err := cloneRequest(ctx, h.req)
+	if err != nil {
+		cancel()
+		return err
+	}
+	attempt := len(h.cancels)
+	h.cancels = append(h.cancels, cancel)
+	h.pending++
+	go func() {
+		resp, err := h.t.base.RoundTrip(attemptReq)
+		h.results <- hedgeResult{attempt: attempt, resp: resp, err: err, cancel: cancel}
+	}()
+	return nil
+}
+
+// abandon cancels every attempt except the winner and releases the
+// resources of the ones still in flight once they complete.
+func (h *hedger) abandon(winner int) {
+	for i, cancel := range h.cancels {
+		if i != winner {
+			cancel()
+		}
+	}
+	pending, results := h.pending, h.results
+	if pending == 0 {
+		return
+	}
+	go func() {
+		for i := 0; i < pending; i++ {
+			res := <-results
+			res.release()
+		}
+	}()
+}
+
+// winner returns the response, tying the attempt context to the body so it
+// is cancelled once the caller closes the body.
+func (r hedgeResult) winner() (*http.Response, error) {
+	if r.resp == nil || r.resp.Body == nil {
+		if r.cancel != nil {
+			r.cancel()
+		}
+		return r.resp, r.err
+	}
+	r.resp.Body = &cancelOnClose{ReadCloser: r.resp.Body, cancel: r.cancel}
+	return r.resp, r.err
+}
+
+// release discards the response and cancels the attempt context.
+func (r hedgeResult) release() {
+	discard(r.resp)
+	if r.cancel != nil {
+		r.cancel()
+	}
+}
+
+// cancelOnClose cancels the attempt context when the body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the underlying body and cancels the attempt context.
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
@@ -0,0 +1,122 @@
+// Package client provides runtime helpers for HTTP clients talking to gork
+// services, such as retry and hedging policies driven by operation metadata.
+package client
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gork-labs/gork/pkg/api"
+)
+
+// Operation describes a single API operation as seen by a client.
+type Operation struct {
+	ID     string // operationId from the spec (handler name)
+	Method string // HTTP method (GET, POST, ...)
+	Path   string // Path template, e.g. "/users/{id}"
+}
+
+// Idempotent reports whether the operation's HTTP method is idempotent as
+// defined by RFC 9110. Only idempotent operations are retried or hedged.
+func (o Operation) Idempotent() bool {
+	switch strings.ToUpper(o.Method) {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// matches reports whether the given method and URL path correspond to this
+// operation. Template segments like "{id}" match any single path segment.
+func (o Operation) matches(method, path string) bool {
+	if !strings.EqualFold(o.Method, method) {
+		return false
+	}
+	tmpl := strings.Split(strings.Trim(o.Path, "/"), "/")
+	segs := strings.Split(strings.Trim(path, "/"), "/")
+	if len(tmpl) != len(segs) {
+		return false
+	}
+	for i, t := range tmpl {
+		if strings.HasPrefix(t, "{") && strings.HasSuffix(t, "}") {
+			if segs[i] == "" {
+				return false
+			}
+			continue
+		}
+		if t != segs[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// OperationsFromRegistry builds the operation list from a route registry.
+func OperationsFromRegistry(registry *api.RouteRegistry) []Operation {
+	routes := registry.GetRoutes()
+	ops := make([]Operation, 0, len(routes))
+	for _, route := range routes {
+		ops = append(ops, Operation{
+			ID:     route.HandlerName,
+			Method: route.Method,
+			Path:   route.Path,
+		})
+	}
+	return ops
+}
+
+// OperationsFromSpec builds the operation list from a generated OpenAPI
+// document. Operations are returned in a deterministic (path, method) order.
+func OperationsFromSpec(spec *api.OpenAPISpec) []Operation {
+	if spec == nil {
+		return nil
+	}
+	paths := make([]string, 0, len(spec.Paths))
+	for p := range spec.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	var ops []Operation
+	for _, p := range paths {
+		item := spec.Paths[p]
+		if item == nil {
+			continue
+		}
+		for _, mo := range []struct {
+			method string
+			op     *api.Operation
+		}{
+			{http.MethodGet, item.Get},
+			{http.MethodPost, item.Post},
+			{http.MethodPut, item.Put},
+			{http.MethodPatch, item.Patch},
+			{http.MethodDelete, item.Delete},
+		} {
+			if mo.op == nil {
+				continue
+			}
+			ops = append(ops, Operation{ID: mo.op.OperationID, Method: mo.method, Path: p})
+		}
+	}
+	return ops
+}
+
+type operationContextKey struct{}
+
+// WithOperation attaches the operation being performed to the context so
+// that the Transport can select the right policy without path matching.
+// Generated clients call this for every request.
+func WithOperation(ctx context.Context, op Operation) context.Context {
+	return context.WithValue(ctx, operationContextKey{}, op)
+}
+
+// OperationFromContext returns the operation previously attached with
+// WithOperation, if any.
+func OperationFromContext(ctx context.Context) (Operation, bool) {
+	op, ok := ctx.Value(operationContextKey{}).(Operation)
+	return op, ok
+}
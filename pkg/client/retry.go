@@ -0,0 +1,270 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how failed attempts of an idempotent operation are
+// retried and, optionally, hedged.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one.
+	// Values below 2 disable retries and hedging.
+	MaxAttempts int
+	// BaseDelay is the backoff before the second attempt. Each following
+	// attempt doubles the delay up to MaxDelay.
+	BaseDelay time.Duration
+	// MaxDelay caps both the exponential backoff and server-provided
+	// Retry-After values. A Retry-After beyond MaxDelay stops retrying and
+	// the response is returned to the caller as is.
+	MaxDelay time.Duration
+	// RetryableStatuses lists response codes that trigger a retry.
+	RetryableStatuses []int
+	// HedgeAfter enables hedging when positive: if an attempt has not
+	// completed after this duration another one is started in parallel and
+	// the first successful response wins.
+	HedgeAfter time.Duration
+}
+
+// DefaultRetryPolicy returns the policy used when no other policy applies:
+// three attempts with exponential backoff on 429, 502, 503 and 504.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:       3,
+		BaseDelay:         100 * time.Millisecond,
+		MaxDelay:          5 * time.Second,
+		RetryableStatuses: []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+	}
+}
+
+// backoff returns the delay before the given attempt (1-based, >= 2).
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseDelay
+	for i := 2; i < attempt; i++ {
+		d *= 2
+		if p.MaxDelay > 0 && d >= p.MaxDelay {
+			return p.MaxDelay
+		}
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return d
+}
+
+// retryableStatus reports whether the status code should trigger a retry.
+func (p RetryPolicy) retryableStatus(code int) bool {
+	for _, c := range p.RetryableStatuses {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// Option configures a Transport.
+type Option func(*Transport)
+
+// WithDefaultPolicy replaces the policy applied to operations without a
+// dedicated policy.
+func WithDefaultPolicy(p RetryPolicy) Option {
+	return func(t *Transport) { t.defaultPolicy = p }
+}
+
+// WithOperationPolicy sets the policy for a single operation identified by
+// its operationId.
+func WithOperationPolicy(operationID string, p RetryPolicy) Option {
+	return func(t *Transport) { t.policies[operationID] = p }
+}
+
+// Transport is an http.RoundTripper that retries and hedges idempotent
+// operations according to per-operation policies. Requests that cannot be
+// attributed to a known operation, non-idempotent operations and requests
+// whose body cannot be replayed are sent exactly once.
+type Transport struct {
+	base          http.RoundTripper
+	operations    []Operation
+	defaultPolicy RetryPolicy
+	policies      map[string]RetryPolicy
+
+	// sleep and now allow dependency injection for testing.
+	sleep func(ctx context.Context, d time.Duration) error
+	now   func() time.Time
+}
+
+// NewTransport wraps base (http.DefaultTransport when nil) with retry and
+// hedging support for the given operations.
+func NewTransport(base http.RoundTripper, operations []Operation, opts ...Option) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	t := &Transport{
+		base:          base,
+		operations:    operations,
+		defaultPolicy: DefaultRetryPolicy(),
+		policies:      map[string]RetryPolicy{},
+		sleep:         sleepContext,
+		now:           time.Now,
+	}
+	for _, o := range opts {
+		o(t)
+	}
+	return t
+}
+
+// NewHTTPClient returns an *http.Client using a retrying Transport.
+func NewHTTPClient(operations []Operation, opts ...Option) *http.Client {
+	return &http.Client{Transport: NewTransport(nil, operations, opts...)}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	op, ok := t.resolveOperation(req)
+	if !ok || !op.Idempotent() || !replayable(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	policy := t.policyFor(op)
+	if policy.MaxAttempts < 2 {
+		return t.base.RoundTrip(req)
+	}
+	if policy.HedgeAfter > 0 {
+		return t.hedge(req, policy)
+	}
+	return t.retry(req, policy)
+}
+
+// resolveOperation finds the operation for a request, preferring the one
+// attached to the context over path matching.
+func (t *Transport) resolveOperation(req *http.Request) (Operation, bool) {
+	if op, ok := OperationFromContext(req.Context()); ok {
+		return op, true
+	}
+	for _, op := range t.operations {
+		if op.matches(req.Method, req.URL.Path) {
+			return op, true
+		}
+	}
+	return Operation{}, false
+}
+
+// policyFor returns the policy configured for the operation.
+func (t *Transport) policyFor(op Operation) RetryPolicy {
+	if p, ok := t.policies[op.ID]; ok {
+		return p
+	}
+	return t.defaultPolicy
+}
+
+// retry performs sequential attempts with backoff.
+func (t *Transport) retry(req *http.Request, policy RetryPolicy) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		attemptReq, err := cloneRequest(req.Context(), req)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := t.base.RoundTrip(attemptReq)
+		if attempt >= policy.MaxAttempts || !shouldRetry(policy, resp, err) {
+			return resp, err
+		}
+
+		delay, ok := t.retryDelay(policy, attempt+1, resp)
+		if !ok {
+			return resp, err
+		}
+		discard(resp)
+		if serr := t.sleep(req.Context(), delay); serr != nil {
+			return nil, serr
+		}
+	}
+}
+
+// retryDelay computes the wait before the next attempt. It honours a
+// Retry-After header on the response and reports false when the server asks
+// to wait longer than the policy allows.
+func (t *Transport) retryDelay(policy RetryPolicy, nextAttempt int, resp *http.Response) (time.Duration, bool) {
+	delay := policy.backoff(nextAttempt)
+	if resp == nil {
+		return delay, true
+	}
+	ra, ok := parseRetryAfter(resp.Header.Get("Retry-After"), t.now())
+	if !ok {
+		return delay, true
+	}
+	if policy.MaxDelay > 0 && ra > policy.MaxDelay {
+		return 0, false
+	}
+	return ra, true
+}
+
+// shouldRetry reports whether an attempt outcome is worth retrying.
+func shouldRetry(policy RetryPolicy, resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	return policy.retryableStatus(resp.StatusCode)
+}
+
+// parseRetryAfter parses a Retry-After header given either as delay seconds
+// or as an HTTP date.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		d := at.Sub(now)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// replayable reports whether the request body can be sent more than once.
+func replayable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// cloneRequest prepares a copy of req bound to ctx with a fresh body.
+func cloneRequest(ctx context.Context, req *http.Request) (*http.Request, error) {
+	clone := req.Clone(ctx)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		clone.Body = body
+	}
+	return clone, nil
+}
+
+// discard drains and closes a response body so the connection can be reused.
+func discard(resp *http.Response) {
+	if resp == nil || resp.Body == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
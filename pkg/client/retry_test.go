@@ -0,0 +1,538 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gork-labs/gork/pkg/api"
+)
+
+// roundTripFunc adapts a function to http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func response(code int, header http.Header) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{StatusCode: code, Header: header, Body: io.NopCloser(strings.NewReader("body"))}
+}
+
+func noSleep(t *Transport) { t.sleep = func(context.Context, time.Duration) error { return nil } }
+
+var usersOps = []Operation{
+	{ID: "GetUser", Method: http.MethodGet, Path: "/users/{id}"},
+	{ID: "CreateUser", Method: http.MethodPost, Path: "/users"},
+}
+
+func TestOperationIdempotent(t *testing.T) {
+	for method, want := range map[string]bool{
+		"GET": true, "head": true, "PUT": true, "DELETE": true, "OPTIONS": true, "TRACE": true,
+		"POST": false, "PATCH": false,
+	} {
+		if got := (Operation{Method: method}).Idempotent(); got != want {
+			t.Errorf("Idempotent(%s) = %v, want %v", method, got, want)
+		}
+	}
+}
+
+func TestOperationMatches(t *testing.T) {
+	op := Operation{Method: "GET", Path: "/users/{id}/posts"}
+	cases := []struct {
+		method, path string
+		want         bool
+	}{
+		{"GET", "/users/42/posts", true},
+		{"get", "/users/42/posts/", true},
+		{"POST", "/users/42/posts", false},
+		{"GET", "/users//posts", false},
+		{"GET", "/users/42", false},
+		{"GET", "/teams/42/posts", false},
+	}
+	for _, c := range cases {
+		if got := op.matches(c.method, c.path); got != c.want {
+			t.Errorf("matches(%s %s) = %v, want %v", c.method, c.path, got, c.want)
+		}
+	}
+}
+
+func TestRetryOnRetryableStatus(t *testing.T) {
+	var calls int32
+	base := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			return response(http.StatusServiceUnavailable, nil), nil
+		}
+		return response(http.StatusOK, nil), nil
+	})
+
+	var delays []time.Duration
+	tr := NewTransport(base, usersOps)
+	tr.sleep = func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "http://svc/users/1", nil)
+	resp, err := tr.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected result: %v %v", resp, err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 attempts, got %d", calls)
+	}
+	if len(delays) != 2 || delays[0] != 100*time.Millisecond || delays[1] != 200*time.Millisecond {
+		t.Fatalf("unexpected backoff delays: %v", delays)
+	}
+}
+
+func TestNoRetryForNonIdempotentOrUnknownOperations(t *testing.T) {
+	var calls int32
+	base := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		return response(http.StatusServiceUnavailable, nil), nil
+	})
+	tr := NewTransport(base, usersOps, noSleep)
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodPost, "http://svc/users", strings.NewReader("{}")),
+		httptest.NewRequest(http.MethodGet, "http://svc/unknown", nil),
+	} {
+		atomic.StoreInt32(&calls, 0)
+		if _, err := tr.RoundTrip(req); err != nil {
+			t.Fatal(err)
+		}
+		if calls != 1 {
+			t.Fatalf("%s %s: expected single attempt, got %d", req.Method, req.URL.Path, calls)
+		}
+	}
+}
+
+func TestNoRetryForNonReplayableBody(t *testing.T) {
+	var calls int32
+	base := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		return response(http.StatusServiceUnavailable, nil), nil
+	})
+	tr := NewTransport(base, []Operation{{ID: "Put", Method: "PUT", Path: "/items/{id}"}}, noSleep)
+	req, _ := http.NewRequest(http.MethodPut, "http://svc/items/1", io.NopCloser(strings.NewReader("x")))
+	if _, err := tr.RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected single attempt, got %d", calls)
+	}
+}
+
+func TestRetryReplaysBody(t *testing.T) {
+	var bodies []string
+	base := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if len(bodies) == 1 {
+			return nil, errors.New("connection reset")
+		}
+		return response(http.StatusOK, nil), nil
+	})
+	tr := NewTransport(base, []Operation{{ID: "Put", Method: "PUT", Path: "/items/{id}"}}, noSleep)
+	req, _ := http.NewRequest(http.MethodPut, "http://svc/items/1", strings.NewReader("payload"))
+	if _, err := tr.RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+	if len(bodies) != 2 || bodies[0] != "payload" || bodies[1] != "payload" {
+		t.Fatalf("unexpected bodies: %q", bodies)
+	}
+}
+
+func TestGetBodyErrorIsReturned(t *testing.T) {
+	tr := NewTransport(roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return response(http.StatusOK, nil), nil
+	}), []Operation{{ID: "Put", Method: "PUT", Path: "/items/{id}"}}, noSleep)
+	req, _ := http.NewRequest(http.MethodPut, "http://svc/items/1", strings.NewReader("x"))
+	req.GetBody = func() (io.ReadCloser, error) { return nil, errors.New("no body") }
+	if _, err := tr.RoundTrip(req); err == nil {
+		t.Fatal("expected GetBody error")
+	}
+}
+
+func TestRetryAfterHonoured(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := map[string]time.Duration{
+		"2": 2 * time.Second,
+		now.Add(3 * time.Second).Format(http.TimeFormat): 3 * time.Second,
+		now.Add(-time.Hour).Format(http.TimeFormat):      0,
+		"garbage": 100 * time.Millisecond,
+		"-1":      100 * time.Millisecond,
+	}
+	for header, want := range cases {
+		calls := 0
+		base := roundTripFunc(func(*http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				return response(http.StatusTooManyRequests, http.Header{"Retry-After": {header}}), nil
+			}
+			return response(http.StatusOK, nil), nil
+		})
+		var got time.Duration
+		tr := NewTransport(base, usersOps)
+		tr.now = func() time.Time { return now }
+		tr.sleep = func(_ context.Context, d time.Duration) error { got = d; return nil }
+		if _, err := tr.RoundTrip(httptest.NewRequest(http.MethodGet, "http://svc/users/1", nil)); err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("Retry-After %q: slept %v, want %v", header, got, want)
+		}
+	}
+}
+
+func TestRetryAfterBeyondMaxDelayStops(t *testing.T) {
+	calls := 0
+	base := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		calls++
+		return response(http.StatusServiceUnavailable, http.Header{"Retry-After": {"60"}}), nil
+	})
+	tr := NewTransport(base, usersOps, noSleep)
+	resp, err := tr.RoundTrip(httptest.NewRequest(http.MethodGet, "http://svc/users/1", nil))
+	if err != nil || resp.StatusCode != http.StatusServiceUnavailable || calls != 1 {
+		t.Fatalf("expected the 503 to be returned after one attempt, got %v %v (calls=%d)", resp, err, calls)
+	}
+}
+
+func TestRetryStopsOnContextCancellation(t *testing.T) {
+	calls := 0
+	base := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		calls++
+		return nil, context.Canceled
+	})
+	tr := NewTransport(base, usersOps, noSleep)
+	if _, err := tr.RoundTrip(httptest.NewRequest(http.MethodGet, "http://svc/users/1", nil)); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected single attempt, got %d", calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	tr = NewTransport(roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return response(http.StatusBadGateway, nil), nil
+	}), usersOps)
+	req := httptest.NewRequest(http.MethodGet, "http://svc/users/1", nil).WithContext(ctx)
+	if _, err := tr.RoundTrip(req); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected sleep to observe cancellation, got %v", err)
+	}
+}
+
+func TestPolicySelection(t *testing.T) {
+	calls := 0
+	base := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		calls++
+		return response(http.StatusBadGateway, nil), nil
+	})
+	tr := NewTransport(base, usersOps, noSleep,
+		WithOperationPolicy("GetUser", RetryPolicy{MaxAttempts: 1}),
+		WithDefaultPolicy(RetryPolicy{MaxAttempts: 5, RetryableStatuses: []int{http.StatusBadGateway}}),
+	)
+	if _, err := tr.RoundTrip(httptest.NewRequest(http.MethodGet, "http://svc/users/1", nil)); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Fatalf("per-operation policy should disable retries, got %d attempts", calls)
+	}
+
+	calls = 0
+	ctx := WithOperation(context.Background(), Operation{ID: "ListTeams", Method: "GET", Path: "/teams"})
+	req := httptest.NewRequest(http.MethodGet, "http://svc/v1/teams", nil).WithContext(ctx)
+	if _, err := tr.RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 5 {
+		t.Fatalf("default policy should apply to context operation, got %d attempts", calls)
+	}
+}
+
+func TestBackoffCapped(t *testing.T) {
+	p := RetryPolicy{BaseDelay: time.Second, MaxDelay: 3 * time.Second}
+	for attempt, want := range map[int]time.Duration{2: time.Second, 3: 2 * time.Second, 4: 3 * time.Second, 9: 3 * time.Second} {
+		if got := p.backoff(attempt); got != want {
+			t.Errorf("backoff(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+	if got := (RetryPolicy{BaseDelay: 5 * time.Second, MaxDelay: time.Second}).backoff(2); got != time.Second {
+		t.Errorf("expected base delay to be capped, got %v", got)
+	}
+}
+
+func TestHedgingFirstResponseWins(t *testing.T) {
+	release := make(chan struct{})
+	var calls int32
+	var mu sync.Mutex
+	cancelled := 0
+	base := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			// The first attempt is slow and only finishes when cancelled.
+			select {
+			case <-r.Context().Done():
+				mu.Lock()
+				cancelled++
+				mu.Unlock()
+				close(release)
+				return nil, r.Context().Err()
+			case <-time.After(5 * time.Second):
+				return response(http.StatusOK, nil), nil
+			}
+		}
+		return response(http.StatusOK, http.Header{"X-Attempt": {"2"}}), nil
+	})
+	policy := DefaultRetryPolicy()
+	policy.HedgeAfter = 10 * time.Millisecond
+	tr := NewTransport(base, usersOps, WithDefaultPolicy(policy))
+
+	resp, err := tr.RoundTrip(httptest.NewRequest(http.MethodGet, "http://svc/users/1", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Header.Get("X-Attempt") != "2" {
+		t.Fatalf("expected hedged attempt to win")
+	}
+	_ = resp.Body.Close()
+
+	select {
+	case <-release:
+	case <-time.After(2 * time.Second):
+		t.Fatal("slow attempt was not cancelled")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if cancelled != 1 {
+		t.Fatalf("expected losing attempt to be cancelled")
+	}
+}
+
+func TestHedgingRetriesFailures(t *testing.T) {
+	var calls int32
+	base := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			return response(http.StatusServiceUnavailable, nil), nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}, nil
+	})
+	policy := DefaultRetryPolicy()
+	policy.HedgeAfter = time.Hour
+	tr := NewTransport(base, usersOps, WithDefaultPolicy(policy))
+	resp, err := tr.RoundTrip(httptest.NewRequest(http.MethodGet, "http://svc/users/1", nil))
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected result: %v %v", resp, err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestHedgingHonoursRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	// hedge answers the first attempt with a 429 asking to wait retryAfter
+	// from now, and returns the final status and when each attempt started.
+	hedge := func(retryAfter time.Duration) (int, []time.Time) {
+		var mu sync.Mutex
+		var starts []time.Time
+		base := roundTripFunc(func(*http.Request) (*http.Response, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			starts = append(starts, time.Now())
+			if len(starts) == 1 {
+				return response(http.StatusTooManyRequests, http.Header{"Retry-After": {now.Add(retryAfter).Format(http.TimeFormat)}}), nil
+			}
+			return response(http.StatusOK, nil), nil
+		})
+		policy := DefaultRetryPolicy()
+		policy.HedgeAfter = time.Millisecond
+		tr := NewTransport(base, usersOps, WithDefaultPolicy(policy))
+		// The clock runs 50ms short of a whole second past now.
+		tr.now = func() time.Time { return now.Add(950 * time.Millisecond) }
+
+		resp, err := tr.RoundTrip(httptest.NewRequest(http.MethodGet, "http://svc/users/1", nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		return resp.StatusCode, starts
+	}
+
+	status, starts := hedge(time.Second)
+	if status != http.StatusOK || len(starts) != 2 {
+		t.Fatalf("expected 200 after 2 attempts, got %d after %d", status, len(starts))
+	}
+	if waited := starts[1].Sub(starts[0]); waited < 50*time.Millisecond {
+		t.Errorf("second attempt started %v after a 429, want at least 50ms", waited)
+	}
+
+	// A Retry-After beyond MaxDelay stops hedging with the 429.
+	if status, starts := hedge(time.Minute); status != http.StatusTooManyRequests || len(starts) != 1 {
+		t.Errorf("expected the 429 after one attempt, got %d after %d", status, len(starts))
+	}
+}
+
+func TestHedgingReturnsLastFailure(t *testing.T) {
+	var calls int32
+	base := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		return response(http.StatusBadGateway, nil), nil
+	})
+	policy := DefaultRetryPolicy()
+	policy.HedgeAfter = time.Millisecond
+	tr := NewTransport(base, usersOps, WithDefaultPolicy(policy))
+	resp, err := tr.RoundTrip(httptest.NewRequest(http.MethodGet, "http://svc/users/1", nil))
+	if err != nil || resp.StatusCode != http.StatusBadGateway {
+		t.Fatalf("unexpected result: %v %v", resp, err)
+	}
+	if b, _ := io.ReadAll(resp.Body); string(b) != "body" {
+		t.Fatalf("last response body should be readable, got %q", b)
+	}
+	_ = resp.Body.Close()
+	if calls != 3 {
+		t.Fatalf("expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestHedgingGetBodyError(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	policy.HedgeAfter = time.Millisecond
+	ops := []Operation{{ID: "Put", Method: "PUT", Path: "/items/{id}"}}
+
+	tr := NewTransport(roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return response(http.StatusOK, nil), nil
+	}), ops, WithDefaultPolicy(policy))
+	req, _ := http.NewRequest(http.MethodPut, "http://svc/items/1", strings.NewReader("x"))
+	req.GetBody = func() (io.ReadCloser, error) { return nil, errors.New("no body") }
+	if _, err := tr.RoundTrip(req); err == nil {
+		t.Fatal("expected GetBody error on first attempt")
+	}
+
+	for _, slow := range []bool{false, true} {
+		var calls int32
+		tr = NewTransport(roundTripFunc(func(*http.Request) (*http.Response, error) {
+			atomic.AddInt32(&calls, 1)
+			if slow {
+				time.Sleep(20 * time.Millisecond)
+				return response(http.StatusOK, nil), nil
+			}
+			return nil, errors.New("reset")
+		}), ops, WithDefaultPolicy(policy))
+		req, _ = http.NewRequest(http.MethodPut, "http://svc/items/1", strings.NewReader("x"))
+		n := 0
+		req.GetBody = func() (io.ReadCloser, error) {
+			n++
+			if n > 1 {
+				return nil, errors.New("no body")
+			}
+			return io.NopCloser(strings.NewReader("x")), nil
+		}
+		if _, err := tr.RoundTrip(req); err == nil {
+			t.Fatalf("slow=%v: expected GetBody error on follow-up attempt", slow)
+		}
+	}
+}
+
+func TestHedgeResultWinnerWithoutBody(t *testing.T) {
+	cancelled := false
+	r := hedgeResult{err: errors.New("x"), cancel: func() { cancelled = true }}
+	if _, err := r.winner(); err == nil || !cancelled {
+		t.Fatal("expected error to be returned and context cancelled")
+	}
+	if resp, _ := (hedgeResult{}).winner(); resp != nil {
+		t.Fatal("expected nil response")
+	}
+	(hedgeResult{}).release()
+}
+
+func TestOperationsFromRegistryAndSpec(t *testing.T) {
+	reg := api.NewRouteRegistry()
+	reg.Register(&api.RouteInfo{Method: "GET", Path: "/users/{id}", HandlerName: "GetUser"})
+	ops := OperationsFromRegistry(reg)
+	if len(ops) != 1 || ops[0] != (Operation{ID: "GetUser", Method: "GET", Path: "/users/{id}"}) {
+		t.Fatalf("unexpected operations: %+v", ops)
+	}
+
+	spec := &api.OpenAPISpec{Paths: map[string]*api.PathItem{
+		"/users":      {Get: &api.Operation{OperationID: "ListUsers"}, Post: &api.Operation{OperationID: "CreateUser"}},
+		"/users/{id}": {Put: &api.Operation{OperationID: "UpdateUser"}, Patch: &api.Operation{OperationID: "PatchUser"}, Delete: &api.Operation{OperationID: "DeleteUser"}},
+		"/nil":        nil,
+	}}
+	ops = OperationsFromSpec(spec)
+	var ids []string
+	for _, op := range ops {
+		ids = append(ids, op.Method+" "+op.ID)
+	}
+	want := "GET ListUsers,POST CreateUser,PUT UpdateUser,PATCH PatchUser,DELETE DeleteUser"
+	if strings.Join(ids, ",") != want {
+		t.Fatalf("unexpected operations: %v", ids)
+	}
+	if OperationsFromSpec(nil) != nil {
+		t.Fatal("expected nil for nil spec")
+	}
+}
+
+func TestNewHTTPClientAgainstServer(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	c := NewHTTPClient(usersOps)
+	resp, err := c.Get(srv.URL + "/users/7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK || calls != 2 {
+		t.Fatalf("unexpected result: status=%d calls=%d", resp.StatusCode, calls)
+	}
+}
+
+func TestSleepContext(t *testing.T) {
+	if err := sleepContext(context.Background(), time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := sleepContext(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation, got %v", err)
+	}
+}
+
+func TestHedgingStopsLaunchingWhenBudgetSpent(t *testing.T) {
+	var calls int32
+	base := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(20 * time.Millisecond)
+		return response(http.StatusOK, nil), nil
+	})
+	policy := RetryPolicy{MaxAttempts: 2, HedgeAfter: time.Millisecond}
+	tr := NewTransport(base, usersOps, WithDefaultPolicy(policy))
+	resp, err := tr.RoundTrip(httptest.NewRequest(http.MethodGet, "http://svc/users/1", nil))
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected result: %v %v", resp, err)
+	}
+	_ = resp.Body.Close()
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Fatalf("expected exactly 2 attempts, got %d", n)
+	}
+}
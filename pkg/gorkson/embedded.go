@@ -0,0 +1,125 @@
+package gorkson
+
+import (
+	"reflect"
+	"sync"
+)
+
+// fieldInfo is a struct field as gorkson encodes it.
+type fieldInfo struct {
+	name string
+	// index is the path to the field, through embedded structs for
+	// promoted fields.
+	index []int
+	field reflect.StructField
+	tag   GorkTagInfo
+}
+
+// fieldCache holds the fields of each struct type seen, so that tags are
+// parsed once per type rather than on every call. Callers must not modify
+// the cached slices.
+var fieldCache sync.Map // map[reflect.Type][]fieldInfo
+
+// structFields returns the named fields of struct t. As with encoding/json,
+// the fields of embedded structs without a gork or json name are promoted,
+// and a field hides promoted fields of the same name from deeper levels;
+// among fields at the same depth the first wins.
+func (m *Marshaler) structFields(t reflect.Type) []fieldInfo {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.([]fieldInfo)
+	}
+	fields := m.collectFields(t)
+	fieldCache.Store(t, fields)
+	return fields
+}
+
+// collectFields walks t breadth-first for structFields.
+func (m *Marshaler) collectFields(t reflect.Type) []fieldInfo {
+	type level struct {
+		t     reflect.Type
+		index []int
+	}
+	var fields []fieldInfo
+	taken := map[string]bool{}
+	visited := map[reflect.Type]bool{}
+	for current := []level{{t: t}}; len(current) > 0; {
+		var next []level
+		var found []fieldInfo
+		for _, l := range current {
+			if visited[l.t] {
+				continue
+			}
+			visited[l.t] = true
+			for i := 0; i < l.t.NumField(); i++ {
+				field := l.t.Field(i)
+				index := append(append([]int{}, l.index...), i)
+				if embedded, ok := m.embeddedStruct(field); ok {
+					next = append(next, level{t: embedded, index: index})
+					continue
+				}
+				name := m.getFieldName(field)
+				if !field.IsExported() || name == "" || name == "-" {
+					continue
+				}
+				found = append(found, fieldInfo{name: name, index: index, field: field, tag: parseGorkTag(field.Tag.Get("gork"))})
+			}
+		}
+		for _, f := range found {
+			if !taken[f.name] {
+				taken[f.name] = true
+				fields = append(fields, f)
+			}
+		}
+		current = next
+	}
+	return fields
+}
+
+// embeddedStruct returns the struct type whose fields field promotes: an
+// embedded struct, or pointer to one, without a gork or json name.
+func (m *Marshaler) embeddedStruct(field reflect.StructField) (reflect.Type, bool) {
+	if !field.Anonymous || m.getFieldName(field) != "" || field.Tag.Get("json") == "-" || field.Tag.Get("gork") == "-" {
+		return nil, false
+	}
+	t := field.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, false
+	}
+	return t, true
+}
+
+// fieldByIndex returns the field of v at index, or false when the path runs
+// through a nil embedded pointer.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// settableFieldByIndex returns the field of v at index, allocating nil
+// embedded pointers on the way, or false when one cannot be set.
+func settableFieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
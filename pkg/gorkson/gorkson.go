@@ -2,9 +2,20 @@
 package gorkson
 
 import (
+	"encoding"
 	"encoding/json"
 	"reflect"
+	"sort"
 	"strings"
+	"time"
+)
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	jsonMarshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
 )
 
 // Marshaler handles JSON marshaling using gork tags only.
@@ -49,6 +60,21 @@ func (m *Marshaler) convertToGorkSON(v any) any {
 		val = val.Elem()
 	}
 
+	// Durations are sent like "1m30s"; types that marshal themselves, such
+	// as time.Time, are left to encoding/json
+	if !val.IsValid() {
+		return v
+	}
+	if val.Type() == durationType {
+		return time.Duration(val.Int()).String()
+	}
+	if isSQLNullType(val.Type()) {
+		return m.sqlNullValue(val)
+	}
+	if val.Type().Implements(jsonMarshalerType) || val.Type().Implements(textMarshalerType) {
+		return val.Interface()
+	}
+
 	// Handle slices by converting each element
 	if val.Kind() == reflect.Slice {
 		result := make([]interface{}, val.Len())
@@ -58,6 +84,20 @@ func (m *Marshaler) convertToGorkSON(v any) any {
 		return result
 	}
 
+	// Handle maps by converting each value, keeping the key type so that
+	// encoding/json formats the keys.
+	if val.Kind() == reflect.Map {
+		if val.IsNil() {
+			return nil
+		}
+		result := reflect.MakeMapWithSize(reflect.MapOf(val.Type().Key(), reflect.TypeOf((*any)(nil)).Elem()), val.Len())
+		iter := val.MapRange()
+		for iter.Next() {
+			result.SetMapIndex(iter.Key(), reflect.ValueOf(m.convertToGorkSON(iter.Value().Interface())))
+		}
+		return result.Interface()
+	}
+
 	if val.Kind() != reflect.Struct {
 		return v
 	}
@@ -65,24 +105,18 @@ func (m *Marshaler) convertToGorkSON(v any) any {
 	result := make(map[string]interface{})
 	typ := val.Type()
 
-	for i := 0; i < val.NumField(); i++ {
-		field := typ.Field(i)
-		fieldValue := val.Field(i)
-
-		// Skip unexported fields
-		if !fieldValue.CanInterface() {
+	for _, f := range m.structFields(typ) {
+		fieldValue, ok := fieldByIndex(val, f.index)
+		if !ok || !fieldValue.CanInterface() {
 			continue
 		}
 
-		// Get field name from gork tag
-		fieldName := m.getFieldName(field)
-		if fieldName == "" || fieldName == "-" {
+		if m.omitField(f.field, fieldValue) {
 			continue
 		}
 
 		// Recursively convert nested structs
-		value := m.convertToGorkSON(fieldValue.Interface())
-		result[fieldName] = value
+		result[f.name] = m.convertToGorkSON(fieldValue.Interface())
 	}
 
 	return result
@@ -97,6 +131,7 @@ func (m *Marshaler) convertFromGorkSON(jsonMap map[string]any, v any) error {
 
 	structVal := val.Elem()
 	fieldMap := m.buildFieldMap(structVal.Type())
+	m.applyAliases(structVal.Type(), jsonMap)
 	return m.setFieldsFromMap(structVal, fieldMap, jsonMap)
 }
 
@@ -114,25 +149,74 @@ func (m *Marshaler) convertNonStruct(jsonMap map[string]any, v any) error {
 	return json.Unmarshal(data, v)
 }
 
-// buildFieldMap creates a mapping from field names to field indices.
-func (m *Marshaler) buildFieldMap(structType reflect.Type) map[string]int {
-	fieldMap := make(map[string]int)
-	for i := 0; i < structType.NumField(); i++ {
-		field := structType.Field(i)
-		fieldName := m.getFieldName(field)
-		if fieldName != "" && fieldName != "-" {
-			fieldMap[fieldName] = i
-		}
+// buildFieldMap maps field names, promoted fields included, to the paths
+// of the fields.
+func (m *Marshaler) buildFieldMap(structType reflect.Type) map[string][]int {
+	fieldMap := make(map[string][]int)
+	for _, f := range m.structFields(structType) {
+		fieldMap[f.name] = f.index
 	}
 	return fieldMap
 }
 
+// applyAliases copies values sent under an alias (`gork:"new,alias=old"`),
+// or with nocase under a differently cased name, to the field's current name
+// unless the current name is present as well, in which case it wins.
+func (m *Marshaler) applyAliases(structType reflect.Type, jsonMap map[string]any) {
+	for _, f := range m.structFields(structType) {
+		if _, ok := jsonMap[f.name]; ok {
+			continue
+		}
+		if key, ok := matchKey(jsonMap, f.name, f.tag); ok {
+			jsonMap[f.name] = jsonMap[key]
+		}
+	}
+}
+
+// matchKey returns the key of jsonMap a field named name accepts in place of
+// its name: the first of its aliases present or, with nocase, a key equal to
+// the name or an alias under case folding.
+func matchKey(jsonMap map[string]any, name string, tagInfo GorkTagInfo) (string, bool) {
+	for _, alias := range tagInfo.Aliases {
+		if _, ok := jsonMap[alias]; ok {
+			return alias, true
+		}
+	}
+	if !tagInfo.NoCase {
+		return "", false
+	}
+	keys := make([]string, 0, len(jsonMap))
+	for key := range jsonMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if tagInfo.acceptsFolded(key, name) {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// acceptsFolded reports whether a nocase field named name accepts key.
+func (t GorkTagInfo) acceptsFolded(key, name string) bool {
+	if !t.NoCase {
+		return false
+	}
+	for _, candidate := range append([]string{name}, t.Aliases...) {
+		if strings.EqualFold(key, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
 // setFieldsFromMap sets struct field values from the JSON map.
-func (m *Marshaler) setFieldsFromMap(structVal reflect.Value, fieldMap map[string]int, jsonMap map[string]any) error {
+func (m *Marshaler) setFieldsFromMap(structVal reflect.Value, fieldMap map[string][]int, jsonMap map[string]any) error {
 	for jsonKey, jsonValue := range jsonMap {
 		if fieldIndex, exists := fieldMap[jsonKey]; exists {
-			field := structVal.Field(fieldIndex)
-			if field.CanSet() {
+			field, ok := settableFieldByIndex(structVal, fieldIndex)
+			if ok && field.CanSet() {
 				if err := m.setFieldValue(field, jsonValue); err != nil {
 					return err
 				}
@@ -165,9 +249,68 @@ func (m *Marshaler) getFieldName(field reflect.StructField) string {
 	return ""
 }
 
+// omitField reports whether Marshal leaves out field: it is writeonly, or
+// its omitempty or omitzero option (from the gork tag, or the json tag
+// without one) asks to drop its current value.
+func (m *Marshaler) omitField(field reflect.StructField, value reflect.Value) bool {
+	tagInfo := parseGorkTag(field.Tag.Get("gork"))
+	if tagInfo.Name == "" {
+		tagInfo = parseGorkTag(field.Tag.Get("json"))
+	}
+	return tagInfo.WriteOnly || (tagInfo.OmitEmpty && isEmptyValue(value)) || (tagInfo.OmitZero && isZeroValue(value))
+}
+
+// isEmptyValue reports whether v is empty in the sense of encoding/json's
+// omitempty: false, 0, a nil pointer or interface, or an empty array, slice,
+// map or string.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Ptr:
+		return v.IsZero()
+	}
+	return false
+}
+
+// isZeroValue reports whether v is zero in the sense of encoding/json's
+// omitzero: its IsZero method says so, or it is the zero value of its type.
+func isZeroValue(v reflect.Value) bool {
+	if zeroer, ok := v.Interface().(interface{ IsZero() bool }); ok {
+		if v.Kind() == reflect.Ptr && v.IsNil() {
+			return true
+		}
+		return zeroer.IsZero()
+	}
+	return v.IsZero()
+}
+
 // GorkTagInfo represents parsed information from a gork struct tag.
 type GorkTagInfo struct {
 	Name string
+	// Aliases are previous names still accepted when unmarshaling, listed
+	// as `alias=old` once per alias or as `alias=old|older`. They are never
+	// emitted by Marshal.
+	Aliases []string
+	// NoCase accepts the name and aliases in any case when unmarshaling
+	// (`gork:"user_id,nocase"`); exact matches win.
+	NoCase bool
+	// OmitEmpty drops false, 0, nil and empty values from Marshal output
+	// (`gork:"tags,omitempty"`).
+	OmitEmpty bool
+	// OmitZero drops zero values, as reported by an IsZero method if the
+	// type has one, from Marshal output (`gork:"deleted_at,omitzero"`).
+	OmitZero bool
+	// ReadOnly marks fields set by servers only, which request bodies may
+	// not contain (`gork:"id,readonly"`, see CheckReadOnly).
+	ReadOnly bool
+	// WriteOnly marks fields sent by clients only, such as passwords
+	// (`gork:"password,writeonly"`). Marshal never emits them.
+	WriteOnly bool
 }
 
 // parseGorkTag parses a gork struct tag and returns the tag information.
@@ -176,21 +319,81 @@ func parseGorkTag(tag string) GorkTagInfo {
 		return GorkTagInfo{}
 	}
 
-	// Split by comma to handle multiple options (e.g., "fieldName,discriminator=value")
-	parts := strings.Split(tag, ",")
-	name := strings.TrimSpace(parts[0])
+	// Split by comma to handle multiple options (e.g., "fieldName,discriminator=value"),
+	// keeping commas of single-quoted values (e.g., "tags,example='a,b'")
+	parts := SplitTag(tag)
+	info := GorkTagInfo{Name: strings.TrimSpace(parts[0])}
+	for _, part := range parts[1:] {
+		part = strings.TrimSpace(part)
+		if aliases, ok := strings.CutPrefix(part, "alias="); ok {
+			for _, alias := range strings.Split(aliases, "|") {
+				if alias = strings.TrimSpace(alias); alias != "" {
+					info.Aliases = append(info.Aliases, alias)
+				}
+			}
+		}
+		switch part {
+		case "nocase":
+			info.NoCase = true
+		case "omitempty":
+			info.OmitEmpty = true
+		case "omitzero":
+			info.OmitZero = true
+		case "readonly":
+			info.ReadOnly = true
+		case "writeonly":
+			info.WriteOnly = true
+		}
+	}
 
-	return GorkTagInfo{
-		Name: name,
+	return info
+}
+
+// SplitTag splits a gork tag into its comma separated options. Commas inside
+// single quotes, which values containing commas are written in
+// (`gork:"tags,example='a,b'"`), do not split. The request parser, code
+// generators and linter all split tags with it so they read them alike.
+func SplitTag(tag string) []string {
+	var parts []string
+	quoted := false
+	start := 0
+	for i := 0; i < len(tag); i++ {
+		switch tag[i] {
+		case '\'':
+			quoted = !quoted
+		case ',':
+			if !quoted {
+				parts = append(parts, tag[start:i])
+				start = i + 1
+			}
+		}
 	}
+	return append(parts, tag[start:])
 }
 
 // setFieldValue sets a reflect.Value from an interface{} value.
 func (m *Marshaler) setFieldValue(field reflect.Value, value any) error {
 	if value == nil {
+		// As with encoding/json, unmarshalers see null so that they can
+		// tell it from an absent field.
+		if field.Kind() != reflect.Ptr && field.CanAddr() && reflect.PointerTo(field.Type()).Implements(jsonUnmarshalerType) {
+			return field.Addr().Interface().(json.Unmarshaler).UnmarshalJSON([]byte("null"))
+		}
+		// Null clears nullable fields, overriding the defaults they were
+		// given; other fields are left alone like encoding/json does
+		if _, nullable := NullableValueType(field.Type()); nullable && field.CanSet() {
+			field.Set(reflect.Zero(field.Type()))
+		}
 		return nil
 	}
 
+	if field.Type() == durationType {
+		return m.setDurationField(field, value)
+	}
+	if ptr := reflect.PointerTo(field.Type()); field.Kind() != reflect.Ptr && (ptr.Implements(jsonUnmarshalerType) || ptr.Implements(textUnmarshalerType)) {
+		return m.setGenericField(field, value)
+	}
+
 	kind := field.Kind()
 
 	// Check if it's a basic field type
@@ -199,12 +402,18 @@ func (m *Marshaler) setFieldValue(field reflect.Value, value any) error {
 	}
 
 	// Handle specific non-basic types
+	if isSQLNullType(field.Type()) {
+		return m.setSQLNullField(field, value)
+	}
 	if kind == reflect.Struct {
 		return m.setStructField(field, value)
 	}
 	if kind == reflect.Ptr {
 		return m.setPtrField(field, value)
 	}
+	if kind == reflect.Map {
+		return m.setMapField(field, value)
+	}
 
 	// Handle all other types as generic fields
 	return m.setGenericField(field, value)
@@ -280,6 +489,22 @@ func (m *Marshaler) setBoolField(field reflect.Value, value any) error {
 	return nil
 }
 
+// setDurationField sets a time.Duration field from a duration string such
+// as "1m30s" or a number of nanoseconds.
+func (m *Marshaler) setDurationField(field reflect.Value, value any) error {
+	switch v := value.(type) {
+	case string:
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+	case float64:
+		field.SetInt(int64(v))
+	}
+	return nil
+}
+
 // setStructField sets a struct field value.
 func (m *Marshaler) setStructField(field reflect.Value, value any) error {
 	data, err := json.Marshal(value)
@@ -294,22 +519,59 @@ func (m *Marshaler) setStructField(field reflect.Value, value any) error {
 	return nil
 }
 
-// setPtrField sets a pointer field value.
+// setPtrField sets a pointer field value, allocating its target so that
+// present values, zero ones included, are told apart from absent ones.
 func (m *Marshaler) setPtrField(field reflect.Value, value any) error {
-	if field.Type().Elem().Kind() == reflect.Struct {
-		data, err := json.Marshal(value)
-		if err != nil {
-			return err
-		}
-		newVal := reflect.New(field.Type().Elem())
-		if err := m.UnmarshalFromJSON(data, newVal.Interface()); err != nil {
+	newVal := reflect.New(field.Type().Elem())
+	if err := m.setFieldValue(newVal.Elem(), value); err != nil {
+		return err
+	}
+	field.Set(newVal)
+	return nil
+}
+
+// setMapField sets a map field value, decoding every value with gork tags.
+// Keys are decoded by encoding/json so that integer and TextUnmarshaler keys
+// work as they do there.
+func (m *Marshaler) setMapField(field reflect.Value, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	raw := reflect.New(reflect.MapOf(field.Type().Key(), reflect.TypeOf(json.RawMessage(nil))))
+	if err := json.Unmarshal(data, raw.Interface()); err != nil {
+		return err
+	}
+	result := reflect.MakeMapWithSize(field.Type(), raw.Elem().Len())
+	iter := raw.Elem().MapRange()
+	for iter.Next() {
+		elem := reflect.New(field.Type().Elem())
+		if err := m.unmarshalValue(iter.Value().Bytes(), elem); err != nil {
 			return err
 		}
-		field.Set(newVal)
+		result.SetMapIndex(iter.Key(), elem.Elem())
 	}
+	field.Set(result)
 	return nil
 }
 
+// unmarshalValue decodes data into ptr, using gork tags for structs,
+// pointers to structs and maps and encoding/json for everything else.
+func (m *Marshaler) unmarshalValue(data []byte, ptr reflect.Value) error {
+	t := ptr.Type().Elem()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct && (t.Kind() != reflect.Map || ptr.Type().Elem().Kind() == reflect.Ptr) {
+		return json.Unmarshal(data, ptr.Interface())
+	}
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return m.setFieldValue(ptr.Elem(), value)
+}
+
 // setGenericField sets a generic field value using JSON marshaling.
 func (m *Marshaler) setGenericField(field reflect.Value, value any) error {
 	data, err := json.Marshal(value)
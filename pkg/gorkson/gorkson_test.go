@@ -1,9 +1,14 @@
 package gorkson
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"math"
+	"net/netip"
 	"reflect"
 	"testing"
+	"time"
 )
 
 // Test types for comprehensive testing
@@ -680,6 +685,14 @@ func TestParseGorkTag(t *testing.T) {
 				Name: "field_name",
 			},
 		},
+		{
+			name: "tag with aliases",
+			tag:  "display_name,alias=name,alias=full_name",
+			expected: GorkTagInfo{
+				Name:    "display_name",
+				Aliases: []string{"name", "full_name"},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -692,6 +705,19 @@ func TestParseGorkTag(t *testing.T) {
 	}
 }
 
+func TestSplitTag(t *testing.T) {
+	for tag, want := range map[string][]string{
+		"":                           {""},
+		"tags":                       {"tags"},
+		"tags,omitempty":             {"tags", "omitempty"},
+		"tags,example='a,b',default": {"tags", "example='a,b'", "default"},
+	} {
+		if got := SplitTag(tag); !reflect.DeepEqual(got, want) {
+			t.Errorf("SplitTag(%q) = %q, want %q", tag, got, want)
+		}
+	}
+}
+
 // Test setFieldValue method
 func TestMarshaler_setFieldValue(t *testing.T) {
 	m := &Marshaler{}
@@ -1106,3 +1132,445 @@ func TestMarshaler_SetBasicFieldValue(t *testing.T) {
 		})
 	}
 }
+
+func TestAliasedFieldRename(t *testing.T) {
+	type user struct {
+		DisplayName string `gork:"display_name,alias=name"`
+		Email       string `gork:"email"`
+	}
+
+	var old user
+	if err := Unmarshal([]byte(`{"name":"Jane","email":"j@example.com"}`), &old); err != nil {
+		t.Fatal(err)
+	}
+	if old.DisplayName != "Jane" {
+		t.Errorf("expected alias to populate DisplayName, got %q", old.DisplayName)
+	}
+
+	var both user
+	if err := Unmarshal([]byte(`{"name":"Old","display_name":"New"}`), &both); err != nil {
+		t.Fatal(err)
+	}
+	if both.DisplayName != "New" {
+		t.Errorf("expected current name to win over alias, got %q", both.DisplayName)
+	}
+
+	data, err := Marshal(user{DisplayName: "Jane"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"display_name":"Jane","email":""}` {
+		t.Errorf("expected only the new name to be emitted, got %s", data)
+	}
+}
+
+func TestMapOfStructsRoundTrip(t *testing.T) {
+	type entry struct {
+		Owner SimpleStruct `gork:"owner"`
+		Tags  []string     `gork:"tags"`
+	}
+	type body struct {
+		Entries map[string]entry          `gork:"entries"`
+		Users   map[int]*SimpleStruct     `gork:"users"`
+		Nested  map[string]map[string]int `gork:"nested"`
+	}
+	in := body{
+		Entries: map[string]entry{"a": {Owner: SimpleStruct{Name: "Ann", Age: 30}, Tags: []string{"x"}}},
+		Users:   map[int]*SimpleStruct{7: {Email: "bob@example.com"}},
+		Nested:  map[string]map[string]int{"x": {"y": 1}},
+	}
+	data, err := Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"entries":{"a":{"owner":{"age":30,"email":"","name":"Ann"},"tags":["x"]}},` +
+		`"nested":{"x":{"y":1}},"users":{"7":{"age":0,"email":"bob@example.com","name":""}}}`
+	if string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	var out body
+	if err := Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(out, in) {
+		t.Errorf("Unmarshal() = %+v, want %+v", out, in)
+	}
+}
+
+func TestPointerFieldsRoundTrip(t *testing.T) {
+	type body struct {
+		Count  *int      `gork:"count"`
+		Note   *string   `gork:"note"`
+		Active *bool     `gork:"active"`
+		Tags   *[]string `gork:"tags"`
+		Absent *int      `gork:"absent"`
+	}
+	var out body
+	if err := Unmarshal([]byte(`{"count":0,"note":"hi","active":false,"tags":["a"],"absent":null}`), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Count == nil || *out.Count != 0 || out.Note == nil || *out.Note != "hi" || out.Active == nil || *out.Active {
+		t.Errorf("present values must be set, got %+v", out)
+	}
+	if out.Tags == nil || len(*out.Tags) != 1 || out.Absent != nil {
+		t.Errorf("tags = %v, absent = %v", out.Tags, out.Absent)
+	}
+
+	data, err := Marshal(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"absent":null,"active":false,"count":0,"note":"hi","tags":["a"]}`; string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+}
+
+func TestTimeFieldsRoundTrip(t *testing.T) {
+	type body struct {
+		At      time.Time      `gork:"at"`
+		Timeout time.Duration  `gork:"timeout"`
+		Retry   *time.Duration `gork:"retry"`
+		Addr    netip.Addr     `gork:"addr"`
+		Any     any            `gork:"any"`
+	}
+	retry := 2 * time.Second
+	in := body{At: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), Timeout: 90 * time.Second, Retry: &retry, Addr: netip.MustParseAddr("10.0.0.1")}
+	data, err := Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"addr":"10.0.0.1","any":null,"at":"2024-01-02T03:04:05Z","retry":"2s","timeout":"1m30s"}`
+	if string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	var out body
+	if err := Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(out, in) {
+		t.Errorf("Unmarshal() = %+v, want %+v", out, in)
+	}
+
+	if err := Unmarshal([]byte(`{"timeout":1000}`), &out); err != nil || out.Timeout != time.Microsecond {
+		t.Errorf("durations must accept nanoseconds, got %v (%v)", out.Timeout, err)
+	}
+	if err := Unmarshal([]byte(`{"timeout":"soon"}`), &out); err == nil {
+		t.Error("expected an invalid duration error")
+	}
+}
+
+func TestOmitEmptyAndOmitZero(t *testing.T) {
+	type point struct {
+		X int `gork:"x"`
+	}
+	type body struct {
+		Name     string            `gork:"name,omitempty"`
+		Tags     []string          `gork:"tags,omitempty"`
+		Labels   map[string]string `gork:"labels,omitempty"`
+		Count    int               `gork:"count,omitempty"`
+		Next     *point            `gork:"next,omitempty"`
+		Origin   point             `gork:"origin,omitzero"`
+		At       time.Time         `gork:"at,omitzero"`
+		Legacy   string            `json:"legacy,omitempty"`
+		Kept     string            `gork:"kept"`
+		Empty    []string          `gork:"empty,omitzero"`
+		Explicit bool              `gork:"explicit,alias=flag,omitempty"`
+	}
+
+	data, err := Marshal(body{Empty: []string{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"empty":[],"kept":""}`; string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	full := body{
+		Name: "a", Tags: []string{"b"}, Labels: map[string]string{"c": "d"}, Count: 1,
+		Next: &point{}, Origin: point{X: 1}, At: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		Legacy: "e", Explicit: true,
+	}
+	data, err = Marshal(full)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"at":"2024-01-02T00:00:00Z","count":1,"explicit":true,"kept":"","labels":{"c":"d"},"legacy":"e","name":"a","next":{"x":0},"origin":{"x":1},"tags":["b"]}`
+	if string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+}
+
+func TestUnmarshalStrict(t *testing.T) {
+	type item struct {
+		Price int `gork:"price"`
+	}
+	type body struct {
+		Email   string          `gork:"email,alias=mail"`
+		Items   []item          `gork:"items"`
+		ByID    map[string]item `gork:"by_id"`
+		Next    *item           `gork:"next"`
+		Legacy  string          `json:"legacy"`
+		Timeout time.Duration   `gork:"timeout"`
+	}
+
+	var out body
+	err := UnmarshalStrict([]byte(`{"mail":"a@b.c","items":[{"price":1}],"by_id":{"x":{"price":2}},"next":{"price":3},"legacy":"l","timeout":"1s"}`), &out)
+	if err != nil {
+		t.Fatalf("expected known fields and aliases to decode, got %v", err)
+	}
+	if out.Email != "a@b.c" || out.Items[0].Price != 1 || out.ByID["x"].Price != 2 || out.Next.Price != 3 || out.Legacy != "l" {
+		t.Errorf("unexpected result %+v", out)
+	}
+
+	err = UnmarshalStrict([]byte(`{"emial":"a@b.c","items":[{"price":1},{"prise":2}],"by_id":{"x":{"cost":2}},"next":{"Price":3}}`), &out)
+	var unknown *UnknownFieldsError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("expected an UnknownFieldsError, got %v", err)
+	}
+	want := []string{"by_id.x.cost", "emial", "items[1].prise", "next.Price"}
+	if !reflect.DeepEqual(unknown.Fields, want) {
+		t.Errorf("Fields = %v, want %v", unknown.Fields, want)
+	}
+	if err.Error() != "unknown fields: by_id.x.cost, emial, items[1].prise, next.Price" {
+		t.Errorf("unexpected message %q", err)
+	}
+
+	if err := Unmarshal([]byte(`{"emial":"a@b.c"}`), &out); err != nil {
+		t.Errorf("Unmarshal must keep ignoring unknown fields, got %v", err)
+	}
+}
+
+func TestCheckReadOnly(t *testing.T) {
+	type line struct {
+		ID  string `gork:"id,readonly"`
+		Qty int    `gork:"qty"`
+	}
+	type body struct {
+		ID       string `gork:"id,readonly,alias=uid"`
+		Password string `gork:"password,writeonly"`
+		Lines    []line `gork:"lines"`
+	}
+
+	if err := CheckReadOnly([]byte(`{"password":"p","lines":[{"qty":1}],"other":1}`), &body{}); err != nil {
+		t.Errorf("expected a body without read-only fields to pass, got %v", err)
+	}
+
+	err := CheckReadOnly([]byte(`{"uid":"u1","lines":[{"qty":1},{"id":"l2","qty":2}]}`), &body{})
+	var readOnly *ReadOnlyFieldsError
+	if !errors.As(err, &readOnly) {
+		t.Fatalf("expected a ReadOnlyFieldsError, got %v", err)
+	}
+	if want := []string{"lines[1].id", "uid"}; !reflect.DeepEqual(readOnly.Fields, want) {
+		t.Errorf("Fields = %v, want %v", readOnly.Fields, want)
+	}
+	if err.Error() != "read-only fields: lines[1].id, uid" {
+		t.Errorf("unexpected message %q", err)
+	}
+
+	if err := CheckReadOnly([]byte(`{`), &body{}); err == nil {
+		t.Error("expected malformed JSON to fail")
+	}
+	if info := parseGorkTag("tags,example='a,readonly'"); info.ReadOnly {
+		t.Errorf("parseGorkTag() = %+v, want quoted values left alone", info)
+	}
+	if info := parseGorkTag("password,writeonly"); !info.WriteOnly || info.ReadOnly {
+		t.Errorf("parseGorkTag() = %+v, want WriteOnly", info)
+	}
+}
+
+func TestMarshalDropsWriteOnly(t *testing.T) {
+	type account struct {
+		Email    string `gork:"email"`
+		Password string `gork:"password,writeonly"`
+	}
+	data, err := Marshal([]account{{Email: "a@b.c", Password: "secret"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `[{"email":"a@b.c"}]` {
+		t.Errorf("Marshal() = %s, want the writeonly password left out", data)
+	}
+	var decoded account
+	if err := Unmarshal([]byte(`{"email":"a@b.c","password":"secret"}`), &decoded); err != nil || decoded.Password != "secret" {
+		t.Errorf("Unmarshal() = %+v, %v, want the password read", decoded, err)
+	}
+}
+
+type auditFields struct {
+	CreatedBy string `gork:"created_by"`
+	Version   int    `gork:"version"`
+}
+
+type Timestamps struct {
+	CreatedAt string `gork:"created_at"`
+	UpdatedAt string `gork:"updated_at,omitempty"`
+}
+
+func TestEmbeddedStructs(t *testing.T) {
+	type Owner struct {
+		ID string `gork:"id"`
+	}
+	type record struct {
+		*Timestamps
+		auditFields
+		Owner   `gork:"owner"`
+		ID      string `gork:"id"`
+		Version string `gork:"version"`
+	}
+
+	in := record{
+		Timestamps:  &Timestamps{CreatedAt: "monday"},
+		auditFields: auditFields{CreatedBy: "ann", Version: 2},
+		Owner:       Owner{ID: "o1"},
+		ID:          "r1",
+		Version:     "v3",
+	}
+	data, err := Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"created_at":"monday","created_by":"ann","id":"r1","owner":{"id":"o1"},"version":"v3"}`
+	if string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	var out record
+	if err := Unmarshal([]byte(`{"created_at":"tuesday","created_by":"bob","id":"r2","owner":{"id":"o2"},"version":"v4"}`), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.ID != "r2" || out.Owner.ID != "o2" || out.Version != "v4" || out.CreatedBy != "bob" || out.auditFields.Version != 0 {
+		t.Errorf("unexpected result %+v", out)
+	}
+	if out.Timestamps == nil || out.CreatedAt != "tuesday" {
+		t.Errorf("expected the embedded pointer to be allocated, got %+v", out.Timestamps)
+	}
+
+	data, err = Marshal(record{ID: "r3"})
+	if err != nil || string(data) != `{"created_by":"","id":"r3","owner":{"id":""},"version":""}` {
+		t.Errorf("expected nil embedded pointers to be skipped, got %s (%v)", data, err)
+	}
+
+	if err := UnmarshalStrict([]byte(`{"created_at":"x","updated_at":"y","created_by":"z"}`), &out); err != nil {
+		t.Errorf("expected promoted fields to be known in strict mode, got %v", err)
+	}
+}
+
+func TestAliasListsAndNoCase(t *testing.T) {
+	type user struct {
+		UserID string `gork:"user_id,alias=userId|uid"`
+		Email  string `gork:"email,nocase,alias=mail"`
+		Name   string `gork:"name"`
+	}
+
+	for body, want := range map[string]user{
+		`{"userId":"1"}`:                         {UserID: "1"},
+		`{"uid":"2"}`:                            {UserID: "2"},
+		`{"uid":"3","userId":"4"}`:               {UserID: "4"},
+		`{"user_id":"5","uid":"6"}`:              {UserID: "5"},
+		`{"EMAIL":"a@b.c"}`:                      {Email: "a@b.c"},
+		`{"Mail":"d@e.f"}`:                       {Email: "d@e.f"},
+		`{"Email":"x@y.z","email":"a@b.c"}`:      {Email: "a@b.c"},
+		`{"NAME":"ignored","name":"Ann"}`:        {Name: "Ann"},
+		`{"UserID":"case-sensitive","Name":"x"}`: {},
+	} {
+		var got user
+		if err := Unmarshal([]byte(body), &got); err != nil {
+			t.Fatalf("%s: %v", body, err)
+		}
+		if got != want {
+			t.Errorf("%s: got %+v, want %+v", body, got, want)
+		}
+	}
+
+	data, err := Marshal(user{UserID: "1", Email: "a@b.c"})
+	if err != nil || string(data) != `{"email":"a@b.c","name":"","user_id":"1"}` {
+		t.Errorf("expected canonical names only, got %s (%v)", data, err)
+	}
+
+	var got user
+	err = UnmarshalStrict([]byte(`{"UID":"1","uid":"2","E-Mail":"x","MAIL":"y"}`), &got)
+	var unknown *UnknownFieldsError
+	if !errors.As(err, &unknown) || !reflect.DeepEqual(unknown.Fields, []string{"E-Mail", "UID"}) {
+		t.Errorf("expected only the case-sensitive alias and the misspelling to be unknown, got %v", err)
+	}
+}
+
+func TestNullableFields(t *testing.T) {
+	type record struct {
+		Nickname *string           `gork:"nickname"`
+		Note     sql.NullString    `gork:"note"`
+		Count    sql.NullInt64     `gork:"count"`
+		Seen     sql.NullTime      `gork:"seen"`
+		Score    sql.Null[float64] `gork:"score"`
+	}
+
+	nickname := "default"
+	decoded := record{Nickname: &nickname, Note: sql.NullString{String: "default", Valid: true}}
+	if err := Unmarshal([]byte(`{"nickname":null,"note":null,"count":3,"seen":"2024-05-01T10:00:00Z","score":1.5}`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Nickname != nil || decoded.Note.Valid {
+		t.Errorf("expected null to clear the fields, got %+v", decoded)
+	}
+	seen := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	if decoded.Count != (sql.NullInt64{Int64: 3, Valid: true}) || !decoded.Seen.Valid || !decoded.Seen.Time.Equal(seen) || decoded.Score != (sql.Null[float64]{V: 1.5, Valid: true}) {
+		t.Errorf("unexpected values %+v", decoded)
+	}
+
+	data, err := Marshal(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"count":3,"nickname":null,"note":null,"score":1.5,"seen":"2024-05-01T10:00:00Z"}`
+	if string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+	if err := UnmarshalStrict([]byte(`{"note":"n","count":null}`), &record{}); err != nil {
+		t.Errorf("UnmarshalStrict() error = %v", err)
+	}
+
+	for _, tt := range []struct {
+		value any
+		want  reflect.Type
+		ok    bool
+	}{
+		{(*int)(nil), reflect.TypeOf(0), true},
+		{sql.NullString{}, reflect.TypeOf(""), true},
+		{sql.Null[float64]{}, reflect.TypeOf(0.0), true},
+		{"", nil, false},
+		{SimpleStruct{}, nil, false},
+	} {
+		got, ok := NullableValueType(reflect.TypeOf(tt.value))
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("NullableValueType(%T) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestAppendMatchesEncodingJSON(t *testing.T) {
+	for _, s := range []string{"", "plain", `quote " and \ slash`, "<a href='x'>&amp;</a>", "tab\tnew\nline\r\b\f\x00\x1f\x7f", "café \u2028 \u2029 \U0001F600", "bad \xff utf8 \xe2\x82"} {
+		want, _ := json.Marshal(s)
+		if got := AppendString(nil, s); string(got) != string(want) {
+			t.Errorf("AppendString(%q) = %s, want %s", s, got, want)
+		}
+	}
+
+	for _, f := range []float64{0, 1, -1.5, 0.1, 1e-7, 1.5e-7, 123456789, 1e20, 1e21, 1.7976931348623157e308, 5e-324} {
+		want, _ := json.Marshal(f)
+		if got, err := AppendFloat(nil, f, 64); err != nil || string(got) != string(want) {
+			t.Errorf("AppendFloat(%v, 64) = %s, %v, want %s", f, got, err, want)
+		}
+		if math.IsInf(float64(float32(f)), 0) {
+			continue
+		}
+		want, _ = json.Marshal(float32(f))
+		if got, err := AppendFloat(nil, float64(float32(f)), 32); err != nil || string(got) != string(want) {
+			t.Errorf("AppendFloat(%v, 32) = %s, %v, want %s", float32(f), got, err, want)
+		}
+	}
+	if _, err := AppendFloat(nil, math.NaN(), 64); err == nil {
+		t.Error("expected NaN to be an error")
+	}
+}
@@ -0,0 +1,65 @@
+package gorkson
+
+import (
+	"reflect"
+	"strings"
+)
+
+// NullableValueType reports whether values of t are encoded as null when
+// unset, and returns the type of their other values. Nullable types are:
+//
+//   - pointers, nil when null;
+//   - the Null types of database/sql, such as sql.NullString and
+//     sql.Null[T], invalid when null;
+//   - unions.Optional[T], which records null itself.
+//
+// Unmarshal decodes explicit null into these types, Marshal encodes their
+// unset values as null, and the OpenAPI generator documents them as
+// nullable, so that specs and the wire format agree.
+func NullableValueType(t reflect.Type) (reflect.Type, bool) {
+	if t == nil {
+		return nil, false
+	}
+	if t.Kind() == reflect.Ptr {
+		return t.Elem(), true
+	}
+	if isSQLNullType(t) {
+		return t.Field(0).Type, true
+	}
+	if t.Kind() == reflect.Struct && strings.HasSuffix(t.PkgPath(), "/unions") && strings.HasPrefix(t.Name(), "Optional[") {
+		if value, ok := t.FieldByName("Value"); ok {
+			return value.Type, true
+		}
+	}
+	return nil, false
+}
+
+// isSQLNullType reports whether t is one of the Null types of database/sql:
+// a struct holding a value and a Valid flag, such as sql.NullString.
+func isSQLNullType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.PkgPath() == "database/sql" && strings.HasPrefix(t.Name(), "Null") &&
+		t.NumField() == 2 && t.Field(1).Name == "Valid" && t.Field(1).Type.Kind() == reflect.Bool
+}
+
+// sqlNullValue returns the value held by v, a database/sql Null type, or
+// nil when v is not valid.
+func (m *Marshaler) sqlNullValue(v reflect.Value) any {
+	if !v.Field(1).Bool() {
+		return nil
+	}
+	return m.convertToGorkSON(v.Field(0).Interface())
+}
+
+// setSQLNullField sets field, a database/sql Null type, from a decoded JSON
+// value: null leaves it invalid, anything else sets its value.
+func (m *Marshaler) setSQLNullField(field reflect.Value, value any) error {
+	result := reflect.New(field.Type()).Elem()
+	if value != nil {
+		if err := m.setFieldValue(result.Field(0), value); err != nil {
+			return err
+		}
+		result.Field(1).SetBool(true)
+	}
+	field.Set(result)
+	return nil
+}
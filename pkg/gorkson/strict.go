@@ -0,0 +1,138 @@
+package gorkson
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// UnknownFieldsError reports JSON object keys that match no field of the
+// struct they are decoded into.
+type UnknownFieldsError struct {
+	// Fields are the offending keys as paths from the top-level value,
+	// e.g. "emial" or "items[0].prise", sorted.
+	Fields []string
+}
+
+func (e *UnknownFieldsError) Error() string {
+	return "unknown fields: " + strings.Join(e.Fields, ", ")
+}
+
+// UnmarshalStrict is Unmarshal, but it returns an *UnknownFieldsError
+// instead of ignoring keys that match no field, names and aliases from gork
+// tags or json tags, of the structs data is decoded into. Values that decode
+// themselves, such as unions, check their own keys.
+func UnmarshalStrict(data []byte, v any) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if unknown := unknownFields(reflect.TypeOf(v), value); len(unknown) > 0 {
+		sort.Strings(unknown)
+		return &UnknownFieldsError{Fields: unknown}
+	}
+	return Unmarshal(data, v)
+}
+
+// ReadOnlyFieldsError reports JSON object keys setting fields marked
+// readonly, which only servers set.
+type ReadOnlyFieldsError struct {
+	// Fields are the offending keys as paths from the top-level value,
+	// e.g. "id" or "items[0].created_at", sorted.
+	Fields []string
+}
+
+func (e *ReadOnlyFieldsError) Error() string {
+	return "read-only fields: " + strings.Join(e.Fields, ", ")
+}
+
+// CheckReadOnly returns a *ReadOnlyFieldsError when data, the JSON v is to
+// be decoded from, sets fields of v's structs marked readonly
+// (`gork:"id,readonly"`). Request bodies are checked with it so that clients
+// cannot set fields such as IDs and timestamps.
+func CheckReadOnly(data []byte, v any) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	readOnly := walkKeys(reflect.TypeOf(v), value, "", func(f *fieldInfo) bool { return f != nil && f.tag.ReadOnly })
+	if len(readOnly) > 0 {
+		sort.Strings(readOnly)
+		return &ReadOnlyFieldsError{Fields: readOnly}
+	}
+	return nil
+}
+
+// unknownFields returns the paths of the object keys of value, decoded
+// JSON, that the Go type t it is decoded into has no field for.
+func unknownFields(t reflect.Type, value any) []string {
+	return walkKeys(t, value, "", func(f *fieldInfo) bool { return f == nil })
+}
+
+// walkKeys walks value, decoded JSON, alongside the Go type t it is decoded
+// into and returns the paths of the object keys report selects. report is
+// given the field a key sets, or nil for keys matching no field; keys of
+// fields it does not select are walked into.
+func walkKeys(t reflect.Type, value any, path string, report func(f *fieldInfo) bool) []string {
+	for t != nil && (t.Kind() == reflect.Ptr || isSQLNullType(t)) {
+		t, _ = NullableValueType(t)
+	}
+	if t == nil || value == nil || t == durationType {
+		return nil
+	}
+	if ptr := reflect.PointerTo(t); ptr.Implements(jsonUnmarshalerType) || ptr.Implements(textUnmarshalerType) {
+		return nil
+	}
+
+	var found []string
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		structFields := defaultMarshaler.structFields(t)
+		fields := map[string]*fieldInfo{}
+		for i := range structFields {
+			fields[structFields[i].name] = &structFields[i]
+			for _, alias := range structFields[i].tag.Aliases {
+				fields[alias] = &structFields[i]
+			}
+		}
+		for key, item := range object {
+			f := fields[key]
+			for i := range structFields {
+				if f == nil && structFields[i].tag.acceptsFolded(key, structFields[i].name) {
+					f = &structFields[i]
+				}
+			}
+			if report(f) {
+				found = append(found, joinPath(path, key))
+				continue
+			}
+			if f != nil {
+				found = append(found, walkKeys(f.field.Type, item, joinPath(path, key), report)...)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		items, _ := value.([]any)
+		for i, item := range items {
+			found = append(found, walkKeys(t.Elem(), item, path+"["+strconv.Itoa(i)+"]", report)...)
+		}
+	case reflect.Map:
+		entries, _ := value.(map[string]any)
+		for key, item := range entries {
+			found = append(found, walkKeys(t.Elem(), item, joinPath(path, key), report)...)
+		}
+	}
+	return found
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,113 @@
+// Package metrics records Prometheus metrics for gork routes.
+//
+//	m := metrics.New(metrics.Config{Namespace: "billing"})
+//	router := stdlib.NewRouter(mux, api.WithMetrics(m))
+//	mux.Handle("GET /metrics", router.MetricsHandler())
+//
+// Requests are labeled by the route's path template, method and status, so
+// "/users/{id}" is one series however many users there are.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/gork-labs/gork/pkg/api"
+)
+
+// Config configures the metrics created by New.
+type Config struct {
+	// Namespace prefixes every metric name, e.g. "billing_http_requests_total".
+	Namespace string
+	// Registry receives the metrics. When nil a new registry is created, so
+	// Handler serves the route metrics only.
+	Registry *prometheus.Registry
+	// DurationBuckets are the buckets of the duration histogram, in
+	// seconds. prometheus.DefBuckets when empty.
+	DurationBuckets []float64
+	// SizeBuckets are the buckets of the size histograms, in bytes. Powers
+	// of 4 from 64 bytes to 16 MiB when empty.
+	SizeBuckets []float64
+}
+
+// Metrics records request count, duration, request and response sizes and
+// validation failures per route. It implements api.Metrics.
+type Metrics struct {
+	registry           *prometheus.Registry
+	requests           *prometheus.CounterVec
+	duration           *prometheus.HistogramVec
+	requestSize        *prometheus.HistogramVec
+	responseSize       *prometheus.HistogramVec
+	validationFailures *prometheus.CounterVec
+}
+
+var _ api.Metrics = (*Metrics)(nil)
+
+// New creates the metrics and registers them with cfg.Registry. It panics
+// when they are already registered there.
+func New(cfg Config) *Metrics {
+	if cfg.Registry == nil {
+		cfg.Registry = prometheus.NewRegistry()
+	}
+	if len(cfg.DurationBuckets) == 0 {
+		cfg.DurationBuckets = prometheus.DefBuckets
+	}
+	if len(cfg.SizeBuckets) == 0 {
+		cfg.SizeBuckets = prometheus.ExponentialBuckets(64, 4, 10)
+	}
+
+	withStatus := []string{"method", "route", "status"}
+	m := &Metrics{
+		registry: cfg.Registry,
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.Namespace,
+			Name:      "http_requests_total",
+			Help:      "Number of HTTP requests served.",
+		}, withStatus),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: cfg.Namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Time spent serving HTTP requests.",
+			Buckets:   cfg.DurationBuckets,
+		}, withStatus),
+		requestSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: cfg.Namespace,
+			Name:      "http_request_size_bytes",
+			Help:      "Size of the HTTP request bodies read.",
+			Buckets:   cfg.SizeBuckets,
+		}, withStatus),
+		responseSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: cfg.Namespace,
+			Name:      "http_response_size_bytes",
+			Help:      "Size of the HTTP response bodies written.",
+			Buckets:   cfg.SizeBuckets,
+		}, withStatus),
+		validationFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.Namespace,
+			Name:      "http_validation_failures_total",
+			Help:      "Number of HTTP requests rejected by validation.",
+		}, []string{"method", "route"}),
+	}
+	cfg.Registry.MustRegister(m.requests, m.duration, m.requestSize, m.responseSize, m.validationFailures)
+	return m
+}
+
+// ObserveRequest records a served request.
+func (m *Metrics) ObserveRequest(route *api.RouteInfo, obs api.RequestObservation) {
+	labels := prometheus.Labels{"method": route.Method, "route": route.Path, "status": strconv.Itoa(obs.Status)}
+	m.requests.With(labels).Inc()
+	m.duration.With(labels).Observe(obs.Duration.Seconds())
+	m.requestSize.With(labels).Observe(float64(obs.RequestSize))
+	m.responseSize.With(labels).Observe(float64(obs.ResponseSize))
+	if obs.ValidationFailed {
+		m.validationFailures.With(prometheus.Labels{"method": route.Method, "route": route.Path}).Inc()
+	}
+}
+
+// Handler serves the metrics of the registry in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
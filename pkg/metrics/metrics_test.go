@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/gork-labs/gork/pkg/api"
+)
+
+type createUserRequest struct {
+	Body struct {
+		Name string `gork:"name" validate:"required"`
+	}
+}
+
+type createUserResponse struct {
+	Body struct {
+		ID string `gork:"id"`
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	m := New(Config{Namespace: "test"})
+	handlers := map[string]http.HandlerFunc{}
+	router := api.NewTypedRouter[*struct{}](nil, api.NewRouteRegistry(), "", []api.Option{api.WithMetrics(m)}, &api.DefaultParameterAdapter{},
+		func(method, path string, h http.HandlerFunc, _ *api.RouteInfo) {
+			handlers[method+" "+path] = h
+		})
+	router.Post("/users/{org}", func(context.Context, createUserRequest) (*createUserResponse, error) {
+		resp := &createUserResponse{}
+		resp.Body.ID = "1"
+		return resp, nil
+	})
+
+	send := func(body string) {
+		w := httptest.NewRecorder()
+		handlers["POST /users/{org}"](w, httptest.NewRequest(http.MethodPost, "/users/acme", strings.NewReader(body)))
+	}
+	send(`{"name":"Ada"}`)
+	send(`{"name":"Grace"}`)
+	send(`{}`)
+
+	if got := testutil.ToFloat64(m.requests.WithLabelValues("POST", "/users/{org}", "200")); got != 2 {
+		t.Errorf("200 requests = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(m.requests.WithLabelValues("POST", "/users/{org}", "400")); got != 1 {
+		t.Errorf("400 requests = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(m.validationFailures.WithLabelValues("POST", "/users/{org}")); got != 1 {
+		t.Errorf("validation failures = %v, want 1", got)
+	}
+
+	w := httptest.NewRecorder()
+	router.MetricsHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	for _, want := range []string{
+		`test_http_request_duration_seconds_count{method="POST",route="/users/{org}",status="200"} 2`,
+		`test_http_request_size_bytes_sum{method="POST",route="/users/{org}",status="200"} 30`,
+		`test_http_response_size_bytes_count{method="POST",route="/users/{org}",status="400"} 1`,
+	} {
+		if !strings.Contains(w.Body.String(), want) {
+			t.Errorf("metrics output lacks %s:\n%s", want, w.Body)
+		}
+	}
+}
+
+func TestMetricsHandlerWithoutMetrics(t *testing.T) {
+	router := api.NewTypedRouter[*struct{}](nil, api.NewRouteRegistry(), "", nil, &api.DefaultParameterAdapter{}, nil)
+	w := httptest.NewRecorder()
+	router.MetricsHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", w.Code)
+	}
+}
@@ -0,0 +1,363 @@
+package unions
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gork-labs/gork/pkg/gorkson"
+)
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// MatchError reports that data matched none of the variants of a union, or
+// several of them equally well. It is returned by the UnmarshalJSON methods
+// of the union types.
+type MatchError struct {
+	// Mismatches explains, for every rejected variant in order, why it was
+	// rejected.
+	Mismatches []VariantMismatch
+	// Candidates lists, when data is ambiguous, the variants it matches
+	// equally well, e.g. "A (shop.Card)".
+	Candidates []string
+}
+
+// VariantMismatch is the reason data does not match a union variant.
+type VariantMismatch struct {
+	// Variant is the union field of the variant, e.g. "A".
+	Variant string
+	// Type is the variant type.
+	Type string
+	Err  error
+}
+
+func (e *MatchError) Error() string {
+	if len(e.Candidates) > 0 {
+		return "failed to unmarshal into any union type: data matches several union variants equally well: " + strings.Join(e.Candidates, ", ")
+	}
+	parts := make([]string, len(e.Mismatches))
+	for i, m := range e.Mismatches {
+		parts[i] = fmt.Sprintf("%s (%s): %v", m.Variant, m.Type, m.Err)
+	}
+	return "failed to unmarshal into any union type: data does not match any of the union variants: " + strings.Join(parts, "; ")
+}
+
+// decodeVariant decodes data into the variant among targets, pointers to the
+// zero values of a union's variants, that it matches best and returns the
+// variant's index.
+//
+// Struct variants match when data is an object without unknown fields, whose
+// values have the JSON kinds of the fields, and that passes validation.
+// Among matching variants the one with the most required fields present in
+// data wins, so that a payload is not taken for a variant whose fields are a
+// subset of another's, then the one with the most integers decoded into
+// integer rather than floating-point fields. Other variants match when data
+// decodes into them. Data matching several variants equally well is
+// rejected as ambiguous, unless the variants have the same structure and
+// data could never tell them apart; the earlier one is decoded then.
+func decodeVariant(data []byte, targets ...any) (int, error) {
+	best, bestScore := -1, variantScore{-1, -1}
+	var tied []int
+	var mismatches []VariantMismatch
+	for i, target := range targets {
+		score, err := matchVariant(data, target)
+		if err != nil {
+			mismatches = append(mismatches, VariantMismatch{
+				Variant: variantName(i),
+				Type:    reflect.TypeOf(target).Elem().String(),
+				Err:     err,
+			})
+			continue
+		}
+		switch {
+		case score.beats(bestScore):
+			best, bestScore, tied = i, score, nil
+		case score == bestScore && !sameStructure(targets[best], target):
+			tied = append(tied, i)
+		}
+	}
+	if best == -1 {
+		return -1, &MatchError{Mismatches: mismatches}
+	}
+	if len(tied) > 0 {
+		candidates := make([]string, 0, len(tied)+1)
+		for _, i := range append([]int{best}, tied...) {
+			candidates = append(candidates, fmt.Sprintf("%s (%s)", variantName(i), reflect.TypeOf(targets[i]).Elem()))
+		}
+		return -1, &MatchError{Mismatches: mismatches, Candidates: candidates}
+	}
+	return best, nil
+}
+
+// variantName returns the union field of the i-th variant, e.g. "A".
+func variantName(i int) string {
+	return string(rune('A' + i))
+}
+
+// variantScore ranks the variants data matches, see decodeVariant.
+type variantScore struct {
+	required int
+	integers int
+}
+
+// beats reports whether s ranks above other.
+func (s variantScore) beats(other variantScore) bool {
+	if s.required != other.required {
+		return s.required > other.required
+	}
+	return s.integers > other.integers
+}
+
+// sameStructure reports whether targets a and b, pointers to union
+// variants, have the same underlying type, so that no data tells them apart.
+func sameStructure(a, b any) bool {
+	ta, tb := reflect.TypeOf(a).Elem(), reflect.TypeOf(b).Elem()
+	return ta == tb || (ta.Kind() == reflect.Struct && tb.Kind() == reflect.Struct && ta.ConvertibleTo(tb))
+}
+
+// matchVariant decodes data into target and returns its score.
+func matchVariant(data []byte, target any) (variantScore, error) {
+	t := reflect.TypeOf(target).Elem()
+	st := t
+	if st.Kind() == reflect.Ptr {
+		st = st.Elem()
+	}
+	if st.Kind() != reflect.Struct || decodesItself(st) {
+		if err := json.Unmarshal(data, target); err != nil {
+			return variantScore{}, err
+		}
+		return variantScore{}, nil
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil || object == nil {
+		return variantScore{}, fmt.Errorf("expected an object")
+	}
+	fields := structFields(st)
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var score variantScore
+	for _, key := range keys {
+		field, ok := lookupField(fields, key)
+		if !ok {
+			return variantScore{}, fmt.Errorf("unknown field %q", key)
+		}
+		if err := checkKind(field.Type, object[key]); err != nil {
+			return variantScore{}, fmt.Errorf("field %q: %w", key, err)
+		}
+		if isRequired(field) {
+			score.required++
+		}
+		if isInteger(field.Type) {
+			score.integers++
+		}
+	}
+
+	if err := gorkson.Unmarshal(data, target); err != nil {
+		return variantScore{}, err
+	}
+	value := reflect.ValueOf(target).Elem()
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return variantScore{}, fmt.Errorf("expected an object")
+		}
+		value = value.Elem()
+	}
+	if err := getValidator().Struct(value.Interface()); err != nil {
+		return variantScore{}, err
+	}
+	return score, nil
+}
+
+// structFields maps the JSON names of the fields of t, aliases and fields
+// promoted from untagged embedded structs included, to the fields, following
+// the gork tag and then the json tag like gorkson.
+func structFields(t reflect.Type) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+	promoted := map[string]reflect.StructField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if embedded, ok := embeddedStruct(f); ok {
+			for name, field := range structFields(embedded) {
+				if _, taken := promoted[name]; !taken {
+					promoted[name] = field
+				}
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if tag := f.Tag.Get("gork"); tag != "" {
+			parts := strings.Split(tag, ",")
+			if name := strings.TrimSpace(parts[0]); name != "" && name != "-" {
+				fields[name] = f
+				for _, part := range parts[1:] {
+					if aliases, ok := strings.CutPrefix(strings.TrimSpace(part), "alias="); ok {
+						for _, alias := range strings.Split(aliases, "|") {
+							fields[strings.TrimSpace(alias)] = f
+						}
+					}
+				}
+				continue
+			}
+		}
+		if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name != "" && name != "-" {
+			fields[name] = f
+		}
+	}
+	for name, field := range promoted {
+		if _, taken := fields[name]; !taken {
+			fields[name] = field
+		}
+	}
+	return fields
+}
+
+// lookupField returns the field of fields key names, matching the names and
+// aliases of nocase fields regardless of case as gorkson does.
+func lookupField(fields map[string]reflect.StructField, key string) (reflect.StructField, bool) {
+	if field, ok := fields[key]; ok {
+		return field, true
+	}
+	for name, field := range fields {
+		if strings.EqualFold(name, key) && hasTagOption(field, "nocase") {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// hasTagOption reports whether the gork tag of field carries option.
+func hasTagOption(field reflect.StructField, option string) bool {
+	parts := strings.Split(field.Tag.Get("gork"), ",")
+	for _, part := range parts[1:] {
+		if strings.TrimSpace(part) == option {
+			return true
+		}
+	}
+	return false
+}
+
+// embeddedStruct returns the struct type whose fields f promotes: an
+// embedded struct, or pointer to one, without a gork or json name.
+func embeddedStruct(f reflect.StructField) (reflect.Type, bool) {
+	gorkName, _, _ := strings.Cut(f.Tag.Get("gork"), ",")
+	jsonName, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if !f.Anonymous || strings.TrimSpace(gorkName) != "" || jsonName != "" {
+		return nil, false
+	}
+	t := f.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t, t.Kind() == reflect.Struct
+}
+
+// isRequired reports whether field carries the required validation rule.
+func isRequired(field reflect.StructField) bool {
+	for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+		if rule == "required" {
+			return true
+		}
+	}
+	return false
+}
+
+// decodesItself reports whether t has its own JSON or text decoding.
+func decodesItself(t reflect.Type) bool {
+	ptr := reflect.PointerTo(t)
+	return ptr.Implements(jsonUnmarshalerType) || ptr.Implements(textUnmarshalerType)
+}
+
+// checkKind reports whether raw has the JSON kind values of t are encoded
+// as. null is accepted for every type.
+func checkKind(t reflect.Type, raw json.RawMessage) error {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == durationType || decodesItself(t) {
+		return nil
+	}
+	c := raw[0]
+	number := c == '-' || (c >= '0' && c <= '9')
+	switch t.Kind() {
+	case reflect.String:
+		if c != '"' {
+			return fmt.Errorf("expected a string")
+		}
+	case reflect.Bool:
+		if c != 't' && c != 'f' {
+			return fmt.Errorf("expected a boolean")
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if _, err := strconv.ParseInt(string(raw), 10, t.Bits()); err != nil {
+			return fmt.Errorf("expected an integer")
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if _, err := strconv.ParseUint(string(raw), 10, t.Bits()); err != nil {
+			return fmt.Errorf("expected a non-negative integer")
+		}
+	case reflect.Float32, reflect.Float64:
+		if !number {
+			return fmt.Errorf("expected a number")
+		}
+	case reflect.Struct, reflect.Map:
+		if c != '{' {
+			return fmt.Errorf("expected an object")
+		}
+	case reflect.Slice:
+		if c != '[' && (t.Elem().Kind() != reflect.Uint8 || c != '"') {
+			return fmt.Errorf("expected an array")
+		}
+	case reflect.Array:
+		if c != '[' {
+			return fmt.Errorf("expected an array")
+		}
+	}
+	return nil
+}
+
+// isInteger reports whether t, or the type it points to, is an integer type.
+func isInteger(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return t != durationType
+	}
+	return false
+}
+
+// validateVariant validates value, a pointer to the variant set in a union,
+// when it is a struct; other variants have no rules of their own.
+func validateVariant(validate *validator.Validate, value any) error {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	return validate.Struct(v.Interface())
+}
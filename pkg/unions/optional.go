@@ -0,0 +1,77 @@
+package unions
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+
+	"github.com/gork-labs/gork/pkg/gorkson"
+)
+
+// Optional holds a JSON value that may be absent, null or set, e.g. for
+// PATCH bodies where null clears a field and an absent field leaves it
+// alone:
+//
+//	type UpdateUserBody struct {
+//		Nickname unions.Optional[string]                       `gork:"nickname"`
+//		Contact  unions.Optional[unions.Union2[Email, Phone]] `gork:"contact"`
+//	}
+//
+// The zero value is absent. Present is set when the field appears in the
+// JSON, and Null when it appears as null. Optional fields are documented as
+// nullable: a oneOf of the value's schema(s) and {type: null}.
+type Optional[T any] struct {
+	Value   T
+	Present bool
+	Null    bool
+}
+
+// Some returns an Optional set to v.
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{Value: v, Present: true}
+}
+
+// Null returns an Optional holding null.
+func Null[T any]() Optional[T] {
+	return Optional[T]{Present: true, Null: true}
+}
+
+// IsSet reports whether o holds a value, as opposed to being absent or null.
+func (o Optional[T]) IsSet() bool {
+	return o.Present && !o.Null
+}
+
+// Get returns the value and whether it is set.
+func (o Optional[T]) Get() (T, bool) {
+	return o.Value, o.IsSet()
+}
+
+// IsZero reports whether o is absent, so that fields tagged omitzero are
+// left out when absent.
+func (o Optional[T]) IsZero() bool {
+	return !o.Present
+}
+
+// UnmarshalJSON implements json.Unmarshaler for Optional. Struct values are
+// decoded with gork tags.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	*o = Optional[T]{Present: true}
+	if string(bytes.TrimSpace(data)) == "null" {
+		o.Null = true
+		return nil
+	}
+	t := reflect.TypeOf(o.Value)
+	if t != nil && t.Kind() == reflect.Struct && !decodesItself(t) {
+		return gorkson.Unmarshal(data, &o.Value)
+	}
+	return json.Unmarshal(data, &o.Value)
+}
+
+// MarshalJSON implements json.Marshaler for Optional. Absent values encode
+// as null unless the field is left out with omitzero.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.IsSet() {
+		return []byte("null"), nil
+	}
+	return gorkson.Marshal(o.Value)
+}
@@ -1,9 +1,7 @@
 package unions
 
 import (
-	"encoding/json"
 	"errors"
-	"fmt"
 	"sync"
 
 	"github.com/go-playground/validator/v10"
@@ -29,33 +27,21 @@ type Union2[A, B any] struct {
 	B *B
 }
 
-// UnmarshalJSON implements json.Unmarshaler for Union2.
+// UnmarshalJSON implements json.Unmarshaler for Union2. It decodes the
+// variant data matches best, see MatchError for the errors it returns.
 func (u *Union2[A, B]) UnmarshalJSON(data []byte) error {
-	u.A = nil
-	u.B = nil
-
-	// Try unmarshaling in order, with validation
-	validate := getValidator()
-
-	// Try type A first
 	var a A
-	if err := json.Unmarshal(data, &a); err == nil {
-		if err := validate.Struct(a); err == nil {
-			u.A = &a
-			return nil
-		}
-	}
-
-	// Try type B
 	var b B
-	if err := json.Unmarshal(data, &b); err == nil {
-		if err := validate.Struct(b); err == nil {
-			u.B = &b
-			return nil
-		}
+	index, err := decodeVariant(data, &a, &b)
+
+	*u = Union2[A, B]{}
+	switch index {
+	case 0:
+		u.A = &a
+	case 1:
+		u.B = &b
 	}
-
-	return fmt.Errorf("failed to unmarshal into any union type: data does not match any of the union variants")
+	return err
 }
 
 // MarshalJSON implements json.Marshaler for Union2.
@@ -91,7 +77,7 @@ func (u Union2[A, B]) Validate(validate *validator.Validate) error {
 		return errors.New("only one union option can be set")
 	}
 
-	return validate.Struct(value)
+	return validateVariant(validate, value)
 }
 
 // Value returns the active value and its type index (0-based).
@@ -113,42 +99,24 @@ type Union3[A, B, C any] struct {
 	C *C
 }
 
-// UnmarshalJSON implements json.Unmarshaler for Union3.
+// UnmarshalJSON implements json.Unmarshaler for Union3. It decodes the
+// variant data matches best, see MatchError for the errors it returns.
 func (u *Union3[A, B, C]) UnmarshalJSON(data []byte) error {
-	u.A = nil
-	u.B = nil
-	u.C = nil
-
-	validate := getValidator()
-
-	// Try type A first
 	var a A
-	if err := json.Unmarshal(data, &a); err == nil {
-		if err := validate.Struct(a); err == nil {
-			u.A = &a
-			return nil
-		}
-	}
-
-	// Try type B
 	var b B
-	if err := json.Unmarshal(data, &b); err == nil {
-		if err := validate.Struct(b); err == nil {
-			u.B = &b
-			return nil
-		}
-	}
-
-	// Try type C
 	var c C
-	if err := json.Unmarshal(data, &c); err == nil {
-		if err := validate.Struct(c); err == nil {
-			u.C = &c
-			return nil
-		}
+	index, err := decodeVariant(data, &a, &b, &c)
+
+	*u = Union3[A, B, C]{}
+	switch index {
+	case 0:
+		u.A = &a
+	case 1:
+		u.B = &b
+	case 2:
+		u.C = &c
 	}
-
-	return fmt.Errorf("failed to unmarshal into any union type: data does not match any of the union variants")
+	return err
 }
 
 // MarshalJSON implements json.Marshaler for Union3.
@@ -190,7 +158,7 @@ func (u Union3[A, B, C]) Validate(validate *validator.Validate) error {
 		return errors.New("only one union option can be set")
 	}
 
-	return validate.Struct(value)
+	return validateVariant(validate, value)
 }
 
 // Value returns the active value and its type index (0-based).
@@ -215,56 +183,646 @@ type Union4[A, B, C, D any] struct {
 	D *D
 }
 
-// UnmarshalJSON implements json.Unmarshaler for Union4.
+// UnmarshalJSON implements json.Unmarshaler for Union4. It decodes the
+// variant data matches best, see MatchError for the errors it returns.
 func (u *Union4[A, B, C, D]) UnmarshalJSON(data []byte) error {
-	u.A = nil
-	u.B = nil
-	u.C = nil
-	u.D = nil
+	var a A
+	var b B
+	var c C
+	var d D
+	index, err := decodeVariant(data, &a, &b, &c, &d)
+
+	*u = Union4[A, B, C, D]{}
+	switch index {
+	case 0:
+		u.A = &a
+	case 1:
+		u.B = &b
+	case 2:
+		u.C = &c
+	case 3:
+		u.D = &d
+	}
+	return err
+}
 
-	validate := getValidator()
+// MarshalJSON implements json.Marshaler for Union4.
+func (u Union4[A, B, C, D]) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.A != nil:
+		return gorkson.Marshal(u.A)
+	case u.B != nil:
+		return gorkson.Marshal(u.B)
+	case u.C != nil:
+		return gorkson.Marshal(u.C)
+	case u.D != nil:
+		return gorkson.Marshal(u.D)
+	default:
+		return nil, errors.New("no value set in union")
+	}
+}
 
-	// Try type A first
+// Validate validates the active union member.
+func (u Union4[A, B, C, D]) Validate(validate *validator.Validate) error {
+	count := 0
+	var value interface{}
+
+	if u.A != nil {
+		count++
+		value = u.A
+	}
+	if u.B != nil {
+		count++
+		value = u.B
+	}
+	if u.C != nil {
+		count++
+		value = u.C
+	}
+	if u.D != nil {
+		count++
+		value = u.D
+	}
+
+	if count == 0 {
+		return errors.New("exactly one union option must be set")
+	}
+	if count > 1 {
+		return errors.New("only one union option can be set")
+	}
+
+	return validateVariant(validate, value)
+}
+
+// Value returns the active value and its type index (0-based).
+func (u Union4[A, B, C, D]) Value() (interface{}, int) {
+	switch {
+	case u.A != nil:
+		return u.A, 0
+	case u.B != nil:
+		return u.B, 1
+	case u.C != nil:
+		return u.C, 2
+	case u.D != nil:
+		return u.D, 3
+	default:
+		return nil, -1
+	}
+}
+
+// Union5 represents a union of five types.
+type Union5[A, B, C, D, E any] struct {
+	A *A
+	B *B
+	C *C
+	D *D
+	E *E
+}
+
+// UnmarshalJSON implements json.Unmarshaler for Union5. It decodes the
+// variant data matches best, see MatchError for the errors it returns.
+func (u *Union5[A, B, C, D, E]) UnmarshalJSON(data []byte) error {
 	var a A
-	if err := json.Unmarshal(data, &a); err == nil {
-		if err := validate.Struct(a); err == nil {
-			u.A = &a
-			return nil
-		}
+	var b B
+	var c C
+	var d D
+	var e E
+	index, err := decodeVariant(data, &a, &b, &c, &d, &e)
+
+	*u = Union5[A, B, C, D, E]{}
+	switch index {
+	case 0:
+		u.A = &a
+	case 1:
+		u.B = &b
+	case 2:
+		u.C = &c
+	case 3:
+		u.D = &d
+	case 4:
+		u.E = &e
 	}
+	return err
+}
 
-	// Try type B
+// MarshalJSON implements json.Marshaler for Union5.
+func (u Union5[A, B, C, D, E]) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.A != nil:
+		return gorkson.Marshal(u.A)
+	case u.B != nil:
+		return gorkson.Marshal(u.B)
+	case u.C != nil:
+		return gorkson.Marshal(u.C)
+	case u.D != nil:
+		return gorkson.Marshal(u.D)
+	case u.E != nil:
+		return gorkson.Marshal(u.E)
+	default:
+		return nil, errors.New("no value set in union")
+	}
+}
+
+// Validate validates the active union member.
+func (u Union5[A, B, C, D, E]) Validate(validate *validator.Validate) error {
+	count := 0
+	var value interface{}
+
+	if u.A != nil {
+		count++
+		value = u.A
+	}
+	if u.B != nil {
+		count++
+		value = u.B
+	}
+	if u.C != nil {
+		count++
+		value = u.C
+	}
+	if u.D != nil {
+		count++
+		value = u.D
+	}
+	if u.E != nil {
+		count++
+		value = u.E
+	}
+
+	if count == 0 {
+		return errors.New("exactly one union option must be set")
+	}
+	if count > 1 {
+		return errors.New("only one union option can be set")
+	}
+
+	return validateVariant(validate, value)
+}
+
+// Value returns the active value and its type index (0-based).
+func (u Union5[A, B, C, D, E]) Value() (interface{}, int) {
+	switch {
+	case u.A != nil:
+		return u.A, 0
+	case u.B != nil:
+		return u.B, 1
+	case u.C != nil:
+		return u.C, 2
+	case u.D != nil:
+		return u.D, 3
+	case u.E != nil:
+		return u.E, 4
+	default:
+		return nil, -1
+	}
+}
+
+// Union6 represents a union of six types.
+type Union6[A, B, C, D, E, F any] struct {
+	A *A
+	B *B
+	C *C
+	D *D
+	E *E
+	F *F
+}
+
+// UnmarshalJSON implements json.Unmarshaler for Union6. It decodes the
+// variant data matches best, see MatchError for the errors it returns.
+func (u *Union6[A, B, C, D, E, F]) UnmarshalJSON(data []byte) error {
+	var a A
 	var b B
-	if err := json.Unmarshal(data, &b); err == nil {
-		if err := validate.Struct(b); err == nil {
-			u.B = &b
-			return nil
-		}
+	var c C
+	var d D
+	var e E
+	var f F
+	index, err := decodeVariant(data, &a, &b, &c, &d, &e, &f)
+
+	*u = Union6[A, B, C, D, E, F]{}
+	switch index {
+	case 0:
+		u.A = &a
+	case 1:
+		u.B = &b
+	case 2:
+		u.C = &c
+	case 3:
+		u.D = &d
+	case 4:
+		u.E = &e
+	case 5:
+		u.F = &f
+	}
+	return err
+}
+
+// MarshalJSON implements json.Marshaler for Union6.
+func (u Union6[A, B, C, D, E, F]) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.A != nil:
+		return gorkson.Marshal(u.A)
+	case u.B != nil:
+		return gorkson.Marshal(u.B)
+	case u.C != nil:
+		return gorkson.Marshal(u.C)
+	case u.D != nil:
+		return gorkson.Marshal(u.D)
+	case u.E != nil:
+		return gorkson.Marshal(u.E)
+	case u.F != nil:
+		return gorkson.Marshal(u.F)
+	default:
+		return nil, errors.New("no value set in union")
+	}
+}
+
+// Validate validates the active union member.
+func (u Union6[A, B, C, D, E, F]) Validate(validate *validator.Validate) error {
+	count := 0
+	var value interface{}
+
+	if u.A != nil {
+		count++
+		value = u.A
+	}
+	if u.B != nil {
+		count++
+		value = u.B
+	}
+	if u.C != nil {
+		count++
+		value = u.C
+	}
+	if u.D != nil {
+		count++
+		value = u.D
+	}
+	if u.E != nil {
+		count++
+		value = u.E
+	}
+	if u.F != nil {
+		count++
+		value = u.F
 	}
 
-	// Try type C
+	if count == 0 {
+		return errors.New("exactly one union option must be set")
+	}
+	if count > 1 {
+		return errors.New("only one union option can be set")
+	}
+
+	return validateVariant(validate, value)
+}
+
+// Value returns the active value and its type index (0-based).
+func (u Union6[A, B, C, D, E, F]) Value() (interface{}, int) {
+	switch {
+	case u.A != nil:
+		return u.A, 0
+	case u.B != nil:
+		return u.B, 1
+	case u.C != nil:
+		return u.C, 2
+	case u.D != nil:
+		return u.D, 3
+	case u.E != nil:
+		return u.E, 4
+	case u.F != nil:
+		return u.F, 5
+	default:
+		return nil, -1
+	}
+}
+
+// Union7 represents a union of seven types.
+type Union7[A, B, C, D, E, F, G any] struct {
+	A *A
+	B *B
+	C *C
+	D *D
+	E *E
+	F *F
+	G *G
+}
+
+// UnmarshalJSON implements json.Unmarshaler for Union7. It decodes the
+// variant data matches best, see MatchError for the errors it returns.
+func (u *Union7[A, B, C, D, E, F, G]) UnmarshalJSON(data []byte) error {
+	var a A
+	var b B
 	var c C
-	if err := json.Unmarshal(data, &c); err == nil {
-		if err := validate.Struct(c); err == nil {
-			u.C = &c
-			return nil
-		}
+	var d D
+	var e E
+	var f F
+	var g G
+	index, err := decodeVariant(data, &a, &b, &c, &d, &e, &f, &g)
+
+	*u = Union7[A, B, C, D, E, F, G]{}
+	switch index {
+	case 0:
+		u.A = &a
+	case 1:
+		u.B = &b
+	case 2:
+		u.C = &c
+	case 3:
+		u.D = &d
+	case 4:
+		u.E = &e
+	case 5:
+		u.F = &f
+	case 6:
+		u.G = &g
+	}
+	return err
+}
+
+// MarshalJSON implements json.Marshaler for Union7.
+func (u Union7[A, B, C, D, E, F, G]) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.A != nil:
+		return gorkson.Marshal(u.A)
+	case u.B != nil:
+		return gorkson.Marshal(u.B)
+	case u.C != nil:
+		return gorkson.Marshal(u.C)
+	case u.D != nil:
+		return gorkson.Marshal(u.D)
+	case u.E != nil:
+		return gorkson.Marshal(u.E)
+	case u.F != nil:
+		return gorkson.Marshal(u.F)
+	case u.G != nil:
+		return gorkson.Marshal(u.G)
+	default:
+		return nil, errors.New("no value set in union")
+	}
+}
+
+// Validate validates the active union member.
+func (u Union7[A, B, C, D, E, F, G]) Validate(validate *validator.Validate) error {
+	count := 0
+	var value interface{}
+
+	if u.A != nil {
+		count++
+		value = u.A
+	}
+	if u.B != nil {
+		count++
+		value = u.B
+	}
+	if u.C != nil {
+		count++
+		value = u.C
+	}
+	if u.D != nil {
+		count++
+		value = u.D
+	}
+	if u.E != nil {
+		count++
+		value = u.E
+	}
+	if u.F != nil {
+		count++
+		value = u.F
+	}
+	if u.G != nil {
+		count++
+		value = u.G
+	}
+
+	if count == 0 {
+		return errors.New("exactly one union option must be set")
 	}
+	if count > 1 {
+		return errors.New("only one union option can be set")
+	}
+
+	return validateVariant(validate, value)
+}
+
+// Value returns the active value and its type index (0-based).
+func (u Union7[A, B, C, D, E, F, G]) Value() (interface{}, int) {
+	switch {
+	case u.A != nil:
+		return u.A, 0
+	case u.B != nil:
+		return u.B, 1
+	case u.C != nil:
+		return u.C, 2
+	case u.D != nil:
+		return u.D, 3
+	case u.E != nil:
+		return u.E, 4
+	case u.F != nil:
+		return u.F, 5
+	case u.G != nil:
+		return u.G, 6
+	default:
+		return nil, -1
+	}
+}
+
+// Union8 represents a union of eight types.
+type Union8[A, B, C, D, E, F, G, H any] struct {
+	A *A
+	B *B
+	C *C
+	D *D
+	E *E
+	F *F
+	G *G
+	H *H
+}
 
-	// Try type D
+// UnmarshalJSON implements json.Unmarshaler for Union8. It decodes the
+// variant data matches best, see MatchError for the errors it returns.
+func (u *Union8[A, B, C, D, E, F, G, H]) UnmarshalJSON(data []byte) error {
+	var a A
+	var b B
+	var c C
 	var d D
-	if err := json.Unmarshal(data, &d); err == nil {
-		if err := validate.Struct(d); err == nil {
-			u.D = &d
-			return nil
-		}
+	var e E
+	var f F
+	var g G
+	var h H
+	index, err := decodeVariant(data, &a, &b, &c, &d, &e, &f, &g, &h)
+
+	*u = Union8[A, B, C, D, E, F, G, H]{}
+	switch index {
+	case 0:
+		u.A = &a
+	case 1:
+		u.B = &b
+	case 2:
+		u.C = &c
+	case 3:
+		u.D = &d
+	case 4:
+		u.E = &e
+	case 5:
+		u.F = &f
+	case 6:
+		u.G = &g
+	case 7:
+		u.H = &h
 	}
+	return err
+}
 
-	return fmt.Errorf("failed to unmarshal into any union type: data does not match any of the union variants")
+// MarshalJSON implements json.Marshaler for Union8.
+func (u Union8[A, B, C, D, E, F, G, H]) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.A != nil:
+		return gorkson.Marshal(u.A)
+	case u.B != nil:
+		return gorkson.Marshal(u.B)
+	case u.C != nil:
+		return gorkson.Marshal(u.C)
+	case u.D != nil:
+		return gorkson.Marshal(u.D)
+	case u.E != nil:
+		return gorkson.Marshal(u.E)
+	case u.F != nil:
+		return gorkson.Marshal(u.F)
+	case u.G != nil:
+		return gorkson.Marshal(u.G)
+	case u.H != nil:
+		return gorkson.Marshal(u.H)
+	default:
+		return nil, errors.New("no value set in union")
+	}
 }
 
-// MarshalJSON implements json.Marshaler for Union4.
-func (u Union4[A, B, C, D]) MarshalJSON() ([]byte, error) {
+// Validate validates the active union member.
+func (u Union8[A, B, C, D, E, F, G, H]) Validate(validate *validator.Validate) error {
+	count := 0
+	var value interface{}
+
+	if u.A != nil {
+		count++
+		value = u.A
+	}
+	if u.B != nil {
+		count++
+		value = u.B
+	}
+	if u.C != nil {
+		count++
+		value = u.C
+	}
+	if u.D != nil {
+		count++
+		value = u.D
+	}
+	if u.E != nil {
+		count++
+		value = u.E
+	}
+	if u.F != nil {
+		count++
+		value = u.F
+	}
+	if u.G != nil {
+		count++
+		value = u.G
+	}
+	if u.H != nil {
+		count++
+		value = u.H
+	}
+
+	if count == 0 {
+		return errors.New("exactly one union option must be set")
+	}
+	if count > 1 {
+		return errors.New("only one union option can be set")
+	}
+
+	return validateVariant(validate, value)
+}
+
+// Value returns the active value and its type index (0-based).
+func (u Union8[A, B, C, D, E, F, G, H]) Value() (interface{}, int) {
+	switch {
+	case u.A != nil:
+		return u.A, 0
+	case u.B != nil:
+		return u.B, 1
+	case u.C != nil:
+		return u.C, 2
+	case u.D != nil:
+		return u.D, 3
+	case u.E != nil:
+		return u.E, 4
+	case u.F != nil:
+		return u.F, 5
+	case u.G != nil:
+		return u.G, 6
+	case u.H != nil:
+		return u.H, 7
+	default:
+		return nil, -1
+	}
+}
+
+// Union9 represents a union of nine types.
+type Union9[A, B, C, D, E, F, G, H, I any] struct {
+	A *A
+	B *B
+	C *C
+	D *D
+	E *E
+	F *F
+	G *G
+	H *H
+	I *I
+}
+
+// UnmarshalJSON implements json.Unmarshaler for Union9. It decodes the
+// variant data matches best, see MatchError for the errors it returns.
+func (u *Union9[A, B, C, D, E, F, G, H, I]) UnmarshalJSON(data []byte) error {
+	var a A
+	var b B
+	var c C
+	var d D
+	var e E
+	var f F
+	var g G
+	var h H
+	var i I
+	index, err := decodeVariant(data, &a, &b, &c, &d, &e, &f, &g, &h, &i)
+
+	*u = Union9[A, B, C, D, E, F, G, H, I]{}
+	switch index {
+	case 0:
+		u.A = &a
+	case 1:
+		u.B = &b
+	case 2:
+		u.C = &c
+	case 3:
+		u.D = &d
+	case 4:
+		u.E = &e
+	case 5:
+		u.F = &f
+	case 6:
+		u.G = &g
+	case 7:
+		u.H = &h
+	case 8:
+		u.I = &i
+	}
+	return err
+}
+
+// MarshalJSON implements json.Marshaler for Union9.
+func (u Union9[A, B, C, D, E, F, G, H, I]) MarshalJSON() ([]byte, error) {
 	switch {
 	case u.A != nil:
 		return gorkson.Marshal(u.A)
@@ -274,13 +832,23 @@ func (u Union4[A, B, C, D]) MarshalJSON() ([]byte, error) {
 		return gorkson.Marshal(u.C)
 	case u.D != nil:
 		return gorkson.Marshal(u.D)
+	case u.E != nil:
+		return gorkson.Marshal(u.E)
+	case u.F != nil:
+		return gorkson.Marshal(u.F)
+	case u.G != nil:
+		return gorkson.Marshal(u.G)
+	case u.H != nil:
+		return gorkson.Marshal(u.H)
+	case u.I != nil:
+		return gorkson.Marshal(u.I)
 	default:
 		return nil, errors.New("no value set in union")
 	}
 }
 
 // Validate validates the active union member.
-func (u Union4[A, B, C, D]) Validate(validate *validator.Validate) error {
+func (u Union9[A, B, C, D, E, F, G, H, I]) Validate(validate *validator.Validate) error {
 	count := 0
 	var value interface{}
 
@@ -300,6 +868,26 @@ func (u Union4[A, B, C, D]) Validate(validate *validator.Validate) error {
 		count++
 		value = u.D
 	}
+	if u.E != nil {
+		count++
+		value = u.E
+	}
+	if u.F != nil {
+		count++
+		value = u.F
+	}
+	if u.G != nil {
+		count++
+		value = u.G
+	}
+	if u.H != nil {
+		count++
+		value = u.H
+	}
+	if u.I != nil {
+		count++
+		value = u.I
+	}
 
 	if count == 0 {
 		return errors.New("exactly one union option must be set")
@@ -308,11 +896,11 @@ func (u Union4[A, B, C, D]) Validate(validate *validator.Validate) error {
 		return errors.New("only one union option can be set")
 	}
 
-	return validate.Struct(value)
+	return validateVariant(validate, value)
 }
 
 // Value returns the active value and its type index (0-based).
-func (u Union4[A, B, C, D]) Value() (interface{}, int) {
+func (u Union9[A, B, C, D, E, F, G, H, I]) Value() (interface{}, int) {
 	switch {
 	case u.A != nil:
 		return u.A, 0
@@ -322,6 +910,16 @@ func (u Union4[A, B, C, D]) Value() (interface{}, int) {
 		return u.C, 2
 	case u.D != nil:
 		return u.D, 3
+	case u.E != nil:
+		return u.E, 4
+	case u.F != nil:
+		return u.F, 5
+	case u.G != nil:
+		return u.G, 6
+	case u.H != nil:
+		return u.H, 7
+	case u.I != nil:
+		return u.I, 8
 	default:
 		return nil, -1
 	}
@@ -2,10 +2,13 @@ package unions
 
 import (
 	"encoding/json"
+	"errors"
+	"strings"
 	"sync"
 	"testing"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/gork-labs/gork/pkg/gorkson"
 )
 
 // Common test types to reduce duplication
@@ -901,3 +904,340 @@ func TestUnionConcurrency(t *testing.T) {
 		}
 	})
 }
+
+// Variant types for the larger unions, told apart by their kind.
+type (
+	KindOne struct {
+		Kind string `json:"kind" validate:"required,eq=one"`
+	}
+	KindTwo struct {
+		Kind string `json:"kind" validate:"required,eq=two"`
+	}
+	KindThree struct {
+		Kind string `json:"kind" validate:"required,eq=three"`
+	}
+	KindFour struct {
+		Kind string `json:"kind" validate:"required,eq=four"`
+	}
+	KindFive struct {
+		Kind string `json:"kind" validate:"required,eq=five"`
+	}
+	KindSix struct {
+		Kind string `json:"kind" validate:"required,eq=six"`
+	}
+	KindSeven struct {
+		Kind string `json:"kind" validate:"required,eq=seven"`
+	}
+	KindEight struct {
+		Kind string `json:"kind" validate:"required,eq=eight"`
+	}
+	KindNine struct {
+		Kind string `json:"kind" validate:"required,eq=nine"`
+	}
+)
+
+// TestLargeUnions covers Union5 through Union9 with their last variant, the
+// one tried last when decoding.
+func TestLargeUnions(t *testing.T) {
+	validate := validator.New()
+	type union interface {
+		Value() (interface{}, int)
+		Validate(*validator.Validate) error
+	}
+	tests := []struct {
+		name  string
+		kind  string
+		union func() union
+	}{
+		{"Union5", "five", func() union { return &Union5[KindOne, KindTwo, KindThree, KindFour, KindFive]{} }},
+		{"Union6", "six", func() union { return &Union6[KindOne, KindTwo, KindThree, KindFour, KindFive, KindSix]{} }},
+		{"Union7", "seven", func() union {
+			return &Union7[KindOne, KindTwo, KindThree, KindFour, KindFive, KindSix, KindSeven]{}
+		}},
+		{"Union8", "eight", func() union {
+			return &Union8[KindOne, KindTwo, KindThree, KindFour, KindFive, KindSix, KindSeven, KindEight]{}
+		}},
+		{"Union9", "nine", func() union {
+			return &Union9[KindOne, KindTwo, KindThree, KindFour, KindFive, KindSix, KindSeven, KindEight, KindNine]{}
+		}},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := tt.union()
+			if _, index := u.Value(); index != -1 {
+				t.Errorf("expected no value, got index %d", index)
+			}
+			if err := u.Validate(validate); err == nil {
+				t.Error("expected an empty union to fail validation")
+			}
+			data := `{"kind":"` + tt.kind + `"}`
+			if err := json.Unmarshal([]byte(data), u); err != nil {
+				t.Fatal(err)
+			}
+			if _, index := u.Value(); index != i+4 {
+				t.Errorf("expected the last variant, got index %d", index)
+			}
+			if err := u.Validate(validate); err != nil {
+				t.Errorf("unexpected validation error: %v", err)
+			}
+			out, err := json.Marshal(u)
+			if err != nil || string(out) != data {
+				t.Errorf("expected %s, got %s (%v)", data, out, err)
+			}
+			if err := json.Unmarshal([]byte(`{"kind":"ten"}`), u); err == nil {
+				t.Error("expected an error for data matching no variant")
+			}
+			if _, err := json.Marshal(tt.union()); err == nil {
+				t.Error("expected an error marshaling an empty union")
+			}
+		})
+	}
+
+	both := Union5[KindOne, KindTwo, KindThree, KindFour, KindFive]{A: &KindOne{Kind: "one"}, E: &KindFive{Kind: "five"}}
+	if err := both.Validate(validate); err == nil {
+		t.Error("expected an error when several variants are set")
+	}
+}
+
+// TestStructuralMatching covers decoding of unions without discriminators.
+func TestStructuralMatching(t *testing.T) {
+	type Person struct {
+		Name string `gork:"name" validate:"required"`
+	}
+	type Employee struct {
+		Name     string `gork:"name" validate:"required"`
+		Employer string `gork:"employer_name,alias=employer" validate:"required"`
+	}
+
+	t.Run("more required fields win over declaration order", func(t *testing.T) {
+		var u Union2[Person, Employee]
+		if err := json.Unmarshal([]byte(`{"name":"ann","employer_name":"acme"}`), &u); err != nil {
+			t.Fatal(err)
+		}
+		if u.B == nil || u.B.Employer != "acme" {
+			t.Errorf("expected the employee variant with gork names decoded, got %+v", u)
+		}
+	})
+
+	t.Run("aliases are known fields", func(t *testing.T) {
+		var u Union2[Person, Employee]
+		if err := json.Unmarshal([]byte(`{"name":"ann","employer":"acme"}`), &u); err != nil || u.B == nil {
+			t.Errorf("expected the alias to select the employee variant, got %+v (%v)", u, err)
+		}
+	})
+
+	t.Run("missing required fields reject a variant", func(t *testing.T) {
+		var u Union2[Person, Employee]
+		if err := json.Unmarshal([]byte(`{"name":"ann"}`), &u); err != nil || u.A == nil {
+			t.Errorf("expected the person variant, got %+v (%v)", u, err)
+		}
+	})
+
+	t.Run("ties are ambiguous", func(t *testing.T) {
+		type Pet struct {
+			Name    string `gork:"name" validate:"required"`
+			Species string `gork:"species"`
+		}
+		var u Union2[Person, Pet]
+		err := json.Unmarshal([]byte(`{"name":"rex"}`), &u)
+		var matchErr *MatchError
+		if !errors.As(err, &matchErr) || len(matchErr.Candidates) != 2 {
+			t.Fatalf("expected a MatchError listing both variants, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "A (unions.Person), B (unions.Pet)") {
+			t.Errorf("expected the candidates in the message, got %q", err.Error())
+		}
+		if u.A != nil || u.B != nil {
+			t.Errorf("expected no variant to be set, got %+v", u)
+		}
+		if err := json.Unmarshal([]byte(`{"name":"rex","species":"dog"}`), &u); err != nil || u.B == nil {
+			t.Errorf("expected the pet variant, got %+v (%v)", u, err)
+		}
+	})
+
+	t.Run("mismatches are listed per variant", func(t *testing.T) {
+		var u Union2[Person, IntData]
+		err := json.Unmarshal([]byte(`{"name":"ann","age":3}`), &u)
+		var matchErr *MatchError
+		if !errors.As(err, &matchErr) || len(matchErr.Mismatches) != 2 {
+			t.Fatalf("expected a MatchError for both variants, got %v", err)
+		}
+		if matchErr.Mismatches[0].Variant != "A" || !strings.Contains(matchErr.Mismatches[0].Err.Error(), `unknown field "age"`) {
+			t.Errorf("unexpected mismatch %+v", matchErr.Mismatches[0])
+		}
+		if !strings.Contains(err.Error(), "B (unions.IntData)") {
+			t.Errorf("expected the second variant in the message, got %q", err.Error())
+		}
+		if u.A != nil || u.B != nil {
+			t.Errorf("expected no variant to be set, got %+v", u)
+		}
+	})
+
+	t.Run("kinds must match", func(t *testing.T) {
+		var u Union2[IntData, FloatData]
+		if err := json.Unmarshal([]byte(`{"value":2.5}`), &u); err != nil || u.B == nil {
+			t.Errorf("expected a fractional number to skip the integer variant, got %+v (%v)", u, err)
+		}
+		err := json.Unmarshal([]byte(`{"value":"2"}`), &u)
+		if err == nil || !strings.Contains(err.Error(), `field "value": expected an integer`) {
+			t.Errorf("expected a kind mismatch, got %v", err)
+		}
+	})
+}
+
+// TestStringOrObjectUnion covers Stripe-style expandable fields.
+func TestStringOrObjectUnion(t *testing.T) {
+	var u Union2[string, User]
+	if err := json.Unmarshal([]byte(`"usr_1"`), &u); err != nil || u.A == nil || *u.A != "usr_1" {
+		t.Fatalf("expected the string variant, got %+v (%v)", u, err)
+	}
+	if err := u.Validate(validator.New()); err != nil {
+		t.Errorf("expected a string variant to validate, got %v", err)
+	}
+
+	data := `{"name":"Ann","email":"ann@example.com","address":{"street":"Main","city":"Berlin"}}`
+	if err := json.Unmarshal([]byte(data), &u); err != nil || u.A != nil || u.B == nil {
+		t.Fatalf("expected the object variant, got %+v (%v)", u, err)
+	}
+	out, err := json.Marshal(u)
+	if err != nil || !strings.Contains(string(out), `"email":"ann@example.com"`) {
+		t.Errorf("unexpected encoding %s (%v)", out, err)
+	}
+
+	if err := json.Unmarshal([]byte(`42`), &u); err == nil {
+		t.Error("expected a number to match neither variant")
+	}
+}
+
+func TestOptional(t *testing.T) {
+	type patch struct {
+		Nickname Optional[string]                     `gork:"nickname"`
+		Auth     Optional[Union2[EmailAuth, IntData]] `gork:"auth"`
+	}
+
+	tests := []struct {
+		name    string
+		json    string
+		present bool
+		null    bool
+	}{
+		{"absent", `{}`, false, false},
+		{"null", `{"nickname":null,"auth":null}`, true, true},
+		{"set", `{"nickname":"bob","auth":{"value":3}}`, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var viaGorkson, viaJSON patch
+			if err := gorkson.Unmarshal([]byte(tt.json), &viaGorkson); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(tt.json), &viaJSON); err != nil {
+				t.Fatal(err)
+			}
+			for _, p := range []patch{viaGorkson, viaJSON} {
+				if p.Nickname.Present != tt.present || p.Nickname.Null != tt.null || p.Auth.Present != tt.present || p.Auth.Null != tt.null {
+					t.Errorf("expected present=%v null=%v, got %+v", tt.present, tt.null, p)
+				}
+				if p.Nickname.IsSet() && (p.Nickname.Value != "bob" || p.Auth.Value.B == nil || p.Auth.Value.B.Value != 3) {
+					t.Errorf("unexpected values %+v", p)
+				}
+			}
+		})
+	}
+
+	if v, ok := Some("bob").Get(); !ok || v != "bob" {
+		t.Errorf("expected Some to be set, got %q %v", v, ok)
+	}
+	if _, ok := Null[string]().Get(); ok || !Null[string]().Present {
+		t.Error("expected Null to be present but not set")
+	}
+	if !(Optional[string]{}).IsZero() || Null[string]().IsZero() {
+		t.Error("expected only absent values to be zero")
+	}
+
+	data, err := json.Marshal(struct {
+		A Optional[string] `json:"a"`
+		B Optional[string] `json:"b"`
+		C Optional[string] `json:"c,omitzero"`
+	}{A: Some("x"), B: Null[string]()})
+	if err != nil || string(data) != `{"a":"x","b":null}` {
+		t.Errorf("unexpected encoding %s (%v)", data, err)
+	}
+}
+
+type authVisitor struct{ visited []string }
+
+func (v *authVisitor) VisitA(a EmailAuth) { v.visited = append(v.visited, "email "+a.Email) }
+func (v *authVisitor) VisitB(b PhoneAuth) { v.visited = append(v.visited, "phone "+b.Phone) }
+func (v *authVisitor) VisitC(c IntData)   { v.visited = append(v.visited, "int") }
+
+func TestMatchAndVisit(t *testing.T) {
+	var u Union3[EmailAuth, PhoneAuth, IntData]
+	u.B = &PhoneAuth{Phone: "+15555550100"}
+
+	var got string
+	u.Match(
+		func(a EmailAuth) { got = "email" },
+		func(b PhoneAuth) { got = b.Phone },
+		func(c IntData) { got = "int" },
+	)
+	if got != "+15555550100" {
+		t.Errorf("expected the phone variant, got %q", got)
+	}
+
+	// Nil functions and empty unions are ignored.
+	u.Match(nil, nil, nil)
+	Union3[EmailAuth, PhoneAuth, IntData]{}.Match(nil, func(PhoneAuth) { t.Error("unexpected call") }, nil)
+
+	visitor := &authVisitor{}
+	u.Visit(visitor)
+	Union3[EmailAuth, PhoneAuth, IntData]{A: &EmailAuth{Email: "a@example.com"}}.Visit(visitor)
+	if strings.Join(visitor.visited, ", ") != "phone +15555550100, email a@example.com" {
+		t.Errorf("unexpected visits %v", visitor.visited)
+	}
+
+	var large Union9[int, int, int, int, int, int, int, int, string]
+	large.I = new(string)
+	matched := false
+	large.Match(nil, nil, nil, nil, nil, nil, nil, nil, func(string) { matched = true })
+	if !matched {
+		t.Error("expected the last variant of a Union9 to match")
+	}
+}
+
+type Contact struct {
+	Email string `gork:"email" validate:"required"`
+}
+
+type EmbeddedPerson struct {
+	Contact
+	Name string `gork:"name" validate:"required"`
+}
+
+func TestEmbeddedVariantFields(t *testing.T) {
+	var u Union2[EmbeddedPerson, IntData]
+	if err := json.Unmarshal([]byte(`{"name":"Ann","email":"ann@example.com"}`), &u); err != nil {
+		t.Fatalf("expected promoted fields to match, got %v", err)
+	}
+	if u.A == nil || u.A.Email != "ann@example.com" || u.A.Name != "Ann" {
+		t.Errorf("expected the person variant, got %+v", u)
+	}
+}
+
+type LegacyLogin struct {
+	UserID   string `gork:"user_id,alias=userId|uid" validate:"required"`
+	Password string `gork:"password,nocase" validate:"required"`
+}
+
+func TestVariantAliasesAndNoCase(t *testing.T) {
+	var u Union2[LegacyLogin, IntData]
+	if err := json.Unmarshal([]byte(`{"uid":"u1","PASSWORD":"secret"}`), &u); err != nil {
+		t.Fatalf("expected aliases and nocase names to match, got %v", err)
+	}
+	if u.A == nil || u.A.UserID != "u1" || u.A.Password != "secret" {
+		t.Errorf("expected the login variant, got %+v", u.A)
+	}
+	if err := json.Unmarshal([]byte(`{"UID":"u1","password":"secret"}`), &u); err == nil {
+		t.Error("expected aliases without nocase to be case-sensitive")
+	}
+}
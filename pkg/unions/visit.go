@@ -0,0 +1,357 @@
+package unions
+
+// Match and Visit dispatch on the variant set in a union, replacing if/else
+// chains over its fields:
+//
+//	method.Match(
+//		func(card CreditCard) { charge(card) },
+//		func(bank BankAccount) { debit(bank) },
+//	)
+
+// Visitor2 handles each variant of a Union2.
+type Visitor2[A, B any] interface {
+	VisitA(A)
+	VisitB(B)
+}
+
+// Match calls the function of the variant set in u with its value. Nil
+// functions and unions with no variant set are ignored.
+func (u Union2[A, B]) Match(onA func(A), onB func(B)) {
+	switch {
+	case u.A != nil:
+		if onA != nil {
+			onA(*u.A)
+		}
+	case u.B != nil:
+		if onB != nil {
+			onB(*u.B)
+		}
+	}
+}
+
+// Visit calls the method of visitor for the variant set in u.
+func (u Union2[A, B]) Visit(visitor Visitor2[A, B]) {
+	u.Match(visitor.VisitA, visitor.VisitB)
+}
+
+// Visitor3 handles each variant of a Union3.
+type Visitor3[A, B, C any] interface {
+	VisitA(A)
+	VisitB(B)
+	VisitC(C)
+}
+
+// Match calls the function of the variant set in u with its value. Nil
+// functions and unions with no variant set are ignored.
+func (u Union3[A, B, C]) Match(onA func(A), onB func(B), onC func(C)) {
+	switch {
+	case u.A != nil:
+		if onA != nil {
+			onA(*u.A)
+		}
+	case u.B != nil:
+		if onB != nil {
+			onB(*u.B)
+		}
+	case u.C != nil:
+		if onC != nil {
+			onC(*u.C)
+		}
+	}
+}
+
+// Visit calls the method of visitor for the variant set in u.
+func (u Union3[A, B, C]) Visit(visitor Visitor3[A, B, C]) {
+	u.Match(visitor.VisitA, visitor.VisitB, visitor.VisitC)
+}
+
+// Visitor4 handles each variant of a Union4.
+type Visitor4[A, B, C, D any] interface {
+	VisitA(A)
+	VisitB(B)
+	VisitC(C)
+	VisitD(D)
+}
+
+// Match calls the function of the variant set in u with its value. Nil
+// functions and unions with no variant set are ignored.
+func (u Union4[A, B, C, D]) Match(onA func(A), onB func(B), onC func(C), onD func(D)) {
+	switch {
+	case u.A != nil:
+		if onA != nil {
+			onA(*u.A)
+		}
+	case u.B != nil:
+		if onB != nil {
+			onB(*u.B)
+		}
+	case u.C != nil:
+		if onC != nil {
+			onC(*u.C)
+		}
+	case u.D != nil:
+		if onD != nil {
+			onD(*u.D)
+		}
+	}
+}
+
+// Visit calls the method of visitor for the variant set in u.
+func (u Union4[A, B, C, D]) Visit(visitor Visitor4[A, B, C, D]) {
+	u.Match(visitor.VisitA, visitor.VisitB, visitor.VisitC, visitor.VisitD)
+}
+
+// Visitor5 handles each variant of a Union5.
+type Visitor5[A, B, C, D, E any] interface {
+	VisitA(A)
+	VisitB(B)
+	VisitC(C)
+	VisitD(D)
+	VisitE(E)
+}
+
+// Match calls the function of the variant set in u with its value. Nil
+// functions and unions with no variant set are ignored.
+func (u Union5[A, B, C, D, E]) Match(onA func(A), onB func(B), onC func(C), onD func(D), onE func(E)) {
+	switch {
+	case u.A != nil:
+		if onA != nil {
+			onA(*u.A)
+		}
+	case u.B != nil:
+		if onB != nil {
+			onB(*u.B)
+		}
+	case u.C != nil:
+		if onC != nil {
+			onC(*u.C)
+		}
+	case u.D != nil:
+		if onD != nil {
+			onD(*u.D)
+		}
+	case u.E != nil:
+		if onE != nil {
+			onE(*u.E)
+		}
+	}
+}
+
+// Visit calls the method of visitor for the variant set in u.
+func (u Union5[A, B, C, D, E]) Visit(visitor Visitor5[A, B, C, D, E]) {
+	u.Match(visitor.VisitA, visitor.VisitB, visitor.VisitC, visitor.VisitD, visitor.VisitE)
+}
+
+// Visitor6 handles each variant of a Union6.
+type Visitor6[A, B, C, D, E, F any] interface {
+	VisitA(A)
+	VisitB(B)
+	VisitC(C)
+	VisitD(D)
+	VisitE(E)
+	VisitF(F)
+}
+
+// Match calls the function of the variant set in u with its value. Nil
+// functions and unions with no variant set are ignored.
+func (u Union6[A, B, C, D, E, F]) Match(onA func(A), onB func(B), onC func(C), onD func(D), onE func(E), onF func(F)) {
+	switch {
+	case u.A != nil:
+		if onA != nil {
+			onA(*u.A)
+		}
+	case u.B != nil:
+		if onB != nil {
+			onB(*u.B)
+		}
+	case u.C != nil:
+		if onC != nil {
+			onC(*u.C)
+		}
+	case u.D != nil:
+		if onD != nil {
+			onD(*u.D)
+		}
+	case u.E != nil:
+		if onE != nil {
+			onE(*u.E)
+		}
+	case u.F != nil:
+		if onF != nil {
+			onF(*u.F)
+		}
+	}
+}
+
+// Visit calls the method of visitor for the variant set in u.
+func (u Union6[A, B, C, D, E, F]) Visit(visitor Visitor6[A, B, C, D, E, F]) {
+	u.Match(visitor.VisitA, visitor.VisitB, visitor.VisitC, visitor.VisitD, visitor.VisitE, visitor.VisitF)
+}
+
+// Visitor7 handles each variant of a Union7.
+type Visitor7[A, B, C, D, E, F, G any] interface {
+	VisitA(A)
+	VisitB(B)
+	VisitC(C)
+	VisitD(D)
+	VisitE(E)
+	VisitF(F)
+	VisitG(G)
+}
+
+// Match calls the function of the variant set in u with its value. Nil
+// functions and unions with no variant set are ignored.
+func (u Union7[A, B, C, D, E, F, G]) Match(onA func(A), onB func(B), onC func(C), onD func(D), onE func(E), onF func(F), onG func(G)) {
+	switch {
+	case u.A != nil:
+		if onA != nil {
+			onA(*u.A)
+		}
+	case u.B != nil:
+		if onB != nil {
+			onB(*u.B)
+		}
+	case u.C != nil:
+		if onC != nil {
+			onC(*u.C)
+		}
+	case u.D != nil:
+		if onD != nil {
+			onD(*u.D)
+		}
+	case u.E != nil:
+		if onE != nil {
+			onE(*u.E)
+		}
+	case u.F != nil:
+		if onF != nil {
+			onF(*u.F)
+		}
+	case u.G != nil:
+		if onG != nil {
+			onG(*u.G)
+		}
+	}
+}
+
+// Visit calls the method of visitor for the variant set in u.
+func (u Union7[A, B, C, D, E, F, G]) Visit(visitor Visitor7[A, B, C, D, E, F, G]) {
+	u.Match(visitor.VisitA, visitor.VisitB, visitor.VisitC, visitor.VisitD, visitor.VisitE, visitor.VisitF, visitor.VisitG)
+}
+
+// Visitor8 handles each variant of a Union8.
+type Visitor8[A, B, C, D, E, F, G, H any] interface {
+	VisitA(A)
+	VisitB(B)
+	VisitC(C)
+	VisitD(D)
+	VisitE(E)
+	VisitF(F)
+	VisitG(G)
+	VisitH(H)
+}
+
+// Match calls the function of the variant set in u with its value. Nil
+// functions and unions with no variant set are ignored.
+func (u Union8[A, B, C, D, E, F, G, H]) Match(onA func(A), onB func(B), onC func(C), onD func(D), onE func(E), onF func(F), onG func(G), onH func(H)) {
+	switch {
+	case u.A != nil:
+		if onA != nil {
+			onA(*u.A)
+		}
+	case u.B != nil:
+		if onB != nil {
+			onB(*u.B)
+		}
+	case u.C != nil:
+		if onC != nil {
+			onC(*u.C)
+		}
+	case u.D != nil:
+		if onD != nil {
+			onD(*u.D)
+		}
+	case u.E != nil:
+		if onE != nil {
+			onE(*u.E)
+		}
+	case u.F != nil:
+		if onF != nil {
+			onF(*u.F)
+		}
+	case u.G != nil:
+		if onG != nil {
+			onG(*u.G)
+		}
+	case u.H != nil:
+		if onH != nil {
+			onH(*u.H)
+		}
+	}
+}
+
+// Visit calls the method of visitor for the variant set in u.
+func (u Union8[A, B, C, D, E, F, G, H]) Visit(visitor Visitor8[A, B, C, D, E, F, G, H]) {
+	u.Match(visitor.VisitA, visitor.VisitB, visitor.VisitC, visitor.VisitD, visitor.VisitE, visitor.VisitF, visitor.VisitG, visitor.VisitH)
+}
+
+// Visitor9 handles each variant of a Union9.
+type Visitor9[A, B, C, D, E, F, G, H, I any] interface {
+	VisitA(A)
+	VisitB(B)
+	VisitC(C)
+	VisitD(D)
+	VisitE(E)
+	VisitF(F)
+	VisitG(G)
+	VisitH(H)
+	VisitI(I)
+}
+
+// Match calls the function of the variant set in u with its value. Nil
+// functions and unions with no variant set are ignored.
+func (u Union9[A, B, C, D, E, F, G, H, I]) Match(onA func(A), onB func(B), onC func(C), onD func(D), onE func(E), onF func(F), onG func(G), onH func(H), onI func(I)) {
+	switch {
+	case u.A != nil:
+		if onA != nil {
+			onA(*u.A)
+		}
+	case u.B != nil:
+		if onB != nil {
+			onB(*u.B)
+		}
+	case u.C != nil:
+		if onC != nil {
+			onC(*u.C)
+		}
+	case u.D != nil:
+		if onD != nil {
+			onD(*u.D)
+		}
+	case u.E != nil:
+		if onE != nil {
+			onE(*u.E)
+		}
+	case u.F != nil:
+		if onF != nil {
+			onF(*u.F)
+		}
+	case u.G != nil:
+		if onG != nil {
+			onG(*u.G)
+		}
+	case u.H != nil:
+		if onH != nil {
+			onH(*u.H)
+		}
+	case u.I != nil:
+		if onI != nil {
+			onI(*u.I)
+		}
+	}
+}
+
+// Visit calls the method of visitor for the variant set in u.
+func (u Union9[A, B, C, D, E, F, G, H, I]) Visit(visitor Visitor9[A, B, C, D, E, F, G, H, I]) {
+	u.Match(visitor.VisitA, visitor.VisitB, visitor.VisitC, visitor.VisitD, visitor.VisitE, visitor.VisitF, visitor.VisitG, visitor.VisitH, visitor.VisitI)
+}
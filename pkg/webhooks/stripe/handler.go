@@ -4,7 +4,9 @@ package stripe
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/gork-labs/gork/pkg/api"
@@ -14,7 +16,9 @@ import (
 
 // Handler implements WebhookHandler for Stripe webhooks with event type validation.
 type Handler struct {
-	secret           string
+	secrets          []string
+	verified         []atomic.Uint64
+	onPrevious       func(index int, eventType string)
 	tolerance        time.Duration
 	customEventTypes []string
 }
@@ -23,16 +27,48 @@ type Handler struct {
 // The webhookSecret is required for signature verification using the official Stripe SDK.
 // If customEventTypes is provided, it will be used instead of the default StripeEventTypes.
 func NewHandler(secret string, customEventTypes ...string) api.WebhookHandler[WebhookRequest] {
-	return &Handler{secret: secret, tolerance: 5 * time.Minute, customEventTypes: customEventTypes}
+	return NewRotatingHandler(RotationConfig{Secrets: []string{secret}}, customEventTypes...)
+}
+
+// RotationConfig configures a handler that accepts several signing secrets
+// so that an endpoint secret can be rotated without dropping events.
+type RotationConfig struct {
+	// Secrets lists the active signing secrets: the primary secret first,
+	// followed by previous secrets that are being rotated out.
+	Secrets []string
+	// OnPreviousSecret, when set, is called when a request was verified by a
+	// previous secret only, with its index in Secrets and the event type.
+	// It signals that the sender still uses an old secret and must not block.
+	OnPreviousSecret func(index int, eventType string)
+}
+
+// NewRotatingHandler creates a Stripe webhook handler that verifies
+// signatures against each of the configured secrets in order.
+func NewRotatingHandler(cfg RotationConfig, customEventTypes ...string) *Handler {
+	return &Handler{
+		secrets:          cfg.Secrets,
+		verified:         make([]atomic.Uint64, len(cfg.Secrets)),
+		onPrevious:       cfg.OnPreviousSecret,
+		tolerance:        5 * time.Minute,
+		customEventTypes: customEventTypes,
+	}
+}
+
+// VerifiedCounts returns how many requests each secret verified, indexed
+// like RotationConfig.Secrets. It tells when a previous secret is no longer
+// used and can be removed.
+func (h *Handler) VerifiedCounts() []uint64 {
+	counts := make([]uint64, len(h.verified))
+	for i := range h.verified {
+		counts[i] = h.verified[i].Load()
+	}
+	return counts
 }
 
 // ParseRequest extracts the event type and data from the Stripe webhook payload using the official Stripe SDK.
 // Uses webhook.ConstructEvent() for signature verification and event parsing.
 func (h *Handler) ParseRequest(req WebhookRequest) (api.WebhookEvent, error) {
-	ev, err := webhook.ConstructEventWithOptions(req.Body, req.Headers.StripeSignature, h.secret, webhook.ConstructEventOptions{
-		Tolerance:                h.tolerance,
-		IgnoreAPIVersionMismatch: true,
-	})
+	ev, err := h.constructEvent(req)
 	if err != nil {
 		return api.WebhookEvent{}, fmt.Errorf("stripe webhook signature verification failed: %w", err)
 	}
@@ -47,6 +83,32 @@ func (h *Handler) ParseRequest(req WebhookRequest) (api.WebhookEvent, error) {
 	}, nil
 }
 
+// constructEvent verifies the signature with each secret in turn. Only a
+// signature mismatch moves on to the next secret; malformed headers and
+// expired timestamps fail immediately.
+func (h *Handler) constructEvent(req WebhookRequest) (stripe.Event, error) {
+	err := webhook.ErrNoValidSignature
+	for i, secret := range h.secrets {
+		var ev stripe.Event
+		ev, err = webhook.ConstructEventWithOptions(req.Body, req.Headers.StripeSignature, secret, webhook.ConstructEventOptions{
+			Tolerance:                h.tolerance,
+			IgnoreAPIVersionMismatch: true,
+		})
+		if errors.Is(err, webhook.ErrNoValidSignature) {
+			continue
+		}
+		if err != nil {
+			return stripe.Event{}, err
+		}
+		h.verified[i].Add(1)
+		if i > 0 && h.onPrevious != nil {
+			h.onPrevious(i, string(ev.Type))
+		}
+		return ev, nil
+	}
+	return stripe.Event{}, err
+}
+
 func (h *Handler) mapProviderObjectAndMetadata(ev stripe.Event) (any, json.RawMessage) {
 	// Default: return the full event
 	var userMeta json.RawMessage
@@ -252,3 +252,63 @@ func TestTypes_UtilityCoverage(t *testing.T) {
 	var req WebhookRequest
 	req.WebhookRequest()
 }
+
+func TestRotatingHandler_AcceptsPreviousSecret(t *testing.T) {
+	raw, _ := json.Marshal(&stripe.PaymentIntent{ID: "pi_rot"})
+	body, _ := json.Marshal(stripe.Event{
+		ID:   "evt_rot",
+		Type: stripe.EventTypePaymentIntentSucceeded,
+		Data: &stripe.EventData{Raw: raw},
+	})
+	request := func(secret string) WebhookRequest {
+		ts := time.Now().Unix()
+		req := WebhookRequest{Body: body}
+		req.Headers.StripeSignature = fmt.Sprintf("t=%d,v1=%s", ts, computeStripeSig(secret, ts, body))
+		return req
+	}
+
+	var previousIndex int
+	var previousEvent string
+	h := NewRotatingHandler(RotationConfig{
+		Secrets: []string{"whsec_new", "whsec_old"},
+		OnPreviousSecret: func(index int, eventType string) {
+			previousIndex, previousEvent = index, eventType
+		},
+	})
+
+	if _, err := h.ParseRequest(request("whsec_new")); err != nil {
+		t.Fatalf("primary secret: %v", err)
+	}
+	if previousEvent != "" {
+		t.Fatalf("hook must not fire for the primary secret")
+	}
+	if _, err := h.ParseRequest(request("whsec_old")); err != nil {
+		t.Fatalf("previous secret: %v", err)
+	}
+	if previousIndex != 1 || previousEvent != string(stripe.EventTypePaymentIntentSucceeded) {
+		t.Fatalf("unexpected hook call: %d %q", previousIndex, previousEvent)
+	}
+	if _, err := h.ParseRequest(request("whsec_unknown")); err == nil {
+		t.Fatalf("expected error for unknown secret")
+	}
+
+	counts := h.VerifiedCounts()
+	if len(counts) != 2 || counts[0] != 1 || counts[1] != 1 {
+		t.Fatalf("unexpected verified counts: %v", counts)
+	}
+}
+
+func TestRotatingHandler_StaleTimestampFailsFast(t *testing.T) {
+	body := []byte(`{"id":"evt_old","type":"payment_intent.succeeded"}`)
+	ts := time.Now().Add(-time.Hour).Unix()
+	req := WebhookRequest{Body: body}
+	req.Headers.StripeSignature = fmt.Sprintf("t=%d,v1=%s", ts, computeStripeSig("whsec_old", ts, body))
+
+	h := NewRotatingHandler(RotationConfig{Secrets: []string{"whsec_new", "whsec_old"}})
+	if _, err := h.ParseRequest(req); err == nil {
+		t.Fatalf("expected error for stale timestamp")
+	}
+	if counts := h.VerifiedCounts(); counts[0] != 0 || counts[1] != 0 {
+		t.Fatalf("unexpected verified counts: %v", counts)
+	}
+}